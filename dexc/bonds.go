@@ -0,0 +1,162 @@
+package dexc
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+)
+
+// BondStatus describes where a bond is in its lifecycle.
+type BondStatus string
+
+const (
+	// BondStatusPending is a bond that has been broadcast but has not yet
+	// reached the number of confirmations required by the server.
+	BondStatusPending BondStatus = "pending"
+	// BondStatusActive is a confirmed bond that counts towards the account
+	// tier.
+	BondStatusActive BondStatus = "active"
+	// BondStatusExpired is a bond that no longer counts towards the account
+	// tier but whose funds are still locked until the bond lock time.
+	BondStatusExpired BondStatus = "expired"
+	// BondStatusRefunded is a bond whose funds have been returned to the
+	// bond wallet.
+	BondStatusRefunded BondStatus = "refunded"
+)
+
+// Bond is a summary of a single fidelity bond posted to a DEX server.
+type Bond struct {
+	AssetID  uint32
+	CoinID   string
+	Amount   uint64
+	Strength uint32
+	Status   BondStatus
+	// Confs is only set for pending bonds.
+	Confs uint32
+	// ExpiresAt is the time the server stops counting the bond towards the
+	// account tier.
+	ExpiresAt time.Time
+	// LockTime is the time the bond funds can be refunded.
+	LockTime time.Time
+	// RefundTxID is the ID of the transaction that refunded the bond. It is
+	// only known if the bond wallet keeps a transaction history.
+	RefundTxID string
+}
+
+// Bonds returns all bonds ever posted to the DEX server at host, including
+// refunded bonds, sorted by lock time with the most recent first. The DEX
+// password is required because the complete bond history is only accessible
+// via an account export.
+func (dc *DEXClient) Bonds(appPW []byte, host string) ([]*Bond, error) {
+	xc, err := dc.Exchange(host)
+	if err != nil {
+		return nil, err
+	}
+
+	_, dbBonds, err := dc.AccountExport(appPW, host)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting account: %w", err)
+	}
+
+	pendingBonds := make(map[string]*core.PendingBondState, len(xc.Auth.PendingBonds))
+	for _, pb := range xc.Auth.PendingBonds {
+		pendingBonds[pb.CoinID] = pb
+	}
+
+	expiredBonds := make(map[string]bool, len(xc.Auth.ExpiredBonds))
+	for _, b := range xc.Auth.ExpiredBonds {
+		expiredBonds[coinIDString(b.AssetID, b.CoinID)] = true
+	}
+
+	bondExpiry := time.Duration(xc.BondExpiry) * time.Second
+	bonds := make([]*Bond, 0, len(dbBonds))
+	for _, b := range dbBonds {
+		coinID := coinIDString(b.AssetID, b.CoinID)
+		lockTime := time.Unix(int64(b.LockTime), 0)
+		bond := &Bond{
+			AssetID:   b.AssetID,
+			CoinID:    coinID,
+			Amount:    b.Amount,
+			Strength:  b.Strength,
+			Status:    BondStatusActive,
+			ExpiresAt: lockTime.Add(-bondExpiry),
+			LockTime:  lockTime,
+		}
+
+		switch pb, pending := pendingBonds[coinID]; {
+		case b.Refunded:
+			bond.Status = BondStatusRefunded
+			bond.RefundTxID = dc.bondRefundTxID(b.AssetID, b.CoinID)
+		case expiredBonds[coinID] || !time.Now().Before(bond.ExpiresAt):
+			bond.Status = BondStatusExpired
+		case pending:
+			bond.Status = BondStatusPending
+			bond.Confs = pb.Confs
+		case !b.Confirmed:
+			bond.Status = BondStatusPending
+		}
+
+		bonds = append(bonds, bond)
+	}
+
+	sort.Slice(bonds, func(i, j int) bool {
+		return bonds[i].LockTime.After(bonds[j].LockTime)
+	})
+
+	return bonds, nil
+}
+
+// bondRefundTxID searches the bond wallet's transaction history for the
+// transaction that refunded the bond with the provided coin ID. An empty string
+// is returned if the wallet does not know of such a transaction.
+func (dc *DEXClient) bondRefundTxID(assetID uint32, bondCoinID []byte) string {
+	txs, err := dc.TxHistory(assetID, 0, nil, false)
+	if err != nil {
+		dc.log.Debugf("Unable to fetch %d tx history: %v", assetID, err)
+		return ""
+	}
+
+	for _, tx := range txs {
+		if tx.Type == asset.RedeemBond && tx.BondInfo != nil && bytes.Equal(tx.BondInfo.BondID, bondCoinID) {
+			return tx.ID
+		}
+	}
+	return ""
+}
+
+// BondTransaction returns the bond information recorded by the DEX wallet for
+// assetID if txID posted or refunded a bond. A nil value is returned if txID is
+// not a bond transaction or the DEX client has no wallet for assetID.
+func (dc *DEXClient) BondTransaction(assetID uint32, txID string) *asset.WalletTransaction {
+	if !dc.HasWallet(int32(assetID)) {
+		return nil
+	}
+
+	tx, err := dc.WalletTransaction(assetID, txID)
+	if err != nil || (tx.Type != asset.CreateBond && tx.Type != asset.RedeemBond) {
+		return nil
+	}
+	return tx
+}
+
+// TiersAtRisk returns the number of tiers that will be lost when the bonds that
+// are about to expire do expire, after accounting for bonds that are pending
+// and will replace them.
+func TiersAtRisk(auth *core.ExchangeAuth) int64 {
+	if auth.WeakStrength <= auth.PendingStrength {
+		return 0
+	}
+	return auth.WeakStrength - auth.PendingStrength
+}
+
+func coinIDString(assetID uint32, coinID []byte) string {
+	coinStr, err := asset.DecodeCoinID(assetID, coinID)
+	if err != nil {
+		return fmt.Sprintf("%x", coinID)
+	}
+	return coinStr
+}
@@ -0,0 +1,363 @@
+package dexc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/order"
+)
+
+// defaultConversionFactor is used for assets whose unit info is not known to
+// the DEX client.
+const defaultConversionFactor = 1e8
+
+// TradeFilter selects the trades returned by TradeHistory. Zero values match
+// everything.
+type TradeFilter struct {
+	Host string
+	// Market is the DEX market name, e.g. dcr_btc.
+	Market   string
+	Statuses []order.OrderStatus
+	From, To time.Time
+	OrderID  string
+}
+
+// TradeMatch is a single match of a trade.
+type TradeMatch struct {
+	MatchID string    `json:"matchID"`
+	Status  string    `json:"status"`
+	Side    string    `json:"side"`
+	Rate    float64   `json:"rate"`
+	Qty     float64   `json:"qty"`
+	Stamp   time.Time `json:"stamp"`
+	Settled bool      `json:"settled"`
+	// SwapTxID, RedeemTxID and RefundTxID are the IDs of the transactions
+	// created by the user's wallets for this match.
+	SwapTxID   string `json:"swapTxID,omitempty"`
+	RedeemTxID string `json:"redeemTxID,omitempty"`
+	RefundTxID string `json:"refundTxID,omitempty"`
+}
+
+// Trade is a DEX order with the amounts and fees resulting from its matches.
+// Amounts are in conventional units. Swap, funding and refund fees are paid in
+// the asset that is sold, redemption fees in the asset that is bought.
+type Trade struct {
+	OrderID     string        `json:"orderID"`
+	Host        string        `json:"host"`
+	MarketID    string        `json:"market"`
+	BaseID      uint32        `json:"baseID"`
+	QuoteID     uint32        `json:"quoteID"`
+	BaseSymbol  string        `json:"baseSymbol"`
+	QuoteSymbol string        `json:"quoteSymbol"`
+	Type        string        `json:"type"`
+	Sell        bool          `json:"sell"`
+	Status      string        `json:"status"`
+	SubmitTime  time.Time     `json:"submitTime"`
+	Qty         float64       `json:"qty"`
+	Rate        float64       `json:"rate,omitempty"`
+	BaseFilled  float64       `json:"baseFilled"`
+	QuoteFilled float64       `json:"quoteFilled"`
+	AvgRate     float64       `json:"avgRate"`
+	SwapFees    float64       `json:"swapFees"`
+	RedeemFees  float64       `json:"redeemFees"`
+	RefundFees  float64       `json:"refundFees"`
+	FundingFees float64       `json:"fundingFees"`
+	Matches     []*TradeMatch `json:"matches"`
+}
+
+// FromAsset returns the ID of the asset sold in the trade.
+func (t *Trade) FromAsset() uint32 {
+	if t.Sell {
+		return t.BaseID
+	}
+	return t.QuoteID
+}
+
+// ToAsset returns the ID of the asset bought in the trade.
+func (t *Trade) ToAsset() uint32 {
+	if t.Sell {
+		return t.QuoteID
+	}
+	return t.BaseID
+}
+
+// FiatValues returns the fiat value of the settled volume, the fees paid and
+// the mark-to-market profit or loss of the trade using the provided fiat
+// rates, which are keyed by asset ID and are per conventional unit. The
+// mark-to-market profit or loss is the value received minus the value sent
+// and the fees paid, all valued at the provided rates rather than at the
+// rates of the match times. ok is false if a rate is missing for either asset.
+func (t *Trade) FiatValues(rates map[uint32]float64) (volume, fees, markToMarketPL float64, ok bool) {
+	baseRate, baseOk := rates[t.BaseID]
+	quoteRate, quoteOk := rates[t.QuoteID]
+	if !baseOk || !quoteOk {
+		return 0, 0, 0, false
+	}
+
+	baseValue, quoteValue := t.BaseFilled*baseRate, t.QuoteFilled*quoteRate
+	fromRate, toRate := quoteRate, baseRate
+	sent, received := quoteValue, baseValue
+	if t.Sell {
+		fromRate, toRate = baseRate, quoteRate
+		sent, received = baseValue, quoteValue
+	}
+
+	fees = (t.SwapFees+t.RefundFees+t.FundingFees)*fromRate + t.RedeemFees*toRate
+	return quoteValue, fees, received - sent - fees, true
+}
+
+// TradeSummary aggregates the fiat values of a set of trades.
+type TradeSummary struct {
+	Trades int
+	Priced int
+	Volume float64
+	Fees   float64
+	// MarkToMarketPL is the profit or loss of the trades valued at the
+	// current fiat rates.
+	MarkToMarketPL float64
+}
+
+// SummarizeTrades computes a TradeSummary for trades. Trades without a fiat
+// rate for both assets are counted but not priced.
+func SummarizeTrades(trades []*Trade, rates map[uint32]float64) *TradeSummary {
+	summary := &TradeSummary{Trades: len(trades)}
+	for _, t := range trades {
+		volume, fees, markToMarketPL, ok := t.FiatValues(rates)
+		if !ok {
+			continue
+		}
+		summary.Priced++
+		summary.Volume += volume
+		summary.Fees += fees
+		summary.MarkToMarketPL += markToMarketPL
+	}
+	return summary
+}
+
+// TradeHistory returns the trades that match the filter, most recent first.
+func (dc *DEXClient) TradeHistory(filter *TradeFilter) ([]*Trade, error) {
+	orderFilter := &core.OrderFilter{
+		Statuses: filter.Statuses,
+	}
+	if filter.Host != "" {
+		orderFilter.Hosts = []string{filter.Host}
+	}
+
+	orders, err := dc.Orders(orderFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]*Trade, 0, len(orders))
+	for _, ord := range orders {
+		if ord.Type == order.CancelOrderType || !filter.matches(ord) {
+			continue
+		}
+		trades = append(trades, newTrade(ord))
+	}
+	return trades, nil
+}
+
+func (filter *TradeFilter) matches(ord *core.Order) bool {
+	submitTime := time.UnixMilli(int64(ord.SubmitTime))
+	switch {
+	case filter.Market != "" && filter.Market != ord.MarketID,
+		filter.OrderID != "" && filter.OrderID != ord.ID.String(),
+		!filter.From.IsZero() && submitTime.Before(filter.From),
+		!filter.To.IsZero() && !submitTime.Before(filter.To):
+		return false
+	}
+	return true
+}
+
+func newTrade(ord *core.Order) *Trade {
+	baseFactor, quoteFactor := ConversionFactor(ord.BaseID), ConversionFactor(ord.QuoteID)
+	fromFactor, toFactor := quoteFactor, baseFactor
+	if ord.Sell {
+		fromFactor, toFactor = baseFactor, quoteFactor
+	}
+
+	t := &Trade{
+		OrderID:     ord.ID.String(),
+		Host:        ord.Host,
+		MarketID:    ord.MarketID,
+		BaseID:      ord.BaseID,
+		QuoteID:     ord.QuoteID,
+		BaseSymbol:  ord.BaseSymbol,
+		QuoteSymbol: ord.QuoteSymbol,
+		Type:        ord.Type.String(),
+		Sell:        ord.Sell,
+		Status:      ord.Status.String(),
+		SubmitTime:  time.UnixMilli(int64(ord.SubmitTime)),
+		Qty:         float64(ord.Qty) / baseFactor,
+	}
+	if ord.Type == order.LimitOrderType {
+		t.Rate = conventionalRate(ord.Rate, baseFactor, quoteFactor)
+	}
+
+	var baseFilled, quoteFilled uint64
+	for _, m := range ord.Matches {
+		if m.IsCancel {
+			continue
+		}
+
+		settled := m.Refund == nil && (m.Redeem != nil || m.Status >= order.MatchComplete)
+		if settled {
+			baseFilled += m.Qty
+			quoteFilled += calc.BaseToQuote(m.Rate, m.Qty)
+		}
+
+		t.Matches = append(t.Matches, &TradeMatch{
+			MatchID:    m.MatchID.String(),
+			Status:     m.Status.String(),
+			Side:       m.Side.String(),
+			Rate:       conventionalRate(m.Rate, baseFactor, quoteFactor),
+			Qty:        float64(m.Qty) / baseFactor,
+			Stamp:      time.UnixMilli(int64(m.Stamp)),
+			Settled:    settled,
+			SwapTxID:   coinTxID(m.Swap),
+			RedeemTxID: coinTxID(m.Redeem),
+			RefundTxID: coinTxID(m.Refund),
+		})
+	}
+
+	t.BaseFilled, t.QuoteFilled = float64(baseFilled)/baseFactor, float64(quoteFilled)/quoteFactor
+	if t.BaseFilled > 0 {
+		t.AvgRate = t.QuoteFilled / t.BaseFilled
+	}
+
+	if fees := ord.FeesPaid; fees != nil {
+		t.SwapFees = float64(fees.Swap) / fromFactor
+		t.RedeemFees = float64(fees.Redemption) / toFactor
+		t.RefundFees = float64(fees.Refund) / fromFactor
+		t.FundingFees = float64(fees.Funding) / fromFactor
+	}
+
+	return t
+}
+
+// OrderIDForTx returns the ID of the order that created the swap, redeem or
+// refund transaction with the provided ID. An empty string is returned if txID
+// is not a DEX trade transaction.
+func (dc *DEXClient) OrderIDForTx(assetID uint32, txID string) (string, error) {
+	orders, err := dc.Orders(&core.OrderFilter{Assets: []uint32{assetID}})
+	if err != nil {
+		return "", err
+	}
+
+	for _, ord := range orders {
+		for _, m := range ord.Matches {
+			for _, coin := range []*core.Coin{m.Swap, m.Redeem, m.Refund} {
+				if coin != nil && coin.AssetID == assetID && coinTxID(coin) == txID {
+					return ord.ID.String(), nil
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// coinTxID extracts the transaction ID from the string representation of a
+// UTXO-based coin ID, e.g. txid:vout.
+func coinTxID(coin *core.Coin) string {
+	if coin == nil {
+		return ""
+	}
+	txID, _, _ := strings.Cut(coin.StringID, ":")
+	return txID
+}
+
+// ConversionFactor returns the number of atoms in a conventional unit of the
+// asset. defaultConversionFactor is returned for assets unknown to the DEX
+// client.
+func ConversionFactor(assetID uint32) float64 {
+	ui, err := asset.UnitInfo(assetID)
+	if err != nil || ui.Conventional.ConversionFactor == 0 {
+		return defaultConversionFactor
+	}
+	return float64(ui.Conventional.ConversionFactor)
+}
+
+func conventionalRate(msgRate uint64, baseFactor, quoteFactor float64) float64 {
+	return float64(msgRate) / calc.RateEncodingFactor * baseFactor / quoteFactor
+}
+
+// WriteTradesJSON writes trades to w as a JSON array.
+func WriteTradesJSON(w io.Writer, trades []*Trade) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(trades)
+}
+
+// WriteTradesCSV writes trades to w in CSV format, one row per trade. The match
+// transaction IDs are joined with a semicolon.
+func WriteTradesCSV(w io.Writer, trades []*Trade, crlf bool) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = crlf
+
+	headers := []string{"order_id", "host", "market", "type", "side", "status", "submit_time", "qty", "rate", "base_filled",
+		"quote_filled", "avg_rate", "swap_fees", "redeem_fees", "refund_fees", "funding_fees", "swap_txs", "redeem_txs", "refund_txs"}
+	if err := writer.Write(headers); err != nil {
+		return fmt.Errorf("csv.Writer.Write error: %w", err)
+	}
+
+	formatFloat := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	for _, t := range trades {
+		side := "buy"
+		if t.Sell {
+			side = "sell"
+		}
+
+		var swapTxs, redeemTxs, refundTxs []string
+		for _, m := range t.Matches {
+			if m.SwapTxID != "" {
+				swapTxs = append(swapTxs, m.SwapTxID)
+			}
+			if m.RedeemTxID != "" {
+				redeemTxs = append(redeemTxs, m.RedeemTxID)
+			}
+			if m.RefundTxID != "" {
+				refundTxs = append(refundTxs, m.RefundTxID)
+			}
+		}
+
+		err := writer.Write([]string{
+			t.OrderID,
+			t.Host,
+			t.MarketID,
+			t.Type,
+			side,
+			t.Status,
+			t.SubmitTime.UTC().Format(time.RFC3339),
+			formatFloat(t.Qty),
+			formatFloat(t.Rate),
+			formatFloat(t.BaseFilled),
+			formatFloat(t.QuoteFilled),
+			formatFloat(t.AvgRate),
+			formatFloat(t.SwapFees),
+			formatFloat(t.RedeemFees),
+			formatFloat(t.RefundFees),
+			formatFloat(t.FundingFees),
+			strings.Join(swapTxs, ";"),
+			strings.Join(redeemTxs, ";"),
+			strings.Join(refundTxs, ";"),
+		})
+		if err != nil {
+			return fmt.Errorf("csv.Writer.Write error: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
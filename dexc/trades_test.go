@@ -0,0 +1,185 @@
+package dexc
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/order"
+)
+
+const (
+	testBaseID  = 42
+	testQuoteID = 0
+)
+
+func TestNewTrade(t *testing.T) {
+	coin := func(txID string) *core.Coin {
+		return &core.Coin{StringID: txID + ":0"}
+	}
+	settled := &core.Match{MatchID: dex.Bytes{1}, Status: order.MatchComplete, Rate: 5e7, Qty: 2e8, Swap: coin("swap1"), Redeem: coin("redeem1")}
+	redeemed := &core.Match{MatchID: dex.Bytes{2}, Status: order.MakerRedeemed, Rate: 6e7, Qty: 1e8, Swap: coin("swap2"), Redeem: coin("redeem2")}
+	pending := &core.Match{MatchID: dex.Bytes{3}, Status: order.MakerSwapCast, Rate: 5e7, Qty: 1e8, Swap: coin("swap3")}
+	refunded := &core.Match{MatchID: dex.Bytes{4}, Status: order.MatchComplete, Rate: 5e7, Qty: 1e8, Swap: coin("swap4"), Refund: coin("refund4")}
+	cancel := &core.Match{MatchID: dex.Bytes{5}, IsCancel: true}
+
+	tests := []struct {
+		name            string
+		ord             *core.Order
+		expected        *Trade
+		expectedSettled []bool
+	}{
+		{
+			name: "limit buy",
+			ord: &core.Order{Type: order.LimitOrderType, BaseID: testBaseID, QuoteID: testQuoteID, Qty: 3e8, Rate: 5e7,
+				Status: order.OrderStatusExecuted, Matches: []*core.Match{settled, pending, cancel},
+				FeesPaid: &core.FeeBreakdown{Swap: 1e4, Redemption: 2e4, Funding: 3e4}},
+			expected: &Trade{Type: "limit", Status: "executed", Qty: 3, Rate: 0.5, BaseFilled: 2, QuoteFilled: 1, AvgRate: 0.5,
+				SwapFees: 1e-4, RedeemFees: 2e-4, FundingFees: 3e-4},
+			expectedSettled: []bool{true, false},
+		},
+		{
+			name: "market sell",
+			ord: &core.Order{Type: order.MarketOrderType, BaseID: testBaseID, QuoteID: testQuoteID, Sell: true, Qty: 3e8,
+				Status: order.OrderStatusExecuted, Matches: []*core.Match{settled, redeemed, refunded}},
+			expected: &Trade{Type: "market", Status: "executed", Sell: true, Qty: 3, BaseFilled: 3, QuoteFilled: 1.6,
+				AvgRate: 1.6 / 3},
+			expectedSettled: []bool{true, true, false},
+		},
+		{
+			name:     "unfilled",
+			ord:      &core.Order{Type: order.LimitOrderType, BaseID: testBaseID, QuoteID: testQuoteID, Qty: 1e8, Rate: 5e7, Status: order.OrderStatusBooked},
+			expected: &Trade{Type: "limit", Status: "booked", Qty: 1, Rate: 0.5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			trade := newTrade(tc.ord)
+
+			var settled []bool
+			for _, m := range trade.Matches {
+				settled = append(settled, m.Settled)
+			}
+			if !reflect.DeepEqual(settled, tc.expectedSettled) {
+				t.Errorf("(%v), expected settled matches (%v), got (%v)", tc.name, tc.expectedSettled, settled)
+			}
+
+			trade.OrderID, trade.SubmitTime, trade.BaseID, trade.QuoteID, trade.Matches = "", time.Time{}, 0, 0, nil
+			if !reflect.DeepEqual(trade, tc.expected) {
+				t.Errorf("(%v), expected (%+v), got (%+v)", tc.name, tc.expected, trade)
+			}
+		})
+	}
+}
+
+func TestTradeFiatValues(t *testing.T) {
+	trade := func(sell bool) *Trade {
+		return &Trade{BaseID: testBaseID, QuoteID: testQuoteID, Sell: sell, BaseFilled: 10, QuoteFilled: 0.5,
+			SwapFees: 0.01, RedeemFees: 0.001, FundingFees: 0.02}
+	}
+	rates := map[uint32]float64{testBaseID: 15, testQuoteID: 40000}
+
+	tests := []struct {
+		name                 string
+		trade                *Trade
+		rates                map[uint32]float64
+		expectedVolume       float64
+		expectedFees         float64
+		expectedMarkToMarket float64
+		expectedOk           bool
+	}{
+		{
+			// Fees are paid in the quote asset sold, except the redemption
+			// fees in the base asset bought.
+			name:  "buy",
+			trade: trade(false), rates: rates,
+			expectedVolume: 20000, expectedFees: 0.03*40000 + 0.001*15, expectedMarkToMarket: 150 - 20000 - (0.03*40000 + 0.001*15),
+			expectedOk: true,
+		},
+		{
+			name:  "sell",
+			trade: trade(true), rates: rates,
+			expectedVolume: 20000, expectedFees: 0.03*15 + 0.001*40000, expectedMarkToMarket: 20000 - 150 - (0.03*15 + 0.001*40000),
+			expectedOk: true,
+		},
+		{
+			name:  "missing rate",
+			trade: trade(false), rates: map[uint32]float64{testBaseID: 15},
+		},
+	}
+
+	for _, tc := range tests {
+		volume, fees, markToMarket, ok := tc.trade.FiatValues(tc.rates)
+		if ok != tc.expectedOk || volume != tc.expectedVolume || fees != tc.expectedFees || markToMarket != tc.expectedMarkToMarket {
+			t.Errorf("(%v), expected (%v, %v, %v, %v), got (%v, %v, %v, %v)", tc.name, tc.expectedVolume, tc.expectedFees,
+				tc.expectedMarkToMarket, tc.expectedOk, volume, fees, markToMarket, ok)
+		}
+	}
+
+	summary := SummarizeTrades([]*Trade{trade(false), trade(true), {BaseID: 1, QuoteID: testQuoteID}}, rates)
+	if summary.Trades != 3 || summary.Priced != 2 || summary.Volume != 40000 {
+		t.Errorf("expected 3 trades, 2 priced and a volume of 40000, got %+v", summary)
+	}
+}
+
+func TestWriteTrades(t *testing.T) {
+	trades := []*Trade{
+		{
+			OrderID: "ab", Host: "dex.example.com", MarketID: "dcr_btc", Type: "limit", Sell: true, Status: "executed",
+			SubmitTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), Qty: 2, Rate: 0.5, BaseFilled: 2, QuoteFilled: 1, AvgRate: 0.5,
+			SwapFees: 0.0001, RedeemFees: 0.0002,
+			Matches: []*TradeMatch{
+				{SwapTxID: "swap1", RedeemTxID: "redeem1"},
+				{SwapTxID: "swap2", RefundTxID: "refund2"},
+			},
+		},
+		{OrderID: "cd", Type: "market", Status: "canceled", SubmitTime: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name     string
+		crlf     bool
+		expected string
+	}{
+		{
+			name: "lf",
+			expected: "order_id,host,market,type,side,status,submit_time,qty,rate,base_filled,quote_filled,avg_rate,swap_fees,redeem_fees,refund_fees,funding_fees,swap_txs,redeem_txs,refund_txs\n" +
+				"ab,dex.example.com,dcr_btc,limit,sell,executed,2024-05-01T12:00:00Z,2,0.5,2,1,0.5,0.0001,0.0002,0,0,swap1;swap2,redeem1,refund2\n" +
+				"cd,,,market,buy,canceled,2024-05-02T00:00:00Z,0,0,0,0,0,0,0,0,0,,,\n",
+		},
+		{
+			name: "crlf",
+			crlf: true,
+			expected: "order_id,host,market,type,side,status,submit_time,qty,rate,base_filled,quote_filled,avg_rate,swap_fees,redeem_fees,refund_fees,funding_fees,swap_txs,redeem_txs,refund_txs\r\n" +
+				"ab,dex.example.com,dcr_btc,limit,sell,executed,2024-05-01T12:00:00Z,2,0.5,2,1,0.5,0.0001,0.0002,0,0,swap1;swap2,redeem1,refund2\r\n" +
+				"cd,,,market,buy,canceled,2024-05-02T00:00:00Z,0,0,0,0,0,0,0,0,0,,,\r\n",
+		},
+	}
+
+	for _, tc := range tests {
+		var buf bytes.Buffer
+		if err := WriteTradesCSV(&buf, trades, tc.crlf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTradesJSON(&buf, trades); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []*Trade
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, trades) {
+		t.Errorf("expected the JSON trades to decode to the written trades, got %s", buf.String())
+	}
+}
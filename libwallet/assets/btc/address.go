@@ -5,6 +5,8 @@ import (
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
@@ -25,6 +27,16 @@ func (asset *Asset) IsAddressValid(address string) bool {
 	return err == nil
 }
 
+// AddressScript returns the output script paying to the address on the
+// network.
+func AddressScript(address string, chainParams *chaincfg.Params) ([]byte, error) {
+	addr, err := btcutil.DecodeAddress(address, chainParams)
+	if err != nil {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+	return txscript.PayToAddrScript(addr)
+}
+
 // HaveAddress checks if the provided address belongs to the wallet.
 func (asset *Asset) HaveAddress(address string) bool {
 	if !asset.WalletOpened() {
@@ -0,0 +1,62 @@
+package btc
+
+import (
+	"sync/atomic"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// startElectrumSync connects to the Electrum server of the Electrum network
+// mode and syncs the wallet with it.
+func (asset *Asset) startElectrumSync() error {
+	server := asset.ElectrumServer()
+	if server == nil {
+		return errors.E(utils.ErrInvalid, "Electrum server is not set")
+	}
+	if err := server.Validate(); err != nil {
+		return err
+	}
+
+	client := newElectrumClient(server, asset.chainParams)
+	if err := client.Start(); err != nil {
+		log.Errorf("couldn't connect to the electrum server at %s: %v", server.Address, err)
+		asset.CancelSync()
+		return err
+	}
+	asset.electrumClient.Store(client)
+
+	// Subscribe to chainclient notifications.
+	if err := client.NotifyBlocks(); err != nil {
+		log.Errorf("subscribing to notifications failed: %v", err)
+		return err
+	}
+
+	// Listen and handle incoming notification events.
+	if atomic.CompareAndSwapUint32(&asset.syncData.syncstarted, stop, start) {
+		go asset.handleNotifications()
+	}
+
+	log.Infof("Synchronizing wallet (%s) with the electrum server at %s...", asset.GetWalletName(), server.Address)
+	asset.Internal().BTC.SynchronizeRPC(client)
+
+	return nil
+}
+
+// electrumBestBlock returns the best block of the Electrum server, or the block
+// the wallet is synced to while the server is not connected.
+func (asset *Asset) electrumBestBlock() *sharedW.BlockInfo {
+	if client := asset.electrumClient.Load(); client != nil {
+		if connected, err := client.connected(); err == nil {
+			tip := connected.Tip()
+			return &sharedW.BlockInfo{Height: tip.Height, Timestamp: tip.Timestamp().Unix()}
+		}
+	}
+
+	if !asset.WalletOpened() {
+		return sharedW.InvalidBlock
+	}
+	syncedTo := asset.Internal().BTC.Manager.SyncedTo()
+	return &sharedW.BlockInfo{Height: syncedTo.Height, Timestamp: syncedTo.Timestamp.Unix()}
+}
@@ -30,6 +30,83 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 	return asset.rescanBlocks(startHeight, nil)
 }
 
+// Rescan rescans the blockchain from the start of the options for the
+// addresses of the accounts of the options. With a gap limit, the addresses
+// of the accounts are first extended past their last used address.
+func (asset *Asset) Rescan(options *sharedW.RescanOptions) error {
+	if !asset.WalletOpened() {
+		return utils.ErrBTCNotInitialized
+	}
+	if asset.IsRescanning() {
+		return errors.E(utils.ErrSyncAlreadyInProgress)
+	}
+
+	netType := asset.NetType()
+	startHeight := options.StartBlockHeight(asset.GetBestBlockHeight(), GetGenesisTimestamp(netType), GetTargetTimePerBlock(netType))
+	addrs, err := asset.rescanAddresses(options.Accounts, options.GapLimit)
+	if err != nil {
+		return err
+	}
+	return asset.rescanBlocks(startHeight, addrs)
+}
+
+// rescanAddresses returns the addresses of the accounts, or of all accounts if
+// none is provided. The addresses of each account are first extended by
+// gapLimit addresses past the last derived address of its branches.
+func (asset *Asset) rescanAddresses(accounts []int32, gapLimit uint32) ([]btcutil.Address, error) {
+	if len(accounts) == 0 {
+		accts, err := asset.GetAccountsRaw()
+		if err != nil {
+			return nil, err
+		}
+		for _, acct := range accts.Accounts {
+			accounts = append(accounts, acct.Number)
+		}
+	}
+
+	if gapLimit > 0 {
+		scopedMgr, err := asset.Internal().BTC.Manager.FetchScopedKeyManager(GetScope())
+		if err != nil {
+			return nil, err
+		}
+		err = walletdb.Update(asset.Internal().BTC.Database(), func(dbtx walletdb.ReadWriteTx) error {
+			ns := dbtx.ReadWriteBucket(wAddrMgrBkt)
+			for _, account := range accounts {
+				if uint32(account) > waddrmgr.MaxAccountNum {
+					// Imported addresses are not derived.
+					continue
+				}
+				props, err := scopedMgr.AccountProperties(ns, uint32(account))
+				if err != nil {
+					return err
+				}
+				err = scopedMgr.ExtendExternalAddresses(ns, uint32(account), props.ExternalKeyCount+gapLimit-1)
+				if err != nil {
+					return err
+				}
+				err = scopedMgr.ExtendInternalAddresses(ns, uint32(account), props.InternalKeyCount+gapLimit-1)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var addrs []btcutil.Address
+	for _, account := range accounts {
+		accountAddrs, err := asset.Internal().BTC.AccountAddresses(uint32(account))
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, accountAddrs...)
+	}
+	return addrs, nil
+}
+
 func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) error {
 	if !asset.IsConnectedToBitcoinNetwork() {
 		return errors.E(utils.ErrNotConnected)
@@ -59,6 +136,7 @@ func (asset *Asset) rescanBlocks(startHeight int32, addrs []btcutil.Address) err
 	asset.syncData.mu.Lock()
 	asset.syncData.isRescan = true
 	asset.syncData.rescanStartTime = time.Now()
+	asset.syncData.rescanStartHeight = nil
 	asset.syncData.mu.Unlock()
 
 	job := &w.RescanJob{
@@ -328,14 +406,14 @@ func (asset *Asset) getblockStamp(height int32) (*waddrmgr.BlockStamp, error) {
 		return nil, fmt.Errorf("invalid block height provided: Error: %v", err)
 	}
 
-	block, err := asset.chainClient.GetBlock(startHash)
+	header, err := asset.chainSource().GetBlockHeader(startHash)
 	if err != nil {
 		return nil, fmt.Errorf("invalid block hash provided: Error: %v", err)
 	}
 
 	return &waddrmgr.BlockStamp{
-		Hash:      block.BlockHash(),
+		Hash:      header.BlockHash(),
 		Height:    height,
-		Timestamp: block.Header.Timestamp,
+		Timestamp: header.Timestamp,
 	}, nil
 }
@@ -0,0 +1,221 @@
+package btc
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/trezor"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// softwareSigner signs the transactions of the asset with the keys of its
+// wallet.
+type softwareSigner struct {
+	asset *Asset
+}
+
+var _ sharedW.Signer = (*softwareSigner)(nil)
+
+// SignTx unlocks the wallet with the private passphrase and signs every input
+// of the transaction.
+func (s *softwareSigner) SignTx(_ context.Context, req *sharedW.SignRequest, passphrase string) ([]byte, error) {
+	msgTx := new(wire.MsgTx)
+	if err := msgTx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return nil, err
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	err := s.asset.Internal().BTC.Unlock([]byte(passphrase), lock)
+	if err != nil {
+		log.Errorf("unlocking the wallet failed: %v", err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	prevOutFetcher := signRequestPrevOuts(req)
+	sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
+	for index, input := range req.Inputs {
+		prevOut := &wire.TxOut{Value: input.Amount, PkScript: input.PkScript}
+		witness, signature, err := s.asset.Internal().BTC.ComputeInputScript(
+			msgTx, prevOut, index, sigHashes, txscript.SigHashAll, nil,
+		)
+		if err != nil {
+			log.Errorf("generating input signatures failed: %v", err)
+			return nil, err
+		}
+
+		msgTx.TxIn[index].Witness = witness
+		msgTx.TxIn[index].SignatureScript = signature
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(msgTx.SerializeSize())
+	if err := msgTx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// signer returns the hardware signer of a watch-only wallet, or the software
+// signer of the wallet.
+func (asset *Asset) signer() (sharedW.Signer, error) {
+	cfg := asset.HardwareSigner()
+	if cfg == nil {
+		return &softwareSigner{asset: asset}, nil
+	}
+	coin, err := trezor.CoinName(asset.GetAssetType(), asset.NetType())
+	if err != nil {
+		return nil, err
+	}
+	return trezor.NewSigner(cfg, coin), nil
+}
+
+// signRequest describes the transaction to the signers. The spent outputs
+// and transactions are looked up in the wallet.
+func (asset *Asset) signRequest(msgTx *wire.MsgTx) (*sharedW.SignRequest, error) {
+	var buf bytes.Buffer
+	buf.Grow(msgTx.SerializeSize())
+	if err := msgTx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	req := &sharedW.SignRequest{
+		UnsignedTx: buf.Bytes(),
+		Version:    uint32(msgTx.Version),
+		LockTime:   msgTx.LockTime,
+		PrevTxs:    make(map[string]*sharedW.PrevTx),
+	}
+	for _, txIn := range msgTx.TxIn {
+		prevTx, prevOut, derivation, _, err := asset.Internal().BTC.FetchInputInfo(&txIn.PreviousOutPoint)
+		if err != nil {
+			log.Errorf("fetch previous outpoint txout failed: %v", err)
+			return nil, err
+		}
+
+		prevHash := txIn.PreviousOutPoint.Hash.String()
+		req.Inputs = append(req.Inputs, &sharedW.SignInput{
+			PrevHash:   prevHash,
+			PrevIndex:  txIn.PreviousOutPoint.Index,
+			Sequence:   txIn.Sequence,
+			Amount:     prevOut.Value,
+			PkScript:   prevOut.PkScript,
+			Path:       derivation.Bip32Path,
+			PubKey:     derivation.PubKey,
+			ScriptType: inputScriptType(prevOut.PkScript),
+		})
+		if _, ok := req.PrevTxs[prevHash]; !ok {
+			req.PrevTxs[prevHash] = signRequestPrevTx(prevTx)
+		}
+	}
+
+	for _, txOut := range msgTx.TxOut {
+		var address string
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, asset.chainParams)
+		if err == nil && len(addrs) == 1 {
+			address = addrs[0].EncodeAddress()
+		}
+		req.Outputs = append(req.Outputs, &sharedW.SignOutput{
+			Address:  address,
+			Amount:   txOut.Value,
+			PkScript: txOut.PkScript,
+		})
+	}
+	return req, nil
+}
+
+// signRequestPrevTx describes a transaction spent by a signed transaction.
+func signRequestPrevTx(tx *wire.MsgTx) *sharedW.PrevTx {
+	prevTx := &sharedW.PrevTx{
+		Version:  uint32(tx.Version),
+		LockTime: tx.LockTime,
+	}
+	for _, txIn := range tx.TxIn {
+		prevTx.Inputs = append(prevTx.Inputs, &sharedW.PrevTxInput{
+			PrevHash:  txIn.PreviousOutPoint.Hash.String(),
+			PrevIndex: txIn.PreviousOutPoint.Index,
+			Sequence:  txIn.Sequence,
+			ScriptSig: txIn.SignatureScript,
+		})
+	}
+	for _, txOut := range tx.TxOut {
+		prevTx.Outputs = append(prevTx.Outputs, &sharedW.PrevTxOutput{
+			Amount:   txOut.Value,
+			PkScript: txOut.PkScript,
+		})
+	}
+	return prevTx
+}
+
+// signRequestPrevOuts returns the fetcher of the outputs spent by the inputs
+// of the request.
+func signRequestPrevOuts(req *sharedW.SignRequest) *txscript.MultiPrevOutFetcher {
+	prevOuts := make(map[wire.OutPoint]*wire.TxOut, len(req.Inputs))
+	for _, input := range req.Inputs {
+		prevHash, err := chainhash.NewHashFromStr(input.PrevHash)
+		if err != nil {
+			continue
+		}
+		prevOuts[*wire.NewOutPoint(prevHash, input.PrevIndex)] = &wire.TxOut{
+			Value:    input.Amount,
+			PkScript: input.PkScript,
+		}
+	}
+	return txscript.NewMultiPrevOutFetcher(prevOuts)
+}
+
+// inputScriptType returns the kind of script of the wallet spent by an input.
+// The P2SH scripts of the wallet are nested P2WPKH scripts.
+func inputScriptType(pkScript []byte) sharedW.InputScriptType {
+	switch {
+	case txscript.IsPayToWitnessPubKeyHash(pkScript):
+		return sharedW.SpendWitness
+	case txscript.IsPayToScriptHash(pkScript):
+		return sharedW.SpendP2SHWitness
+	case txscript.IsPayToTaproot(pkScript):
+		return sharedW.SpendTaproot
+	default:
+		return sharedW.SpendAddress
+	}
+}
+
+// verifySignedTx checks that the signed transaction spends and pays the same
+// as the unsigned one and proves that every input has been validly signed by
+// executing its script pair.
+func verifySignedTx(unsignedTx, msgTx *wire.MsgTx, req *sharedW.SignRequest) error {
+	stripped := msgTx.Copy()
+	for _, txIn := range stripped.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+	if stripped.TxHash() != unsignedTx.TxHash() {
+		return errors.E(errors.Invalid, "the signed transaction does not match the unsigned transaction")
+	}
+
+	prevOutFetcher := signRequestPrevOuts(req)
+	sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
+	flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
+		txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops |
+		txscript.ScriptVerifyWitness
+	for index, input := range req.Inputs {
+		vm, err := txscript.NewEngine(input.PkScript, msgTx, index, flags, nil, sigHashes,
+			input.Amount, prevOutFetcher)
+		if err != nil {
+			log.Errorf("creating validation engine failed: %v", err)
+			return err
+		}
+		if err := vm.Execute(); err != nil {
+			log.Errorf("executing the validation engine failed: %v", err)
+			return err
+		}
+	}
+	return nil
+}
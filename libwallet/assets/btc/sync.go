@@ -110,6 +110,19 @@ func (asset *Asset) RemoveSyncProgressListener(uniqueIdentifier string) {
 // bestServerPeerBlockHeight accesses the connected peers and requests for the
 // last synced block height.
 func (asset *Asset) bestServerPeerBlockHeight() {
+	if client := asset.rpcClient.Load(); client != nil {
+		if _, height, err := client.GetBestBlock(); err == nil && height > asset.syncData.bestBlockheight {
+			asset.syncData.bestBlockheight = height
+		}
+		return
+	}
+	if client := asset.electrumClient.Load(); client != nil {
+		if _, height, err := client.GetBestBlock(); err == nil && height > asset.syncData.bestBlockheight {
+			asset.syncData.bestBlockheight = height
+		}
+		return
+	}
+
 	serverPeers := asset.chainClient.CS.(ExtraNeutrinoChainService).Peers()
 	for _, p := range serverPeers {
 		if p.LastBlock() > asset.syncData.bestBlockheight {
@@ -317,8 +330,11 @@ func (asset *Asset) loadChainService() (chainService *neutrino.ChainService, err
 		ChainParams:   *asset.chainParams,
 		PersistToDisk: true, // keep cfilter headers on disk for efficient rescanning
 		ConnectPeers:  validPeerAddresses,
+		// The healthy preferred peers are only used when no specific peers
+		// are set.
+		AddPeers: asset.HealthyPeers(),
 		// Dialer function helps to better control the dialer functionality.
-		Dialer: utils.DialerFunc(asset.dailerCtx),
+		Dialer: asset.peerDialer(utils.DialerFunc(asset.dailerCtx)),
 		// WARNING: PublishTransaction currently uses the entire duration
 		// because if an external bug, but even if the resolved, a typical
 		// inv/getdata round trip is ~4 seconds, so we set this so neutrino does
@@ -377,7 +393,9 @@ func (asset *Asset) stopSync() {
 	}
 
 	// 2. shutdown the chain client.
-	asset.chainClient.Stop() // If active, attempt to shut it down.
+	chainSource := asset.chainSource()
+	usesNeutrino := chainSource == chain.Interface(asset.chainClient)
+	chainSource.Stop() // If active, attempt to shut it down.
 
 	if asset.WalletOpened() {
 		// Neutrino performs explicit chain service start but never explicit
@@ -385,18 +403,22 @@ func (asset *Asset) stopSync() {
 		// a wallet sync.
 		// 3. Disabling the peers connectivity allows the upstream handleChainNotification
 		// goroutine to return.
-		if err := asset.chainClient.CS.Stop(); err != nil {
-			// ignore the error and proceed with shutdown.
-			log.Errorf("Stopping chain client failed: %v", err)
+		if usesNeutrino {
+			if err := asset.chainClient.CS.Stop(); err != nil {
+				// ignore the error and proceed with shutdown.
+				log.Errorf("Stopping chain client failed: %v", err)
+			}
+			asset.syncData.chainServiceStopped = true
 		}
 
-		asset.syncData.chainServiceStopped = true
 		// 4. Wait for the upstream wallet to shutdown completely.
 		loadedAsset.WaitForShutdown()
 	}
 
 	// 5. Wait for the chain client to shutdown
-	asset.chainClient.WaitForShutdown()
+	chainSource.WaitForShutdown()
+	asset.rpcClient.Store(nil)
+	asset.electrumClient.Store(nil)
 
 	// Declares that the sync context is done and goroutines listening to it
 	// should exit. The shutdown protocol will eventually attempt to end this
@@ -422,6 +444,13 @@ func (asset *Asset) stopSync() {
 // startSync initiates the full chain sync starting protocols. It attempts to
 // restart the chain service if it hasn't been initialized.
 func (asset *Asset) startSync() error {
+	switch asset.NetworkMode() {
+	case sharedW.NetworkModeRPC:
+		return asset.startRPCSync()
+	case sharedW.NetworkModeElectrum:
+		return asset.startElectrumSync()
+	}
+
 	g, _ := errgroup.WithContext(asset.syncCtx)
 
 	if asset.syncData.chainServiceStopped {
@@ -493,15 +522,16 @@ func (asset *Asset) waitForSyncCompletion() {
 	for {
 		select {
 		case <-t.C:
-			block, err := asset.chainClient.CS.BestBlock()
+			height, isCurrent, err := asset.chainTip()
 			if err != nil {
 				log.Error("GetBestBlock hash for BTC failed, Err: ", err)
+				continue
 			}
-			asset.updateSyncProgress(block.Height)
-			asset.updateRescanProgress(block.Height)
+			asset.updateSyncProgress(height)
+			asset.updateRescanProgress(height)
 
-			if asset.chainClient.IsCurrent() {
-				asset.rescanFinished(block.Height)
+			if isCurrent {
+				asset.rescanFinished(height)
 
 				asset.syncData.mu.Lock()
 				asset.syncData.synced = true
@@ -510,6 +540,7 @@ func (asset *Asset) waitForSyncCompletion() {
 
 				// Trigger UI update showing btc address recovery is in progress.
 				asset.handleSyncUIUpdate()
+				asset.updatePeerScores()
 				return
 			}
 		case <-asset.syncCtx.Done():
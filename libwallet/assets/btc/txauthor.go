@@ -6,12 +6,10 @@ import (
 	"fmt"
 	"sort"
 	"sync"
-	"time"
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
-	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/wallet/txauthor"
 	"github.com/btcsuite/btcwallet/wallet/txrules"
@@ -37,6 +35,10 @@ type TxAuthor struct {
 	unsignedTx     *txauthor.AuthoredTx
 	needsConstruct bool
 
+	// airgapReq is the request of the transaction last exported to be
+	// signed by an offline wallet.
+	airgapReq *sharedW.SignRequest
+
 	selectedUXTOs []*sharedW.UnspentOutput
 
 	mu sync.RWMutex
@@ -284,94 +286,65 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 	asset.TxAuthoredInfo.mu.Lock()
 	defer asset.TxAuthoredInfo.mu.Unlock()
 
-	unsignedTx, err := asset.unsignedTransaction()
+	unsignedTx, err := asset.finalUnsignedTx()
 	if err != nil {
-		return "", utils.TranslateError(err)
-	}
-
-	// If the change output is the only one, no need to change position.
-	if unsignedTx.ChangeIndex > 0 {
-		unsignedTx.RandomizeChangePosition()
+		return "", err
 	}
 
-	// Test encode and decode the tx to check its validity after being signed.
-	msgTx := unsignedTx.Tx
-
-	lock := make(chan time.Time, 1)
-	defer func() {
-		lock <- time.Time{}
-	}()
-
-	err = asset.Internal().BTC.Unlock([]byte(privatePassphrase), lock)
+	req, err := asset.signRequest(unsignedTx)
 	if err != nil {
-		log.Errorf("unlocking the wallet failed: %v", err)
-		return "", errors.New(utils.ErrInvalidPassphrase)
+		return "", err
 	}
-
-	// To discourage fee sniping, LockTime is explicitly set in the raw tx.
-	// More documentation on this:
-	// https://bitcoin.stackexchange.com/questions/48384/why-bitcoin-core-creates-time-locked-transactions-by-default
-	msgTx.LockTime = uint32(asset.GetBestBlockHeight())
-
-	for index, txIn := range msgTx.TxIn {
-		_, previousTXout, _, _, err := asset.Internal().BTC.FetchInputInfo(&txIn.PreviousOutPoint)
-		if err != nil {
-			log.Errorf("fetch previous outpoint txout failed: %v", err)
-			return "", err
-		}
-
-		prevOutScript := unsignedTx.PrevScripts[index]
-		prevOutAmount := int64(asset.TxAuthoredInfo.inputValues[index])
-		prevOutFetcher := txscript.NewCannedPrevOutputFetcher(prevOutScript, prevOutAmount)
-		sigHashes := txscript.NewTxSigHashes(msgTx, prevOutFetcher)
-
-		witness, signature, err := asset.Internal().BTC.ComputeInputScript(
-			msgTx, previousTXout, index, sigHashes, txscript.SigHashAll, nil,
-		)
-		if err != nil {
-			log.Errorf("generating input signatures failed: %v", err)
-			return "", err
-		}
-
-		msgTx.TxIn[index].Witness = witness
-		msgTx.TxIn[index].SignatureScript = signature
-
-		// Prove that the transaction has been validly signed by executing the
-		// script pair.
-		flags := txscript.ScriptBip16 | txscript.ScriptVerifyDERSignatures |
-			txscript.ScriptStrictMultiSig | txscript.ScriptDiscourageUpgradableNops
-		vm, err := txscript.NewEngine(previousTXout.PkScript, msgTx, 0, flags, nil, nil,
-			prevOutAmount, prevOutFetcher)
-		if err != nil {
-			log.Errorf("creating validation engine failed: %v", err)
-			return "", err
-		}
-		if err := vm.Execute(); err != nil {
-			log.Errorf("executing the validation engine failed: %v", err)
-			return "", err
-		}
+	signer, err := asset.signer()
+	if err != nil {
+		return "", err
 	}
 
-	var serializedTransaction bytes.Buffer
-	serializedTransaction.Grow(msgTx.SerializeSize())
-	err = msgTx.Serialize(&serializedTransaction)
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+	signedTx, err := signer.SignTx(ctx, req, privatePassphrase)
 	if err != nil {
-		log.Errorf("encoding the tx to test its validity failed: %v", err)
 		return "", err
 	}
 
-	err = msgTx.Deserialize(bytes.NewReader(serializedTransaction.Bytes()))
-	if err != nil {
+	// Test decode the tx to check its validity after being signed.
+	msgTx := new(wire.MsgTx)
+	if err := msgTx.Deserialize(bytes.NewReader(signedTx)); err != nil {
 		// Invalid tx
 		log.Errorf("decoding the tx to test its validity failed: %v", err)
 		return "", err
 	}
+	if err := verifySignedTx(unsignedTx, msgTx, req); err != nil {
+		return "", err
+	}
 
 	err = asset.Internal().BTC.PublishTransaction(msgTx, transactionLabel)
 	txHash := msgTx.TxHash()
 	return txHash.String(), utils.TranslateError(err)
 }
 
+// finalUnsignedTx returns the transaction to sign, with its change output at
+// a random position and its lock time set.
+func (asset *Asset) finalUnsignedTx() (*wire.MsgTx, error) {
+	unsignedTx, err := asset.unsignedTransaction()
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	// If the change output is the only one, no need to change position.
+	if unsignedTx.ChangeIndex > 0 {
+		unsignedTx.RandomizeChangePosition()
+	}
+
+	msgTx := unsignedTx.Tx
+
+	// To discourage fee sniping, LockTime is explicitly set in the raw tx.
+	// More documentation on this:
+	// https://bitcoin.stackexchange.com/questions/48384/why-bitcoin-core-creates-time-locked-transactions-by-default
+	msgTx.LockTime = uint32(asset.GetBestBlockHeight())
+	return msgTx, nil
+}
+
 func (asset *Asset) unsignedTransaction() (*txauthor.AuthoredTx, error) {
 	if asset.TxAuthoredInfo.needsConstruct || asset.TxAuthoredInfo.unsignedTx == nil {
 		unsignedTx, err := asset.constructTransaction()
@@ -609,6 +582,11 @@ func (asset *Asset) makeInputSource(outputs []*sharedW.UnspentOutput, sendMax bo
 	}
 }
 
+// IsSaneOutputValue returns true if amount is a valid output value.
+func (asset *Asset) IsSaneOutputValue(amount int64) bool {
+	return saneOutputValue(Amount(amount))
+}
+
 func saneOutputValue(amount Amount) bool {
 	return amount >= 0 && amount <= btcutil.MaxSatoshi
 }
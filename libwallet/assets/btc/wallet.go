@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"decred.org/dcrwallet/v4/errors"
@@ -37,6 +38,8 @@ type Asset struct {
 	*sharedW.Wallet
 
 	chainClient    *chain.NeutrinoClient
+	rpcClient      atomic.Pointer[rpcNodeClient]  // Set while syncing with a full node.
+	electrumClient atomic.Pointer[electrumClient] // Set while syncing with an Electrum server.
 	chainParams    *chaincfg.Params
 	TxAuthoredInfo *TxAuthor
 
@@ -208,6 +211,41 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 	return btcWallet, nil
 }
 
+// RecreateWallet restores the wallet database of the existing wallet from the
+// seed mnemonic, keeping the ID and config of the wallet, and returns the
+// restored wallet.
+func RecreateWallet(w *sharedW.Wallet, seedMnemonic, privatePassphrase string, wordSeedType sharedW.WordSeedType,
+	params *sharedW.InitParams,
+) (sharedW.Asset, error) {
+	chainParams, err := utils.BTCChainParams(params.NetType)
+	if err != nil {
+		return nil, err
+	}
+
+	ldr := initWalletLoader(chainParams, params.RootDir)
+	err = w.RecreateWallet(seedMnemonic, privatePassphrase, wordSeedType, ldr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	btcWallet := &Asset{
+		Wallet:      w,
+		chainParams: chainParams,
+		syncData: &SyncData{
+			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
+		},
+		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+	}
+
+	if err := btcWallet.prepareChain(); err != nil {
+		return nil, err
+	}
+
+	btcWallet.SetNetworkCancelCallback(btcWallet.SafelyCancelSync)
+
+	return btcWallet, nil
+}
+
 // LoadExisting accepts the stored shared wallet information and the init parameters.
 // It validates the network type passed by fetching the chain parameters
 // associated with it for the BTC asset. It then generates the BTC loader interface
@@ -251,6 +289,11 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 	}
 
 	if err := btcWallet.prepareChain(); err != nil {
+		// Release the wallet data database of the wallet that failed to
+		// load, so it can be diagnosed and repaired.
+		if closeErr := btcWallet.GetWalletDataDb().Close(); closeErr != nil {
+			log.Errorf("tx db closed with error: %v", closeErr)
+		}
 		return nil, err
 	}
 
@@ -322,6 +365,20 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
+	switch asset.NetworkMode() {
+	case sharedW.NetworkModeRPC:
+		// The full node is the only peer of the wallet.
+		if client := asset.rpcClient.Load(); client != nil && !client.Disconnected() {
+			return 1
+		}
+		return 0
+	case sharedW.NetworkModeElectrum:
+		// So is the Electrum server.
+		if client := asset.electrumClient.Load(); client != nil && client.IsCurrent() {
+			return 1
+		}
+		return 0
+	}
 	return asset.chainClient.CS.(ExtraNeutrinoChainService).ConnectedCount()
 }
 
@@ -332,6 +389,13 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
+	switch asset.NetworkMode() {
+	case sharedW.NetworkModeRPC:
+		return asset.rpcBestBlock()
+	case sharedW.NetworkModeElectrum:
+		return asset.electrumBestBlock()
+	}
+
 	block, err := asset.chainClient.CS.BestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for BTC failed, Err: ", err)
@@ -356,7 +420,7 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	height, err := asset.chainBlockHeight(&hash)
 	if err != nil {
 		log.Warn("GetBlockHeight for BTC failed, Err: %v", err)
 		return -1, err
@@ -366,7 +430,7 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	blockhash, err := asset.chainSource().GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for BTC failed, Err: %v", err)
 		return nil, err
@@ -0,0 +1,266 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/sign"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// airgapBundle is the message of an air-gapped Decred transaction: the
+// serialized transaction and the outputs spent by its inputs with the paths
+// of their keys, which an offline wallet without the spent transactions
+// signs with.
+type airgapBundle struct {
+	Network string         `json:"network"`
+	Tx      string         `json:"tx"`
+	Inputs  []*airgapInput `json:"inputs"`
+}
+
+// airgapInput is the output spent by an input of a bundle.
+type airgapInput struct {
+	Amount   int64    `json:"amount"`
+	PkScript string   `json:"pkScript"`
+	Path     []uint32 `json:"path"`
+}
+
+// encodeAirgapBundle returns the air-gapped transaction of the bundle.
+func encodeAirgapBundle(bundle *airgapBundle) (*sharedW.AirgapTx, error) {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, err
+	}
+	return &sharedW.AirgapTx{Type: sharedW.AirgapTxDCR, Data: data}, nil
+}
+
+// decodeAirgapBundle returns the bundle of an air-gapped transaction of the
+// network of the asset and its transaction.
+func (asset *Asset) decodeAirgapBundle(tx *sharedW.AirgapTx) (*airgapBundle, *wire.MsgTx, error) {
+	if tx.Type != sharedW.AirgapTxDCR {
+		return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("expected a %s transaction, got %s", sharedW.AirgapTxDCR, tx.Type))
+	}
+	bundle := new(airgapBundle)
+	if err := json.Unmarshal(tx.Data, bundle); err != nil {
+		return nil, nil, errors.E(errors.Invalid, fmt.Errorf("invalid transaction bundle: %v", err))
+	}
+	if bundle.Network != asset.chainParams.Name {
+		return nil, nil, errors.E(errors.Invalid, fmt.Sprintf("the transaction is for %s", bundle.Network))
+	}
+	serialized, err := hex.DecodeString(bundle.Tx)
+	if err != nil {
+		return nil, nil, errors.E(errors.Invalid, "invalid transaction bundle")
+	}
+	msgTx := new(wire.MsgTx)
+	if err := msgTx.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, nil, errors.E(errors.Invalid, fmt.Errorf("invalid transaction: %v", err))
+	}
+	if len(bundle.Inputs) != len(msgTx.TxIn) {
+		return nil, nil, errors.E(errors.Invalid, "the bundle does not describe every input")
+	}
+	return bundle, msgTx, nil
+}
+
+// ExportUnsignedTx returns the bundle of the transaction being authored, to
+// be signed by the offline wallet holding the seed of the watch-only wallet.
+func (asset *Asset) ExportUnsignedTx() (*sharedW.AirgapTx, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	unsignedTx, err := asset.finalUnsignedTx()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+	req, err := asset.signRequest(ctx, unsignedTx)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	bundle := &airgapBundle{
+		Network: asset.chainParams.Name,
+		Tx:      hex.EncodeToString(req.UnsignedTx),
+	}
+	for i, input := range req.Inputs {
+		if input.Path == nil {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("the key of input %d is not known", i))
+		}
+		bundle.Inputs = append(bundle.Inputs, &airgapInput{
+			Amount:   input.Amount,
+			PkScript: hex.EncodeToString(input.PkScript),
+			Path:     input.Path,
+		})
+	}
+	tx, err := encodeAirgapBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	asset.TxAuthoredInfo.airgapReq = req
+	return tx, nil
+}
+
+// AirgapTxSummary returns the outputs and fee of the bundle.
+func (asset *Asset) AirgapTxSummary(tx *sharedW.AirgapTx) (*sharedW.AirgapTxSummary, error) {
+	bundle, msgTx, err := asset.decodeAirgapBundle(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &sharedW.AirgapTxSummary{Signed: true}
+	for i, input := range bundle.Inputs {
+		summary.Fee += input.Amount
+		summary.Signed = summary.Signed && len(msgTx.TxIn[i].SignatureScript) > 0
+	}
+	for _, txOut := range msgTx.TxOut {
+		var address string
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+		if len(addrs) == 1 {
+			address = addrs[0].String()
+		}
+		summary.Outputs = append(summary.Outputs, &sharedW.SignOutput{
+			Address:  address,
+			Amount:   txOut.Value,
+			PkScript: txOut.PkScript,
+		})
+		summary.Fee -= txOut.Value
+	}
+	return summary, nil
+}
+
+// SignAirgapTx signs the bundle exported by the watch-only wallet of the seed
+// of the wallet. The keys of the inputs are derived from the extended private
+// keys of their accounts, so the wallet needs neither the spent transactions
+// nor to have derived the addresses yet.
+func (asset *Asset) SignAirgapTx(tx *sharedW.AirgapTx, passphrase string) (*sharedW.AirgapTx, error) {
+	if asset.IsWatchingOnlyWallet() {
+		return nil, errors.E(errors.Invalid, "watch-only wallets can not sign transactions")
+	}
+	bundle, msgTx, err := asset.decodeAirgapBundle(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+	if err := asset.Internal().DCR.Unlock(ctx, []byte(passphrase), lock); err != nil {
+		log.Error(err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	accountKeys := make(map[uint32]*hdkeychain.ExtendedKey)
+	for i, input := range bundle.Inputs {
+		path := input.Path
+		if len(path) != 5 || path[2] < hdkeychain.HardenedKeyStart {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("input %d has an invalid key path", i))
+		}
+		pkScript, err := hex.DecodeString(input.PkScript)
+		if err != nil {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("input %d has an invalid script", i))
+		}
+
+		// The accounts of the watch-only wallet are the accounts of the
+		// same number of the wallet.
+		account := path[2] - hdkeychain.HardenedKeyStart
+		accountKey, ok := accountKeys[account]
+		if !ok {
+			accountKey, err = asset.Internal().DCR.AccountXpriv(ctx, account)
+			if err != nil {
+				return nil, err
+			}
+			accountKeys[account] = accountKey
+		}
+		key, err := accountKey.Child(path[3])
+		if err == nil {
+			key, err = key.Child(path[4])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(stdaddr.Hash160(key.SerializedPubKey()), asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		_, script := addr.PaymentScript()
+		if !bytes.Equal(script, pkScript) {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("input %d is not spent by this wallet", i))
+		}
+
+		privKey, err := key.SerializedPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		sigScript, err := sign.SignatureScript(msgTx, i, pkScript, txscript.SigHashAll, privKey, dcrec.STEcdsaSecp256k1, true)
+		if err != nil {
+			return nil, err
+		}
+		msgTx.TxIn[i].SignatureScript = sigScript
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(msgTx.SerializeSize())
+	if err := msgTx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	bundle.Tx = hex.EncodeToString(buf.Bytes())
+	return encodeAirgapBundle(bundle)
+}
+
+// PublishAirgapTx publishes the bundle signed by the offline wallet after
+// checking that it is the transaction last exported, validly signed.
+func (asset *Asset) PublishAirgapTx(tx *sharedW.AirgapTx, label string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrDCRNotInitialized
+	}
+
+	req := asset.TxAuthoredInfo.airgapReq
+	if req == nil {
+		return "", errors.E(errors.Invalid, "no transaction was exported to be signed")
+	}
+	_, msgTx, err := asset.decodeAirgapBundle(tx)
+	if err != nil {
+		return "", err
+	}
+
+	unsignedTx := new(wire.MsgTx)
+	if err := unsignedTx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return "", err
+	}
+	if err := verifySignedTx(unsignedTx, msgTx, req); err != nil {
+		return "", err
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		log.Error(err)
+		return "", err
+	}
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, msgTx, n)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	asset.TxAuthoredInfo.airgapReq = nil
+	return txHash.String(), asset.updateTxLabel(txHash, label)
+}
@@ -3,4 +3,9 @@ package dcr
 const (
 	ErrSyncAlreadyInProgress = "sync_already_in_progress"
 	ErrListenerAlreadyExist  = "listener_already_exist"
+
+	ErrMultisigAccountNotFound     = "multisig_account_not_found"
+	ErrMultisigNotEnoughSignatures = "multisig_not_enough_signatures"
+	ErrMultisigBundleMismatch      = "multisig_bundle_mismatch"
+	ErrMultisigNoSigner            = "multisig_no_signer"
 )
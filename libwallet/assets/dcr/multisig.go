@@ -0,0 +1,508 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/wallet/txrules"
+	"decred.org/dcrwallet/v4/wallet/txsizes"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// maxMultisigKeys is the largest number of keys of a multisig account. It
+// keeps the signature script of the required signatures within the standard
+// size.
+const maxMultisigKeys = 15
+
+// MultisigAccount is an m-of-n P2SH multisig account shared by the wallet
+// with other cosigners. The wallet holds one of its keys and watches its
+// address through the imported redeem script.
+type MultisigAccount struct {
+	Name      string `json:"name"`
+	Threshold int    `json:"threshold"`
+	// PubKeys are the hex encoded compressed public keys of the cosigners,
+	// sorted so that every cosigner derives the same script from the same
+	// keys.
+	PubKeys []string `json:"pubKeys"`
+	// OwnAddress is the P2PKH address of the wallet whose key is one of the
+	// keys of the account.
+	OwnAddress   string `json:"ownAddress"`
+	Address      string `json:"address"`
+	RedeemScript string `json:"redeemScript"`
+	CreatedAt    int64  `json:"createdAt"`
+}
+
+// multisigUTXO is an unspent output of a multisig account.
+type multisigUTXO struct {
+	OutPoint wire.OutPoint
+	Amount   int64
+}
+
+// newMultisigAccount builds the account of the threshold of the keys.
+func newMultisigAccount(name string, threshold int, pubKeys [][]byte, params stdaddr.AddressParams) (*MultisigAccount, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.E(errors.Invalid, "missing multisig account name")
+	}
+	if len(pubKeys) < 2 || len(pubKeys) > maxMultisigKeys {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("multisig accounts have 2 to %d keys", maxMultisigKeys))
+	}
+	if threshold < 1 || threshold > len(pubKeys) {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("required signatures must be between 1 and %d", len(pubKeys)))
+	}
+
+	sorted := make([][]byte, len(pubKeys))
+	copy(sorted, pubKeys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	account := &MultisigAccount{Name: name, Threshold: threshold}
+	for i, key := range sorted {
+		if i > 0 && bytes.Equal(key, sorted[i-1]) {
+			return nil, errors.E(errors.Invalid, "multisig account keys must be unique")
+		}
+		account.PubKeys = append(account.PubKeys, hex.EncodeToString(key))
+	}
+
+	script, err := stdscript.MultiSigScriptV0(threshold, sorted...)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, err)
+	}
+	address, err := stdaddr.NewAddressScriptHashV0(script, params)
+	if err != nil {
+		return nil, err
+	}
+	account.RedeemScript = hex.EncodeToString(script)
+	account.Address = address.String()
+	return account, nil
+}
+
+// parseMultisigKey parses a cosigner key given as a hex encoded public key or
+// a public key address.
+func parseMultisigKey(key string, params stdaddr.AddressParams) ([]byte, error) {
+	key = strings.TrimSpace(key)
+	serialized, err := hex.DecodeString(key)
+	if err != nil {
+		addr, err := stdaddr.DecodeAddress(key, params)
+		if err != nil {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid cosigner key %s", key))
+		}
+		pubKeyAddr, ok := addr.(*stdaddr.AddressPubKeyEcdsaSecp256k1V0)
+		if !ok {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("%s is not a public key address", key))
+		}
+		serialized = pubKeyAddr.SerializedPubKey()
+	}
+
+	pubKey, err := secp256k1.ParsePubKey(serialized)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid cosigner key %s", key))
+	}
+	return pubKey.SerializeCompressed(), nil
+}
+
+// multisigSigScriptSize returns the size of the signature script of the
+// required signatures of the redeem script.
+func multisigSigScriptSize(threshold int, redeemScript []byte) int {
+	size := len(redeemScript)
+	switch {
+	case size <= 75:
+		size++
+	case size <= math.MaxUint8:
+		size += 2
+	default:
+		size += 3
+	}
+	// Each signature is a data push of at most 72 bytes of DER signature
+	// and the hash type.
+	return size + threshold*(1+73)
+}
+
+// createSpend creates the unsigned bundle of a transaction paying the amount
+// to the pkScript from the UTXOs, the largest first. The change goes back to
+// the address of the account.
+func (account *MultisigAccount) createSpend(utxos []*multisigUTXO, pkScript []byte, amount int64,
+	relayFeePerKb dcrutil.Amount, network string, params stdaddr.AddressParams) (*MultisigBundle, error) {
+	if amount <= 0 {
+		return nil, errors.E(errors.Invalid, "amount must be positive")
+	}
+	output := wire.NewTxOut(amount, pkScript)
+	if txrules.IsDustOutput(output, relayFeePerKb) {
+		return nil, errors.E(errors.Invalid, "amount is too small")
+	}
+
+	redeemScript, err := hex.DecodeString(account.RedeemScript)
+	if err != nil {
+		return nil, err
+	}
+	address, err := stdaddr.DecodeAddress(account.Address, params)
+	if err != nil {
+		return nil, err
+	}
+	_, changeScript := address.PaymentScript()
+
+	sorted := make([]*multisigUTXO, len(utxos))
+	copy(sorted, utxos)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Amount > sorted[j].Amount
+	})
+
+	sigScriptSize := multisigSigScriptSize(account.Threshold, redeemScript)
+	fee := func(inputs int, change bool) int64 {
+		scriptSizes := make([]int, inputs)
+		for i := range scriptSizes {
+			scriptSizes[i] = sigScriptSize
+		}
+		changeSize := 0
+		if change {
+			changeSize = len(changeScript)
+		}
+		size := txsizes.EstimateSerializeSize(scriptSizes, []*wire.TxOut{output}, changeSize)
+		return int64(txrules.FeeForSerializeSize(relayFeePerKb, size))
+	}
+
+	var selected []*multisigUTXO
+	var total int64
+	for _, utxo := range sorted {
+		if total >= amount+fee(len(selected), false) {
+			break
+		}
+		selected = append(selected, utxo)
+		total += utxo.Amount
+	}
+	if total < amount+fee(len(selected), false) {
+		return nil, errors.New(utils.ErrInsufficientBalance)
+	}
+
+	tx := wire.NewMsgTx()
+	inputs := make([]*MultisigInput, 0, len(selected))
+	for _, utxo := range selected {
+		tx.AddTxIn(wire.NewTxIn(&utxo.OutPoint, utxo.Amount, nil))
+		inputs = append(inputs, &MultisigInput{Amount: utxo.Amount, RedeemScript: account.RedeemScript})
+	}
+	tx.AddTxOut(output)
+
+	// Change too small to be worth an output is left to the fee.
+	change := total - amount - fee(len(selected), true)
+	if change > 0 && !txrules.IsDustAmount(dcrutil.Amount(change), len(changeScript), relayFeePerKb) {
+		tx.AddTxOut(wire.NewTxOut(change, changeScript))
+	}
+
+	return newMultisigBundle(network, tx, inputs)
+}
+
+// MultisigAccounts returns the multisig accounts of the wallet, oldest first.
+func (asset *Asset) MultisigAccounts() []*MultisigAccount {
+	var accounts []*MultisigAccount
+	_ = asset.ReadUserConfigValue(sharedW.MultisigAccountsConfigKey, &accounts)
+	return accounts
+}
+
+// MultisigAccount returns the multisig account of the P2SH address.
+func (asset *Asset) MultisigAccount(address string) (*MultisigAccount, error) {
+	for _, account := range asset.MultisigAccounts() {
+		if account.Address == address {
+			return account, nil
+		}
+	}
+	return nil, errors.New(ErrMultisigAccountNotFound)
+}
+
+// MultisigCosignerKey returns the public key address of the next address of
+// the account, which the wallet shares with the other cosigners of a
+// multisig account.
+func (asset *Asset) MultisigCosignerKey(account int32) (string, error) {
+	address, err := asset.NextAddress(account)
+	if err != nil {
+		return "", err
+	}
+	return asset.AddressPubKey(address)
+}
+
+// CreateMultisigAccount creates the multisig account of the threshold of the
+// cosigner keys and imports its redeem script for the wallet to watch its
+// address. One of the keys must belong to the wallet. If none does, the key
+// of the next address of the account is added to the keys. Funds the address
+// received before the account was created are found by a rescan.
+func (asset *Asset) CreateMultisigAccount(name string, threshold int, cosignerKeys []string, account int32) (*MultisigAccount, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrDCRNotInitialized
+	}
+
+	var pubKeys [][]byte
+	var ownAddress string
+	for _, key := range cosignerKeys {
+		pubKey, err := parseMultisigKey(key, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+		if ownAddress == "" {
+			ownAddress = asset.keyAddress(pubKey)
+		}
+	}
+	if ownAddress == "" {
+		keyAddress, err := asset.MultisigCosignerKey(account)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := parseMultisigKey(keyAddress, asset.chainParams)
+		if err != nil {
+			return nil, err
+		}
+		pubKeys = append(pubKeys, pubKey)
+		ownAddress = asset.keyAddress(pubKey)
+	}
+
+	multisigAccount, err := newMultisigAccount(name, threshold, pubKeys, asset.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	multisigAccount.OwnAddress = ownAddress
+	multisigAccount.CreatedAt = time.Now().Unix()
+
+	asset.multisigMu.Lock()
+	defer asset.multisigMu.Unlock()
+
+	accounts := asset.MultisigAccounts()
+	for _, existing := range accounts {
+		if existing.Address == multisigAccount.Address {
+			return nil, errors.E(errors.Exist, "the multisig account already exists")
+		}
+	}
+
+	redeemScript, _ := hex.DecodeString(multisigAccount.RedeemScript)
+	ctx, _ := asset.ShutdownContextWithCancel()
+	if err := asset.Internal().DCR.ImportScript(ctx, redeemScript); err != nil && !errors.Is(err, errors.Exist) {
+		return nil, utils.TranslateError(err)
+	}
+
+	asset.SaveUserConfigValue(sharedW.MultisigAccountsConfigKey, append(accounts, multisigAccount))
+	return multisigAccount, nil
+}
+
+// keyAddress returns the P2PKH address of the public key if it belongs to the
+// wallet, or an empty string.
+func (asset *Asset) keyAddress(pubKey []byte) string {
+	pubKeyAddr, err := stdaddr.NewAddressPubKeyEcdsaSecp256k1V0Raw(pubKey, asset.chainParams)
+	if err != nil {
+		return ""
+	}
+	address := pubKeyAddr.AddressPubKeyHash().String()
+	if !asset.HaveAddress(address) {
+		return ""
+	}
+	return address
+}
+
+// RemoveMultisigAccount removes the multisig account of the P2SH address. The
+// wallet keeps watching the address, and the account can be created again
+// from its keys.
+func (asset *Asset) RemoveMultisigAccount(address string) error {
+	asset.multisigMu.Lock()
+	defer asset.multisigMu.Unlock()
+
+	accounts := make([]*MultisigAccount, 0)
+	var found bool
+	for _, account := range asset.MultisigAccounts() {
+		if account.Address == address {
+			found = true
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	if !found {
+		return errors.New(ErrMultisigAccountNotFound)
+	}
+	asset.SaveUserConfigValue(sharedW.MultisigAccountsConfigKey, accounts)
+	return nil
+}
+
+// multisigUnspent returns the unspent outputs paying to the address of the
+// multisig account.
+func (asset *Asset) multisigUnspent(account *MultisigAccount) ([]*multisigUTXO, error) {
+	ctx, _ := asset.ShutdownContextWithCancel()
+	unspent, err := asset.Internal().DCR.ListUnspent(ctx, 0, math.MaxInt32,
+		map[string]struct{}{account.Address: {}}, "")
+	if err != nil {
+		return nil, utils.TranslateError(err)
+	}
+
+	utxos := make([]*multisigUTXO, 0, len(unspent))
+	for _, output := range unspent {
+		hash, err := chainhash.NewHashFromStr(output.TxID)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := dcrutil.NewAmount(output.Amount)
+		if err != nil {
+			return nil, err
+		}
+		utxos = append(utxos, &multisigUTXO{
+			OutPoint: *wire.NewOutPoint(hash, output.Vout, output.Tree),
+			Amount:   int64(amount),
+		})
+	}
+	return utxos, nil
+}
+
+// MultisigBalance returns the unspent funds of the multisig account.
+func (asset *Asset) MultisigBalance(address string) (sharedW.AssetAmount, error) {
+	account, err := asset.MultisigAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	utxos, err := asset.multisigUnspent(account)
+	if err != nil {
+		return nil, err
+	}
+	var balance int64
+	for _, utxo := range utxos {
+		balance += utxo.Amount
+	}
+	return asset.ToAmount(balance), nil
+}
+
+// CreateMultisigSpend creates the unsigned transaction bundle of a spend of
+// the amount in atoms from the multisig account to the destination address.
+// The bundle is JSON encoded for the cosigners to sign.
+func (asset *Asset) CreateMultisigSpend(address, destination string, amount int64) (string, error) {
+	account, err := asset.MultisigAccount(address)
+	if err != nil {
+		return "", err
+	}
+	destinationAddr, err := stdaddr.DecodeAddress(strings.TrimSpace(destination), asset.chainParams)
+	if err != nil {
+		return "", errors.New(utils.ErrInvalidAddress)
+	}
+	_, pkScript := destinationAddr.PaymentScript()
+
+	utxos, err := asset.multisigUnspent(account)
+	if err != nil {
+		return "", err
+	}
+	bundle, err := account.createSpend(utxos, pkScript, amount, txrules.DefaultRelayFeePerKb,
+		asset.chainParams.Name, asset.chainParams)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := bundle.Encode()
+	return string(encoded), err
+}
+
+// decodeMultisigBundle decodes the bundle and checks that it is a bundle of
+// the network of the wallet.
+func (asset *Asset) decodeMultisigBundle(encoded string) (*MultisigBundle, error) {
+	bundle, err := DecodeMultisigBundle([]byte(encoded))
+	if err != nil {
+		return nil, err
+	}
+	if bundle.Network != asset.chainParams.Name {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("the multisig bundle is for %s", bundle.Network))
+	}
+	return bundle, nil
+}
+
+// SignMultisigBundle adds the signatures of the keys of the wallet to the
+// inputs of the bundle spending its multisig accounts and returns the signed
+// bundle.
+func (asset *Asset) SignMultisigBundle(encoded, passphrase string) (string, error) {
+	bundle, err := asset.decodeMultisigBundle(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	err = asset.UnlockWallet(passphrase)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	defer asset.LockWallet()
+
+	ctx, _ := asset.ShutdownContextWithCancel()
+	var signed int
+	for _, account := range asset.MultisigAccounts() {
+		addr, err := stdaddr.DecodeAddress(account.OwnAddress, asset.chainParams)
+		if err != nil {
+			return "", err
+		}
+		wifStr, err := asset.Internal().DCR.DumpWIFPrivateKey(ctx, addr)
+		if err != nil {
+			return "", utils.TranslateError(err)
+		}
+		wif, err := dcrutil.DecodeWIF(wifStr, asset.chainParams.PrivateKeyID)
+		if err != nil {
+			return "", err
+		}
+		n, err := bundle.Sign(wif.PrivKey())
+		if err != nil {
+			return "", err
+		}
+		signed += n
+	}
+	if signed == 0 {
+		return "", errors.New(ErrMultisigNoSigner)
+	}
+
+	signedBundle, err := bundle.Encode()
+	return string(signedBundle), err
+}
+
+// CombineMultisigBundles merges the signatures of the bundles of the same
+// transaction. It returns the combined bundle and true if it has the
+// signatures needed to publish it.
+func (asset *Asset) CombineMultisigBundles(encoded ...string) (string, bool, error) {
+	bundles := make([]*MultisigBundle, 0, len(encoded))
+	for _, e := range encoded {
+		bundle, err := asset.decodeMultisigBundle(e)
+		if err != nil {
+			return "", false, err
+		}
+		bundles = append(bundles, bundle)
+	}
+	combined, err := CombineMultisigBundles(bundles...)
+	if err != nil {
+		return "", false, err
+	}
+	encodedBundle, err := combined.Encode()
+	if err != nil {
+		return "", false, err
+	}
+	return string(encodedBundle), combined.Complete(), nil
+}
+
+// PublishMultisigBundle publishes the transaction of the complete bundle and
+// returns its hash.
+func (asset *Asset) PublishMultisigBundle(encoded string) (string, error) {
+	bundle, err := asset.decodeMultisigBundle(encoded)
+	if err != nil {
+		return "", err
+	}
+	tx, err := bundle.SignedTx()
+	if err != nil {
+		return "", err
+	}
+
+	n, err := asset.Internal().DCR.NetworkBackend()
+	if err != nil {
+		return "", err
+	}
+	ctx, _ := asset.ShutdownContextWithCancel()
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, tx, n)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	return txHash.String(), nil
+}
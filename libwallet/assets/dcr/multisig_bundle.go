@@ -0,0 +1,319 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/decred/dcrd/dcrec"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/sign"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// multisigBundleVersion is the version of the transaction bundles created by
+// the wallet. Bundles of later versions are rejected.
+const multisigBundleVersion = 1
+
+// MultisigSignature is the signature of a multisig input by one of the keys
+// of its redeem script.
+type MultisigSignature struct {
+	// PubKey is the hex encoded compressed public key of the signer.
+	PubKey string `json:"pubKey"`
+	// Signature is the hex encoded DER signature followed by its hash type
+	// byte, which is always SIGHASH_ALL.
+	Signature string `json:"signature"`
+}
+
+// MultisigInput describes an input of a bundle spending a P2SH multisig
+// output.
+type MultisigInput struct {
+	// Amount is the value of the spent output in atoms.
+	Amount int64 `json:"amount"`
+	// RedeemScript is the hex encoded m-of-n multisig script of the P2SH
+	// address of the spent output.
+	RedeemScript string `json:"redeemScript"`
+	// Signatures are the signatures of the input collected so far.
+	Signatures []*MultisigSignature `json:"signatures"`
+}
+
+// MultisigBundle is the transaction bundle that carries a spend of P2SH
+// multisig outputs between its cosigners. It is exchanged as JSON:
+//
+//	{
+//	  "version": 1,
+//	  "network": "testnet3",
+//	  "tx": "<hex serialized transaction with empty signature scripts>",
+//	  "inputs": [
+//	    {
+//	      "amount": 150000000,
+//	      "redeemScript": "<hex m-of-n multisig script>",
+//	      "signatures": [
+//	        {"pubKey": "<hex compressed public key>", "signature": "<hex DER signature and hash type>"}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// The inputs follow the order of the inputs of the transaction. Each cosigner
+// adds the signatures of its key and the bundles are combined until every
+// input has the signatures required by its script. The signature scripts of
+// the transaction are only built when it is published, so the transaction of
+// a bundle never changes while the signatures are collected.
+type MultisigBundle struct {
+	Version int              `json:"version"`
+	Network string           `json:"network"`
+	Tx      string           `json:"tx"`
+	Inputs  []*MultisigInput `json:"inputs"`
+
+	tx *wire.MsgTx
+}
+
+// newMultisigBundle creates the unsigned bundle of the transaction spending
+// the inputs on the network.
+func newMultisigBundle(network string, tx *wire.MsgTx, inputs []*MultisigInput) (*MultisigBundle, error) {
+	var buf bytes.Buffer
+	buf.Grow(tx.SerializeSize())
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	return &MultisigBundle{
+		Version: multisigBundleVersion,
+		Network: network,
+		Tx:      hex.EncodeToString(buf.Bytes()),
+		Inputs:  inputs,
+		tx:      tx,
+	}, nil
+}
+
+// DecodeMultisigBundle decodes the JSON encoded bundle and checks that its
+// inputs spend multisig scripts and that its signatures are valid.
+func DecodeMultisigBundle(data []byte) (*MultisigBundle, error) {
+	bundle := new(MultisigBundle)
+	if err := json.Unmarshal(bytes.TrimSpace(data), bundle); err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Errorf("invalid multisig bundle: %v", err))
+	}
+	if bundle.Version < 1 || bundle.Version > multisigBundleVersion {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("unsupported multisig bundle version %d", bundle.Version))
+	}
+
+	txBytes, err := hex.DecodeString(bundle.Tx)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Errorf("invalid multisig bundle transaction: %v", err))
+	}
+	bundle.tx = new(wire.MsgTx)
+	if err := bundle.tx.Deserialize(bytes.NewReader(txBytes)); err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Errorf("invalid multisig bundle transaction: %v", err))
+	}
+	if len(bundle.Inputs) != len(bundle.tx.TxIn) {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("multisig bundle has %d inputs for a transaction of %d inputs",
+			len(bundle.Inputs), len(bundle.tx.TxIn)))
+	}
+
+	for i, input := range bundle.Inputs {
+		script, err := hex.DecodeString(input.RedeemScript)
+		if err != nil || !stdscript.IsMultiSigScriptV0(script) {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("input %d does not spend a multisig script", i))
+		}
+		signatures := input.Signatures
+		input.Signatures = nil
+		for _, signature := range signatures {
+			if err := bundle.verifySignature(i, script, signature); err != nil {
+				return nil, err
+			}
+			// Keys are compared by their lowercase hex encoding.
+			signature.PubKey = strings.ToLower(signature.PubKey)
+			if !input.signedBy(signature.PubKey) {
+				input.Signatures = append(input.Signatures, signature)
+			}
+		}
+	}
+	return bundle, nil
+}
+
+// Encode returns the JSON encoding of the bundle.
+func (b *MultisigBundle) Encode() ([]byte, error) {
+	return json.Marshal(b)
+}
+
+// TxHash returns the hash of the transaction of the bundle, which does not
+// change as it is signed.
+func (b *MultisigBundle) TxHash() string {
+	return b.tx.TxHash().String()
+}
+
+// verifySignature checks that the signature of input idx is a valid
+// signature of one of the keys of the redeem script.
+func (b *MultisigBundle) verifySignature(idx int, script []byte, signature *MultisigSignature) error {
+	invalid := errors.E(errors.Invalid, fmt.Sprintf("invalid signature of input %d", idx))
+
+	pubKey, err := hex.DecodeString(signature.PubKey)
+	if err != nil || multisigKeyIndex(script, pubKey) < 0 {
+		return invalid
+	}
+	sig, err := hex.DecodeString(signature.Signature)
+	if err != nil || len(sig) == 0 || txscript.SigHashType(sig[len(sig)-1]) != txscript.SigHashAll {
+		return invalid
+	}
+
+	hash, err := txscript.CalcSignatureHash(script, txscript.SigHashAll, b.tx, idx, nil)
+	if err != nil {
+		return err
+	}
+	parsedSig, err := ecdsa.ParseDERSignature(sig[:len(sig)-1])
+	if err != nil {
+		return invalid
+	}
+	parsedKey, err := secp256k1.ParsePubKey(pubKey)
+	if err != nil {
+		return invalid
+	}
+	if !parsedSig.Verify(hash, parsedKey) {
+		return invalid
+	}
+	return nil
+}
+
+// Sign adds the signatures of the private key to the inputs of the bundle
+// whose redeem script includes its public key. It returns the number of
+// inputs signed.
+func (b *MultisigBundle) Sign(privKey []byte) (int, error) {
+	pubKeyBytes := secp256k1.PrivKeyFromBytes(privKey).PubKey().SerializeCompressed()
+	pubKey := hex.EncodeToString(pubKeyBytes)
+
+	var signed int
+	for i, input := range b.Inputs {
+		script, err := hex.DecodeString(input.RedeemScript)
+		if err != nil {
+			return signed, err
+		}
+		if multisigKeyIndex(script, pubKeyBytes) < 0 || input.signedBy(pubKey) {
+			continue
+		}
+
+		sig, err := sign.RawTxInSignature(b.tx, i, script, txscript.SigHashAll, privKey, dcrec.STEcdsaSecp256k1)
+		if err != nil {
+			return signed, err
+		}
+		input.Signatures = append(input.Signatures, &MultisigSignature{
+			PubKey:    pubKey,
+			Signature: hex.EncodeToString(sig),
+		})
+		signed++
+	}
+	return signed, nil
+}
+
+// signedBy returns true if the input has a signature of the hex encoded
+// public key.
+func (input *MultisigInput) signedBy(pubKey string) bool {
+	for _, signature := range input.Signatures {
+		if signature.PubKey == pubKey {
+			return true
+		}
+	}
+	return false
+}
+
+// Complete returns true if every input of the bundle has the signatures
+// required by its redeem script.
+func (b *MultisigBundle) Complete() bool {
+	for _, input := range b.Inputs {
+		script, err := hex.DecodeString(input.RedeemScript)
+		if err != nil {
+			return false
+		}
+		details := stdscript.ExtractMultiSigScriptDetailsV0(script, false)
+		if len(input.Signatures) < int(details.RequiredSigs) {
+			return false
+		}
+	}
+	return true
+}
+
+// CombineMultisigBundles merges the signatures of the bundles of the same
+// transaction.
+func CombineMultisigBundles(bundles ...*MultisigBundle) (*MultisigBundle, error) {
+	if len(bundles) == 0 {
+		return nil, errors.E(errors.Invalid, "no multisig bundles to combine")
+	}
+
+	combined := bundles[0]
+	txHash := combined.tx.TxHash()
+	for _, bundle := range bundles[1:] {
+		if bundle.Network != combined.Network || bundle.tx.TxHash() != txHash {
+			return nil, errors.New(ErrMultisigBundleMismatch)
+		}
+		for i, input := range bundle.Inputs {
+			for _, signature := range input.Signatures {
+				if !combined.Inputs[i].signedBy(signature.PubKey) {
+					combined.Inputs[i].Signatures = append(combined.Inputs[i].Signatures, signature)
+				}
+			}
+		}
+	}
+	return combined, nil
+}
+
+// SignedTx builds the signature scripts of the inputs of the complete bundle
+// and returns its signed transaction. The signature script of an input holds
+// the required number of signatures, in the order of the keys of the redeem
+// script, followed by the redeem script.
+func (b *MultisigBundle) SignedTx() (*wire.MsgTx, error) {
+	if !b.Complete() {
+		return nil, errors.New(ErrMultisigNotEnoughSignatures)
+	}
+
+	tx := b.tx.Copy()
+	for i, input := range b.Inputs {
+		script, err := hex.DecodeString(input.RedeemScript)
+		if err != nil {
+			return nil, err
+		}
+		details := stdscript.ExtractMultiSigScriptDetailsV0(script, true)
+
+		builder := txscript.NewScriptBuilder()
+		var added uint16
+		for _, pubKey := range details.PubKeys {
+			if added == details.RequiredSigs {
+				break
+			}
+			for _, signature := range input.Signatures {
+				if signature.PubKey != hex.EncodeToString(pubKey) {
+					continue
+				}
+				sig, err := hex.DecodeString(signature.Signature)
+				if err != nil {
+					return nil, err
+				}
+				builder.AddData(sig)
+				added++
+				break
+			}
+		}
+		builder.AddData(script)
+		tx.TxIn[i].SignatureScript, err = builder.Script()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// multisigKeyIndex returns the index of the public key in the multisig
+// script, or -1 if the script does not include it.
+func multisigKeyIndex(script, pubKey []byte) int {
+	details := stdscript.ExtractMultiSigScriptDetailsV0(script, true)
+	for i, key := range details.PubKeys {
+		if bytes.Equal(key, pubKey) {
+			return i
+		}
+	}
+	return -1
+}
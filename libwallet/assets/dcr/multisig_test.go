@@ -0,0 +1,194 @@
+package dcr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"decred.org/dcrwallet/v4/wallet/txrules"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+var testParams = chaincfg.TestNet3Params()
+
+func testPrivKey(i int) []byte {
+	return bytes.Repeat([]byte{byte(i + 1)}, 32)
+}
+
+func testPubKey(i int) []byte {
+	return secp256k1.PrivKeyFromBytes(testPrivKey(i)).PubKey().SerializeCompressed()
+}
+
+// testMultisigAccount returns a 2-of-3 account of the test keys.
+func testMultisigAccount(t *testing.T) *MultisigAccount {
+	t.Helper()
+	account, err := newMultisigAccount("treasury", 2, [][]byte{testPubKey(0), testPubKey(1), testPubKey(2)}, testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account
+}
+
+func TestMultisigAccount(t *testing.T) {
+	account := testMultisigAccount(t)
+
+	// The keys are sorted, so every cosigner derives the same address.
+	reordered, err := newMultisigAccount("treasury", 2, [][]byte{testPubKey(2), testPubKey(0), testPubKey(1)}, testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reordered.Address != account.Address || reordered.RedeemScript != account.RedeemScript {
+		t.Fatalf("expected address %s, got %s", account.Address, reordered.Address)
+	}
+
+	if _, err := newMultisigAccount("treasury", 3, [][]byte{testPubKey(0), testPubKey(1)}, testParams); err == nil {
+		t.Error("expected a threshold above the keys to fail")
+	}
+	if _, err := newMultisigAccount("treasury", 1, [][]byte{testPubKey(0), testPubKey(0)}, testParams); err == nil {
+		t.Error("expected a repeated key to fail")
+	}
+}
+
+func TestParseMultisigKey(t *testing.T) {
+	pubKeyAddr, err := stdaddr.NewAddressPubKeyEcdsaSecp256k1V0Raw(testPubKey(0), testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{hex.EncodeToString(testPubKey(0)), pubKeyAddr.String()} {
+		pubKey, err := parseMultisigKey(key, testParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pubKey, testPubKey(0)) {
+			t.Errorf("%s: expected key %x, got %x", key, testPubKey(0), pubKey)
+		}
+	}
+
+	if _, err := parseMultisigKey(pubKeyAddr.AddressPubKeyHash().String(), testParams); err == nil {
+		t.Error("expected a pubkey hash address to fail")
+	}
+}
+
+func TestMultisigSpend(t *testing.T) {
+	account := testMultisigAccount(t)
+	address, err := stdaddr.DecodeAddress(account.Address, testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, pkScript := address.PaymentScript()
+
+	utxos := []*multisigUTXO{
+		{OutPoint: *wire.NewOutPoint(&chainhash.Hash{1}, 0, wire.TxTreeRegular), Amount: 2e8},
+		{OutPoint: *wire.NewOutPoint(&chainhash.Hash{2}, 1, wire.TxTreeRegular), Amount: 1e8},
+	}
+	payee := append([]byte{txscript.OP_DUP, txscript.OP_HASH160, 20}, bytes.Repeat([]byte{7}, 20)...)
+	payee = append(payee, txscript.OP_EQUALVERIFY, txscript.OP_CHECKSIG)
+
+	bundle, err := account.createSpend(utxos, payee, 25e7, txrules.DefaultRelayFeePerKb, testParams.Name, testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundle.tx.TxIn) != 2 || len(bundle.tx.TxOut) != 2 {
+		t.Fatalf("expected 2 inputs and 2 outputs, got %d and %d", len(bundle.tx.TxIn), len(bundle.tx.TxOut))
+	}
+	encoded, err := bundle.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first and third cosigners sign copies of the bundle.
+	var signedBundles []*MultisigBundle
+	for _, i := range []int{0, 2} {
+		signerBundle, err := DecodeMultisigBundle(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed, err := signerBundle.Sign(testPrivKey(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signed != 2 {
+			t.Fatalf("cosigner %d: expected 2 signed inputs, got %d", i, signed)
+		}
+		if _, err := signerBundle.SignedTx(); err == nil {
+			t.Fatal("expected a bundle with one signature not to be complete")
+		}
+
+		// The signatures survive the encoding.
+		signedEncoded, err := signerBundle.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signerBundle, err = DecodeMultisigBundle(signedEncoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signedBundles = append(signedBundles, signerBundle)
+	}
+
+	if signed, err := bundle.Sign(testPrivKey(9)); err != nil || signed != 0 {
+		t.Fatalf("expected a key of no cosigner not to sign, got %d signed inputs and %v", signed, err)
+	}
+
+	combined, err := CombineMultisigBundles(signedBundles...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !combined.Complete() {
+		t.Fatal("expected the combined bundle to be complete")
+	}
+	tx, err := combined.SignedTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.TxHash().String() != bundle.TxHash() {
+		t.Fatal("expected the signed transaction to keep the hash of the bundle")
+	}
+
+	for i := range tx.TxIn {
+		engine, err := txscript.NewEngine(pkScript, tx, i, txscript.ScriptVerifyCleanStack|txscript.ScriptVerifySigPushOnly, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.Execute(); err != nil {
+			t.Fatalf("input %d: %v", i, err)
+		}
+	}
+}
+
+func TestMultisigBundleRejectsInvalidSignatures(t *testing.T) {
+	account := testMultisigAccount(t)
+	utxos := []*multisigUTXO{{OutPoint: *wire.NewOutPoint(&chainhash.Hash{1}, 0, wire.TxTreeRegular), Amount: 1e8}}
+	bundle, err := account.createSpend(utxos, []byte{txscript.OP_TRUE}, 5e7, txrules.DefaultRelayFeePerKb, testParams.Name, testParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bundle.Sign(testPrivKey(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A signature moved to another key of the script is invalid.
+	bundle.Inputs[0].Signatures[0].PubKey = hex.EncodeToString(testPubKey(0))
+	encoded, err := bundle.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeMultisigBundle(encoded); err == nil {
+		t.Fatal("expected a bundle with an invalid signature to fail")
+	}
+}
+
+func TestMultisigSpendInsufficientFunds(t *testing.T) {
+	account := testMultisigAccount(t)
+	utxos := []*multisigUTXO{{OutPoint: *wire.NewOutPoint(&chainhash.Hash{1}, 0, wire.TxTreeRegular), Amount: 1e6}}
+	_, err := account.createSpend(utxos, []byte{txscript.OP_TRUE}, 1e6, txrules.DefaultRelayFeePerKb, testParams.Name, testParams)
+	if err == nil || err.Error() != utils.ErrInsufficientBalance {
+		t.Fatalf("expected %s, got %v", utils.ErrInsufficientBalance, err)
+	}
+}
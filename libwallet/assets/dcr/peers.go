@@ -0,0 +1,99 @@
+package dcr
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/spv"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/decred/dcrd/addrmgr/v2"
+	"github.com/decred/dcrd/wire"
+)
+
+// spvSyncer returns the syncer of the running SPV sync, or nil.
+func (asset *Asset) spvSyncer() *spv.Syncer {
+	asset.syncData.mu.RLock()
+	defer asset.syncData.mu.RUnlock()
+
+	if asset.syncData.activeSyncData == nil {
+		return nil
+	}
+	syncer, _ := asset.syncData.activeSyncData.syncer.(*spv.Syncer)
+	return syncer
+}
+
+// BanPeer bans the host of the peer address for the duration and disconnects
+// the peers of the host.
+func (asset *Asset) BanPeer(address string, duration time.Duration) error {
+	if err := asset.SaveBannedPeer(address, duration); err != nil {
+		return err
+	}
+
+	syncer := asset.spvSyncer()
+	if syncer == nil {
+		return nil
+	}
+	host := sharedW.PeerHost(address)
+	for _, rp := range syncer.GetRemotePeers() {
+		if sharedW.PeerHost(rp.RemoteAddr().String()) == host {
+			rp.Disconnect(errors.Errorf("peer %s is banned", host))
+		}
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the host of the peer address.
+func (asset *Asset) UnbanPeer(address string) error {
+	return asset.RemoveBannedPeer(address)
+}
+
+// dialPeer dials the peer and seeder connections of the SPV sync. Banned
+// peers are not dialed.
+func (asset *Asset) dialPeer(ctx context.Context, network, address string) (net.Conn, error) {
+	if asset.IsPeerBanned(address) {
+		return nil, errors.Errorf("peer %s is banned", address)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, address)
+}
+
+// preferHealthyPeers adds the healthy preferred peers to the address manager
+// as good addresses, which it picks before the addresses of the seeders.
+func (asset *Asset) preferHealthyPeers(addrManager *addrmgr.AddrManager) {
+	for _, address := range asset.HealthyPeers() {
+		host, portStr, err := net.SplitHostPort(address)
+		if err != nil {
+			continue
+		}
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			continue
+		}
+		na, err := addrManager.HostToNetAddress(host, uint16(port), wire.SFNodeNetwork)
+		if err != nil {
+			log.Errorf("Preferred peer %s is invalid: %v", address, err)
+			continue
+		}
+		addrManager.AddAddresses([]*addrmgr.NetAddress{na}, na)
+		if err := addrManager.Good(na); err != nil {
+			log.Debugf("Marking preferred peer %s as good failed: %v", address, err)
+		}
+	}
+}
+
+// updatePeerScores updates the scores of the preferred peers with the peers
+// connected once the SPV sync completed.
+func (asset *Asset) updatePeerScores() {
+	syncer := asset.spvSyncer()
+	if syncer == nil {
+		return
+	}
+	var connected []string
+	for _, rp := range syncer.GetRemotePeers() {
+		connected = append(connected, rp.RemoteAddr().String())
+	}
+	asset.UpdatePeerScores(connected)
+}
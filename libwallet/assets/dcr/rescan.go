@@ -8,6 +8,7 @@ import (
 	"decred.org/dcrwallet/v4/errors"
 	w "decred.org/dcrwallet/v4/wallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -15,6 +16,31 @@ func (asset *Asset) RescanBlocks() error {
 	return asset.RescanBlocksFromHeight(0)
 }
 
+// Rescan rescans the blockchain from the start of the options. With a gap
+// limit, the used addresses are discovered from the start block first. DCR
+// wallets always discover the addresses of all their accounts, so the
+// options must not limit the rescan to some accounts.
+func (asset *Asset) Rescan(options *sharedW.RescanOptions) error {
+	if len(options.Accounts) > 0 {
+		return errors.New(utils.ErrAccountRescanUnsupported)
+	}
+
+	netType := asset.NetType()
+	startHeight := options.StartBlockHeight(asset.GetBestBlockHeight(), GetGenesisTimestamp(netType), GetTargetTimePerBlock(netType))
+	if options.GapLimit == 0 {
+		return asset.RescanBlocksFromHeight(startHeight)
+	}
+
+	return asset.discoverUsage(startHeight, options.GapLimit, func() {
+		if err := asset.RescanBlocksFromHeight(startHeight); err != nil {
+			log.Errorf("Rescanning %s after the address discovery failed: %v", asset.GetWalletName(), err)
+			if asset.blocksRescanProgressListener != nil {
+				asset.blocksRescanProgressListener.OnBlocksRescanEnded(asset.ID, err)
+			}
+		}
+	})
+}
+
 func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 	netBackend, err := asset.Internal().DCR.NetworkBackend()
 	if err != nil {
@@ -65,7 +91,10 @@ func (asset *Asset) RescanBlocksFromHeight(startHeight int32) error {
 			}
 
 			elapsedRescanTime := time.Since(rescanStartTime).Seconds()
-			rescanRate := float64(p.ScannedThrough) / float64(rescanProgressReport.TotalHeadersToScan)
+			rescanRate := 1.0
+			if blocksToScan := rescanProgressReport.TotalHeadersToScan - startHeight; blocksToScan > 0 {
+				rescanRate = float64(p.ScannedThrough-startHeight) / float64(blocksToScan)
+			}
 
 			rescanProgressReport.RescanProgress = int32(math.Round(rescanRate * 100))
 			estimatedTotalRescanTime := elapsedRescanTime / rescanRate
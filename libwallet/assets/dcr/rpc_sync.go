@@ -0,0 +1,91 @@
+package dcr
+
+import (
+	"decred.org/dcrwallet/v4/chain"
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// rpcSync syncs the wallet with the dcrd node of the RPC network mode.
+func (asset *Asset) rpcSync() error {
+	node, err := asset.RPCSyncNode()
+	if err != nil {
+		return err
+	}
+
+	asset.initActiveSyncData()
+
+	syncer := chain.NewSyncer(asset.Internal().DCR, &chain.RPCOptions{
+		Address:  node.Address,
+		User:     node.User,
+		Pass:     node.Password,
+		CA:       []byte(node.Cert),
+		Insecure: node.DisableTLS(),
+	})
+	syncer.SetCallbacks(asset.rpcSyncCallbacks())
+
+	log.Infof("[%d] Syncing with the dcrd node at %s", asset.ID, node.Address)
+	asset.runSyncer(syncer)
+	return nil
+}
+
+// rpcSyncCallbacks returns the SPV sync notification callbacks adapted to the
+// RPC syncer. The RPC node counts as the only connected peer once the syncer
+// reports progress.
+func (asset *Asset) rpcSyncCallbacks() *chain.Callbacks {
+	spvCallbacks := asset.spvSyncNotificationCallbacks()
+	connected := func() {
+		if asset.ConnectedPeers() != 1 {
+			asset.handlePeerCountUpdate(1)
+		}
+	}
+
+	return &chain.Callbacks{
+		Synced: func(synced bool) {
+			connected()
+			spvCallbacks.Synced(synced)
+		},
+		FetchMissingCFiltersStarted: func() {
+			connected()
+			spvCallbacks.FetchMissingCFiltersStarted()
+		},
+		FetchMissingCFiltersProgress: spvCallbacks.FetchMissingCFiltersProgress,
+		FetchMissingCFiltersFinished: spvCallbacks.FetchMissingCFiltersFinished,
+		FetchHeadersStarted: func() {
+			connected()
+			spvCallbacks.FetchHeadersStarted()
+		},
+		FetchHeadersProgress:      spvCallbacks.FetchHeadersProgress,
+		FetchHeadersFinished:      spvCallbacks.FetchHeadersFinished,
+		DiscoverAddressesStarted:  spvCallbacks.DiscoverAddressesStarted,
+		DiscoverAddressesFinished: spvCallbacks.DiscoverAddressesFinished,
+		RescanStarted:             spvCallbacks.RescanStarted,
+		RescanProgress:            spvCallbacks.RescanProgress,
+		RescanFinished:            spvCallbacks.RescanFinished,
+	}
+}
+
+// SetNetworkMode saves the network mode of the wallet and restarts the sync
+// with the new backend if the wallet is connected.
+func (asset *Asset) SetNetworkMode(mode string, node *sharedW.RPCNode) error {
+	if mode == sharedW.NetworkModeElectrum {
+		return errors.E(utils.ErrInvalid, "DCR wallets cannot sync with an Electrum server")
+	}
+	if node != nil && node.Bitcoind() {
+		return errors.E(utils.ErrInvalid, "DCR wallets sync with a dcrd node only")
+	}
+	if err := asset.SaveNetworkMode(mode, node); err != nil {
+		return err
+	}
+	if !asset.IsConnectedToNetwork() {
+		return nil
+	}
+
+	go func() {
+		if err := asset.RestartSpvSync(); err != nil {
+			log.Errorf("[%d] Restarting the sync failed: %v", asset.ID, err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,225 @@
+package dcr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	w "decred.org/dcrwallet/v4/wallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/trezor"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+)
+
+// softwareSigner signs the transactions of the asset with the keys of its
+// wallet.
+type softwareSigner struct {
+	asset *Asset
+}
+
+var _ sharedW.Signer = (*softwareSigner)(nil)
+
+// SignTx unlocks the wallet with the private passphrase and signs every input
+// of the transaction.
+func (s *softwareSigner) SignTx(ctx context.Context, req *sharedW.SignRequest, passphrase string) ([]byte, error) {
+	var msgTx wire.MsgTx
+	if err := msgTx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return nil, err
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	err := s.asset.Internal().DCR.Unlock(ctx, []byte(passphrase), lock)
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	var additionalPkScripts map[wire.OutPoint][]byte
+
+	_, err = s.asset.Internal().DCR.SignTransaction(ctx, &msgTx, txscript.SigHashAll, additionalPkScripts, nil, nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(msgTx.SerializeSize())
+	if err := msgTx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// signer returns the hardware signer of a watch-only wallet, or the software
+// signer of the wallet.
+func (asset *Asset) signer() (sharedW.Signer, error) {
+	cfg := asset.HardwareSigner()
+	if cfg == nil {
+		return &softwareSigner{asset: asset}, nil
+	}
+	coin, err := trezor.CoinName(asset.GetAssetType(), asset.NetType())
+	if err != nil {
+		return nil, err
+	}
+	return trezor.NewSigner(cfg, coin), nil
+}
+
+// signRequest describes the transaction to the signers. The spent
+// transactions are looked up in the wallet. The paths of the keys follow
+// BIP0044 with the SLIP0044 coin type the devices derive Decred keys with.
+func (asset *Asset) signRequest(ctx context.Context, msgTx *wire.MsgTx) (*sharedW.SignRequest, error) {
+	var buf bytes.Buffer
+	buf.Grow(msgTx.SerializeSize())
+	if err := msgTx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+
+	req := &sharedW.SignRequest{
+		UnsignedTx: buf.Bytes(),
+		Version:    uint32(msgTx.Version),
+		LockTime:   msgTx.LockTime,
+		Expiry:     msgTx.Expiry,
+		PrevTxs:    make(map[string]*sharedW.PrevTx),
+	}
+
+	var prevHashes []*chainhash.Hash
+	seen := make(map[chainhash.Hash]bool)
+	for _, txIn := range msgTx.TxIn {
+		hash := txIn.PreviousOutPoint.Hash
+		if !seen[hash] {
+			seen[hash] = true
+			prevHashes = append(prevHashes, &hash)
+		}
+	}
+	prevTxs, notFound, err := asset.Internal().DCR.GetTransactionsByHashes(ctx, prevHashes)
+	if err != nil {
+		return nil, err
+	}
+	if len(notFound) > 0 {
+		return nil, fmt.Errorf("spent transaction %s not found", notFound[0].Hash)
+	}
+	prevTxsByHash := make(map[chainhash.Hash]*wire.MsgTx, len(prevTxs))
+	for _, prevTx := range prevTxs {
+		prevTxsByHash[prevTx.TxHash()] = prevTx
+		req.PrevTxs[prevTx.TxHash().String()] = signRequestPrevTx(prevTx)
+	}
+
+	for _, txIn := range msgTx.TxIn {
+		outPoint := txIn.PreviousOutPoint
+		prevTx := prevTxsByHash[outPoint.Hash]
+		if prevTx == nil || int(outPoint.Index) >= len(prevTx.TxOut) {
+			return nil, fmt.Errorf("spent output %s not found", outPoint)
+		}
+		prevOut := prevTx.TxOut[outPoint.Index]
+		req.Inputs = append(req.Inputs, &sharedW.SignInput{
+			PrevHash:   outPoint.Hash.String(),
+			PrevIndex:  outPoint.Index,
+			Tree:       outPoint.Tree,
+			Sequence:   txIn.Sequence,
+			Amount:     prevOut.Value,
+			PkScript:   prevOut.PkScript,
+			Path:       asset.keyPath(ctx, prevOut),
+			ScriptType: sharedW.SpendAddress,
+		})
+	}
+
+	for _, txOut := range msgTx.TxOut {
+		var address string
+		_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+		if len(addrs) == 1 {
+			address = addrs[0].String()
+		}
+		req.Outputs = append(req.Outputs, &sharedW.SignOutput{
+			Address:  address,
+			Amount:   txOut.Value,
+			PkScript: txOut.PkScript,
+		})
+	}
+	return req, nil
+}
+
+// keyPath returns the BIP0044 path of the key of the wallet paid by the
+// output, or nil if the key was not derived by the wallet.
+func (asset *Asset) keyPath(ctx context.Context, txOut *wire.TxOut) []uint32 {
+	_, addrs := stdscript.ExtractAddrs(txOut.Version, txOut.PkScript, asset.chainParams)
+	if len(addrs) != 1 {
+		return nil
+	}
+	knownAddr, err := asset.Internal().DCR.KnownAddress(ctx, addrs[0])
+	if err != nil {
+		return nil
+	}
+	bip44Addr, ok := knownAddr.(w.BIP0044Address)
+	if !ok {
+		return nil
+	}
+	account, branch, child := bip44Addr.Path()
+	return []uint32{
+		44 + hdkeychain.HardenedKeyStart,
+		asset.chainParams.SLIP0044CoinType + hdkeychain.HardenedKeyStart,
+		account + hdkeychain.HardenedKeyStart,
+		branch,
+		child,
+	}
+}
+
+// signRequestPrevTx describes a transaction spent by a signed transaction.
+func signRequestPrevTx(tx *wire.MsgTx) *sharedW.PrevTx {
+	prevTx := &sharedW.PrevTx{
+		Version:  uint32(tx.Version),
+		LockTime: tx.LockTime,
+		Expiry:   tx.Expiry,
+	}
+	for _, txIn := range tx.TxIn {
+		prevTx.Inputs = append(prevTx.Inputs, &sharedW.PrevTxInput{
+			PrevHash:  txIn.PreviousOutPoint.Hash.String(),
+			PrevIndex: txIn.PreviousOutPoint.Index,
+			Tree:      txIn.PreviousOutPoint.Tree,
+			Sequence:  txIn.Sequence,
+			ScriptSig: txIn.SignatureScript,
+		})
+	}
+	for _, txOut := range tx.TxOut {
+		prevTx.Outputs = append(prevTx.Outputs, &sharedW.PrevTxOutput{
+			Amount:        txOut.Value,
+			PkScript:      txOut.PkScript,
+			ScriptVersion: txOut.Version,
+		})
+	}
+	return prevTx
+}
+
+// verifySignedTx checks that the signed transaction spends and pays the same
+// as the unsigned one and proves that every input has been validly signed by
+// executing its script pair.
+func verifySignedTx(unsignedTx, msgTx *wire.MsgTx, req *sharedW.SignRequest) error {
+	if msgTx.TxHash() != unsignedTx.TxHash() {
+		return errors.E(errors.Invalid, "the signed transaction does not match the unsigned transaction")
+	}
+
+	flags := txscript.ScriptDiscourageUpgradableNops | txscript.ScriptVerifyCleanStack |
+		txscript.ScriptVerifyCheckLockTimeVerify | txscript.ScriptVerifyCheckSequenceVerify
+	for index, input := range req.Inputs {
+		vm, err := txscript.NewEngine(input.PkScript, msgTx, index, flags, 0, nil)
+		if err != nil {
+			log.Errorf("creating validation engine failed: %v", err)
+			return err
+		}
+		if err := vm.Execute(); err != nil {
+			log.Errorf("executing the validation engine failed: %v", err)
+			return err
+		}
+	}
+	return nil
+}
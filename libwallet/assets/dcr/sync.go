@@ -16,6 +16,8 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/addrmgr/v2"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
 )
 
 // reading/writing of properties of this struct are protected by mutex.x
@@ -78,9 +80,17 @@ func (s *SyncData) generalSyncProgress() *sharedW.GeneralSyncProgress {
 	return s.activeSyncData.genSyncProgress
 }
 
+// networkSyncer syncs the wallet with the network. It is a *spv.Syncer, or a
+// *chain.Syncer in the RPC network mode.
+type networkSyncer interface {
+	Run(ctx context.Context) error
+	Synced(ctx context.Context) (bool, int32)
+	Blocks(ctx context.Context, blockHashes []*chainhash.Hash) ([]*wire.MsgBlock, error)
+}
+
 // reading/writing of properties of this struct are protected by syncData.mu.
 type activeSyncData struct {
-	syncer    *spv.Syncer
+	syncer    networkSyncer
 	syncStage utils.SyncStage
 
 	addressDiscoveryCompletedOrCanceled chan bool
@@ -196,6 +206,10 @@ func (asset *Asset) SpvSync() error {
 		return errors.New(utils.ErrSyncAlreadyInProgress)
 	}
 
+	if asset.NetworkMode() == sharedW.NetworkModeRPC {
+		return asset.rpcSync()
+	}
+
 	peerAddresses := asset.ReadStringConfigValueForKey(sharedW.SpvPersistentPeerAddressesConfigKey, "")
 	validPeerAddresses, errs := sharedW.ParseWalletPeers(peerAddresses, asset.chainParams.DefaultPort)
 	for _, err := range errs { // Log errors if any
@@ -210,12 +224,10 @@ func (asset *Asset) SpvSync() error {
 	// to calculate sync estimates only during sync
 	asset.initActiveSyncData()
 
-	asset.waitingForHeaders = true
-	asset.syncing = true
-
 	addr := &net.TCPAddr{IP: net.ParseIP("::1"), Port: 0}
 	addrManager := addrmgr.New(asset.DataDir(), net.LookupIP) // TODO: be mindful of tor
 	lp := p2p.NewLocalPeer(asset.chainParams, addr, addrManager)
+	lp.SetDialFunc(asset.dialPeer)
 
 	// Set the node to only connect to remote peers whose advertised best block
 	// height is greater than the currently synced.
@@ -225,8 +237,20 @@ func (asset *Asset) SpvSync() error {
 	syncer.SetNotifications(asset.spvSyncNotificationCallbacks())
 	if len(validPeerAddresses) > 0 {
 		syncer.SetPersistentPeers(validPeerAddresses)
+	} else {
+		asset.preferHealthyPeers(addrManager)
 	}
 
+	asset.runSyncer(syncer)
+	return nil
+}
+
+// runSyncer runs the syncer until the sync is canceled or fails. The active
+// sync data must be initialized.
+func (asset *Asset) runSyncer(syncer networkSyncer) {
+	asset.waitingForHeaders = true
+	asset.syncing = true
+
 	ctx, cancel := asset.ShutdownContextWithCancel()
 
 	asset.syncData.mu.Lock()
@@ -250,9 +274,9 @@ func (asset *Asset) SpvSync() error {
 		syncError := syncer.Run(ctx)
 		// sync has ended or errored
 		if syncError != nil {
-			if syncError == context.DeadlineExceeded {
-				asset.notifySyncError(errors.Errorf("SPV synchronization deadline exceeded: %v", syncError))
-			} else if syncError == context.Canceled {
+			if errors.Is(syncError, context.DeadlineExceeded) {
+				asset.notifySyncError(errors.Errorf("synchronization deadline exceeded: %v", syncError))
+			} else if errors.Is(syncError, context.Canceled) {
 				asset.notifySyncCanceled()
 			} else {
 				asset.notifySyncError(syncError)
@@ -264,7 +288,6 @@ func (asset *Asset) SpvSync() error {
 		// reset sync variables
 		asset.resetSyncData()
 	}()
-	return nil
 }
 
 func (asset *Asset) RestartSpvSync() error {
@@ -380,7 +403,11 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 		return nil, errors.New(utils.ErrNotConnected)
 	}
 
-	syncer := asset.syncData.activeSyncData.syncer
+	syncer, ok := asset.syncData.activeSyncData.syncer.(*spv.Syncer)
+	if !ok {
+		// The RPC node is the only peer of the wallet.
+		return []sharedW.PeerInfo{}, nil
+	}
 
 	infos := make([]sharedW.PeerInfo, 0, len(syncer.GetRemotePeers()))
 	for _, rp := range syncer.GetRemotePeers() {
@@ -393,6 +420,7 @@ func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
 			SubVer:         rp.UA(),
 			StartingHeight: int64(rp.InitialHeight()),
 			BanScore:       int32(rp.BanScore()),
+			LastBlock:      rp.LastHeight(),
 		}
 
 		infos = append(infos, info)
@@ -460,6 +488,13 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 }
 
 func (asset *Asset) DiscoverUsage(gapLimit uint32) error {
+	return asset.discoverUsage(0, gapLimit, nil)
+}
+
+// discoverUsage discovers the addresses used from the block at startHeight
+// within the gap limit, and the accounts used if the wallet is unlocked. done
+// is called once the discovery succeeds.
+func (asset *Asset) discoverUsage(startHeight int32, gapLimit uint32, done func()) error {
 	if !asset.WalletOpened() {
 		return utils.ErrDCRNotInitialized
 	}
@@ -479,16 +514,24 @@ func (asset *Asset) DiscoverUsage(gapLimit uint32) error {
 		return errors.New(utils.ErrNotSynced)
 	}
 
-	// rescan from genesis block. Todo: Allow users to supply rescanpoint.
-	startBlock := asset.Internal().DCR.ChainParams().GenesisHash
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	startBlock, err := asset.Internal().DCR.BlockInfo(ctx, w.NewBlockIdentifierFromHeight(startHeight))
+	cancel()
+	if err != nil {
+		return err
+	}
 
 	go func() {
+		var err error
 		defer func() {
 			asset.syncData.mu.Lock()
 			asset.syncData.syncing = false
 			asset.syncData.cancelSync = nil
 			asset.syncData.mu.Unlock()
 			asset.discoverAddressesFinished()
+			if err == nil && done != nil {
+				done()
+			}
 		}()
 
 		ctx, cancel := asset.ShutdownContextWithCancel()
@@ -500,7 +543,7 @@ func (asset *Asset) DiscoverUsage(gapLimit uint32) error {
 
 		asset.discoverAddressesStarted()
 
-		err := asset.Internal().DCR.DiscoverActiveAddresses(ctx, netBackend, &startBlock, !asset.Internal().DCR.Locked(), gapLimit)
+		err = asset.Internal().DCR.DiscoverActiveAddresses(ctx, netBackend, &startBlock.Hash, !asset.Internal().DCR.Locked(), gapLimit)
 		if err != nil {
 			log.Error(err)
 		}
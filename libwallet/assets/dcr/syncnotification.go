@@ -675,6 +675,10 @@ func (asset *Asset) syncedWallet(synced bool) {
 	asset.syncData.synced = true
 	asset.syncData.mu.Unlock()
 
+	if synced {
+		asset.updatePeerScores()
+	}
+
 	indexTransactions()
 }
 
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"time"
 
 	"decred.org/dcrwallet/v4/errors"
 	w "decred.org/dcrwallet/v4/wallet"
@@ -17,7 +16,6 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/decred/dcrd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrutil/v4"
-	"github.com/decred/dcrd/txscript/v4"
 	"github.com/decred/dcrd/txscript/v4/stdaddr"
 	"github.com/decred/dcrd/wire"
 )
@@ -31,6 +29,10 @@ type TxAuthor struct {
 	utxos          []*sharedW.UnspentOutput
 	unsignedTx     *txauthor.AuthoredTx
 	needsConstruct bool
+
+	// airgapReq is the request of the transaction last exported to be
+	// signed by an offline wallet.
+	airgapReq *sharedW.SignRequest
 }
 
 func (asset *Asset) NewUnsignedTx(sourceAccountNumber int32, utxos []*sharedW.UnspentOutput) error {
@@ -219,76 +221,75 @@ func (asset *Asset) Broadcast(privatePassphrase, transactionLabel string) (strin
 		return "", err
 	}
 
-	unsignedTx, err := asset.unsignedTransaction()
+	unsignedTx, err := asset.finalUnsignedTx()
 	if err != nil {
-		return "", utils.TranslateError(err)
+		return "", err
 	}
 
-	if unsignedTx.ChangeIndex >= 0 {
-		unsignedTx.RandomizeChangePosition()
-	}
+	ctx, cancel := asset.ShutdownContextWithCancel()
+	defer cancel()
 
-	var txBuf bytes.Buffer
-	txBuf.Grow(unsignedTx.Tx.SerializeSize())
-	err = unsignedTx.Tx.Serialize(&txBuf)
+	req, err := asset.signRequest(ctx, unsignedTx)
 	if err != nil {
 		log.Error(err)
 		return "", err
 	}
-
-	var msgTx wire.MsgTx
-	err = msgTx.Deserialize(bytes.NewReader(txBuf.Bytes()))
+	signer, err := asset.signer()
 	if err != nil {
-		log.Error(err)
-		// Bytes do not represent a valid raw transaction
 		return "", err
 	}
-
-	lock := make(chan time.Time, 1)
-	defer func() {
-		lock <- time.Time{}
-	}()
-
-	ctx, _ := asset.ShutdownContextWithCancel()
-	err = asset.Internal().DCR.Unlock(ctx, []byte(privatePassphrase), lock)
+	signedTx, err := signer.SignTx(ctx, req, privatePassphrase)
 	if err != nil {
-		log.Error(err)
-		return "", errors.New(utils.ErrInvalidPassphrase)
+		return "", err
 	}
 
-	var additionalPkScripts map[wire.OutPoint][]byte
-
-	invalidSigs, err := asset.Internal().DCR.SignTransaction(ctx, &msgTx, txscript.SigHashAll, additionalPkScripts, nil, nil)
+	// Test decode the tx to check its validity after being signed.
+	var msgTx wire.MsgTx
+	err = msgTx.Deserialize(bytes.NewReader(signedTx))
 	if err != nil {
+		// Invalid tx
 		log.Error(err)
 		return "", err
 	}
+	if err := verifySignedTx(unsignedTx, &msgTx, req); err != nil {
+		return "", err
+	}
 
-	invalidInputIndexes := make([]uint32, len(invalidSigs))
-	for i, e := range invalidSigs {
-		invalidInputIndexes[i] = e.InputIndex
+	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, &msgTx, n)
+	if err != nil {
+		return "", utils.TranslateError(err)
 	}
+	return txHash.String(), asset.updateTxLabel(txHash, transactionLabel)
+}
 
-	var serializedTransaction bytes.Buffer
-	serializedTransaction.Grow(msgTx.SerializeSize())
-	err = msgTx.Serialize(&serializedTransaction)
+// finalUnsignedTx returns a copy of the transaction to sign, with its change
+// output at a random position.
+func (asset *Asset) finalUnsignedTx() (*wire.MsgTx, error) {
+	unsignedTx, err := asset.unsignedTransaction()
 	if err != nil {
-		log.Error(err)
-		return "", err
+		return nil, utils.TranslateError(err)
 	}
 
-	err = msgTx.Deserialize(bytes.NewReader(serializedTransaction.Bytes()))
+	if unsignedTx.ChangeIndex >= 0 {
+		unsignedTx.RandomizeChangePosition()
+	}
+
+	var txBuf bytes.Buffer
+	txBuf.Grow(unsignedTx.Tx.SerializeSize())
+	err = unsignedTx.Tx.Serialize(&txBuf)
 	if err != nil {
-		// Invalid tx
 		log.Error(err)
-		return "", err
+		return nil, err
 	}
 
-	txHash, err := asset.Internal().DCR.PublishTransaction(ctx, &msgTx, n)
+	msgTx := new(wire.MsgTx)
+	err = msgTx.Deserialize(bytes.NewReader(txBuf.Bytes()))
 	if err != nil {
-		return "", utils.TranslateError(err)
+		log.Error(err)
+		// Bytes do not represent a valid raw transaction
+		return nil, err
 	}
-	return txHash.String(), asset.updateTxLabel(txHash, transactionLabel)
+	return msgTx, nil
 }
 
 // updateTxLabel saves the tx label in the local instance.
@@ -516,6 +517,11 @@ func (asset *Asset) validateSendAmount(sendMax bool, atomAmount int64) error {
 	return nil
 }
 
+// IsSaneOutputValue returns true if amount is a valid output value.
+func (asset *Asset) IsSaneOutputValue(amount int64) bool {
+	return saneOutputValue(Amount(amount))
+}
+
 func saneOutputValue(amount Amount) bool {
 	return amount >= 0 && amount <= dcrutil.MaxAmount
 }
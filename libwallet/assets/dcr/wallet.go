@@ -42,6 +42,9 @@ type Asset struct {
 	txAndBlockNotificationListeners   map[string]*sharedW.TxAndBlockNotificationListener
 	blocksRescanProgressListener      *sharedW.BlocksRescanProgressListener
 
+	// multisigMu serializes the changes to the multisig accounts.
+	multisigMu sync.Mutex
+
 	// dbMutex should be held when db transactions would circle back around
 	// and hold the mu lock to prevent a freeze.
 	dbMutex *sync.Mutex
@@ -210,6 +213,41 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 	return dcrWallet, nil
 }
 
+// RecreateWallet restores the wallet database of the existing wallet from the
+// seed mnemonic, keeping the ID and config of the wallet, and returns the
+// restored wallet.
+func RecreateWallet(w *sharedW.Wallet, seedMnemonic, privatePassphrase string, wordSeedType sharedW.WordSeedType,
+	params *sharedW.InitParams,
+) (sharedW.Asset, error) {
+	chainParams, err := utils.DCRChainParams(params.NetType)
+	if err != nil {
+		return nil, err
+	}
+
+	var dbMutex sync.Mutex
+	ldr := initWalletLoader(chainParams, params.RootDir, params.DbDriver, &dbMutex)
+	err = w.RecreateWallet(seedMnemonic, privatePassphrase, wordSeedType, ldr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	dcrWallet := &Asset{
+		Wallet:      w,
+		chainParams: chainParams,
+		syncData: &SyncData{
+			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
+		},
+		vspClients:                        make(map[string]*vsp.Client),
+		txAndBlockNotificationListeners:   make(map[string]*sharedW.TxAndBlockNotificationListener),
+		accountMixerNotificationListeners: make(map[string]*AccountMixerNotificationListener),
+		dbMutex:                           &dbMutex,
+	}
+
+	dcrWallet.SetNetworkCancelCallback(dcrWallet.SafelyCancelSync)
+
+	return dcrWallet, nil
+}
+
 // LoadExisting accepts the stored shared wallet information and the init parameters.
 // It validates the network type passed by fetching the chain parameters
 // associated with it for the DCR asset. It then generates the DCR loader interface
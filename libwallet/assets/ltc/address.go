@@ -5,7 +5,9 @@ import (
 
 	"decred.org/dcrwallet/v4/errors"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/ltcsuite/ltcd/chaincfg"
 	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
 )
 
 // AddressInfo holds information about an address.
@@ -25,6 +27,16 @@ func (asset *Asset) IsAddressValid(address string) bool {
 	return err == nil
 }
 
+// AddressScript returns the output script paying to the address on the
+// network.
+func AddressScript(address string, chainParams *chaincfg.Params) ([]byte, error) {
+	addr, err := ltcutil.DecodeAddress(address, chainParams)
+	if err != nil {
+		return nil, errors.New(utils.ErrInvalidAddress)
+	}
+	return txscript.PayToAddrScript(addr)
+}
+
 // HaveAddress checks if the provided address belongs to the wallet.
 func (asset *Asset) HaveAddress(address string) bool {
 	if !asset.WalletOpened() {
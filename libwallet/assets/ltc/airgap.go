@@ -0,0 +1,255 @@
+package ltc
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/waddrmgr"
+	"github.com/dcrlabs/ltcwallet/walletdb"
+	"github.com/ltcsuite/ltcd/ltcutil/hdkeychain"
+	"github.com/ltcsuite/ltcd/ltcutil/psbt"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+// ExportUnsignedTx returns the PSBT of the transaction being authored, to be
+// signed by the offline wallet holding the seed of the watch-only wallet. The
+// inputs carry the outputs they spend and the paths of their keys.
+func (asset *Asset) ExportUnsignedTx() (*sharedW.AirgapTx, error) {
+	if !asset.WalletOpened() {
+		return nil, utils.ErrLTCNotInitialized
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	unsignedTx, err := asset.finalUnsignedTx()
+	if err != nil {
+		return nil, err
+	}
+	req, err := asset.signRequest(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(unsignedTx.Copy())
+	if err != nil {
+		return nil, err
+	}
+	for i, input := range req.Inputs {
+		packet.Inputs[i].WitnessUtxo = wire.NewTxOut(input.Amount, input.PkScript)
+		packet.Inputs[i].SighashType = txscript.SigHashAll
+		if len(input.PubKey) > 0 {
+			packet.Inputs[i].Bip32Derivation = []*psbt.Bip32Derivation{{
+				PubKey:    input.PubKey,
+				Bip32Path: input.Path,
+			}}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := packet.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	asset.TxAuthoredInfo.airgapReq = req
+	return &sharedW.AirgapTx{Type: sharedW.AirgapTxPSBT, Data: buf.Bytes()}, nil
+}
+
+// decodeAirgapPSBT returns the PSBT of an air-gapped transaction.
+func decodeAirgapPSBT(tx *sharedW.AirgapTx) (*psbt.Packet, error) {
+	if tx.Type != sharedW.AirgapTxPSBT {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("expected a %s transaction, got %s", sharedW.AirgapTxPSBT, tx.Type))
+	}
+	packet, err := psbt.NewFromRawBytes(bytes.NewReader(tx.Data), false)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Errorf("invalid PSBT: %v", err))
+	}
+	for i, input := range packet.Inputs {
+		if input.WitnessUtxo == nil {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("input %d has no witness UTXO", i))
+		}
+	}
+	return packet, nil
+}
+
+// AirgapTxSummary returns the outputs and fee of the PSBT.
+func (asset *Asset) AirgapTxSummary(tx *sharedW.AirgapTx) (*sharedW.AirgapTxSummary, error) {
+	packet, err := decodeAirgapPSBT(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &sharedW.AirgapTxSummary{Signed: packet.IsComplete()}
+	for _, input := range packet.Inputs {
+		summary.Fee += input.WitnessUtxo.Value
+	}
+	for _, txOut := range packet.UnsignedTx.TxOut {
+		var address string
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, asset.chainParams)
+		if err == nil && len(addrs) == 1 {
+			address = addrs[0].EncodeAddress()
+		}
+		summary.Outputs = append(summary.Outputs, &sharedW.SignOutput{
+			Address:  address,
+			Amount:   txOut.Value,
+			PkScript: txOut.PkScript,
+		})
+		summary.Fee -= txOut.Value
+	}
+	return summary, nil
+}
+
+// SignAirgapTx signs the PSBT exported by the watch-only wallet of the seed of
+// the wallet. The keys of the inputs are derived from their paths, which the
+// wallet may not have derived yet as it does not sync while offline. The
+// returned PSBT is finalized.
+func (asset *Asset) SignAirgapTx(tx *sharedW.AirgapTx, passphrase string) (*sharedW.AirgapTx, error) {
+	if asset.IsWatchingOnlyWallet() {
+		return nil, errors.E(errors.Invalid, "watch-only wallets can not sign transactions")
+	}
+	packet, err := decodeAirgapPSBT(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+	if err := asset.Internal().LTC.Unlock([]byte(passphrase), lock); err != nil {
+		log.Errorf("unlocking the wallet failed: %v", err)
+		return nil, errors.New(utils.ErrInvalidPassphrase)
+	}
+
+	scopedMgr, err := asset.Internal().LTC.Manager.FetchScopedKeyManager(GetScope())
+	if err != nil {
+		return nil, err
+	}
+
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range packet.Inputs {
+		prevOuts.AddPrevOut(packet.UnsignedTx.TxIn[i].PreviousOutPoint, input.WitnessUtxo)
+	}
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, prevOuts)
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	err = walletdb.View(asset.Internal().LTC.Database(), func(dbtx walletdb.ReadTx) error {
+		ns := dbtx.ReadBucket(wAddrMgrBkt)
+		for i, input := range packet.Inputs {
+			if !txscript.IsPayToWitnessPubKeyHash(input.WitnessUtxo.PkScript) {
+				return errors.E(errors.Invalid, fmt.Sprintf("input %d does not spend a P2WPKH output", i))
+			}
+			if len(input.Bip32Derivation) != 1 {
+				return errors.E(errors.Invalid, fmt.Sprintf("input %d has no key path", i))
+			}
+			derivation := input.Bip32Derivation[0]
+			path := derivation.Bip32Path
+			if len(path) != 5 || path[2] < hdkeychain.HardenedKeyStart {
+				return errors.E(errors.Invalid, fmt.Sprintf("input %d has an invalid key path", i))
+			}
+
+			// The accounts of the watch-only wallet are the accounts of
+			// the same number of the wallet.
+			account := path[2] - hdkeychain.HardenedKeyStart
+			addr, err := scopedMgr.DeriveFromKeyPath(ns, waddrmgr.DerivationPath{
+				InternalAccount: account,
+				Account:         path[2],
+				Branch:          path[3],
+				Index:           path[4],
+			})
+			if err != nil {
+				return err
+			}
+			pubKeyAddr, ok := addr.(waddrmgr.ManagedPubKeyAddress)
+			if !ok {
+				return errors.E(errors.Invalid, fmt.Sprintf("input %d has an invalid key path", i))
+			}
+			pubKey := pubKeyAddr.PubKey().SerializeCompressed()
+			pkScript, err := txscript.PayToAddrScript(addr.Address())
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(pubKey, derivation.PubKey) || !bytes.Equal(pkScript, input.WitnessUtxo.PkScript) {
+				return errors.E(errors.Invalid, fmt.Sprintf("input %d is not spent by this wallet", i))
+			}
+
+			privKey, err := pubKeyAddr.PrivKey()
+			if err != nil {
+				return err
+			}
+			sig, err := txscript.RawTxInWitnessSignature(packet.UnsignedTx, sigHashes, i,
+				input.WitnessUtxo.Value, input.WitnessUtxo.PkScript, txscript.SigHashAll, privKey)
+			if err != nil {
+				return err
+			}
+			if _, err := updater.Sign(i, sig, pubKey, nil, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Errorf("signing the PSBT failed: %v", err)
+		return nil, err
+	}
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := packet.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	return &sharedW.AirgapTx{Type: sharedW.AirgapTxPSBT, Data: buf.Bytes()}, nil
+}
+
+// PublishAirgapTx publishes the PSBT signed by the offline wallet after
+// checking that it is the transaction last exported, validly signed.
+func (asset *Asset) PublishAirgapTx(tx *sharedW.AirgapTx, label string) (string, error) {
+	if !asset.WalletOpened() {
+		return "", utils.ErrLTCNotInitialized
+	}
+
+	asset.TxAuthoredInfo.mu.Lock()
+	defer asset.TxAuthoredInfo.mu.Unlock()
+
+	req := asset.TxAuthoredInfo.airgapReq
+	if req == nil {
+		return "", errors.E(errors.Invalid, "no transaction was exported to be signed")
+	}
+	packet, err := decodeAirgapPSBT(tx)
+	if err != nil {
+		return "", err
+	}
+	if !packet.IsComplete() {
+		return "", errors.E(errors.Invalid, "the transaction is not signed")
+	}
+	msgTx, err := psbt.Extract(packet)
+	if err != nil {
+		return "", err
+	}
+
+	unsignedTx := new(wire.MsgTx)
+	if err := unsignedTx.Deserialize(bytes.NewReader(req.UnsignedTx)); err != nil {
+		return "", err
+	}
+	if err := verifySignedTx(unsignedTx, msgTx, req); err != nil {
+		return "", err
+	}
+
+	err = asset.Internal().LTC.PublishTransaction(msgTx, label)
+	if err != nil {
+		return "", utils.TranslateError(err)
+	}
+	asset.TxAuthoredInfo.airgapReq = nil
+	txHash := msgTx.TxHash()
+	return txHash.String(), nil
+}
@@ -0,0 +1,756 @@
+package ltc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	btcchainhash "github.com/btcsuite/btcd/chaincfg/chainhash"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/chain"
+	"github.com/dcrlabs/ltcwallet/waddrmgr"
+	"github.com/dcrlabs/ltcwallet/wtxmgr"
+	"github.com/ltcsuite/ltcd/chaincfg"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/ltcutil"
+	"github.com/ltcsuite/ltcd/txscript"
+	"github.com/ltcsuite/ltcd/wire"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// electrumReconnectInterval is the time waited between the attempts to
+	// reconnect to the Electrum server.
+	electrumReconnectInterval = 10 * time.Second
+
+	// maxElectrumRequests is the number of concurrent requests made for the
+	// script hashes of the wallet.
+	maxElectrumRequests = 10
+)
+
+// electrumClient is the chain.Interface of the Electrum network mode. The
+// transactions of the wallet are found through the histories of the script
+// hashes of its addresses, the blocks themselves are never downloaded.
+type electrumClient struct {
+	server      *sharedW.ElectrumServer
+	chainParams *chaincfg.Params
+	chain       *electrum.Chain
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	client atomic.Pointer[electrum.Client]
+	ntfns  *electrum.Queue
+	wg     sync.WaitGroup
+
+	mu           sync.Mutex
+	notifyBlocks bool
+	// watched maps the script hashes of the addresses the wallet is
+	// notified of to the addresses.
+	watched map[string]ltcutil.Address
+	// subscribed holds the script hashes subscribed to on the current
+	// connection.
+	subscribed map[string]bool
+	// reported maps the transactions sent to the wallet to the height they
+	// were sent with, -1 for mempool transactions.
+	reported map[chainhash.Hash]int32
+	// histories caches the histories of script hashes. It is cleared when
+	// the best chain changes.
+	histories map[string][]*electrum.HistoryItem
+	txs       map[chainhash.Hash]*wire.MsgTx
+}
+
+// Compile time check that electrumClient is a chain backend of the wallet.
+var _ chain.Interface = (*electrumClient)(nil)
+
+// ElectrumChain returns the chain of the network the headers of Electrum
+// servers are verified with.
+func ElectrumChain(chainParams *chaincfg.Params) *electrum.Chain {
+	checkpoints := map[int32]btcchainhash.Hash{0: btcchainhash.Hash(*chainParams.GenesisHash)}
+	for _, checkpoint := range chainParams.Checkpoints {
+		checkpoints[checkpoint.Height] = btcchainhash.Hash(*checkpoint.Hash)
+	}
+	return &electrum.Chain{
+		Checkpoints: checkpoints,
+		PowLimit:    chainParams.PowLimit,
+		PowHash:     scryptHash,
+	}
+}
+
+func newElectrumClient(server *sharedW.ElectrumServer, chainParams *chaincfg.Params) *electrumClient {
+	return &electrumClient{
+		server:      server,
+		chainParams: chainParams,
+		chain:       ElectrumChain(chainParams),
+		ntfns:       electrum.NewQueue(),
+		watched:     make(map[string]ltcutil.Address),
+		subscribed:  make(map[string]bool),
+		reported:    make(map[chainhash.Hash]int32),
+		histories:   make(map[string][]*electrum.HistoryItem),
+		txs:         make(map[chainhash.Hash]*wire.MsgTx),
+	}
+}
+
+// Start connects to the Electrum server.
+func (c *electrumClient) Start() error {
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	client, err := electrum.Connect(c.ctx, c.server.Address, c.server.Cert, c.chain)
+	if err != nil {
+		c.cancel()
+		return err
+	}
+	c.client.Store(client)
+
+	c.wg.Add(1)
+	go c.run(client)
+
+	c.ntfns.Push(chain.ClientConnected{})
+	return nil
+}
+
+// Stop disconnects from the Electrum server.
+func (c *electrumClient) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	if client := c.client.Load(); client != nil {
+		client.Close()
+	}
+	c.ntfns.Stop()
+}
+
+// WaitForShutdown blocks until the client has stopped.
+func (c *electrumClient) WaitForShutdown() {
+	c.wg.Wait()
+}
+
+// connected returns the connection to the server, or an error if it is lost.
+func (c *electrumClient) connected() (*electrum.Client, error) {
+	client := c.client.Load()
+	if client == nil || client.Err() != nil {
+		return nil, errors.E(utils.ErrNotConnected, "not connected to the Electrum server")
+	}
+	return client, nil
+}
+
+// run handles the notifications of the server and reconnects to it when the
+// connection is lost.
+func (c *electrumClient) run(client *electrum.Client) {
+	defer c.wg.Done()
+
+	for {
+		for n := range client.Notifications() {
+			switch n := n.(type) {
+			case *electrum.TipChanged:
+				c.tipChanged(n)
+			case *electrum.ScriptHashChanged:
+				c.mu.Lock()
+				delete(c.histories, n.ScriptHash)
+				c.mu.Unlock()
+				if err := c.scriptHashChanged(client, n.ScriptHash); err != nil {
+					log.Errorf("Updating the transactions of script hash %s failed: %v", n.ScriptHash, err)
+				}
+			}
+		}
+
+		if c.ctx.Err() != nil {
+			return
+		}
+		log.Warnf("Lost connection to the electrum server %s: %v", c.server.Address, client.Err())
+
+		client = c.reconnect()
+		if client == nil {
+			return
+		}
+	}
+}
+
+// reconnect connects to the server again and restores the subscriptions of
+// the previous connection. It returns nil if the client is stopped.
+func (c *electrumClient) reconnect() *electrum.Client {
+	for {
+		select {
+		case <-time.After(electrumReconnectInterval):
+		case <-c.ctx.Done():
+			return nil
+		}
+
+		client, err := electrum.Connect(c.ctx, c.server.Address, c.server.Cert, c.chain)
+		if err != nil {
+			log.Errorf("Reconnecting to the electrum server %s failed: %v", c.server.Address, err)
+			continue
+		}
+
+		c.mu.Lock()
+		c.subscribed = make(map[string]bool)
+		c.histories = make(map[string][]*electrum.HistoryItem)
+		addrs := make([]ltcutil.Address, 0, len(c.watched))
+		for _, addr := range c.watched {
+			addrs = append(addrs, addr)
+		}
+		c.mu.Unlock()
+
+		c.client.Store(client)
+		if err := c.watch(client, addrs); err != nil {
+			log.Errorf("Resubscribing to the wallet addresses failed: %v", err)
+			client.Close()
+			continue
+		}
+
+		// The wallet syncs with the server again on reconnection.
+		c.ntfns.Push(chain.ClientConnected{})
+		return client
+	}
+}
+
+// scryptHash returns the scrypt hash of the serialized header, the proof of
+// work of litecoin blocks.
+func scryptHash(raw []byte) btcchainhash.Hash {
+	header := new(wire.BlockHeader)
+	if err := header.Deserialize(bytes.NewReader(raw)); err != nil {
+		// Return a hash above any target, the header is rejected.
+		var hash btcchainhash.Hash
+		for i := range hash {
+			hash[i] = 0xff
+		}
+		return hash
+	}
+	return btcchainhash.Hash(header.PowHash())
+}
+
+// blockMeta returns the wallet's description of the block of the header.
+func blockMeta(header *electrum.Header) *wtxmgr.BlockMeta {
+	return &wtxmgr.BlockMeta{
+		Block: wtxmgr.Block{Hash: chainhash.Hash(header.Hash), Height: header.Height},
+		Time:  header.Timestamp(),
+	}
+}
+
+// tipChanged sends the blocks connected and disconnected by a change of the
+// best chain.
+func (c *electrumClient) tipChanged(change *electrum.TipChanged) {
+	c.mu.Lock()
+	c.histories = make(map[string][]*electrum.HistoryItem)
+	notifyBlocks := c.notifyBlocks
+	c.mu.Unlock()
+	if !notifyBlocks {
+		return
+	}
+
+	for _, header := range change.Disconnected {
+		c.ntfns.Push(chain.BlockDisconnected(*blockMeta(header)))
+	}
+	for _, header := range change.Connected {
+		c.ntfns.Push(chain.BlockConnected(*blockMeta(header)))
+	}
+}
+
+// scriptHashChanged sends the transactions of the script hash that are new
+// or whose block changed.
+func (c *electrumClient) scriptHashChanged(client *electrum.Client, scriptHash string) error {
+	history, err := client.History(c.ctx, scriptHash)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	changed := history[:0:0]
+	for _, item := range history {
+		hash, err := chainhash.NewHashFromStr(item.TxHash)
+		if err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		if height, ok := c.reported[*hash]; !ok || height != reportedHeight(item.Height) {
+			changed = append(changed, item)
+		}
+	}
+	c.mu.Unlock()
+
+	return c.sendTransactions(client, changed)
+}
+
+// reportedHeight returns the height a transaction of a history is reported
+// with, -1 for mempool transactions.
+func reportedHeight(historyHeight int32) int32 {
+	if historyHeight <= 0 {
+		return -1
+	}
+	return historyHeight
+}
+
+// sendTransactions sends the transactions of the history items to the wallet,
+// oldest first.
+func (c *electrumClient) sendTransactions(client *electrum.Client, items []*electrum.HistoryItem) error {
+	// Mempool transactions have non-positive heights and go last.
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Height <= 0 || items[j].Height <= 0 {
+			return items[j].Height <= 0 && items[i].Height > 0
+		}
+		return items[i].Height < items[j].Height
+	})
+
+	for _, item := range items {
+		hash, err := chainhash.NewHashFromStr(item.TxHash)
+		if err != nil {
+			return err
+		}
+		tx, header, err := c.transaction(client, hash, item.Height)
+		if err != nil {
+			return err
+		}
+
+		received := time.Now()
+		var block *wtxmgr.BlockMeta
+		if header != nil {
+			block = blockMeta(header)
+			received = block.Time
+		}
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(tx, received)
+		if err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		c.reported[*hash] = reportedHeight(item.Height)
+		c.mu.Unlock()
+		c.ntfns.Push(chain.RelevantTx{TxRecord: rec, Block: block})
+	}
+	return nil
+}
+
+// transaction returns the transaction and, if it is mined at the height, the
+// header of its block. The inclusion of mined transactions is verified.
+func (c *electrumClient) transaction(client *electrum.Client, hash *chainhash.Hash, height int32) (*wire.MsgTx, *electrum.Header, error) {
+	var header *electrum.Header
+	if height > 0 {
+		var err error
+		if header, err = client.Header(c.ctx, height); err != nil {
+			return nil, nil, err
+		}
+		if err := client.VerifyTransaction(c.ctx, btcchainhash.Hash(*hash), height); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	c.mu.Lock()
+	tx := c.txs[*hash]
+	c.mu.Unlock()
+	if tx != nil {
+		return tx, header, nil
+	}
+
+	raw, err := client.Transaction(c.ctx, hash.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	tx = new(wire.MsgTx)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, nil, err
+	}
+	if tx.TxHash() != *hash {
+		return nil, nil, fmt.Errorf("electrum server returned transaction %s for %s", tx.TxHash(), hash)
+	}
+
+	c.mu.Lock()
+	c.txs[*hash] = tx
+	c.mu.Unlock()
+	return tx, header, nil
+}
+
+// scriptHash returns the script hash of the address.
+func scriptHash(addr ltcutil.Address) (string, error) {
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return "", err
+	}
+	return electrum.ScriptHash(pkScript), nil
+}
+
+// forEachScriptHash calls fn for the script hashes concurrently.
+func forEachScriptHash(scriptHashes []string, fn func(scriptHash string) error) error {
+	var g errgroup.Group
+	g.SetLimit(maxElectrumRequests)
+	for _, sh := range scriptHashes {
+		sh := sh
+		g.Go(func() error { return fn(sh) })
+	}
+	return g.Wait()
+}
+
+// watch subscribes to the script hashes of the addresses.
+func (c *electrumClient) watch(client *electrum.Client, addrs []ltcutil.Address) error {
+	var scriptHashes []string
+	c.mu.Lock()
+	for _, addr := range addrs {
+		sh, err := scriptHash(addr)
+		if err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		c.watched[sh] = addr
+		if !c.subscribed[sh] {
+			c.subscribed[sh] = true
+			scriptHashes = append(scriptHashes, sh)
+		}
+	}
+	c.mu.Unlock()
+
+	return forEachScriptHash(scriptHashes, func(sh string) error {
+		_, err := client.Subscribe(c.ctx, sh)
+		return err
+	})
+}
+
+// history returns the history of the script hash, cached until the best chain
+// changes.
+func (c *electrumClient) history(client *electrum.Client, scriptHash string) ([]*electrum.HistoryItem, error) {
+	c.mu.Lock()
+	history, ok := c.histories[scriptHash]
+	c.mu.Unlock()
+	if ok {
+		return history, nil
+	}
+
+	history, err := client.History(c.ctx, scriptHash)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.histories[scriptHash] = history
+	c.mu.Unlock()
+	return history, nil
+}
+
+// GetBestBlock returns the hash and height of the best block of the server.
+func (c *electrumClient) GetBestBlock() (*chainhash.Hash, int32, error) {
+	client, err := c.connected()
+	if err != nil {
+		return nil, 0, err
+	}
+	tip := client.Tip()
+	hash := chainhash.Hash(tip.Hash)
+	return &hash, tip.Height, nil
+}
+
+// GetBlock is not supported, Electrum servers do not serve blocks.
+func (c *electrumClient) GetBlock(*chainhash.Hash) (*wire.MsgBlock, error) {
+	return nil, errors.E(utils.ErrUnavailable, "Electrum servers do not serve blocks")
+}
+
+// GetBlockHash returns the hash of the block of the best chain at the height.
+func (c *electrumClient) GetBlockHash(height int64) (*chainhash.Hash, error) {
+	client, err := c.connected()
+	if err != nil {
+		return nil, err
+	}
+	header, err := client.Header(c.ctx, int32(height))
+	if err != nil {
+		return nil, err
+	}
+	hash := chainhash.Hash(header.Hash)
+	return &hash, nil
+}
+
+// GetBlockHeader returns the header of the block of the best chain with the
+// hash.
+func (c *electrumClient) GetBlockHeader(hash *chainhash.Hash) (*wire.BlockHeader, error) {
+	header, err := c.header(hash)
+	if err != nil {
+		return nil, err
+	}
+	blockHeader := new(wire.BlockHeader)
+	if err := blockHeader.Deserialize(bytes.NewReader(header.Raw)); err != nil {
+		return nil, err
+	}
+	return blockHeader, nil
+}
+
+// header returns the header of the block of the best chain with the hash.
+func (c *electrumClient) header(hash *chainhash.Hash) (*electrum.Header, error) {
+	client, err := c.connected()
+	if err != nil {
+		return nil, err
+	}
+	return client.HeaderByHash(btcchainhash.Hash(*hash))
+}
+
+// IsCurrent returns true while the client is connected. Electrum servers only
+// serve their best chain once synced.
+func (c *electrumClient) IsCurrent() bool {
+	_, err := c.connected()
+	return err == nil
+}
+
+// FilterBlocks finds the first block of the request with transactions of the
+// addresses or outpoints of the request, using the histories of their script
+// hashes.
+func (c *electrumClient) FilterBlocks(req *chain.FilterBlocksRequest) (*chain.FilterBlocksResponse, error) {
+	client, err := c.connected()
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Blocks) == 0 {
+		return nil, nil
+	}
+
+	external := make(map[string]waddrmgr.ScopedIndex, len(req.ExternalAddrs))
+	internal := make(map[string]waddrmgr.ScopedIndex, len(req.InternalAddrs))
+	addrs := make(map[string]struct{})
+	for index, addr := range req.ExternalAddrs {
+		external[addr.EncodeAddress()] = index
+		addrs[addr.EncodeAddress()] = struct{}{}
+	}
+	for index, addr := range req.InternalAddrs {
+		internal[addr.EncodeAddress()] = index
+		addrs[addr.EncodeAddress()] = struct{}{}
+	}
+	scriptHashes := make([]string, 0, len(addrs))
+	addAddr := func(addr ltcutil.Address) error {
+		sh, err := scriptHash(addr)
+		if err == nil {
+			scriptHashes = append(scriptHashes, sh)
+		}
+		return err
+	}
+	for _, addr := range req.ExternalAddrs {
+		if err := addAddr(addr); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range req.InternalAddrs {
+		if err := addAddr(addr); err != nil {
+			return nil, err
+		}
+	}
+	for _, addr := range req.WatchedOutPoints {
+		if _, ok := addrs[addr.EncodeAddress()]; !ok {
+			addrs[addr.EncodeAddress()] = struct{}{}
+			if err := addAddr(addr); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Find the first block of the request with transactions of the script
+	// hashes.
+	blockIndex := make(map[int32]int, len(req.Blocks))
+	for i, block := range req.Blocks {
+		blockIndex[block.Height] = i
+	}
+	var mu sync.Mutex
+	first := -1
+	txHashes := make(map[int][]string)
+	err = forEachScriptHash(scriptHashes, func(sh string) error {
+		history, err := c.history(client, sh)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, item := range history {
+			if i, ok := blockIndex[item.Height]; ok {
+				txHashes[i] = append(txHashes[i], item.TxHash)
+				if first == -1 || i < first {
+					first = i
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if first == -1 {
+		return nil, nil
+	}
+
+	block := req.Blocks[first]
+	header, err := client.Header(c.ctx, block.Height)
+	if err != nil {
+		return nil, err
+	}
+	if chainhash.Hash(header.Hash) != block.Hash {
+		return nil, fmt.Errorf("block %d (%s) is not in the best chain of the electrum server", block.Height, block.Hash)
+	}
+
+	resp := &chain.FilterBlocksResponse{
+		BatchIndex:         uint32(first),
+		BlockMeta:          block,
+		FoundExternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundInternalAddrs: make(map[waddrmgr.KeyScope]map[uint32]struct{}),
+		FoundOutPoints:     make(map[wire.OutPoint]ltcutil.Address),
+	}
+	found := func(foundAddrs map[waddrmgr.KeyScope]map[uint32]struct{}, index waddrmgr.ScopedIndex) {
+		if foundAddrs[index.Scope] == nil {
+			foundAddrs[index.Scope] = make(map[uint32]struct{})
+		}
+		foundAddrs[index.Scope][index.Index] = struct{}{}
+	}
+
+	seen := make(map[string]bool)
+	for _, txHash := range txHashes[first] {
+		if seen[txHash] {
+			continue
+		}
+		seen[txHash] = true
+
+		hash, err := chainhash.NewHashFromStr(txHash)
+		if err != nil {
+			return nil, err
+		}
+		tx, _, err := c.transaction(client, hash, block.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, out := range tx.TxOut {
+			_, outAddrs, _, err := txscript.ExtractPkScriptAddrs(out.PkScript, c.chainParams)
+			if err != nil {
+				continue
+			}
+			for _, addr := range outAddrs {
+				index, isExternal := external[addr.EncodeAddress()]
+				if isExternal {
+					found(resp.FoundExternalAddrs, index)
+				}
+				index, isInternal := internal[addr.EncodeAddress()]
+				if isInternal {
+					found(resp.FoundInternalAddrs, index)
+				}
+				if isExternal || isInternal {
+					resp.FoundOutPoints[wire.OutPoint{Hash: *hash, Index: uint32(i)}] = addr
+				}
+			}
+		}
+		resp.RelevantTxns = append(resp.RelevantTxns, tx)
+	}
+	return resp, nil
+}
+
+// BlockStamp returns the best block of the server.
+func (c *electrumClient) BlockStamp() (*waddrmgr.BlockStamp, error) {
+	client, err := c.connected()
+	if err != nil {
+		return nil, err
+	}
+	tip := client.Tip()
+	return &waddrmgr.BlockStamp{Height: tip.Height, Hash: chainhash.Hash(tip.Hash), Timestamp: tip.Timestamp()}, nil
+}
+
+// SendRawTransaction broadcasts the transaction through the server.
+func (c *electrumClient) SendRawTransaction(tx *wire.MsgTx, _ bool) (*chainhash.Hash, error) {
+	client, err := c.connected()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	// The wallet maps the rejection reasons relayed by the server.
+	if _, err := client.Broadcast(c.ctx, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	hash := tx.TxHash()
+	return &hash, nil
+}
+
+// Rescan sends the transactions of the addresses, the outpoints and the
+// addresses already watched that are mined from the start block or are in the
+// mempool, then the RescanFinished notification. The addresses are watched
+// for new transactions afterwards.
+func (c *electrumClient) Rescan(startHash *chainhash.Hash, addrs []ltcutil.Address, outPoints map[wire.OutPoint]ltcutil.Address) error {
+	client, err := c.connected()
+	if err != nil {
+		return err
+	}
+
+	// The whole history is sent if the start block is not known, the
+	// wallet ignores the transactions it already has.
+	startHeight := int32(0)
+	if header, err := client.HeaderByHash(btcchainhash.Hash(*startHash)); err == nil {
+		startHeight = header.Height
+	}
+
+	for _, addr := range outPoints {
+		addrs = append(addrs, addr)
+	}
+	if err := c.watch(client, addrs); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	scriptHashes := make([]string, 0, len(c.watched))
+	for sh := range c.watched {
+		scriptHashes = append(scriptHashes, sh)
+	}
+	c.mu.Unlock()
+
+	var mu sync.Mutex
+	items := make(map[string]*electrum.HistoryItem)
+	err = forEachScriptHash(scriptHashes, func(sh string) error {
+		history, err := client.History(c.ctx, sh)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, item := range history {
+			if item.Height <= 0 || item.Height >= startHeight {
+				items[item.TxHash] = item
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	history := make([]*electrum.HistoryItem, 0, len(items))
+	for _, item := range items {
+		history = append(history, item)
+	}
+	if err := c.sendTransactions(client, history); err != nil {
+		return err
+	}
+
+	tip := client.Tip()
+	tipHash := chainhash.Hash(tip.Hash)
+	c.ntfns.Push(&chain.RescanFinished{Hash: &tipHash, Height: tip.Height, Time: tip.Timestamp()})
+	return nil
+}
+
+// NotifyReceived watches the addresses for new transactions.
+func (c *electrumClient) NotifyReceived(addrs []ltcutil.Address) error {
+	client, err := c.connected()
+	if err != nil {
+		return err
+	}
+	return c.watch(client, addrs)
+}
+
+// NotifyBlocks starts sending the blocks connected to and disconnected from
+// the best chain.
+func (c *electrumClient) NotifyBlocks() error {
+	c.mu.Lock()
+	c.notifyBlocks = true
+	c.mu.Unlock()
+	return nil
+}
+
+// Notifications returns the channel of the notifications to the wallet.
+func (c *electrumClient) Notifications() <-chan interface{} {
+	return c.ntfns.C()
+}
+
+// BackEnd returns the name of the backend.
+func (c *electrumClient) BackEnd() string {
+	return "electrum"
+}
@@ -0,0 +1,114 @@
+package ltc
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	neutrino "github.com/dcrlabs/ltcwallet/spv"
+)
+
+// chainService returns the neutrino chain service while the wallet syncs
+// with the P2P network, or nil.
+func (asset *Asset) chainService() *neutrino.ChainService {
+	if asset.NetworkMode() != sharedW.NetworkModeSPV || asset.cl == nil {
+		return nil
+	}
+	return asset.cl
+}
+
+// PeerInfoRaw returns the peers the wallet is connected to.
+func (asset *Asset) PeerInfoRaw() ([]sharedW.PeerInfo, error) {
+	if !asset.IsConnectedToNetwork() {
+		return nil, errors.New(utils.ErrNotConnected)
+	}
+
+	cs := asset.chainService()
+	if cs == nil {
+		// The full node or the Electrum server is the only peer of the
+		// wallet.
+		return []sharedW.PeerInfo{}, nil
+	}
+
+	serverPeers := cs.Peers()
+	infos := make([]sharedW.PeerInfo, 0, len(serverPeers))
+	for _, sp := range serverPeers {
+		stats := sp.StatsSnapshot()
+		info := sharedW.PeerInfo{
+			ID:             stats.ID,
+			Addr:           stats.Addr,
+			Services:       fmt.Sprintf("%08d", uint64(stats.Services)),
+			Version:        stats.Version,
+			SubVer:         stats.UserAgent,
+			StartingHeight: int64(stats.StartingHeight),
+			LastBlock:      stats.LastBlock,
+			Latency:        time.Duration(stats.LastPingMicros) * time.Microsecond,
+			BytesSent:      stats.BytesSent,
+			BytesReceived:  stats.BytesRecv,
+		}
+		if localAddr := sp.LocalAddr(); localAddr != nil {
+			info.AddrLocal = localAddr.String()
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos, nil
+}
+
+// BanPeer bans the host of the peer address for the duration and disconnects
+// the peers of the host.
+func (asset *Asset) BanPeer(address string, duration time.Duration) error {
+	if err := asset.SaveBannedPeer(address, duration); err != nil {
+		return err
+	}
+
+	cs := asset.chainService()
+	if cs == nil {
+		return nil
+	}
+	host := sharedW.PeerHost(address)
+	for _, sp := range cs.Peers() {
+		if sharedW.PeerHost(sp.Addr()) == host {
+			sp.Disconnect()
+		}
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the host of the peer address.
+func (asset *Asset) UnbanPeer(address string) error {
+	return asset.RemoveBannedPeer(address)
+}
+
+// peerDialer returns the dialer of the neutrino peer connections. Banned
+// peers are not dialed.
+func (asset *Asset) peerDialer(dial utils.Dailer) utils.Dailer {
+	return func(addr net.Addr) (net.Conn, error) {
+		if asset.IsPeerBanned(addr.String()) {
+			return nil, fmt.Errorf("peer %s is banned", addr)
+		}
+		return dial(addr)
+	}
+}
+
+// updatePeerScores updates the scores of the preferred peers with the peers
+// connected once the sync completed.
+func (asset *Asset) updatePeerScores() {
+	cs := asset.chainService()
+	if cs == nil {
+		return
+	}
+	var connected []string
+	for _, sp := range cs.Peers() {
+		connected = append(connected, sp.Addr())
+	}
+	asset.UpdatePeerScores(connected)
+}
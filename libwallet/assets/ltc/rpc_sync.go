@@ -0,0 +1,235 @@
+package ltc
+
+import (
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/dcrlabs/ltcwallet/chain"
+	"github.com/ltcsuite/ltcd/btcjson"
+	"github.com/ltcsuite/ltcd/chaincfg/chainhash"
+	"github.com/ltcsuite/ltcd/wire"
+)
+
+const (
+	// rpcReconnectAttempts is the number of attempts made to connect to the
+	// ltcd node before the sync fails.
+	rpcReconnectAttempts = 3
+
+	// bitcoindBlockPollingInterval and bitcoindTxPollingInterval are how
+	// often the litecoind node is polled for new blocks and mempool
+	// transactions.
+	bitcoindBlockPollingInterval = 10 * time.Second
+	bitcoindTxPollingInterval    = 30 * time.Second
+)
+
+// rpcNodeClient is the chain client of the full node of the RPC network mode,
+// an ltcd or a litecoind node.
+type rpcNodeClient struct {
+	rpcChainClient
+	// bitcoindConn is the connection of the litecoind client, nil for an ltcd
+	// node.
+	bitcoindConn *chain.BitcoindConn
+}
+
+// rpcChainClient is implemented by the ltcd and the litecoind chain clients.
+type rpcChainClient interface {
+	chain.Interface
+	GetBlockHeaderVerbose(hash *chainhash.Hash) (*btcjson.GetBlockHeaderVerboseResult, error)
+}
+
+// Stop stops the client and the connection of a litecoind node.
+func (client *rpcNodeClient) Stop() {
+	client.rpcChainClient.Stop()
+	if client.bitcoindConn != nil {
+		client.bitcoindConn.Stop()
+	}
+}
+
+// Disconnected returns true if the node cannot be reached. The litecoind
+// node is polled over HTTP, it is reached if it serves its best block.
+func (client *rpcNodeClient) Disconnected() bool {
+	if ltcdClient, ok := client.rpcChainClient.(*chain.RPCClient); ok {
+		return ltcdClient.Disconnected()
+	}
+	_, _, err := client.GetBestBlock()
+	return err != nil
+}
+
+// newRPCNodeClient returns the chain client of the node.
+func (asset *Asset) newRPCNodeClient(node *sharedW.RPCNode) (*rpcNodeClient, error) {
+	if !node.Bitcoind() {
+		client, err := chain.NewRPCClient(asset.chainParams, node.Address, node.User, node.Password,
+			[]byte(node.Cert), node.DisableTLS(), rpcReconnectAttempts)
+		if err != nil {
+			return nil, err
+		}
+		return &rpcNodeClient{rpcChainClient: client}, nil
+	}
+
+	conn, err := chain.NewBitcoindConn(&chain.BitcoindConfig{
+		ChainParams: asset.chainParams,
+		Host:        node.Address,
+		User:        node.User,
+		Pass:        node.Password,
+		PollingConfig: &chain.PollingConfig{
+			BlockPollingInterval: bitcoindBlockPollingInterval,
+			TxPollingInterval:    bitcoindTxPollingInterval,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.Start(); err != nil {
+		return nil, err
+	}
+	return &rpcNodeClient{rpcChainClient: conn.NewBitcoindClient(), bitcoindConn: conn}, nil
+}
+
+// startRPCSync connects to the ltcd or litecoind node of the RPC network mode
+// and syncs the wallet with it.
+func (asset *Asset) startRPCSync() error {
+	node, err := asset.RPCSyncNode()
+	if err != nil {
+		return err
+	}
+
+	client, err := asset.newRPCNodeClient(node)
+	if err != nil {
+		log.Errorf("couldn't connect to the RPC node at %s: %v", node.Address, err)
+		return err
+	}
+	if err := client.Start(); err != nil {
+		log.Errorf("couldn't connect to the RPC node at %s: %v", node.Address, err)
+		client.Stop()
+		asset.CancelSync()
+		return err
+	}
+	asset.rpcClient.Store(client)
+
+	// Subscribe to chainclient notifications.
+	if err := client.NotifyBlocks(); err != nil {
+		log.Errorf("subscribing to notifications failed: %v", err)
+		client.Stop()
+		asset.rpcClient.Store(nil)
+		asset.CancelSync()
+		return err
+	}
+
+	// Listen and handle incoming notification events.
+	if atomic.CompareAndSwapUint32(&asset.syncData.syncstarted, stop, start) {
+		go asset.handleNotifications()
+	}
+
+	log.Infof("Synchronizing wallet (%s) with the RPC node at %s...", asset.GetWalletName(), node.Address)
+	// The wallet is given the underlying client, which it configures by
+	// type.
+	asset.Internal().LTC.SynchronizeRPC(client.rpcChainClient)
+
+	return nil
+}
+
+// chainSource returns the chain backend of the wallet: the full node client
+// or the Electrum client while the wallet syncs in their network mode, the
+// neutrino client otherwise.
+func (asset *Asset) chainSource() chain.Interface {
+	if client := asset.rpcClient.Load(); client != nil {
+		return client
+	}
+	if client := asset.electrumClient.Load(); client != nil {
+		return client
+	}
+	return asset.chainClient
+}
+
+// chainTip returns the best block height of the chain backend and whether the
+// wallet considers itself synced with it.
+func (asset *Asset) chainTip() (int32, bool, error) {
+	if client := asset.rpcClient.Load(); client != nil {
+		_, height, err := client.GetBestBlock()
+		if err != nil {
+			return 0, false, err
+		}
+		return height, asset.Internal().LTC.ChainSynced(), nil
+	}
+	if client := asset.electrumClient.Load(); client != nil {
+		// The wallet scans the headers served by the server up to its
+		// tip, report the progress of the scan.
+		if _, err := client.connected(); err != nil {
+			return 0, false, err
+		}
+		return asset.Internal().LTC.Manager.SyncedTo().Height, asset.Internal().LTC.ChainSynced(), nil
+	}
+	if asset.NetworkMode() != sharedW.NetworkModeSPV {
+		// The full node or the Electrum server is not connected yet.
+		return 0, false, errors.New(utils.ErrNotConnected)
+	}
+
+	block, err := asset.chainClient.CS.BestBlock()
+	if err != nil {
+		return 0, false, err
+	}
+	return block.Height, asset.chainClient.IsCurrent(), nil
+}
+
+// rpcBestBlock returns the best block of the full node, or the block the
+// wallet is synced to while the node is not connected.
+func (asset *Asset) rpcBestBlock() *sharedW.BlockInfo {
+	if client := asset.rpcClient.Load(); client != nil {
+		hash, height, err := client.GetBestBlock()
+		var header *wire.BlockHeader
+		if err == nil {
+			header, err = client.GetBlockHeader(hash)
+		}
+		if err == nil {
+			return &sharedW.BlockInfo{Height: height, Timestamp: header.Timestamp.Unix()}
+		}
+		log.Error("GetBestBlock from the full node failed, Err: ", err)
+	}
+
+	if !asset.WalletOpened() {
+		return sharedW.InvalidBlock
+	}
+	syncedTo := asset.Internal().LTC.Manager.SyncedTo()
+	return &sharedW.BlockInfo{Height: syncedTo.Height, Timestamp: syncedTo.Timestamp.Unix()}
+}
+
+// chainBlockHeight returns the height of the block with the provided hash.
+func (asset *Asset) chainBlockHeight(hash *chainhash.Hash) (int32, error) {
+	if client := asset.rpcClient.Load(); client != nil {
+		header, err := client.GetBlockHeaderVerbose(hash)
+		if err != nil {
+			return -1, err
+		}
+		return header.Height, nil
+	}
+	if client := asset.electrumClient.Load(); client != nil {
+		header, err := client.header(hash)
+		if err != nil {
+			return -1, err
+		}
+		return header.Height, nil
+	}
+	return asset.chainClient.GetBlockHeight(hash)
+}
+
+// SetNetworkMode saves the network mode of the wallet and restarts the sync
+// with the new backend if the wallet is connected.
+func (asset *Asset) SetNetworkMode(mode string, node *sharedW.RPCNode) error {
+	if err := asset.SaveNetworkMode(mode, node); err != nil {
+		return err
+	}
+	if !asset.IsConnectedToNetwork() {
+		return nil
+	}
+
+	go func() {
+		asset.CancelSync()
+		if err := asset.SpvSync(); err != nil {
+			log.Errorf("Restarting the sync of %s failed: %v", asset.GetWalletName(), err)
+		}
+	}()
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"decred.org/dcrwallet/v4/errors"
@@ -41,6 +42,8 @@ type Asset struct {
 
 	cl             *neutrino.ChainService
 	chainClient    *chain.NeutrinoClient
+	rpcClient      atomic.Pointer[rpcNodeClient]  // Set while syncing with a full node.
+	electrumClient atomic.Pointer[electrumClient] // Set while syncing with an Electrum server.
 	chainParams    *ltcchaincfg.Params
 	TxAuthoredInfo *TxAuthor
 
@@ -224,6 +227,41 @@ func RestoreWallet(seedMnemonic string, pass *sharedW.AuthInfo, params *sharedW.
 	return ltcWallet, nil
 }
 
+// RecreateWallet restores the wallet database of the existing wallet from the
+// seed mnemonic, keeping the ID and config of the wallet, and returns the
+// restored wallet.
+func RecreateWallet(w *sharedW.Wallet, seedMnemonic, privatePassphrase string, wordSeedType sharedW.WordSeedType,
+	params *sharedW.InitParams,
+) (sharedW.Asset, error) {
+	chainParams, err := utils.LTCChainParams(params.NetType)
+	if err != nil {
+		return nil, err
+	}
+
+	ldr := initWalletLoader(chainParams, params.RootDir)
+	err = w.RecreateWallet(seedMnemonic, privatePassphrase, wordSeedType, ldr, params)
+	if err != nil {
+		return nil, err
+	}
+
+	ltcWallet := &Asset{
+		Wallet:      w,
+		chainParams: chainParams,
+		syncData: &SyncData{
+			syncProgressListeners: make(map[string]*sharedW.SyncProgressListener),
+		},
+		txAndBlockNotificationListeners: make(map[string]*sharedW.TxAndBlockNotificationListener),
+	}
+
+	if err := ltcWallet.prepareChain(); err != nil {
+		return nil, err
+	}
+
+	ltcWallet.SetNetworkCancelCallback(ltcWallet.SafelyCancelSync)
+
+	return ltcWallet, nil
+}
+
 // LoadExisting accepts the stored shared wallet information and the init parameters.
 // It validates the network type passed by fetching the chain parameters
 // associated with it for the LTC asset. It then generates the LTC loader interface
@@ -267,6 +305,11 @@ func LoadExisting(w *sharedW.Wallet, params *sharedW.InitParams) (sharedW.Asset,
 	}
 
 	if err := ltcWallet.prepareChain(); err != nil {
+		// Release the wallet data database of the wallet that failed to
+		// load, so it can be diagnosed and repaired.
+		if closeErr := ltcWallet.GetWalletDataDb().Close(); closeErr != nil {
+			log.Errorf("tx db closed with error: %v", closeErr)
+		}
 		return nil, err
 	}
 
@@ -340,6 +383,20 @@ func (asset *Asset) ConnectedPeers() int32 {
 	if !asset.IsConnectedToNetwork() {
 		return -1
 	}
+	switch asset.NetworkMode() {
+	case sharedW.NetworkModeRPC:
+		// The full node is the only peer of the wallet.
+		if client := asset.rpcClient.Load(); client != nil && !client.Disconnected() {
+			return 1
+		}
+		return 0
+	case sharedW.NetworkModeElectrum:
+		// So is the Electrum server.
+		if client := asset.electrumClient.Load(); client != nil && client.IsCurrent() {
+			return 1
+		}
+		return 0
+	}
 
 	return int32(len(asset.cl.Peers()))
 }
@@ -351,6 +408,13 @@ func (asset *Asset) IsConnectedToNetwork() bool {
 
 // GetBestBlock returns the best block.
 func (asset *Asset) GetBestBlock() *sharedW.BlockInfo {
+	switch asset.NetworkMode() {
+	case sharedW.NetworkModeRPC:
+		return asset.rpcBestBlock()
+	case sharedW.NetworkModeElectrum:
+		return asset.electrumBestBlock()
+	}
+
 	block, err := asset.chainClient.CS.BestBlock()
 	if err != nil {
 		log.Error("GetBestBlock hash for LTC failed, Err: ", err)
@@ -375,7 +439,7 @@ func (asset *Asset) GetBestBlockTimeStamp() int64 {
 
 // GetBlockHeight returns the block height for the given block hash.
 func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
-	height, err := asset.chainClient.GetBlockHeight(&hash)
+	height, err := asset.chainBlockHeight(&hash)
 	if err != nil {
 		log.Warn("GetBlockHeight for LTC failed, Err: %v", err)
 		return -1, err
@@ -385,7 +449,7 @@ func (asset *Asset) GetBlockHeight(hash chainhash.Hash) (int32, error) {
 
 // GetBlockHash returns the block hash for the given block height.
 func (asset *Asset) GetBlockHash(height int64) (*chainhash.Hash, error) {
-	blockhash, err := asset.chainClient.GetBlockHash(height)
+	blockhash, err := asset.chainSource().GetBlockHash(height)
 	if err != nil {
 		log.Warn("GetBlockHash for LTC failed, Err: %v", err)
 		return nil, err
@@ -0,0 +1,71 @@
+package wallet
+
+import (
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/ur"
+)
+
+const (
+	// AirgapTxPSBT is the type of the PSBTs of BTC and LTC transactions.
+	AirgapTxPSBT = ur.TypePSBT
+	// AirgapTxDCR is the type of the Decred transactions, serialized with
+	// the amounts, scripts and key paths of their inputs.
+	AirgapTxDCR = "dcr-tx"
+
+	// airgapFragmentLen is the largest fragment of the animated QR codes of
+	// a transaction, which keeps every frame easy to scan.
+	airgapFragmentLen = 200
+)
+
+// AirgapTx is a transaction carried between a watch-only wallet and an
+// offline wallet holding its seed, unsigned on the way to the offline wallet
+// and signed on the way back. It travels as a UR, shown as an animated QR code
+// or saved to a file.
+type AirgapTx struct {
+	Type string
+	Data []byte
+}
+
+// AirgapTxSummary is what a transaction pays, shown to confirm it before it
+// is signed.
+type AirgapTxSummary struct {
+	Outputs []*SignOutput
+	Fee     int64
+	// Signed is true once every input of the transaction is signed.
+	Signed bool
+}
+
+// UREncoder returns the encoder of the animated QR code of the transaction.
+func (tx *AirgapTx) UREncoder() (*ur.Encoder, error) {
+	return ur.NewEncoder(tx.Type, ur.EncodeBytes(tx.Data), airgapFragmentLen)
+}
+
+// UR returns the single part UR of the transaction, the content of the files
+// it is saved to.
+func (tx *AirgapTx) UR() string {
+	return ur.Encode(tx.Type, ur.EncodeBytes(tx.Data))
+}
+
+// ParseAirgapTx decodes a transaction from its UR parts, one per line, read
+// from a file or pasted.
+func ParseAirgapTx(text string) (*AirgapTx, error) {
+	urType, message, err := ur.Decode(strings.Fields(text)...)
+	if err != nil {
+		return nil, err
+	}
+	return AirgapTxFromUR(urType, message)
+}
+
+// AirgapTxFromUR returns the transaction of a UR recovered by a decoder.
+func AirgapTxFromUR(urType string, message []byte) (*AirgapTx, error) {
+	if urType != AirgapTxPSBT && urType != AirgapTxDCR {
+		return nil, errors.E(errors.Invalid, "unsupported UR type "+urType)
+	}
+	data, err := ur.DecodeBytes(message)
+	if err != nil {
+		return nil, err
+	}
+	return &AirgapTx{Type: urType, Data: data}, nil
+}
@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"context"
+	"time"
 
 	"github.com/crypto-power/cryptopower/libwallet/internal/loader"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
@@ -17,9 +18,24 @@ type Asset interface {
 	CancelSync()
 	IsRescanning() bool
 	RescanBlocks() error
+	Rescan(options *RescanOptions) error
 	ConnectedPeers() int32
 	RemovePeers()
 	SetSpecificPeer(address string)
+	PeerInfoRaw() ([]PeerInfo, error)
+	BanPeer(address string, duration time.Duration) error
+	UnbanPeer(address string) error
+	BannedPeers() []*BannedPeer
+	PreferredPeers() []*PreferredPeer
+	AddPreferredPeer(address string) error
+	RemovePreferredPeer(address string) error
+	NetworkMode() string
+	RPCNode() *RPCNode
+	SetRPCPassword(password string)
+	RPCPasswordRequired() bool
+	SetNetworkMode(mode string, node *RPCNode) error
+	ElectrumServer() *ElectrumServer
+	SaveElectrumServer(server *ElectrumServer) error
 	GetExtendedPubKey(account int32) (string, error)
 	IsSyncShuttingDown() bool
 	EnableSyncShuttingDown()
@@ -33,6 +49,10 @@ type Asset interface {
 	GetWalletID() int
 	GetWalletName() string
 	IsWatchingOnlyWallet() bool
+	HardwareSigner() *HardwareSignerConfig
+	SaveHardwareSigner(cfg *HardwareSignerConfig) error
+	CanSign() bool
+	AirgapSigner() bool
 	UnlockWallet(string) error
 	DeleteWallet(privPass string) error
 	RenameWallet(newName string) error
@@ -109,12 +129,20 @@ type Asset interface {
 	ReadLongConfigValueForKey(key string, defaultValue int64) int64
 	ReadStringConfigValueForKey(key string, defaultValue string) string
 
+	LockTxAuthor(ctx context.Context) error
+	TryLockTxAuthor() bool
+	UnlockTxAuthor()
 	NewUnsignedTx(accountNumber int32, utxos []*UnspentOutput) error
 	AddSendDestination(id int, address string, unitAmount int64, sendMax bool) error
 	ComputeTxSizeEstimation(dstAddress string, utxos []*UnspentOutput) (int, error)
 	Broadcast(passphrase, label string) (string, error)
+	ExportUnsignedTx() (*AirgapTx, error)
+	AirgapTxSummary(tx *AirgapTx) (*AirgapTxSummary, error)
+	SignAirgapTx(tx *AirgapTx, passphrase string) (*AirgapTx, error)
+	PublishAirgapTx(tx *AirgapTx, label string) (string, error)
 	EstimateFeeAndSize() (*TxFeeAndSize, error)
 	IsUnsignedTxExist() bool
+	IsSaneOutputValue(amount int64) bool
 	RemoveSendDestination(id int)
 	SendDestination(id int) *TransactionDestination
 	UpdateSendDestination(id int, address string, atomAmount int64, sendMax bool) error
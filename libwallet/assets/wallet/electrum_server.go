@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ElectrumServer is the server a wallet in the Electrum network mode syncs
+// with.
+type ElectrumServer struct {
+	// Address is the host:port of the server.
+	Address string `json:"address"`
+	// Cert is the PEM encoded TLS certificate the connection is pinned to.
+	// Without it, servers on the loopback interface are connected to over
+	// plain TCP and other servers must present a certificate trusted by the
+	// system.
+	Cert string `json:"cert"`
+}
+
+// Validate checks the server address and certificate.
+func (server *ElectrumServer) Validate() error {
+	host, port, err := net.SplitHostPort(server.Address)
+	if err != nil || host == "" || port == "" {
+		return errors.E(utils.ErrInvalidAddress, fmt.Errorf("Electrum server address %q must be host:port", server.Address))
+	}
+	if server.Cert == "" {
+		return nil
+	}
+
+	block, _ := pem.Decode([]byte(server.Cert))
+	if block == nil {
+		return errors.E(utils.ErrInvalid, "Electrum server certificate is not PEM encoded")
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return errors.E(utils.ErrInvalid, fmt.Errorf("invalid Electrum server certificate: %v", err))
+	}
+	return nil
+}
+
+// ElectrumServer returns the server configured for the Electrum network mode,
+// or nil if none is set.
+func (wallet *Wallet) ElectrumServer() *ElectrumServer {
+	server := new(ElectrumServer)
+	if err := wallet.ReadUserConfigValue(ElectrumServerConfigKey, server); err != nil || server.Address == "" {
+		return nil
+	}
+	return server
+}
+
+// SaveElectrumServer validates and saves the server of the Electrum network
+// mode. It does not change the network mode of the wallet.
+func (wallet *Wallet) SaveElectrumServer(server *ElectrumServer) error {
+	server.Address = strings.TrimSpace(server.Address)
+	server.Cert = strings.TrimSpace(server.Cert)
+	if err := server.Validate(); err != nil {
+		return err
+	}
+	return wallet.walletConfigSave(ElectrumServerConfigKey, server)
+}
@@ -0,0 +1,27 @@
+package wallet
+
+import "testing"
+
+func TestElectrumServerValidate(t *testing.T) {
+	cert := testCert(t)
+
+	tests := []struct {
+		name    string
+		server  ElectrumServer
+		wantErr bool
+	}{
+		{"local", ElectrumServer{Address: "127.0.0.1:50001"}, false},
+		{"remote with system certificates", ElectrumServer{Address: "electrum.example.com:50002"}, false},
+		{"remote with pinned certificate", ElectrumServer{Address: "electrum.example.com:50002", Cert: cert}, false},
+		{"missing port", ElectrumServer{Address: "electrum.example.com"}, true},
+		{"missing host", ElectrumServer{Address: ":50002"}, true},
+		{"invalid certificate", ElectrumServer{Address: "127.0.0.1:50002", Cert: "not a certificate"}, true},
+	}
+
+	for _, test := range tests {
+		err := test.server.Validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.wantErr, err)
+		}
+	}
+}
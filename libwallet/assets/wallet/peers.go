@@ -0,0 +1,274 @@
+package wallet
+
+import (
+	"net"
+	"sort"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Health scores of the preferred peers. A peer gains score each time a sync
+// completes with it connected and loses score each time a sync completes
+// without it. The sync only prefers the healthy peers, so the unhealthy peers
+// are not penalized further and instead recover toward initialPeerScore every
+// peerRecoveryPeriod until they are preferred again.
+const (
+	initialPeerScore   = 50
+	maxPeerScore       = 100
+	healthyPeerScore   = 30
+	connectedPeerScore = 5
+	missingPeerScore   = -10
+	recoveryPeerScore  = 5
+
+	peerRecoveryPeriod = time.Hour
+)
+
+// BannedPeer is a peer host the wallet does not connect to until the ban
+// expires.
+type BannedPeer struct {
+	Host string `json:"host"`
+	// Until is the unix time the ban expires at.
+	Until int64 `json:"until"`
+}
+
+// PreferredPeer is a peer the wallet prefers to connect to while it is
+// healthy.
+type PreferredPeer struct {
+	Address string `json:"address"`
+	Score   int32  `json:"score"`
+	// LastSeen is the unix time a sync last completed with the peer
+	// connected, zero if it never did.
+	LastSeen int64 `json:"last_seen"`
+	// ScoredAt is the unix time the score was last updated, which the
+	// recovery of an unhealthy peer counts from.
+	ScoredAt int64 `json:"scored_at"`
+}
+
+// Healthy returns true if the sync prefers the peer.
+func (peer *PreferredPeer) Healthy() bool {
+	return peer.Score >= healthyPeerScore
+}
+
+// PeerHost returns the host of the peer address, which may omit the port.
+// Peers are banned by host.
+func PeerHost(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// activeBans returns the bans that have not expired at now.
+func activeBans(bans []*BannedPeer, now time.Time) []*BannedPeer {
+	active := make([]*BannedPeer, 0, len(bans))
+	for _, ban := range bans {
+		if ban.Until > now.Unix() {
+			active = append(active, ban)
+		}
+	}
+	return active
+}
+
+// scorePeers updates the scores of the preferred peers after a sync completed
+// with the connected peers. The unhealthy peers were not preferred by the sync
+// and recover instead of losing score when missing.
+func scorePeers(peers []*PreferredPeer, connected []string, now time.Time) {
+	connectedHosts := make(map[string]bool, len(connected))
+	for _, address := range connected {
+		connectedHosts[PeerHost(address)] = true
+	}
+
+	for _, peer := range peers {
+		switch {
+		case connectedHosts[PeerHost(peer.Address)]:
+			peer.Score += connectedPeerScore
+			peer.LastSeen = now.Unix()
+			peer.ScoredAt = now.Unix()
+		case peer.Healthy():
+			peer.Score += missingPeerScore
+			peer.ScoredAt = now.Unix()
+		default:
+			recoverPeer(peer, now)
+		}
+		if peer.Score > maxPeerScore {
+			peer.Score = maxPeerScore
+		}
+		if peer.Score < 0 {
+			peer.Score = 0
+		}
+	}
+}
+
+// recoverPeer raises the score of an unhealthy peer by recoveryPeerScore for
+// each peerRecoveryPeriod elapsed since it was scored, up to
+// initialPeerScore. The rest of an unfinished period counts toward the next
+// recovery.
+func recoverPeer(peer *PreferredPeer, now time.Time) {
+	periods := now.Sub(time.Unix(peer.ScoredAt, 0)) / peerRecoveryPeriod
+	if periods <= 0 {
+		return
+	}
+	peer.ScoredAt += int64(periods * peerRecoveryPeriod / time.Second)
+
+	recovered := int64(peer.Score) + int64(periods)*recoveryPeerScore
+	if recovered > initialPeerScore {
+		recovered = initialPeerScore
+	}
+	if recovered > int64(peer.Score) {
+		peer.Score = int32(recovered)
+	}
+}
+
+// BannedPeers returns the peer hosts banned by the wallet.
+func (wallet *Wallet) BannedPeers() []*BannedPeer {
+	var bans []*BannedPeer
+	_ = wallet.ReadUserConfigValue(BannedPeersConfigKey, &bans)
+	bans = activeBans(bans, time.Now())
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Until < bans[j].Until
+	})
+	return bans
+}
+
+// IsPeerBanned returns true if the host of the peer address is banned.
+func (wallet *Wallet) IsPeerBanned(address string) bool {
+	host := PeerHost(address)
+	for _, ban := range wallet.BannedPeers() {
+		if ban.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveBannedPeer bans the host of the peer address for the duration. It does
+// not disconnect the peer.
+func (wallet *Wallet) SaveBannedPeer(address string, duration time.Duration) error {
+	host := PeerHost(address)
+	if net.ParseIP(host) == nil {
+		return errors.E(utils.ErrInvalidAddress, "peers are banned by IP address")
+	}
+	if duration <= 0 {
+		return errors.E(utils.ErrInvalid, "ban duration must be positive")
+	}
+
+	wallet.peersMu.Lock()
+	defer wallet.peersMu.Unlock()
+
+	bans := make([]*BannedPeer, 0)
+	for _, ban := range wallet.BannedPeers() {
+		if ban.Host != host {
+			bans = append(bans, ban)
+		}
+	}
+	bans = append(bans, &BannedPeer{Host: host, Until: time.Now().Add(duration).Unix()})
+	return wallet.walletConfigSave(BannedPeersConfigKey, bans)
+}
+
+// RemoveBannedPeer lifts the ban of the host of the peer address.
+func (wallet *Wallet) RemoveBannedPeer(address string) error {
+	host := PeerHost(address)
+
+	wallet.peersMu.Lock()
+	defer wallet.peersMu.Unlock()
+
+	bans := make([]*BannedPeer, 0)
+	for _, ban := range wallet.BannedPeers() {
+		if ban.Host != host {
+			bans = append(bans, ban)
+		}
+	}
+	return wallet.walletConfigSave(BannedPeersConfigKey, bans)
+}
+
+// PreferredPeers returns the preferred peers of the wallet, healthiest
+// first.
+func (wallet *Wallet) PreferredPeers() []*PreferredPeer {
+	var peers []*PreferredPeer
+	_ = wallet.ReadUserConfigValue(PreferredPeersConfigKey, &peers)
+	sort.SliceStable(peers, func(i, j int) bool {
+		return peers[i].Score > peers[j].Score
+	})
+	return peers
+}
+
+// AddPreferredPeer adds the peer address to the preferred peers. The default
+// port of the network is used if the address has none.
+func (wallet *Wallet) AddPreferredPeer(address string) error {
+	addresses, errs := ParseWalletPeers(address, wallet.defaultPeerPort())
+	if len(errs) > 0 {
+		return errors.E(utils.ErrInvalidAddress, errs[0])
+	}
+	if len(addresses) != 1 {
+		return errors.E(utils.ErrInvalidAddress, "a single peer address is required")
+	}
+
+	wallet.peersMu.Lock()
+	defer wallet.peersMu.Unlock()
+
+	peers := wallet.PreferredPeers()
+	for _, peer := range peers {
+		if peer.Address == addresses[0] {
+			return errors.E(utils.ErrExist, "peer is already preferred")
+		}
+	}
+	peers = append(peers, &PreferredPeer{Address: addresses[0], Score: initialPeerScore})
+	return wallet.walletConfigSave(PreferredPeersConfigKey, peers)
+}
+
+// RemovePreferredPeer removes the peer address from the preferred peers.
+func (wallet *Wallet) RemovePreferredPeer(address string) error {
+	wallet.peersMu.Lock()
+	defer wallet.peersMu.Unlock()
+
+	peers := make([]*PreferredPeer, 0)
+	for _, peer := range wallet.PreferredPeers() {
+		if peer.Address != address {
+			peers = append(peers, peer)
+		}
+	}
+	return wallet.walletConfigSave(PreferredPeersConfigKey, peers)
+}
+
+// UpdatePeerScores updates the health scores of the preferred peers after a
+// sync completed with the connected peer addresses.
+func (wallet *Wallet) UpdatePeerScores(connected []string) {
+	wallet.peersMu.Lock()
+	defer wallet.peersMu.Unlock()
+
+	peers := wallet.PreferredPeers()
+	if len(peers) == 0 {
+		return
+	}
+	scorePeers(peers, connected, time.Now())
+	if err := wallet.walletConfigSave(PreferredPeersConfigKey, peers); err != nil {
+		log.Errorf("error saving the preferred peers scores: %v", err)
+	}
+}
+
+// HealthyPeers returns the addresses of the healthy preferred peers that are
+// not banned, healthiest first.
+func (wallet *Wallet) HealthyPeers() []string {
+	var addresses []string
+	for _, peer := range wallet.PreferredPeers() {
+		if peer.Healthy() && !wallet.IsPeerBanned(peer.Address) {
+			addresses = append(addresses, peer.Address)
+		}
+	}
+	return addresses
+}
+
+// defaultPeerPort returns the default P2P port of the network of the wallet.
+func (wallet *Wallet) defaultPeerPort() string {
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
+		return wallet.chainsParams.BTC.DefaultPort
+	case utils.LTCWalletAsset:
+		return wallet.chainsParams.LTC.DefaultPort
+	default:
+		return wallet.chainsParams.DCR.DefaultPort
+	}
+}
@@ -0,0 +1,100 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeerHost(t *testing.T) {
+	tests := map[string]string{
+		"192.168.1.10:9108": "192.168.1.10",
+		"192.168.1.10":      "192.168.1.10",
+		"[::1]:8333":        "::1",
+		"node.example.com":  "node.example.com",
+	}
+	for address, want := range tests {
+		if got := PeerHost(address); got != want {
+			t.Errorf("%s: expected host %s, got %s", address, want, got)
+		}
+	}
+}
+
+func TestActiveBans(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	bans := []*BannedPeer{
+		{Host: "10.0.0.1", Until: now.Add(time.Hour).Unix()},
+		{Host: "10.0.0.2", Until: now.Add(-time.Hour).Unix()},
+		{Host: "10.0.0.3", Until: now.Unix()},
+	}
+
+	active := activeBans(bans, now)
+	if len(active) != 1 || active[0].Host != "10.0.0.1" {
+		t.Fatalf("expected only the unexpired ban of 10.0.0.1, got %v", active)
+	}
+}
+
+func TestScorePeers(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	peers := []*PreferredPeer{
+		{Address: "10.0.0.1:9108", Score: initialPeerScore},
+		{Address: "10.0.0.2:9108", Score: initialPeerScore},
+		{Address: "10.0.0.3:9108", Score: maxPeerScore},
+		{Address: "10.0.0.4:9108", Score: 5, ScoredAt: now.Unix()},
+	}
+
+	// Peers are matched by host, the outbound port may differ.
+	scorePeers(peers, []string{"10.0.0.1:9108", "10.0.0.3:19108"}, now)
+
+	want := []struct {
+		score    int32
+		lastSeen int64
+	}{
+		{initialPeerScore + connectedPeerScore, now.Unix()},
+		{initialPeerScore + missingPeerScore, 0},
+		{maxPeerScore, now.Unix()},
+		{5, 0}, // unhealthy peers are not dialed, so not penalized
+	}
+	for i, peer := range peers {
+		if peer.Score != want[i].score || peer.LastSeen != want[i].lastSeen {
+			t.Errorf("%s: expected score %d last seen %d, got score %d last seen %d",
+				peer.Address, want[i].score, want[i].lastSeen, peer.Score, peer.LastSeen)
+		}
+	}
+
+	// A peer missing from enough syncs stops being healthy.
+	peer := &PreferredPeer{Address: "10.0.0.5:9108", Score: initialPeerScore}
+	for peer.Healthy() {
+		scorePeers([]*PreferredPeer{peer}, nil, now)
+	}
+	if peer.Score >= healthyPeerScore {
+		t.Fatalf("expected an unhealthy score, got %d", peer.Score)
+	}
+}
+
+func TestPeerScoreRecovery(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	peer := &PreferredPeer{Address: "10.0.0.1:9108", Score: healthyPeerScore}
+	scorePeers([]*PreferredPeer{peer}, nil, now)
+	unhealthy := int32(healthyPeerScore + missingPeerScore)
+
+	tests := []struct {
+		elapsed time.Duration
+		score   int32
+	}{
+		{elapsed: 0, score: unhealthy},
+		{elapsed: peerRecoveryPeriod / 2, score: unhealthy},
+		// The half period elapsed before counts toward this recovery.
+		{elapsed: peerRecoveryPeriod / 2, score: unhealthy + recoveryPeerScore},
+		{elapsed: peerRecoveryPeriod, score: unhealthy + 2*recoveryPeerScore},
+		// Healthy again, the peer is dialed and loses score if missing.
+		{elapsed: 0, score: unhealthy},
+		{elapsed: 24 * peerRecoveryPeriod, score: initialPeerScore},
+	}
+	for i, test := range tests {
+		now = now.Add(test.elapsed)
+		scorePeers([]*PreferredPeer{peer}, nil, now)
+		if peer.Score != test.score {
+			t.Fatalf("%d: expected score %d, got %d", i, test.score, peer.Score)
+		}
+	}
+}
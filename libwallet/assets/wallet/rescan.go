@@ -0,0 +1,45 @@
+package wallet
+
+import "time"
+
+// rescanDateMargin is how much earlier than the requested date a rescan from
+// a date starts. Blocks are not mined exactly at the target time per block,
+// so the height estimated for a date may be past the blocks of that date.
+const rescanDateMargin = 7 * 24 * time.Hour
+
+// RescanOptions describes a rescan of the blockchain.
+type RescanOptions struct {
+	// StartHeight is the height of the first block rescanned.
+	StartHeight int32
+	// StartDate takes precedence over StartHeight if set. The rescan then
+	// starts at a height estimated from the genesis timestamp and the target
+	// time per block of the chain.
+	StartDate time.Time
+	// Accounts limits the address discovery to these accounts. All the
+	// accounts of the wallet are used if it is empty. DCR wallets always
+	// discover all their accounts and reject a rescan with Accounts set.
+	Accounts []int32
+	// GapLimit is the number of unused addresses past the last used address
+	// of each account that are discovered before the rescan. Addresses are
+	// not discovered if it is zero.
+	GapLimit uint32
+}
+
+// StartBlockHeight returns the height the rescan starts at, between the
+// genesis block and the best block. genesisTimestamp is in seconds since the
+// epoch and targetTimePerBlock is in seconds.
+func (options *RescanOptions) StartBlockHeight(bestHeight int32, genesisTimestamp, targetTimePerBlock int64) int32 {
+	height := options.StartHeight
+	if !options.StartDate.IsZero() && targetTimePerBlock > 0 {
+		start := options.StartDate.Add(-rescanDateMargin).Unix()
+		height = int32((start - genesisTimestamp) / targetTimePerBlock)
+	}
+
+	if height > bestHeight {
+		height = bestHeight
+	}
+	if height < 0 {
+		height = 0
+	}
+	return height
+}
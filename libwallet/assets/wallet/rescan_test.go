@@ -0,0 +1,33 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRescanStartBlockHeight(t *testing.T) {
+	genesis := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	const targetTimePerBlock = 600 // 10 minutes.
+	const bestHeight = 100000
+
+	tests := []struct {
+		name    string
+		options RescanOptions
+		want    int32
+	}{
+		{"genesis", RescanOptions{}, 0},
+		{"height", RescanOptions{StartHeight: 5000}, 5000},
+		{"height past best block", RescanOptions{StartHeight: bestHeight + 1}, bestHeight},
+		{"date", RescanOptions{StartDate: genesis.Add(30 * 24 * time.Hour)}, (30 - 7) * 144},
+		{"date takes precedence", RescanOptions{StartHeight: 5000, StartDate: genesis.Add(30 * 24 * time.Hour)}, (30 - 7) * 144},
+		{"date before genesis", RescanOptions{StartDate: genesis.Add(-time.Hour)}, 0},
+		{"date in the future", RescanOptions{StartDate: genesis.Add(10 * 365 * 24 * time.Hour)}, bestHeight},
+	}
+
+	for _, test := range tests {
+		got := test.options.StartBlockHeight(bestHeight, genesis.Unix(), targetTimePerBlock)
+		if got != test.want {
+			t.Errorf("%s: expected start height %d, got %d", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package wallet
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Network modes of a wallet.
+const (
+	// NetworkModeSPV syncs the wallet with SPV peers. It is the default.
+	NetworkModeSPV = "spv"
+	// NetworkModeRPC syncs the wallet with a full node over its JSON-RPC
+	// interface.
+	NetworkModeRPC = "rpc"
+	// NetworkModeElectrum syncs the wallet with an Electrum server. Only
+	// the BTC and LTC assets support it.
+	NetworkModeElectrum = "electrum"
+)
+
+// Full node implementations of the RPC network mode. DCR wallets sync with
+// dcrd only.
+const (
+	// RPCBackendBtcd is a btcd or ltcd node, connected to over its websocket.
+	// It is the default.
+	RPCBackendBtcd = "btcd"
+	// RPCBackendBitcoind is a bitcoind or litecoind node, polled for new
+	// blocks and transactions over its RPC interface. It does not serve TLS,
+	// so it must be on the loopback interface.
+	RPCBackendBitcoind = "bitcoind"
+)
+
+// RPCNode is the full node a wallet in the RPC network mode syncs with.
+type RPCNode struct {
+	// Backend is RPCBackendBtcd or RPCBackendBitcoind, empty for the
+	// former.
+	Backend string `json:"backend"`
+	// Address is the host:port of the node's RPC server.
+	Address string `json:"address"`
+	User    string `json:"user"`
+	// Password is not saved with the node but kept in memory until the app
+	// closes, see Wallet.SetRPCPassword.
+	Password string `json:"-"`
+	// HasPassword is true if the node requires a password.
+	HasPassword bool `json:"has_password"`
+	// Cert is the PEM encoded TLS certificate of the RPC server. It is the
+	// only certificate trusted for the connection. Nodes on the loopback
+	// interface may be connected to without TLS by leaving it empty.
+	Cert string `json:"cert"`
+}
+
+// DisableTLS returns true if the connection to the node is not encrypted.
+func (node *RPCNode) DisableTLS() bool {
+	return node.Cert == ""
+}
+
+// Bitcoind returns true if the node is a bitcoind or litecoind node.
+func (node *RPCNode) Bitcoind() bool {
+	return node.Backend == RPCBackendBitcoind
+}
+
+// Validate checks the node backend, address and certificate.
+func (node *RPCNode) Validate() error {
+	switch node.Backend {
+	case "", RPCBackendBtcd:
+	case RPCBackendBitcoind:
+		if node.Cert != "" {
+			return errors.E(utils.ErrInvalid, "bitcoind does not serve TLS, connect to it on the loopback interface")
+		}
+	default:
+		return errors.E(utils.ErrInvalid, fmt.Sprintf("unknown RPC node backend %q", node.Backend))
+	}
+
+	host, port, err := net.SplitHostPort(node.Address)
+	if err != nil || host == "" || port == "" {
+		return errors.E(utils.ErrInvalidAddress, fmt.Errorf("RPC node address %q must be host:port", node.Address))
+	}
+
+	if node.Cert == "" {
+		ip := net.ParseIP(host)
+		if host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return errors.E(utils.ErrInvalid, "a TLS certificate is required for a remote RPC node")
+		}
+		return nil
+	}
+
+	block, _ := pem.Decode([]byte(node.Cert))
+	if block == nil {
+		return errors.E(utils.ErrInvalid, "RPC node certificate is not PEM encoded")
+	}
+	if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+		return errors.E(utils.ErrInvalid, fmt.Errorf("invalid RPC node certificate: %v", err))
+	}
+	return nil
+}
+
+// NetworkMode returns the network mode of the wallet, NetworkModeSPV,
+// NetworkModeRPC or NetworkModeElectrum.
+func (wallet *Wallet) NetworkMode() string {
+	switch mode := wallet.ReadStringConfigValueForKey(NetworkModeConfigKey, NetworkModeSPV); mode {
+	case NetworkModeRPC, NetworkModeElectrum:
+		return mode
+	}
+	return NetworkModeSPV
+}
+
+// RPCNode returns the full node configured for the RPC network mode, or nil
+// if none is set. Its Password is empty until it is set for the session.
+func (wallet *Wallet) RPCNode() *RPCNode {
+	node := new(RPCNode)
+	if err := wallet.ReadUserConfigValue(RPCNodeConfigKey, node); err != nil || node.Address == "" {
+		return nil
+	}
+
+	wallet.rpcPasswordMu.Lock()
+	node.Password = wallet.rpcPassword
+	wallet.rpcPasswordMu.Unlock()
+	return node
+}
+
+// SetRPCPassword sets the password of the full node until the app closes.
+// The password is not saved, the user enters it again in later sessions.
+func (wallet *Wallet) SetRPCPassword(password string) {
+	wallet.rpcPasswordMu.Lock()
+	wallet.rpcPassword = password
+	wallet.rpcPasswordMu.Unlock()
+}
+
+// RPCPasswordRequired returns true if the wallet syncs with a full node that
+// requires a password not set for the session yet.
+func (wallet *Wallet) RPCPasswordRequired() bool {
+	if wallet.NetworkMode() != NetworkModeRPC {
+		return false
+	}
+	node := wallet.RPCNode()
+	return node != nil && node.HasPassword && node.Password == ""
+}
+
+// RPCSyncNode returns the node of the RPC network mode once it is ready
+// to sync with.
+func (wallet *Wallet) RPCSyncNode() (*RPCNode, error) {
+	node := wallet.RPCNode()
+	if node == nil {
+		return nil, errors.E(utils.ErrInvalid, "RPC node is not set")
+	}
+	if node.HasPassword && node.Password == "" {
+		return nil, errors.New(utils.ErrRPCPasswordRequired)
+	}
+	if err := node.Validate(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// SaveNetworkMode validates and saves the network mode of the wallet. The
+// node is required for NetworkModeRPC and kept for later use with the other
+// modes. NetworkModeElectrum requires the server saved with
+// SaveElectrumServer. The assets restart their sync after saving the mode.
+func (wallet *Wallet) SaveNetworkMode(mode string, node *RPCNode) error {
+	switch mode {
+	case NetworkModeSPV:
+	case NetworkModeRPC:
+		if node == nil {
+			return errors.E(utils.ErrInvalid, "RPC node is required")
+		}
+	case NetworkModeElectrum:
+		if wallet.ElectrumServer() == nil {
+			return errors.E(utils.ErrInvalid, "Electrum server is required")
+		}
+	default:
+		return errors.E(utils.ErrInvalid, fmt.Sprintf("unknown network mode %q", mode))
+	}
+
+	if node != nil {
+		node.Address = strings.TrimSpace(node.Address)
+		node.Cert = strings.TrimSpace(node.Cert)
+		if err := node.Validate(); err != nil {
+			return err
+		}
+		node.HasPassword = node.Password != ""
+		if err := wallet.walletConfigSave(RPCNodeConfigKey, node); err != nil {
+			return err
+		}
+		wallet.SetRPCPassword(node.Password)
+	}
+	return wallet.walletConfigSave(NetworkModeConfigKey, mode)
+}
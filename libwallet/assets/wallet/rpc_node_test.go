@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func testCert(t *testing.T) string {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "node"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestRPCNodeValidate(t *testing.T) {
+	cert := testCert(t)
+
+	tests := []struct {
+		name    string
+		node    RPCNode
+		wantErr bool
+	}{
+		{"local without TLS", RPCNode{Address: "127.0.0.1:19556"}, false},
+		{"localhost without TLS", RPCNode{Address: "localhost:8334"}, false},
+		{"ipv6 loopback without TLS", RPCNode{Address: "[::1]:9109"}, false},
+		{"remote with TLS", RPCNode{Address: "node.example.com:9109", Cert: cert}, false},
+		{"remote without TLS", RPCNode{Address: "node.example.com:9109"}, true},
+		{"missing port", RPCNode{Address: "127.0.0.1"}, true},
+		{"missing host", RPCNode{Address: ":9109"}, true},
+		{"invalid certificate", RPCNode{Address: "127.0.0.1:9109", Cert: "not a certificate"}, true},
+		{"local bitcoind", RPCNode{Backend: RPCBackendBitcoind, Address: "127.0.0.1:8332"}, false},
+		{"remote bitcoind", RPCNode{Backend: RPCBackendBitcoind, Address: "node.example.com:8332"}, true},
+		{"bitcoind with TLS", RPCNode{Backend: RPCBackendBitcoind, Address: "node.example.com:8332", Cert: cert}, true},
+		{"unknown backend", RPCNode{Backend: "electrs", Address: "127.0.0.1:8332"}, true},
+	}
+
+	for _, test := range tests {
+		err := test.node.Validate()
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.wantErr, err)
+		}
+	}
+
+	if (&RPCNode{Cert: cert}).DisableTLS() || !(&RPCNode{}).DisableTLS() {
+		t.Error("unexpected DisableTLS")
+	}
+}
@@ -0,0 +1,176 @@
+package wallet
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+// InputScriptType is the kind of script an input of a transaction to sign
+// spends.
+type InputScriptType uint32
+
+// The values match the InputScriptType enum of the Trezor protocol.
+const (
+	SpendAddress     InputScriptType = 0 // P2PKH
+	SpendWitness     InputScriptType = 3 // P2WPKH
+	SpendP2SHWitness InputScriptType = 4 // P2WPKH nested in P2SH
+	SpendTaproot     InputScriptType = 5 // P2TR key path
+)
+
+// Signer signs the transactions authored by a wallet. The software wallet
+// signs with the keys derived from its seed, a hardware signer passes the
+// transaction to the device that holds the keys of a watch-only wallet.
+type Signer interface {
+	// SignTx signs every input of the transaction of the request and
+	// returns the serialized signed transaction. The passphrase is the
+	// private passphrase of a software wallet or the passphrase of the
+	// hidden wallet of a device, which is empty for its standard wallet.
+	SignTx(ctx context.Context, req *SignRequest, passphrase string) ([]byte, error)
+}
+
+// SignRequest describes a transaction to sign. Software signers only use the
+// serialized unsigned transaction, hardware signers need the other fields to
+// stream the transaction to the device.
+type SignRequest struct {
+	UnsignedTx []byte
+
+	Version  uint32
+	LockTime uint32
+	// Expiry is the expiry height of a Decred transaction.
+	Expiry  uint32
+	Inputs  []*SignInput
+	Outputs []*SignOutput
+	// PrevTxs are the transactions spent by the inputs, keyed by their
+	// hash. Devices check the amounts of the inputs against them.
+	PrevTxs map[string]*PrevTx
+}
+
+// SignInput is an input of a transaction to sign.
+type SignInput struct {
+	PrevHash  string
+	PrevIndex uint32
+	// Tree is the tree of the spent output of a Decred transaction.
+	Tree     int8
+	Sequence uint32
+	Amount   int64
+	PkScript []byte
+	// Path is the BIP32 derivation path of the key of the spent output,
+	// from the master key of the device.
+	Path []uint32
+	// PubKey is the public key of the spent output, if the wallet knows it.
+	PubKey     []byte
+	ScriptType InputScriptType
+}
+
+// SignOutput is an output of a transaction to sign.
+type SignOutput struct {
+	Address  string
+	Amount   int64
+	PkScript []byte
+}
+
+// PrevTx is a transaction spent by a transaction to sign.
+type PrevTx struct {
+	Version  uint32
+	LockTime uint32
+	Expiry   uint32
+	Inputs   []*PrevTxInput
+	Outputs  []*PrevTxOutput
+}
+
+// PrevTxInput is an input of a spent transaction.
+type PrevTxInput struct {
+	PrevHash  string
+	PrevIndex uint32
+	Tree      int8
+	Sequence  uint32
+	ScriptSig []byte
+}
+
+// PrevTxOutput is an output of a spent transaction.
+type PrevTxOutput struct {
+	Amount        int64
+	PkScript      []byte
+	ScriptVersion uint16
+}
+
+const (
+	// HardwareSignerTrezor is the type of Trezor devices.
+	HardwareSignerTrezor = "trezor"
+
+	// HardwareTransportBridge connects to a device through the Trezor
+	// Bridge service, which owns the USB connection.
+	HardwareTransportBridge = "bridge"
+	// HardwareTransportUDP connects to the UDP port of the Trezor
+	// emulator.
+	HardwareTransportUDP = "udp"
+)
+
+// HardwareSignerConfig is the device that signs the transactions of a
+// watch-only wallet created from one of its extended public keys.
+type HardwareSignerConfig struct {
+	Type string `json:"type"`
+	// Transport is how the device is reached, either through the bridge or
+	// the UDP port of an emulator.
+	Transport string `json:"transport"`
+	// Address is the host:port of the bridge or emulator. It defaults to
+	// their standard local port when empty.
+	Address string `json:"address"`
+}
+
+// Validate checks the type, transport and address of the device.
+func (cfg *HardwareSignerConfig) Validate() error {
+	if cfg.Type != HardwareSignerTrezor {
+		return errors.E(errors.Invalid, "unsupported hardware signer "+cfg.Type)
+	}
+	if cfg.Transport != HardwareTransportBridge && cfg.Transport != HardwareTransportUDP {
+		return errors.E(errors.Invalid, "unsupported hardware signer transport "+cfg.Transport)
+	}
+	if cfg.Address != "" {
+		if _, _, err := net.SplitHostPort(cfg.Address); err != nil {
+			return errors.E(errors.Invalid, "hardware signer address must be host:port")
+		}
+	}
+	return nil
+}
+
+// HardwareSigner returns the device that signs the transactions of the
+// wallet, or nil if the wallet signs with its own keys.
+func (wallet *Wallet) HardwareSigner() *HardwareSignerConfig {
+	cfg := new(HardwareSignerConfig)
+	if err := wallet.ReadUserConfigValue(HardwareSignerConfigKey, cfg); err != nil || cfg.Type == "" {
+		return nil
+	}
+	return cfg
+}
+
+// SaveHardwareSigner sets the device that signs the transactions of the
+// watch-only wallet. A nil config removes the device.
+func (wallet *Wallet) SaveHardwareSigner(cfg *HardwareSignerConfig) error {
+	if cfg == nil {
+		return wallet.walletConfigSave(HardwareSignerConfigKey, &HardwareSignerConfig{})
+	}
+	if !wallet.IsWatchingOnlyWallet() {
+		return errors.E(errors.Invalid, "only watch-only wallets sign with a hardware signer")
+	}
+	cfg.Address = strings.TrimSpace(cfg.Address)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return wallet.walletConfigSave(HardwareSignerConfigKey, cfg)
+}
+
+// AirgapSigner returns true if the transactions of the watch-only wallet are
+// signed by an offline wallet holding its seed.
+func (wallet *Wallet) AirgapSigner() bool {
+	return wallet.IsWatchingOnlyWallet() && wallet.ReadBoolConfigValueForKey(AirgapSignerConfigKey, false)
+}
+
+// CanSign returns true if the wallet can sign its transactions, either with
+// its own keys, with a hardware signer or with an offline wallet.
+func (wallet *Wallet) CanSign() bool {
+	return !wallet.IsWatchingOnlyWallet() || wallet.HardwareSigner() != nil || wallet.AirgapSigner()
+}
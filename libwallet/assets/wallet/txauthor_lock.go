@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"context"
+)
+
+// txAuthor returns the lock of the wallet's unsigned transaction.
+func (wallet *Wallet) txAuthor() chan struct{} {
+	wallet.txAuthorLockOnce.Do(func() {
+		wallet.txAuthorLock = make(chan struct{}, 1)
+	})
+	return wallet.txAuthorLock
+}
+
+// LockTxAuthor waits until no other transaction is authored on the wallet and
+// reserves the wallet's unsigned transaction, so that NewUnsignedTx does not
+// replace it before it is broadcast. The lock is held from NewUnsignedTx
+// through Broadcast and released with UnlockTxAuthor.
+func (wallet *Wallet) LockTxAuthor(ctx context.Context) error {
+	select {
+	case wallet.txAuthor() <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryLockTxAuthor reserves the wallet's unsigned transaction like
+// LockTxAuthor if no other transaction is authored on the wallet, and returns
+// false otherwise.
+func (wallet *Wallet) TryLockTxAuthor() bool {
+	select {
+	case wallet.txAuthor() <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// UnlockTxAuthor releases the wallet's unsigned transaction reserved with
+// LockTxAuthor or TryLockTxAuthor.
+func (wallet *Wallet) UnlockTxAuthor() {
+	select {
+	case <-wallet.txAuthor():
+	default:
+	}
+}
@@ -222,6 +222,12 @@ type PeerInfo struct {
 	SubVer         string `json:"sub_ver"`
 	StartingHeight int64  `json:"starting_height"`
 	BanScore       int32  `json:"ban_score"`
+	LastBlock      int32  `json:"last_block"`
+	// Latency, BytesSent and BytesReceived are zero if the backend does
+	// not report them.
+	Latency       time.Duration `json:"latency"`
+	BytesSent     uint64        `json:"bytes_sent"`
+	BytesReceived uint64        `json:"bytes_received"`
 }
 
 /** begin sync-related types */
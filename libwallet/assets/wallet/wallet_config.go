@@ -18,6 +18,8 @@ const (
 	PrivacyModeConfigKey        = "privacy_mode"
 	SpendUnconfirmedConfigKey   = "spend_unconfirmed"
 	CurrencyConversionConfigKey = "currency_conversion_option"
+	CustomRateSourcesConfigKey  = "custom_rate_sources"
+	FiatCurrencyConfigKey       = "fiat_currency"
 
 	IsStartupSecuritySetConfigKey = "startup_security_set"
 	StartupSecurityTypeConfigKey  = "startup_security_type"
@@ -28,6 +30,10 @@ const (
 
 	SyncOnCellularConfigKey             = "always_sync"
 	NetworkModeConfigKey                = "network_mode"
+	RPCNodeConfigKey                    = "rpc_node"
+	ElectrumServerConfigKey             = "electrum_server"
+	BannedPeersConfigKey                = "banned_peers"
+	PreferredPeersConfigKey             = "preferred_peers"
 	SpvPersistentPeerAddressesConfigKey = "spv_peer_addresses"
 	UserAgentConfigKey                  = "user_agent"
 
@@ -37,6 +43,10 @@ const (
 
 	KnownVSPsConfigKey = "known_vsps"
 
+	MultisigAccountsConfigKey = "multisig_accounts"
+	HardwareSignerConfigKey   = "hardware_signer"
+	AirgapSignerConfigKey     = "airgap_signer"
+
 	TicketBuyerVSPHostConfigKey = "tb_vsp_host"
 	TicketBuyerWalletConfigKey  = "tb_wallet_id"
 	TicketBuyerAccountConfigKey = "tb_account_number"
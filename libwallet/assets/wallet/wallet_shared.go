@@ -44,6 +44,19 @@ type Wallet struct {
 	// sync switch is disabled from receiving more user clicks until its over.
 	isSyncShuttingDown atomic.Bool
 
+	// peersMu serializes the updates of the banned and preferred peers.
+	peersMu sync.Mutex
+
+	// rpcPassword is the password of the full node of the RPC network mode,
+	// kept in memory only.
+	rpcPassword   string
+	rpcPasswordMu sync.Mutex
+
+	// txAuthorLock is held by whoever authors the wallet's single unsigned
+	// transaction, see LockTxAuthor.
+	txAuthorLock     chan struct{}
+	txAuthorLockOnce sync.Once
+
 	// Birthday holds the timestamp of the birthday block from where wallet
 	// restoration begins from. CreatedAt is available for audit purposes
 	// in relation to how long the wallet has been in existence.
@@ -208,13 +221,19 @@ func (wallet *Wallet) DataDir() string {
 }
 
 func (wallet *Wallet) dataDir() string {
+	return WalletDataDir(wallet.rootDir, wallet.netType, wallet.Type, wallet.ID)
+}
+
+// WalletDataDir returns the data directory of the wallet of the asset type and
+// ID in the root directory.
+func WalletDataDir(rootDir string, netType utils.NetworkType, assetType utils.AssetType, walletID int) string {
 	dirName := ""
 	// testnet datadir takes a special structure to differentiate "testnet4" and "testnet3"
 	// data directory.
-	if wallet.netType == utils.Testnet {
-		dirName = utils.NetDir(wallet.Type, wallet.netType)
+	if netType == utils.Testnet {
+		dirName = utils.NetDir(assetType, netType)
 	}
-	return filepath.Join(wallet.rootDir, dirName, wallet.Type.ToStringLower(), strconv.Itoa(wallet.ID))
+	return filepath.Join(rootDir, dirName, assetType.ToStringLower(), strconv.Itoa(walletID))
 }
 
 // RootDir returns the root of current wallet bucket. It is exported via the interface
@@ -556,6 +575,55 @@ func RestoreWallet(seedMnemonic string, pass *AuthInfo, loader loader.AssetLoade
 	return wallet, nil
 }
 
+// RecreateWallet replaces the wallet database of the existing wallet with one
+// restored from the seed mnemonic. The wallet keeps its ID, so its config and
+// the other records saved against its ID are preserved. The former data
+// directory of the wallet is kept as a backup.
+func (wallet *Wallet) RecreateWallet(seedMnemonic, privatePassphrase string, wordSeedType WordSeedType,
+	loader loader.AssetLoader, params *InitParams,
+) error {
+	wallet.mu.Lock()
+	wallet.db = params.DB
+	wallet.dbDriver = params.DbDriver
+	wallet.loader = loader
+	wallet.netType = params.NetType
+	wallet.rootDir = params.RootDir
+	wallet.logDir = params.LogDir
+
+	walletDataDir := wallet.dataDir()
+	dirExists, err := fileExists(walletDataDir)
+	if err == nil && dirExists {
+		var backupDir string
+		backupDir, err = backupFile(walletDataDir, 1)
+		if err == nil {
+			log.Infof("Data directory of wallet %d moved to %s", wallet.ID, backupDir)
+		}
+	}
+	if err == nil {
+		err = os.MkdirAll(walletDataDir, utils.UserFilePerm)
+	}
+	if err == nil {
+		err = wallet.prepare()
+	}
+	wallet.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := wallet.createWallet(privatePassphrase, seedMnemonic, wordSeedType); err != nil {
+		if closeErr := wallet.walletDataDB.Close(); closeErr != nil {
+			log.Errorf("tx db closed with error: %v", closeErr)
+		}
+		return err
+	}
+
+	// Setting HasDiscoveredAccounts to false triggers the address recovery of
+	// the restored wallet on startup.
+	wallet.IsRestored = true
+	wallet.HasDiscoveredAccounts = false
+	return utils.TranslateError(wallet.db.Save(wallet))
+}
+
 func (wallet *Wallet) WalletNameExists(walletName string) (bool, error) {
 	if strings.HasPrefix(walletName, reservedWalletPrefix) {
 		return false, errors.E(utils.ErrReservedWalletName)
@@ -3,6 +3,7 @@ package walletdata
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/asdine/storm"
 	bolt "go.etcd.io/bbolt"
@@ -81,6 +82,27 @@ func (db *DB) Close() error {
 	return db.walletDataDB.Close()
 }
 
+// ReadIndexInfo reads the version and the tx index end block of the wallet
+// data database at dbPath without upgrading it. The database must not be open.
+func ReadIndexInfo(dbPath string) (version uint32, endBlock int32, err error) {
+	walletDataDB, err := storm.Open(dbPath, storm.BoltOptions(0600, &bolt.Options{ReadOnly: true, Timeout: time.Second}))
+	if err != nil {
+		return 0, 0, fmt.Errorf("error opening wallet data database: %s", err.Error())
+	}
+	defer walletDataDB.Close()
+
+	// Earlier db versions did not set a version number nor an end block.
+	err = walletDataDB.Get(TxBucketName, KeyDbVersion, &version)
+	if err != nil && err != storm.ErrNotFound {
+		return 0, 0, fmt.Errorf("error reading wallet data database version: %s", err.Error())
+	}
+	err = walletDataDB.Get(TxBucketName, KeyEndBlock, &endBlock)
+	if err != nil && err != storm.ErrNotFound {
+		return 0, 0, fmt.Errorf("error reading tx index end block: %s", err.Error())
+	}
+	return version, endBlock, nil
+}
+
 func openOrCreateDB(dbPath string) (*storm.DB, error) {
 	var isNewDbFile bool
 
@@ -13,6 +13,7 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -178,6 +179,77 @@ func (mgr *AssetsManager) SetCurrencyConversionExchange(xc string) {
 	}()
 }
 
+// CustomRateSources returns the user defined rate sources.
+func (mgr *AssetsManager) CustomRateSources() []ext.HTTPSourceConfig {
+	var sources []ext.HTTPSourceConfig
+	mgr.ReadAppConfigValue(sharedW.CustomRateSourcesConfigKey, &sources)
+	return sources
+}
+
+// AddCustomRateSource registers and saves a user defined rate source.
+func (mgr *AssetsManager) AddCustomRateSource(cfg ext.HTTPSourceConfig) error {
+	source, err := ext.NewHTTPSource(cfg)
+	if err != nil {
+		return err
+	}
+	if err = ext.RegisterProvider(source); err != nil {
+		return err
+	}
+
+	mgr.SaveAppConfigValue(sharedW.CustomRateSourcesConfigKey, append(mgr.CustomRateSources(), cfg))
+	return nil
+}
+
+// RemoveCustomRateSource removes a user defined rate source. The currency
+// conversion is disabled if the source is in use.
+func (mgr *AssetsManager) RemoveCustomRateSource(name string) {
+	sources := mgr.CustomRateSources()
+	for i, cfg := range sources {
+		if cfg.Name == name {
+			sources = append(sources[:i], sources[i+1:]...)
+			break
+		}
+	}
+	mgr.SaveAppConfigValue(sharedW.CustomRateSourcesConfigKey, sources)
+	ext.UnregisterProvider(name)
+
+	if mgr.GetCurrencyConversionExchange() == name {
+		mgr.SetCurrencyConversionExchange(values.DefaultExchangeValue)
+	}
+}
+
+// registerCustomRateSources registers the saved user defined rate sources.
+func (mgr *AssetsManager) registerCustomRateSources() {
+	for _, cfg := range mgr.CustomRateSources() {
+		source, err := ext.NewHTTPSource(cfg)
+		if err == nil {
+			err = ext.RegisterProvider(source)
+		}
+		if err != nil {
+			log.Errorf("Failed to register rate source %s: %v", cfg.Name, err)
+		}
+	}
+}
+
+// GetFiatCurrency returns the ISO 4217 code of the currency balances are
+// displayed in.
+func (mgr *AssetsManager) GetFiatCurrency() string {
+	var code string
+	mgr.ReadAppConfigValue(sharedW.FiatCurrencyConfigKey, &code)
+	if code == "" {
+		return values.DefaultFiatCurrency
+	}
+	return code
+}
+
+// SetFiatCurrency sets the currency balances are displayed in.
+func (mgr *AssetsManager) SetFiatCurrency(code string) {
+	mgr.SaveAppConfigValue(sharedW.FiatCurrencyConfigKey, code)
+	if mgr.RateSource != nil {
+		go mgr.RateSource.Refresh(false)
+	}
+}
+
 // ExchangeRateFetchingEnabled returns true if privacy mode isn't turned on and
 // a valid exchange rate source is configured.
 func (mgr *AssetsManager) ExchangeRateFetchingEnabled() bool {
@@ -17,9 +17,16 @@ import (
 	"github.com/asdine/storm/q"
 	"github.com/crypto-power/cryptopower/appos"
 	"github.com/crypto-power/cryptopower/dexc"
+	"github.com/crypto-power/cryptopower/libwallet/dbmigrate"
+	"github.com/crypto-power/cryptopower/libwallet/dexbot"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/hooks"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	"github.com/crypto-power/cryptopower/libwallet/internal/politeia"
+	"github.com/crypto-power/cryptopower/libwallet/multisig"
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/libwallet/payrequests"
+	"github.com/crypto-power/cryptopower/libwallet/portfolio"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/notification"
@@ -40,8 +47,8 @@ const LogFilename = "cryptopower.log"
 // assetIdentifier use for listen balance of all wallet changed
 const assetIdentifier = "assets_manager"
 
-const BoltDB = "bdb"        // Bolt db driver
-const BadgerDB = "badgerdb" // Badger db driver
+const BoltDB = dbmigrate.BoltDB     // Bolt db driver
+const BadgerDB = dbmigrate.BadgerDB // Badger db driver
 
 // Assets is a struct that holds all the assets supported by the wallet.
 type Assets struct {
@@ -72,10 +79,25 @@ type AssetsManager struct {
 	ConsensusAgenda *dcr.ConsensusAgenda
 	Politeia        *politeia.Politeia
 	InstantSwap     *instantswap.InstantSwap
+	DEXBots         *dexbot.Manager
+	PriceCache      *portfolio.PriceCache
+	Notifications   *notifications.Center
+	Hooks           *hooks.Manager
+	PaymentRequests *payrequests.Manager
+	Multisig        *multisig.Manager
 	ExternalService *ext.Service
 	RateSource      ext.RateSource
 	rateMutex       sync.Mutex
 
+	// The event dispatcher, see startEventDispatcher.
+	eventsOnce         sync.Once
+	eventsMtx          sync.RWMutex
+	eventsCtx          context.Context
+	eventSubscribers   []*eventSubscriber
+	dexEventsListening atomic.Bool
+
+	orderWatcherMu sync.Mutex
+
 	dexcMtx     sync.RWMutex
 	dexcCtx     context.Context
 	dexc        DEXClient
@@ -84,6 +106,9 @@ type AssetsManager struct {
 	//TODO: some time need show message for user. Change it if has other solution
 	toast *notification.Toast
 
+	// badWalletErrors are the load errors of the bad wallets by ID.
+	badWalletErrors map[int]error
+
 	NeedMigrate bool
 }
 
@@ -117,8 +142,9 @@ func initializeAssetsFields(rootDir, dbDriver, logDir string, netType utils.Netw
 	}
 
 	mgr := &AssetsManager{
-		params: params,
-		Assets: new(Assets),
+		params:          params,
+		Assets:          new(Assets),
+		badWalletErrors: make(map[int]error),
 	}
 
 	mgr.Assets.BTC.Wallets = make(map[int]sharedW.Asset)
@@ -152,14 +178,18 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 	needMigrate := false
 	isMobile := appos.Current().IsMobile()
 
-	dbDriver := BoltDB
+	dbDriver, otherDBDriver := BoltDB, BadgerDB
 	if isMobile {
-		dbDriver = BadgerDB
+		dbDriver, otherDBDriver = BadgerDB, BoltDB
 	}
 
 	if fileExists(filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))) {
 		// New db
 		rootDir = filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))
+	} else if fileExists(filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), otherDBDriver))) {
+		// The wallets were migrated to the db driver of the other platforms.
+		dbDriver = otherDBDriver
+		rootDir = filepath.Join(rootDir, fmt.Sprintf("%s-%s", string(netType), dbDriver))
 	} else if fileExists(filepath.Join(rootDir, string(netType))) {
 		// old db
 		dbDriver = BoltDB
@@ -218,11 +248,47 @@ func NewAssetsManager(rootDir, logDir string, netType utils.NetworkType, dexTest
 		return nil, err
 	}
 
+	dexBots, err := dexbot.NewManager(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	priceCache, err := portfolio.NewPriceCache(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationCenter, err := notifications.NewCenter(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	hooksManager, err := hooks.NewManager(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRequests, err := payrequests.NewManager(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
+	multisigManager, err := multisig.NewManager(mwDB)
+	if err != nil {
+		return nil, err
+	}
+
 	mgr.ConsensusAgenda = dcr.NewConsensusAgenda(mgr.chainsParams.DCR, mwDB)
 
 	mgr.params.DB = mwDB
 	mgr.Politeia = politeia
 	mgr.InstantSwap = instantSwap
+	mgr.DEXBots = dexBots
+	mgr.PriceCache = priceCache
+	mgr.Notifications = notificationCenter
+	mgr.Hooks = hooksManager
+	mgr.PaymentRequests = paymentRequests
+	mgr.Multisig = multisigManager
 
 	// initialize the ExternalService. ExternalService provides assetsManager
 	// with the functionalities to retrieve data from some 3rd party services.
@@ -284,6 +350,7 @@ func (mgr *AssetsManager) initRateSource() (err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 	mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
 
+	mgr.registerCustomRateSources()
 	rateSource := mgr.GetCurrencyConversionExchange()
 	disabled := mgr.IsPrivacyModeOn()
 
@@ -293,6 +360,7 @@ func (mgr *AssetsManager) initRateSource() (err error) {
 	}
 
 	mgr.RateSource.ToggleStatus(disabled)
+	mgr.listenForPortfolioPrices()
 
 	// Start the refresh goroutine even if rate source is disabled.
 	go func() {
@@ -341,7 +409,8 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 			w, err := btc.LoadExisting(wallet, mgr.params)
 			if err != nil {
 				mgr.Assets.BTC.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored btc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
+				mgr.badWalletErrors[wallet.ID] = err
+				log.Warnf("Ignored btc wallet load error for wallet %d (%s): %v", wallet.ID, wallet.Name, err)
 			} else {
 				mgr.Assets.BTC.Wallets[wallet.ID] = w
 			}
@@ -350,7 +419,8 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 			w, err := dcr.LoadExisting(wallet, mgr.params)
 			if err != nil {
 				mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored dcr wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
+				mgr.badWalletErrors[wallet.ID] = err
+				log.Warnf("Ignored dcr wallet load error for wallet %d (%s): %v", wallet.ID, wallet.Name, err)
 			} else {
 				mgr.Assets.DCR.Wallets[wallet.ID] = w
 			}
@@ -359,7 +429,8 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 			w, err := ltc.LoadExisting(wallet, mgr.params)
 			if err != nil {
 				mgr.Assets.LTC.BadWallets[wallet.ID] = wallet
-				log.Warnf("Ignored ltc wallet load error for wallet %d (%s)", wallet.ID, wallet.Name)
+				mgr.badWalletErrors[wallet.ID] = err
+				log.Warnf("Ignored ltc wallet load error for wallet %d (%s): %v", wallet.ID, wallet.Name, err)
 			} else {
 				mgr.Assets.LTC.Wallets[wallet.ID] = w
 			}
@@ -367,6 +438,7 @@ func (mgr *AssetsManager) prepareExistingWallets() error {
 		default:
 			// Classify all wallets with missing AssetTypes as DCR badwallets.
 			mgr.Assets.DCR.BadWallets[wallet.ID] = wallet
+			mgr.badWalletErrors[wallet.ID] = errors.Errorf("unknown asset type %q", wallet.Type)
 		}
 	}
 	return nil
@@ -400,8 +472,16 @@ func (mgr *AssetsManager) Shutdown() {
 		mgr.InstantSwap.StopSync()
 	}
 
-	// Shutdown dexc before closing wallets.
+	// Stop running order schedules. They remain active and are resumed from
+	// their next due run when started again.
+	for _, scheduleID := range mgr.RunningScheduleIDs() {
+		mgr.cancelSchedule(scheduleID)
+	}
+
+	// Shutdown dexc before closing wallets. Running bots are stopped first so
+	// that their orders are canceled.
 	if mgr.DEXCInitialized() {
+		mgr.DEXBots.StopAll()
 		mgr.dexcMtx.RLock()
 		mgr.dexc.Shutdown()
 		mgr.dexc.WaitForShutdown()
@@ -477,6 +557,7 @@ func (mgr *AssetsManager) OpenWallets(startupPassphrase string) error {
 		}
 	}
 
+	mgr.startEventDispatcher()
 	return nil
 }
 
@@ -599,6 +680,13 @@ func (mgr *AssetsManager) DeleteWallet(walletID int, privPass string) error {
 		delete(mgr.Assets.LTC.Wallets, walletID)
 	}
 
+	if err := mgr.PaymentRequests.DeleteWalletRequests(walletID); err != nil {
+		log.Errorf("Error deleting the payment requests of wallet %d: %v", walletID, err)
+	}
+	if err := mgr.Multisig.DeleteSignerWallets(walletID); err != nil {
+		log.Errorf("Error removing wallet %d from the multisig wallets: %v", walletID, err)
+	}
+
 	return nil
 }
 
@@ -666,16 +754,22 @@ func (mgr *AssetsManager) DeleteBadWallet(walletID int) error {
 
 	os.RemoveAll(wallet.DataDir())
 
+	mgr.removeBadWallet(wallet)
+	return nil
+}
+
+// removeBadWallet removes the wallet from the bad wallets.
+func (mgr *AssetsManager) removeBadWallet(wallet *sharedW.Wallet) {
+	delete(mgr.badWalletErrors, wallet.ID)
 	switch wallet.GetAssetType() {
 	case utils.BTCWalletAsset:
-		delete(mgr.Assets.BTC.BadWallets, walletID)
-	case utils.DCRWalletAsset:
-		delete(mgr.Assets.DCR.BadWallets, walletID)
+		delete(mgr.Assets.BTC.BadWallets, wallet.ID)
 	case utils.LTCWalletAsset:
-		delete(mgr.Assets.LTC.BadWallets, walletID)
+		delete(mgr.Assets.LTC.BadWallets, wallet.ID)
+	default:
+		// Wallets of unknown asset types are DCR bad wallets.
+		delete(mgr.Assets.DCR.BadWallets, wallet.ID)
 	}
-
-	return nil
 }
 
 // Check if wallet name already exists
@@ -952,6 +1046,54 @@ func (mgr *AssetsManager) CalculateAssetsUSDBalance(balances map[utils.AssetType
 	return assetsTotalUSDBalance, nil
 }
 
+// USDToFiat converts a USD amount to the fiat currency with the ISO 4217
+// code.
+func (mgr *AssetsManager) USDToFiat(usd float64, code string) (float64, error) {
+	if mgr.RateSource == nil {
+		return 0, fmt.Errorf("no %s rate information available", code)
+	}
+	rate, ok := mgr.RateSource.FiatRate(code)
+	if !ok {
+		return 0, fmt.Errorf("no %s rate information available", code)
+	}
+	return usd * rate, nil
+}
+
+// AssetFiatRate returns the price of a coin of the asset in the fiat currency
+// with the ISO 4217 code.
+func (mgr *AssetsManager) AssetFiatRate(assetType utils.AssetType, code string) (float64, error) {
+	market, exist := values.AssetExchangeMarketValue[assetType]
+	if !exist {
+		return 0, fmt.Errorf("unsupported asset type: %s", assetType)
+	}
+
+	if mgr.RateSource == nil {
+		return 0, fmt.Errorf("no rate information available")
+	}
+	rate := mgr.RateSource.GetTicker(market, true)
+	if rate == nil || rate.LastTradePrice <= 0 {
+		return 0, fmt.Errorf("no rate information available")
+	}
+	return mgr.USDToFiat(rate.LastTradePrice, code)
+}
+
+// CalculateAssetsFiatBalance converts the balances to the fiat currency with
+// the ISO 4217 code.
+func (mgr *AssetsManager) CalculateAssetsFiatBalance(balances map[utils.AssetType]sharedW.AssetAmount, code string) (map[utils.AssetType]float64, error) {
+	usdBalances, err := mgr.CalculateAssetsUSDBalance(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	fiatBalances := make(map[utils.AssetType]float64, len(usdBalances))
+	for assetType, usdBalance := range usdBalances {
+		if fiatBalances[assetType], err = mgr.USDToFiat(usdBalance, code); err != nil {
+			return nil, err
+		}
+	}
+	return fiatBalances, nil
+}
+
 // DexClient returns a dexc client that MUST never be modified.
 func (mgr *AssetsManager) DexClient() DEXClient {
 	mgr.dexcMtx.RLock()
@@ -1016,6 +1158,7 @@ func (mgr *AssetsManager) InitializeDEX() {
 	mgr.dexcMtx.Lock()
 	mgr.dexc = dexClient
 	mgr.dexcMtx.Unlock()
+	mgr.listenForDEXEvents()
 
 	go func() {
 		<-dexClient.WaitForShutdown()
@@ -1032,6 +1175,8 @@ func (mgr *AssetsManager) DeleteDEXData() error {
 		return nil // nothing to do.
 	}
 
+	mgr.DEXBots.StopAll()
+
 	mgr.dexcMtx.Lock()
 	defer mgr.dexcMtx.Unlock()
 
@@ -16,6 +16,10 @@ import (
 	"github.com/dgraph-io/badger/options"
 )
 
+// maxPendingRestoreWrites is the number of pending writes of the database
+// restore.
+const maxPendingRestoreWrites = 256
+
 // convertErr wraps a driver-specific error with an error code.
 func convertErr(err error) error {
 	if err == nil {
@@ -601,12 +605,48 @@ func (db *db) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
 	return db.beginTx(true)
 }
 
-// Copy writes a copy of the database to the provided writer.  This call will
-// start a read-only transaction to perform all operations.
+// Copy writes a copy of the database to the provided writer.  The copy is a
+// badger backup stream of a consistent snapshot of the database, which
+// Restore loads into a new database.
 //
 // This function is part of the walletdb.DB interface implementation.
-func (db *db) Copy(_ io.Writer) error {
-	return errors.E(errors.Invalid, "method not implemented")
+func (db *db) Copy(w io.Writer) error {
+	if db.closed {
+		return errors.E(errors.Invalid)
+	}
+
+	_, err := db.DB.Backup(w, 0)
+	return convertErr(err)
+}
+
+// ForEachBucket invokes the passed function with the key of every top-level
+// bucket of the database.
+func (db *db) ForEachBucket(fn func(key []byte) error) error {
+	if db.closed {
+		return errors.E(errors.Invalid)
+	}
+
+	return db.DB.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			if item.UserMeta() != metaBucket {
+				continue
+			}
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return convertErr(err)
+			}
+			// The prefix of the top-level buckets is their whole key.
+			if len(val) > 0 && int(val[0]) == len(item.Key()) {
+				if err := fn(item.KeyCopy(nil)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
 }
 
 // Close cleanly shuts down the database and syncs all data.
@@ -665,3 +705,23 @@ func openDB(dbPath string, create bool) (walletdb.DB, error) {
 
 	return d, convertErr(err)
 }
+
+// Restore creates a new database at dbPath from a copy of a database written
+// by Copy.
+func Restore(r io.Reader, dbPath string) error {
+	if fileExists(dbPath) {
+		return errors.E(errors.Exist, "database already exists")
+	}
+
+	d, err := openDB(dbPath, true)
+	if err != nil {
+		return err
+	}
+	restored := d.(*db)
+	if err := restored.DB.Load(r, maxPendingRestoreWrites); err != nil {
+		_ = restored.Close()
+		_ = os.RemoveAll(dbPath)
+		return convertErr(err)
+	}
+	return restored.Close()
+}
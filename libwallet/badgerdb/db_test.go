@@ -0,0 +1,76 @@
+package badgerdb
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+)
+
+func TestCopyRestore(t *testing.T) {
+	dir := t.TempDir()
+
+	wdb, err := walletdb.Create(dbType, filepath.Join(dir, "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wdb.Close()
+
+	err = walletdb.Update(nil, wdb, func(tx walletdb.ReadWriteTx) error {
+		b, err := tx.CreateTopLevelBucket([]byte("waddrmgr"))
+		if err != nil {
+			return err
+		}
+		nested, err := b.CreateBucket([]byte("acct"))
+		if err != nil {
+			return err
+		}
+		return nested.Put([]byte("name"), []byte("savings"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var backup bytes.Buffer
+	if err := wdb.Copy(&backup); err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+
+	restoredPath := filepath.Join(dir, "restored.db")
+	if err := Restore(&backup, restoredPath); err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	restored, err := walletdb.Open(dbType, restoredPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	var buckets []string
+	err = restored.(*db).ForEachBucket(func(key []byte) error {
+		buckets = append(buckets, string(key))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 1 || buckets[0] != "waddrmgr" {
+		t.Fatalf("expected the waddrmgr top-level bucket, got %v", buckets)
+	}
+
+	err = walletdb.View(nil, restored, func(tx walletdb.ReadTx) error {
+		nested := tx.ReadBucket([]byte("waddrmgr")).NestedReadBucket([]byte("acct"))
+		if nested == nil {
+			t.Fatal("missing nested bucket")
+		}
+		if got := nested.Get([]byte("name")); string(got) != "savings" {
+			t.Fatalf("expected savings, got %s", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
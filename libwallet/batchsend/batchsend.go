@@ -0,0 +1,251 @@
+package batchsend
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// ParseCSV reads the address, amount and optional label columns of the
+// payments in r. Amounts are in coins. A header row is skipped. The rows are
+// not validated, see Validate.
+func ParseCSV(r io.Reader) ([]*Row, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []*Row
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(rows) == 0 && isHeader(record) {
+			continue
+		}
+
+		row := &Row{Line: line}
+		rows = append(rows, row)
+		if len(record) < 2 {
+			row.Error = ErrMissingField
+			continue
+		}
+
+		row.Address = strings.TrimSpace(record[0])
+		if len(record) > 2 {
+			row.Label = strings.TrimSpace(record[2])
+		}
+		if row.Amount, err = parseAtoms(record[1]); err != nil {
+			row.Error = ErrInvalidAmount
+		}
+	}
+	return rows, nil
+}
+
+// isHeader returns true if record is the header row of the CSV file.
+func isHeader(record []string) bool {
+	if len(record) < 2 {
+		return false
+	}
+	_, err := parseAtoms(record[1])
+	return err != nil && strings.EqualFold(strings.TrimSpace(record[0]), "address")
+}
+
+// parseAtoms converts a decimal coin amount to atoms without floating point
+// rounding.
+func parseAtoms(amount string) (int64, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if whole == "" && fraction == "" || len(fraction) > coinDecimals {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	fraction += strings.Repeat("0", coinDecimals-len(fraction))
+	for _, digits := range []string{whole, fraction} {
+		for _, c := range digits {
+			if c < '0' || c > '9' {
+				return 0, fmt.Errorf("invalid amount %q", amount)
+			}
+		}
+	}
+
+	var atoms int64
+	if whole != "" {
+		coins, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || coins > math.MaxInt64/atomsPerCoin {
+			return 0, fmt.Errorf("invalid amount %q", amount)
+		}
+		atoms = coins * atomsPerCoin
+	}
+	fractionAtoms, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	return atoms + fractionAtoms, nil
+}
+
+// Validate checks the address and amount of the rows and flags the rows that
+// pay an address already paid by a previous row. The number of valid rows is
+// returned.
+func Validate(w Wallet, rows []*Row) int {
+	firstLines := make(map[string]int, len(rows))
+	var valid int
+	for _, row := range rows {
+		if row.Error != "" {
+			continue
+		}
+
+		switch {
+		case !w.IsAddressValid(row.Address):
+			row.Error = ErrInvalidAddress
+		case row.Amount <= 0 || !w.IsSaneOutputValue(row.Amount):
+			row.Error = ErrInvalidAmount
+		case firstLines[row.Address] != 0:
+			row.Error = ErrDuplicateAddress
+			row.DuplicateOf = firstLines[row.Address]
+		default:
+			firstLines[row.Address] = row.Line
+			valid++
+		}
+	}
+	return valid
+}
+
+// Plan groups the valid rows into batches sent from the account. The rows
+// are split into several transactions when a batch has more than MaxOutputs
+// payments or its estimated size exceeds MaxTxSize.
+//
+// The batches are planned with the wallet's unsigned transaction, which is
+// replaced. It fails if another transaction is authored on the wallet.
+func Plan(w Wallet, account int32, rows []*Row) ([]*Batch, error) {
+	if !w.TryLockTxAuthor() {
+		return nil, errors.New(utils.ErrTxAuthorBusy)
+	}
+	defer w.UnlockTxAuthor()
+
+	valid := make([]*Row, 0, len(rows))
+	for _, row := range rows {
+		if row.Error == "" && row.TxHash == "" {
+			valid = append(valid, row)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, errors.New("no valid payment to send")
+	}
+
+	var batches []*Batch
+	var total int64
+	for len(valid) > 0 {
+		n := len(valid)
+		if n > MaxOutputs {
+			n = MaxOutputs
+		}
+
+		for {
+			feeAndSize, err := estimate(w, account, valid[:n])
+			if err != nil {
+				return nil, err
+			}
+
+			if feeAndSize.EstimatedSignedSize <= MaxTxSize || n == 1 {
+				batch := &Batch{
+					Rows: valid[:n],
+					Fee:  feeAndSize.Fee.UnitValue,
+					Size: feeAndSize.EstimatedSignedSize,
+				}
+				total += batch.Fee + batch.Total()
+				batches = append(batches, batch)
+				break
+			}
+
+			// Shrink the batch in proportion to its excess size.
+			smaller := n * MaxTxSize / feeAndSize.EstimatedSignedSize
+			if smaller >= n {
+				smaller = n - 1
+			}
+			n = max(smaller, 1)
+		}
+		valid = valid[n:]
+	}
+
+	// Each batch is estimated against the full balance. The batches spend
+	// the balance one after the other.
+	balance, err := w.GetAccountBalance(account)
+	if err != nil {
+		return nil, err
+	}
+	if total > balance.Spendable.ToInt() {
+		return nil, errors.New(utils.ErrInsufficientBalance)
+	}
+	return batches, nil
+}
+
+// estimate returns the fee and size of a transaction paying rows.
+func estimate(w Wallet, account int32, rows []*Row) (*sharedW.TxFeeAndSize, error) {
+	if err := w.NewUnsignedTx(account, nil); err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		if err := w.AddSendDestination(i, row.Address, row.Amount, false); err != nil {
+			return nil, fmt.Errorf("line %d: %w", row.Line, err)
+		}
+	}
+	return w.EstimateFeeAndSize()
+}
+
+// Total returns the amount paid by the batch, in atoms.
+func (b *Batch) Total() int64 {
+	var total int64
+	for _, row := range b.Rows {
+		total += row.Amount
+	}
+	return total
+}
+
+// Send signs and broadcasts the batches one after the other, and sets the tx
+// hash of the batches and their rows. Sending stops at the first failure; the
+// batches sent before it keep their tx hash.
+func Send(w Wallet, account int32, batches []*Batch, passphrase, label string) error {
+	if !w.TryLockTxAuthor() {
+		return errors.New(utils.ErrTxAuthorBusy)
+	}
+	defer w.UnlockTxAuthor()
+
+	for i, batch := range batches {
+		if batch.TxHash != "" {
+			continue
+		}
+
+		if _, err := estimate(w, account, batch.Rows); err != nil {
+			return fmt.Errorf("batch %d: %w", i+1, err)
+		}
+
+		txLabel := label
+		if len(batches) > 1 {
+			txLabel = fmt.Sprintf("%s (%d/%d)", label, i+1, len(batches))
+		}
+		hash, err := w.Broadcast(passphrase, strings.TrimSpace(txLabel))
+		if err != nil {
+			return fmt.Errorf("batch %d: %w", i+1, err)
+		}
+
+		log.Infof("Sent batch %d/%d of %d payments: %s", i+1, len(batches), len(batch.Rows), hash)
+		batch.TxHash = hash
+		for _, row := range batch.Rows {
+			row.TxHash = hash
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package batchsend
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+type testAmount int64
+
+func (a testAmount) ToCoin() float64                      { return float64(a) / 1e8 }
+func (a testAmount) String() string                       { return fmt.Sprint(a.ToCoin()) }
+func (a testAmount) MulF64(f float64) sharedW.AssetAmount { return testAmount(float64(a) * f) }
+func (a testAmount) ToInt() int64                         { return int64(a) }
+
+// testWallet estimates 100 bytes per output unless bytesPerOutput is set and
+// 1 atom per byte, and broadcasts the transactions as tx1, tx2...
+type testWallet struct {
+	spendable      int64
+	bytesPerOutput int
+	destinations   int
+	broadcasts     []string
+	authoring      bool
+}
+
+func (w *testWallet) TryLockTxAuthor() bool {
+	if w.authoring {
+		return false
+	}
+	w.authoring = true
+	return true
+}
+
+func (w *testWallet) UnlockTxAuthor() { w.authoring = false }
+
+func (w *testWallet) IsAddressValid(address string) bool { return strings.HasPrefix(address, "Ts") }
+
+func (w *testWallet) IsSaneOutputValue(amount int64) bool { return amount <= 21e6*1e8 }
+
+func (w *testWallet) GetAccountBalance(int32) (*sharedW.Balance, error) {
+	return &sharedW.Balance{Spendable: testAmount(w.spendable)}, nil
+}
+
+func (w *testWallet) NewUnsignedTx(int32, []*sharedW.UnspentOutput) error {
+	w.destinations = 0
+	return nil
+}
+
+func (w *testWallet) AddSendDestination(int, string, int64, bool) error {
+	w.destinations++
+	return nil
+}
+
+func (w *testWallet) EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error) {
+	bytesPerOutput := w.bytesPerOutput
+	if bytesPerOutput == 0 {
+		bytesPerOutput = 100
+	}
+	size := 200 + bytesPerOutput*w.destinations
+	return &sharedW.TxFeeAndSize{
+		Fee:                 &sharedW.Amount{UnitValue: int64(size)},
+		EstimatedSignedSize: size,
+	}, nil
+}
+
+func (w *testWallet) Broadcast(_, label string) (string, error) {
+	w.broadcasts = append(w.broadcasts, label)
+	return fmt.Sprintf("tx%d", len(w.broadcasts)), nil
+}
+
+func TestParseAndValidate(t *testing.T) {
+	csv := `address,amount,label
+TsAddr1, 1.5, alice
+TsAddr2,0.00000001
+TsAddr1,2,alice again
+notAnAddress,1
+TsAddr3,1.123456789
+TsAddr4
+TsAddr5,-1
+TsAddr6,0
+`
+	rows, err := ParseCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 8 {
+		t.Fatalf("expected 8 rows, got %d", len(rows))
+	}
+
+	valid := Validate(&testWallet{}, rows)
+	if valid != 2 {
+		t.Errorf("expected 2 valid rows, got %d", valid)
+	}
+
+	want := []struct {
+		line   int
+		amount int64
+		label  string
+		err    string
+	}{
+		{2, 150000000, "alice", ""},
+		{3, 1, "", ""},
+		{4, 200000000, "alice again", ErrDuplicateAddress},
+		{5, 100000000, "", ErrInvalidAddress},
+		{6, 0, "", ErrInvalidAmount},
+		{7, 0, "", ErrMissingField},
+		{8, 0, "", ErrInvalidAmount},
+		{9, 0, "", ErrInvalidAmount},
+	}
+	for i, row := range rows {
+		if row.Line != want[i].line || row.Amount != want[i].amount || row.Label != want[i].label || row.Error != want[i].err {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], row)
+		}
+	}
+	if rows[2].DuplicateOf != 2 {
+		t.Errorf("expected duplicate of line 2, got %d", rows[2].DuplicateOf)
+	}
+}
+
+func TestPlanAndSend(t *testing.T) {
+	var csv strings.Builder
+	for i := 0; i < 2500; i++ {
+		fmt.Fprintf(&csv, "TsAddr%d,0.1\n", i)
+	}
+	rows, err := ParseCSV(strings.NewReader(csv.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := &testWallet{spendable: 1e8}
+	if valid := Validate(w, rows); valid != len(rows) {
+		t.Fatalf("expected %d valid rows, got %d", len(rows), valid)
+	}
+
+	if _, err := Plan(w, 0, rows); err == nil {
+		t.Fatal("expected insufficient balance")
+	}
+
+	w.spendable = 300 * 1e8
+	batches, err := Plan(w, 0, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// MaxOutputs fit in MaxTxSize with the test estimates.
+	if len(batches) != 25 {
+		t.Fatalf("expected 25 batches, got %d", len(batches))
+	}
+	for _, batch := range batches {
+		if len(batch.Rows) != MaxOutputs || batch.Size > MaxTxSize || batch.Fee != int64(batch.Size) {
+			t.Fatalf("unexpected batch of %d rows, size %d, fee %d", len(batch.Rows), batch.Size, batch.Fee)
+		}
+	}
+
+	// The wallet's unsigned tx is not replaced while another tx is
+	// authored.
+	w.authoring = true
+	if err := Send(w, 0, batches, "pass", "payroll"); err == nil || err.Error() != utils.ErrTxAuthorBusy {
+		t.Fatalf("expected %s, got %v", utils.ErrTxAuthorBusy, err)
+	}
+	w.authoring = false
+
+	if err := Send(w, 0, batches, "pass", "payroll"); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.broadcasts) != 25 || w.broadcasts[0] != "payroll (1/25)" {
+		t.Fatalf("unexpected broadcasts %v", w.broadcasts)
+	}
+	if rows[0].TxHash != "tx1" || rows[len(rows)-1].TxHash != "tx25" {
+		t.Fatalf("unexpected row tx hashes %s, %s", rows[0].TxHash, rows[len(rows)-1].TxHash)
+	}
+}
+
+func TestPlanSplitsLargeTransactions(t *testing.T) {
+	rows := make([]*Row, 150)
+	for i := range rows {
+		rows[i] = &Row{Line: i + 1, Address: fmt.Sprintf("TsAddr%d", i), Amount: 1}
+	}
+
+	// 100 outputs are 200200 bytes, twice MaxTxSize.
+	w := &testWallet{spendable: 1e8, bytesPerOutput: 2000}
+	batches, err := Plan(w, 0, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var planned int
+	for _, batch := range batches {
+		if batch.Size > MaxTxSize {
+			t.Fatalf("batch size %d exceeds MaxTxSize", batch.Size)
+		}
+		planned += len(batch.Rows)
+	}
+	if planned != len(rows) || len(batches) != 4 {
+		t.Fatalf("expected %d rows in 4 batches, got %d rows in %d batches", len(rows), planned, len(batches))
+	}
+}
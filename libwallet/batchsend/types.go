@@ -0,0 +1,75 @@
+package batchsend
+
+import (
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// Row validation errors.
+const (
+	ErrMissingField     = "missing_field"
+	ErrInvalidAddress   = "invalid_address"
+	ErrInvalidAmount    = "invalid_amount"
+	ErrDuplicateAddress = "duplicate_address"
+)
+
+const (
+	// MaxOutputs is the number of payments above which a batch is split into
+	// several transactions.
+	MaxOutputs = 100
+
+	// MaxTxSize is the estimated signed size, in bytes, above which a batch
+	// is split into several transactions. It is the standard transaction
+	// size limit of the supported assets.
+	MaxTxSize = 100000
+
+	// coinDecimals is the number of decimals of the amounts of all the
+	// supported assets.
+	coinDecimals = 8
+
+	atomsPerCoin int64 = 1e8
+)
+
+// Row is a payment read from the CSV file.
+type Row struct {
+	// Line is the line of the row in the CSV file, starting at 1.
+	Line    int
+	Address string
+	// Amount is in atoms.
+	Amount int64
+	Label  string
+
+	// Error is one of the row validation errors. It is empty for valid
+	// rows.
+	Error string
+	// DuplicateOf is the line of the first row with the same address if
+	// Error is ErrDuplicateAddress.
+	DuplicateOf int
+
+	// TxHash is set once the row is sent.
+	TxHash string
+}
+
+// Batch is the payments sent in a single transaction.
+type Batch struct {
+	Rows []*Row
+	// Fee is the estimated fee in atoms.
+	Fee int64
+	// Size is the estimated signed size in bytes.
+	Size int
+
+	// TxHash is set once the batch is sent.
+	TxHash string
+}
+
+// Wallet is the part of sharedW.Asset used to send the batches.
+type Wallet interface {
+	IsAddressValid(address string) bool
+	IsSaneOutputValue(amount int64) bool
+	GetAccountBalance(accountNumber int32) (*sharedW.Balance, error)
+	TryLockTxAuthor() bool
+	UnlockTxAuthor()
+	NewUnsignedTx(accountNumber int32, utxos []*sharedW.UnspentOutput) error
+	AddSendDestination(id int, address string, unitAmount int64, sendMax bool) error
+	EstimateFeeAndSize() (*sharedW.TxFeeAndSize, error)
+	Broadcast(passphrase, label string) (string, error)
+}
@@ -0,0 +1,147 @@
+// Package dbcheck verifies the integrity of the wallet databases.
+package dbcheck
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/crypto-power/cryptopower/libwallet/dbmigrate"
+)
+
+// maxProblems is the number of problems of a database reported, a corrupt
+// database may have a lot.
+const maxProblems = 20
+
+// Report is the result of the check of a database.
+type Report struct {
+	// Buckets are the top-level buckets of the database.
+	Buckets []string
+	// MissingBuckets are the required top-level buckets the database lacks.
+	MissingBuckets []string
+	// Keys is the number of keys of the database, nested buckets included.
+	Keys int
+	// Problems are the inconsistencies found in the database pages or while
+	// reading its keys.
+	Problems []string
+}
+
+// Healthy returns true if the database has no missing buckets nor problems.
+func (r *Report) Healthy() bool {
+	return len(r.MissingBuckets) == 0 && len(r.Problems) == 0
+}
+
+// Check verifies the database at dbPath opened with the driver holds the
+// required top-level buckets and that all its keys can be read. The pages of
+// bolt databases are checked too. The database must not be open. An
+// errors.NotExist error is returned if there is no database at dbPath.
+func Check(driver, dbPath string, requiredBuckets []string) (*Report, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.E(errors.NotExist, fmt.Sprintf("no database at %s", dbPath))
+		}
+		return nil, errors.E(errors.IO, err)
+	}
+
+	report := new(Report)
+	if driver == dbmigrate.BoltDB {
+		if err := checkBoltPages(dbPath, report); err != nil {
+			return nil, err
+		}
+	}
+
+	var buckets [][]byte
+	err := recovered(func() (err error) {
+		buckets, err = dbmigrate.TopLevelBuckets(driver, dbPath)
+		return err
+	})
+	if err != nil {
+		// A database that does not open is corrupt.
+		report.addProblem(err.Error())
+		return report, nil
+	}
+
+	found := make(map[string]bool, len(buckets))
+	for _, key := range buckets {
+		found[string(key)] = true
+		report.Buckets = append(report.Buckets, string(key))
+	}
+	for _, name := range requiredBuckets {
+		if !found[name] {
+			report.MissingBuckets = append(report.MissingBuckets, name)
+		}
+	}
+
+	err = recovered(func() (err error) {
+		report.Keys, err = countKeys(driver, dbPath, buckets)
+		return err
+	})
+	if err != nil {
+		report.addProblem(err.Error())
+	}
+	return report, nil
+}
+
+// checkBoltPages adds the inconsistencies of the pages of the bolt database
+// to the report.
+func checkBoltPages(dbPath string, report *Report) error {
+	db, err := bolt.Open(dbPath, 0600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return errors.E(errors.Invalid, "database is in use")
+		}
+		// Reported once listing the buckets fails.
+		return nil
+	}
+	defer db.Close()
+
+	err = recovered(func() error {
+		return db.View(func(tx *bolt.Tx) error {
+			for err := range tx.Check() {
+				report.addProblem(err.Error())
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		report.addProblem(err.Error())
+	}
+	return nil
+}
+
+// countKeys reads all the keys of the buckets of the database.
+func countKeys(driver, dbPath string, buckets [][]byte) (int, error) {
+	db, err := walletdb.Open(driver, dbPath)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	var keys int
+	err = walletdb.View(nil, db, func(tx walletdb.ReadTx) error {
+		keys, err = dbmigrate.CountKeys(tx, buckets)
+		return err
+	})
+	return keys, err
+}
+
+// recovered runs fn, returning the panics of reading corrupt pages as
+// errors.
+func recovered(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("reading the database failed: %v", r)
+		}
+	}()
+	return fn()
+}
+
+func (r *Report) addProblem(problem string) {
+	if len(r.Problems) < maxProblems {
+		r.Problems = append(r.Problems, problem)
+	}
+}
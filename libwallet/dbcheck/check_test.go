@@ -0,0 +1,86 @@
+package dbcheck
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"decred.org/dcrwallet/v4/errors"
+	_ "decred.org/dcrwallet/v4/wallet/drivers/bdb" // register the bolt driver
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+
+	_ "github.com/crypto-power/cryptopower/libwallet/badgerdb" // register the badger driver
+	"github.com/crypto-power/cryptopower/libwallet/dbmigrate"
+)
+
+func createTestDB(t *testing.T, driver, dbPath string, buckets ...string) {
+	t.Helper()
+
+	db, err := walletdb.Create(driver, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = walletdb.Update(nil, db, func(tx walletdb.ReadWriteTx) error {
+		for _, name := range buckets {
+			b, err := tx.CreateTopLevelBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte("key"), []byte("value")); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, driver := range []string{dbmigrate.BoltDB, dbmigrate.BadgerDB} {
+		dbPath := filepath.Join(t.TempDir(), "wallet.db")
+		createTestDB(t, driver, dbPath, "waddrmgr", "wtxmgr")
+
+		report, err := Check(driver, dbPath, []string{"waddrmgr", "wtxmgr"})
+		if err != nil {
+			t.Fatalf("%s: %v", driver, err)
+		}
+		if !report.Healthy() || report.Keys != 2 {
+			t.Fatalf("%s: expected a healthy database of 2 keys, got %+v", driver, report)
+		}
+
+		report, err = Check(driver, dbPath, []string{"waddrmgr", "wtxmgr", "wstakemgr"})
+		if err != nil {
+			t.Fatalf("%s: %v", driver, err)
+		}
+		if report.Healthy() || !reflect.DeepEqual(report.MissingBuckets, []string{"wstakemgr"}) {
+			t.Fatalf("%s: expected the wstakemgr bucket missing, got %+v", driver, report)
+		}
+	}
+}
+
+func TestCheckCorrupt(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "wallet.db")
+	if err := os.WriteFile(dbPath, make([]byte, 8192), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Check(dbmigrate.BoltDB, dbPath, []string{"waddrmgr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Healthy() || len(report.Problems) == 0 {
+		t.Fatalf("expected the corrupt database reported, got %+v", report)
+	}
+}
+
+func TestCheckMissing(t *testing.T) {
+	_, err := Check(dbmigrate.BoltDB, filepath.Join(t.TempDir(), "wallet.db"), nil)
+	if !errors.Is(err, errors.NotExist) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+}
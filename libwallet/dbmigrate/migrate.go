@@ -0,0 +1,314 @@
+// Package dbmigrate copies wallet databases between the walletdb drivers,
+// key by key, preserving all their buckets.
+package dbmigrate
+
+import (
+	"bytes"
+	"os"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// BoltDB is the walletdb driver of the bolt databases.
+	BoltDB = "bdb"
+	// BadgerDB is the walletdb driver of the badger databases.
+	BadgerDB = "badgerdb"
+)
+
+// batchSize is the number of keys written per transaction of the destination
+// database. Badger rejects transactions that grow too large.
+const batchSize = 1000
+
+// Progress reports the number of keys copied out of the total keys of the
+// database, nested buckets included.
+type Progress func(copied, total int)
+
+// bucketLister is implemented by the databases that list their top-level
+// buckets, walletdb.DB does not.
+type bucketLister interface {
+	ForEachBucket(fn func(key []byte) error) error
+}
+
+// Migrate copies the database at srcPath opened with the srcDriver to a new
+// database at dstPath created with the dstDriver. The source database must not
+// be open. The destination database is removed if the migration fails.
+func Migrate(srcDriver, srcPath, dstDriver, dstPath string, progress Progress) (err error) {
+	if _, err := os.Stat(dstPath); err == nil {
+		return errors.E(errors.Exist, "destination database already exists")
+	}
+
+	buckets, err := TopLevelBuckets(srcDriver, srcPath)
+	if err != nil {
+		return err
+	}
+
+	src, err := walletdb.Open(srcDriver, srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := walletdb.Create(dstDriver, dstPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dst.Close(); err == nil && closeErr != nil {
+			err = errors.E(errors.IO, closeErr)
+		}
+		if err != nil {
+			_ = os.RemoveAll(dstPath)
+		}
+	}()
+
+	srcTx, err := src.BeginReadTx()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = srcTx.Rollback()
+	}()
+
+	total, err := CountKeys(srcTx, buckets)
+	if err != nil {
+		return err
+	}
+
+	w := &writer{db: dst, total: total, progress: progress}
+	defer w.rollback()
+	for _, key := range buckets {
+		if err := w.createTopLevelBucket(key); err != nil {
+			return err
+		}
+		if err := copyBucket(srcTx.ReadBucket(key), w, [][]byte{key}); err != nil {
+			return err
+		}
+	}
+	if err := w.commit(); err != nil {
+		return err
+	}
+
+	// Verify the destination holds as many keys as the source before it
+	// replaces it.
+	dstTx, err := dst.BeginReadTx()
+	if err != nil {
+		return err
+	}
+	copied, err := CountKeys(dstTx, buckets)
+	_ = dstTx.Rollback()
+	if err != nil {
+		return err
+	}
+	if copied != total {
+		return errors.Errorf("copied %d of %d keys", copied, total)
+	}
+	return nil
+}
+
+// TopLevelBuckets returns the keys of the top-level buckets of the database.
+func TopLevelBuckets(driver, dbPath string) ([][]byte, error) {
+	var buckets [][]byte
+	add := func(key []byte) error {
+		buckets = append(buckets, bytes.Clone(key))
+		return nil
+	}
+
+	switch driver {
+	case BoltDB:
+		db, err := bolt.Open(dbPath, 0600, &bolt.Options{ReadOnly: true, Timeout: time.Second})
+		if err != nil {
+			return nil, errors.E(errors.IO, err)
+		}
+		defer db.Close()
+		err = db.View(func(tx *bolt.Tx) error {
+			return tx.ForEach(func(key []byte, _ *bolt.Bucket) error {
+				return add(key)
+			})
+		})
+		return buckets, err
+
+	default:
+		db, err := walletdb.Open(driver, dbPath)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+		lister, ok := db.(bucketLister)
+		if !ok {
+			return nil, errors.Errorf("database driver %s does not list its buckets", driver)
+		}
+		err = lister.ForEachBucket(add)
+		return buckets, err
+	}
+}
+
+// CountKeys returns the number of keys of the buckets, nested buckets
+// included.
+func CountKeys(tx walletdb.ReadTx, buckets [][]byte) (int, error) {
+	var count func(b walletdb.ReadBucket) (int, error)
+	count = func(b walletdb.ReadBucket) (int, error) {
+		n := 0
+		err := b.ForEach(func(k, v []byte) error {
+			n++
+			if v != nil {
+				return nil
+			}
+			nested := b.NestedReadBucket(k)
+			if nested == nil {
+				return nil
+			}
+			nestedN, err := count(nested)
+			n += nestedN
+			return err
+		})
+		return n, err
+	}
+
+	total := 0
+	for _, key := range buckets {
+		b := tx.ReadBucket(key)
+		if b == nil {
+			return 0, errors.E(errors.NotExist, errors.Errorf("missing bucket %x", key))
+		}
+		n, err := count(b)
+		if err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// copyBucket copies the keys and nested buckets of the bucket to the bucket
+// at the path of the destination database.
+func copyBucket(b walletdb.ReadBucket, w *writer, path [][]byte) error {
+	if b == nil {
+		return errors.E(errors.NotExist, errors.Errorf("missing bucket %x", path[len(path)-1]))
+	}
+	return b.ForEach(func(k, v []byte) error {
+		// The keys and values are only valid until the cursor moves.
+		k = bytes.Clone(k)
+		if v == nil {
+			if nested := b.NestedReadBucket(k); nested != nil {
+				if err := w.createBucket(path, k); err != nil {
+					return err
+				}
+				return copyBucket(nested, w, append(path[:len(path):len(path)], k))
+			}
+		}
+		return w.put(path, k, bytes.Clone(v))
+	})
+}
+
+// writer writes the keys to the destination database in transactions of
+// batchSize keys.
+type writer struct {
+	db       walletdb.DB
+	tx       walletdb.ReadWriteTx
+	pending  int
+	copied   int
+	total    int
+	progress Progress
+}
+
+func (w *writer) begin() error {
+	if w.tx != nil {
+		return nil
+	}
+	tx, err := w.db.BeginReadWriteTx()
+	if err != nil {
+		return err
+	}
+	w.tx = tx
+	return nil
+}
+
+// bucket returns the bucket at the path, in the current transaction.
+func (w *writer) bucket(path [][]byte) (walletdb.ReadWriteBucket, error) {
+	if err := w.begin(); err != nil {
+		return nil, err
+	}
+	b := w.tx.ReadWriteBucket(path[0])
+	for _, key := range path[1:] {
+		if b == nil {
+			break
+		}
+		b = b.NestedReadWriteBucket(key)
+	}
+	if b == nil {
+		return nil, errors.E(errors.NotExist, errors.Errorf("missing bucket %x", path[len(path)-1]))
+	}
+	return b, nil
+}
+
+func (w *writer) createTopLevelBucket(key []byte) error {
+	if err := w.begin(); err != nil {
+		return err
+	}
+	if _, err := w.tx.CreateTopLevelBucket(key); err != nil {
+		return err
+	}
+	// Top-level buckets are not counted as keys.
+	return w.commit()
+}
+
+func (w *writer) createBucket(path [][]byte, key []byte) error {
+	b, err := w.bucket(path)
+	if err != nil {
+		return err
+	}
+	if _, err := b.CreateBucket(key); err != nil {
+		return err
+	}
+	return w.written()
+}
+
+func (w *writer) put(path [][]byte, key, value []byte) error {
+	b, err := w.bucket(path)
+	if err != nil {
+		return err
+	}
+	if err := b.Put(key, value); err != nil {
+		return err
+	}
+	return w.written()
+}
+
+// written counts a key written and commits the transaction once batchSize
+// keys are pending.
+func (w *writer) written() error {
+	w.pending++
+	w.copied++
+	if w.pending >= batchSize {
+		return w.commit()
+	}
+	return nil
+}
+
+func (w *writer) commit() error {
+	if w.tx == nil {
+		return nil
+	}
+	err := w.tx.Commit()
+	w.tx = nil
+	w.pending = 0
+	if err != nil {
+		return err
+	}
+	if w.progress != nil {
+		w.progress(w.copied, w.total)
+	}
+	return nil
+}
+
+// rollback discards the keys pending in the current transaction.
+func (w *writer) rollback() {
+	if w.tx != nil {
+		_ = w.tx.Rollback()
+		w.tx = nil
+	}
+}
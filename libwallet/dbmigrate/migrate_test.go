@@ -0,0 +1,178 @@
+package dbmigrate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"decred.org/dcrwallet/v4/errors"
+	_ "decred.org/dcrwallet/v4/wallet/drivers/bdb" // register the bolt driver
+	"decred.org/dcrwallet/v4/wallet/walletdb"
+
+	_ "github.com/crypto-power/cryptopower/libwallet/badgerdb" // register the badger driver
+)
+
+// testData is the content of the test databases: top-level bucket, then key
+// and value pairs where a nil value is a nested bucket named by the key,
+// holding the nestedData.
+var (
+	testData = map[string]map[string][]byte{
+		"waddrmgr": {"acct": []byte("default"), "label": []byte("savings"), "nested": nil},
+		"wtxmgr":   {"tx1": []byte{1, 2, 3}, "empty": {}},
+	}
+	nestedData = map[string][]byte{"addr": []byte("DsExample"), "idx": []byte{0}}
+)
+
+func createTestDB(t *testing.T, driver, dbPath string) {
+	t.Helper()
+
+	db, err := walletdb.Create(driver, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = walletdb.Update(nil, db, func(tx walletdb.ReadWriteTx) error {
+		for name, pairs := range testData {
+			b, err := tx.CreateTopLevelBucket([]byte(name))
+			if err != nil {
+				return err
+			}
+			for k, v := range pairs {
+				if v != nil {
+					if err := b.Put([]byte(k), v); err != nil {
+						return err
+					}
+					continue
+				}
+				nested, err := b.CreateBucket([]byte(k))
+				if err != nil {
+					return err
+				}
+				for nk, nv := range nestedData {
+					if err := nested.Put([]byte(nk), nv); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkTestDB(t *testing.T, driver, dbPath string) {
+	t.Helper()
+
+	db, err := walletdb.Open(driver, dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = walletdb.View(nil, db, func(tx walletdb.ReadTx) error {
+		for name, pairs := range testData {
+			b := tx.ReadBucket([]byte(name))
+			if b == nil {
+				t.Fatalf("%s: missing bucket %s", driver, name)
+			}
+			for k, v := range pairs {
+				if v != nil {
+					if got := b.Get([]byte(k)); !bytes.Equal(got, v) {
+						t.Errorf("%s: %s/%s: expected %x, got %x", driver, name, k, v, got)
+					}
+					continue
+				}
+				nested := b.NestedReadBucket([]byte(k))
+				if nested == nil {
+					t.Fatalf("%s: missing nested bucket %s/%s", driver, name, k)
+				}
+				for nk, nv := range nestedData {
+					if got := nested.Get([]byte(nk)); !bytes.Equal(got, nv) {
+						t.Errorf("%s: %s/%s/%s: expected %x, got %x", driver, name, k, nk, nv, got)
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	boltPath := filepath.Join(dir, "wallet.db")
+	badgerPath := filepath.Join(dir, "wallet-badger.db")
+	backPath := filepath.Join(dir, "wallet-bolt.db")
+
+	createTestDB(t, BoltDB, boltPath)
+
+	var copied, total int
+	progress := func(c, t int) {
+		copied, total = c, t
+	}
+
+	if err := Migrate(BoltDB, boltPath, BadgerDB, badgerPath, progress); err != nil {
+		t.Fatalf("bolt to badger migration failed: %v", err)
+	}
+	// 3 + 2 keys, the nested bucket included, and 2 nested keys.
+	if copied != 7 || total != 7 {
+		t.Fatalf("expected 7 of 7 keys copied, got %d of %d", copied, total)
+	}
+	checkTestDB(t, BadgerDB, badgerPath)
+
+	if err := Migrate(BadgerDB, badgerPath, BoltDB, backPath, nil); err != nil {
+		t.Fatalf("badger to bolt migration failed: %v", err)
+	}
+	checkTestDB(t, BoltDB, backPath)
+}
+
+func TestMigrateExistingDestination(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.db")
+	dstPath := filepath.Join(dir, "dst.db")
+
+	createTestDB(t, BoltDB, srcPath)
+	createTestDB(t, BoltDB, dstPath)
+
+	err := Migrate(BoltDB, srcPath, BadgerDB, dstPath, nil)
+	if !errors.Is(err, errors.Exist) {
+		t.Fatalf("expected an existing destination error, got %v", err)
+	}
+	// The existing destination is left untouched.
+	checkTestDB(t, BoltDB, dstPath)
+}
+
+func TestMigrateRollback(t *testing.T) {
+	dir := t.TempDir()
+	srcPath := filepath.Join(dir, "src.db")
+	dstPath := filepath.Join(dir, "dst.db")
+
+	createTestDB(t, BadgerDB, srcPath)
+
+	// Bolt rejects keys larger than its maximum key size, which badger
+	// accepts. The migration fails once some keys were written.
+	db, err := walletdb.Open(BadgerDB, srcPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = walletdb.Update(nil, db, func(tx walletdb.ReadWriteTx) error {
+		return tx.ReadWriteBucket([]byte("wtxmgr")).Put(bytes.Repeat([]byte{1}, 40000), []byte{1})
+	})
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(BadgerDB, srcPath, BoltDB, dstPath, nil); err == nil {
+		t.Fatal("expected the migration to fail")
+	}
+	if _, err := os.Stat(dstPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the destination to be removed, got %v", err)
+	}
+}
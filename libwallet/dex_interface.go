@@ -1,9 +1,11 @@
 package libwallet
 
 import (
+	"decred.org/dcrdex/client/asset"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/orderbook"
 	"decred.org/dcrdex/dex"
+	"github.com/crypto-power/cryptopower/dexc"
 )
 
 type DEXClient interface {
@@ -24,13 +26,19 @@ type DEXClient interface {
 	AddWallet(assetID uint32, settings map[string]string, appPW, walletPW []byte) error
 	SetWalletPassword(appPW []byte, assetID uint32, newPW []byte) error
 	PostBond(form *core.PostBondForm) (*core.PostBondResult, error)
+	UpdateBondOptions(form *core.BondOptionsForm) error
+	Bonds(appPW []byte, host string) ([]*dexc.Bond, error)
+	BondTransaction(assetID uint32, txID string) *asset.WalletTransaction
 	NotificationFeed() *core.NoteFeed
 	Exchanges() map[string]*core.Exchange
 	Exchange(host string) (*core.Exchange, error)
 	ExportSeed(pw []byte) (string, error)
 	SyncBook(dex string, base, quote uint32) (*orderbook.OrderBook, core.BookFeed, error)
 	Orders(filter *core.OrderFilter) ([]*core.Order, error)
+	Order(oid dex.Bytes) (*core.Order, error)
 	ActiveOrders() (map[string][]*core.Order, map[string][]*core.InFlightOrder, error)
+	TradeHistory(filter *dexc.TradeFilter) ([]*dexc.Trade, error)
+	OrderIDForTx(assetID uint32, txID string) (string, error)
 	Active() bool
 	Trade(pw []byte, form *core.TradeForm) (*core.Order, error)
 	// TradeAsync is like Trade but a temporary order is returned before order
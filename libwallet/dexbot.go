@@ -0,0 +1,56 @@
+package libwallet
+
+import (
+	"fmt"
+	"strings"
+
+	"decred.org/dcrdex/dex"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// StartDEXBot starts the market-making bot with the provided ID. The DEX
+// password is kept in memory while the bot runs because it is required to
+// place orders.
+func (mgr *AssetsManager) StartDEXBot(botID int, appPW []byte) error {
+	if !mgr.DEXCInitialized() {
+		return fmt.Errorf("DEX client is not initialized")
+	}
+
+	return mgr.DEXBots.Start(mgr.dexcCtx, mgr.DexClient(), botID, appPW, mgr.dexOraclePrice)
+}
+
+// dexOraclePrice computes the price of the base asset in units of the quote
+// asset from the USD rates of both assets.
+func (mgr *AssetsManager) dexOraclePrice(baseID, quoteID uint32) (float64, error) {
+	if !mgr.ExchangeRateFetchingEnabled() {
+		return 0, fmt.Errorf("the USD exchange rate is disabled")
+	}
+
+	usdRate := func(assetID uint32) (float64, error) {
+		assetType := utils.AssetType(strings.ToUpper(dex.BipIDSymbol(assetID)))
+		market, ok := values.AssetExchangeMarketValue[assetType]
+		if !ok {
+			return 0, fmt.Errorf("unsupported asset %d", assetID)
+		}
+
+		rate := mgr.RateSource.GetTicker(market, true)
+		if rate == nil || rate.LastTradePrice <= 0 {
+			return 0, fmt.Errorf("no %s rate information available", assetType)
+		}
+		return rate.LastTradePrice, nil
+	}
+
+	baseRate, err := usdRate(baseID)
+	if err != nil {
+		return 0, err
+	}
+
+	quoteRate, err := usdRate(quoteID)
+	if err != nil {
+		return 0, err
+	}
+
+	return baseRate / quoteRate, nil
+}
@@ -0,0 +1,506 @@
+package dexbot
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/orderbook"
+	"decred.org/dcrdex/dex"
+
+	"github.com/crypto-power/cryptopower/dexc"
+)
+
+// botOrder is an order placed by a running bot.
+type botOrder struct {
+	id         dex.Bytes
+	sell       bool
+	rate       uint64
+	qty        uint64
+	filled     uint64
+	cancelling bool
+}
+
+// bot places and maintains a ladder of limit orders on both sides of a DEX
+// market.
+type bot struct {
+	cfg    *Config
+	mkt    *core.Market
+	dc     DEXClient
+	appPW  []byte
+	oracle OracleFunc
+	mgr    *Manager
+	book   *orderbook.OrderBook
+	feed   core.BookFeed
+
+	mtx     sync.RWMutex
+	orders  map[string]*botOrder
+	refRate uint64
+	status  Status
+	lastErr string
+
+	stopOnce   sync.Once
+	stopReason string
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+func (b *bot) run(ctx context.Context) {
+	defer close(b.done)
+	defer b.feed.Close()
+
+	noteFeed := b.dc.NotificationFeed()
+	defer noteFeed.ReturnFeed()
+
+	ticker := time.NewTicker(b.cfg.RebalanceInterval)
+	defer ticker.Stop()
+
+	bookUpdates := b.feed.Next()
+	b.refresh(true)
+	for {
+		select {
+		case <-ctx.Done():
+			b.cancelAll()
+			return
+
+		case <-ticker.C:
+			b.refresh(true)
+
+		case u, ok := <-bookUpdates:
+			if !ok {
+				bookUpdates = nil
+				b.stop("order book feed closed")
+				continue
+			}
+
+			switch u.Action {
+			case core.FreshBookAction, core.EpochResolved:
+				b.refresh(false)
+			}
+
+		case n := <-noteFeed.C:
+			if n == nil {
+				b.stop("DEX client shut down")
+				continue
+			}
+
+			if note, ok := n.(*core.MatchNote); ok && b.hasOrder(note.OrderID) {
+				b.updateOrders()
+				b.checkInventory()
+			}
+		}
+	}
+}
+
+// stop stops the bot. The reason is recorded with the stop event.
+func (b *bot) stop(reason string) {
+	b.stopOnce.Do(func() {
+		b.stopReason = reason
+		b.cancel()
+	})
+}
+
+func (b *bot) currentStatus() Status {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	status := b.status
+	status.RefPrice = b.mkt.MsgRateToConventional(b.refRate)
+	for _, o := range b.orders {
+		if o.sell {
+			status.SellOrders++
+		} else {
+			status.BuyOrders++
+		}
+	}
+	return status
+}
+
+func (b *bot) hasOrder(oid dex.Bytes) bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	_, found := b.orders[oid.String()]
+	return found
+}
+
+// refresh updates the bot's orders and places or replaces orders so that the
+// ladder matches the reference price. The reference price is only changed if
+// rebalance is true or the price has moved by more than half the spread since
+// the orders were placed.
+func (b *bot) refresh(rebalance bool) {
+	b.updateOrders()
+	if !b.checkInventory() {
+		return
+	}
+
+	refRate, err := b.referenceRate()
+	if err != nil {
+		b.reportError(err)
+		return
+	}
+	if !b.checkOracleDeviation(refRate) {
+		return
+	}
+
+	b.mtx.Lock()
+	if !rebalance && b.refRate != 0 {
+		if rateDeviation(refRate, b.refRate) <= b.cfg.SpreadPercent/2 {
+			refRate = b.refRate
+		}
+	}
+	b.refRate = refRate
+	b.mtx.Unlock()
+
+	buys, sells := ladder(refRate, b.cfg.SpreadPercent, b.cfg.Levels, b.mkt.RateStep, b.mkt.MinimumRate)
+	if b.reconcile(false, buys) {
+		b.reconcile(true, sells)
+	}
+}
+
+// checkInventory stops the bot if the net amount bought or sold exceeds the
+// configured maximum inventory. It returns false if the bot was stopped.
+func (b *bot) checkInventory() bool {
+	b.mtx.RLock()
+	netInventory := b.status.NetInventory
+	b.mtx.RUnlock()
+
+	if uint64(math.Abs(float64(netInventory))) <= b.cfg.MaxInventory {
+		return true
+	}
+
+	b.stop(fmt.Sprintf("inventory limit reached: net %s %s", formatAtoms(netInventory, b.cfg.BaseID), strings.ToUpper(b.mkt.BaseSymbol)))
+	return false
+}
+
+// checkOracleDeviation pauses the bot and cancels its orders while the oracle
+// reference rate deviates from the order book mid-gap by more than the
+// configured maximum, and resumes it once they agree again. It returns false
+// while the bot is paused. Bots using the mid-gap are never paused.
+func (b *bot) checkOracleDeviation(refRate uint64) bool {
+	if b.cfg.PriceSource != PriceSourceOracle {
+		return true
+	}
+
+	maxDeviation := b.cfg.MaxOracleDeviation
+	if maxDeviation == 0 {
+		maxDeviation = DefaultMaxOracleDeviation
+	}
+
+	// There is nothing to compare the oracle with if a side of the book is
+	// empty.
+	var reason string
+	if midGap, err := b.book.MidGap(); err == nil && midGap > 0 {
+		if deviation := rateDeviation(refRate, midGap); deviation > maxDeviation {
+			reason = fmt.Sprintf("oracle price %s deviates %.2f%% from the book mid-gap %s", formatRate(b.mkt, refRate),
+				deviation, formatRate(b.mkt, midGap))
+		}
+	}
+
+	b.mtx.Lock()
+	wasPaused := b.status.Paused
+	b.status.Paused = reason != ""
+	b.mtx.Unlock()
+
+	switch {
+	case reason != "" && !wasPaused:
+		log.Warnf("Bot %d: paused: %s", b.cfg.ID, reason)
+		b.cancelAll()
+		b.mgr.saveEvent(&Event{BotID: b.cfg.ID, Type: EventPaused, Message: reason})
+	case reason == "" && wasPaused:
+		log.Infof("Bot %d: resumed", b.cfg.ID)
+		b.mgr.saveEvent(&Event{BotID: b.cfg.ID, Type: EventResumed})
+	}
+	return reason == ""
+}
+
+// rateDeviation returns the deviation of rate from refRate as a percentage of
+// refRate.
+func rateDeviation(rate, refRate uint64) float64 {
+	return math.Abs(float64(rate)-float64(refRate)) / float64(refRate) * 100
+}
+
+// referenceRate returns the message-rate around which orders are placed.
+func (b *bot) referenceRate() (uint64, error) {
+	switch b.cfg.PriceSource {
+	case PriceSourceOracle:
+		if b.oracle == nil {
+			return 0, fmt.Errorf("no price oracle")
+		}
+		price, err := b.oracle(b.cfg.BaseID, b.cfg.QuoteID)
+		if err != nil {
+			return 0, fmt.Errorf("oracle price unavailable: %w", err)
+		}
+		if price <= 0 {
+			return 0, fmt.Errorf("invalid oracle price %f", price)
+		}
+		return b.mkt.ConventionalRateToMsg(price), nil
+	default:
+		midGap, err := b.book.MidGap()
+		if err != nil {
+			return 0, fmt.Errorf("mid-gap unavailable: %w", err)
+		}
+		return midGap, nil
+	}
+}
+
+// ladder returns the rates of the buy and sell orders for the reference rate.
+// Rates are rounded away from the reference rate to the market rate step. Buy
+// rates below the market minimum rate are dropped.
+func ladder(refRate uint64, spreadPercent float64, levels int, rateStep, minRate uint64) (buys, sells []uint64) {
+	if rateStep == 0 {
+		rateStep = 1
+	}
+
+	for i := 1; i <= levels; i++ {
+		gap := float64(refRate) * spreadPercent * float64(i) / 100
+
+		buy := uint64(float64(refRate)-gap) / rateStep * rateStep
+		if buy > 0 && buy >= minRate {
+			buys = append(buys, buy)
+		}
+
+		sell := uint64(math.Ceil((float64(refRate)+gap)/float64(rateStep))) * rateStep
+		sells = append(sells, sell)
+	}
+	return buys, sells
+}
+
+// reconcile cancels the orders on one side of the book that are not at one of
+// the desired rates and places orders for the missing rates. It returns false
+// if the bot was stopped because the wallet balance cannot fund any order on
+// that side.
+func (b *bot) reconcile(sell bool, rates []uint64) bool {
+	wanted := make(map[uint64]bool, len(rates))
+	for _, rate := range rates {
+		wanted[rate] = true
+	}
+
+	var sideOrders []*botOrder
+	b.mtx.RLock()
+	for _, o := range b.orders {
+		if o.sell == sell {
+			sideOrders = append(sideOrders, o)
+		}
+	}
+	b.mtx.RUnlock()
+
+	placed := make(map[uint64]bool, len(rates))
+	for _, o := range sideOrders {
+		if wanted[o.rate] && !placed[o.rate] && !o.cancelling {
+			placed[o.rate] = true
+			continue
+		}
+		if !o.cancelling {
+			b.cancelOrder(o)
+		}
+	}
+
+	qty := b.cfg.LotsPerLevel * b.mkt.LotSize
+	for _, rate := range rates {
+		if placed[rate] {
+			continue
+		}
+
+		// The orders of a side are limited so that the inventory stays
+		// within the maximum if they are all filled.
+		if !b.inventoryAllows(sell, qty) {
+			break
+		}
+
+		if b.maxLots(sell, rate) < b.cfg.LotsPerLevel {
+			if len(sideOrders) == 0 {
+				symbol := b.mkt.QuoteSymbol
+				if sell {
+					symbol = b.mkt.BaseSymbol
+				}
+				b.stop(fmt.Sprintf("insufficient %s balance", strings.ToUpper(symbol)))
+				return false
+			}
+			// Funds are still locked in orders being canceled. Try again on
+			// the next refresh.
+			break
+		}
+
+		b.placeOrder(sell, rate, qty)
+	}
+	return true
+}
+
+// inventoryAllows returns true if the net inventory stays within the maximum
+// inventory when all the bot's orders on one side and a new order of qty on
+// that side are filled. Orders being canceled are counted as they may still
+// be matched.
+func (b *bot) inventoryAllows(sell bool, qty uint64) bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	pending := qty
+	for _, o := range b.orders {
+		if o.sell == sell {
+			pending += o.qty - o.filled
+		}
+	}
+
+	maxInventory := int64(b.cfg.MaxInventory)
+	if sell {
+		return b.status.NetInventory-int64(pending) >= -maxInventory
+	}
+	return b.status.NetInventory+int64(pending) <= maxInventory
+}
+
+func (b *bot) maxLots(sell bool, rate uint64) uint64 {
+	var est *core.MaxOrderEstimate
+	var err error
+	if sell {
+		est, err = b.dc.MaxSell(b.cfg.Host, b.cfg.BaseID, b.cfg.QuoteID)
+	} else {
+		est, err = b.dc.MaxBuy(b.cfg.Host, b.cfg.BaseID, b.cfg.QuoteID, rate)
+	}
+	if err != nil || est == nil || est.Swap == nil {
+		log.Debugf("Bot %d: no max order estimate: %v", b.cfg.ID, err)
+		return 0
+	}
+	return est.Swap.Lots
+}
+
+func (b *bot) placeOrder(sell bool, rate, qty uint64) {
+	ord, err := b.dc.Trade(b.appPW, &core.TradeForm{
+		Host:    b.cfg.Host,
+		IsLimit: true,
+		Sell:    sell,
+		Base:    b.cfg.BaseID,
+		Quote:   b.cfg.QuoteID,
+		Qty:     qty,
+		Rate:    rate,
+	})
+	if err != nil {
+		b.reportError(fmt.Errorf("error placing order: %w", err))
+		return
+	}
+
+	b.mtx.Lock()
+	b.orders[ord.ID.String()] = &botOrder{id: ord.ID, sell: sell, rate: rate, qty: qty}
+	b.mtx.Unlock()
+
+	b.mgr.saveEvent(b.orderEvent(EventOrderPlaced, ord.ID, sell, rate, qty))
+}
+
+func (b *bot) cancelOrder(o *botOrder) {
+	if err := b.dc.Cancel(o.id); err != nil {
+		b.reportError(fmt.Errorf("error canceling order %s: %w", o.id, err))
+		return
+	}
+
+	b.mtx.Lock()
+	o.cancelling = true
+	b.mtx.Unlock()
+
+	b.mgr.saveEvent(b.orderEvent(EventOrderCanceled, o.id, o.sell, o.rate, o.qty-o.filled))
+}
+
+// cancelAll cancels all active orders of the bot.
+func (b *bot) cancelAll() {
+	b.updateOrders()
+
+	b.mtx.RLock()
+	orders := make([]*botOrder, 0, len(b.orders))
+	for _, o := range b.orders {
+		if !o.cancelling {
+			orders = append(orders, o)
+		}
+	}
+	b.mtx.RUnlock()
+
+	for _, o := range orders {
+		b.cancelOrder(o)
+	}
+}
+
+// updateOrders records the fills of the bot's orders and stops tracking orders
+// that are no longer active.
+func (b *bot) updateOrders() {
+	b.mtx.RLock()
+	orders := make([]*botOrder, 0, len(b.orders))
+	for _, o := range b.orders {
+		orders = append(orders, o)
+	}
+	b.mtx.RUnlock()
+
+	for _, o := range orders {
+		ord, err := b.dc.Order(o.id)
+		if err != nil {
+			log.Errorf("Bot %d: error fetching order %s: %v", b.cfg.ID, o.id, err)
+			continue
+		}
+
+		b.mtx.Lock()
+		var filled uint64
+		if ord.Filled > o.filled {
+			filled = ord.Filled - o.filled
+			o.filled = ord.Filled
+			if o.sell {
+				b.status.NetInventory -= int64(filled)
+			} else {
+				b.status.NetInventory += int64(filled)
+			}
+		}
+		active := ord.Status.IsActive()
+		if active {
+			o.cancelling = ord.Cancelling
+		} else {
+			delete(b.orders, o.id.String())
+		}
+		b.mtx.Unlock()
+
+		if filled > 0 {
+			b.mgr.saveEvent(b.orderEvent(EventOrderFilled, o.id, o.sell, o.rate, filled))
+		}
+		if !active {
+			event := b.orderEvent(EventOrderDone, o.id, o.sell, o.rate, o.filled)
+			event.Message = ord.Status.String()
+			b.mgr.saveEvent(event)
+		}
+	}
+}
+
+// reportError records an error event unless it repeats the previous error.
+func (b *bot) reportError(err error) {
+	log.Errorf("Bot %d: %v", b.cfg.ID, err)
+
+	b.mtx.Lock()
+	repeated := b.lastErr == err.Error()
+	b.lastErr = err.Error()
+	b.mtx.Unlock()
+
+	if !repeated {
+		b.mgr.saveEvent(&Event{BotID: b.cfg.ID, Type: EventError, Message: err.Error()})
+	}
+}
+
+func (b *bot) orderEvent(eventType EventType, oid dex.Bytes, sell bool, rate, qty uint64) *Event {
+	return &Event{
+		BotID:   b.cfg.ID,
+		Type:    eventType,
+		OrderID: oid.String(),
+		Sell:    sell,
+		Rate:    b.mkt.MsgRateToConventional(rate),
+		Qty:     float64(qty) / dexc.ConversionFactor(b.cfg.BaseID),
+	}
+}
+
+func formatRate(mkt *core.Market, rate uint64) string {
+	return fmt.Sprintf("%.8f %s", mkt.MsgRateToConventional(rate), strings.ToUpper(mkt.QuoteSymbol))
+}
+
+func formatAtoms(atoms int64, assetID uint32) string {
+	return fmt.Sprintf("%.8f", float64(atoms)/dexc.ConversionFactor(assetID))
+}
+
+func orderIDFromString(oid string) (dex.Bytes, error) {
+	return hex.DecodeString(oid)
+}
@@ -0,0 +1,306 @@
+package dexbot
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/orderbook"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/calc"
+	"decred.org/dcrdex/dex/msgjson"
+	"decred.org/dcrdex/dex/order"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/testutil"
+)
+
+// testDEXClient is a DEXClient whose orders stay booked until they are
+// canceled.
+type testDEXClient struct {
+	DEXClient
+	orders   map[string]*core.Order
+	placed   []*core.TradeForm
+	canceled []string
+	maxLots  uint64
+}
+
+func (dc *testDEXClient) Trade(_ []byte, form *core.TradeForm) (*core.Order, error) {
+	oid := make(dex.Bytes, order.OrderIDSize)
+	binary.BigEndian.PutUint32(oid, uint32(len(dc.orders)+1))
+	ord := &core.Order{ID: oid, Sell: form.Sell, Rate: form.Rate, Qty: form.Qty, Status: order.OrderStatusBooked}
+	dc.orders[oid.String()] = ord
+	dc.placed = append(dc.placed, form)
+	return ord, nil
+}
+
+func (dc *testDEXClient) Cancel(oid dex.Bytes) error {
+	dc.orders[oid.String()].Status = order.OrderStatusCanceled
+	dc.canceled = append(dc.canceled, oid.String())
+	return nil
+}
+
+func (dc *testDEXClient) Order(oid dex.Bytes) (*core.Order, error) {
+	return dc.orders[oid.String()], nil
+}
+
+func (dc *testDEXClient) MaxBuy(string, uint32, uint32, uint64) (*core.MaxOrderEstimate, error) {
+	return &core.MaxOrderEstimate{Swap: &asset.SwapEstimate{Lots: dc.maxLots}}, nil
+}
+
+func (dc *testDEXClient) MaxSell(string, uint32, uint32) (*core.MaxOrderEstimate, error) {
+	return &core.MaxOrderEstimate{Swap: &asset.SwapEstimate{Lots: dc.maxLots}}, nil
+}
+
+// newTestBot creates a bot for a market with a lot size of 10 atoms and a
+// conventional rate equal to the message-rate divided by 1e8. The order book
+// has a buy and a sell order at the provided rates.
+func newTestBot(t *testing.T, cfg *Config, bookBuy, bookSell uint64) (*bot, *testDEXClient) {
+	mgr, err := NewManager(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book := orderbook.NewOrderBook(dex.StdOutLogger("BOOK", dex.LevelOff))
+	err = book.Sync(&msgjson.OrderBook{Orders: []*msgjson.BookOrderNote{
+		{OrderNote: msgjson.OrderNote{OrderID: make([]byte, order.OrderIDSize)}, TradeNote: msgjson.TradeNote{Side: msgjson.BuyOrderNum, Quantity: 10, Rate: bookBuy}},
+		{OrderNote: msgjson.OrderNote{OrderID: append(make([]byte, order.OrderIDSize-1), 1)}, TradeNote: msgjson.TradeNote{Side: msgjson.SellOrderNum, Quantity: 10, Rate: bookSell}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dc := &testDEXClient{orders: make(map[string]*core.Order), maxLots: 1000}
+	return &bot{
+		cfg:    cfg,
+		mkt:    &core.Market{BaseSymbol: "dcr", QuoteSymbol: "btc", LotSize: 10, RateStep: 1, AtomToConv: calc.RateEncodingFactor / 1e8},
+		dc:     dc,
+		mgr:    mgr,
+		book:   book,
+		orders: make(map[string]*botOrder),
+		cancel: func() {},
+	}, dc
+}
+
+func TestLadder(t *testing.T) {
+	tests := []struct {
+		name          string
+		refRate       uint64
+		spreadPercent float64
+		levels        int
+		rateStep      uint64
+		minRate       uint64
+		expectedBuys  []uint64
+		expectedSells []uint64
+	}{
+		{
+			name:    "one level",
+			refRate: 1000, spreadPercent: 1, levels: 1, rateStep: 1,
+			expectedBuys: []uint64{990}, expectedSells: []uint64{1010},
+		},
+		{
+			name:    "levels",
+			refRate: 1000, spreadPercent: 2, levels: 3, rateStep: 1,
+			expectedBuys: []uint64{980, 960, 940}, expectedSells: []uint64{1020, 1040, 1060},
+		},
+		{
+			name:    "rounded away from the reference rate",
+			refRate: 1000, spreadPercent: 1.5, levels: 2, rateStep: 20,
+			expectedBuys: []uint64{980, 960}, expectedSells: []uint64{1020, 1040},
+		},
+		{
+			name:    "zero rate step",
+			refRate: 1000, spreadPercent: 1, levels: 1,
+			expectedBuys: []uint64{990}, expectedSells: []uint64{1010},
+		},
+		{
+			name:    "buys below the minimum rate dropped",
+			refRate: 1000, spreadPercent: 10, levels: 3, rateStep: 1, minRate: 850,
+			expectedBuys: []uint64{900}, expectedSells: []uint64{1100, 1200, 1300},
+		},
+	}
+
+	for _, tc := range tests {
+		buys, sells := ladder(tc.refRate, tc.spreadPercent, tc.levels, tc.rateStep, tc.minRate)
+		if !reflect.DeepEqual(buys, tc.expectedBuys) || !reflect.DeepEqual(sells, tc.expectedSells) {
+			t.Errorf("(%v), expected (%v, %v), got (%v, %v)", tc.name, tc.expectedBuys, tc.expectedSells, buys, sells)
+		}
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	tests := []struct {
+		name           string
+		existing       []uint64
+		rates          []uint64
+		netInventory   int64
+		maxInventory   uint64
+		maxLots        uint64
+		expectedPlaced []uint64
+		expectedKept   int
+		expectedStop   bool
+	}{
+		{
+			name:           "place all levels",
+			rates:          []uint64{990, 980},
+			maxInventory:   1000,
+			maxLots:        10,
+			expectedPlaced: []uint64{990, 980},
+		},
+		{
+			name:           "replace moved orders",
+			existing:       []uint64{990, 970},
+			rates:          []uint64{990, 980},
+			maxInventory:   1000,
+			maxLots:        10,
+			expectedPlaced: []uint64{980},
+			expectedKept:   1,
+		},
+		{
+			name:           "duplicate order canceled",
+			existing:       []uint64{990, 990},
+			rates:          []uint64{990},
+			maxInventory:   1000,
+			maxLots:        10,
+			expectedKept:   1,
+			expectedPlaced: nil,
+		},
+		{
+			name:           "inventory limits the ladder",
+			rates:          []uint64{990, 980, 970},
+			netInventory:   10,
+			maxInventory:   30,
+			maxLots:        10,
+			expectedPlaced: []uint64{990, 980},
+		},
+		{
+			name:         "inventory full",
+			rates:        []uint64{990},
+			netInventory: 30,
+			maxInventory: 30,
+			maxLots:      10,
+		},
+		{
+			name:         "insufficient balance stops the bot",
+			rates:        []uint64{990},
+			maxInventory: 1000,
+			expectedStop: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, dc := newTestBot(t, &Config{LotsPerLevel: 1, MaxInventory: tc.maxInventory}, 0, 0)
+			b.status.NetInventory = tc.netInventory
+			for _, rate := range tc.existing {
+				b.placeOrder(false, rate, 10)
+			}
+			dc.placed, dc.maxLots = nil, tc.maxLots
+
+			var stopped bool
+			b.cancel = func() { stopped = true }
+			b.reconcile(false, tc.rates)
+
+			var placed []uint64
+			for _, form := range dc.placed {
+				placed = append(placed, form.Rate)
+			}
+			if !reflect.DeepEqual(placed, tc.expectedPlaced) {
+				t.Errorf("(%v), expected placed (%v), got (%v)", tc.name, tc.expectedPlaced, placed)
+			}
+			if kept := len(tc.existing) - len(dc.canceled); kept != tc.expectedKept {
+				t.Errorf("(%v), expected %d orders kept, got %d", tc.name, tc.expectedKept, kept)
+			}
+			if stopped != tc.expectedStop {
+				t.Errorf("(%v), expected stopped (%v), got (%v)", tc.name, tc.expectedStop, stopped)
+			}
+		})
+	}
+}
+
+func TestInventory(t *testing.T) {
+	b, dc := newTestBot(t, &Config{LotsPerLevel: 1, MaxInventory: 30}, 0, 0)
+	b.placeOrder(false, 990, 10)
+	b.placeOrder(true, 1010, 10)
+
+	for _, ord := range dc.orders {
+		if ord.Sell {
+			ord.Filled = 5
+		} else {
+			ord.Filled, ord.Status = 10, order.OrderStatusExecuted
+		}
+	}
+	b.updateOrders()
+	if b.status.NetInventory != 5 {
+		t.Errorf("expected a net inventory of 5, got %d", b.status.NetInventory)
+	}
+	if len(b.orders) != 1 {
+		t.Errorf("expected the executed order to be dropped, got %d orders", len(b.orders))
+	}
+
+	if !b.inventoryAllows(false, 20) || b.inventoryAllows(false, 30) {
+		t.Error("expected buys to be limited to 25 atoms")
+	}
+	// The 5 atoms left in the sell order count against the sell side.
+	if !b.inventoryAllows(true, 30) || b.inventoryAllows(true, 40) {
+		t.Error("expected sells to be limited to 30 atoms")
+	}
+
+	var stopped bool
+	b.cancel = func() { stopped = true }
+	if !b.checkInventory() || stopped {
+		t.Error("expected the bot to keep running within the maximum inventory")
+	}
+	b.status.NetInventory = -31
+	if b.checkInventory() || !stopped {
+		t.Error("expected the bot to stop above the maximum inventory")
+	}
+}
+
+func TestCheckOracleDeviation(t *testing.T) {
+	tests := []struct {
+		name           string
+		priceSource    PriceSource
+		maxDeviation   float64
+		refRate        uint64
+		expectedPaused bool
+	}{
+		{name: "within default", priceSource: PriceSourceOracle, refRate: 1040},
+		{name: "above default", priceSource: PriceSourceOracle, refRate: 1060, expectedPaused: true},
+		{name: "below default", priceSource: PriceSourceOracle, refRate: 940, expectedPaused: true},
+		{name: "within configured", priceSource: PriceSourceOracle, maxDeviation: 10, refRate: 1060},
+		{name: "mid-gap source", priceSource: PriceSourceMidGap, refRate: 1200},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{PriceSource: tc.priceSource, MaxOracleDeviation: tc.maxDeviation}
+			// The book mid-gap is 1000.
+			b, dc := newTestBot(t, cfg, 990, 1010)
+			b.placeOrder(false, 990, 10)
+
+			if ok := b.checkOracleDeviation(tc.refRate); ok == tc.expectedPaused || b.status.Paused != tc.expectedPaused {
+				t.Fatalf("(%v), expected paused (%v), got (%v)", tc.name, tc.expectedPaused, b.status.Paused)
+			}
+			if canceled := len(dc.canceled) == 1; canceled != tc.expectedPaused {
+				t.Errorf("(%v), expected the orders canceled (%v), got (%v)", tc.name, tc.expectedPaused, canceled)
+			}
+		})
+	}
+
+	b, _ := newTestBot(t, &Config{PriceSource: PriceSourceOracle}, 990, 1010)
+	if b.checkOracleDeviation(1100) {
+		t.Fatal("expected the bot to pause")
+	}
+	if !b.checkOracleDeviation(1000) || b.status.Paused {
+		t.Error("expected the bot to resume")
+	}
+	events, err := b.mgr.Events(0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Type != EventResumed || events[1].Type != EventPaused {
+		t.Errorf("expected paused and resumed events, got %v", events)
+	}
+}
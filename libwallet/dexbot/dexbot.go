@@ -0,0 +1,358 @@
+package dexbot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex"
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// Manager persists the bot configurations and events, and runs the bots.
+type Manager struct {
+	db *storm.DB
+
+	runningMtx sync.RWMutex
+	running    map[int]*bot
+
+	notificationListenersMu sync.RWMutex
+	notificationListeners   map[string]*BotNotificationListener
+}
+
+// NewManager creates a bot manager that uses db for persistence.
+func NewManager(db *storm.DB) (*Manager, error) {
+	if err := db.Init(&Config{}); err != nil {
+		log.Errorf("Error initializing bot config database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&Event{}); err != nil {
+		log.Errorf("Error initializing bot events database: %s", err.Error())
+		return nil, err
+	}
+
+	return &Manager{
+		db:                    db,
+		running:               make(map[int]*bot),
+		notificationListeners: make(map[string]*BotNotificationListener),
+	}, nil
+}
+
+// validate checks that the bot parameters are usable.
+func (cfg *Config) validate() error {
+	switch {
+	case cfg.Host == "":
+		return errors.New("missing DEX host")
+	case cfg.PriceSource != PriceSourceOracle && cfg.PriceSource != PriceSourceMidGap:
+		return fmt.Errorf("unknown price source %q", cfg.PriceSource)
+	case cfg.SpreadPercent <= 0 || cfg.SpreadPercent*float64(cfg.Levels) >= 100:
+		return errors.New("spread must be positive and all levels must be within 100% of the reference price")
+	case cfg.Levels < 1 || cfg.Levels > MaxLevels:
+		return fmt.Errorf("levels must be between 1 and %d", MaxLevels)
+	case cfg.LotsPerLevel == 0:
+		return errors.New("lots per level must be at least 1")
+	case cfg.MaxInventory == 0:
+		return errors.New("max inventory must be set")
+	case cfg.MaxOracleDeviation < 0:
+		return errors.New("max oracle deviation cannot be negative")
+	case cfg.RebalanceInterval < MinRebalanceInterval:
+		return fmt.Errorf("rebalance interval must be at least %s", MinRebalanceInterval)
+	}
+	return nil
+}
+
+// SaveBot creates or updates a bot configuration. Only one bot can be
+// configured for each market and the configuration of a running bot cannot be
+// changed.
+func (m *Manager) SaveBot(cfg *Config) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	if m.IsRunning(cfg.ID) {
+		return errors.New(ErrBotRunning)
+	}
+
+	if existing, err := m.MarketBot(cfg.Host, cfg.BaseID, cfg.QuoteID); err != nil {
+		return err
+	} else if existing != nil && existing.ID != cfg.ID {
+		return errors.New(ErrBotExists)
+	}
+
+	if cfg.ID == 0 {
+		cfg.CreatedAt = time.Now().Unix()
+	}
+	return m.db.Save(cfg)
+}
+
+// Bots returns all saved bot configurations.
+func (m *Manager) Bots() ([]*Config, error) {
+	var bots []*Config
+	err := m.db.All(&bots)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return bots, nil
+}
+
+// Bot returns the configuration of the bot with the provided ID.
+func (m *Manager) Bot(botID int) (*Config, error) {
+	var cfg Config
+	if err := m.db.One("ID", botID, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// MarketBot returns the configuration of the bot for a market. A nil value is
+// returned if no bot is configured for the market.
+func (m *Manager) MarketBot(host string, baseID, quoteID uint32) (*Config, error) {
+	var cfg Config
+	err := m.db.Select(q.Eq("Host", host), q.Eq("BaseID", baseID), q.Eq("QuoteID", quoteID)).First(&cfg)
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// DeleteBot deletes a stopped bot and its events.
+func (m *Manager) DeleteBot(botID int) error {
+	if m.IsRunning(botID) {
+		return errors.New(ErrBotRunning)
+	}
+
+	if err := m.db.Select(q.Eq("BotID", botID)).Delete(&Event{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return m.db.DeleteStruct(&Config{ID: botID})
+}
+
+// Events returns the most recent events of a bot, newest first. All events are
+// returned if limit is 0.
+func (m *Manager) Events(botID, limit int) ([]*Event, error) {
+	query := m.db.Select(q.Eq("BotID", botID)).OrderBy("Stamp", "ID").Reverse()
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var events []*Event
+	err := query.Find(&events)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (m *Manager) saveEvent(event *Event) {
+	event.Stamp = time.Now().Unix()
+	if err := m.db.Save(event); err != nil {
+		log.Errorf("Error saving bot %d event: %v", event.BotID, err)
+	}
+	m.publishEvent(event)
+}
+
+// Start starts the bot with the provided ID. Orders left open by a previous run
+// of the bot, e.g. if the app was not shut down cleanly, are canceled first.
+// The bot runs until it is stopped, one of its limits is exceeded or ctx is
+// canceled.
+func (m *Manager) Start(ctx context.Context, dc DEXClient, botID int, appPW []byte, oracle OracleFunc) error {
+	cfg, err := m.Bot(botID)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+
+	mkt, err := market(dc, cfg)
+	if err != nil {
+		return err
+	}
+
+	if m.IsRunning(botID) {
+		return errors.New(ErrBotRunning)
+	}
+
+	m.cancelOrphanedOrders(dc, botID)
+
+	b := &bot{
+		cfg:    cfg,
+		mkt:    mkt,
+		dc:     dc,
+		appPW:  appPW,
+		oracle: oracle,
+		mgr:    m,
+		orders: make(map[string]*botOrder),
+		status: Status{Running: true, StartedAt: time.Now()},
+		done:   make(chan struct{}),
+	}
+
+	// Sync the book before starting so that the reference price can be
+	// computed on the first refresh.
+	b.book, b.feed, err = dc.SyncBook(cfg.Host, cfg.BaseID, cfg.QuoteID)
+	if err != nil {
+		return fmt.Errorf("error syncing %s book: %w", mkt.Name, err)
+	}
+
+	m.runningMtx.Lock()
+	if _, running := m.running[botID]; running {
+		m.runningMtx.Unlock()
+		b.feed.Close()
+		return errors.New(ErrBotRunning)
+	}
+	var botCtx context.Context
+	botCtx, b.cancel = context.WithCancel(ctx)
+	m.running[botID] = b
+	m.runningMtx.Unlock()
+
+	m.saveEvent(&Event{BotID: botID, Type: EventStarted})
+	go func() {
+		b.run(botCtx)
+
+		m.runningMtx.Lock()
+		delete(m.running, botID)
+		m.runningMtx.Unlock()
+
+		m.saveEvent(&Event{BotID: botID, Type: EventStopped, Message: b.stopReason})
+	}()
+
+	return nil
+}
+
+// cancelOrphanedOrders cancels the orders placed by a previous run of the bot
+// that are still active.
+func (m *Manager) cancelOrphanedOrders(dc DEXClient, botID int) {
+	var lastStart Event
+	err := m.db.Select(q.Eq("BotID", botID), q.Eq("Type", EventStarted)).OrderBy("Stamp", "ID").Reverse().First(&lastStart)
+	if err != nil {
+		return // never started
+	}
+
+	var placed []*Event
+	err = m.db.Select(q.Eq("BotID", botID), q.Eq("Type", EventOrderPlaced), q.Gte("ID", lastStart.ID)).Find(&placed)
+	if err != nil {
+		return
+	}
+
+	for _, event := range placed {
+		oid, err := orderIDFromString(event.OrderID)
+		if err != nil {
+			continue
+		}
+
+		ord, err := dc.Order(oid)
+		if err != nil || !ord.Status.IsActive() || ord.Cancelling {
+			continue
+		}
+
+		if err := dc.Cancel(oid); err != nil {
+			log.Errorf("Error canceling orphaned bot order %s: %v", event.OrderID, err)
+			continue
+		}
+		m.saveEvent(&Event{BotID: botID, Type: EventOrderCanceled, OrderID: event.OrderID, Sell: event.Sell, Rate: event.Rate, Qty: event.Qty})
+	}
+}
+
+// Stop stops a running bot and cancels its orders. Stop blocks until the bot
+// has exited.
+func (m *Manager) Stop(botID int) {
+	m.runningMtx.RLock()
+	b := m.running[botID]
+	m.runningMtx.RUnlock()
+
+	if b == nil {
+		return
+	}
+
+	b.stop("")
+	<-b.done
+}
+
+// StopAll stops all running bots and cancels their orders.
+func (m *Manager) StopAll() {
+	m.runningMtx.RLock()
+	botIDs := make([]int, 0, len(m.running))
+	for botID := range m.running {
+		botIDs = append(botIDs, botID)
+	}
+	m.runningMtx.RUnlock()
+
+	for _, botID := range botIDs {
+		m.Stop(botID)
+	}
+}
+
+// IsRunning returns true if the bot with the provided ID is running.
+func (m *Manager) IsRunning(botID int) bool {
+	m.runningMtx.RLock()
+	defer m.runningMtx.RUnlock()
+	_, running := m.running[botID]
+	return running
+}
+
+// Status returns the state of a bot. A zero value is returned if the bot is
+// not running.
+func (m *Manager) Status(botID int) Status {
+	m.runningMtx.RLock()
+	b := m.running[botID]
+	m.runningMtx.RUnlock()
+
+	if b == nil {
+		return Status{}
+	}
+	return b.currentStatus()
+}
+
+func (m *Manager) AddNotificationListener(notificationListener *BotNotificationListener, uniqueIdentifier string) error {
+	m.notificationListenersMu.Lock()
+	defer m.notificationListenersMu.Unlock()
+
+	if _, ok := m.notificationListeners[uniqueIdentifier]; ok {
+		return errors.New(ErrListenerAlreadyExist)
+	}
+
+	m.notificationListeners[uniqueIdentifier] = notificationListener
+	return nil
+}
+
+func (m *Manager) RemoveNotificationListener(uniqueIdentifier string) {
+	m.notificationListenersMu.Lock()
+	defer m.notificationListenersMu.Unlock()
+
+	delete(m.notificationListeners, uniqueIdentifier)
+}
+
+func (m *Manager) publishEvent(event *Event) {
+	m.notificationListenersMu.RLock()
+	defer m.notificationListenersMu.RUnlock()
+
+	for _, notificationListener := range m.notificationListeners {
+		if notificationListener.OnBotEvent != nil {
+			notificationListener.OnBotEvent(event)
+		}
+	}
+}
+
+// market returns the DEX market traded by the bot.
+func market(dc DEXClient, cfg *Config) (*core.Market, error) {
+	xc, err := dc.Exchange(cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mkt := range xc.Markets {
+		if mkt.BaseID == cfg.BaseID && mkt.QuoteID == cfg.QuoteID {
+			return mkt, nil
+		}
+	}
+	return nil, fmt.Errorf("%s does not have a %s-%s market", cfg.Host, dex.BipIDSymbol(cfg.BaseID), dex.BipIDSymbol(cfg.QuoteID))
+}
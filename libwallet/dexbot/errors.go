@@ -0,0 +1,7 @@
+package dexbot
+
+const (
+	ErrListenerAlreadyExist = "listener_already_exist"
+	ErrBotRunning           = "bot_running"
+	ErrBotExists            = "bot_exists"
+)
@@ -0,0 +1,129 @@
+package dexbot
+
+import (
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/client/orderbook"
+	"decred.org/dcrdex/dex"
+)
+
+// DEXClient is the subset of the DEX client methods used by the bots.
+type DEXClient interface {
+	Exchange(host string) (*core.Exchange, error)
+	SyncBook(host string, base, quote uint32) (*orderbook.OrderBook, core.BookFeed, error)
+	NotificationFeed() *core.NoteFeed
+	Trade(pw []byte, form *core.TradeForm) (*core.Order, error)
+	Cancel(oid dex.Bytes) error
+	Order(oid dex.Bytes) (*core.Order, error)
+	MaxBuy(host string, base, quote uint32, rate uint64) (*core.MaxOrderEstimate, error)
+	MaxSell(host string, base, quote uint32) (*core.MaxOrderEstimate, error)
+}
+
+// OracleFunc returns the conventional price of the base asset in units of the
+// quote asset from an external price source.
+type OracleFunc func(baseID, quoteID uint32) (float64, error)
+
+// PriceSource is the source of the reference price around which a bot places
+// its orders.
+type PriceSource string
+
+const (
+	// PriceSourceOracle uses the fiat rates of both market assets to compute
+	// the reference price.
+	PriceSourceOracle PriceSource = "oracle"
+	// PriceSourceMidGap uses the mid-gap of the DEX order book as the
+	// reference price.
+	PriceSourceMidGap PriceSource = "midgap"
+)
+
+const (
+	// MinRebalanceInterval is the shortest allowed interval between two
+	// periodic rebalances of a bot's orders.
+	MinRebalanceInterval = time.Minute
+	// MaxLevels is the maximum number of orders a bot places on each side of
+	// the book.
+	MaxLevels = 10
+	// DefaultMaxOracleDeviation is the maximum oracle price deviation used
+	// by bots that do not set one.
+	DefaultMaxOracleDeviation = 5.0
+)
+
+// Config is the persisted configuration of a market-making bot. A bot places
+// Levels buy orders below and Levels sell orders above the reference price,
+// each SpreadPercent apart.
+type Config struct {
+	ID          int         `storm:"id,increment" json:"id"`
+	Host        string      `storm:"index" json:"host"`
+	BaseID      uint32      `json:"baseID"`
+	QuoteID     uint32      `json:"quoteID"`
+	PriceSource PriceSource `json:"priceSource"`
+	// SpreadPercent is the gap between the reference price and the first
+	// order on each side, and between adjacent orders, as a percentage of the
+	// reference price.
+	SpreadPercent float64 `json:"spreadPercent"`
+	Levels        int     `json:"levels"`
+	LotsPerLevel  uint64  `json:"lotsPerLevel"`
+	// MaxInventory is the maximum net amount of the base asset, in atoms,
+	// that the bot may buy or sell before it stops.
+	MaxInventory uint64 `json:"maxInventory"`
+	// MaxOracleDeviation is the maximum deviation, as a percentage, of the
+	// oracle price from the order book mid-gap. The bot cancels its orders and
+	// pauses while the deviation is greater. It is only used with
+	// PriceSourceOracle, DefaultMaxOracleDeviation is used if zero.
+	MaxOracleDeviation float64       `json:"maxOracleDeviation,omitempty"`
+	RebalanceInterval  time.Duration `json:"rebalanceInterval"`
+	CreatedAt          int64         `json:"createdAt"`
+}
+
+// EventType identifies what happened in a bot event.
+type EventType string
+
+const (
+	EventStarted       EventType = "started"
+	EventStopped       EventType = "stopped"
+	EventOrderPlaced   EventType = "order_placed"
+	EventOrderCanceled EventType = "order_canceled"
+	EventOrderFilled   EventType = "order_filled"
+	EventOrderDone     EventType = "order_done"
+	EventPaused        EventType = "paused"
+	EventResumed       EventType = "resumed"
+	EventError         EventType = "error"
+)
+
+// Event is a persisted record of a bot action or of an update to one of the
+// bot's orders. Rate and Qty are in conventional units.
+type Event struct {
+	ID      int       `storm:"id,increment" json:"id"`
+	BotID   int       `storm:"index" json:"botID"`
+	Type    EventType `storm:"index" json:"type"`
+	Stamp   int64     `storm:"index" json:"stamp"`
+	OrderID string    `json:"orderID,omitempty"`
+	Sell    bool      `json:"sell,omitempty"`
+	Rate    float64   `json:"rate,omitempty"`
+	Qty     float64   `json:"qty,omitempty"`
+	Message string    `json:"message,omitempty"`
+}
+
+// Status is the state of a running bot.
+type Status struct {
+	Running   bool
+	StartedAt time.Time
+	// RefPrice is the conventional reference price used for the current
+	// orders.
+	RefPrice float64
+	// NetInventory is the net amount of the base asset, in atoms, bought by
+	// the bot since it started. It is negative if the bot sold more than it
+	// bought.
+	NetInventory int64
+	// Paused is true while the bot has no orders because the oracle price
+	// deviates too much from the order book mid-gap.
+	Paused     bool
+	BuyOrders  int
+	SellOrders int
+}
+
+// BotNotificationListener receives the events of all bots.
+type BotNotificationListener struct {
+	OnBotEvent func(event *Event)
+}
@@ -0,0 +1,496 @@
+package electrum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+const (
+	// requestTimeout is the time the server has to respond to a request.
+	requestTimeout = 30 * time.Second
+
+	// headersBatchSize is the number of headers requested at once. It is
+	// the most servers return for a request.
+	headersBatchSize = 2016
+
+	// maxReorgDepth is the deepest reorganization of the chain the client
+	// follows. Headers of blocks deeper than it are not refetched.
+	maxReorgDepth = 100
+
+	// maxCachedHeaders is the number of headers kept in memory before the
+	// headers below the last requested one are dropped. The headers within
+	// maxReorgDepth of the tip are always kept.
+	maxCachedHeaders = 4 * headersBatchSize
+)
+
+// ErrUnknownBlock is returned for blocks the client has not fetched the header
+// of.
+var ErrUnknownBlock = errors.New("unknown block")
+
+// TipChanged is sent on the notifications channel when the best chain of the
+// server changes.
+type TipChanged struct {
+	// Disconnected are the blocks removed from the best chain, tip first.
+	Disconnected []*Header
+	// Connected are the blocks added to the best chain, in ascending
+	// order. The last one is the new tip.
+	Connected []*Header
+}
+
+// ScriptHashChanged is sent on the notifications channel when the history of
+// a subscribed script hash changes.
+type ScriptHashChanged struct {
+	ScriptHash string
+	Status     string
+}
+
+// HistoryItem is a transaction of the history of a script hash. Height is 0
+// for mempool transactions and -1 for mempool transactions with unconfirmed
+// inputs.
+type HistoryItem struct {
+	TxHash string `json:"tx_hash"`
+	Height int32  `json:"height"`
+}
+
+// Unspent is an unspent output of a script hash.
+type Unspent struct {
+	TxHash string `json:"tx_hash"`
+	TxPos  uint32 `json:"tx_pos"`
+	Height int32  `json:"height"`
+	Value  int64  `json:"value"`
+}
+
+// rawNotification is a notification read from the connection.
+type rawNotification struct {
+	method string
+	params json.RawMessage
+}
+
+// Client is a connection to an Electrum server. It verifies the block headers
+// served against the proof of work and checkpoints of the chain and tracks the
+// best chain of the server. The difficulty retargeting of the chain is not
+// checked.
+type Client struct {
+	address string
+	chain   *Chain
+	conn    *conn
+
+	raw   *Queue
+	ntfns *Queue
+
+	mu      sync.RWMutex
+	tip     *Header
+	headers map[int32]*Header
+	heights map[chainhash.Hash]int32
+}
+
+// ScriptHash returns the script hash of the output script, the Electrum
+// protocol's index of the transactions paying to and spending from it.
+func ScriptHash(pkScript []byte) string {
+	hash := sha256.Sum256(pkScript)
+	slices.Reverse(hash[:])
+	return hex.EncodeToString(hash[:])
+}
+
+// Connect connects to the Electrum server at the address and subscribes to its
+// best chain. The cert is the PEM encoded certificate the server's TLS
+// certificate is pinned to, see tlsConfig.
+func Connect(ctx context.Context, address, cert string, chain *Chain) (*Client, error) {
+	c := &Client{
+		address: address,
+		chain:   chain,
+		raw:     NewQueue(),
+		ntfns:   NewQueue(),
+		headers: make(map[int32]*Header),
+		heights: make(map[chainhash.Hash]int32),
+	}
+
+	conn, err := dial(ctx, address, cert, func(method string, params json.RawMessage) {
+		c.raw.Push(&rawNotification{method: method, params: params})
+	})
+	if err != nil {
+		c.raw.Stop()
+		c.ntfns.Stop()
+		return nil, err
+	}
+	c.conn = conn
+
+	var tip struct {
+		Height int32  `json:"height"`
+		Hex    string `json:"hex"`
+	}
+	err = c.request(ctx, "blockchain.headers.subscribe", nil, &tip)
+	if err == nil {
+		c.tip, err = c.parseHexHeader(tip.Height, tip.Hex)
+	}
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	c.cacheHeaders(c.tip)
+
+	go c.handleNotifications()
+	go func() {
+		<-conn.done
+		c.raw.Stop()
+		c.ntfns.Stop()
+	}()
+
+	log.Infof("Connected to electrum server %s at height %d", address, tip.Height)
+	return c, nil
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() {
+	c.conn.close(errors.New("electrum client closed"))
+}
+
+// Done returns a channel that is closed when the connection is closed.
+func (c *Client) Done() <-chan struct{} {
+	return c.conn.done
+}
+
+// Err returns the error the connection was closed with, if it is closed.
+func (c *Client) Err() error {
+	return c.conn.closeErr()
+}
+
+// Address returns the address of the server.
+func (c *Client) Address() string {
+	return c.address
+}
+
+// Notifications returns the channel the TipChanged and ScriptHashChanged
+// notifications are received from. It is closed with the connection.
+func (c *Client) Notifications() <-chan any {
+	return c.ntfns.C()
+}
+
+// request makes a request to the server, timing out after requestTimeout.
+func (c *Client) request(ctx context.Context, method string, params []any, result any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	return c.conn.request(ctx, method, params, result)
+}
+
+// handleNotifications handles the notifications of the connection in order.
+func (c *Client) handleNotifications() {
+	for item := range c.raw.C() {
+		n := item.(*rawNotification)
+		switch n.method {
+		case "blockchain.headers.subscribe":
+			var tips []struct {
+				Height int32  `json:"height"`
+				Hex    string `json:"hex"`
+			}
+			if err := json.Unmarshal(n.params, &tips); err != nil {
+				log.Errorf("Invalid header notification: %v", err)
+				continue
+			}
+			for _, tip := range tips {
+				header, err := c.parseHexHeader(tip.Height, tip.Hex)
+				if err == nil {
+					err = c.connectTip(context.Background(), header)
+				}
+				if err != nil {
+					// The headers of the server cannot be
+					// trusted anymore.
+					c.conn.close(fmt.Errorf("invalid chain tip from electrum server: %v", err))
+					return
+				}
+			}
+
+		case "blockchain.scripthash.subscribe":
+			var params []*string
+			if err := json.Unmarshal(n.params, &params); err != nil || len(params) != 2 || params[0] == nil {
+				log.Errorf("Invalid script hash notification: %s", n.params)
+				continue
+			}
+			status := ""
+			if params[1] != nil {
+				status = *params[1]
+			}
+			c.ntfns.Push(&ScriptHashChanged{ScriptHash: *params[0], Status: status})
+		}
+	}
+}
+
+func (c *Client) parseHexHeader(height int32, hexHeader string) (*Header, error) {
+	raw, err := hex.DecodeString(hexHeader)
+	if err != nil {
+		return nil, err
+	}
+	return c.chain.parseHeader(height, raw)
+}
+
+// fetchHeaders fetches and verifies the headers from start to end, which are
+// not cached.
+func (c *Client) fetchHeaders(ctx context.Context, start, end int32) ([]*Header, error) {
+	var headers []*Header
+	for height := start; height <= end; {
+		count := min(end-height+1, headersBatchSize)
+		var resp struct {
+			Count int32  `json:"count"`
+			Hex   string `json:"hex"`
+		}
+		if err := c.request(ctx, "blockchain.block.headers", []any{height, count}, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Count <= 0 || resp.Count > count {
+			return nil, fmt.Errorf("electrum server returned %d headers, requested %d", resp.Count, count)
+		}
+		raw, err := hex.DecodeString(resp.Hex)
+		if err != nil {
+			return nil, err
+		}
+		batch, err := c.chain.parseHeaders(height, raw)
+		if err != nil {
+			return nil, err
+		}
+		if len(headers) > 0 && batch[0].PrevHash() != headers[len(headers)-1].Hash {
+			return nil, fmt.Errorf("block header %d does not connect to the previous header", height)
+		}
+		headers = append(headers, batch...)
+		height += int32(len(batch))
+	}
+	return headers, nil
+}
+
+// cacheHeaders caches headers of the best chain.
+func (c *Client) cacheHeaders(headers ...*Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, header := range headers {
+		if old, ok := c.headers[header.Height]; ok {
+			delete(c.heights, old.Hash)
+		}
+		c.headers[header.Height] = header
+		c.heights[header.Hash] = header.Height
+	}
+}
+
+// trimHeaders drops the cached headers below the height, except those within
+// maxReorgDepth of the tip, once there are more than maxCachedHeaders.
+func (c *Client) trimHeaders(height int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.headers) <= maxCachedHeaders {
+		return
+	}
+	for h, header := range c.headers {
+		if h < height && h < c.tip.Height-maxReorgDepth {
+			delete(c.headers, h)
+			delete(c.heights, header.Hash)
+		}
+	}
+}
+
+// connectTip makes the header the tip of the best chain and sends the blocks
+// connected and disconnected by the change.
+func (c *Client) connectTip(ctx context.Context, tip *Header) error {
+	c.mu.RLock()
+	oldTip := c.tip
+	c.mu.RUnlock()
+	if tip.Hash == oldTip.Hash {
+		return nil
+	}
+
+	// Walk back from the new tip until it connects to the cached chain.
+	newChain := map[int32]*Header{tip.Height: tip}
+	fork := int32(-1)
+	for cur := tip; cur.Height > 0; {
+		height := cur.Height - 1
+		c.mu.RLock()
+		cached := c.headers[height]
+		c.mu.RUnlock()
+		if cached != nil && cached.Hash == cur.PrevHash() {
+			fork = height
+			break
+		}
+		if cached == nil && height <= oldTip.Height {
+			// The cached headers were trimmed below the height,
+			// too deep for a reorganization.
+			fork = height
+			break
+		}
+		if tip.Height-height > maxReorgDepth {
+			return fmt.Errorf("chain reorganization deeper than %d blocks", maxReorgDepth)
+		}
+
+		prev := newChain[height]
+		if prev == nil {
+			start := height
+			if height > oldTip.Height {
+				start = oldTip.Height + 1
+			}
+			headers, err := c.fetchHeaders(ctx, start, height)
+			if err != nil {
+				return err
+			}
+			for _, header := range headers {
+				newChain[header.Height] = header
+			}
+			prev = newChain[height]
+		}
+		if prev.Hash != cur.PrevHash() {
+			return fmt.Errorf("block header %d does not connect to the next header", height)
+		}
+		cur = prev
+	}
+
+	change := new(TipChanged)
+	c.mu.Lock()
+	for height := oldTip.Height; height > fork; height-- {
+		if header, ok := c.headers[height]; ok {
+			change.Disconnected = append(change.Disconnected, header)
+			delete(c.headers, height)
+			delete(c.heights, header.Hash)
+		}
+	}
+	for height := fork + 1; height <= tip.Height; height++ {
+		change.Connected = append(change.Connected, newChain[height])
+	}
+	c.tip = tip
+	c.mu.Unlock()
+
+	c.cacheHeaders(change.Connected...)
+	c.trimHeaders(tip.Height)
+
+	log.Debugf("Electrum server %s tip changed to block %d (%s)", c.address, tip.Height, tip.Hash)
+	c.ntfns.Push(change)
+	return nil
+}
+
+// Tip returns the header of the best block of the server.
+func (c *Client) Tip() *Header {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tip
+}
+
+// Header returns the header of the best chain at the height, fetching it and
+// the following headers if it is not cached.
+func (c *Client) Header(ctx context.Context, height int32) (*Header, error) {
+	c.mu.RLock()
+	header, tipHeight := c.headers[height], c.tip.Height
+	c.mu.RUnlock()
+	if header != nil {
+		return header, nil
+	}
+	if height < 0 || height > tipHeight {
+		return nil, fmt.Errorf("%w at height %d", ErrUnknownBlock, height)
+	}
+
+	headers, err := c.fetchHeaders(ctx, height, min(height+headersBatchSize-1, tipHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	// The fetched headers must connect to the cached ones around them,
+	// unless the best chain changed meanwhile.
+	first, last := headers[0], headers[len(headers)-1]
+	c.mu.RLock()
+	prev, next := c.headers[first.Height-1], c.headers[last.Height+1]
+	c.mu.RUnlock()
+	if (prev != nil && first.PrevHash() != prev.Hash) || (next != nil && next.PrevHash() != last.Hash) {
+		return nil, fmt.Errorf("block headers %d-%d do not connect to the best chain", first.Height, last.Height)
+	}
+
+	c.cacheHeaders(headers...)
+	c.trimHeaders(height)
+	return first, nil
+}
+
+// HeaderByHash returns the cached header of the best chain with the hash.
+func (c *Client) HeaderByHash(hash chainhash.Hash) (*Header, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	height, ok := c.heights[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownBlock, hash)
+	}
+	return c.headers[height], nil
+}
+
+// History returns the confirmed and mempool transactions of the script hash.
+func (c *Client) History(ctx context.Context, scriptHash string) ([]*HistoryItem, error) {
+	var history []*HistoryItem
+	err := c.request(ctx, "blockchain.scripthash.get_history", []any{scriptHash}, &history)
+	return history, err
+}
+
+// ListUnspent returns the unspent outputs of the script hash.
+func (c *Client) ListUnspent(ctx context.Context, scriptHash string) ([]*Unspent, error) {
+	var unspent []*Unspent
+	err := c.request(ctx, "blockchain.scripthash.listunspent", []any{scriptHash}, &unspent)
+	return unspent, err
+}
+
+// Subscribe subscribes to the changes of the history of the script hash and
+// returns the current status of the history, which is empty if the history is
+// empty.
+func (c *Client) Subscribe(ctx context.Context, scriptHash string) (string, error) {
+	var status *string
+	if err := c.request(ctx, "blockchain.scripthash.subscribe", []any{scriptHash}, &status); err != nil {
+		return "", err
+	}
+	if status == nil {
+		return "", nil
+	}
+	return *status, nil
+}
+
+// Transaction returns the serialized transaction with the hash.
+func (c *Client) Transaction(ctx context.Context, txHash string) ([]byte, error) {
+	var txHex string
+	if err := c.request(ctx, "blockchain.transaction.get", []any{txHash}, &txHex); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(txHex)
+}
+
+// VerifyTransaction checks the merkle proof of the transaction's inclusion in
+// the block of the best chain at the height.
+func (c *Client) VerifyTransaction(ctx context.Context, txHash chainhash.Hash, height int32) error {
+	header, err := c.Header(ctx, height)
+	if err != nil {
+		return err
+	}
+
+	var proof struct {
+		Merkle []string `json:"merkle"`
+		Pos    int      `json:"pos"`
+	}
+	if err := c.request(ctx, "blockchain.transaction.get_merkle", []any{txHash.String(), height}, &proof); err != nil {
+		return err
+	}
+	branch := make([]chainhash.Hash, len(proof.Merkle))
+	for i, hashStr := range proof.Merkle {
+		hash, err := chainhash.NewHashFromStr(hashStr)
+		if err != nil {
+			return err
+		}
+		branch[i] = *hash
+	}
+
+	if merkleRoot(txHash, branch, proof.Pos) != header.MerkleRoot() {
+		return fmt.Errorf("transaction %s is not included in block %d", txHash, height)
+	}
+	return nil
+}
+
+// Broadcast publishes the serialized transaction and returns its hash.
+func (c *Client) Broadcast(ctx context.Context, rawTx []byte) (string, error) {
+	var txHash string
+	err := c.request(ctx, "blockchain.transaction.broadcast", []any{hex.EncodeToString(rawTx)}, &txHash)
+	return txHash, err
+}
@@ -0,0 +1,281 @@
+package electrum
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+const (
+	// clientName is the name the client reports to the server.
+	clientName = "cryptopower"
+	// protocolVersion is the Electrum protocol version the client speaks.
+	protocolVersion = "1.4"
+
+	dialTimeout  = 10 * time.Second
+	pingInterval = time.Minute
+
+	// maxMessageSize is the size of the largest message read from the
+	// server. The largest responses are batches of block headers.
+	maxMessageSize = 4 << 20
+)
+
+// request is a JSON-RPC request sent to the server.
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// RPCError is an error returned by the server for a request.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("electrum error %d: %s", e.Code, e.Message)
+}
+
+// message is a response or a notification read from the server. Responses
+// have an ID, notifications have a method.
+type message struct {
+	ID     *uint64         `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+// conn is a JSON-RPC connection to an Electrum server. Requests may be made
+// concurrently, notifications are handed to the notify function in the order
+// they are received.
+type conn struct {
+	netConn net.Conn
+	notify  func(method string, params json.RawMessage)
+
+	writeMu sync.Mutex
+
+	mu        sync.Mutex
+	nextID    uint64
+	responses map[uint64]chan *message
+	err       error
+
+	done chan struct{}
+}
+
+// tlsConfig returns the TLS configuration for the server at the host. A PEM
+// encoded cert pins the connection to that certificate. Without it, servers
+// on the loopback interface are connected to over plain TCP and remote servers
+// must present a certificate trusted by the system.
+func tlsConfig(host, cert string) (*tls.Config, error) {
+	if cert == "" {
+		ip := net.ParseIP(host)
+		if host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil, nil
+		}
+		return &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}, nil
+	}
+
+	block, _ := pem.Decode([]byte(cert))
+	if block == nil {
+		return nil, errors.New("electrum server certificate is not PEM encoded")
+	}
+	pinned, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid electrum server certificate: %v", err)
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Electrum servers commonly use self-signed certificates. The
+		// chain is not verified, the certificate presented must be the
+		// pinned one instead.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], pinned.Raw) {
+				return errors.New("electrum server certificate does not match the pinned certificate")
+			}
+			return nil
+		},
+	}, nil
+}
+
+// dial connects to the server at the address and negotiates the protocol
+// version.
+func dial(ctx context.Context, address, cert string, notify func(string, json.RawMessage)) (*conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := tlsConfig(host, cert)
+	if err != nil {
+		return nil, err
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	netConn, err := new(net.Dialer).DialContext(dialCtx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	if cfg != nil {
+		tlsConn := tls.Client(netConn, cfg)
+		if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+		netConn = tlsConn
+	}
+
+	c := &conn{
+		netConn:   netConn,
+		notify:    notify,
+		responses: make(map[uint64]chan *message),
+		done:      make(chan struct{}),
+	}
+	go c.read()
+
+	var version []string
+	if err := c.request(dialCtx, "server.version", []any{clientName, protocolVersion}, &version); err != nil {
+		c.close(err)
+		return nil, err
+	}
+	log.Debugf("Connected to electrum server %s (%v)", address, version)
+
+	go c.ping()
+	return c, nil
+}
+
+// read reads the messages from the server until the connection is closed.
+func (c *conn) read() {
+	scanner := bufio.NewScanner(c.netConn)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxMessageSize)
+	for scanner.Scan() {
+		msg := new(message)
+		if err := json.Unmarshal(scanner.Bytes(), msg); err != nil {
+			log.Errorf("Invalid message from electrum server: %v", err)
+			continue
+		}
+
+		if msg.ID == nil {
+			if msg.Method != "" {
+				c.notify(msg.Method, msg.Params)
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		respC := c.responses[*msg.ID]
+		delete(c.responses, *msg.ID)
+		c.mu.Unlock()
+		if respC != nil {
+			respC <- msg
+		}
+	}
+
+	err := scanner.Err()
+	if err == nil {
+		err = errors.New("electrum server closed the connection")
+	}
+	c.close(err)
+}
+
+// ping keeps the connection alive.
+func (c *conn) ping() {
+	t := time.NewTicker(pingInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+			err := c.request(ctx, "server.ping", nil, nil)
+			cancel()
+			if err != nil {
+				c.close(fmt.Errorf("electrum server ping failed: %v", err))
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// request sends a request to the server and decodes its result into result,
+// which may be nil.
+func (c *conn) request(ctx context.Context, method string, params []any, result any) error {
+	if params == nil {
+		params = []any{}
+	}
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := c.nextID
+	respC := make(chan *message, 1)
+	c.responses[id] = respC
+	c.mu.Unlock()
+
+	b, err := json.Marshal(&request{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	_, err = c.netConn.Write(append(b, '\n'))
+	c.writeMu.Unlock()
+	if err != nil {
+		c.close(err)
+		return err
+	}
+
+	select {
+	case msg := <-respC:
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	case <-c.done:
+		return c.closeErr()
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.responses, id)
+		c.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// close closes the connection with the error returned by later requests.
+func (c *conn) close(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	c.netConn.Close()
+	close(c.done)
+}
+
+// closeErr returns the error the connection was closed with.
+func (c *conn) closeErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
@@ -0,0 +1,431 @@
+package electrum
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var regtest = &Chain{
+	Checkpoints: map[int32]chainhash.Hash{0: *chaincfg.RegressionNetParams.GenesisHash},
+	PowLimit:    chaincfg.RegressionNetParams.PowLimit,
+}
+
+// mineHeader returns a regtest header building on prev that meets its proof of
+// work target.
+func mineHeader(t *testing.T, prev chainhash.Hash, merkleRoot chainhash.Hash, ts time.Time) []byte {
+	t.Helper()
+
+	header := wire.BlockHeader{
+		Version:    4,
+		PrevBlock:  prev,
+		MerkleRoot: merkleRoot,
+		Timestamp:  ts,
+		Bits:       chaincfg.RegressionNetParams.PowLimitBits,
+	}
+	target := blockchain.CompactToBig(header.Bits)
+	for {
+		hash := header.BlockHash()
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			break
+		}
+		header.Nonce++
+	}
+
+	var buf bytes.Buffer
+	if err := header.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// mineChain returns the genesis header followed by n mined headers.
+func mineChain(t *testing.T, n int) [][]byte {
+	t.Helper()
+
+	var genesis bytes.Buffer
+	if err := chaincfg.RegressionNetParams.GenesisBlock.Header.Serialize(&genesis); err != nil {
+		t.Fatal(err)
+	}
+	chain := [][]byte{genesis.Bytes()}
+	return extendChain(t, chain, n, chainhash.Hash{})
+}
+
+// extendChain mines n headers on top of the chain with the merkle root.
+func extendChain(t *testing.T, chain [][]byte, n int, merkleRoot chainhash.Hash) [][]byte {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		prev := chain[len(chain)-1]
+		ts := time.Unix(1700000000+int64(len(chain))*600, 0)
+		chain = append(chain, mineHeader(t, chainhash.DoubleHashH(prev), merkleRoot, ts))
+	}
+	return chain
+}
+
+// standIn is a local Electrum server serving a chain of headers and the
+// results of the other methods set by the tests.
+type standIn struct {
+	t        *testing.T
+	listener net.Listener
+
+	mu      sync.Mutex
+	headers [][]byte
+	results map[string]any
+	conns   []net.Conn
+}
+
+func newStandIn(t *testing.T, headers [][]byte, tlsCfg *tls.Config) *standIn {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsCfg != nil {
+		listener = tls.NewListener(listener, tlsCfg)
+	}
+	s := &standIn{t: t, listener: listener, headers: headers, results: make(map[string]any)}
+	t.Cleanup(func() {
+		listener.Close()
+		s.mu.Lock()
+		for _, conn := range s.conns {
+			conn.Close()
+		}
+		s.mu.Unlock()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *standIn) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *standIn) setResult(method string, result any) {
+	s.mu.Lock()
+	s.results[method] = result
+	s.mu.Unlock()
+}
+
+func (s *standIn) setHeaders(headers [][]byte) {
+	s.mu.Lock()
+	s.headers = headers
+	s.mu.Unlock()
+}
+
+// notify sends a notification to the connected clients.
+func (s *standIn) notify(method string, params ...any) {
+	b, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Write(append(b, '\n'))
+	}
+}
+
+func (s *standIn) tip() map[string]any {
+	return map[string]any{"height": len(s.headers) - 1, "hex": hex.EncodeToString(s.headers[len(s.headers)-1])}
+}
+
+func (s *standIn) serve(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var req struct {
+			ID     uint64            `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			s.t.Errorf("invalid request: %v", err)
+			return
+		}
+
+		s.mu.Lock()
+		var result any
+		switch req.Method {
+		case "server.version":
+			result = []string{"stand-in", protocolVersion}
+		case "server.ping":
+		case "blockchain.headers.subscribe":
+			result = s.tip()
+		case "blockchain.block.headers":
+			var start, count int
+			json.Unmarshal(req.Params[0], &start)
+			json.Unmarshal(req.Params[1], &count)
+			end := min(start+count, len(s.headers))
+			result = map[string]any{"count": end - start, "hex": hex.EncodeToString(bytes.Join(s.headers[start:end], nil)), "max": headersBatchSize}
+		default:
+			result = s.results[req.Method]
+		}
+		s.mu.Unlock()
+
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result}
+		if err, ok := result.(*RPCError); ok {
+			resp = map[string]any{"jsonrpc": "2.0", "id": req.ID, "error": err}
+		}
+		b, _ := json.Marshal(resp)
+		if _, err := conn.Write(append(b, '\n')); err != nil {
+			return
+		}
+	}
+}
+
+func connect(t *testing.T, s *standIn, cert string) *Client {
+	t.Helper()
+
+	c, err := Connect(context.Background(), s.address(), cert, regtest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestScriptHash(t *testing.T) {
+	// The example of the Electrum protocol documentation, the P2PKH script
+	// of 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa.
+	script, _ := hex.DecodeString("76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac")
+	want := "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+	if got := ScriptHash(script); got != want {
+		t.Fatalf("expected script hash %s, got %s", want, got)
+	}
+}
+
+func TestClientHeaders(t *testing.T) {
+	headers := mineChain(t, 30)
+	s := newStandIn(t, headers, nil)
+	c := connect(t, s, "")
+
+	if tip := c.Tip(); tip.Height != 30 || tip.Hash != chainhash.DoubleHashH(headers[30]) {
+		t.Fatalf("unexpected tip %d %s", tip.Height, tip.Hash)
+	}
+
+	header, err := c.Header(context.Background(), 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Hash != chainhash.DoubleHashH(headers[12]) || header.PrevHash() != chainhash.DoubleHashH(headers[11]) {
+		t.Fatalf("unexpected header %d", header.Height)
+	}
+	if byHash, err := c.HeaderByHash(header.Hash); err != nil || byHash.Height != 12 {
+		t.Fatalf("header by hash: %v", err)
+	}
+	if _, err := c.Header(context.Background(), 31); !errors.Is(err, ErrUnknownBlock) {
+		t.Fatalf("expected ErrUnknownBlock above the tip, got %v", err)
+	}
+}
+
+func TestClientRejectsInvalidHeaders(t *testing.T) {
+	headers := mineChain(t, 10)
+
+	// A header that does not build on the previous one.
+	broken := append([][]byte{}, headers...)
+	broken[5] = mineHeader(t, chainhash.Hash{1}, chainhash.Hash{}, time.Unix(1700000000, 0))
+	c := connect(t, newStandIn(t, broken, nil), "")
+	if _, err := c.Header(context.Background(), 3); err == nil {
+		t.Fatal("expected an error for headers that do not connect")
+	}
+
+	// A tip without the proof of work of its target.
+	invalid := append([][]byte{}, headers...)
+	tip := bytes.Clone(invalid[10])
+	for {
+		tip[76]++ // nonce
+		hash := chainhash.DoubleHashH(tip)
+		if blockchain.HashToBig(&hash).Cmp(regtest.PowLimit) > 0 {
+			break
+		}
+	}
+	invalid[10] = tip
+	if _, err := Connect(context.Background(), newStandIn(t, invalid, nil).address(), "", regtest); err == nil {
+		t.Fatal("expected an error for a tip without proof of work")
+	}
+
+	// A genesis block of another chain.
+	wrongGenesis := &Chain{Checkpoints: map[int32]chainhash.Hash{0: {2}}, PowLimit: regtest.PowLimit}
+	c, err := Connect(context.Background(), newStandIn(t, headers, nil).address(), "", wrongGenesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if _, err := c.Header(context.Background(), 0); err == nil {
+		t.Fatal("expected an error for a genesis block not matching the checkpoint")
+	}
+}
+
+func TestClientTipChanges(t *testing.T) {
+	headers := mineChain(t, 20)
+	s := newStandIn(t, headers, nil)
+	c := connect(t, s, "")
+	if _, err := c.Header(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	next := func() *TipChanged {
+		t.Helper()
+		select {
+		case n := <-c.Notifications():
+			return n.(*TipChanged)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no tip notification")
+			return nil
+		}
+	}
+
+	// Two new blocks, the first one not announced.
+	headers = extendChain(t, headers, 2, chainhash.Hash{})
+	s.setHeaders(headers)
+	s.notify("blockchain.headers.subscribe", s.tip())
+	change := next()
+	if len(change.Disconnected) != 0 || len(change.Connected) != 2 || change.Connected[0].Height != 21 || c.Tip().Height != 22 {
+		t.Fatalf("unexpected tip change: %d disconnected, %d connected", len(change.Disconnected), len(change.Connected))
+	}
+
+	// A reorganization replacing the last two blocks with three others.
+	fork := extendChain(t, append([][]byte{}, headers[:21]...), 3, chainhash.Hash{3})
+	s.setHeaders(fork)
+	s.notify("blockchain.headers.subscribe", s.tip())
+	change = next()
+	if len(change.Disconnected) != 2 || change.Disconnected[0].Height != 22 || change.Disconnected[1].Height != 21 {
+		t.Fatalf("expected blocks 22 and 21 to be disconnected, got %d blocks", len(change.Disconnected))
+	}
+	if len(change.Connected) != 3 || change.Connected[0].Height != 21 || change.Connected[2].Hash != chainhash.DoubleHashH(fork[23]) {
+		t.Fatalf("expected blocks 21 to 23 to be connected, got %d blocks", len(change.Connected))
+	}
+	if _, err := c.HeaderByHash(chainhash.DoubleHashH(headers[22])); err == nil {
+		t.Fatal("disconnected block is still known")
+	}
+}
+
+func TestClientScriptHashes(t *testing.T) {
+	s := newStandIn(t, mineChain(t, 5), nil)
+	c := connect(t, s, "")
+	ctx := context.Background()
+	const scriptHash = "8b01df4e368ea28f8dc0423bcf7a4923e3a12d307c875e47a0cfbf90b5c39161"
+
+	s.setResult("blockchain.scripthash.subscribe", nil)
+	if status, err := c.Subscribe(ctx, scriptHash); err != nil || status != "" {
+		t.Fatalf("expected an empty status, got %q %v", status, err)
+	}
+
+	s.notify("blockchain.scripthash.subscribe", scriptHash, "status")
+	select {
+	case n := <-c.Notifications():
+		if changed, ok := n.(*ScriptHashChanged); !ok || changed.ScriptHash != scriptHash || changed.Status != "status" {
+			t.Fatalf("unexpected notification %#v", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no script hash notification")
+	}
+
+	s.setResult("blockchain.scripthash.get_history", []map[string]any{{"tx_hash": "aa", "height": 3}, {"tx_hash": "bb", "height": 0}})
+	history, err := c.History(ctx, scriptHash)
+	if err != nil || len(history) != 2 || history[0].Height != 3 || history[1].TxHash != "bb" {
+		t.Fatalf("unexpected history %v %v", history, err)
+	}
+
+	s.setResult("blockchain.scripthash.listunspent", []map[string]any{{"tx_hash": "aa", "tx_pos": 1, "height": 3, "value": 5000}})
+	unspent, err := c.ListUnspent(ctx, scriptHash)
+	if err != nil || len(unspent) != 1 || unspent[0].TxPos != 1 || unspent[0].Value != 5000 {
+		t.Fatalf("unexpected unspent outputs %v %v", unspent, err)
+	}
+
+	s.setResult("blockchain.transaction.broadcast", &RPCError{Code: 1, Message: "min relay fee not met"})
+	var rpcErr *RPCError
+	if _, err := c.Broadcast(ctx, []byte{1}); !errors.As(err, &rpcErr) || rpcErr.Code != 1 {
+		t.Fatalf("expected the broadcast error of the server, got %v", err)
+	}
+}
+
+func TestClientVerifyTransaction(t *testing.T) {
+	// A block of three transactions, the third one duplicated to pair it.
+	txA, txB, txC := chainhash.Hash{0xa}, chainhash.Hash{0xb}, chainhash.Hash{0xc}
+	pair := func(l, r chainhash.Hash) chainhash.Hash {
+		return chainhash.DoubleHashH(append(l[:], r[:]...))
+	}
+	ab := pair(txA, txB)
+	root := pair(ab, pair(txC, txC))
+
+	headers := extendChain(t, mineChain(t, 3), 1, root)
+	s := newStandIn(t, headers, nil)
+	c := connect(t, s, "")
+
+	s.setResult("blockchain.transaction.get_merkle", map[string]any{
+		"block_height": 4, "pos": 2, "merkle": []string{txC.String(), ab.String()},
+	})
+	if err := c.VerifyTransaction(context.Background(), txC, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.VerifyTransaction(context.Background(), txA, 4); err == nil {
+		t.Fatal("expected an error for a transaction not in the block")
+	}
+}
+
+func testTLSCert(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "electrum"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestClientPinnedCertificate(t *testing.T) {
+	cert, certPEM := testTLSCert(t)
+	_, otherPEM := testTLSCert(t)
+	s := newStandIn(t, mineChain(t, 2), &tls.Config{Certificates: []tls.Certificate{cert}})
+
+	c := connect(t, s, certPEM)
+	if c.Tip().Height != 2 {
+		t.Fatalf("unexpected tip %d", c.Tip().Height)
+	}
+
+	if _, err := Connect(context.Background(), s.address(), otherPEM, regtest); err == nil {
+		t.Fatal("expected an error for a certificate that is not pinned")
+	}
+}
@@ -0,0 +1,128 @@
+package electrum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+)
+
+// HeaderSize is the size of a serialized block header.
+const HeaderSize = 80
+
+// Chain describes the blockchain served by an Electrum server. It is used to
+// verify the block headers received from the server.
+type Chain struct {
+	// Checkpoints maps heights to the hashes of known blocks. It should
+	// include the genesis block.
+	Checkpoints map[int32]chainhash.Hash
+	// PowLimit is the highest proof of work target of the chain.
+	PowLimit *big.Int
+	// PowHash returns the hash of a serialized header its proof of work is
+	// checked against. The block hash is used if it is nil.
+	PowHash func(header []byte) chainhash.Hash
+}
+
+// Header is a block header verified by the client.
+type Header struct {
+	Height int32
+	Hash   chainhash.Hash
+	// Raw is the serialized header.
+	Raw []byte
+}
+
+// PrevHash returns the hash of the previous block.
+func (h *Header) PrevHash() chainhash.Hash {
+	var hash chainhash.Hash
+	copy(hash[:], h.Raw[4:36])
+	return hash
+}
+
+// MerkleRoot returns the merkle root of the transactions of the block.
+func (h *Header) MerkleRoot() chainhash.Hash {
+	var hash chainhash.Hash
+	copy(hash[:], h.Raw[36:68])
+	return hash
+}
+
+// Timestamp returns the time the block was mined.
+func (h *Header) Timestamp() time.Time {
+	return time.Unix(int64(binary.LittleEndian.Uint32(h.Raw[68:72])), 0)
+}
+
+// parseHeader verifies the proof of work of the serialized header at the
+// height. The header must match the checkpoint of the height, if any.
+func (chain *Chain) parseHeader(height int32, raw []byte) (*Header, error) {
+	if len(raw) != HeaderSize {
+		return nil, fmt.Errorf("block header %d has %d bytes", height, len(raw))
+	}
+
+	header := &Header{
+		Height: height,
+		Hash:   chainhash.DoubleHashH(raw),
+		Raw:    bytes.Clone(raw),
+	}
+
+	if checkpoint, ok := chain.Checkpoints[height]; ok && checkpoint != header.Hash {
+		return nil, fmt.Errorf("block %d is %s, expected checkpoint %s", height, header.Hash, checkpoint)
+	}
+
+	target := blockchain.CompactToBig(binary.LittleEndian.Uint32(raw[72:76]))
+	if target.Sign() <= 0 || target.Cmp(chain.PowLimit) > 0 {
+		return nil, fmt.Errorf("block %d has an invalid proof of work target", height)
+	}
+	powHash := header.Hash
+	if chain.PowHash != nil {
+		powHash = chain.PowHash(raw)
+	}
+	if blockchain.HashToBig(&powHash).Cmp(target) > 0 {
+		return nil, fmt.Errorf("block %d does not meet its proof of work target", height)
+	}
+
+	return header, nil
+}
+
+// parseHeaders verifies the concatenated headers beginning at the height and
+// that each of them builds on the previous one.
+func (chain *Chain) parseHeaders(height int32, raw []byte) ([]*Header, error) {
+	if len(raw)%HeaderSize != 0 {
+		return nil, errors.New("block headers are truncated")
+	}
+
+	headers := make([]*Header, 0, len(raw)/HeaderSize)
+	for i := 0; i < len(raw); i += HeaderSize {
+		header, err := chain.parseHeader(height+int32(len(headers)), raw[i:i+HeaderSize])
+		if err != nil {
+			return nil, err
+		}
+		if len(headers) > 0 && header.PrevHash() != headers[len(headers)-1].Hash {
+			return nil, fmt.Errorf("block header %d does not connect to the previous header", header.Height)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// merkleRoot returns the merkle root of the block the transaction is included
+// in from the branch of the transaction's merkle proof at the position.
+func merkleRoot(txHash chainhash.Hash, branch []chainhash.Hash, pos int) chainhash.Hash {
+	root := txHash
+	var buf [chainhash.HashSize * 2]byte
+	for _, hash := range branch {
+		if pos&1 == 1 {
+			copy(buf[:chainhash.HashSize], hash[:])
+			copy(buf[chainhash.HashSize:], root[:])
+		} else {
+			copy(buf[:chainhash.HashSize], root[:])
+			copy(buf[chainhash.HashSize:], hash[:])
+		}
+		root = chainhash.DoubleHashH(buf[:])
+		pos >>= 1
+	}
+	return root
+}
@@ -0,0 +1,91 @@
+package electrum
+
+import "sync"
+
+// Queue is an unbounded FIFO queue of notifications. Pushing never blocks, so
+// the notifications of a connection may be queued while its reader waits for
+// the responses of requests made by the consumer.
+type Queue struct {
+	mu      sync.Mutex
+	items   []any
+	signal  chan struct{}
+	out     chan any
+	quit    chan struct{}
+	stopped bool
+}
+
+// NewQueue returns a started queue.
+func NewQueue() *Queue {
+	q := &Queue{
+		signal: make(chan struct{}, 1),
+		out:    make(chan any),
+		quit:   make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Push adds an item to the end of the queue. Items pushed after Stop are
+// dropped.
+func (q *Queue) Push(item any) {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+	q.items = append(q.items, item)
+	q.mu.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// C returns the channel the queued items are received from. It is closed
+// after Stop.
+func (q *Queue) C() <-chan any {
+	return q.out
+}
+
+// Stop drops the queued items and closes the channel returned by C.
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.stopped {
+		return
+	}
+	q.stopped = true
+	q.items = nil
+	close(q.quit)
+}
+
+func (q *Queue) run() {
+	defer close(q.out)
+	for {
+		q.mu.Lock()
+		var next any
+		ok := len(q.items) > 0
+		if ok {
+			next = q.items[0]
+			q.items[0] = nil
+			q.items = q.items[1:]
+		}
+		q.mu.Unlock()
+
+		if !ok {
+			select {
+			case <-q.signal:
+				continue
+			case <-q.quit:
+				return
+			}
+		}
+
+		select {
+		case q.out <- next:
+		case <-q.quit:
+			return
+		}
+	}
+}
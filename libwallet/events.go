@@ -0,0 +1,148 @@
+package libwallet
+
+import (
+	"context"
+
+	"decred.org/dcrdex/client/core"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// eventDispatcherID identifies the tx and block listener of the event
+// dispatcher.
+const eventDispatcherID = "event_dispatcher"
+
+// eventSubscriber receives the wallet and DEX events of the event dispatcher.
+// Unset callbacks are skipped.
+type eventSubscriber struct {
+	onTransaction          func(walletID int, tx *sharedW.Transaction)
+	onTransactionConfirmed func(walletID int, hash string, blockHeight int32)
+	onBlockAttached        func(walletID int, blockHeight int32)
+	// onDEXMatch receives the new matches of the DEX orders, cancel matches
+	// excluded.
+	onDEXMatch func(note *core.MatchNote)
+	// stop runs when the assets manager shuts down.
+	stop func()
+}
+
+// startEventDispatcher subscribes the notification center, the hooks, the
+// order watcher and the payment requests to the wallet and DEX events. The
+// wallets are listened to as they are added, see attachEventListener, and the
+// DEX client when it is initialized, see listenForDEXEvents.
+func (mgr *AssetsManager) startEventDispatcher() {
+	mgr.eventsOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		mgr.cancelFuncs = append(mgr.cancelFuncs, cancel)
+
+		subscribers := []*eventSubscriber{
+			mgr.subscribeNotificationCenter(),
+			mgr.subscribeHooks(ctx),
+			mgr.subscribeOrderWatcher(),
+			mgr.subscribePaymentRequests(),
+		}
+
+		mgr.eventsMtx.Lock()
+		mgr.eventsCtx = ctx
+		mgr.eventSubscribers = subscribers
+		mgr.eventsMtx.Unlock()
+
+		for _, wallet := range mgr.AllWallets() {
+			mgr.attachEventListener(wallet)
+		}
+		mgr.listenForDEXEvents()
+
+		go func() {
+			<-ctx.Done()
+			for _, wallet := range mgr.AllWallets() {
+				wallet.RemoveTxAndBlockNotificationListener(eventDispatcherID)
+			}
+			for _, subscriber := range subscribers {
+				if subscriber.stop != nil {
+					subscriber.stop()
+				}
+			}
+		}()
+	})
+}
+
+// attachEventListener dispatches the tx and block notifications of a newly
+// added wallet to the subscribers. Wallets added before the dispatcher starts
+// are attached when it starts.
+func (mgr *AssetsManager) attachEventListener(wallet sharedW.Asset) {
+	mgr.eventsMtx.Lock()
+	defer mgr.eventsMtx.Unlock()
+
+	if mgr.eventsCtx == nil || wallet.IsNotificationListenerExist(eventDispatcherID) {
+		return
+	}
+
+	subscribers := mgr.eventSubscribers
+	txAndBlockNotificationListener := &sharedW.TxAndBlockNotificationListener{
+		OnTransaction: func(walletID int, tx *sharedW.Transaction) {
+			for _, subscriber := range subscribers {
+				if subscriber.onTransaction != nil {
+					subscriber.onTransaction(walletID, tx)
+				}
+			}
+		},
+		OnTransactionConfirmed: func(walletID int, hash string, blockHeight int32) {
+			for _, subscriber := range subscribers {
+				if subscriber.onTransactionConfirmed != nil {
+					subscriber.onTransactionConfirmed(walletID, hash, blockHeight)
+				}
+			}
+		},
+		OnBlockAttached: func(walletID int, blockHeight int32) {
+			for _, subscriber := range subscribers {
+				if subscriber.onBlockAttached != nil {
+					subscriber.onBlockAttached(walletID, blockHeight)
+				}
+			}
+		},
+	}
+	err := wallet.AddTxAndBlockNotificationListener(txAndBlockNotificationListener, eventDispatcherID)
+	if err != nil && err.Error() != utils.ErrListenerAlreadyExist {
+		log.Errorf("Event dispatcher: can't listen tx notifications for %s wallet: %v", wallet.GetWalletName(), err)
+	}
+}
+
+// listenForDEXEvents dispatches the new DEX matches to the subscribers once
+// the DEX client is initialized. Listening stops when the DEX client shuts
+// down and resumes when it is initialized again.
+func (mgr *AssetsManager) listenForDEXEvents() {
+	mgr.eventsMtx.RLock()
+	ctx, subscribers := mgr.eventsCtx, mgr.eventSubscribers
+	mgr.eventsMtx.RUnlock()
+
+	if ctx == nil || !mgr.DEXCInitialized() || !mgr.dexEventsListening.CompareAndSwap(false, true) {
+		return
+	}
+
+	noteFeed := mgr.DexClient().NotificationFeed()
+	go func() {
+		defer mgr.dexEventsListening.Store(false)
+		defer noteFeed.ReturnFeed()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-noteFeed.C:
+				if n == nil {
+					return // DEX client shut down
+				}
+
+				note, ok := n.(*core.MatchNote)
+				if !ok || note.Topic() != core.TopicNewMatch || note.Match == nil || note.Match.IsCancel {
+					continue
+				}
+				for _, subscriber := range subscribers {
+					if subscriber.onDEXMatch != nil {
+						subscriber.onDEXMatch(note)
+					}
+				}
+			}
+		}
+	}()
+}
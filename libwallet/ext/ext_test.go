@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
 	chainjson "github.com/decred/dcrd/rpc/jsonrpc/types/v4"
 	apiTypes "github.com/decred/dcrdata/v8/api/types"
 	"github.com/decred/dcrdata/v8/db/dbtypes"
@@ -608,3 +609,302 @@ func TestGetAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONPathFloat(t *testing.T) {
+	var doc interface{} = map[string]interface{}{
+		"price": 12.5,
+		"data": map[string]interface{}{
+			"last":  "13.25",
+			"label": "dcr",
+		},
+		"result": []interface{}{
+			map[string]interface{}{"last": 14.0},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		expected    float64
+		expectedErr bool
+	}{
+		{name: "top level number", path: "price", expected: 12.5},
+		{name: "nested string number", path: "data.last", expected: 13.25},
+		{name: "array index", path: "result.0.last", expected: 14},
+		{name: "missing key", path: "data.volume", expectedErr: true},
+		{name: "index out of range", path: "result.1.last", expectedErr: true},
+		{name: "invalid index", path: "result.first.last", expectedErr: true},
+		{name: "path through a number", path: "price.value", expectedErr: true},
+		{name: "not a number", path: "data.label", expectedErr: true},
+		{name: "object value", path: "data", expectedErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := jsonPathFloat(doc, tc.path)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("(%v), expected error (%v), got (%v)", tc.name, tc.expectedErr, err)
+			}
+			if resp != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, resp)
+			}
+		})
+	}
+}
+
+func TestMedianOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		nums     []float64
+		expected float64
+	}{
+		{name: "empty", nums: nil, expected: 0},
+		{name: "single", nums: []float64{3}, expected: 3},
+		{name: "odd unsorted", nums: []float64{9, 1, 5}, expected: 5},
+		{name: "even unsorted", nums: []float64{4, 1, 3, 2}, expected: 2.5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if resp := medianOf(tc.nums); resp != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, resp)
+			}
+		})
+	}
+}
+
+// useProviders replaces the registered rate sources for the duration of a
+// test.
+func useProviders(t *testing.T, tickerProviders ...TickerProvider) {
+	providersMtx.Lock()
+	savedProviders, savedNames := providers, providerNames
+	providers, providerNames = make(map[string]*registeredProvider), nil
+	providersMtx.Unlock()
+
+	t.Cleanup(func() {
+		providersMtx.Lock()
+		providers, providerNames = savedProviders, savedNames
+		providersMtx.Unlock()
+	})
+
+	for _, provider := range tickerProviders {
+		if err := RegisterProvider(provider); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAggregateTicker(t *testing.T) {
+	source := func(name string, price, volume float64, change *float64) TickerProvider {
+		return newFuncProvider(name, func(market values.Market) (*Ticker, error) {
+			return &Ticker{Market: market.String(), LastTradePrice: price, Volume: volume, PriceChangePercent: change}, nil
+		})
+	}
+	failing := newFuncProvider("failing", func(values.Market) (*Ticker, error) {
+		return nil, errors.New("unavailable")
+	})
+	change := func(c float64) *float64 { return &c }
+
+	tests := []struct {
+		name           string
+		sources        []TickerProvider
+		expectedPrice  float64
+		expectedVolume float64
+		expectedChange *float64
+		expectedErr    bool
+	}{
+		{
+			name:          "single source",
+			sources:       []TickerProvider{source("a", 20, 100, nil)},
+			expectedPrice: 20, expectedVolume: 100,
+		},
+		{
+			name: "median of agreeing sources",
+			sources: []TickerProvider{
+				source("a", 20, 100, change(1)),
+				source("b", 20.4, 0, change(3)),
+				source("c", 20.2, 300, nil),
+			},
+			expectedPrice: 20.2, expectedVolume: 200, expectedChange: change(2),
+		},
+		{
+			name: "outlier dropped",
+			sources: []TickerProvider{
+				source("a", 20, 0, nil),
+				source("b", 20.2, 0, nil),
+				source("c", 20.4, 0, nil),
+				source("outlier", 40, 0, nil),
+			},
+			expectedPrice: 20.2,
+		},
+		{
+			name:          "failing source skipped",
+			sources:       []TickerProvider{failing, source("a", 20, 0, nil)},
+			expectedPrice: 20,
+		},
+		{
+			name:        "all sources failing",
+			sources:     []TickerProvider{failing},
+			expectedErr: true,
+		},
+		{
+			name: "two sources far apart",
+			sources: []TickerProvider{
+				source("a", 20, 0, nil),
+				source("b", 24, 0, nil),
+			},
+			expectedErr: true,
+		},
+		{
+			name: "zero price skipped",
+			sources: []TickerProvider{
+				source("a", 20, 0, nil),
+				source("zero", 0, 0, nil),
+			},
+			expectedPrice: 20,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useProviders(t, tc.sources...)
+			resp, err := aggregateTicker(values.DCRUSDTMarket)
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("(%v), expected error (%v), got (%v)", tc.name, tc.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if resp.LastTradePrice != tc.expectedPrice || resp.Volume != tc.expectedVolume {
+				t.Errorf("(%v), expected price %v and volume %v, got %v and %v", tc.name,
+					tc.expectedPrice, tc.expectedVolume, resp.LastTradePrice, resp.Volume)
+			}
+			if !reflect.DeepEqual(resp.PriceChangePercent, tc.expectedChange) {
+				t.Errorf("(%v), expected change (%v), got (%v)", tc.name, tc.expectedChange, resp.PriceChangePercent)
+			}
+		})
+	}
+}
+
+func TestHTTPSourceConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         HTTPSourceConfig
+		expectedErr bool
+	}{
+		{
+			name: "valid",
+			cfg:  HTTPSourceConfig{Name: "custom", URLTemplate: "https://example.com/{BASE}-{QUOTE}", PricePath: "price"},
+		},
+		{
+			name: "lower case placeholders",
+			cfg:  HTTPSourceConfig{Name: "custom", URLTemplate: "https://example.com/{base}/{quote}", PricePath: "price"},
+		},
+		{
+			name:        "missing name",
+			cfg:         HTTPSourceConfig{Name: " ", URLTemplate: "https://example.com/{BASE}", PricePath: "price"},
+			expectedErr: true,
+		},
+		{
+			name:        "missing price path",
+			cfg:         HTTPSourceConfig{Name: "custom", URLTemplate: "https://example.com/{BASE}"},
+			expectedErr: true,
+		},
+		{
+			name:        "missing base placeholder",
+			cfg:         HTTPSourceConfig{Name: "custom", URLTemplate: "https://example.com/{QUOTE}", PricePath: "price"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid URL",
+			cfg:         HTTPSourceConfig{Name: "custom", URLTemplate: "example/{BASE}", PricePath: "price"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.cfg.Validate(); (err != nil) != tc.expectedErr {
+				t.Errorf("(%v), expected error (%v), got (%v)", tc.name, tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		volumePath     string
+		expectedPrice  float64
+		expectedVolume float64
+		expectedErr    bool
+	}{
+		{
+			name:          "price",
+			body:          `{"data":{"price":"13.5"}}`,
+			expectedPrice: 13.5,
+		},
+		{
+			name:          "price and volume",
+			body:          `{"data":{"price":13.5,"stats":[{"volume":250}]}}`,
+			volumePath:    "data.stats.0.volume",
+			expectedPrice: 13.5, expectedVolume: 250,
+		},
+		{
+			name:        "missing price",
+			body:        `{"data":{}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "zero price",
+			body:        `{"data":{"price":"0"}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "negative price",
+			body:        `{"data":{"price":-13.5}}`,
+			expectedErr: true,
+		},
+		{
+			name:        "missing volume",
+			body:        `{"data":{"price":13.5}}`,
+			volumePath:  "data.volume",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requestPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestPath = r.URL.Path
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			source, err := NewHTTPSource(HTTPSourceConfig{
+				Name:        "custom",
+				URLTemplate: server.URL + "/ticker/{BASE}/{quote}",
+				PricePath:   "data.price",
+				VolumePath:  tc.volumePath,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := source.GetTicker(values.DCRUSDTMarket)
+			if requestPath != "/ticker/DCR/usdt" {
+				t.Errorf("(%v), expected request path /ticker/DCR/usdt, got %s", tc.name, requestPath)
+			}
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("(%v), expected error (%v), got (%v)", tc.name, tc.expectedErr, err)
+			}
+			if err == nil && (resp.LastTradePrice != tc.expectedPrice || resp.Volume != tc.expectedVolume) {
+				t.Errorf("(%v), expected price %v and volume %v, got %v and %v", tc.name,
+					tc.expectedPrice, tc.expectedVolume, resp.LastTradePrice, resp.Volume)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ext
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// USD is the currency of the USDT markets rates are fetched for. Rates in
+	// other fiat currencies are derived from it using the FX rates.
+	USD = "USD"
+
+	// fiatRatesURL returns the USD based exchange rates of all fiat
+	// currencies in a single call. The rates are updated once a day, see
+	// https://www.exchangerate-api.com/docs/free.
+	fiatRatesURL = "https://open.er-api.com/v6/latest/USD"
+
+	// fiatRateExpiry is how long the fetched FX rates are used before they
+	// are refreshed.
+	fiatRateExpiry = 6 * time.Hour
+)
+
+// fetchFiatRates returns the amount of each fiat currency that a USD buys,
+// keyed by the ISO 4217 currency code.
+func fetchFiatRates() (map[string]float64, error) {
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: fiatRatesURL,
+		Method:  "GET",
+	}
+
+	var res struct {
+		Result    string             `json:"result"`
+		ErrorType string             `json:"error-type"`
+		Rates     map[string]float64 `json:"rates"`
+	}
+	if _, err := utils.HTTPRequest(reqCfg, &res); err != nil {
+		return nil, fmt.Errorf("failed to fetch fiat rates: %w", err)
+	}
+	if res.Result != "success" {
+		return nil, fmt.Errorf("failed to fetch fiat rates: %s", res.ErrorType)
+	}
+
+	rates := make(map[string]float64, len(res.Rates))
+	for code, rate := range res.Rates {
+		if rate > 0 {
+			rates[strings.ToUpper(code)] = rate
+		}
+	}
+	rates[USD] = 1
+	return rates, nil
+}
+
+// FiatRate returns the amount of the fiat currency with the ISO 4217 code
+// that a USD buys. False is returned if the rate has not been fetched.
+func (cs *CommonRateSource) FiatRate(code string) (float64, bool) {
+	code = strings.ToUpper(code)
+	if code == USD {
+		return 1, true
+	}
+
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	rate, ok := cs.fiatRates[code]
+	return rate, ok
+}
+
+// refreshFiatRates fetches the FX rates if they are missing or expired.
+func (cs *CommonRateSource) refreshFiatRates(force bool) {
+	cs.mtx.RLock()
+	expired := time.Since(cs.fiatRatesUpdate) > fiatRateExpiry
+	cs.mtx.RUnlock()
+	if !force && !expired {
+		return
+	}
+
+	rates, err := fetchFiatRates()
+	if err != nil {
+		cs.fail("Error fetching fiat rates", err)
+		return
+	}
+
+	cs.mtx.Lock()
+	cs.fiatRates = rates
+	cs.fiatRatesUpdate = time.Now()
+	cs.mtx.Unlock()
+}
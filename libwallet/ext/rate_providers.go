@@ -0,0 +1,447 @@
+package ext
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	// aggregateOutlierThreshold is the maximum percentage by which a rate may
+	// deviate from the median of all the rates fetched before it is dropped
+	// from the aggregate rate.
+	aggregateOutlierThreshold = 5
+
+	// coinpaprikaCacheExpiry is how long the tickers fetched in a single
+	// coinpaprika call are reused for the other markets.
+	coinpaprikaCacheExpiry = time.Minute
+)
+
+// TickerProvider is implemented by every rate source. Providers are
+// registered with RegisterProvider and selected by name.
+type TickerProvider interface {
+	// Name uniquely identifies the provider.
+	Name() string
+	// GetTicker fetches the current ticker of a market from the provider.
+	GetTicker(market values.Market) (*Ticker, error)
+}
+
+// SourceHealth describes the most recent requests made to a rate source.
+type SourceHealth struct {
+	Name        string
+	Custom      bool
+	LastSuccess time.Time
+	LastError   string
+	LastErrorAt time.Time
+	// Failures is the number of consecutive failed requests.
+	Failures int
+}
+
+// Healthy returns true if the last request made to the source succeeded.
+func (h *SourceHealth) Healthy() bool {
+	return h.Failures == 0 && !h.LastSuccess.IsZero()
+}
+
+type registeredProvider struct {
+	TickerProvider
+	custom bool
+	health SourceHealth
+}
+
+var (
+	providersMtx sync.RWMutex
+	// providers holds the registered rate sources keyed by name.
+	providers = make(map[string]*registeredProvider)
+	// providerNames holds the names of the registered rate sources in the
+	// order they are tried when the selected source fails.
+	providerNames []string
+)
+
+func init() {
+	builtins := []TickerProvider{
+		newFuncProvider(binance, binanceTickerFunc(binanceURLs.price)),
+		newFuncProvider(binanceUS, binanceTickerFunc(binanceUSURLs.price)),
+		newFuncProvider(messari, messariGetTicker),
+		newFuncProvider(kucoinExchange, kucoinGetTicker),
+		&coinpaprikaProvider{},
+	}
+	for _, provider := range builtins {
+		if err := registerProvider(provider, false); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterProvider adds a rate source that can be selected by its name. An
+// error is returned if a source with the same name is already registered.
+func RegisterProvider(provider TickerProvider) error {
+	return registerProvider(provider, true)
+}
+
+func registerProvider(provider TickerProvider, custom bool) error {
+	name := provider.Name()
+	if name == "" || name == none || name == aggregate {
+		return fmt.Errorf("invalid rate source name %q", name)
+	}
+
+	providersMtx.Lock()
+	defer providersMtx.Unlock()
+	if _, ok := providers[name]; ok {
+		return fmt.Errorf("rate source %s is already registered", name)
+	}
+
+	providers[name] = &registeredProvider{
+		TickerProvider: provider,
+		custom:         custom,
+		health:         SourceHealth{Name: name, Custom: custom},
+	}
+	providerNames = append(providerNames, name)
+	return nil
+}
+
+// UnregisterProvider removes a custom rate source. Built-in rate sources
+// cannot be removed.
+func UnregisterProvider(name string) {
+	providersMtx.Lock()
+	defer providersMtx.Unlock()
+
+	if provider, ok := providers[name]; !ok || !provider.custom {
+		return
+	}
+
+	delete(providers, name)
+	for i, providerName := range providerNames {
+		if providerName == name {
+			providerNames = append(providerNames[:i], providerNames[i+1:]...)
+			break
+		}
+	}
+}
+
+// SourceNames returns the names of the registered rate sources, built-in
+// sources first.
+func SourceNames() []string {
+	providersMtx.RLock()
+	defer providersMtx.RUnlock()
+	return append([]string(nil), providerNames...)
+}
+
+// SourcesHealth returns the health of the registered rate sources.
+func SourcesHealth() []SourceHealth {
+	providersMtx.RLock()
+	defer providersMtx.RUnlock()
+
+	health := make([]SourceHealth, 0, len(providerNames))
+	for _, name := range providerNames {
+		health = append(health, providers[name].health)
+	}
+	return health
+}
+
+// fetchProviderTicker fetches a ticker from the named rate source and records
+// the outcome in the source health.
+func fetchProviderTicker(name string, market values.Market) (*Ticker, error) {
+	providersMtx.RLock()
+	provider, ok := providers[name]
+	providersMtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("rate source %s is not supported", name)
+	}
+
+	ticker, err := provider.GetTicker(market)
+	if err == nil && (ticker == nil || ticker.LastTradePrice <= 0) {
+		err = fmt.Errorf("%s returned no price for %s", name, market)
+	}
+
+	providersMtx.Lock()
+	if err != nil {
+		provider.health.LastError = err.Error()
+		provider.health.LastErrorAt = time.Now()
+		provider.health.Failures++
+	} else {
+		provider.health.LastSuccess = time.Now()
+		provider.health.Failures = 0
+	}
+	providersMtx.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	return ticker, nil
+}
+
+// aggregateTicker fetches the ticker of a market from every registered rate
+// source and returns their median rate. Rates deviating from the median by
+// more than aggregateOutlierThreshold percent are dropped before the final
+// median is computed. An error is returned if no rate is left.
+func aggregateTicker(market values.Market) (*Ticker, error) {
+	names := SourceNames()
+
+	type result struct {
+		ticker *Ticker
+		err    error
+	}
+	results := make([]result, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i].ticker, results[i].err = fetchProviderTicker(name, market)
+		}(i, name)
+	}
+	wg.Wait()
+
+	var tickers []*Ticker
+	var errs []error
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		tickers = append(tickers, res.ticker)
+	}
+	if len(tickers) == 0 {
+		return nil, fmt.Errorf("no rate source returned a rate for %s: %w", market, errors.Join(errs...))
+	}
+
+	prices := make([]float64, len(tickers))
+	for i, ticker := range tickers {
+		prices[i] = ticker.LastTradePrice
+	}
+	median := medianOf(prices)
+
+	var kept []*Ticker
+	for _, ticker := range tickers {
+		if math.Abs(ticker.LastTradePrice-median)/median*100 <= aggregateOutlierThreshold {
+			kept = append(kept, ticker)
+		}
+	}
+	if len(kept) == 0 {
+		// With an even number of sources, all the rates can be outliers.
+		return nil, fmt.Errorf("the rate sources disagree on the rate for %s", market)
+	}
+
+	prices, changes, volumes := prices[:0], []float64{}, []float64{}
+	for _, ticker := range kept {
+		prices = append(prices, ticker.LastTradePrice)
+		if ticker.PriceChangePercent != nil {
+			changes = append(changes, *ticker.PriceChangePercent)
+		}
+		if ticker.Volume > 0 {
+			volumes = append(volumes, ticker.Volume)
+		}
+	}
+
+	ticker := &Ticker{
+		Market:         market.String(),
+		LastTradePrice: medianOf(prices),
+		Volume:         medianOf(volumes),
+		lastUpdate:     time.Now(),
+	}
+	if len(changes) > 0 {
+		change := medianOf(changes)
+		ticker.PriceChangePercent = &change
+	}
+	return ticker, nil
+}
+
+// medianOf returns the median of nums, or zero if nums is empty. nums is
+// sorted in place.
+func medianOf(nums []float64) float64 {
+	if len(nums) == 0 {
+		return 0
+	}
+	sort.Float64s(nums)
+	mid := len(nums) / 2
+	if len(nums)%2 == 0 {
+		return (nums[mid-1] + nums[mid]) / 2
+	}
+	return nums[mid]
+}
+
+// funcProvider is a TickerProvider backed by a ticker function.
+type funcProvider struct {
+	name      string
+	getTicker tickerFunc
+}
+
+func newFuncProvider(name string, getTicker tickerFunc) *funcProvider {
+	return &funcProvider{name: name, getTicker: getTicker}
+}
+
+func (p *funcProvider) Name() string {
+	return p.name
+}
+
+func (p *funcProvider) GetTicker(market values.Market) (*Ticker, error) {
+	return p.getTicker(market)
+}
+
+// coinpaprikaProvider fetches the tickers of all the supported markets in a
+// single call and reuses them for coinpaprikaCacheExpiry.
+type coinpaprikaProvider struct {
+	mtx       sync.Mutex
+	tickers   map[values.Market]*Ticker
+	fetchedAt time.Time
+}
+
+func (p *coinpaprikaProvider) Name() string {
+	return coinpaprika
+}
+
+func (p *coinpaprikaProvider) GetTicker(market values.Market) (*Ticker, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if time.Since(p.fetchedAt) > coinpaprikaCacheExpiry {
+		tickers, err := coinpaprikaGetTickers()
+		if err != nil {
+			return nil, err
+		}
+		p.tickers, p.fetchedAt = tickers, time.Now()
+	}
+
+	ticker, ok := p.tickers[market]
+	if !ok {
+		return nil, fmt.Errorf("%s does not support %s", coinpaprika, market)
+	}
+	tickerCopy := *ticker
+	return &tickerCopy, nil
+}
+
+// HTTPSourceConfig defines a user rate source that returns the price of a
+// market as JSON over HTTP.
+type HTTPSourceConfig struct {
+	Name string `json:"name"`
+	// URLTemplate is the URL requested for a market. The {BASE} and {QUOTE}
+	// placeholders are replaced with the upper case symbols of the market
+	// (e.g. DCR and USDT), {base} and {quote} with the lower case symbols.
+	URLTemplate string `json:"urlTemplate"`
+	// PricePath is the dot separated path of the price in the JSON response,
+	// e.g. "data.price" or "result.0.last". Array elements are selected by
+	// their index.
+	PricePath string `json:"pricePath"`
+	// VolumePath is the optional path of the 24h volume in the JSON response.
+	VolumePath string `json:"volumePath,omitempty"`
+}
+
+// Validate checks that the source can be used to fetch rates.
+func (cfg *HTTPSourceConfig) Validate() error {
+	switch {
+	case strings.TrimSpace(cfg.Name) == "":
+		return errors.New("rate source name is required")
+	case strings.TrimSpace(cfg.PricePath) == "":
+		return errors.New("rate source price path is required")
+	case !strings.Contains(strings.ToLower(cfg.URLTemplate), "{base}"):
+		return errors.New("rate source URL must contain the {BASE} or {base} placeholder")
+	}
+
+	if _, err := url.ParseRequestURI(cfg.marketURL(values.DCRUSDTMarket)); err != nil {
+		return fmt.Errorf("invalid rate source URL: %w", err)
+	}
+	return nil
+}
+
+func (cfg *HTTPSourceConfig) marketURL(market values.Market) string {
+	base, quote, _ := strings.Cut(market.String(), MktSep)
+	return strings.NewReplacer(
+		"{BASE}", strings.ToUpper(base),
+		"{QUOTE}", strings.ToUpper(quote),
+		"{base}", strings.ToLower(base),
+		"{quote}", strings.ToLower(quote),
+	).Replace(cfg.URLTemplate)
+}
+
+// httpSource is a TickerProvider for a HTTPSourceConfig.
+type httpSource struct {
+	cfg HTTPSourceConfig
+}
+
+// NewHTTPSource creates a rate source from a user definition.
+func NewHTTPSource(cfg HTTPSourceConfig) (TickerProvider, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &httpSource{cfg: cfg}, nil
+}
+
+func (s *httpSource) Name() string {
+	return s.cfg.Name
+}
+
+func (s *httpSource) GetTicker(market values.Market) (*Ticker, error) {
+	reqCfg := &utils.ReqConfig{
+		HTTPURL: s.cfg.marketURL(market),
+		Method:  "GET",
+	}
+
+	var res interface{}
+	if _, err := utils.HTTPRequest(reqCfg, &res); err != nil {
+		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", s.cfg.Name, market, err)
+	}
+
+	price, err := jsonPathFloat(res, s.cfg.PricePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: price: %w", s.cfg.Name, err)
+	}
+	if price <= 0 {
+		return nil, fmt.Errorf("%s: invalid price %v for %s", s.cfg.Name, price, market)
+	}
+
+	ticker := &Ticker{
+		Market:         market.String(),
+		LastTradePrice: price,
+		lastUpdate:     time.Now(),
+	}
+
+	if s.cfg.VolumePath != "" {
+		volume, err := jsonPathFloat(res, s.cfg.VolumePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: volume: %w", s.cfg.Name, err)
+		}
+		ticker.Volume = volume
+	}
+
+	return ticker, nil
+}
+
+// jsonPathFloat returns the number found at path in a decoded JSON value.
+// Numbers encoded as strings are accepted.
+func jsonPathFloat(value interface{}, path string) (float64, error) {
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[key]; !ok {
+				return 0, fmt.Errorf("%q not found in path %s", key, path)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return 0, fmt.Errorf("invalid index %q in path %s", key, path)
+			}
+			value = v[i]
+		default:
+			return 0, fmt.Errorf("cannot select %q in path %s", key, path)
+		}
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	}
+	return 0, fmt.Errorf("value at path %s is not a number", path)
+}
@@ -24,6 +24,7 @@ const (
 	coinpaprika    = values.Coinpaprika
 	messari        = values.Messari
 	kucoinExchange = values.KucoinExchange
+	aggregate      = values.AggregateRateSource
 	none           = values.DefaultExchangeValue
 
 	// MktSep is used repo wide to separate market symbols.
@@ -111,9 +112,9 @@ var (
 	RateRefreshDuration = 60 * time.Minute
 )
 
-// RateSource is the interface that binds different rate sources. Most of the
-// methods are implemented by CommonRateSource, but Refresh is implemented in
-// the individual rate source.
+// RateSource is the interface that binds different rate sources. It is
+// implemented by CommonRateSource, which fetches tickers from the registered
+// TickerProvider selected by name or from all of them in aggregate mode.
 type RateSource interface {
 	Name() string
 	Ready() bool
@@ -129,6 +130,8 @@ type RateSource interface {
 	RemoveWarningMsgListener(uniqueIdentifier string)
 	IsRateListenerExist(uniqueIdentifier string) bool
 	IsWarningMsgListenerExist(uniqueIdentifier string) bool
+	SourcesHealth() []SourceHealth
+	FiatRate(code string) (float64, bool)
 }
 
 // RateListener listens for new tickers and rate source change notifications.
@@ -153,6 +156,8 @@ type CommonRateSource struct {
 	disabled                  bool
 	mtx                       sync.RWMutex
 	tickers                   map[values.Market]*Ticker
+	fiatRates                 map[string]float64
+	fiatRatesUpdate           time.Time
 	refreshing                bool
 	cond                      *sync.Cond
 	getTicker                 tickerFunc
@@ -180,7 +185,7 @@ func NewCommonRateSource(ctx context.Context, source string, disableConversionEx
 		ratesListeners:            make(map[string]*RateListener),
 		warningMsgListeners:       make(map[string]*WarningMsgListener),
 	}
-	s.getTicker = s.sourceGetTickerFunc(source)
+	s.getTicker = sourceGetTickerFunc(source)
 	s.cond = sync.NewCond(&s.mtx)
 
 	return s, nil
@@ -300,7 +305,7 @@ func (cs *CommonRateSource) ToggleSource(newSource string) error {
 		refresh = false /* none is the dummy rate source for when user disables rates */
 	}
 
-	getTickerFn := cs.sourceGetTickerFunc(newSource)
+	getTickerFn := sourceGetTickerFunc(newSource)
 	if getTickerFn == nil {
 		return fmt.Errorf("new rate source %s is not supported", newSource)
 	}
@@ -319,6 +324,11 @@ func (cs *CommonRateSource) ToggleSource(newSource string) error {
 	return nil
 }
 
+// SourcesHealth returns the health of the registered rate sources.
+func (cs *CommonRateSource) SourcesHealth() []SourceHealth {
+	return SourcesHealth()
+}
+
 // Log the error along with the token and an additional passed identifier.
 func (cs *CommonRateSource) fail(msg string, err error) {
 	log.Errorf("%s: %s: %v", cs.source, msg, err)
@@ -386,6 +396,8 @@ func (cs *CommonRateSource) Refresh(force bool) {
 	cs.mtx.Lock()
 	cs.tickers = tickers
 	cs.mtx.Unlock()
+
+	cs.refreshFiatRates(force)
 }
 
 // GetTicker retrieves ticker information for the provided market. Data will be
@@ -450,14 +462,22 @@ func (cs *CommonRateSource) retryGetTicker(market values.Market) (*Ticker, error
 			return newTicker, nil
 		}
 	}
+	// The aggregate source already queried all the other sources.
+	if cs.source == aggregate {
+		if cs.disableConversionExchange != nil {
+			cs.disableConversionExchange()
+		}
+		return nil, err
+	}
+
 	// fetch ticker from available exchanges
 	log.Infof("fetching from other exchanges")
 	invalidSource := cs.source
-	for _, source := range sources {
+	for _, source := range SourceNames() {
 		if source == invalidSource {
 			continue
 		}
-		getTickerFn := cs.sourceGetTickerFunc(source)
+		getTickerFn := sourceGetTickerFunc(source)
 		select {
 		case <-cs.ctx.Done():
 			log.Errorf("fetching ticker canceled: %v", cs.ctx.Err())
@@ -480,34 +500,37 @@ func (cs *CommonRateSource) retryGetTicker(market values.Market) (*Ticker, error
 	return nil, err
 }
 
-func (cs *CommonRateSource) binanceGetTicker(market values.Market) (*Ticker, error) {
-	reqCfg := &utils.ReqConfig{
-		HTTPURL: fmt.Sprintf(binanceURLs.price, market.MarketWithoutSep()),
-		Method:  "GET",
-	}
-	if cs.source == binanceUS {
-		reqCfg.HTTPURL = fmt.Sprintf(binanceUSURLs.price, market.MarketWithoutSep())
-	}
+// binanceTickerFunc returns a ticker function for the binance API at the
+// priceURL format.
+func binanceTickerFunc(priceURL string) tickerFunc {
+	return func(market values.Market) (*Ticker, error) {
+		reqCfg := &utils.ReqConfig{
+			HTTPURL: fmt.Sprintf(priceURL, market.MarketWithoutSep()),
+			Method:  "GET",
+		}
 
-	resp := new(BinanceTickerResponse)
-	_, err := utils.HTTPRequest(reqCfg, &resp)
-	if err != nil {
-		return nil, fmt.Errorf("%s failed to fetch ticker for %s: %w", cs.source, market, err)
-	}
+		resp := new(BinanceTickerResponse)
+		_, err := utils.HTTPRequest(reqCfg, &resp)
+		if err != nil {
+			return nil, fmt.Errorf("binance failed to fetch ticker for %s: %w", market, err)
+		}
 
-	percentChange := resp.PriceChangePercent
-	ticker := &Ticker{
-		Market:             market.String(),
-		LastTradePrice:     resp.LastPrice,
-		PriceChangePercent: &percentChange,
-		lastUpdate:         time.Now(),
-	}
+		percentChange := resp.PriceChangePercent
+		ticker := &Ticker{
+			Market:             market.String(),
+			LastTradePrice:     resp.LastPrice,
+			PriceChangePercent: &percentChange,
+			Volume:             resp.Volume,
+			lastUpdate:         time.Now(),
+		}
 
-	return ticker, nil
+		return ticker, nil
+	}
 }
 
-// coinpaprikaGetTicker don't need market param, but need it to satisfy the format of the getrate function
-func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker, error) {
+// coinpaprikaGetTickers fetches the tickers of all the supported USDT markets
+// in a single call.
+func coinpaprikaGetTickers() (map[values.Market]*Ticker, error) {
 	reqCfg := &utils.ReqConfig{
 		HTTPURL: coinpaprikaURLs.price,
 		Method:  "GET",
@@ -518,6 +541,7 @@ func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker,
 		Quotes struct {
 			USD struct {
 				Price         float64 `json:"price"`
+				Volume        float64 `json:"volume_24h"`
 				PercentChange float64 `json:"percent_change_24h"`
 			} `json:"USD"`
 		} `json:"quotes"`
@@ -527,7 +551,7 @@ func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker,
 		return nil, fmt.Errorf("%s failed to fetch ticker: %w", coinpaprika, err)
 	}
 
-	cs.mtx.Lock()
+	tickers := make(map[values.Market]*Ticker)
 	for _, coinInfo := range res {
 		market := values.NewMarket(coinInfo.Symbol, "USDT")
 		_, found := supportedUSDTMarkets[market]
@@ -540,17 +564,17 @@ func (cs *CommonRateSource) coinpaprikaGetTicker(market values.Market) (*Ticker,
 			log.Errorf("zero-price returned from coinpaprika for asset with ticker %s", coinInfo.Symbol)
 			continue
 		}
-		ticker := &Ticker{
+		percentChange := coinInfo.Quotes.USD.PercentChange
+		tickers[market] = &Ticker{
 			Market:             market.String(), // Ok: e.g BTC-USDT
 			LastTradePrice:     price,
+			Volume:             coinInfo.Quotes.USD.Volume,
 			lastUpdate:         time.Now(),
-			PriceChangePercent: &coinInfo.Quotes.USD.PercentChange,
+			PriceChangePercent: &percentChange,
 		}
-		cs.tickers[market] = ticker
 	}
-	cs.mtx.Unlock()
 
-	return cs.tickers[market], nil
+	return tickers, nil
 }
 
 func messariGetTicker(market values.Market) (*Ticker, error) {
@@ -668,38 +692,28 @@ func isSupportedMarket(market values.Market, rateSource string) (values.Market,
 }
 
 func isValidSource(source string) bool {
-	switch source {
-	case binance, binanceUS, coinpaprika, messari, kucoinExchange, none:
-		return true
-	default:
-		return false
-	}
+	return sourceGetTickerFunc(source) != nil
 }
 
-func (cs *CommonRateSource) sourceGetTickerFunc(source string) func(values.Market) (*Ticker, error) {
+// sourceGetTickerFunc returns the ticker function of the named rate source,
+// or nil if the source is not supported.
+func sourceGetTickerFunc(source string) tickerFunc {
 	switch source {
-	case binance, binanceUS:
-		return cs.binanceGetTicker
-	case messari:
-		return messariGetTicker
-	case kucoinExchange:
-		return kucoinGetTicker
-	case coinpaprika:
-		return cs.coinpaprikaGetTicker
 	case none:
 		return dummyGetTickerFunc
-	default:
-		return nil
+	case aggregate:
+		return aggregateTicker
 	}
-}
 
-var sources = []string{
-	binance,
-	binanceUS,
-	messari,
-	kucoinExchange,
-	coinpaprika,
-	none,
+	providersMtx.RLock()
+	_, ok := providers[source]
+	providersMtx.RUnlock()
+	if !ok {
+		return nil
+	}
+	return func(market values.Market) (*Ticker, error) {
+		return fetchProviderTicker(source, market)
+	}
 }
 
 func dummyGetTickerFunc(values.Market) (*Ticker, error) {
@@ -111,6 +111,7 @@ type (
 		Market             string
 		LastTradePrice     float64
 		PriceChangePercent *float64
+		Volume             float64 // 24h volume, zero if not provided by the source
 
 		lastUpdate time.Time
 	}
@@ -134,6 +135,7 @@ type (
 		Symbol             string  `json:"symbol"`
 		LastPrice          float64 `json:"lastPrice,string"`
 		PriceChangePercent float64 `json:"priceChangePercent,string"`
+		Volume             float64 `json:"volume,string"`
 	}
 
 	// KuCoinTicker models Kucoin's specific ticker information.
@@ -0,0 +1,150 @@
+package libwallet
+
+import (
+	"context"
+
+	"decred.org/dcrdex/client/core"
+	"github.com/crypto-power/instantswap/instantswap"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/hooks"
+	swap "github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+)
+
+// hooksID identifies the listeners of the hooks.
+const hooksID = "hooks"
+
+// txHookData is the data of the transaction hook events.
+type txHookData struct {
+	Asset         string               `json:"asset"`
+	Confirmations int32                `json:"confirmations,omitempty"`
+	Transaction   *sharedW.Transaction `json:"transaction"`
+}
+
+// swapHookData is the data of the swap status hook events.
+type swapHookData struct {
+	PreviousStatus string      `json:"previousStatus"`
+	Order          *swap.Order `json:"order"`
+}
+
+// dexMatchHookData is the data of the DEX match hook events.
+type dexMatchHookData struct {
+	MarketID string      `json:"marketID"`
+	OrderID  string      `json:"orderID"`
+	Match    *core.Match `json:"match"`
+}
+
+// subscribeHooks delivers the wallet, instantswap and DEX events to the
+// hooks, resuming the deliveries pending when the app last closed.
+func (mgr *AssetsManager) subscribeHooks(ctx context.Context) *eventSubscriber {
+	orderNotificationListener := &swap.OrderNotificationListener{
+		OnOrderStatusChanged: func(order *swap.Order, previous instantswap.Status) {
+			mgr.Hooks.Fire(ctx, &hooks.Event{
+				Type:     hooks.EventSwapStatus,
+				WalletID: order.SourceWalletID,
+				Subject:  order.UUID,
+				Data: &swapHookData{
+					PreviousStatus: previous.String(),
+					Order:          order,
+				},
+			})
+		},
+	}
+	if err := mgr.InstantSwap.AddNotificationListener(orderNotificationListener, hooksID); err != nil {
+		log.Errorf("Hooks: can't listen instantswap orders: %v", err)
+	}
+
+	mgr.Hooks.ResumeDeliveries(ctx)
+
+	return &eventSubscriber{
+		onTransaction: func(walletID int, tx *sharedW.Transaction) {
+			mgr.fireTxHooks(ctx, walletID, tx)
+		},
+		onTransactionConfirmed: func(walletID int, hash string, blockHeight int32) {
+			mgr.Hooks.TrackTx(walletID, hash, blockHeight)
+			mgr.fireTxConfirmedHooks(ctx, walletID)
+		},
+		onBlockAttached: func(walletID int, _ int32) {
+			mgr.fireTxConfirmedHooks(ctx, walletID)
+		},
+		onDEXMatch: func(note *core.MatchNote) {
+			mgr.Hooks.Fire(ctx, &hooks.Event{
+				Type:    hooks.EventDEXMatch,
+				Subject: note.Match.MatchID.String(),
+				Data: &dexMatchHookData{
+					MarketID: note.MarketID,
+					OrderID:  note.OrderID.String(),
+					Match:    note.Match,
+				},
+			})
+		},
+		stop: func() {
+			mgr.InstantSwap.RemoveNotificationListener(hooksID)
+		},
+	}
+}
+
+func (mgr *AssetsManager) fireTxHooks(ctx context.Context, walletID int, tx *sharedW.Transaction) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return
+	}
+
+	data := &txHookData{
+		Asset:       string(wallet.GetAssetType()),
+		Transaction: tx,
+	}
+	mgr.Hooks.Fire(ctx, &hooks.Event{
+		Type:     hooks.EventTransaction,
+		WalletID: walletID,
+		Subject:  tx.Hash,
+		Data:     data,
+	})
+
+	if tx.Type == txhelper.TxTypeVote {
+		mgr.Hooks.Fire(ctx, &hooks.Event{
+			Type:     hooks.EventTicketVoted,
+			WalletID: walletID,
+			Subject:  tx.Hash,
+			Data:     data,
+		})
+	}
+}
+
+// fireTxConfirmedHooks fires the EventTxConfirmed hooks of the transactions
+// tracked for a wallet and stops tracking the transactions that have enough
+// confirmations for all the hooks.
+func (mgr *AssetsManager) fireTxConfirmedHooks(ctx context.Context, walletID int) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return
+	}
+
+	maxConfs := mgr.Hooks.MaxConfirmations()
+	bestBlock := wallet.GetBestBlockHeight()
+	for hash, minedAt := range mgr.Hooks.TrackedTxs(walletID) {
+		confs := bestBlock - minedAt + 1
+		if maxConfs == 0 || confs >= maxConfs {
+			mgr.Hooks.UntrackTx(walletID, hash)
+		}
+		if maxConfs == 0 || confs < 1 {
+			continue
+		}
+
+		tx, err := wallet.GetTransactionRaw(hash)
+		if err != nil {
+			log.Errorf("Hooks: can't read tx %s: %v", hash, err)
+			continue
+		}
+		mgr.Hooks.FireConfirmed(ctx, &hooks.Event{
+			WalletID: walletID,
+			Subject:  hash,
+			Data: &txHookData{
+				Asset:         string(wallet.GetAssetType()),
+				Confirmations: confs,
+				Transaction:   tx,
+			},
+		}, confs)
+	}
+}
@@ -0,0 +1,497 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// Manager persists the hooks and their delivery log, and delivers the events
+// to the hooks.
+type Manager struct {
+	db *storm.DB
+
+	httpClient *http.Client
+
+	// wg tracks the running deliveries.
+	wg sync.WaitGroup
+}
+
+// NewManager creates a hook manager that uses db for persistence.
+func NewManager(db *storm.DB) (*Manager, error) {
+	if err := db.Init(&Hook{}); err != nil {
+		log.Errorf("Error initializing hooks database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&Delivery{}); err != nil {
+		log.Errorf("Error initializing hook deliveries database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&confirmedTx{}); err != nil {
+		log.Errorf("Error initializing confirmed hook txs database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&trackedTx{}); err != nil {
+		log.Errorf("Error initializing tracked hook txs database: %s", err.Error())
+		return nil, err
+	}
+
+	return &Manager{
+		db:         db,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// validate checks that the hook parameters are usable.
+func (hook *Hook) validate() error {
+	switch {
+	case strings.TrimSpace(hook.Name) == "":
+		return errors.New("missing hook name")
+	case len(hook.Events) == 0:
+		return errors.New("a hook must subscribe to at least one event")
+	case (hook.URL == "") == (strings.TrimSpace(hook.Command) == ""):
+		return errors.New("a hook must have either a URL or a command")
+	case hook.Confirmations < 0:
+		return errors.New("confirmations cannot be negative")
+	}
+
+	for _, event := range hook.Events {
+		if !isEventType(event) {
+			return fmt.Errorf("unknown event %q", event)
+		}
+	}
+
+	if hook.URL != "" {
+		return validateLocalURL(hook.URL)
+	}
+	return nil
+}
+
+func isEventType(event EventType) bool {
+	for _, eventType := range EventTypes {
+		if eventType == event {
+			return true
+		}
+	}
+	return false
+}
+
+// validateLocalURL checks that rawURL is an HTTP URL on the loopback
+// interface.
+func validateLocalURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("the URL scheme must be http or https")
+	}
+
+	host := u.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return errors.New("the URL must be on localhost")
+	}
+	return nil
+}
+
+// SaveHook creates or updates a hook. A secret is generated for URL hooks
+// without one.
+func (m *Manager) SaveHook(hook *Hook) error {
+	hook.Name = strings.TrimSpace(hook.Name)
+	hook.Command = strings.TrimSpace(hook.Command)
+	if hook.Confirmations == 0 {
+		hook.Confirmations = 1
+	}
+	if err := hook.validate(); err != nil {
+		return err
+	}
+
+	if hook.URL != "" && hook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		hook.Secret = hex.EncodeToString(secret)
+	}
+
+	if hook.ID == 0 {
+		hook.CreatedAt = time.Now().Unix()
+	}
+	return m.db.Save(hook)
+}
+
+// Hooks returns all saved hooks.
+func (m *Manager) Hooks() ([]*Hook, error) {
+	var hooks []*Hook
+	err := m.db.All(&hooks)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return hooks, nil
+}
+
+// DeleteHook deletes a hook and its delivery log.
+func (m *Manager) DeleteHook(hookID int) error {
+	if err := m.db.Select(q.Eq("HookID", hookID)).Delete(&Delivery{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	if err := m.db.Select(q.Eq("HookID", hookID)).Delete(&confirmedTx{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return m.db.DeleteStruct(&Hook{ID: hookID})
+}
+
+// Deliveries returns the most recent deliveries of a hook, newest first. All
+// deliveries are returned if limit is 0.
+func (m *Manager) Deliveries(hookID, limit int) ([]*Delivery, error) {
+	query := m.db.Select(q.Eq("HookID", hookID)).OrderBy("ID").Reverse()
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var deliveries []*Delivery
+	err := query.Find(&deliveries)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// MaxConfirmations returns the highest number of confirmations the enabled
+// EventTxConfirmed hooks wait for.
+func (m *Manager) MaxConfirmations() int32 {
+	hooks, err := m.Hooks()
+	if err != nil {
+		return 0
+	}
+
+	var maxConfs int32
+	for _, hook := range hooks {
+		if hook.Enabled && hook.subscribes(EventTxConfirmed) && hook.Confirmations > maxConfs {
+			maxConfs = hook.Confirmations
+		}
+	}
+	return maxConfs
+}
+
+func (hook *Hook) subscribes(eventType EventType) bool {
+	for _, event := range hook.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the hook should receive event.
+func (hook *Hook) matches(event *Event) bool {
+	return hook.Enabled && hook.subscribes(event.Type) &&
+		(hook.WalletID == 0 || event.WalletID == 0 || hook.WalletID == event.WalletID)
+}
+
+// Fire delivers event to the enabled hooks that subscribe to it. The
+// deliveries are retried with backoff until they succeed, MaxAttempts is
+// reached or ctx is canceled.
+func (m *Manager) Fire(ctx context.Context, event *Event) {
+	m.fire(ctx, event, func(*Hook) bool { return true })
+}
+
+// FireConfirmed delivers the EventTxConfirmed event of a transaction with
+// confirmations confirmations to the hooks that wait for as many or fewer
+// confirmations and have not received it yet.
+func (m *Manager) FireConfirmed(ctx context.Context, event *Event, confirmations int32) {
+	event.Type = EventTxConfirmed
+	m.fire(ctx, event, func(hook *Hook) bool {
+		if confirmations < hook.Confirmations {
+			return false
+		}
+		fired := &confirmedTx{ID: confirmedTxID(hook.ID, event.Subject), HookID: hook.ID}
+		err := m.db.One("ID", fired.ID, &confirmedTx{})
+		if err != storm.ErrNotFound {
+			return false
+		}
+		if err := m.db.Save(fired); err != nil {
+			log.Errorf("Error saving confirmed tx %s of hook %s: %v", event.Subject, hook.Name, err)
+			return false
+		}
+		return true
+	})
+}
+
+func confirmedTxID(hookID int, hash string) string {
+	return fmt.Sprintf("%d:%s", hookID, hash)
+}
+
+func trackedTxID(walletID int, hash string) string {
+	return fmt.Sprintf("%d:%s", walletID, hash)
+}
+
+// TrackTx tracks a transaction of a wallet mined at minedAt until it has the
+// confirmations of the EventTxConfirmed hooks. The tracked transactions are
+// persisted so that the hooks fire after a restart.
+func (m *Manager) TrackTx(walletID int, hash string, minedAt int32) {
+	tx := &trackedTx{ID: trackedTxID(walletID, hash), WalletID: walletID, Hash: hash, MinedAt: minedAt}
+	if err := m.db.Save(tx); err != nil {
+		log.Errorf("Error tracking tx %s: %v", hash, err)
+	}
+}
+
+// UntrackTx stops tracking a transaction of a wallet.
+func (m *Manager) UntrackTx(walletID int, hash string) {
+	err := m.db.DeleteStruct(&trackedTx{ID: trackedTxID(walletID, hash)})
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error untracking tx %s: %v", hash, err)
+	}
+}
+
+// TrackedTxs returns the mined height of the transactions tracked for a
+// wallet, by hash.
+func (m *Manager) TrackedTxs(walletID int) map[string]int32 {
+	var txs []*trackedTx
+	err := m.db.Find("WalletID", walletID, &txs)
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error reading tracked txs: %v", err)
+	}
+
+	tracked := make(map[string]int32, len(txs))
+	for _, tx := range txs {
+		tracked[tx.Hash] = tx.MinedAt
+	}
+	return tracked
+}
+
+// ResumeDeliveries resumes the deliveries that were pending when the app was
+// closed.
+func (m *Manager) ResumeDeliveries(ctx context.Context) {
+	var deliveries []*Delivery
+	err := m.db.Find("Status", DeliveryPending, &deliveries)
+	if err != nil {
+		if err != storm.ErrNotFound {
+			log.Errorf("Error reading pending deliveries: %v", err)
+		}
+		return
+	}
+
+	for _, delivery := range deliveries {
+		hook := new(Hook)
+		if err := m.db.One("ID", delivery.HookID, hook); err != nil {
+			continue
+		}
+
+		m.wg.Add(1)
+		go func(delivery *Delivery) {
+			defer m.wg.Done()
+			m.deliver(ctx, hook, delivery)
+		}(delivery)
+	}
+}
+
+func (m *Manager) fire(ctx context.Context, event *Event, filter func(*Hook) bool) {
+	hooks, err := m.Hooks()
+	if err != nil {
+		log.Errorf("Error reading hooks: %v", err)
+		return
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		log.Errorf("Error encoding %s event: %v", event.Type, err)
+		return
+	}
+
+	for _, hook := range hooks {
+		if !hook.matches(event) || !filter(hook) {
+			continue
+		}
+
+		delivery, err := m.newDelivery(hook, event, data)
+		if err != nil {
+			log.Errorf("Error saving %s delivery to hook %s: %v", event.Type, hook.Name, err)
+			continue
+		}
+
+		m.wg.Add(1)
+		go func(hook *Hook) {
+			defer m.wg.Done()
+			m.deliver(ctx, hook, delivery)
+		}(hook)
+	}
+}
+
+// newDelivery saves a pending delivery of event to hook.
+func (m *Manager) newDelivery(hook *Hook, event *Event, data []byte) (*Delivery, error) {
+	now := time.Now().Unix()
+	delivery := &Delivery{
+		HookID:    hook.ID,
+		Event:     event.Type,
+		Subject:   event.Subject,
+		Status:    DeliveryPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := m.db.Save(delivery); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(&Payload{
+		DeliveryID: delivery.ID,
+		Event:      event.Type,
+		WalletID:   event.WalletID,
+		Timestamp:  now,
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = string(payload)
+	if err := m.db.Update(delivery); err != nil {
+		return nil, err
+	}
+
+	m.pruneDeliveries(hook.ID)
+	return delivery, nil
+}
+
+// pruneDeliveries deletes the oldest deliveries of a hook above
+// maxDeliveries.
+func (m *Manager) pruneDeliveries(hookID int) {
+	count, err := m.db.Select(q.Eq("HookID", hookID)).Count(&Delivery{})
+	if err != nil || count <= maxDeliveries {
+		return
+	}
+
+	err = m.db.Select(q.Eq("HookID", hookID)).OrderBy("ID").Limit(count - maxDeliveries).Delete(&Delivery{})
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error pruning hook deliveries: %v", err)
+	}
+}
+
+// deliver attempts the delivery until it succeeds, MaxAttempts is reached or
+// ctx is canceled. The delay between attempts doubles after each attempt.
+func (m *Manager) deliver(ctx context.Context, hook *Hook, delivery *Delivery) {
+	delay := retryBaseDelay
+	for {
+		err := m.send(ctx, hook, delivery)
+		delivery.Attempts++
+		delivery.UpdatedAt = time.Now().Unix()
+		switch {
+		case err == nil:
+			delivery.Status = DeliveryDelivered
+			delivery.LastError = ""
+		case delivery.Attempts >= MaxAttempts:
+			delivery.Status = DeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			delivery.LastError = err.Error()
+		}
+
+		// Unset fields are not updated by storm, so the whole delivery is
+		// saved.
+		if err := m.db.Save(delivery); err != nil {
+			log.Errorf("Error saving delivery %d: %v", delivery.ID, err)
+		}
+		if delivery.Status != DeliveryPending {
+			if delivery.Status == DeliveryFailed {
+				log.Errorf("Delivery of %s to hook %s failed: %s", delivery.Event, hook.Name, delivery.LastError)
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+			delay *= 2
+		}
+	}
+}
+
+// send makes one attempt to deliver the payload to the hook.
+func (m *Manager) send(ctx context.Context, hook *Hook, delivery *Delivery) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	if hook.Command != "" {
+		return runCommand(ctx, hook.Command, delivery)
+	}
+	return m.post(ctx, hook, delivery)
+}
+
+// Sign returns the signature of the payload, sent in the SignatureHeader of
+// the URL hook requests.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (m *Manager) post(ctx context.Context, hook *Hook, delivery *Delivery) error {
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, payload))
+
+	res, err := m.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", res.Status)
+	}
+	return nil
+}
+
+func runCommand(ctx context.Context, command string, delivery *Delivery) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return errors.New("empty hook command")
+	}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(delivery.Payload)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// Wait blocks until the running deliveries end. The deliveries end when the
+// context they were fired with is canceled.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
@@ -0,0 +1,317 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/testutil"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	m, err := NewManager(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSaveHookValidation(t *testing.T) {
+	m := newTestManager(t)
+
+	tests := []struct {
+		name    string
+		hook    *Hook
+		wantErr bool
+	}{
+		{
+			name: "localhost URL",
+			hook: &Hook{Name: "a", Events: []EventType{EventTransaction}, URL: "http://localhost:8080/hook"},
+		},
+		{
+			name: "loopback IP",
+			hook: &Hook{Name: "b", Events: []EventType{EventTransaction}, URL: "https://[::1]/hook"},
+		},
+		{
+			name: "command",
+			hook: &Hook{Name: "c", Events: []EventType{EventDEXMatch}, Command: "cat"},
+		},
+		{
+			name:    "remote URL",
+			hook:    &Hook{Name: "d", Events: []EventType{EventTransaction}, URL: "http://example.com/hook"},
+			wantErr: true,
+		},
+		{
+			name:    "URL and command",
+			hook:    &Hook{Name: "e", Events: []EventType{EventTransaction}, URL: "http://127.0.0.1/", Command: "cat"},
+			wantErr: true,
+		},
+		{
+			name:    "blank command",
+			hook:    &Hook{Name: "h", Events: []EventType{EventDEXMatch}, Command: " \t "},
+			wantErr: true,
+		},
+		{
+			name:    "no events",
+			hook:    &Hook{Name: "f", URL: "http://127.0.0.1/"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown event",
+			hook:    &Hook{Name: "g", Events: []EventType{"block"}, URL: "http://127.0.0.1/"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := m.SaveHook(test.hook)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.wantErr, err)
+		}
+	}
+
+	hooks, err := m.Hooks()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 3 {
+		t.Fatalf("expected 3 saved hooks, got %d", len(hooks))
+	}
+	for _, hook := range hooks {
+		if hook.URL != "" && hook.Secret == "" {
+			t.Errorf("hook %s: secret not generated", hook.Name)
+		}
+		if hook.Confirmations != 1 {
+			t.Errorf("hook %s: expected 1 confirmation by default, got %d", hook.Name, hook.Confirmations)
+		}
+	}
+}
+
+func TestURLHookSignedDelivery(t *testing.T) {
+	m := newTestManager(t)
+
+	var received atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received.Store(r.Header.Get(SignatureHeader))
+		if r.Header.Get(EventHeader) != string(EventTransaction) {
+			t.Errorf("unexpected event header %q", r.Header.Get(EventHeader))
+		}
+
+		var payload Payload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		if payload.Event != EventTransaction || payload.WalletID != 1 || string(payload.Data) != `{"hash":"abc"}` {
+			t.Errorf("unexpected payload %s", body)
+		}
+		if r.Header.Get(SignatureHeader) != Sign("secret", body) {
+			t.Errorf("invalid signature")
+		}
+	}))
+	defer server.Close()
+
+	hook := &Hook{Name: "server", Events: []EventType{EventTransaction}, Enabled: true, URL: server.URL, Secret: "secret"}
+	if err := m.SaveHook(hook); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(context.Background(), &Event{Type: EventTransaction, WalletID: 1, Subject: "abc", Data: map[string]string{"hash": "abc"}})
+	// Not subscribed.
+	m.Fire(context.Background(), &Event{Type: EventDEXMatch, Subject: "match"})
+	m.Wait()
+
+	if received.Load() == nil {
+		t.Fatal("hook not called")
+	}
+
+	deliveries, err := m.Deliveries(hook.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != DeliveryDelivered || deliveries[0].Attempts != 1 {
+		t.Fatalf("unexpected deliveries %+v", deliveries)
+	}
+}
+
+func TestURLHookRetry(t *testing.T) {
+	defer func(delay time.Duration) { retryBaseDelay = delay }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	m := newTestManager(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	hook := &Hook{Name: "flaky", Events: []EventType{EventSwapStatus}, Enabled: true, URL: server.URL}
+	if err := m.SaveHook(hook); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(context.Background(), &Event{Type: EventSwapStatus, Subject: "order"})
+	m.Wait()
+
+	deliveries, err := m.Deliveries(hook.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != DeliveryDelivered || deliveries[0].Attempts != 3 {
+		t.Fatalf("unexpected deliveries %+v", deliveries)
+	}
+
+	// A hook that never succeeds is marked as failed after MaxAttempts.
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	m.Fire(context.Background(), &Event{Type: EventSwapStatus, Subject: "order"})
+	m.Wait()
+
+	deliveries, err = m.Deliveries(hook.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != DeliveryFailed || deliveries[0].Attempts != MaxAttempts || deliveries[0].LastError == "" {
+		t.Fatalf("unexpected deliveries %+v", deliveries)
+	}
+}
+
+func TestConfirmedHooks(t *testing.T) {
+	m := newTestManager(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	hook := &Hook{Name: "confirmed", Events: []EventType{EventTxConfirmed}, Enabled: true, Confirmations: 3, URL: server.URL}
+	if err := m.SaveHook(hook); err != nil {
+		t.Fatal(err)
+	}
+	if maxConfs := m.MaxConfirmations(); maxConfs != 3 {
+		t.Fatalf("expected 3 max confirmations, got %d", maxConfs)
+	}
+
+	for confs := int32(1); confs <= 5; confs++ {
+		m.FireConfirmed(context.Background(), &Event{WalletID: 1, Subject: "tx"}, confs)
+		m.Wait()
+	}
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single delivery, got %d", calls.Load())
+	}
+
+	// The event does not fire again once its delivery is pruned from the
+	// log.
+	if err := m.db.Select().Delete(&Delivery{}); err != nil {
+		t.Fatal(err)
+	}
+	m.FireConfirmed(context.Background(), &Event{WalletID: 1, Subject: "tx"}, 6)
+	m.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("expected a single delivery, got %d", calls.Load())
+	}
+}
+
+func TestTrackedTxs(t *testing.T) {
+	m := newTestManager(t)
+
+	m.TrackTx(1, "a", 10)
+	m.TrackTx(1, "b", 12)
+	m.TrackTx(2, "a", 11)
+	m.UntrackTx(1, "b")
+
+	tracked := m.TrackedTxs(1)
+	if len(tracked) != 1 || tracked["a"] != 10 {
+		t.Fatalf("unexpected tracked txs %v", tracked)
+	}
+	if tracked := m.TrackedTxs(3); len(tracked) != 0 {
+		t.Fatalf("unexpected tracked txs %v", tracked)
+	}
+}
+
+func TestResumeDeliveries(t *testing.T) {
+	m := newTestManager(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	hook := &Hook{Name: "resumed", Events: []EventType{EventSwapStatus}, Enabled: true, URL: server.URL}
+	if err := m.SaveHook(hook); err != nil {
+		t.Fatal(err)
+	}
+
+	// A delivery left pending when the app was closed.
+	delivery, err := m.newDelivery(hook, &Event{Type: EventSwapStatus, Subject: "order"}, []byte("null"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.ResumeDeliveries(context.Background())
+	m.Wait()
+
+	deliveries, err := m.Deliveries(hook.ID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 1 || len(deliveries) != 1 || deliveries[0].ID != delivery.ID || deliveries[0].Status != DeliveryDelivered {
+		t.Fatalf("unexpected deliveries %+v", deliveries)
+	}
+}
+
+func TestRunCommandBlank(t *testing.T) {
+	if err := runCommand(context.Background(), " ", &Delivery{}); err == nil {
+		t.Fatal("expected an error for a blank command")
+	}
+}
+
+func TestCommandHook(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("no shell available")
+	}
+
+	m := newTestManager(t)
+
+	out := filepath.Join(t.TempDir(), "payload.json")
+	script := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat > "+out+"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	hook := &Hook{Name: "script", Events: []EventType{EventTicketVoted}, Enabled: true, Command: "/bin/sh " + script}
+	if err := m.SaveHook(hook); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(context.Background(), &Event{Type: EventTicketVoted, WalletID: 2, Subject: "vote", Data: "vote"})
+	m.Wait()
+
+	body, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Event != EventTicketVoted || payload.WalletID != 2 || string(payload.Data) != `"vote"` {
+		t.Fatalf("unexpected payload %s", body)
+	}
+}
@@ -0,0 +1,147 @@
+package hooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType is the kind of event a hook fires on.
+type EventType string
+
+const (
+	// EventTransaction fires when a wallet sees a new transaction.
+	EventTransaction EventType = "transaction"
+	// EventTxConfirmed fires when a transaction reaches the number of
+	// confirmations of the hook.
+	EventTxConfirmed EventType = "tx_confirmed"
+	// EventTicketVoted fires when a ticket of a wallet votes.
+	EventTicketVoted EventType = "ticket_voted"
+	// EventSwapStatus fires when the status of an instantswap order changes.
+	EventSwapStatus EventType = "swap_status"
+	// EventDEXMatch fires when a DEX order is matched.
+	EventDEXMatch EventType = "dex_match"
+)
+
+// EventTypes are all the event types, in display order.
+var EventTypes = []EventType{
+	EventTransaction,
+	EventTxConfirmed,
+	EventTicketVoted,
+	EventSwapStatus,
+	EventDEXMatch,
+}
+
+// DeliveryStatus is the state of the delivery of an event to a hook.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+const (
+	// MaxAttempts is the number of times a delivery is attempted before it
+	// is marked as failed.
+	MaxAttempts = 5
+
+	// SignatureHeader holds the hex encoded HMAC-SHA256 of the body of the
+	// URL hook requests, keyed with the hook secret.
+	SignatureHeader = "X-Cryptopower-Signature"
+
+	// EventHeader holds the event type of the URL hook requests.
+	EventHeader = "X-Cryptopower-Event"
+
+	// requestTimeout is how long a URL hook or a command hook may take to
+	// handle an event.
+	requestTimeout = 30 * time.Second
+
+	// maxDeliveries is the number of deliveries kept in the log of each
+	// hook.
+	maxDeliveries = 200
+)
+
+// retryBaseDelay is the delay before the second attempt of a delivery. It is
+// doubled after each failed attempt.
+var retryBaseDelay = 5 * time.Second
+
+// Hook delivers the events it subscribes to either by POSTing a signed JSON
+// payload to a local URL or by running a command with the JSON payload on
+// its stdin.
+type Hook struct {
+	ID      int         `storm:"id,increment" json:"id"`
+	Name    string      `storm:"unique" json:"name"`
+	Events  []EventType `json:"events"`
+	Enabled bool        `json:"enabled"`
+	// WalletID restricts the wallet events to a wallet. Zero matches all
+	// wallets.
+	WalletID int `json:"walletID"`
+	// Confirmations is the number of confirmations at which EventTxConfirmed
+	// fires.
+	Confirmations int32 `json:"confirmations"`
+
+	// URL must be on the loopback interface. Secret keys the payload
+	// signature and is generated when the hook is saved if it is empty.
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+
+	// Command is run without a shell. It is split on spaces into the program
+	// and its arguments.
+	Command string `json:"command"`
+
+	CreatedAt int64 `json:"createdAt"`
+}
+
+// Payload is the JSON document delivered to the hooks.
+type Payload struct {
+	DeliveryID int             `json:"deliveryID"`
+	Event      EventType       `json:"event"`
+	WalletID   int             `json:"walletID,omitempty"`
+	Timestamp  int64           `json:"timestamp"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Delivery records the delivery of an event to a hook.
+type Delivery struct {
+	ID     int       `storm:"id,increment" json:"id"`
+	HookID int       `storm:"index" json:"hookID"`
+	Event  EventType `json:"event"`
+	// Subject identifies what the event is about, e.g. the tx hash or the
+	// swap order UUID.
+	Subject   string         `storm:"index" json:"subject"`
+	Payload   string         `json:"payload"`
+	Status    DeliveryStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	LastError string         `json:"lastError"`
+	CreatedAt int64          `json:"createdAt"`
+	UpdatedAt int64          `json:"updatedAt"`
+}
+
+// confirmedTx records that the EventTxConfirmed event of a transaction fired
+// for a hook. Unlike the delivery log it is not pruned, so the event fires
+// once per hook and transaction.
+type confirmedTx struct {
+	// ID is the hook ID and the transaction hash, see confirmedTxID.
+	ID     string `storm:"id"`
+	HookID int    `storm:"index"`
+}
+
+// trackedTx is a mined transaction waiting for the confirmations of the
+// EventTxConfirmed hooks.
+type trackedTx struct {
+	// ID is the wallet ID and the transaction hash, see trackedTxID.
+	ID       string `storm:"id"`
+	WalletID int    `storm:"index"`
+	Hash     string
+	MinedAt  int32
+}
+
+// Event is an event fired to the hooks.
+type Event struct {
+	Type     EventType
+	WalletID int
+	// Subject identifies what the event is about, e.g. the tx hash.
+	Subject string
+	// Data is marshalled into the payload.
+	Data interface{}
+}
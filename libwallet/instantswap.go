@@ -12,6 +12,8 @@ import (
 
 	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
@@ -42,6 +44,10 @@ const (
 	DefaultRateRequestBTC    = 0.01
 	DefaultRateRequestLTC    = 1
 	DefaultRateRequestDCR    = 10
+
+	// scheduleRetryInterval is the minimum time before a failed run of a
+	// schedule is retried.
+	scheduleRetryInterval = 10 * time.Minute
 )
 
 func DefaultRateRequestAmt(fromCurrency string) float64 {
@@ -56,312 +62,515 @@ func DefaultRateRequestAmt(fromCurrency string) float64 {
 	return DefaultRateRequestAmount
 }
 
-// StartScheduler starts the automatic order scheduler.
-func (mgr *AssetsManager) StartScheduler(ctx context.Context, params instantswap.SchedulerParams) error {
-	const op errors.Op = "mgr.StartScheduler"
-	log.Info("Order Scheduler: started")
+// CreateSchedule saves a new schedule and starts running it. The first order
+// of the schedule is created immediately.
+func (mgr *AssetsManager) CreateSchedule(schedule *instantswap.Schedule, spendingPassphrase string) error {
+	if err := mgr.InstantSwap.SaveSchedule(schedule); err != nil {
+		return err
+	}
+	return mgr.StartSchedule(schedule.ID, spendingPassphrase)
+}
 
-	log.Info("Order Scheduler: verifying source wallet")
-	sourceWallet := mgr.WalletWithID(params.Order.SourceWalletID)
-	if sourceWallet == nil {
-		return errors.E(op, errors.Errorf("wallet with id:%d not found", params.Order.SourceWalletID))
+// StartSchedule starts running a saved schedule. A paused schedule is made
+// active again. The spending passphrase is not saved, so active schedules are
+// not running after a restart until they are started again, see
+// SchedulesToResume. They resume from their next due run.
+func (mgr *AssetsManager) StartSchedule(scheduleID int, spendingPassphrase string) error {
+	const op errors.Op = "mgr.StartSchedule"
+
+	schedule, err := mgr.InstantSwap.Schedule(scheduleID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	if schedule.Status == instantswap.ScheduleEnded {
+		return errors.E(op, errors.Errorf("schedule %q has ended: %s", schedule.Name, schedule.StatusReason))
 	}
 
-	mgr.InstantSwap.CancelOrderSchedulerMu.RLock()
+	sourceWallet := mgr.WalletWithID(schedule.Order.SourceWalletID)
+	if sourceWallet == nil {
+		return errors.E(op, errors.Errorf("wallet with id:%d not found", schedule.Order.SourceWalletID))
+	}
 
-	if mgr.InstantSwap.CancelOrderScheduler != nil {
-		mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
-		return errors.New("scheduler already running")
+	mgr.InstantSwap.RunningSchedulesMu.Lock()
+	if _, running := mgr.InstantSwap.RunningSchedules[scheduleID]; running {
+		mgr.InstantSwap.RunningSchedulesMu.Unlock()
+		return errors.E(op, errors.New(instantswap.ErrScheduleRunning))
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr.InstantSwap.RunningSchedules[scheduleID] = cancel
+	mgr.InstantSwap.RunningSchedulesMu.Unlock()
+
+	if schedule.Status != instantswap.ScheduleActive {
+		schedule.Status, schedule.StatusReason, schedule.Failures = instantswap.ScheduleActive, "", 0
+		if err := mgr.InstantSwap.UpdateSchedule(schedule); err != nil {
+			log.Errorf("Order Scheduler: error updating schedule %q: %v", schedule.Name, err)
+		}
+	}
+
+	log.Infof("Order Scheduler: schedule %q started", schedule.Name)
+	mgr.InstantSwap.PublishScheduleStarted(schedule)
 
-	mgr.InstantSwap.SchedulerStartTime = time.Now()
-	mgr.InstantSwap.SchedulerCtx, mgr.InstantSwap.CancelOrderScheduler = context.WithCancel(ctx)
-	mgr.InstantSwap.PublishOrderSchedulerStarted()
-	defer func() {
-		mgr.InstantSwap.CancelOrderScheduler = nil
-		mgr.InstantSwap.SchedulerStartTime = time.Time{}
-		mgr.InstantSwap.PublishOrderSchedulerEnded()
-		log.Info("Order Scheduler: exited")
+	go func() {
+		mgr.runSchedule(ctx, schedule, sourceWallet, spendingPassphrase)
+
+		mgr.InstantSwap.RunningSchedulesMu.Lock()
+		delete(mgr.InstantSwap.RunningSchedules, scheduleID)
+		mgr.InstantSwap.RunningSchedulesMu.Unlock()
+
+		log.Infof("Order Scheduler: schedule %q exited", schedule.Name)
+		mgr.InstantSwap.PublishScheduleStopped(schedule)
 	}()
-	mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
 
-	// Initialize the exchange server.
-	log.Info("Order Scheduler: initializing exchange server")
-	exchangeObject, err := mgr.InstantSwap.NewExchangeServer(params.Order.ExchangeServer)
+	return nil
+}
+
+// SchedulesToResume returns the active schedules that are not running, which
+// stopped when the app was closed. They are resumed at startup once the
+// spending passphrases of their source wallets are entered.
+func (mgr *AssetsManager) SchedulesToResume() ([]*instantswap.Schedule, error) {
+	schedules, err := mgr.InstantSwap.Schedules()
 	if err != nil {
-		return errors.E(op, err)
+		return nil, err
 	}
 
-	var lastOrderTime time.Time
-	for {
-		// Check if scheduler has been shutdown and exit if true.
-		if mgr.InstantSwap.SchedulerCtx.Err() != nil {
-			return mgr.InstantSwap.SchedulerCtx.Err()
+	toResume := make([]*instantswap.Schedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.Status == instantswap.ScheduleActive && !mgr.IsScheduleRunning(schedule.ID) &&
+			mgr.WalletWithID(schedule.Order.SourceWalletID) != nil {
+			toResume = append(toResume, schedule)
 		}
+	}
+	return toResume, nil
+}
 
-		sourceAccountBalance, err := sourceWallet.GetAccountBalance(params.Order.SourceAccountNumber)
-		if err != nil {
-			log.Error("unable to get account balance")
-			return err
-		}
+// StopSchedule stops a running schedule and pauses it so that it is not
+// resumed until it is started again.
+func (mgr *AssetsManager) StopSchedule(scheduleID int) {
+	mgr.cancelSchedule(scheduleID)
 
-		walletBalance := sourceAccountBalance.Spendable.ToCoin()
-		if walletBalance <= params.BalanceToMaintain {
-			if !lastOrderTime.IsZero() { // some orders have already been concluded
-				return nil
-			}
+	schedule, err := mgr.InstantSwap.Schedule(scheduleID)
+	if err != nil || schedule.Status != instantswap.ScheduleActive {
+		return
+	}
 
-			log.Error("source wallet balance is less than or equals the set balance to maintain")
-			return errors.E(op, "source wallet balance is less than or equals the set balance to maintain") // stop scheduling if the source wallet balance is less than or equals the set balance to maintain
-		}
+	schedule.Status, schedule.StatusReason = instantswap.SchedulePaused, ""
+	if err := mgr.InstantSwap.UpdateSchedule(schedule); err != nil {
+		log.Errorf("Order Scheduler: error pausing schedule %q: %v", schedule.Name, err)
+	}
+	log.Infof("Order Scheduler: schedule %q paused", schedule.Name)
+}
+
+// StopAllSchedules stops and pauses all the running schedules.
+func (mgr *AssetsManager) StopAllSchedules() {
+	for _, scheduleID := range mgr.RunningScheduleIDs() {
+		mgr.StopSchedule(scheduleID)
+	}
+}
 
-		if !lastOrderTime.IsZero() {
-			log.Info("Order Scheduler: creating next order based on selected frequency")
+// DeleteSchedule stops a schedule and deletes it with its execution log.
+func (mgr *AssetsManager) DeleteSchedule(scheduleID int) error {
+	mgr.cancelSchedule(scheduleID)
+	return mgr.InstantSwap.DeleteSchedule(scheduleID)
+}
+
+// IsScheduleRunning returns true if the schedule with the provided ID is
+// running.
+func (mgr *AssetsManager) IsScheduleRunning(scheduleID int) bool {
+	mgr.InstantSwap.RunningSchedulesMu.RLock()
+	defer mgr.InstantSwap.RunningSchedulesMu.RUnlock()
+	_, running := mgr.InstantSwap.RunningSchedules[scheduleID]
+	return running
+}
 
-			// calculate time until the next order
-			timeUntilNextOrder := params.Frequency - time.Since(lastOrderTime)
-			if timeUntilNextOrder <= 0 {
-				log.Info("Order Scheduler: the scheduler start time is equal to or greater than the frequency, starting next order immediately")
-			} else {
-				log.Infof("Order Scheduler: %s until the next order is executed", timeUntilNextOrder)
-				time.Sleep(timeUntilNextOrder)
+// RunningScheduleIDs returns the IDs of the running schedules.
+func (mgr *AssetsManager) RunningScheduleIDs() []int {
+	mgr.InstantSwap.RunningSchedulesMu.RLock()
+	defer mgr.InstantSwap.RunningSchedulesMu.RUnlock()
+
+	scheduleIDs := make([]int, 0, len(mgr.InstantSwap.RunningSchedules))
+	for scheduleID := range mgr.InstantSwap.RunningSchedules {
+		scheduleIDs = append(scheduleIDs, scheduleID)
+	}
+	return scheduleIDs
+}
+
+// cancelSchedule stops a running schedule without changing its status. Active
+// schedules canceled on shutdown are resumed when started again.
+func (mgr *AssetsManager) cancelSchedule(scheduleID int) {
+	mgr.InstantSwap.RunningSchedulesMu.RLock()
+	cancel := mgr.InstantSwap.RunningSchedules[scheduleID]
+	mgr.InstantSwap.RunningSchedulesMu.RUnlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+// runSchedule creates the orders of a schedule when they are due until ctx is
+// canceled, one of the schedule's end conditions is reached or too many runs
+// fail.
+func (mgr *AssetsManager) runSchedule(ctx context.Context, schedule *instantswap.Schedule, sourceWallet sharedW.Asset, spendingPassphrase string) {
+	for {
+		if waitTime := time.Until(time.Unix(schedule.NextRun, 0)); waitTime > 0 {
+			log.Infof("Order Scheduler: %s until the next %q order", waitTime.Round(time.Second), schedule.Name)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(waitTime):
 			}
 		}
 
-		fromCur := params.Order.FromCurrency
-		toCur := params.Order.ToCurrency
-		rateRequestParams := api.ExchangeRateRequest{
-			From:        fromCur,
-			To:          toCur,
-			Amount:      DefaultRateRequestAmt(fromCur), // amount needs to be greater than 0 to get the exchange rate
-			FromNetwork: params.Order.FromNetwork,
-			ToNetwork:   params.Order.ToNetwork,
+		if reason := schedule.EndReason(time.Now()); reason != "" {
+			mgr.updateScheduleStatus(schedule, instantswap.ScheduleEnded, reason)
+			return
 		}
-		log.Info("Order Scheduler: getting exchange rate info")
-		res, err := mgr.InstantSwap.GetExchangeRateInfo(exchangeObject, rateRequestParams)
+
+		execution := &instantswap.ScheduleExecution{ScheduleID: schedule.ID}
+		exchangeObject, order, err := mgr.createScheduledOrder(ctx, schedule, sourceWallet, spendingPassphrase, execution)
 		if err != nil {
-			log.Error("unable to get exchange server rate info")
-			return errors.E(op, err)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Errorf("Order Scheduler: %q run failed: %v", schedule.Name, err)
+			execution.Error = err.Error()
+			mgr.saveScheduleExecution(execution)
+
+			// Failed runs are retried after the schedule frequency, or
+			// after scheduleRetryInterval if it is shorter.
+			schedule.Failures++
+			schedule.NextRun = time.Now().Add(max(schedule.Frequency, scheduleRetryInterval)).Unix()
+			if schedule.Failures >= instantswap.MaxScheduleFailures {
+				mgr.updateScheduleStatus(schedule, instantswap.SchedulePaused, err.Error())
+				return
+			}
+			if err := mgr.InstantSwap.UpdateScheduleProgress(schedule); err != nil {
+				log.Errorf("Order Scheduler: error updating schedule %q: %v", schedule.Name, err)
+			}
+			continue
 		}
-
-		if params.MaxDeviationRate <= 0 {
-			params.MaxDeviationRate = DefaultMarketDeviation // default 5%
+		mgr.saveScheduleExecution(execution)
+
+		schedule.Failures = 0
+		schedule.OrdersCount++
+		schedule.TotalAmount += execution.InvoicedAmount
+		// Schedules saved without a frequency limit run at the minimum
+		// frequency.
+		schedule.NextRun = time.Now().Add(max(schedule.Frequency, instantswap.MinScheduleFrequency)).Unix()
+		// Only the progress is saved, the schedule may have been paused while
+		// the order was created.
+		if err := mgr.InstantSwap.UpdateScheduleProgress(schedule); err != nil {
+			log.Errorf("Order Scheduler: error updating schedule %q: %v", schedule.Name, err)
 		}
 
-		market := values.NewMarket(fromCur, toCur)
-		source := mgr.RateSource.Name()
-		ticker := mgr.RateSource.GetTicker(market, false)
-		if ticker == nil {
-			log.Errorf("unable to get market(%s) rate from %s.", market, source)
-			log.Infof("Proceeding without checking market rate deviation...")
-		} else {
-			exchangeServerRate := res.ExchangeRate // estimated receivable value for libwallet.DefaultRateRequestAmount (1)
-			rateSourceRate := ticker.LastTradePrice
-
-			// Current rate source supported Binance and Bittrex always returns
-			// ticker.LastTradePrice in's the quote asset unit e.g DCR-BTC, LTC-BTC.
-			// We will also do this when and if USDT is supported.
-			if strings.EqualFold(fromCur, "btc") {
-				rateSourceRate = 1 / ticker.LastTradePrice
-			}
-
-			serverRateStr := values.StringF(values.StrServerRate, params.Order.ExchangeServer.Server, fromCur, exchangeServerRate, toCur)
-			log.Info(serverRateStr)
-			binanceRateStr := values.StringF(values.StrCurrencyConverterRate, source, fromCur, rateSourceRate, toCur)
-			log.Info(binanceRateStr)
-
-			// Check if the server rate deviates from the market rate by ± 5%
-			// exit if true
-			percentageDiff := math.Abs((exchangeServerRate-rateSourceRate)/((exchangeServerRate+rateSourceRate)/2)) * 100
-			if percentageDiff > params.MaxDeviationRate {
-				errMsg := fmt.Errorf("exchange rate deviates from the market rate by (%.2f%%) more than %.2f%%", percentageDiff-params.MaxDeviationRate, params.MaxDeviationRate)
-				log.Error(errMsg)
-				return errors.E(op, errMsg)
+		// Wait for the order to be completed before creating the next order
+		// so that the funds of a refunded order can be reused.
+		if err := mgr.waitForScheduledOrder(ctx, exchangeObject, order, execution.TxHash); err != nil {
+			if ctx.Err() != nil {
+				return
 			}
+			log.Errorf("Order Scheduler: %q order %s failed: %v", schedule.Name, order.UUID, err)
+			execution.Error = err.Error()
+			mgr.saveScheduleExecution(execution)
 		}
+	}
+}
+
+// createScheduledOrder creates the order for a run of a schedule and sends
+// the invoiced amount to the exchange server. The order, its amount and the
+// hash of the deposit tx are recorded in execution. The order is deleted if
+// its deposit is not sent.
+func (mgr *AssetsManager) createScheduledOrder(ctx context.Context, schedule *instantswap.Schedule, sourceWallet sharedW.Asset, spendingPassphrase string,
+	execution *instantswap.ScheduleExecution) (api.IDExchange, *instantswap.Order, error) {
+	params := schedule.Order
+	fromCur, toCur := params.FromCurrency, params.ToCurrency
+
+	sourceAccountBalance, err := sourceWallet.GetAccountBalance(params.SourceAccountNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get account balance: %w", err)
+	}
+	walletBalance := sourceAccountBalance.Spendable.ToCoin()
+
+	var invoicedAmount float64
+	switch schedule.AmountMode {
+	case instantswap.AmountFixed:
+		invoicedAmount = schedule.Amount
+	case instantswap.AmountPercentage:
+		invoicedAmount = walletBalance * schedule.Amount / 100
+	case instantswap.AmountAboveThreshold:
+		invoicedAmount = walletBalance - schedule.Amount
+	}
+
+	if schedule.MaxTotal > 0 {
+		invoicedAmount = math.Min(invoicedAmount, schedule.MaxTotal-schedule.TotalAmount)
+	}
+
+	if invoicedAmount <= 0 {
+		return nil, nil, fmt.Errorf("nothing to swap (current balance: %v %s)", walletBalance, fromCur)
+	}
+
+	// The wallet balance must also cover the fee of the deposit tx.
+	if invoicedAmount >= walletBalance {
+		return nil, nil, fmt.Errorf("insufficient balance to swap %v %s and pay the tx fee (current balance: %v %s)",
+			invoicedAmount, fromCur, walletBalance, fromCur)
+	}
+
+	exchangeObject, err := mgr.InstantSwap.NewExchangeServer(params.ExchangeServer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rateRequestParams := api.ExchangeRateRequest{
+		From:        fromCur,
+		To:          toCur,
+		Amount:      invoicedAmount,
+		FromNetwork: params.FromNetwork,
+		ToNetwork:   params.ToNetwork,
+	}
+	res, err := mgr.InstantSwap.GetExchangeRateInfo(exchangeObject, rateRequestParams)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get exchange server rate info: %w", err)
+	}
 
-		// set the max send amount to the max limit set by the server
-		invoicedAmount := res.Max
+	// Swap at most the max limit of the server, a max of 0 means there is no
+	// limit.
+	if res.Max > 0 && invoicedAmount > res.Max {
+		invoicedAmount = res.Max
+	}
+	if invoicedAmount < res.Min {
+		return nil, nil, fmt.Errorf("swap amount %v %s is below the exchange minimum of %v %s", invoicedAmount, fromCur, res.Min, fromCur)
+	}
 
-		estimatedBalanceAfterExchange := walletBalance - invoicedAmount
-		// if the max send limit is 0, then the server does not have a max limit
-		// constraint so we can send the entire source wallet balance
-		if res.Max == 0 || estimatedBalanceAfterExchange < params.BalanceToMaintain {
-			invoicedAmount = walletBalance - params.BalanceToMaintain // deduct the balance to maintain from the source wallet balance
+	maxDeviationRate := schedule.MaxDeviationRate
+	if maxDeviationRate <= 0 {
+		maxDeviationRate = DefaultMarketDeviation
+	}
+
+	source := mgr.RateSource.Name()
+	if rateSourceRate := mgr.instantSwapMarketRate(fromCur, toCur); rateSourceRate <= 0 {
+		log.Errorf("unable to get market(%s) rate from %s.", values.NewMarket(fromCur, toCur), source)
+		log.Infof("Proceeding without checking market rate deviation...")
+	} else {
+		exchangeServerRate := res.ExchangeRate
+		log.Info(values.StringF(values.StrServerRate, params.ExchangeServer.Server, fromCur, exchangeServerRate, toCur))
+		log.Info(values.StringF(values.StrCurrencyConverterRate, source, fromCur, rateSourceRate, toCur))
+
+		percentageDiff := math.Abs((exchangeServerRate-rateSourceRate)/((exchangeServerRate+rateSourceRate)/2)) * 100
+		if percentageDiff > maxDeviationRate {
+			return nil, nil, fmt.Errorf("exchange rate deviates from the market rate by (%.2f%%) more than %.2f%%", percentageDiff-maxDeviationRate, maxDeviationRate)
 		}
+	}
+
+	amount, err := coinToAtoms(sourceWallet.GetAssetType(), invoicedAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The wallet has a single unsigned tx, which the send page or another
+	// schedule must not replace until the deposit is broadcast.
+	if err := sourceWallet.LockTxAuthor(ctx); err != nil {
+		return nil, nil, err
+	}
+	defer sourceWallet.UnlockTxAuthor()
 
-		if invoicedAmount <= 0 {
-			errMsg := fmt.Errorf("balance to maintain is the same or greater than wallet balance(Current Balance: %v, Balance to Maintain: %v)", walletBalance, params.BalanceToMaintain)
-			log.Error(errMsg)
-			return errors.E(op, errMsg)
+	log.Infof("Order Scheduler: creating %q order", schedule.Name)
+	params.InvoicedAmount = invoicedAmount
+	order, err := mgr.InstantSwap.CreateOrder(exchangeObject, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating order: %w", err)
+	}
+	execution.OrderUUID = order.UUID
+	execution.InvoicedAmount = invoicedAmount
+
+	execution.TxHash, err = sendScheduledDeposit(sourceWallet, params.SourceAccountNumber, order.DepositAddress, amount, spendingPassphrase)
+	if err != nil {
+		if err := mgr.InstantSwap.DeleteOrder(order); err != nil {
+			log.Errorf("Order Scheduler: error deleting order %s: %v", order.UUID, err)
 		}
+		return nil, nil, err
+	}
+
+	return exchangeObject, order, nil
+}
+
+// sendScheduledDeposit sends the deposit of a scheduled order. The caller
+// holds the lock of the wallet's unsigned tx.
+func sendScheduledDeposit(sourceWallet sharedW.Asset, accountNumber int32, depositAddress string, amount int64, spendingPassphrase string) (string, error) {
+	err := sourceWallet.NewUnsignedTx(accountNumber, nil)
+	if err != nil {
+		return "", err
+	}
+
+	log.Infof("Order Scheduler: adding send destination, address: %s, amount: %v", depositAddress, amount)
+	err = sourceWallet.AddSendDestination(0, depositAddress, amount, false)
+	if err != nil {
+		return "", fmt.Errorf("error adding send destination: %w", err)
+	}
+
+	log.Info("Order Scheduler: broadcasting tx")
+	txHash, err := sourceWallet.Broadcast(spendingPassphrase, "")
+	if err != nil {
+		return "", fmt.Errorf("error broadcasting tx: %w", err)
+	}
+	return txHash, nil
+}
+
+// waitForScheduledOrder polls the exchange server until the order is completed
+// or refunded, and verifies the payout on the blockchain explorer.
+func (mgr *AssetsManager) waitForScheduledOrder(ctx context.Context, exchangeObject api.IDExchange, order *instantswap.Order, txHash string) error {
+	const op errors.Op = "mgr.waitForScheduledOrder"
 
-		if invoicedAmount == walletBalance {
-			errMsg := "Specify a little balance to maintain to cover for transaction fees... e.g 0.001 for DCR to BTC or LTC swaps"
-			log.Error(errMsg)
-			return errors.E(op, errMsg)
+	for {
+		// depending on the block time for the asset, the order may take a
+		// while to complete so we wait for the estimated block time before
+		// checking the order status
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(assetBlockTime(order.ToCurrency)):
 		}
 
-		log.Info("Order Scheduler: creating order")
-		params.Order.InvoicedAmount = invoicedAmount
-		order, err := mgr.InstantSwap.CreateOrder(exchangeObject, params.Order)
+		orderInfo, err := mgr.InstantSwap.GetOrderInfo(exchangeObject, order.UUID)
 		if err != nil {
-			log.Error("error creating order: ", err.Error())
 			return errors.E(op, err)
 		}
-		lastOrderTime = time.Now()
 
-		log.Info("Order Scheduler: creating unsigned transaction")
+		switch orderInfo.Status {
+		case api.OrderStatusExpired, api.OrderStatusFailed:
+			return errors.E(op, errors.Errorf("order %s", orderInfo.Status))
+		case api.OrderStatusCompleted, api.OrderStatusRefunded:
+		default:
+			continue // order is not completed, check again
+		}
 
-		// construct the transaction to send the invoiced amount to the exchange server
-		err = sourceWallet.NewUnsignedTx(params.Order.SourceAccountNumber, nil)
-		if err != nil {
-			return errors.E(op, err)
+		// If this is empty for any reason, default to the actual tx hash.
+		if orderInfo.TxID == "" {
+			orderInfo.TxID = txHash
 		}
 
-		var amount int64
-		switch sourceWallet.GetAssetType() {
-		case utils.BTCWalletAsset:
-			amount = btc.AmountSatoshi(params.Order.InvoicedAmount)
-		case utils.DCRWalletAsset:
-			amount = dcr.AmountAtom(params.Order.InvoicedAmount)
+		symbol, isRefunded := order.ToCurrency, orderInfo.Status == api.OrderStatusRefunded
+		if isRefunded {
+			log.Info("order was refunded. verifying that the order was refunded successfully from the blockchain explorer")
+			symbol = order.FromCurrency
+			orderInfo.ReceiveAmount = order.InvoicedAmount
 		}
 
-		log.Infof("Order Scheduler: adding send destination, address: %s, amount: %.2f", order.DepositAddress, params.Order.InvoicedAmount)
-		// TODO: Broadcast will fail below if params.Order.InvoicedAmount is the
-		// same as the current wallet balance. We should be able to consider
-		// wallet fees for the transaction whilst constructing the transaction.
-		// As a temporary band aid, a check has been added above to error if
-		// swap amount does not consider tx fees.
-		err = sourceWallet.AddSendDestination(0, order.DepositAddress, amount, false)
+		// verify that the order was completed successfully from the
+		// blockchain explorer
+		explorer, err := blockexplorer.NewExplorer(blockexplorer.Config{
+			EnableOutput: false,
+			Symbol:       symbol,
+		})
 		if err != nil {
-			log.Error("error adding send destination: ", err.Error())
-			return errors.E(op, err)
+			return errors.E(op, fmt.Errorf("error instantiating block explorer: %w", err))
 		}
 
-		log.Info("Order Scheduler: broadcasting tx")
-		txHash, err := sourceWallet.Broadcast(params.SpendingPassphrase, "")
+		log.Infof("Order Scheduler: verifying transaction with ID: %s", orderInfo.TxID)
+		verification, err := explorer.VerifyTransaction(blockexplorer.TxVerifyRequest{
+			TxId:      orderInfo.TxID,
+			Amount:    orderInfo.ReceiveAmount,
+			CreatedAt: orderInfo.CreatedAt,
+			Address:   orderInfo.DestinationAddress,
+			Confirms:  DefaultConfirmations,
+		})
 		if err != nil {
-			log.Error("error broadcasting tx: ", err.Error())
-			return errors.E(op, err)
+			return errors.E(op, fmt.Errorf("error verifying transaction: %w", err))
 		}
 
-		// wait for the order to be completed before scheduling the next order
-		var isRefunded bool
-		for {
-			// Check if scheduler has been shutdown and exit if true.
-			if mgr.InstantSwap.SchedulerCtx.Err() != nil {
-				return mgr.InstantSwap.SchedulerCtx.Err()
-			}
-
-			// depending on the block time for the asset, the order may take a while to complete
-			// so we wait for the estimated block time before checking the order status
-			switch params.Order.ToCurrency {
-			case utils.BTCWalletAsset.String():
-				log.Info("Order Scheduler: waiting for btc block time (10 minutes)")
-				time.Sleep(BTCBlockTime)
-			case utils.DCRWalletAsset.String():
-				log.Info("Order Scheduler: waiting for dcr block time (5 minutes)")
-				time.Sleep(DCRBlockTime)
-			case utils.LTCWalletAsset.String():
-				log.Info("Order Scheduler: waiting for ltc block time (~3 minutes)")
-				time.Sleep(LTCBlockTime)
-			}
-
-			log.Info("Order Scheduler: get newly created order info")
-			orderInfo, err := mgr.InstantSwap.GetOrderInfo(exchangeObject, order.UUID)
-			if err != nil {
-				return errors.E(op, err)
-			}
-
-			// If this is empty for any reason, default to the actual tx hash.
-			if orderInfo.TxID == "" {
-				orderInfo.TxID = txHash
-			}
-
-			if orderInfo.Status == api.OrderStatusRefunded {
-				log.Info("order was refunded. verifying that the order was refunded successfully from the blockchain explorer")
-				tmpFromCurrency := params.Order.FromCurrency
-				tmpToCurrency := params.Order.ToCurrency
+		if !verification.Verified {
+			continue // tx not confirmed yet, check again
+		}
 
-				// swap ToCurrency and FromCurrency
-				params.Order.ToCurrency = tmpFromCurrency
-				params.Order.FromCurrency = tmpToCurrency
+		if verification.BlockExplorerAmount.ToCoin() != orderInfo.ReceiveAmount {
+			return errors.E(op, errors.Errorf("received amount %f does not match the expected amount %f",
+				verification.BlockExplorerAmount.ToCoin(), orderInfo.ReceiveAmount))
+		}
 
-				orderInfo.ReceiveAmount = params.Order.InvoicedAmount
+		if isRefunded {
+			return errors.E(op, errors.New("order was refunded"))
+		}
 
-				isRefunded = true
-			}
+		log.Info("order was completed successfully")
+		return nil
+	}
+}
 
-			log.Info("Order Scheduler: instantiate block explorer")
-			// verify that the order was completed successfully from the blockchain explorer
-			config := blockexplorer.Config{
-				EnableOutput: false,
-				Symbol:       params.Order.ToCurrency,
-			}
-			explorer, err := blockexplorer.NewExplorer(config) // TODO: Confirm if this still works as intended
-			if err != nil {
-				log.Error("error instantiating block explorer: ", err.Error())
-				return errors.E(op, err)
-			}
+func (mgr *AssetsManager) saveScheduleExecution(execution *instantswap.ScheduleExecution) {
+	if err := mgr.InstantSwap.SaveScheduleExecution(execution); err != nil {
+		log.Errorf("Order Scheduler: error saving schedule execution: %v", err)
+	}
+	mgr.InstantSwap.PublishScheduleExecuted(execution)
+}
 
-			verificationInfo := blockexplorer.TxVerifyRequest{
-				TxId:      orderInfo.TxID,
-				Amount:    orderInfo.ReceiveAmount,
-				CreatedAt: orderInfo.CreatedAt,
-				Address:   orderInfo.DestinationAddress,
-				Confirms:  DefaultConfirmations,
-			}
+func (mgr *AssetsManager) updateScheduleStatus(schedule *instantswap.Schedule, status instantswap.ScheduleStatus, reason string) {
+	log.Infof("Order Scheduler: schedule %q %s: %s", schedule.Name, status, reason)
+	schedule.Status, schedule.StatusReason = status, reason
+	if err := mgr.InstantSwap.UpdateSchedule(schedule); err != nil {
+		log.Errorf("Order Scheduler: error updating schedule %q: %v", schedule.Name, err)
+	}
+}
 
-			log.Infof("Order Scheduler: verifying transaction with ID: %s", orderInfo.TxID)
-			verification, err := explorer.VerifyTransaction(verificationInfo)
-			if err != nil {
-				log.Error("error verifying transaction: ", err.Error())
-				return errors.E(op, err)
-			}
+// coinToAtoms converts a coin amount of the provided asset to its smallest
+// unit.
+func coinToAtoms(assetType utils.AssetType, amount float64) (int64, error) {
+	switch assetType {
+	case utils.BTCWalletAsset:
+		return btc.AmountSatoshi(amount), nil
+	case utils.DCRWalletAsset:
+		return dcr.AmountAtom(amount), nil
+	case utils.LTCWalletAsset:
+		return ltc.AmountLitoshi(amount), nil
+	}
+	return 0, fmt.Errorf("unsupported asset type %s", assetType)
+}
 
-			if verification.Verified {
-				if verification.BlockExplorerAmount.ToCoin() != orderInfo.ReceiveAmount {
-					log.Infof("received amount: %f", verification.BlockExplorerAmount.ToCoin())
-					log.Infof("expected amount: %f", orderInfo.ReceiveAmount)
-					log.Error("received amount does not match the expected amount")
-					return errors.E(op, err)
-				}
-
-				if isRefunded {
-					log.Info("order was refunded successfully")
-				} else {
-					log.Info("order was completed successfully")
-				}
-
-				break // order is completed, break out of the loop
-			}
+// instantSwapMarketRate returns the market rate source rate for 1 unit of
+// fromCur in units of toCur. Zero is returned if the rate is not available.
+func (mgr *AssetsManager) instantSwapMarketRate(fromCur, toCur string) float64 {
+	market := values.NewMarket(fromCur, toCur)
+	ticker := mgr.RateSource.GetTicker(market, false)
+	if ticker == nil || ticker.LastTradePrice <= 0 {
+		return 0
+	}
 
-			continue // order is not completed, check again
-		}
+	// Current rate source supported Binance and Bittrex always returns
+	// ticker.LastTradePrice in's the quote asset unit e.g DCR-BTC, LTC-BTC.
+	// We will also do this when and if USDT is supported.
+	if strings.EqualFold(fromCur, "btc") {
+		return 1 / ticker.LastTradePrice
 	}
+	return ticker.LastTradePrice
 }
 
-// StopScheduler stops the order scheduler.
-func (mgr *AssetsManager) StopScheduler() {
-	mgr.InstantSwap.CancelOrderSchedulerMu.RLock()
-	if mgr.InstantSwap.CancelOrderScheduler != nil {
-		mgr.InstantSwap.CancelOrderScheduler()
-		mgr.InstantSwap.CancelOrderScheduler = nil
+// assetBlockTime returns the average block time of the network of the
+// provided currency.
+func assetBlockTime(currency string) time.Duration {
+	switch strings.ToUpper(currency) {
+	case utils.BTCWalletAsset.String():
+		return BTCBlockTime
+	case utils.DCRWalletAsset.String():
+		return DCRBlockTime
+	case utils.LTCWalletAsset.String():
+		return LTCBlockTime
 	}
-	mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
-	log.Info("Order Scheduler: stopped")
+	return BTCBlockTime
 }
 
-// IsOrderSchedulerRunning returns true if the order scheduler is running.
-func (mgr *AssetsManager) IsOrderSchedulerRunning() bool {
-	mgr.InstantSwap.CancelOrderSchedulerMu.RLock()
-	defer mgr.InstantSwap.CancelOrderSchedulerMu.RUnlock()
-	return mgr.InstantSwap.CancelOrderScheduler != nil
-}
+// InstantSwapQuotes requests quotes for swapping amount fromCur to toCur from
+// all the configured exchange servers and returns them ranked from best to
+// worst. Quotes that deviate from the market rate by more than
+// DefaultMarketDeviation are flagged.
+func (mgr *AssetsManager) InstantSwapQuotes(ctx context.Context, fromCur, toCur string, amount float64) []*instantswap.Quote {
+	if amount <= 0 {
+		amount = DefaultRateRequestAmt(fromCur)
+	}
 
-// GetSchedulerRuntime returns the duration the order scheduler has been
-// running.
-func (mgr *AssetsManager) GetSchedulerRuntime() string {
-	return time.Since(mgr.InstantSwap.SchedulerStartTime).Round(time.Second).String()
+	return mgr.InstantSwap.GetQuotes(ctx, instantswap.QuoteRequest{
+		From:         fromCur,
+		To:           toCur,
+		Amount:       amount,
+		MarketRate:   mgr.instantSwapMarketRate(fromCur, toCur),
+		MaxDeviation: DefaultMarketDeviation,
+		NetworkTime:  (assetBlockTime(fromCur) + assetBlockTime(toCur)) * DefaultConfirmations,
+	})
 }
@@ -2,4 +2,6 @@ package instantswap
 
 const (
 	ErrListenerAlreadyExist = "listener_already_exist"
+	ErrScheduleExists       = "schedule_exists"
+	ErrScheduleRunning      = "schedule_running"
 )
@@ -66,6 +66,16 @@ func NewInstantSwap(db *storm.DB) (*InstantSwap, error) {
 		return nil, err
 	}
 
+	if err := db.Init(&Schedule{}); err != nil {
+		log.Errorf("Error initializing instantSwap schedules database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&ScheduleExecution{}); err != nil {
+		log.Errorf("Error initializing instantSwap schedule executions database: %s", err.Error())
+		return nil, err
+	}
+
 	// TODO: Callers should provide a ctx that is tied to the lifetime of the
 	// app, since InstantSwap is not tied to any single page. If it is tied to a
 	// specific page, then that page's ctx should be provided.
@@ -75,6 +85,8 @@ func NewInstantSwap(db *storm.DB) (*InstantSwap, error) {
 		db:  db,
 		ctx: ctx,
 
+		RunningSchedules: make(map[int]context.CancelFunc),
+
 		notificationListenersMu: &sync.RWMutex{},
 		notificationListeners:   make(map[string]*OrderNotificationListener),
 	}, nil
@@ -259,18 +271,36 @@ func (instantSwap *InstantSwap) GetOrderInfo(exchangeObject instantswap.IDExchan
 		return nil, errors.E(op, err)
 	}
 
+	// Reload the order, its verification may have been saved while the
+	// exchange was queried.
+	instantSwap.ordersMu.Lock()
+	order, err = instantSwap.GetOrderByUUIDRaw(orderUUID)
+	if err != nil {
+		instantSwap.ordersMu.Unlock()
+		return nil, errors.E(op, err)
+	}
+
+	previousStatus := order.Status
 	order.TxID = res.TxID
 	order.ReceiveAmount = res.ReceiveAmount
 	order.Status = res.InternalStatus
 	order.ExpiryTime = res.Expires
 	order.Confirmations = res.Confirmations
 	order.LastUpdate = res.LastUpdate
+	if order.Status == instantswap.OrderStatusCompleted && order.CompletedAt == 0 {
+		order.CompletedAt = time.Now().Unix()
+	}
 
 	err = instantSwap.updateOrder(order)
+	instantSwap.ordersMu.Unlock()
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
+	if order.Status != previousStatus {
+		instantSwap.publishOrderStatusChanged(order, previousStatus)
+	}
+
 	return order, nil
 }
 
@@ -0,0 +1,169 @@
+package instantswap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-power/instantswap/instantswap"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/testutil"
+)
+
+// orderInfoExchange answers OrderInfo requests with res, after running
+// during.
+type orderInfoExchange struct {
+	instantswap.IDExchange
+	res    instantswap.OrderInfoResult
+	during func()
+}
+
+func (exchange *orderInfoExchange) OrderInfo(string, ...string) (instantswap.OrderInfoResult, error) {
+	if exchange.during != nil {
+		exchange.during()
+	}
+	return exchange.res, nil
+}
+
+func TestOrderUpdatesInterleaved(t *testing.T) {
+	instantSwap, err := NewInstantSwap(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const uuid = "order-1"
+	err = instantSwap.saveOrder(&Order{
+		UUID:          uuid,
+		ReceiveAmount: 10,
+		Status:        instantswap.OrderStatusWaitingForDeposit,
+		Verification:  OrderVerification{DepositTxID: "deposit-tx"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The watcher holds a copy of the order loaded before the exchange
+	// reports it completed.
+	watched, err := instantSwap.GetOrderByUUIDRaw(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exchange := &orderInfoExchange{
+		res: instantswap.OrderInfoResult{
+			TxID:           "exchange-tx",
+			ReceiveAmount:  10,
+			InternalStatus: instantswap.OrderStatusCompleted,
+		},
+		// The payout is seen while the exchange is queried.
+		during: func() {
+			watched.Verification.PayoutTxID = "payout-tx"
+			if err := instantSwap.SaveOrderVerification(watched); err != nil {
+				t.Error(err)
+			}
+		},
+	}
+	order, err := instantSwap.GetOrderInfo(exchange, uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Verification.PayoutTxID != "payout-tx" {
+		t.Errorf("status refresh overwrote the payout seen during the refresh: %+v", order.Verification)
+	}
+
+	// The payout is evaluated after the status refresh, on the watcher's
+	// copy of the order that still has the status of the deposit time.
+	watched.Status = instantswap.OrderStatusWaitingForDeposit
+	watched.Verification.Status = PayoutReceived
+	if err := instantSwap.SaveOrderVerification(watched); err != nil {
+		t.Fatal(err)
+	}
+	if watched.Status != instantswap.OrderStatusCompleted {
+		t.Errorf("expected the saved verification to refresh the order status, got %s", watched.Status)
+	}
+
+	order, err = instantSwap.GetOrderByUUIDRaw(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != instantswap.OrderStatusCompleted || order.TxID != "exchange-tx" || order.CompletedAt == 0 {
+		t.Errorf("exchange status lost: status %s, tx %q, completed at %d", order.Status, order.TxID, order.CompletedAt)
+	}
+	if order.Verification.DepositTxID != "deposit-tx" || order.Verification.PayoutTxID != "payout-tx" ||
+		order.Verification.Status != PayoutReceived {
+		t.Errorf("verification lost: %+v", order.Verification)
+	}
+}
+
+func TestUpdateScheduleProgressKeepsStatus(t *testing.T) {
+	instantSwap, err := NewInstantSwap(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	running := &Schedule{
+		Name:       "weekly",
+		Order:      Order{FromCurrency: "DCR", ToCurrency: "BTC", ExchangeServer: ExchangeServer{Server: "changenow"}},
+		Frequency:  time.Hour,
+		AmountMode: AmountFixed,
+		Amount:     1,
+	}
+	if err := instantSwap.SaveSchedule(running); err != nil {
+		t.Fatal(err)
+	}
+
+	// The schedule is paused while its run still holds the active copy.
+	paused, err := instantSwap.Schedule(running.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paused.Status = SchedulePaused
+	if err := instantSwap.UpdateSchedule(paused); err != nil {
+		t.Fatal(err)
+	}
+
+	running.Failures = 0
+	running.OrdersCount = 1
+	running.TotalAmount = 1
+	running.NextRun = running.CreatedAt + 3600
+	if err := instantSwap.UpdateScheduleProgress(running); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := instantSwap.Schedule(running.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != SchedulePaused {
+		t.Errorf("expected the schedule to stay paused, got %s", stored.Status)
+	}
+	if stored.OrdersCount != 1 || stored.TotalAmount != 1 || stored.NextRun != running.NextRun {
+		t.Errorf("progress not saved: %+v", stored)
+	}
+}
+
+func TestScheduleValidateFrequency(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency time.Duration
+		valid     bool
+	}{
+		{name: "zero", frequency: 0},
+		{name: "negative", frequency: -time.Hour},
+		{name: "below minimum", frequency: MinScheduleFrequency - time.Second},
+		{name: "minimum", frequency: MinScheduleFrequency, valid: true},
+		{name: "daily", frequency: 24 * time.Hour, valid: true},
+	}
+
+	for _, tc := range tests {
+		schedule := &Schedule{
+			Name:       "schedule",
+			Order:      Order{FromCurrency: "DCR", ToCurrency: "BTC", ExchangeServer: ExchangeServer{Server: "changenow"}},
+			Frequency:  tc.frequency,
+			AmountMode: AmountFixed,
+			Amount:     1,
+		}
+		if err := schedule.Validate(); (err == nil) != tc.valid {
+			t.Errorf("(%v), expected valid (%v), got (%v)", tc.name, tc.valid, err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package instantswap
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm/q"
+	"github.com/crypto-power/instantswap/instantswap"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// DefaultQuoteTimeout is the maximum time spent waiting for the quote of
+	// a single exchange server.
+	DefaultQuoteTimeout = 20 * time.Second
+
+	// completionHistorySize is the number of most recent completed orders of
+	// an exchange server used to estimate its completion time.
+	completionHistorySize = 10
+)
+
+// QuoteRequest describes the swap for which quotes are requested from the
+// exchange servers.
+type QuoteRequest struct {
+	From   string
+	To     string
+	Amount float64
+
+	// Timeout is the maximum time spent waiting for each exchange server.
+	// DefaultQuoteTimeout is used if not set.
+	Timeout time.Duration
+	// MarketRate is the rate reported by the market rate source for 1 unit
+	// of From. Quotes are not checked for deviation if it is not set.
+	MarketRate float64
+	// MaxDeviation is the maximum percentage by which a quoted rate may
+	// deviate from MarketRate before the quote is flagged.
+	MaxDeviation float64
+	// NetworkTime is the estimated time required to confirm the deposit and
+	// the payout on their respective networks. It is used as the completion
+	// time of servers with no completed orders.
+	NetworkTime time.Duration
+}
+
+// Quote is the rate offered by an exchange server for a QuoteRequest.
+type Quote struct {
+	Server      ExchangeServer
+	FromNetwork string
+	ToNetwork   string
+	RateInfo    instantswap.ExchangeRateInfo
+
+	// ReceiveAmount is the amount of To expected for the requested amount
+	// after the exchange fees.
+	ReceiveAmount float64
+	// WithinLimits is false if the requested amount is outside the min/max
+	// limits of the server.
+	WithinLimits bool
+	// EstimatedTime is the estimated time until the swap completes.
+	EstimatedTime time.Duration
+	// Deviation is the percentage difference between the quoted rate and the
+	// market rate. It is negative if the market rate is unknown.
+	Deviation float64
+	// Deviates is true if Deviation exceeds the requested MaxDeviation.
+	Deviates bool
+
+	Err error
+}
+
+// GetQuotes concurrently requests a quote from every configured exchange
+// server and returns them ranked from best to worst by RankQuotes. Servers
+// that fail or do not respond within req.Timeout are returned last with Err
+// set.
+func (instantSwap *InstantSwap) GetQuotes(ctx context.Context, req QuoteRequest) []*Quote {
+	if req.Timeout <= 0 {
+		req.Timeout = DefaultQuoteTimeout
+	}
+
+	exchangeServers := instantSwap.ExchangeServers()
+	quotes := make([]*Quote, len(exchangeServers))
+
+	var wg sync.WaitGroup
+	for i, exchangeServer := range exchangeServers {
+		wg.Add(1)
+		go func(i int, exchangeServer ExchangeServer) {
+			defer wg.Done()
+			quotes[i] = instantSwap.quote(ctx, exchangeServer, req)
+		}(i, exchangeServer)
+	}
+	wg.Wait()
+
+	RankQuotes(quotes)
+	return quotes
+}
+
+// quote requests a quote from a single exchange server. The exchange APIs do
+// not accept a context so the request is abandoned, not aborted, on timeout.
+func (instantSwap *InstantSwap) quote(ctx context.Context, exchangeServer ExchangeServer, req QuoteRequest) *Quote {
+	const op errors.Op = "instantSwap.quote"
+
+	ctx, cancel := context.WithTimeout(ctx, req.Timeout)
+	defer cancel()
+
+	quoteC := make(chan *Quote, 1)
+	go func() {
+		quote := &Quote{Server: exchangeServer, Deviation: -1}
+		exchangeObject, err := instantSwap.NewExchangeServer(exchangeServer)
+		if err != nil {
+			quote.Err = err
+			quoteC <- quote
+			return
+		}
+
+		// Network names are exchange specific, a failure to fetch them is
+		// not fatal as most servers accept an empty network.
+		currencies, err := exchangeObject.GetCurrencies()
+		if err != nil {
+			log.Debugf("Error fetching %s currencies: %v", exchangeServer.Server, err)
+		}
+		quote.FromNetwork = CurrencyNetwork(req.From, currencies)
+		quote.ToNetwork = CurrencyNetwork(req.To, currencies)
+
+		quote.RateInfo, err = exchangeObject.GetExchangeRateInfo(instantswap.ExchangeRateRequest{
+			From:        req.From,
+			FromNetwork: quote.FromNetwork,
+			To:          req.To,
+			ToNetwork:   quote.ToNetwork,
+			Amount:      req.Amount,
+		})
+		if err != nil {
+			quote.Err = errors.E(op, err)
+		}
+		quoteC <- quote
+	}()
+
+	var quote *Quote
+	select {
+	case quote = <-quoteC:
+	case <-ctx.Done():
+		return &Quote{Server: exchangeServer, Deviation: -1, Err: errors.E(op, ctx.Err())}
+	}
+
+	if quote.Err != nil {
+		return quote
+	}
+
+	info := quote.RateInfo
+	quote.ReceiveAmount = info.EstimatedAmount
+	if quote.ReceiveAmount <= 0 {
+		quote.ReceiveAmount = info.ExchangeRate * req.Amount
+	}
+	quote.WithinLimits = req.Amount >= info.Min && (info.Max == 0 || req.Amount <= info.Max)
+
+	if req.MarketRate > 0 && req.Amount > 0 {
+		rate := quote.ReceiveAmount / req.Amount
+		quote.Deviation = math.Abs((rate-req.MarketRate)/((rate+req.MarketRate)/2)) * 100
+		quote.Deviates = req.MaxDeviation > 0 && quote.Deviation > req.MaxDeviation
+	}
+
+	quote.EstimatedTime = req.NetworkTime
+	if completionTime := instantSwap.averageCompletionTime(exchangeServer.Server); completionTime > 0 {
+		quote.EstimatedTime = completionTime
+	}
+
+	return quote
+}
+
+// averageCompletionTime returns the average time taken by the most recent
+// completed orders of a server. Zero is returned if the server has no
+// completed orders with a recorded completion time.
+func (instantSwap *InstantSwap) averageCompletionTime(server Server) time.Duration {
+	var orders []*Order
+	err := instantSwap.db.Select(q.Eq("Status", instantswap.OrderStatusCompleted), q.Gt("CompletedAt", 0)).
+		OrderBy("CreatedAt").Reverse().Find(&orders)
+	if err != nil {
+		return 0
+	}
+
+	var total time.Duration
+	var count int
+	for _, order := range orders {
+		if order.ExchangeServer.Server != server || order.CompletedAt < order.CreatedAt {
+			continue
+		}
+		total += time.Duration(order.CompletedAt-order.CreatedAt) * time.Second
+		count++
+		if count == completionHistorySize {
+			break
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// RankQuotes sorts quotes from best to worst. Successful quotes come first,
+// then quotes for which the requested amount is within the server limits and
+// that do not deviate from the market rate. Quotes in the same group are
+// ranked by the amount received, then by estimated completion time.
+func RankQuotes(quotes []*Quote) {
+	group := func(quote *Quote) int {
+		switch {
+		case quote.Err != nil:
+			return 3
+		case !quote.WithinLimits:
+			return 2
+		case quote.Deviates:
+			return 1
+		}
+		return 0
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		a, b := quotes[i], quotes[j]
+		if groupA, groupB := group(a), group(b); groupA != groupB {
+			return groupA < groupB
+		}
+		if a.ReceiveAmount != b.ReceiveAmount {
+			return a.ReceiveAmount > b.ReceiveAmount
+		}
+		if a.EstimatedTime != b.EstimatedTime {
+			return a.EstimatedTime < b.EstimatedTime
+		}
+		return a.Server.Server < b.Server.Server
+	})
+}
+
+// CurrencyNetwork returns the network name an exchange uses for the currency
+// with the provided symbol. The mainnet network is preferred if the currency
+// is available on several networks.
+func CurrencyNetwork(symbol string, currencies []instantswap.Currency) string {
+	lowerSymbol := strings.ToLower(symbol)
+	for _, currency := range currencies {
+		if strings.ToLower(currency.Symbol) != lowerSymbol || len(currency.Networks) == 0 {
+			continue
+		}
+
+		for _, network := range currency.Networks {
+			lowerNetwork := strings.ToLower(network)
+			if lowerNetwork == string(utils.Mainnet) || lowerNetwork == lowerSymbol {
+				return network
+			}
+		}
+		return currency.Networks[0]
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package instantswap
+
+import (
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+// AmountMode determines how the amount swapped by each run of a schedule is
+// computed.
+type AmountMode string
+
+const (
+	// AmountFixed swaps Schedule.Amount coins on each run.
+	AmountFixed AmountMode = "fixed"
+	// AmountPercentage swaps Schedule.Amount percent of the spendable
+	// balance of the source account on each run.
+	AmountPercentage AmountMode = "percentage"
+	// AmountAboveThreshold swaps the spendable balance of the source account
+	// in excess of Schedule.Amount coins on each run.
+	AmountAboveThreshold AmountMode = "above_threshold"
+)
+
+// ScheduleStatus is the persisted state of a schedule.
+type ScheduleStatus string
+
+const (
+	// ScheduleActive schedules run when their next run is due. Active
+	// schedules are resumed at startup once the spending passphrase of the
+	// source wallet is entered.
+	ScheduleActive ScheduleStatus = "active"
+	// SchedulePaused schedules were stopped by the user or by repeated
+	// failures and can be resumed.
+	SchedulePaused ScheduleStatus = "paused"
+	// ScheduleEnded schedules have reached one of their end conditions.
+	ScheduleEnded ScheduleStatus = "ended"
+)
+
+const (
+	// MaxScheduleFailures is the number of consecutive failed runs after
+	// which a schedule is paused.
+	MaxScheduleFailures = 3
+
+	// MinScheduleFrequency is the shortest time allowed between the runs of
+	// a schedule.
+	MinScheduleFrequency = 10 * time.Minute
+)
+
+// Schedule is a named, persisted recurring swap.
+type Schedule struct {
+	ID   int    `storm:"id,increment"`
+	Name string `storm:"unique"`
+
+	// Order holds the exchange server, wallets, accounts, currencies and
+	// addresses used for the orders created by the schedule.
+	Order Order
+
+	Frequency  time.Duration
+	AmountMode AmountMode
+	Amount     float64
+	// MaxDeviationRate is the maximum deviation rate allowed between the
+	// exchange server rate and the market rate. A run is skipped if the
+	// deviation is greater.
+	MaxDeviationRate float64
+
+	// The schedule ends when any of the set end conditions is reached.
+	MaxOrders   int     // maximum number of orders, 0 for no limit
+	MaxTotal    float64 // maximum total amount swapped, 0 for no limit
+	EndTime     int64   // unix time after which no order is created, 0 for no limit
+	OrdersCount int
+	TotalAmount float64
+
+	Status       ScheduleStatus `storm:"index"`
+	StatusReason string
+	Failures     int   // consecutive failed runs
+	NextRun      int64 // unix time of the next run
+	CreatedAt    int64 `storm:"index"`
+}
+
+// ScheduleExecution records a run of a schedule.
+type ScheduleExecution struct {
+	ID         int   `storm:"id,increment"`
+	ScheduleID int   `storm:"index"`
+	Stamp      int64 `storm:"index"`
+
+	OrderUUID      string
+	InvoicedAmount float64
+	TxHash         string
+	Error          string
+}
+
+// Validate checks that the schedule parameters are usable.
+func (schedule *Schedule) Validate() error {
+	switch {
+	case strings.TrimSpace(schedule.Name) == "":
+		return errors.New("schedule name is required")
+	case schedule.Order.FromCurrency == "" || schedule.Order.ToCurrency == "":
+		return errors.New("schedule currencies are required")
+	case schedule.Order.ExchangeServer.Server == "":
+		return errors.New("schedule exchange server is required")
+	case schedule.Frequency < MinScheduleFrequency:
+		return errors.Errorf("schedule frequency cannot be less than %s", MinScheduleFrequency)
+	case schedule.MaxOrders < 0 || schedule.MaxTotal < 0 || schedule.EndTime < 0:
+		return errors.New("schedule end conditions cannot be negative")
+	}
+
+	switch schedule.AmountMode {
+	case AmountFixed:
+		if schedule.Amount <= 0 {
+			return errors.New("schedule amount must be positive")
+		}
+	case AmountPercentage:
+		if schedule.Amount <= 0 || schedule.Amount > 100 {
+			return errors.New("schedule percentage must be between 0 and 100")
+		}
+	case AmountAboveThreshold:
+		if schedule.Amount < 0 {
+			return errors.New("schedule balance threshold cannot be negative")
+		}
+	default:
+		return errors.Errorf("unknown schedule amount mode %q", schedule.AmountMode)
+	}
+	return nil
+}
+
+// EndReason returns the end condition reached by the schedule at time now, or
+// an empty string if the schedule can keep running.
+func (schedule *Schedule) EndReason(now time.Time) string {
+	switch {
+	case schedule.MaxOrders > 0 && schedule.OrdersCount >= schedule.MaxOrders:
+		return "maximum number of orders reached"
+	case schedule.MaxTotal > 0 && schedule.TotalAmount >= schedule.MaxTotal:
+		return "maximum total amount reached"
+	case schedule.EndTime > 0 && now.Unix() >= schedule.EndTime:
+		return "end time reached"
+	}
+	return ""
+}
+
+// SaveSchedule creates a new active schedule that runs for the first time
+// immediately.
+func (instantSwap *InstantSwap) SaveSchedule(schedule *Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	schedule.ID = 0
+	schedule.Status = ScheduleActive
+	schedule.CreatedAt = now
+	schedule.NextRun = now
+	err := instantSwap.db.Save(schedule)
+	if err == storm.ErrAlreadyExists {
+		return errors.New(ErrScheduleExists)
+	}
+	return err
+}
+
+// UpdateSchedule saves the changes made to an existing schedule.
+func (instantSwap *InstantSwap) UpdateSchedule(schedule *Schedule) error {
+	return instantSwap.db.Save(schedule)
+}
+
+// UpdateScheduleProgress saves the run counters and the next run of a running
+// schedule. The stored status is kept, the schedule may have been paused while
+// the run was in progress.
+func (instantSwap *InstantSwap) UpdateScheduleProgress(schedule *Schedule) error {
+	dbTx, err := instantSwap.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	fields := map[string]interface{}{
+		"Failures":    schedule.Failures,
+		"OrdersCount": schedule.OrdersCount,
+		"TotalAmount": schedule.TotalAmount,
+		"NextRun":     schedule.NextRun,
+	}
+	for field, value := range fields {
+		if err := dbTx.UpdateField(&Schedule{ID: schedule.ID}, field, value); err != nil {
+			return err
+		}
+	}
+	return dbTx.Commit()
+}
+
+// Schedules returns all saved schedules, newest first.
+func (instantSwap *InstantSwap) Schedules() ([]*Schedule, error) {
+	var schedules []*Schedule
+	err := instantSwap.db.Select(q.True()).OrderBy("CreatedAt").Reverse().Find(&schedules)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// Schedule returns the schedule with the provided ID.
+func (instantSwap *InstantSwap) Schedule(scheduleID int) (*Schedule, error) {
+	var schedule Schedule
+	if err := instantSwap.db.One("ID", scheduleID, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// DeleteSchedule deletes a schedule and its execution log.
+func (instantSwap *InstantSwap) DeleteSchedule(scheduleID int) error {
+	err := instantSwap.db.Select(q.Eq("ScheduleID", scheduleID)).Delete(&ScheduleExecution{})
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return instantSwap.db.DeleteStruct(&Schedule{ID: scheduleID})
+}
+
+// SaveScheduleExecution adds a run to the execution log of its schedule.
+func (instantSwap *InstantSwap) SaveScheduleExecution(execution *ScheduleExecution) error {
+	if execution.Stamp == 0 {
+		execution.Stamp = time.Now().Unix()
+	}
+	return instantSwap.db.Save(execution)
+}
+
+// ScheduleExecutions returns the most recent runs of a schedule, newest
+// first. All runs are returned if limit is 0.
+func (instantSwap *InstantSwap) ScheduleExecutions(scheduleID, limit int) ([]*ScheduleExecution, error) {
+	query := instantSwap.db.Select(q.Eq("ScheduleID", scheduleID)).OrderBy("Stamp", "ID").Reverse()
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var executions []*ScheduleExecution
+	err := query.Find(&executions)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return executions, nil
+}
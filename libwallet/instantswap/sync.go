@@ -193,24 +193,46 @@ func (instantSwap *InstantSwap) publishOrderCreated(order *Order) {
 	}
 }
 
-func (instantSwap *InstantSwap) PublishOrderSchedulerStarted() {
+func (instantSwap *InstantSwap) publishOrderStatusChanged(order *Order, previous instantswap.Status) {
 	instantSwap.notificationListenersMu.Lock()
 	defer instantSwap.notificationListenersMu.Unlock()
 
 	for _, notificationListener := range instantSwap.notificationListeners {
-		if notificationListener.OnOrderSchedulerStarted != nil {
-			notificationListener.OnOrderSchedulerStarted()
+		if notificationListener.OnOrderStatusChanged != nil {
+			notificationListener.OnOrderStatusChanged(order, previous)
 		}
 	}
 }
 
-func (instantSwap *InstantSwap) PublishOrderSchedulerEnded() {
+func (instantSwap *InstantSwap) PublishScheduleStarted(schedule *Schedule) {
 	instantSwap.notificationListenersMu.Lock()
 	defer instantSwap.notificationListenersMu.Unlock()
 
 	for _, notificationListener := range instantSwap.notificationListeners {
-		if notificationListener.OnOrderSchedulerEnded != nil {
-			notificationListener.OnOrderSchedulerEnded()
+		if notificationListener.OnScheduleStarted != nil {
+			notificationListener.OnScheduleStarted(schedule)
+		}
+	}
+}
+
+func (instantSwap *InstantSwap) PublishScheduleStopped(schedule *Schedule) {
+	instantSwap.notificationListenersMu.Lock()
+	defer instantSwap.notificationListenersMu.Unlock()
+
+	for _, notificationListener := range instantSwap.notificationListeners {
+		if notificationListener.OnScheduleStopped != nil {
+			notificationListener.OnScheduleStopped(schedule)
+		}
+	}
+}
+
+func (instantSwap *InstantSwap) PublishScheduleExecuted(execution *ScheduleExecution) {
+	instantSwap.notificationListenersMu.Lock()
+	defer instantSwap.notificationListenersMu.Unlock()
+
+	for _, notificationListener := range instantSwap.notificationListeners {
+		if notificationListener.OnScheduleExecuted != nil {
+			notificationListener.OnScheduleExecuted(execution)
 		}
 	}
 }
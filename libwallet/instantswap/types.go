@@ -3,7 +3,6 @@ package instantswap
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/asdine/storm"
 	"github.com/crypto-power/instantswap/instantswap"
@@ -56,20 +55,28 @@ type InstantSwap struct {
 	syncMu     sync.RWMutex
 	cancelSync context.CancelFunc
 
-	SchedulerCtx           context.Context
-	CancelOrderScheduler   context.CancelFunc
-	CancelOrderSchedulerMu sync.RWMutex
-	SchedulerStartTime     time.Time
+	// ordersMu serializes the updates of stored orders by the exchange
+	// status refresh and the on-chain verification, which each change their
+	// own fields of the same order.
+	ordersMu sync.Mutex
+
+	// RunningSchedules holds the cancel functions of the schedules that are
+	// currently running, keyed by schedule ID.
+	RunningSchedules   map[int]context.CancelFunc
+	RunningSchedulesMu sync.RWMutex
 
 	notificationListenersMu *sync.RWMutex // Pointer required to avoid copying literal values.
 	notificationListeners   map[string]*OrderNotificationListener
 }
 
 type OrderNotificationListener struct {
-	OnExchangeOrdersSynced  func()
-	OnOrderCreated          func(order *Order)
-	OnOrderSchedulerStarted func()
-	OnOrderSchedulerEnded   func()
+	OnExchangeOrdersSynced func()
+	OnOrderCreated         func(order *Order)
+	OnScheduleStarted      func(schedule *Schedule)
+	OnScheduleStopped      func(schedule *Schedule)
+	OnScheduleExecuted     func(execution *ScheduleExecution)
+	OnOrderVerified        func(order *Order)
+	OnOrderStatusChanged   func(order *Order, previous instantswap.Status)
 }
 
 type Order struct {
@@ -103,23 +110,13 @@ type Order struct {
 	Status        instantswap.Status `json:"status" storm:"index"`
 	ExpiryTime    int                `json:"expiryTime"` // in seconds
 	CreatedAt     int64              `storm:"index" json:"createdAt"`
-	LastUpdate    string             `json:"lastUpdate"` // should be timestamp (api currently returns string)
+	CompletedAt   int64              `json:"completedAt"` // set when the order is first seen as completed
+	LastUpdate    string             `json:"lastUpdate"`  // should be timestamp (api currently returns string)
 
 	ExtraID string `json:"extraId"` // changenow.io requirement //changelly payinExtraId value
 	UserID  string `json:"userId"`  // changenow.io partner requirement
 
 	Signature string `json:"signature"` // evercoin requirement
-}
-
-type SchedulerParams struct {
-	Order Order
-
-	Frequency         time.Duration // in hours
-	BalanceToMaintain float64
-	// MaxDeviationRate is the maximum deviation rate allowed between
-	// the exchange server rate and the market rate. If the deviation
-	// rate is greater than the MaxDeviationRate, the order is not created
-	MaxDeviationRate float64
 
-	SpendingPassphrase string
+	Verification OrderVerification `json:"verification"` // on-chain verification by the local wallets
 }
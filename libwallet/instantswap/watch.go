@@ -0,0 +1,163 @@
+package instantswap
+
+import (
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/crypto-power/instantswap/instantswap"
+)
+
+// PayoutStatus is the result of the on-chain verification of an order.
+type PayoutStatus string
+
+const (
+	// PayoutPending orders are waiting for their deposit or payout to be
+	// seen in the local wallets.
+	PayoutPending PayoutStatus = ""
+	// PayoutReceived orders were paid the expected amount.
+	PayoutReceived PayoutStatus = "received"
+	// PayoutShort orders were paid less than the expected amount.
+	PayoutShort PayoutStatus = "short"
+	// PayoutLate orders have not been paid within PayoutGracePeriod of their
+	// deposit.
+	PayoutLate PayoutStatus = "late"
+	// PayoutMissing orders are reported completed by the exchange but no
+	// payout was seen within MissingPayoutGracePeriod.
+	PayoutMissing PayoutStatus = "missing"
+	// PayoutRefunded orders had their deposit returned to the refund address.
+	PayoutRefunded PayoutStatus = "refunded"
+)
+
+const (
+	// PayoutGracePeriod is the time after the deposit is seen within which the
+	// payout is expected.
+	PayoutGracePeriod = 2 * time.Hour
+	// MissingPayoutGracePeriod is the time after the exchange reports an
+	// order completed within which the payout is expected.
+	MissingPayoutGracePeriod = 30 * time.Minute
+	// ShortPayoutTolerance is the fraction of the expected amount by which a
+	// payout may fall short before it is flagged.
+	ShortPayoutTolerance = 0.02
+)
+
+// OrderVerification holds the transactions of an order seen in the local
+// wallets.
+type OrderVerification struct {
+	DepositTxID string `json:"depositTxID"`
+	DepositAt   int64  `json:"depositAt"`
+
+	PayoutTxID   string  `json:"payoutTxID"`
+	PayoutAmount float64 `json:"payoutAmount"`
+	PayoutAt     int64   `json:"payoutAt"`
+
+	RefundTxID   string  `json:"refundTxID"`
+	RefundAmount float64 `json:"refundAmount"`
+
+	Status PayoutStatus `json:"status"`
+}
+
+// IsFlagged returns true if the payout of the order is late, short or
+// missing.
+func (verification *OrderVerification) IsFlagged() bool {
+	switch verification.Status {
+	case PayoutLate, PayoutShort, PayoutMissing:
+		return true
+	}
+	return false
+}
+
+// isFinal returns true if no further transaction is expected for the order.
+func (verification *OrderVerification) isFinal() bool {
+	switch verification.Status {
+	case PayoutReceived, PayoutShort, PayoutRefunded:
+		return true
+	}
+	return false
+}
+
+// EvaluatePayout updates the payout status of the order at time now from the
+// transactions recorded in its verification. It returns true if the status
+// changed.
+func (order *Order) EvaluatePayout(now time.Time) bool {
+	verification := &order.Verification
+	status := verification.Status
+
+	switch {
+	case verification.RefundTxID != "":
+		status = PayoutRefunded
+	case verification.PayoutTxID != "":
+		status = PayoutReceived
+		if order.ReceiveAmount > 0 && verification.PayoutAmount < order.ReceiveAmount*(1-ShortPayoutTolerance) {
+			status = PayoutShort
+		}
+	case order.Status == instantswap.OrderStatusCompleted && order.CompletedAt > 0 &&
+		now.Sub(time.Unix(order.CompletedAt, 0)) > MissingPayoutGracePeriod:
+		status = PayoutMissing
+	case verification.DepositAt > 0 && now.Sub(time.Unix(verification.DepositAt, 0)) > PayoutGracePeriod &&
+		order.Status != instantswap.OrderStatusRefunded:
+		status = PayoutLate
+	}
+
+	if status == verification.Status {
+		return false
+	}
+	verification.Status = status
+	return true
+}
+
+// WatchedOrders returns the orders that are still waiting for their deposit,
+// payout or refund to be verified.
+func (instantSwap *InstantSwap) WatchedOrders() ([]*Order, error) {
+	var orders []*Order
+	if err := instantSwap.db.All(&orders); err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+
+	watched := make([]*Order, 0, len(orders))
+	for _, order := range orders {
+		if order.Verification.isFinal() {
+			continue
+		}
+		switch order.Status {
+		case instantswap.OrderStatusExpired, instantswap.OrderStatusFailed:
+			// Nothing was deposited or the deposit is handled by the
+			// exchange support.
+			if order.Verification.DepositTxID == "" {
+				continue
+			}
+		}
+		watched = append(watched, order)
+	}
+	return watched, nil
+}
+
+// SaveOrderVerification saves the verification of an order and notifies the
+// listeners. The other fields of the stored order are kept and copied to
+// order, which may be older than the stored order.
+func (instantSwap *InstantSwap) SaveOrderVerification(order *Order) error {
+	instantSwap.ordersMu.Lock()
+	stored, err := instantSwap.GetOrderByUUIDRaw(order.UUID)
+	if err == nil {
+		stored.Verification = order.Verification
+		err = instantSwap.updateOrder(stored)
+	}
+	instantSwap.ordersMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	*order = *stored
+	instantSwap.publishOrderVerified(order)
+	return nil
+}
+
+func (instantSwap *InstantSwap) publishOrderVerified(order *Order) {
+	instantSwap.notificationListenersMu.Lock()
+	defer instantSwap.notificationListenersMu.Unlock()
+
+	for _, notificationListener := range instantSwap.notificationListeners {
+		if notificationListener.OnOrderVerified != nil {
+			notificationListener.OnOrderVerified(order)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package libwallet
+
+import (
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// orderWatcherPageSize is the number of wallet transactions fetched at a time
+// when scanning the history of a wallet for order transactions.
+const orderWatcherPageSize = 50
+
+// subscribeOrderWatcher starts verifying the instantswap orders against the
+// transactions of the local wallets. The deposit of an order is matched in its
+// source wallet, the payout to DestinationAddress in its destination wallet and
+// refunds to RefundAddress in its source wallet. Orders whose payout is late,
+// short or missing are flagged as the wallets attach new blocks.
+func (mgr *AssetsManager) subscribeOrderWatcher() *eventSubscriber {
+	go func() {
+		mgr.scanOrderTxs()
+		mgr.evaluateOrderPayouts()
+	}()
+
+	return &eventSubscriber{
+		onTransaction: mgr.matchOrderTx,
+		onBlockAttached: func(int, int32) {
+			mgr.evaluateOrderPayouts()
+		},
+	}
+}
+
+// scanOrderTxs matches the watched orders against the transactions received
+// by their wallets while the watcher was not running.
+func (mgr *AssetsManager) scanOrderTxs() {
+	orders, err := mgr.InstantSwap.WatchedOrders()
+	if err != nil {
+		log.Errorf("Order watcher: error loading orders: %v", err)
+		return
+	}
+
+	// The oldest order of each wallet determines how far back its history is
+	// scanned.
+	since := make(map[int]int64)
+	for _, order := range orders {
+		for _, walletID := range []int{order.SourceWalletID, order.DestinationWalletID} {
+			if stamp, ok := since[walletID]; !ok || order.CreatedAt < stamp {
+				since[walletID] = order.CreatedAt
+			}
+		}
+	}
+
+	for walletID, stamp := range since {
+		wallet := mgr.WalletWithID(walletID)
+		if wallet == nil {
+			continue
+		}
+
+		for offset := int32(0); ; offset += orderWatcherPageSize {
+			txs, err := wallet.GetTransactionsRaw(offset, orderWatcherPageSize, utils.TxFilterAll, true, "")
+			if err != nil {
+				log.Errorf("Order watcher: error loading %s wallet transactions: %v", wallet.GetWalletName(), err)
+				break
+			}
+
+			for _, tx := range txs {
+				if tx.Timestamp >= stamp {
+					mgr.matchOrderTx(walletID, tx)
+				}
+			}
+
+			if len(txs) < orderWatcherPageSize || txs[len(txs)-1].Timestamp < stamp {
+				break
+			}
+		}
+	}
+}
+
+// matchOrderTx records tx as the deposit, payout or refund of the watched
+// orders it pays to.
+func (mgr *AssetsManager) matchOrderTx(walletID int, tx *sharedW.Transaction) {
+	mgr.orderWatcherMu.Lock()
+	defer mgr.orderWatcherMu.Unlock()
+
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return
+	}
+
+	orders, err := mgr.InstantSwap.WatchedOrders()
+	if err != nil {
+		log.Errorf("Order watcher: error loading orders: %v", err)
+		return
+	}
+
+	for _, order := range orders {
+		if tx.Timestamp < order.CreatedAt {
+			continue
+		}
+
+		verification := &order.Verification
+		var matched bool
+		switch walletID {
+		case order.SourceWalletID:
+			if verification.DepositTxID == "" && txPaysTo(tx, order.DepositAddress) > 0 {
+				verification.DepositTxID, verification.DepositAt = tx.Hash, tx.Timestamp
+				matched = true
+			} else if verification.RefundTxID == "" && tx.Hash != verification.DepositTxID {
+				if amount := txPaysTo(tx, order.RefundAddress); amount > 0 {
+					verification.RefundTxID = tx.Hash
+					verification.RefundAmount = wallet.ToAmount(amount).ToCoin()
+					matched = true
+				}
+			}
+
+		case order.DestinationWalletID:
+			if verification.PayoutTxID == "" {
+				if amount := txPaysTo(tx, order.DestinationAddress); amount > 0 {
+					verification.PayoutTxID, verification.PayoutAt = tx.Hash, tx.Timestamp
+					verification.PayoutAmount = wallet.ToAmount(amount).ToCoin()
+					matched = true
+				}
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		log.Infof("Order watcher: matched tx %s to order %s", tx.Hash, order.UUID)
+		order.EvaluatePayout(time.Now())
+		mgr.saveOrderVerification(order)
+	}
+}
+
+// evaluateOrderPayouts flags the watched orders whose payout is late or
+// missing.
+func (mgr *AssetsManager) evaluateOrderPayouts() {
+	mgr.orderWatcherMu.Lock()
+	defer mgr.orderWatcherMu.Unlock()
+
+	orders, err := mgr.InstantSwap.WatchedOrders()
+	if err != nil {
+		log.Errorf("Order watcher: error loading orders: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, order := range orders {
+		if order.EvaluatePayout(now) {
+			mgr.saveOrderVerification(order)
+		}
+	}
+}
+
+func (mgr *AssetsManager) saveOrderVerification(order *instantswap.Order) {
+	if err := mgr.InstantSwap.SaveOrderVerification(order); err != nil {
+		log.Errorf("Order watcher: error saving order %s: %v", order.UUID, err)
+		return
+	}
+
+	if order.Verification.IsFlagged() {
+		log.Warnf("Order watcher: payout of order %s is %s", order.UUID, order.Verification.Status)
+		if mgr.toast != nil {
+			mgr.toast.NotifyError(values.StringF(values.StrOrderPayoutFlagged, order.UUID, order.Verification.Status))
+		}
+	}
+}
+
+// txPaysTo returns the total amount paid by tx to address.
+func txPaysTo(tx *sharedW.Transaction, address string) int64 {
+	if address == "" {
+		return 0
+	}
+
+	var amount int64
+	for _, output := range tx.Outputs {
+		if output.Address == address {
+			amount += output.Amount
+		}
+	}
+	return amount
+}
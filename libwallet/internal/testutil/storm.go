@@ -0,0 +1,22 @@
+// Package testutil provides helpers shared by the libwallet package tests.
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/asdine/storm"
+)
+
+// OpenDB opens a storm database in a temporary directory of the test. The
+// database is closed when the test ends.
+func OpenDB(t testing.TB) *storm.DB {
+	t.Helper()
+
+	db, err := storm.Open(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
@@ -0,0 +1,395 @@
+package libwallet
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/multisig"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+const (
+	// multisigAccount is the BIP48 account of the cosigner keys of the
+	// wallet seeds.
+	multisigAccount = 0
+
+	// multisigTimeout bounds the Electrum requests of an operation on a
+	// multisig wallet.
+	multisigTimeout = 2 * time.Minute
+)
+
+// multisigNetParams returns the network parameters of the multisig wallets of
+// the asset.
+func (mgr *AssetsManager) multisigNetParams(assetType utils.AssetType) (*multisig.NetParams, error) {
+	switch assetType {
+	case utils.BTCWalletAsset:
+		params := mgr.chainsParams.BTC
+		return &multisig.NetParams{
+			Bech32HRP:      params.Bech32HRPSegwit,
+			HDPrivateKeyID: params.HDPrivateKeyID,
+			HDPublicKeyID:  params.HDPublicKeyID,
+			CoinType:       params.HDCoinType,
+		}, nil
+	case utils.LTCWalletAsset:
+		params := mgr.chainsParams.LTC
+		return &multisig.NetParams{
+			Bech32HRP:      params.Bech32HRPSegwit,
+			HDPrivateKeyID: params.HDPrivateKeyID,
+			HDPublicKeyID:  params.HDPublicKeyID,
+			CoinType:       params.HDCoinType,
+		}, nil
+	}
+	return nil, errors.E(utils.ErrInvalid, "multisig wallets are only supported for BTC and LTC")
+}
+
+// multisigSeed decrypts the seed of the wallet that signs for one of the
+// cosigners of multisig wallets.
+func (mgr *AssetsManager) multisigSeed(walletID int, privatePassphrase string) (sharedW.Asset, []byte, error) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return nil, nil, errors.New(utils.ErrNotExist)
+	}
+	if !wallet.HasWalletSeed() {
+		return nil, nil, errors.New(utils.ErrNoSeed)
+	}
+
+	seedMnemonic, err := wallet.DecryptSeed(privatePassphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	wordSeedType := sharedW.WordSeedType(len(strings.Fields(seedMnemonic)))
+	seed, err := sharedW.DecodeSeedMnemonic(seedMnemonic, wallet.GetAssetType(), wordSeedType)
+	if err != nil {
+		return nil, nil, err
+	}
+	return wallet, seed, nil
+}
+
+// MultisigCosigner returns the cosigner of the seed of the wallet. Its
+// extended public key is shared with the other cosigners to set up a
+// multisig wallet.
+func (mgr *AssetsManager) MultisigCosigner(walletID int, privatePassphrase string) (*multisig.Cosigner, error) {
+	wallet, seed, err := mgr.multisigSeed(walletID, privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+	params, err := mgr.multisigNetParams(wallet.GetAssetType())
+	if err != nil {
+		return nil, err
+	}
+	return multisig.SignerCosigner(wallet.GetWalletName(), seed, params, multisigAccount)
+}
+
+// CreateMultisigWallet creates a threshold of cosigners multisig wallet of
+// the asset. The wallet signs with the seed of the signer wallet, whose
+// cosigner is added to the cosigners if missing. The wallet is watch-only if
+// signerWalletID is zero.
+func (mgr *AssetsManager) CreateMultisigWallet(name string, assetType utils.AssetType, threshold int,
+	cosigners []*multisig.Cosigner, signerWalletID int, privatePassphrase string) (*multisig.Wallet, error) {
+	wallet := &multisig.Wallet{
+		Name:      name,
+		Asset:     string(assetType),
+		Threshold: threshold,
+		Cosigners: cosigners,
+	}
+	if err := mgr.saveMultisigWallet(wallet, signerWalletID, privatePassphrase, true); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// ImportMultisigWallet creates the multisig wallet of the config shared by a
+// cosigner, a JSON config or an output descriptor. The name overrides the
+// name of the config if it is not empty.
+func (mgr *AssetsManager) ImportMultisigWallet(config []byte, name string, signerWalletID int, privatePassphrase string) (*multisig.Wallet, error) {
+	parsed, err := multisig.ParseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Network != "" && utils.ToNetworkType(parsed.Network) != mgr.NetType() {
+		return nil, errors.E(utils.ErrInvalid, "the multisig config is for another network")
+	}
+	if parsed.Asset == "" {
+		if signer := mgr.WalletWithID(signerWalletID); signer != nil {
+			parsed.Asset = string(signer.GetAssetType())
+		}
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		parsed.Name = name
+	}
+
+	wallet, err := parsed.Wallet()
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.saveMultisigWallet(wallet, signerWalletID, privatePassphrase, false); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// saveMultisigWallet checks the asset and the signer of the wallet and saves
+// it. The cosigner of the signer wallet must be one of the cosigners unless
+// addSigner adds it. The wallet finds its outputs with the Electrum server of
+// the signer wallet if it has one.
+func (mgr *AssetsManager) saveMultisigWallet(wallet *multisig.Wallet, signerWalletID int, privatePassphrase string, addSigner bool) error {
+	assetType := utils.AssetType(wallet.Asset)
+	params, err := mgr.multisigNetParams(assetType)
+	if err != nil {
+		return err
+	}
+
+	if signerWalletID != 0 {
+		signer, seed, err := mgr.multisigSeed(signerWalletID, privatePassphrase)
+		if err != nil {
+			return err
+		}
+		if signer.GetAssetType() != assetType {
+			return errors.E(utils.ErrInvalid, "the signer wallet is of another asset")
+		}
+		cosigner, err := multisig.SignerCosigner(signer.GetWalletName(), seed, params, multisigAccount)
+		if err != nil {
+			return err
+		}
+		if wallet.CosignerIndex(cosigner) < 0 {
+			if !addSigner {
+				return errors.New(multisig.ErrNoSigner)
+			}
+			wallet.Cosigners = append(wallet.Cosigners, cosigner)
+		}
+		wallet.SignerWalletID = signerWalletID
+		if server := signer.ElectrumServer(); server != nil {
+			wallet.ElectrumServer, wallet.ElectrumCert = server.Address, server.Cert
+		}
+	}
+	return mgr.Multisig.Create(wallet)
+}
+
+// ExportMultisigWallet returns the JSON encoded config of the wallet, which
+// the other cosigners import.
+func (mgr *AssetsManager) ExportMultisigWallet(id int) ([]byte, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return nil, err
+	}
+	config, err := wallet.Config(string(mgr.NetType()))
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(config, "", "  ")
+}
+
+// SetMultisigElectrumServer saves the Electrum server the wallet finds its
+// outputs and broadcasts its transactions with.
+func (mgr *AssetsManager) SetMultisigElectrumServer(id int, server *sharedW.ElectrumServer) error {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return err
+	}
+	server.Address = strings.TrimSpace(server.Address)
+	server.Cert = strings.TrimSpace(server.Cert)
+	if err := server.Validate(); err != nil {
+		return err
+	}
+	wallet.ElectrumServer, wallet.ElectrumCert = server.Address, server.Cert
+	return mgr.Multisig.Update(wallet)
+}
+
+// multisigBackend connects to the Electrum server of the wallet. Multisig
+// wallets are only backed by Electrum servers, the SPV and RPC sync of the
+// BTC and LTC wallets only watch the addresses of their own accounts.
+func (mgr *AssetsManager) multisigBackend(ctx context.Context, wallet *multisig.Wallet) (*electrum.Client, error) {
+	if wallet.ElectrumServer == "" {
+		return nil, errors.E(utils.ErrInvalid, "Electrum server is not set")
+	}
+
+	var chain *electrum.Chain
+	switch utils.AssetType(wallet.Asset) {
+	case utils.BTCWalletAsset:
+		chain = btc.ElectrumChain(mgr.chainsParams.BTC)
+	case utils.LTCWalletAsset:
+		chain = ltc.ElectrumChain(mgr.chainsParams.LTC)
+	default:
+		return nil, errors.E(utils.ErrInvalid, "multisig wallets are only supported for BTC and LTC")
+	}
+	return electrum.Connect(ctx, wallet.ElectrumServer, wallet.ElectrumCert, chain)
+}
+
+// scanMultisigWallet finds the unspent outputs of the wallet.
+func (mgr *AssetsManager) scanMultisigWallet(ctx context.Context, wallet *multisig.Wallet) ([]*multisig.UTXO, *multisig.Balance, error) {
+	backend, err := mgr.multisigBackend(ctx, wallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer backend.Close()
+	return mgr.Multisig.Scan(ctx, wallet, backend)
+}
+
+// MultisigBalance returns the balance of the wallet.
+func (mgr *AssetsManager) MultisigBalance(id int) (*multisig.Balance, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), multisigTimeout)
+	defer cancel()
+	_, balance, err := mgr.scanMultisigWallet(ctx, wallet)
+	return balance, err
+}
+
+// MultisigReceiveAddress returns the first unused receive address of the
+// wallet. Scanning the wallet moves past the addresses that received funds.
+func (mgr *AssetsManager) MultisigReceiveAddress(id int) (string, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return "", err
+	}
+	params, err := mgr.multisigNetParams(utils.AssetType(wallet.Asset))
+	if err != nil {
+		return "", err
+	}
+	return wallet.Address(params, multisig.ReceiveBranch, wallet.NextReceiveIndex)
+}
+
+// CreateMultisigPSBT creates the base64 encoded PSBT of a payment of amount
+// coins to the address from the wallet, at the fee rate in atoms per virtual
+// byte.
+func (mgr *AssetsManager) CreateMultisigPSBT(id int, address string, amount float64, feeRate int64) (string, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return "", err
+	}
+
+	assetType := utils.AssetType(wallet.Asset)
+	atoms, err := coinToAtoms(assetType, amount)
+	if err != nil {
+		return "", err
+	}
+	var pkScript []byte
+	switch assetType {
+	case utils.BTCWalletAsset:
+		pkScript, err = btc.AddressScript(strings.TrimSpace(address), mgr.chainsParams.BTC)
+	case utils.LTCWalletAsset:
+		pkScript, err = ltc.AddressScript(strings.TrimSpace(address), mgr.chainsParams.LTC)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), multisigTimeout)
+	defer cancel()
+	utxos, _, err := mgr.scanMultisigWallet(ctx, wallet)
+	if err != nil {
+		return "", err
+	}
+
+	packet, err := wallet.CreatePSBT(utxos, []*wire.TxOut{wire.NewTxOut(atoms, pkScript)}, feeRate)
+	if err != nil {
+		return "", err
+	}
+	return packet.B64Encode()
+}
+
+// SignMultisigPSBT adds the signatures of the signer wallet of the wallet to
+// the base64 encoded PSBT.
+func (mgr *AssetsManager) SignMultisigPSBT(id int, encoded, privatePassphrase string) (string, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return "", err
+	}
+	if wallet.WatchOnly() {
+		return "", errors.New(utils.ErrWalletIsWatchOnly)
+	}
+	packet, err := multisig.DecodePSBT(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	_, seed, err := mgr.multisigSeed(wallet.SignerWalletID, privatePassphrase)
+	if err != nil {
+		return "", err
+	}
+	params, err := mgr.multisigNetParams(utils.AssetType(wallet.Asset))
+	if err != nil {
+		return "", err
+	}
+	if _, err := wallet.SignPSBT(packet, seed, params, multisigAccount); err != nil {
+		return "", err
+	}
+	return packet.B64Encode()
+}
+
+// CombineMultisigPSBTs merges the signatures of the base64 encoded PSBTs
+// signed by the cosigners. It returns the combined PSBT and whether it has
+// enough signatures to be broadcast.
+func (mgr *AssetsManager) CombineMultisigPSBTs(id int, encoded ...string) (string, bool, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return "", false, err
+	}
+
+	packets := make([]*psbt.Packet, 0, len(encoded))
+	for _, e := range encoded {
+		packet, err := multisig.DecodePSBT(e)
+		if err != nil {
+			return "", false, err
+		}
+		packets = append(packets, packet)
+	}
+	combined, err := multisig.CombinePSBTs(packets...)
+	if err != nil {
+		return "", false, err
+	}
+
+	b64, err := combined.B64Encode()
+	if err != nil {
+		return "", false, err
+	}
+	return b64, multisig.Signatures(combined) >= wallet.Threshold, nil
+}
+
+// BroadcastMultisigPSBT finalizes the base64 encoded PSBT signed by the
+// threshold of cosigners and broadcasts its transaction. It returns the hash
+// of the transaction.
+func (mgr *AssetsManager) BroadcastMultisigPSBT(id int, encoded string) (string, error) {
+	wallet, err := mgr.Multisig.Wallet(id)
+	if err != nil {
+		return "", err
+	}
+	packet, err := multisig.DecodePSBT(encoded)
+	if err != nil {
+		return "", err
+	}
+	tx, err := wallet.Finalize(packet)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), multisigTimeout)
+	defer cancel()
+	backend, err := mgr.multisigBackend(ctx, wallet)
+	if err != nil {
+		return "", err
+	}
+	defer backend.Close()
+	return mgr.Multisig.Broadcast(ctx, backend, tx)
+}
+
+// MultisigAmount returns the amount in atoms of the asset of the wallet.
+func MultisigAmount(wallet *multisig.Wallet, atoms int64) sharedW.AssetAmount {
+	if utils.AssetType(wallet.Asset) == utils.LTCWalletAsset {
+		return ltc.Amount(atoms)
+	}
+	return btc.Amount(atoms)
+}
@@ -0,0 +1,279 @@
+package multisig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// Validate checks the threshold and the keys of the cosigners of the wallet.
+func (w *Wallet) Validate() error {
+	if len(w.Cosigners) < 2 || len(w.Cosigners) > MaxCosigners {
+		return errors.E(errors.Invalid, fmt.Sprintf("a multisig wallet has 2 to %d cosigners", MaxCosigners))
+	}
+	if w.Threshold < 1 || w.Threshold > len(w.Cosigners) {
+		return errors.E(errors.Invalid, fmt.Sprintf("threshold must be 1 to %d", len(w.Cosigners)))
+	}
+
+	seen := make(map[string]bool, len(w.Cosigners))
+	for i, cosigner := range w.Cosigners {
+		key, err := cosigner.key()
+		if err != nil {
+			return err
+		}
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return errors.E(errors.Invalid, err)
+		}
+		serialized := string(pubKey.SerializeCompressed())
+		if seen[serialized] {
+			return errors.E(errors.Invalid, fmt.Sprintf("cosigner %d repeats the key of another cosigner", i+1))
+		}
+		seen[serialized] = true
+	}
+	return nil
+}
+
+// key parses the extended public key of the cosigner.
+func (c *Cosigner) key() (*hdkeychain.ExtendedKey, error) {
+	key, err := hdkeychain.NewKeyFromString(strings.TrimSpace(c.XPub))
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Errorf("cosigner %s: %v", c.Name, err))
+	}
+	if key.IsPrivate() {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("cosigner %s: extended private keys are not accepted", c.Name))
+	}
+	if c.Fingerprint != "" {
+		if _, err := c.fingerprint(); err != nil {
+			return nil, err
+		}
+	}
+	if c.Path != "" {
+		if _, err := ParsePath(c.Path); err != nil {
+			return nil, errors.E(errors.Invalid, fmt.Errorf("cosigner %s: %v", c.Name, err))
+		}
+	}
+	return key, nil
+}
+
+// CosignerIndex returns the index of the cosigner of the wallet with the key
+// of c, or -1 if c is not a cosigner. The versions of the extended keys are
+// ignored.
+func (w *Wallet) CosignerIndex(c *Cosigner) int {
+	key, err := c.key()
+	if err != nil {
+		return -1
+	}
+	for i, cosigner := range w.Cosigners {
+		other, err := cosigner.key()
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(key.ChainCode(), other.ChainCode()) && key.Depth() == other.Depth() &&
+			samePubKey(key, other) {
+			return i
+		}
+	}
+	return -1
+}
+
+func samePubKey(a, b *hdkeychain.ExtendedKey) bool {
+	pubKeyA, errA := a.ECPubKey()
+	pubKeyB, errB := b.ECPubKey()
+	return errA == nil && errB == nil && pubKeyA.IsEqual(pubKeyB)
+}
+
+// fingerprint returns the master key fingerprint of the cosigner as the
+// little-endian integer of the PSBT key derivations.
+func (c *Cosigner) fingerprint() (uint32, error) {
+	b, err := hex.DecodeString(c.Fingerprint)
+	if err != nil || len(b) != 4 {
+		return 0, errors.E(errors.Invalid, fmt.Sprintf("cosigner %s: fingerprint must be 4 hex encoded bytes", c.Name))
+	}
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24, nil
+}
+
+// ParsePath parses a derivation path such as m/48'/0'/0'/2'. Hardened
+// indexes are marked with ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "m")
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return nil, nil
+	}
+
+	var indexes []uint32
+	for _, elem := range strings.Split(path, "/") {
+		hardened := strings.HasSuffix(elem, "'") || strings.HasSuffix(elem, "h")
+		if hardened {
+			elem = elem[:len(elem)-1]
+		}
+		index, err := strconv.ParseUint(elem, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path element %q", elem)
+		}
+		if hardened {
+			index += hdkeychain.HardenedKeyStart
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// FormatPath formats the derivation path with h marking hardened indexes.
+func FormatPath(indexes []uint32) string {
+	path := "m"
+	for _, index := range indexes {
+		if index >= hdkeychain.HardenedKeyStart {
+			path += fmt.Sprintf("/%dh", index-hdkeychain.HardenedKeyStart)
+		} else {
+			path += fmt.Sprintf("/%d", index)
+		}
+	}
+	return path
+}
+
+// AccountPath returns the BIP48 derivation path of the P2WSH multisig keys of
+// the account.
+func AccountPath(params *NetParams, account uint32) []uint32 {
+	h := uint32(hdkeychain.HardenedKeyStart)
+	return []uint32{48 + h, params.CoinType + h, account + h, 2 + h}
+}
+
+// SignerCosigner derives the cosigner of the account of the seed, holding its
+// BIP48 P2WSH multisig key.
+func SignerCosigner(name string, seed []byte, params *NetParams, account uint32) (*Cosigner, error) {
+	master, accountKey, err := deriveAccountKey(seed, params, account)
+	if err != nil {
+		return nil, err
+	}
+	masterPubKey, err := master.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := accountKey.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// The extended public key is built directly, neutering the key only
+	// knows the versions of the networks registered with btcd.
+	path := AccountPath(params, account)
+	xpub := hdkeychain.NewExtendedKey(params.HDPublicKeyID[:], pubKey.SerializeCompressed(),
+		accountKey.ChainCode(), parentFingerprint(accountKey), accountKey.Depth(), path[len(path)-1], false)
+
+	return &Cosigner{
+		Name:        name,
+		XPub:        xpub.String(),
+		Fingerprint: hex.EncodeToString(btcutil.Hash160(masterPubKey.SerializeCompressed())[:4]),
+		Path:        FormatPath(path),
+	}, nil
+}
+
+// deriveAccountKey derives the master key of the seed and its BIP48 P2WSH
+// multisig account key.
+func deriveAccountKey(seed []byte, params *NetParams, account uint32) (master, accountKey *hdkeychain.ExtendedKey, err error) {
+	master, err = hdkeychain.NewMaster(seed, &chaincfg.Params{HDPrivateKeyID: params.HDPrivateKeyID})
+	if err != nil {
+		return nil, nil, err
+	}
+	accountKey = master
+	for _, index := range AccountPath(params, account) {
+		if accountKey, err = accountKey.Derive(index); err != nil {
+			return nil, nil, err
+		}
+	}
+	return master, accountKey, nil
+}
+
+func parentFingerprint(key *hdkeychain.ExtendedKey) []byte {
+	fp := key.ParentFingerprint()
+	return []byte{byte(fp >> 24), byte(fp >> 16), byte(fp >> 8), byte(fp)}
+}
+
+// derivedKey is the public key a cosigner derived for an address.
+type derivedKey struct {
+	cosigner *Cosigner
+	pubKey   []byte
+	path     []uint32
+}
+
+// derivedKeys returns the keys of the cosigners for the address at the index
+// of the branch, sorted as in BIP67.
+func (w *Wallet) derivedKeys(branch, index uint32) ([]*derivedKey, error) {
+	keys := make([]*derivedKey, 0, len(w.Cosigners))
+	for _, cosigner := range w.Cosigners {
+		key, err := cosigner.key()
+		if err != nil {
+			return nil, err
+		}
+		if key, err = key.Derive(branch); err != nil {
+			return nil, err
+		}
+		if key, err = key.Derive(index); err != nil {
+			return nil, err
+		}
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		path, _ := ParsePath(cosigner.Path)
+		keys = append(keys, &derivedKey{
+			cosigner: cosigner,
+			pubKey:   pubKey.SerializeCompressed(),
+			path:     append(path, branch, index),
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].pubKey, keys[j].pubKey) < 0
+	})
+	return keys, nil
+}
+
+// WitnessScript returns the multisig script of the address at the index of
+// the branch.
+func (w *Wallet) WitnessScript(branch, index uint32) ([]byte, error) {
+	keys, err := w.derivedKeys(branch, index)
+	if err != nil {
+		return nil, err
+	}
+	return multisigScript(w.Threshold, keys)
+}
+
+func multisigScript(threshold int, keys []*derivedKey) ([]byte, error) {
+	builder := txscript.NewScriptBuilder().AddInt64(int64(threshold))
+	for _, key := range keys {
+		builder.AddData(key.pubKey)
+	}
+	return builder.AddInt64(int64(len(keys))).AddOp(txscript.OP_CHECKMULTISIG).Script()
+}
+
+// PkScript returns the P2WSH output script of the witness script.
+func PkScript(witnessScript []byte) ([]byte, error) {
+	hash := sha256.Sum256(witnessScript)
+	return txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(hash[:]).Script()
+}
+
+// Address returns the P2WSH address at the index of the branch.
+func (w *Wallet) Address(params *NetParams, branch, index uint32) (string, error) {
+	witnessScript, err := w.WitnessScript(branch, index)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(witnessScript)
+	address, err := btcutil.NewAddressWitnessScriptHash(hash[:], &chaincfg.Params{Bech32HRPSegwit: params.Bech32HRP})
+	if err != nil {
+		return "", err
+	}
+	return address.EncodeAddress(), nil
+}
@@ -0,0 +1,176 @@
+package multisig
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+const (
+	descriptorInputCharset = "0123456789()[],'/*abcdefgh@:$%{}" +
+		"IJKLMNOPQRSTUVWXYZ&+-.;<=>?!^_|~" +
+		"ijklmnopqrstuvwxyzABCDEFGH`#\"\\ "
+	descriptorChecksumCharset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// Descriptor returns the output descriptor of the wallet, with the receive
+// and change branches of the keys in the BIP389 multipath form.
+func (w *Wallet) Descriptor() (string, error) {
+	if err := w.Validate(); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(w.Cosigners))
+	for _, cosigner := range w.Cosigners {
+		keys = append(keys, cosigner.KeyExpression()+"/<0;1>/*")
+	}
+
+	desc := fmt.Sprintf("wsh(sortedmulti(%d,%s))", w.Threshold, strings.Join(keys, ","))
+	return desc + "#" + DescriptorChecksum(desc), nil
+}
+
+// ParseDescriptor parses the wsh(sortedmulti(...)) output descriptor into the
+// threshold and the cosigners of a wallet. The checksum is verified if
+// present. The keys may omit their origin and their branches.
+func ParseDescriptor(desc string) (int, []*Cosigner, error) {
+	desc = strings.TrimSpace(desc)
+	if i := strings.LastIndexByte(desc, '#'); i >= 0 {
+		if DescriptorChecksum(desc[:i]) != desc[i+1:] {
+			return 0, nil, errors.E(errors.Invalid, "descriptor checksum mismatch")
+		}
+		desc = desc[:i]
+	}
+
+	const prefix, suffix = "wsh(sortedmulti(", "))"
+	if !strings.HasPrefix(desc, prefix) || !strings.HasSuffix(desc, suffix) {
+		return 0, nil, errors.E(errors.Invalid, "only wsh(sortedmulti(...)) descriptors are supported")
+	}
+	args := strings.Split(desc[len(prefix):len(desc)-len(suffix)], ",")
+	if len(args) < 2 {
+		return 0, nil, errors.E(errors.Invalid, "descriptor has no keys")
+	}
+
+	threshold, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, nil, errors.E(errors.Invalid, fmt.Sprintf("invalid threshold %q", args[0]))
+	}
+
+	cosigners := make([]*Cosigner, 0, len(args)-1)
+	for i, key := range args[1:] {
+		cosigner, err := parseDescriptorKey(key)
+		if err != nil {
+			return 0, nil, errors.E(errors.Invalid, fmt.Errorf("key %d: %v", i+1, err))
+		}
+		cosigner.Name = fmt.Sprintf("Cosigner %d", i+1)
+		cosigners = append(cosigners, cosigner)
+	}
+	return threshold, cosigners, nil
+}
+
+// KeyExpression returns the extended public key of the cosigner, prefixed
+// with its origin if known, as in [fingerprint/48h/0h/0h/2h]xpub.
+func (c *Cosigner) KeyExpression() string {
+	key := strings.TrimSpace(c.XPub)
+	if c.Fingerprint == "" {
+		return key
+	}
+	origin := c.Fingerprint
+	if c.Path != "" {
+		path, _ := ParsePath(c.Path)
+		origin += strings.TrimPrefix(FormatPath(path), "m")
+	}
+	return "[" + origin + "]" + key
+}
+
+// ParseCosigner parses the key expression of a cosigner, an extended public
+// key optionally prefixed with its origin.
+func ParseCosigner(name, key string) (*Cosigner, error) {
+	cosigner, err := parseDescriptorKey(strings.TrimSpace(key))
+	if err != nil {
+		return nil, errors.E(errors.Invalid, err)
+	}
+	cosigner.Name = name
+	return cosigner, nil
+}
+
+// parseDescriptorKey parses a [fingerprint/path]xpub/<0;1>/* key expression.
+func parseDescriptorKey(key string) (*Cosigner, error) {
+	cosigner := new(Cosigner)
+	if strings.HasPrefix(key, "[") {
+		end := strings.IndexByte(key, ']')
+		if end < 0 {
+			return nil, errors.New("unterminated key origin")
+		}
+		origin := strings.SplitN(key[1:end], "/", 2)
+		cosigner.Fingerprint = strings.ToLower(origin[0])
+		if len(origin) == 2 {
+			path, err := ParsePath(origin[1])
+			if err != nil {
+				return nil, err
+			}
+			cosigner.Path = FormatPath(path)
+		}
+		key = key[end+1:]
+	}
+
+	// The addresses are always derived from the receive and change branches
+	// of the key, so keys limited to a single branch are rejected.
+	xpub, branches, _ := strings.Cut(key, "/")
+	switch branches {
+	case "", "<0;1>/*":
+	default:
+		return nil, fmt.Errorf("unsupported key derivation /%s", branches)
+	}
+	cosigner.XPub = xpub
+	if _, err := cosigner.key(); err != nil {
+		return nil, err
+	}
+	return cosigner, nil
+}
+
+// DescriptorChecksum returns the BIP380 checksum of the descriptor.
+func DescriptorChecksum(desc string) string {
+	generator := [5]uint64{0xf5dee51989, 0xa9fdca3312, 0x1bab10e32d, 0x3706b1677a, 0x644d626ffd}
+	chk := uint64(1)
+	polymod := func(value uint64) {
+		top := chk >> 35
+		chk = (chk&0x7ffffffff)<<5 ^ value
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+
+	var groups []uint64
+	for _, c := range desc {
+		pos := strings.IndexRune(descriptorInputCharset, c)
+		if pos < 0 {
+			return ""
+		}
+		polymod(uint64(pos & 31))
+		groups = append(groups, uint64(pos>>5))
+		if len(groups) == 3 {
+			polymod(groups[0]*9 + groups[1]*3 + groups[2])
+			groups = groups[:0]
+		}
+	}
+	switch len(groups) {
+	case 1:
+		polymod(groups[0])
+	case 2:
+		polymod(groups[0]*3 + groups[1])
+	}
+	for i := 0; i < 8; i++ {
+		polymod(0)
+	}
+	chk ^= 1
+
+	checksum := make([]byte, 8)
+	for i := range checksum {
+		checksum[i] = descriptorChecksumCharset[(chk>>(5*(7-i)))&31]
+	}
+	return string(checksum)
+}
@@ -0,0 +1,9 @@
+package multisig
+
+const (
+	ErrWalletNotFound      = "multisig_wallet_not_found"
+	ErrInsufficientFunds   = "insufficient_funds"
+	ErrNotEnoughSignatures = "not_enough_signatures"
+	ErrNoSigner            = "no_signer"
+	ErrPSBTMismatch        = "psbt_mismatch"
+)
@@ -0,0 +1,259 @@
+package multisig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+
+	"github.com/crypto-power/cryptopower/libwallet/electrum"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Backend is the source of the outputs of multisig wallets and the network
+// their transactions are broadcast to. It is implemented by the Electrum
+// client.
+type Backend interface {
+	History(ctx context.Context, scriptHash string) ([]*electrum.HistoryItem, error)
+	ListUnspent(ctx context.Context, scriptHash string) ([]*electrum.Unspent, error)
+	Broadcast(ctx context.Context, rawTx []byte) (string, error)
+}
+
+// Compile time check that the Electrum client is a backend.
+var _ Backend = (*electrum.Client)(nil)
+
+// Manager persists the multisig wallets.
+type Manager struct {
+	db *storm.DB
+}
+
+// NewManager creates a multisig wallet manager that uses db for persistence.
+func NewManager(db *storm.DB) (*Manager, error) {
+	if err := db.Init(&Wallet{}); err != nil {
+		log.Errorf("Error initializing multisig wallets database: %s", err.Error())
+		return nil, err
+	}
+	return &Manager{db: db}, nil
+}
+
+// Create validates and saves a new wallet.
+func (m *Manager) Create(wallet *Wallet) error {
+	wallet.Name = strings.TrimSpace(wallet.Name)
+	if wallet.Name == "" {
+		return errors.E(errors.Invalid, "missing multisig wallet name")
+	}
+	if err := wallet.Validate(); err != nil {
+		return err
+	}
+
+	wallet.ID = 0
+	wallet.NextReceiveIndex, wallet.NextChangeIndex = 0, 0
+	wallet.CreatedAt = time.Now().Unix()
+	if err := m.db.Save(wallet); err != nil {
+		if err == storm.ErrAlreadyExists {
+			return errors.New(utils.ErrWalletNameExist)
+		}
+		return err
+	}
+	return nil
+}
+
+// Wallets returns the wallets, oldest first.
+func (m *Manager) Wallets() ([]*Wallet, error) {
+	var wallets []*Wallet
+	if err := m.db.All(&wallets); err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+// Wallet returns the wallet with the given ID.
+func (m *Manager) Wallet(id int) (*Wallet, error) {
+	var wallet Wallet
+	if err := m.db.One("ID", id, &wallet); err != nil {
+		if err == storm.ErrNotFound {
+			return nil, errors.New(ErrWalletNotFound)
+		}
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// Update saves the changes to the server and the address indexes of the
+// wallet. Its cosigners can not change.
+func (m *Manager) Update(wallet *Wallet) error {
+	saved, err := m.Wallet(wallet.ID)
+	if err != nil {
+		return err
+	}
+	saved.ElectrumServer, saved.ElectrumCert = wallet.ElectrumServer, wallet.ElectrumCert
+	saved.NextReceiveIndex, saved.NextChangeIndex = wallet.NextReceiveIndex, wallet.NextChangeIndex
+	return m.db.Save(saved)
+}
+
+// Delete deletes the wallet. Its funds are untouched and the wallet can be
+// imported again from its config.
+func (m *Manager) Delete(id int) error {
+	wallet, err := m.Wallet(id)
+	if err != nil {
+		return err
+	}
+	return m.db.DeleteStruct(wallet)
+}
+
+// DeleteSignerWallets turns the wallets signing with the keys of the deleted
+// wallet into watch-only wallets.
+func (m *Manager) DeleteSignerWallets(signerWalletID int) error {
+	wallets, err := m.Wallets()
+	if err != nil {
+		return err
+	}
+	for _, wallet := range wallets {
+		if wallet.SignerWalletID == signerWalletID {
+			if err := m.db.UpdateField(wallet, "SignerWalletID", 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Scan finds the unspent outputs of the wallet, scanning each branch until
+// GapLimit consecutive addresses have no history, and advances the indexes
+// of the wallet past the used addresses.
+func (m *Manager) Scan(ctx context.Context, wallet *Wallet, backend Backend) ([]*UTXO, *Balance, error) {
+	var utxos []*UTXO
+	balance := new(Balance)
+	for _, branch := range []uint32{ReceiveBranch, ChangeBranch} {
+		next := uint32(0)
+		for index, unused := uint32(0), 0; unused < GapLimit; index++ {
+			witnessScript, err := wallet.WitnessScript(branch, index)
+			if err != nil {
+				return nil, nil, err
+			}
+			pkScript, err := PkScript(witnessScript)
+			if err != nil {
+				return nil, nil, err
+			}
+			scriptHash := electrum.ScriptHash(pkScript)
+
+			history, err := backend.History(ctx, scriptHash)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(history) == 0 {
+				unused++
+				continue
+			}
+			unused, next = 0, index+1
+
+			unspent, err := backend.ListUnspent(ctx, scriptHash)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, output := range unspent {
+				txHash, err := chainhash.NewHashFromStr(output.TxHash)
+				if err != nil {
+					return nil, nil, err
+				}
+				utxos = append(utxos, &UTXO{
+					OutPoint: *wire.NewOutPoint(txHash, output.TxPos),
+					Value:    output.Value,
+					Height:   output.Height,
+					Branch:   branch,
+					Index:    index,
+				})
+				if output.Height > 0 {
+					balance.Confirmed += output.Value
+				} else {
+					balance.Unconfirmed += output.Value
+				}
+			}
+		}
+
+		if branch == ReceiveBranch && next > wallet.NextReceiveIndex {
+			wallet.NextReceiveIndex = next
+		}
+		if branch == ChangeBranch && next > wallet.NextChangeIndex {
+			wallet.NextChangeIndex = next
+		}
+	}
+
+	if err := m.Update(wallet); err != nil {
+		return nil, nil, err
+	}
+	return utxos, balance, nil
+}
+
+// Broadcast publishes the transaction and returns its hash.
+func (m *Manager) Broadcast(ctx context.Context, backend Backend, tx *wire.MsgTx) (string, error) {
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", err
+	}
+	return backend.Broadcast(ctx, buf.Bytes())
+}
+
+// Config returns the shareable config of the wallet on the network.
+func (w *Wallet) Config(network string) (*Config, error) {
+	descriptor, err := w.Descriptor()
+	if err != nil {
+		return nil, err
+	}
+	return &Config{
+		Version:    configVersion,
+		Name:       w.Name,
+		Asset:      w.Asset,
+		Network:    network,
+		Threshold:  w.Threshold,
+		Cosigners:  w.Cosigners,
+		Descriptor: descriptor,
+	}, nil
+}
+
+// ParseConfig parses a JSON encoded config or a bare output descriptor, which
+// leaves the name, asset and network of the config empty.
+func ParseConfig(data []byte) (*Config, error) {
+	data = bytes.TrimSpace(data)
+	config := new(Config)
+	if bytes.HasPrefix(data, []byte("{")) {
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, errors.E(errors.Invalid, fmt.Errorf("invalid multisig config: %v", err))
+		}
+		if config.Version > configVersion {
+			return nil, errors.E(errors.Invalid, fmt.Sprintf("unsupported multisig config version %d", config.Version))
+		}
+	} else {
+		config.Descriptor = string(data)
+	}
+
+	if len(config.Cosigners) == 0 {
+		threshold, cosigners, err := ParseDescriptor(config.Descriptor)
+		if err != nil {
+			return nil, err
+		}
+		config.Threshold, config.Cosigners = threshold, cosigners
+	}
+	return config, nil
+}
+
+// Wallet returns the wallet of the config.
+func (c *Config) Wallet() (*Wallet, error) {
+	wallet := &Wallet{
+		Name:      c.Name,
+		Asset:     c.Asset,
+		Threshold: c.Threshold,
+		Cosigners: c.Cosigners,
+	}
+	if err := wallet.Validate(); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
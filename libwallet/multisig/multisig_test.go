@@ -0,0 +1,280 @@
+package multisig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+var testParams = &NetParams{
+	Bech32HRP:      "tb",
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf},
+	CoinType:       1,
+}
+
+func testSeed(i int) []byte {
+	return bytes.Repeat([]byte{byte(i + 1)}, 32)
+}
+
+// testWallet returns a 2-of-3 wallet of the cosigners of the test seeds.
+func testWallet(t *testing.T) *Wallet {
+	t.Helper()
+	wallet := &Wallet{Name: "treasury", Asset: "BTC", Threshold: 2}
+	for i := 0; i < 3; i++ {
+		cosigner, err := SignerCosigner(fmt.Sprintf("Cosigner %d", i+1), testSeed(i), testParams, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wallet.Cosigners = append(wallet.Cosigners, cosigner)
+	}
+	if err := wallet.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return wallet
+}
+
+func TestDescriptorChecksum(t *testing.T) {
+	tests := []struct {
+		descriptor string
+		checksum   string
+	}{
+		{"raw(deadbeef)", "89f8spxm"},
+		{"sh(multi(2,[00000000/111'/222]xprvA1RpRA33e1JQ7ifknakTFpgNXPmW2YvmhqLQYMmrj4xJXXWYpDPS3xz7iAxn8L39njGVyuoseXzU6rcxFLJ8HFsTjSyQbLYnMpCqE2VbFWc,xprv9uPDJpEQgRQfDcW7BkF7eTya6RPxXeJCqCJGHuCJ4GiRVLzkTXBAJMu2qaMWPrS7AANYqdq6vcBcBUdJCVVFceUvJFjaPdGZ2y9WACViL4L/0))", "ggrsrxfy"},
+	}
+	for _, test := range tests {
+		if checksum := DescriptorChecksum(test.descriptor); checksum != test.checksum {
+			t.Errorf("%s: expected checksum %s, got %s", test.descriptor, test.checksum, checksum)
+		}
+	}
+}
+
+func TestDescriptor(t *testing.T) {
+	wallet := testWallet(t)
+	descriptor, err := wallet.Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	threshold, cosigners, err := ParseDescriptor(descriptor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if threshold != wallet.Threshold || len(cosigners) != len(wallet.Cosigners) {
+		t.Fatalf("expected %d of %d cosigners, got %d of %d", wallet.Threshold, len(wallet.Cosigners), threshold, len(cosigners))
+	}
+	for i, cosigner := range cosigners {
+		expected := wallet.Cosigners[i]
+		if cosigner.XPub != expected.XPub || cosigner.Fingerprint != expected.Fingerprint || cosigner.Path != expected.Path {
+			t.Errorf("cosigner %d: expected %+v, got %+v", i, expected, cosigner)
+		}
+	}
+
+	if _, _, err := ParseDescriptor(descriptor[:len(descriptor)-1] + "q"); err == nil {
+		t.Error("expected a descriptor with a wrong checksum to fail")
+	}
+	if _, _, err := ParseDescriptor("wpkh(" + wallet.Cosigners[0].XPub + ")"); err == nil {
+		t.Error("expected a single key descriptor to fail")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	wallet := testWallet(t)
+	wallet.Threshold = 4
+	if err := wallet.Validate(); err == nil {
+		t.Error("expected a threshold above the cosigners to fail")
+	}
+
+	wallet = testWallet(t)
+	wallet.Cosigners[2] = &Cosigner{Name: "Copy", XPub: wallet.Cosigners[0].XPub}
+	if err := wallet.Validate(); err == nil {
+		t.Error("expected a repeated key to fail")
+	}
+}
+
+func TestAddressesAreSorted(t *testing.T) {
+	wallet := testWallet(t)
+	address, err := wallet.Address(testParams, ReceiveBranch, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// BIP67 makes the address independent of the order of the cosigners.
+	wallet.Cosigners[0], wallet.Cosigners[2] = wallet.Cosigners[2], wallet.Cosigners[0]
+	reordered, err := wallet.Address(testParams, ReceiveBranch, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != reordered {
+		t.Fatalf("expected address %s, got %s", address, reordered)
+	}
+
+	change, err := wallet.Address(testParams, ChangeBranch, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change == address {
+		t.Fatal("expected the change branch to have other addresses")
+	}
+}
+
+func TestPSBT(t *testing.T) {
+	wallet := testWallet(t)
+	utxos := []*UTXO{
+		{OutPoint: wire.OutPoint{Hash: chainhash.Hash{1}}, Value: 50000, Branch: ReceiveBranch, Index: 0},
+		{OutPoint: wire.OutPoint{Hash: chainhash.Hash{2}, Index: 1}, Value: 80000, Branch: ReceiveBranch, Index: 3},
+	}
+	payee := wire.NewTxOut(100000, append([]byte{txscript.OP_0, 20}, bytes.Repeat([]byte{7}, 20)...))
+
+	packet, err := wallet.CreatePSBT(utxos, []*wire.TxOut{payee}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packet.UnsignedTx.TxIn) != 2 || len(packet.UnsignedTx.TxOut) != 2 {
+		t.Fatalf("expected 2 inputs and 2 outputs, got %d and %d", len(packet.UnsignedTx.TxIn), len(packet.UnsignedTx.TxOut))
+	}
+	encoded, err := packet.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first and third cosigners sign copies of the PSBT.
+	var signedPackets []*psbt.Packet
+	for _, i := range []int{0, 2} {
+		signerPacket, err := DecodePSBT(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signed, err := wallet.SignPSBT(signerPacket, testSeed(i), testParams, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signed != 2 {
+			t.Fatalf("cosigner %d: expected 2 signed inputs, got %d", i, signed)
+		}
+		if _, err := wallet.Finalize(signerPacket); err == nil {
+			t.Fatal("expected a PSBT with one signature not to finalize")
+		}
+		signedPackets = append(signedPackets, signerPacket)
+	}
+
+	if _, err := wallet.SignPSBT(packet, bytes.Repeat([]byte{9}, 32), testParams, 0); err == nil {
+		t.Fatal("expected a seed of no cosigner not to sign")
+	}
+
+	combined, err := CombinePSBTs(signedPackets...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := wallet.Finalize(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range combined.Inputs {
+		prevOuts.AddPrevOut(tx.TxIn[i].PreviousOutPoint, input.WitnessUtxo)
+	}
+	sigHashes := txscript.NewTxSigHashes(tx, prevOuts)
+	for i, input := range combined.Inputs {
+		engine, err := txscript.NewEngine(input.WitnessUtxo.PkScript, tx, i, txscript.StandardVerifyFlags,
+			nil, sigHashes, input.WitnessUtxo.Value, prevOuts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.Execute(); err != nil {
+			t.Fatalf("input %d: %v", i, err)
+		}
+	}
+}
+
+func TestPSBTInsufficientFunds(t *testing.T) {
+	wallet := testWallet(t)
+	utxos := []*UTXO{{OutPoint: wire.OutPoint{Hash: chainhash.Hash{1}}, Value: 1000}}
+	payee := wire.NewTxOut(1000, []byte{txscript.OP_TRUE})
+	if _, err := wallet.CreatePSBT(utxos, []*wire.TxOut{payee}, 1); err == nil || err.Error() != ErrInsufficientFunds {
+		t.Fatalf("expected %s, got %v", ErrInsufficientFunds, err)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	wallet := testWallet(t)
+	config, err := wallet.Config("testnet3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseConfig(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := parsed.Wallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.Name != wallet.Name || imported.Asset != wallet.Asset || parsed.Network != "testnet3" {
+		t.Fatalf("expected %s %s testnet3, got %s %s %s", wallet.Name, wallet.Asset, imported.Name, imported.Asset, parsed.Network)
+	}
+
+	address, _ := wallet.Address(testParams, ReceiveBranch, 5)
+	importedAddress, err := imported.Address(testParams, ReceiveBranch, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != importedAddress {
+		t.Fatalf("expected address %s, got %s", address, importedAddress)
+	}
+
+	// A bare descriptor is a config too.
+	parsed, err = ParseConfig([]byte(config.Descriptor + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Threshold != 2 || len(parsed.Cosigners) != 3 {
+		t.Fatalf("expected 2 of 3 cosigners, got %d of %d", parsed.Threshold, len(parsed.Cosigners))
+	}
+}
+
+func TestParseCosigner(t *testing.T) {
+	wallet := testWallet(t)
+	expected := wallet.Cosigners[1]
+	cosigner, err := ParseCosigner("Bob", expected.KeyExpression())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cosigner.XPub != expected.XPub || cosigner.Fingerprint != expected.Fingerprint || cosigner.Path != expected.Path {
+		t.Fatalf("expected %+v, got %+v", expected, cosigner)
+	}
+
+	if _, err := ParseCosigner("Bob", "[0011/48h]"+expected.XPub); err == nil {
+		t.Fatal("expected a short fingerprint to fail")
+	}
+
+	tests := []struct {
+		derivation  string
+		expectedErr bool
+	}{
+		{derivation: ""},
+		{derivation: "/<0;1>/*"},
+		{derivation: "/0/*", expectedErr: true},
+		{derivation: "/1/*", expectedErr: true},
+		{derivation: "/*", expectedErr: true},
+		{derivation: "/<0;1>/0", expectedErr: true},
+	}
+	for _, tc := range tests {
+		_, err := ParseCosigner("Bob", expected.KeyExpression()+tc.derivation)
+		if (err != nil) != tc.expectedErr {
+			t.Errorf("(%v), expected error (%v), got (%v)", tc.derivation, tc.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package multisig
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// dustThreshold is the smallest change output added to a transaction,
+	// smaller change is left to the fee.
+	dustThreshold = 546
+
+	// txOverheadWeight is the weight of the version, locktime, input and
+	// output counts and segwit marker of a transaction.
+	txOverheadWeight = 4*(4+4+1+1) + 2
+
+	// p2wshOutputSize is the size of a P2WSH output.
+	p2wshOutputSize = 8 + 1 + 34
+)
+
+// inputWeight estimates the weight of an input spending a P2WSH m-of-n
+// output: the outpoint, empty signature script and sequence, and the witness
+// of the dummy element, m signatures and the script.
+func inputWeight(threshold, keys int) int {
+	scriptSize := 3 + 34*keys
+	witnessSize := 1 + 1 + threshold*(1+73) + wire.VarIntSerializeSize(uint64(scriptSize)) + scriptSize
+	return 4*(32+4+1+4) + witnessSize
+}
+
+// outputWeight returns the weight of an output paying to pkScript.
+func outputWeight(pkScript []byte) int {
+	return 4 * (8 + wire.VarIntSerializeSize(uint64(len(pkScript))) + len(pkScript))
+}
+
+// fee returns the fee of a transaction of the weight at the rate in atoms
+// per virtual byte.
+func fee(weight int, feeRate int64) int64 {
+	return int64((weight+3)/4) * feeRate
+}
+
+// CreatePSBT creates the PSBT of a transaction paying the outputs from the
+// UTXOs of the wallet, the largest first, at the fee rate in atoms per
+// virtual byte. The change goes to the next change address of the wallet.
+func (w *Wallet) CreatePSBT(utxos []*UTXO, outputs []*wire.TxOut, feeRate int64) (*psbt.Packet, error) {
+	if len(outputs) == 0 {
+		return nil, errors.E(errors.Invalid, "no outputs to pay")
+	}
+	if feeRate <= 0 {
+		return nil, errors.E(errors.Invalid, "fee rate must be positive")
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	weight := txOverheadWeight
+	var amount int64
+	for _, output := range outputs {
+		if output.Value <= 0 {
+			return nil, errors.E(errors.Invalid, "output amounts must be positive")
+		}
+		tx.AddTxOut(output)
+		weight += outputWeight(output.PkScript)
+		amount += output.Value
+	}
+
+	sorted := make([]*UTXO, len(utxos))
+	copy(sorted, utxos)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+
+	var selected []*UTXO
+	var total int64
+	for _, utxo := range sorted {
+		if total >= amount+fee(weight, feeRate) {
+			break
+		}
+		selected = append(selected, utxo)
+		total += utxo.Value
+		weight += inputWeight(w.Threshold, len(w.Cosigners))
+	}
+	if total < amount+fee(weight, feeRate) {
+		return nil, errors.New(ErrInsufficientFunds)
+	}
+
+	change := total - amount - fee(weight+4*p2wshOutputSize, feeRate)
+	var changeScript []byte
+	if change >= dustThreshold {
+		var err error
+		changeScript, err = w.WitnessScript(ChangeBranch, w.NextChangeIndex)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := PkScript(changeScript)
+		if err != nil {
+			return nil, err
+		}
+		tx.AddTxOut(wire.NewTxOut(change, pkScript))
+	}
+
+	for _, utxo := range selected {
+		tx.AddTxIn(wire.NewTxIn(&utxo.OutPoint, nil, nil))
+	}
+
+	packet, err := psbt.NewFromUnsignedTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, utxo := range selected {
+		keys, err := w.derivedKeys(utxo.Branch, utxo.Index)
+		if err != nil {
+			return nil, err
+		}
+		witnessScript, err := multisigScript(w.Threshold, keys)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := PkScript(witnessScript)
+		if err != nil {
+			return nil, err
+		}
+		if err := updater.AddInWitnessUtxo(wire.NewTxOut(utxo.Value, pkScript), i); err != nil {
+			return nil, err
+		}
+		if err := updater.AddInWitnessScript(witnessScript, i); err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if key.cosigner.Fingerprint == "" {
+				continue
+			}
+			fingerprint, _ := key.cosigner.fingerprint()
+			if err := updater.AddInBip32Derivation(fingerprint, key.path, key.pubKey, i); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The change output carries its script and keys for the signers to
+	// recognize it as their own.
+	if changeScript != nil {
+		changeIndex := len(tx.TxOut) - 1
+		if err := updater.AddOutWitnessScript(changeScript, changeIndex); err != nil {
+			return nil, err
+		}
+		keys, err := w.derivedKeys(ChangeBranch, w.NextChangeIndex)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if key.cosigner.Fingerprint == "" {
+				continue
+			}
+			fingerprint, _ := key.cosigner.fingerprint()
+			if err := updater.AddOutBip32Derivation(fingerprint, key.path, key.pubKey, changeIndex); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return packet, nil
+}
+
+// SignPSBT adds the signatures of the cosigner of the seed to the inputs of
+// the PSBT spending outputs of the wallet. It returns the number of inputs
+// signed.
+func (w *Wallet) SignPSBT(packet *psbt.Packet, seed []byte, params *NetParams, account uint32) (int, error) {
+	signer, err := SignerCosigner("", seed, params, account)
+	if err != nil {
+		return 0, err
+	}
+	if w.CosignerIndex(signer) < 0 {
+		return 0, errors.New(ErrNoSigner)
+	}
+
+	_, accountKey, err := deriveAccountKey(seed, params, account)
+	if err != nil {
+		return 0, err
+	}
+
+	prevOuts := txscript.NewMultiPrevOutFetcher(nil)
+	for i, input := range packet.Inputs {
+		if input.WitnessUtxo == nil {
+			return 0, errors.E(errors.Invalid, fmt.Sprintf("input %d has no witness UTXO", i))
+		}
+		prevOuts.AddPrevOut(packet.UnsignedTx.TxIn[i].PreviousOutPoint, input.WitnessUtxo)
+	}
+	sigHashes := txscript.NewTxSigHashes(packet.UnsignedTx, prevOuts)
+
+	updater, err := psbt.NewUpdater(packet)
+	if err != nil {
+		return 0, err
+	}
+
+	var signed int
+	for i, input := range packet.Inputs {
+		branch, index, ok := w.inputAddress(&input)
+		if !ok {
+			continue
+		}
+		key, err := accountKey.Derive(branch)
+		if err == nil {
+			key, err = key.Derive(index)
+		}
+		if err != nil {
+			return signed, err
+		}
+		privKey, err := key.ECPrivKey()
+		if err != nil {
+			return signed, err
+		}
+		pubKey := privKey.PubKey().SerializeCompressed()
+
+		alreadySigned := false
+		for _, sig := range input.PartialSigs {
+			alreadySigned = alreadySigned || bytes.Equal(sig.PubKey, pubKey)
+		}
+		if alreadySigned {
+			continue
+		}
+
+		sig, err := txscript.RawTxInWitnessSignature(packet.UnsignedTx, sigHashes, i,
+			input.WitnessUtxo.Value, input.WitnessScript, txscript.SigHashAll, privKey)
+		if err != nil {
+			return signed, err
+		}
+		if _, err := updater.Sign(i, sig, pubKey, nil, input.WitnessScript); err != nil {
+			return signed, err
+		}
+		signed++
+	}
+	return signed, nil
+}
+
+// inputAddress finds the branch and index of the address of the wallet that
+// the input spends from.
+func (w *Wallet) inputAddress(input *psbt.PInput) (branch, index uint32, ok bool) {
+	if input.WitnessScript == nil || input.WitnessUtxo == nil {
+		return 0, 0, false
+	}
+
+	// The derivations of the input locate the address. The script is
+	// rebuilt to check that it belongs to the wallet.
+	for _, derivation := range input.Bip32Derivation {
+		if len(derivation.Bip32Path) < 2 {
+			continue
+		}
+		branch = derivation.Bip32Path[len(derivation.Bip32Path)-2]
+		index = derivation.Bip32Path[len(derivation.Bip32Path)-1]
+		witnessScript, err := w.WitnessScript(branch, index)
+		if err == nil && bytes.Equal(witnessScript, input.WitnessScript) {
+			return branch, index, true
+		}
+	}
+
+	// PSBTs of wallets without key origins are matched against the used
+	// addresses of the wallet.
+	for _, branch := range []uint32{ReceiveBranch, ChangeBranch} {
+		next := w.NextReceiveIndex
+		if branch == ChangeBranch {
+			next = w.NextChangeIndex
+		}
+		for index := uint32(0); index < next+GapLimit; index++ {
+			witnessScript, err := w.WitnessScript(branch, index)
+			if err != nil {
+				return 0, 0, false
+			}
+			if bytes.Equal(witnessScript, input.WitnessScript) {
+				return branch, index, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// CombinePSBTs merges the signatures of the PSBTs of the same transaction.
+func CombinePSBTs(packets ...*psbt.Packet) (*psbt.Packet, error) {
+	if len(packets) == 0 {
+		return nil, errors.E(errors.Invalid, "no PSBTs to combine")
+	}
+
+	combined := packets[0]
+	txHash := combined.UnsignedTx.TxHash()
+	for _, packet := range packets[1:] {
+		if packet.UnsignedTx.TxHash() != txHash {
+			return nil, errors.New(ErrPSBTMismatch)
+		}
+		for i, input := range packet.Inputs {
+			for _, sig := range input.PartialSigs {
+				known := false
+				for _, existing := range combined.Inputs[i].PartialSigs {
+					known = known || bytes.Equal(existing.PubKey, sig.PubKey)
+				}
+				if !known {
+					combined.Inputs[i].PartialSigs = append(combined.Inputs[i].PartialSigs, sig)
+				}
+			}
+		}
+	}
+	return combined, nil
+}
+
+// Signatures returns the smallest number of signatures of the inputs of the
+// PSBT.
+func Signatures(packet *psbt.Packet) int {
+	if len(packet.Inputs) == 0 {
+		return 0
+	}
+	signatures := len(packet.Inputs[0].PartialSigs)
+	for _, input := range packet.Inputs[1:] {
+		if len(input.PartialSigs) < signatures {
+			signatures = len(input.PartialSigs)
+		}
+	}
+	return signatures
+}
+
+// Finalize builds the witnesses of the inputs of the PSBT from the
+// signatures of the threshold of cosigners and extracts the signed
+// transaction.
+func (w *Wallet) Finalize(packet *psbt.Packet) (*wire.MsgTx, error) {
+	if Signatures(packet) < w.Threshold {
+		return nil, errors.New(ErrNotEnoughSignatures)
+	}
+
+	// The witness holds exactly the threshold of signatures.
+	for i := range packet.Inputs {
+		packet.Inputs[i].PartialSigs = packet.Inputs[i].PartialSigs[:w.Threshold]
+	}
+	if err := psbt.MaybeFinalizeAll(packet); err != nil {
+		return nil, err
+	}
+	return psbt.Extract(packet)
+}
+
+// DecodePSBT decodes the base64 encoded PSBT.
+func DecodePSBT(encoded string) (*psbt.Packet, error) {
+	packet, err := psbt.NewFromRawBytes(strings.NewReader(strings.TrimSpace(encoded)), true)
+	if err != nil {
+		return nil, errors.E(errors.Invalid, fmt.Errorf("invalid PSBT: %v", err))
+	}
+	return packet, nil
+}
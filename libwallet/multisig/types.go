@@ -0,0 +1,113 @@
+package multisig
+
+import (
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// ReceiveBranch and ChangeBranch are the branches of the addresses
+	// derived from the cosigner keys.
+	ReceiveBranch uint32 = 0
+	ChangeBranch  uint32 = 1
+
+	// GapLimit is the number of consecutive unused addresses a scan of a
+	// branch stops after.
+	GapLimit = 20
+
+	// MaxCosigners is the largest number of keys of a standard P2WSH
+	// multisig script.
+	MaxCosigners = 15
+
+	// configVersion is the version of the exported wallet config files.
+	configVersion = 1
+)
+
+// NetParams are the parameters of the network of the addresses and keys of
+// a multisig wallet.
+type NetParams struct {
+	// Bech32HRP is the human-readable part of the segwit addresses.
+	Bech32HRP string
+	// HDPrivateKeyID and HDPublicKeyID are the versions of the extended
+	// keys.
+	HDPrivateKeyID [4]byte
+	HDPublicKeyID  [4]byte
+	// CoinType is the BIP44 coin type of the derivation path of the keys.
+	CoinType uint32
+}
+
+// Cosigner is one of the keys of a multisig wallet.
+type Cosigner struct {
+	Name string `json:"name"`
+	// XPub is the extended public key of the account of the cosigner. The
+	// addresses are derived from its receive and change branches.
+	XPub string `json:"xpub"`
+	// Fingerprint is the hex encoded fingerprint of the master key of the
+	// cosigner and Path the derivation path of XPub from it. Signers find
+	// their keys in PSBTs with them. They are optional for watch-only
+	// cosigners.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Path        string `json:"path,omitempty"`
+}
+
+// Wallet is an m-of-n P2WSH multisig wallet. Its addresses are built from the
+// keys of the cosigners sorted as in BIP67.
+type Wallet struct {
+	ID   int    `storm:"id,increment"`
+	Name string `storm:"unique"`
+	// Asset is the asset type of the wallet, BTC or LTC.
+	Asset     string
+	Threshold int
+	Cosigners []*Cosigner
+
+	// SignerWalletID is the ID of the wallet whose seed holds the key of
+	// one of the cosigners. Zero for watch-only wallets.
+	SignerWalletID int
+	// ElectrumServer and ElectrumCert are the server the wallet finds its
+	// outputs and broadcasts its transactions with.
+	ElectrumServer string
+	ElectrumCert   string
+
+	// NextReceiveIndex and NextChangeIndex are the indexes of the first
+	// unused addresses of the branches.
+	NextReceiveIndex uint32
+	NextChangeIndex  uint32
+
+	CreatedAt int64
+}
+
+// WatchOnly returns true if none of the cosigner keys can sign here.
+func (w *Wallet) WatchOnly() bool {
+	return w.SignerWalletID == 0
+}
+
+// Config is the shareable setup of a multisig wallet. Each cosigner imports
+// it to watch the wallet and sign its transactions.
+type Config struct {
+	Version   int         `json:"version"`
+	Name      string      `json:"name"`
+	Asset     string      `json:"asset"`
+	Network   string      `json:"network"`
+	Threshold int         `json:"threshold"`
+	Cosigners []*Cosigner `json:"cosigners"`
+	// Descriptor is the output descriptor of the wallet. Configs with a
+	// descriptor may omit the threshold and cosigners.
+	Descriptor string `json:"descriptor,omitempty"`
+}
+
+// UTXO is an unspent output of a multisig wallet.
+type UTXO struct {
+	OutPoint wire.OutPoint
+	Value    int64
+	// Height is the height of the block of the output, zero or less for
+	// unconfirmed outputs.
+	Height int32
+	// Branch and Index locate the address of the output.
+	Branch uint32
+	Index  uint32
+}
+
+// Balance is the balance of a multisig wallet in atoms.
+type Balance struct {
+	Confirmed   int64
+	Unconfirmed int64
+}
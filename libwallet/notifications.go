@@ -0,0 +1,156 @@
+package libwallet
+
+import (
+	"decred.org/dcrdex/client/core"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// notificationCenterID identifies the listeners of the notification center.
+const notificationCenterID = "notification_center"
+
+// subscribeNotificationCenter subscribes the notification center to the
+// wallet, proposal, instantswap and DEX events.
+func (mgr *AssetsManager) subscribeNotificationCenter() *eventSubscriber {
+	err := mgr.Politeia.AddSyncCallback(mgr.postProposalNotification, notificationCenterID)
+	if err != nil {
+		log.Errorf("Notification center: can't listen proposal updates: %v", err)
+	}
+
+	orderNotificationListener := &instantswap.OrderNotificationListener{
+		OnOrderVerified: mgr.postSwapNotification,
+	}
+	if err := mgr.InstantSwap.AddNotificationListener(orderNotificationListener, notificationCenterID); err != nil {
+		log.Errorf("Notification center: can't listen instantswap orders: %v", err)
+	}
+
+	return &eventSubscriber{
+		onTransaction:          mgr.postTxNotification,
+		onTransactionConfirmed: mgr.postTxConfirmedNotification,
+		onDEXMatch:             mgr.postDEXMatchNotification,
+		stop: func() {
+			mgr.Politeia.RemoveSyncCallback(notificationCenterID)
+			mgr.InstantSwap.RemoveNotificationListener(notificationCenterID)
+		},
+	}
+}
+
+func (mgr *AssetsManager) postTxNotification(walletID int, tx *sharedW.Transaction) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return
+	}
+
+	n := &notifications.Notification{
+		WalletID: walletID,
+		Asset:    string(wallet.GetAssetType()),
+		Subject:  tx.Hash,
+	}
+	switch {
+	case tx.Type == txhelper.TxTypeVote:
+		n.Type = notifications.EventTicketVoted
+		n.Amount = wallet.ToAmount(tx.VoteReward).ToCoin()
+	case tx.Type == txhelper.TxTypeRevocation:
+		n.Type = notifications.EventTicketRevoked
+	case tx.Type == txhelper.TxTypeRegular && tx.Direction == txhelper.TxDirectionReceived:
+		n.Type = notifications.EventTxReceived
+		n.Amount = wallet.ToAmount(tx.Amount).ToCoin()
+	default:
+		return
+	}
+
+	if mgr.Notifications.Exists(n.Type, n.Subject) {
+		return
+	}
+	mgr.Notifications.Post(n, mgr.IsTransactionNotificationsOn())
+}
+
+// postTxConfirmedNotification posts the first confirmation of the
+// transactions received by a wallet.
+func (mgr *AssetsManager) postTxConfirmedNotification(walletID int, hash string, _ int32) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil || mgr.Notifications.Exists(notifications.EventTxConfirmed, hash) {
+		return
+	}
+
+	tx, err := wallet.GetTransactionRaw(hash)
+	if err != nil || tx.Direction != txhelper.TxDirectionReceived || tx.Type != txhelper.TxTypeRegular {
+		return
+	}
+
+	mgr.Notifications.Post(&notifications.Notification{
+		Type:     notifications.EventTxConfirmed,
+		WalletID: walletID,
+		Asset:    string(wallet.GetAssetType()),
+		Amount:   wallet.ToAmount(tx.Amount).ToCoin(),
+		Subject:  hash,
+	}, mgr.IsTransactionNotificationsOn())
+}
+
+func (mgr *AssetsManager) postProposalNotification(propName string, status utils.ProposalStatus) {
+	var details string
+	switch status {
+	case utils.ProposalStatusSynced:
+		return
+	case utils.ProposalStatusNewProposal:
+		details = notifications.ProposalNew
+	case utils.ProposalStatusVoteStarted:
+		details = notifications.ProposalVoteStarted
+	case utils.ProposalStatusVoteFinished:
+		details = notifications.ProposalVoteFinished
+	default:
+		details = notifications.ProposalUpdated
+	}
+
+	mgr.Notifications.Post(&notifications.Notification{
+		Type:    notifications.EventProposalUpdate,
+		Subject: propName,
+		Details: details,
+	}, mgr.isProposalAlertOn())
+}
+
+// isProposalAlertOn returns true if the proposal updates alert, which they do
+// unless privacy mode is on and no wallet turned on its proposal
+// notifications.
+func (mgr *AssetsManager) isProposalAlertOn() bool {
+	if !mgr.IsPrivacyModeOn() {
+		return true
+	}
+	for _, wallet := range mgr.AllDCRWallets() {
+		if wallet.ReadBoolConfigValueForKey(sharedW.ProposalNotificationConfigKey, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// postSwapNotification posts the swaps whose payout was received by the
+// destination wallet.
+func (mgr *AssetsManager) postSwapNotification(order *instantswap.Order) {
+	if order.Verification.Status != instantswap.PayoutReceived ||
+		mgr.Notifications.Exists(notifications.EventSwapCompleted, order.UUID) {
+		return
+	}
+
+	mgr.Notifications.Post(&notifications.Notification{
+		Type:     notifications.EventSwapCompleted,
+		WalletID: order.DestinationWalletID,
+		Asset:    order.ToCurrency,
+		Amount:   order.Verification.PayoutAmount,
+		Subject:  order.UUID,
+		Details:  order.FromCurrency,
+	}, true)
+}
+
+// postDEXMatchNotification posts the new matches of the DEX orders.
+func (mgr *AssetsManager) postDEXMatchNotification(note *core.MatchNote) {
+	mgr.Notifications.Post(&notifications.Notification{
+		Type:    notifications.EventDEXMatch,
+		Subject: note.MarketID,
+		Details: note.Details(),
+	}, true)
+}
@@ -0,0 +1,5 @@
+package notifications
+
+const (
+	ErrListenerAlreadyExist = "listener_already_exist"
+)
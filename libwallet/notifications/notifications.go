@@ -0,0 +1,272 @@
+package notifications
+
+import (
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+)
+
+const minutesInDay = 24 * 60
+
+// Center persists the notification history and the notification rules.
+type Center struct {
+	db *storm.DB
+
+	notificationListenersMu sync.RWMutex
+	notificationListeners   map[string]*NotificationListener
+}
+
+// NewCenter creates a notification center that uses db for persistence.
+func NewCenter(db *storm.DB) (*Center, error) {
+	if err := db.Init(&Notification{}); err != nil {
+		log.Errorf("Error initializing notifications database: %s", err.Error())
+		return nil, err
+	}
+
+	if err := db.Init(&Rule{}); err != nil {
+		log.Errorf("Error initializing notification rules database: %s", err.Error())
+		return nil, err
+	}
+
+	return &Center{
+		db:                    db,
+		notificationListeners: make(map[string]*NotificationListener),
+	}, nil
+}
+
+// Post applies the rules to n and saves it in the history unless its amount
+// is below the rule threshold. The listeners are alerted if alert is true and
+// n is neither muted nor posted during quiet hours. False is returned if n
+// was dropped.
+func (c *Center) Post(n *Notification, alert bool) bool {
+	now := time.Now()
+	if rule := c.RuleFor(n.WalletID, n.Type); rule != nil {
+		if n.Amount != 0 && abs(n.Amount) < rule.MinAmount {
+			return false
+		}
+		alert = alert && !rule.Muted && !rule.isQuiet(now)
+	}
+
+	n.Stamp = now.Unix()
+	if err := c.db.Save(n); err != nil {
+		log.Errorf("Error saving %s notification: %v", n.Type, err)
+		return false
+	}
+	c.pruneHistory()
+
+	c.notificationListenersMu.RLock()
+	defer c.notificationListenersMu.RUnlock()
+	for _, notificationListener := range c.notificationListeners {
+		if notificationListener.OnNotification != nil {
+			notificationListener.OnNotification(n, alert)
+		}
+	}
+	return true
+}
+
+// pruneHistory deletes the oldest notifications above MaxHistory.
+func (c *Center) pruneHistory() {
+	count, err := c.db.Count(&Notification{})
+	if err != nil || count <= MaxHistory {
+		return
+	}
+
+	err = c.db.Select().OrderBy("ID").Limit(count - MaxHistory).Delete(&Notification{})
+	if err != nil && err != storm.ErrNotFound {
+		log.Errorf("Error pruning notification history: %v", err)
+	}
+}
+
+// History returns the most recent notifications, newest first. All
+// notifications are returned if limit is 0.
+func (c *Center) History(limit int) ([]*Notification, error) {
+	query := c.db.Select().OrderBy("ID").Reverse()
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var history []*Notification
+	err := query.Find(&history)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Exists returns true if a notification of eventType about subject is in the
+// history.
+func (c *Center) Exists(eventType EventType, subject string) bool {
+	var n Notification
+	return c.db.Select(q.Eq("Type", eventType), q.Eq("Subject", subject)).First(&n) == nil
+}
+
+// UnreadCount returns the number of unread notifications.
+func (c *Center) UnreadCount() (int, error) {
+	return c.db.Select(q.Eq("Read", false)).Count(&Notification{})
+}
+
+// MarkRead marks the notification with the provided ID as read.
+func (c *Center) MarkRead(id int) error {
+	if err := c.db.UpdateField(&Notification{ID: id}, "Read", true); err != nil {
+		return err
+	}
+	c.publishHistoryChanged()
+	return nil
+}
+
+// MarkAllRead marks all the notifications as read.
+func (c *Center) MarkAllRead() error {
+	var unread []*Notification
+	err := c.db.Select(q.Eq("Read", false)).Find(&unread)
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	for _, n := range unread {
+		if err := c.db.UpdateField(n, "Read", true); err != nil {
+			return err
+		}
+	}
+	c.publishHistoryChanged()
+	return nil
+}
+
+// Clear deletes the notification history.
+func (c *Center) Clear() error {
+	if err := c.db.Drop(&Notification{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	if err := c.db.Init(&Notification{}); err != nil {
+		return err
+	}
+	c.publishHistoryChanged()
+	return nil
+}
+
+func (r *Rule) validate() error {
+	switch {
+	case r.MinAmount < 0:
+		return errors.New("minimum amount cannot be negative")
+	case r.QuietStart < 0 || r.QuietStart >= minutesInDay || r.QuietEnd < 0 || r.QuietEnd >= minutesInDay:
+		return errors.New("quiet hours must be within a day")
+	}
+	return nil
+}
+
+// isQuiet returns true if t is within the quiet hours of the rule.
+func (r *Rule) isQuiet(t time.Time) bool {
+	if r.QuietStart == r.QuietEnd {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if r.QuietStart < r.QuietEnd {
+		return minute >= r.QuietStart && minute < r.QuietEnd
+	}
+	return minute >= r.QuietStart || minute < r.QuietEnd
+}
+
+// specificity ranks how closely the rule matches a notification. Wallet rules
+// take precedence over event type rules.
+func (r *Rule) specificity() int {
+	var s int
+	if r.WalletID != 0 {
+		s += 2
+	}
+	if r.Type != "" {
+		s++
+	}
+	return s
+}
+
+// SaveRule creates or updates a notification rule. A rule replaces the
+// existing rule for the same wallet and event type.
+func (c *Center) SaveRule(rule *Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+
+	var existing Rule
+	err := c.db.Select(q.Eq("WalletID", rule.WalletID), q.Eq("Type", rule.Type)).First(&existing)
+	if err == nil && existing.ID != rule.ID {
+		rule.ID = existing.ID
+	} else if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return c.db.Save(rule)
+}
+
+// DeleteRule deletes the rule with the provided ID.
+func (c *Center) DeleteRule(id int) error {
+	return c.db.DeleteStruct(&Rule{ID: id})
+}
+
+// Rules returns all the notification rules.
+func (c *Center) Rules() ([]*Rule, error) {
+	var rules []*Rule
+	err := c.db.All(&rules)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// RuleFor returns the most specific rule matching the notifications of a
+// wallet and event type. A nil value is returned if no rule matches.
+func (c *Center) RuleFor(walletID int, eventType EventType) *Rule {
+	rules, err := c.Rules()
+	if err != nil {
+		log.Errorf("Error reading notification rules: %v", err)
+		return nil
+	}
+
+	var match *Rule
+	for _, rule := range rules {
+		if (rule.WalletID != 0 && rule.WalletID != walletID) || (rule.Type != "" && rule.Type != eventType) {
+			continue
+		}
+		if match == nil || rule.specificity() > match.specificity() {
+			match = rule
+		}
+	}
+	return match
+}
+
+func (c *Center) AddNotificationListener(notificationListener *NotificationListener, uniqueIdentifier string) error {
+	c.notificationListenersMu.Lock()
+	defer c.notificationListenersMu.Unlock()
+
+	if _, ok := c.notificationListeners[uniqueIdentifier]; ok {
+		return errors.New(ErrListenerAlreadyExist)
+	}
+
+	c.notificationListeners[uniqueIdentifier] = notificationListener
+	return nil
+}
+
+func (c *Center) RemoveNotificationListener(uniqueIdentifier string) {
+	c.notificationListenersMu.Lock()
+	defer c.notificationListenersMu.Unlock()
+
+	delete(c.notificationListeners, uniqueIdentifier)
+}
+
+func (c *Center) publishHistoryChanged() {
+	c.notificationListenersMu.RLock()
+	defer c.notificationListenersMu.RUnlock()
+
+	for _, notificationListener := range c.notificationListeners {
+		if notificationListener.OnHistoryChanged != nil {
+			notificationListener.OnHistoryChanged()
+		}
+	}
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,220 @@
+package notifications
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/testutil"
+)
+
+func newTestCenter(t *testing.T) *Center {
+	t.Helper()
+
+	c, err := NewCenter(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestPostRules(t *testing.T) {
+	c := newTestCenter(t)
+
+	alerts := make(map[EventType]bool)
+	err := c.AddNotificationListener(&NotificationListener{
+		OnNotification: func(n *Notification, alert bool) {
+			alerts[n.Type] = alert
+		},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SaveRule(&Rule{WalletID: 1, Type: EventTxReceived, Muted: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SaveRule(&Rule{Type: EventTxConfirmed, MinAmount: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		n         *Notification
+		wantSaved bool
+		wantAlert bool
+	}{
+		{
+			name:      "muted",
+			n:         &Notification{Type: EventTxReceived, WalletID: 1, Amount: 2, Subject: "a"},
+			wantSaved: true,
+		},
+		{
+			name:      "other wallet",
+			n:         &Notification{Type: EventTicketVoted, WalletID: 2, Subject: "b"},
+			wantSaved: true,
+			wantAlert: true,
+		},
+		{
+			name: "below min amount",
+			n:    &Notification{Type: EventTxConfirmed, WalletID: 2, Amount: 0.5, Subject: "c"},
+		},
+	}
+
+	for _, test := range tests {
+		delete(alerts, test.n.Type)
+		saved := c.Post(test.n, true)
+		if saved != test.wantSaved || c.Exists(test.n.Type, test.n.Subject) != test.wantSaved {
+			t.Errorf("%s: expected saved %v, got %v", test.name, test.wantSaved, saved)
+		}
+		if alert := alerts[test.n.Type]; alert != test.wantAlert {
+			t.Errorf("%s: expected alert %v, got %v", test.name, test.wantAlert, alert)
+		}
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 5, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		name      string
+		rule      *Rule
+		t         time.Time
+		wantQuiet bool
+	}{
+		{name: "no quiet hours", rule: &Rule{}, t: at(0, 0)},
+		{name: "same start and end", rule: &Rule{QuietStart: 60, QuietEnd: 60}, t: at(1, 0)},
+		{name: "within", rule: &Rule{QuietStart: 8 * 60, QuietEnd: 17 * 60}, t: at(12, 30), wantQuiet: true},
+		{name: "at start", rule: &Rule{QuietStart: 8 * 60, QuietEnd: 17 * 60}, t: at(8, 0), wantQuiet: true},
+		{name: "at end", rule: &Rule{QuietStart: 8 * 60, QuietEnd: 17 * 60}, t: at(17, 0)},
+		{name: "before midnight", rule: &Rule{QuietStart: 22 * 60, QuietEnd: 7 * 60}, t: at(23, 59), wantQuiet: true},
+		{name: "after midnight", rule: &Rule{QuietStart: 22 * 60, QuietEnd: 7 * 60}, t: at(6, 59), wantQuiet: true},
+		{name: "outside spanning midnight", rule: &Rule{QuietStart: 22 * 60, QuietEnd: 7 * 60}, t: at(12, 0)},
+	}
+
+	for _, test := range tests {
+		if quiet := test.rule.isQuiet(test.t); quiet != test.wantQuiet {
+			t.Errorf("%s: expected quiet %v, got %v", test.name, test.wantQuiet, quiet)
+		}
+	}
+
+	c := newTestCenter(t)
+	var alerted, posted bool
+	err := c.AddNotificationListener(&NotificationListener{
+		OnNotification: func(_ *Notification, alert bool) {
+			posted, alerted = true, alert
+		},
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The quiet hours cover the current minute.
+	now := time.Now()
+	minute := now.Hour()*60 + now.Minute()
+	if err := c.SaveRule(&Rule{Type: EventTxReceived, QuietStart: minute, QuietEnd: (minute + 2) % minutesInDay}); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Post(&Notification{Type: EventTxReceived, Subject: "quiet"}, true) || !posted || alerted {
+		t.Errorf("expected a quiet notification to be saved without an alert, got saved %v and alert %v", posted, alerted)
+	}
+
+	if err := c.SaveRule(&Rule{Type: EventTxReceived, QuietStart: 24 * 60}); err == nil {
+		t.Error("expected an error for quiet hours outside a day")
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	c := newTestCenter(t)
+
+	var historyChanges int
+	err := c.AddNotificationListener(&NotificationListener{
+		OnHistoryChanged: func() { historyChanges++ },
+	}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, subject := range []string{"a", "b", "c"} {
+		c.Post(&Notification{Type: EventTxReceived, Subject: subject}, false)
+	}
+
+	unreadCount := func(expected int) {
+		t.Helper()
+		count, err := c.UnreadCount()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != expected {
+			t.Errorf("expected %d unread notifications, got %d", expected, count)
+		}
+	}
+	unreadCount(3)
+
+	history, err := c.History(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.MarkRead(history[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	unreadCount(2)
+	// Marking a notification read again does not change the count.
+	if err := c.MarkRead(history[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	unreadCount(2)
+
+	if err := c.MarkAllRead(); err != nil {
+		t.Fatal(err)
+	}
+	unreadCount(0)
+	if historyChanges != 3 {
+		t.Errorf("expected 3 history changes, got %d", historyChanges)
+	}
+
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if history, err := c.History(0); err != nil || len(history) != 0 {
+		t.Errorf("expected an empty history, got %d notifications (%v)", len(history), err)
+	}
+}
+
+func TestPruneHistory(t *testing.T) {
+	c := newTestCenter(t)
+
+	// Fill the history in one transaction, posting each notification is slow.
+	tx, err := c.db.Begin(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < MaxHistory; i++ {
+		if err := tx.Save(&Notification{Type: EventTxReceived, Subject: fmt.Sprint(i)}); err != nil {
+			tx.Rollback()
+			t.Fatal(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Post(&Notification{Type: EventTxReceived, Subject: "newest"}, false)
+	c.Post(&Notification{Type: EventTxReceived, Subject: "latest"}, false)
+
+	history, err := c.History(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != MaxHistory {
+		t.Fatalf("expected %d notifications, got %d", MaxHistory, len(history))
+	}
+	if history[0].Subject != "latest" || history[MaxHistory-1].Subject != "2" {
+		t.Errorf("expected the two oldest notifications to be deleted, got %q to %q", history[MaxHistory-1].Subject, history[0].Subject)
+	}
+	if limited, err := c.History(10); err != nil || len(limited) != 10 {
+		t.Errorf("expected 10 notifications, got %d (%v)", len(limited), err)
+	}
+}
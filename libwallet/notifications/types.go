@@ -0,0 +1,84 @@
+package notifications
+
+// EventType is the kind of event a notification is posted for.
+type EventType string
+
+const (
+	EventTxReceived     EventType = "tx_received"
+	EventTxConfirmed    EventType = "tx_confirmed"
+	EventTicketVoted    EventType = "ticket_voted"
+	EventTicketRevoked  EventType = "ticket_revoked"
+	EventProposalUpdate EventType = "proposal_update"
+	EventDEXMatch       EventType = "dex_match"
+	EventSwapCompleted  EventType = "swap_completed"
+)
+
+// Details of the proposal update notifications.
+const (
+	ProposalNew          = "new"
+	ProposalVoteStarted  = "vote_started"
+	ProposalVoteFinished = "vote_finished"
+	ProposalUpdated      = "updated"
+)
+
+// EventTypes are all the event types, in display order.
+var EventTypes = []EventType{
+	EventTxReceived,
+	EventTxConfirmed,
+	EventTicketVoted,
+	EventTicketRevoked,
+	EventProposalUpdate,
+	EventDEXMatch,
+	EventSwapCompleted,
+}
+
+// MaxHistory is the number of notifications kept in the history. The oldest
+// notifications are deleted when it is exceeded.
+const MaxHistory = 1000
+
+// Notification is a wallet event saved in the notification history.
+type Notification struct {
+	ID   int       `storm:"id,increment" json:"id"`
+	Type EventType `storm:"index" json:"type"`
+	// WalletID is zero for the events that are not tied to a wallet, e.g.
+	// proposal updates.
+	WalletID int    `storm:"index" json:"walletID"`
+	Asset    string `json:"asset"`
+	// Amount is in coins of Asset. It is zero for the events without amount.
+	Amount float64 `json:"amount"`
+	// Subject identifies what the event is about, e.g. the tx hash, the
+	// proposal name, the DEX market or the swap order UUID.
+	Subject string `json:"subject"`
+	Details string `json:"details"`
+	Stamp   int64  `storm:"index" json:"stamp"`
+	Read    bool   `storm:"index" json:"read"`
+}
+
+// Rule filters the notifications of a wallet and event type. A zero WalletID
+// matches all wallets and an empty Type matches all event types. The most
+// specific rule matching a notification is applied.
+type Rule struct {
+	ID       int       `storm:"id,increment" json:"id"`
+	WalletID int       `storm:"index" json:"walletID"`
+	Type     EventType `storm:"index" json:"type"`
+	// Muted notifications are saved in the history without alerts.
+	Muted bool `json:"muted"`
+	// MinAmount is the smallest amount, in coins, of the saved notifications.
+	// It does not apply to the events without amount.
+	MinAmount float64 `json:"minAmount"`
+	// QuietStart and QuietEnd are the minutes since midnight, local time,
+	// between which notifications are saved without alerting the user. The
+	// quiet hours may span midnight. There are no quiet hours if both are
+	// equal.
+	QuietStart int `json:"quietStart"`
+	QuietEnd   int `json:"quietEnd"`
+}
+
+// NotificationListener receives the saved notifications and the changes to
+// the notification history.
+type NotificationListener struct {
+	// OnNotification is called for each saved notification. alert is false
+	// if the user should not be alerted, e.g. during quiet hours.
+	OnNotification   func(n *Notification, alert bool)
+	OnHistoryChanged func()
+}
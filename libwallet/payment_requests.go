@@ -0,0 +1,78 @@
+package libwallet
+
+import (
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/payrequests"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// CreatePaymentRequest reserves a fresh address of the wallet account for a
+// payment request of amount coins. Zero requests any amount. The request
+// does not expire if expiry is zero.
+func (mgr *AssetsManager) CreatePaymentRequest(walletID int, account int32, amount float64, memo string, expiry time.Duration) (*payrequests.Request, error) {
+	wallet := mgr.WalletWithID(walletID)
+	if wallet == nil {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+
+	atoms, err := coinToAtoms(wallet.GetAssetType(), amount)
+	if err != nil {
+		return nil, err
+	}
+	if atoms < 0 {
+		return nil, errors.New(payrequests.ErrInvalidAmount)
+	}
+
+	address, err := wallet.NextAddress(account)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &payrequests.Request{
+		WalletID: walletID,
+		Asset:    string(wallet.GetAssetType()),
+		Account:  account,
+		Address:  address,
+		Amount:   atoms,
+		Memo:     memo,
+	}
+	if expiry > 0 {
+		request.ExpiresAt = time.Now().Add(expiry).Unix()
+	}
+	if err := mgr.PaymentRequests.Create(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// PaymentRequestURI returns the BIP21 URI of a payment request.
+func (mgr *AssetsManager) PaymentRequestURI(request *payrequests.Request) string {
+	var amount float64
+	if wallet := mgr.WalletWithID(request.WalletID); wallet != nil && request.Amount > 0 {
+		amount = wallet.ToAmount(request.Amount).ToCoin()
+	}
+	return payrequests.URI(utils.AssetType(request.Asset), request.Address, amount, request.Memo)
+}
+
+// subscribePaymentRequests records the payments to the payment request
+// addresses seen in the wallet transactions.
+func (mgr *AssetsManager) subscribePaymentRequests() *eventSubscriber {
+	return &eventSubscriber{
+		onTransaction: mgr.recordPayments,
+	}
+}
+
+// recordPayments records the outputs of tx that pay to the wallet's external
+// addresses, which payment request addresses are.
+func (mgr *AssetsManager) recordPayments(walletID int, tx *sharedW.Transaction) {
+	for _, output := range tx.Outputs {
+		if output.Address == "" || output.Internal || output.AccountNumber < 0 {
+			continue
+		}
+		mgr.PaymentRequests.RecordPayment(walletID, output.Address, tx.Hash, output.Amount)
+	}
+}
@@ -0,0 +1,7 @@
+package payrequests
+
+const (
+	ErrListenerAlreadyExist = "listener_already_exist"
+	ErrInvalidAmount        = "invalid_amount"
+	ErrRequestNotFound      = "request_not_found"
+)
@@ -0,0 +1,228 @@
+package payrequests
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// Manager persists the payment requests and records the payments they
+// receive.
+type Manager struct {
+	db *storm.DB
+
+	requestListenersMu sync.RWMutex
+	requestListeners   map[string]*RequestListener
+}
+
+// NewManager creates a payment request manager that uses db for persistence.
+func NewManager(db *storm.DB) (*Manager, error) {
+	if err := db.Init(&Request{}); err != nil {
+		log.Errorf("Error initializing payment requests database: %s", err.Error())
+		return nil, err
+	}
+
+	return &Manager{
+		db:               db,
+		requestListeners: make(map[string]*RequestListener),
+	}, nil
+}
+
+// Status returns the payment state of the request at now.
+func (r *Request) Status(now time.Time) Status {
+	switch {
+	case r.Received == 0 && r.ExpiresAt > 0 && now.Unix() >= r.ExpiresAt:
+		return StatusExpired
+	case r.Received == 0:
+		return StatusOpen
+	case r.Amount == 0 || r.Received == r.Amount:
+		return StatusPaid
+	case r.Received < r.Amount:
+		return StatusUnderpaid
+	default:
+		return StatusOverpaid
+	}
+}
+
+// Settled returns true if the request received at least the requested
+// amount.
+func (r *Request) Settled() bool {
+	return r.Received > 0 && r.Received >= r.Amount
+}
+
+// Create saves a new request. The caller reserves a fresh address of the
+// wallet for the request.
+func (m *Manager) Create(request *Request) error {
+	if request.Amount < 0 {
+		return errors.New(ErrInvalidAmount)
+	}
+	if request.Address == "" {
+		return errors.New("missing payment request address")
+	}
+
+	request.ID = 0
+	request.Memo = strings.TrimSpace(request.Memo)
+	request.CreatedAt = time.Now().Unix()
+	request.Received, request.TxHashes, request.PaidAt = 0, nil, 0
+	if err := m.db.Save(request); err != nil {
+		return err
+	}
+
+	m.publishRequestsChanged(request.WalletID)
+	return nil
+}
+
+// Requests returns the requests of a wallet, newest first.
+func (m *Manager) Requests(walletID int) ([]*Request, error) {
+	var requests []*Request
+	err := m.db.Select(q.Eq("WalletID", walletID)).OrderBy("CreatedAt", "ID").Reverse().Find(&requests)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Request returns the request with the given ID.
+func (m *Manager) Request(id int) (*Request, error) {
+	var request Request
+	if err := m.db.One("ID", id, &request); err != nil {
+		if err == storm.ErrNotFound {
+			return nil, errors.New(ErrRequestNotFound)
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Delete deletes a request. Payments to its address are no longer tracked.
+func (m *Manager) Delete(id int) error {
+	request, err := m.Request(id)
+	if err != nil {
+		return err
+	}
+	if err := m.db.DeleteStruct(request); err != nil {
+		return err
+	}
+
+	m.publishRequestsChanged(request.WalletID)
+	return nil
+}
+
+// DeleteWalletRequests deletes the requests of a wallet.
+func (m *Manager) DeleteWalletRequests(walletID int) error {
+	err := m.db.Select(q.Eq("WalletID", walletID)).Delete(&Request{})
+	if err != nil && err != storm.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+// RecordPayment adds amount to the request of the wallet address. Payments
+// of a transaction already recorded for the request are ignored. The
+// request is returned if the payment is recorded.
+func (m *Manager) RecordPayment(walletID int, address, txHash string, amount int64) *Request {
+	var request Request
+	err := m.db.Select(q.Eq("WalletID", walletID), q.Eq("Address", address)).First(&request)
+	if err != nil {
+		if err != storm.ErrNotFound {
+			log.Errorf("Error reading payment request of %s: %v", address, err)
+		}
+		return nil
+	}
+
+	for _, hash := range request.TxHashes {
+		if hash == txHash {
+			return nil
+		}
+	}
+
+	settled := request.Settled()
+	request.Received += amount
+	request.TxHashes = append(request.TxHashes, txHash)
+	if !settled && request.Settled() {
+		request.PaidAt = time.Now().Unix()
+	}
+	if err := m.db.Save(&request); err != nil {
+		log.Errorf("Error saving payment to request %d: %v", request.ID, err)
+		return nil
+	}
+
+	m.requestListenersMu.RLock()
+	defer m.requestListenersMu.RUnlock()
+	for _, requestListener := range m.requestListeners {
+		if requestListener.OnRequestPaid != nil {
+			requestListener.OnRequestPaid(&request)
+		}
+	}
+	return &request
+}
+
+// URIScheme returns the BIP21 URI scheme of an asset.
+func URIScheme(asset utils.AssetType) string {
+	switch asset {
+	case utils.BTCWalletAsset:
+		return "bitcoin"
+	case utils.LTCWalletAsset:
+		return "litecoin"
+	case utils.DCRWalletAsset:
+		return "decred"
+	}
+	return strings.ToLower(string(asset))
+}
+
+// URI returns the BIP21 URI of a payment to address. amount is in coins and
+// is omitted if it is zero, as is an empty memo.
+func URI(asset utils.AssetType, address string, amount float64, memo string) string {
+	params := make([]string, 0, 2)
+	if amount > 0 {
+		params = append(params, "amount="+strconv.FormatFloat(amount, 'f', -1, 64))
+	}
+	if memo != "" {
+		// BIP21 uses percent encoding, spaces included.
+		params = append(params, "message="+strings.ReplaceAll(url.QueryEscape(memo), "+", "%20"))
+	}
+
+	uri := URIScheme(asset) + ":" + address
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+	return uri
+}
+
+func (m *Manager) AddRequestListener(requestListener *RequestListener, uniqueIdentifier string) error {
+	m.requestListenersMu.Lock()
+	defer m.requestListenersMu.Unlock()
+
+	if _, ok := m.requestListeners[uniqueIdentifier]; ok {
+		return errors.New(ErrListenerAlreadyExist)
+	}
+
+	m.requestListeners[uniqueIdentifier] = requestListener
+	return nil
+}
+
+func (m *Manager) RemoveRequestListener(uniqueIdentifier string) {
+	m.requestListenersMu.Lock()
+	defer m.requestListenersMu.Unlock()
+
+	delete(m.requestListeners, uniqueIdentifier)
+}
+
+func (m *Manager) publishRequestsChanged(walletID int) {
+	m.requestListenersMu.RLock()
+	defer m.requestListenersMu.RUnlock()
+
+	for _, requestListener := range m.requestListeners {
+		if requestListener.OnRequestsChanged != nil {
+			requestListener.OnRequestsChanged(walletID)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package payrequests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crypto-power/cryptopower/libwallet/internal/testutil"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	m, err := NewManager(testutil.OpenDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRequestStatus(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour).Unix(), now.Add(time.Hour).Unix()
+
+	tests := []struct {
+		name    string
+		request Request
+		want    Status
+	}{
+		{"open", Request{Amount: 100, ExpiresAt: future}, StatusOpen},
+		{"no expiry", Request{Amount: 100}, StatusOpen},
+		{"expired", Request{Amount: 100, ExpiresAt: past}, StatusExpired},
+		{"underpaid", Request{Amount: 100, Received: 60}, StatusUnderpaid},
+		{"underpaid after expiry", Request{Amount: 100, Received: 60, ExpiresAt: past}, StatusUnderpaid},
+		{"paid", Request{Amount: 100, Received: 100}, StatusPaid},
+		{"any amount paid", Request{Received: 1}, StatusPaid},
+		{"overpaid", Request{Amount: 100, Received: 101}, StatusOverpaid},
+	}
+
+	for _, test := range tests {
+		if got := test.request.Status(now); got != test.want {
+			t.Errorf("%s: expected %s, got %s", test.name, test.want, got)
+		}
+	}
+}
+
+func TestRecordPayment(t *testing.T) {
+	m := newTestManager(t)
+
+	request := &Request{WalletID: 1, Address: "addr1", Amount: 100}
+	if err := m.Create(request); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Create(&Request{WalletID: 1, Address: "addr1"}); err == nil {
+		t.Fatal("expected an error reusing a request address")
+	}
+	if err := m.Create(&Request{WalletID: 1, Address: "addr2", Amount: -1}); err == nil {
+		t.Fatal("expected an error creating a negative amount request")
+	}
+
+	var paid int
+	err := m.AddRequestListener(&RequestListener{OnRequestPaid: func(*Request) { paid++ }}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.RecordPayment(2, "addr1", "tx1", 60) != nil {
+		t.Fatal("recorded a payment to another wallet")
+	}
+	if m.RecordPayment(1, "addr3", "tx1", 60) != nil {
+		t.Fatal("recorded a payment to an address without request")
+	}
+
+	updated := m.RecordPayment(1, "addr1", "tx1", 60)
+	if updated == nil || updated.Status(time.Now()) != StatusUnderpaid || updated.PaidAt != 0 {
+		t.Fatalf("unexpected request after first payment %+v", updated)
+	}
+	if m.RecordPayment(1, "addr1", "tx1", 60) != nil {
+		t.Fatal("recorded the same transaction twice")
+	}
+
+	updated = m.RecordPayment(1, "addr1", "tx2", 40)
+	if updated == nil || updated.Status(time.Now()) != StatusPaid || updated.PaidAt == 0 || len(updated.TxHashes) != 2 {
+		t.Fatalf("unexpected request after second payment %+v", updated)
+	}
+	if paid != 2 {
+		t.Fatalf("expected 2 paid notifications, got %d", paid)
+	}
+
+	requests, err := m.Requests(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 || requests[0].Received != 100 {
+		t.Fatalf("unexpected requests %+v", requests)
+	}
+
+	if err := m.DeleteWalletRequests(1); err != nil {
+		t.Fatal(err)
+	}
+	if requests, _ := m.Requests(1); len(requests) != 0 {
+		t.Fatalf("expected no requests, got %d", len(requests))
+	}
+}
+
+func TestURI(t *testing.T) {
+	tests := []struct {
+		asset   utils.AssetType
+		amount  float64
+		memo    string
+		wantURI string
+	}{
+		{utils.BTCWalletAsset, 0, "", "bitcoin:addr"},
+		{utils.LTCWalletAsset, 0.5, "", "litecoin:addr?amount=0.5"},
+		{utils.DCRWalletAsset, 1.00000001, "Invoice #1 & co", "decred:addr?amount=1.00000001&message=Invoice%20%231%20%26%20co"},
+	}
+
+	for _, test := range tests {
+		if uri := URI(test.asset, "addr", test.amount, test.memo); uri != test.wantURI {
+			t.Errorf("expected %s, got %s", test.wantURI, uri)
+		}
+	}
+}
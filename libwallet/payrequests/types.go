@@ -0,0 +1,52 @@
+package payrequests
+
+// Status is the payment state of a request.
+type Status string
+
+const (
+	// StatusOpen requests have not received any payment yet.
+	StatusOpen Status = "open"
+	// StatusUnderpaid requests have received less than the requested amount.
+	StatusUnderpaid Status = "underpaid"
+	// StatusPaid requests have received exactly the requested amount, or any
+	// amount if the request has no amount.
+	StatusPaid Status = "paid"
+	// StatusOverpaid requests have received more than the requested amount.
+	StatusOverpaid Status = "overpaid"
+	// StatusExpired requests expired before receiving any payment.
+	StatusExpired Status = "expired"
+)
+
+// Request is a payment request tied to a fresh receive address of a wallet.
+type Request struct {
+	ID       int    `storm:"id,increment" json:"id"`
+	WalletID int    `storm:"index" json:"walletID"`
+	Asset    string `json:"asset"`
+	Account  int32  `json:"account"`
+	// Address is reserved for the request when it is created. It is not
+	// handed out for anything else.
+	Address string `storm:"unique" json:"address"`
+	// Amount is the requested amount in atoms. Zero requests any amount.
+	Amount int64  `json:"amount"`
+	Memo   string `json:"memo"`
+
+	CreatedAt int64 `storm:"index" json:"createdAt"`
+	// ExpiresAt is zero for the requests that do not expire.
+	ExpiresAt int64 `json:"expiresAt"`
+
+	// Received is the total amount, in atoms, paid to Address by the
+	// transactions in TxHashes.
+	Received int64    `json:"received"`
+	TxHashes []string `json:"txHashes"`
+	// PaidAt is the time of the first payment that covered the requested
+	// amount.
+	PaidAt int64 `json:"paidAt"`
+}
+
+// RequestListener receives the changes to the payment requests.
+type RequestListener struct {
+	// OnRequestPaid is called when a payment to a request is recorded.
+	OnRequestPaid func(request *Request)
+	// OnRequestsChanged is called when requests are created or deleted.
+	OnRequestsChanged func(walletID int)
+}
@@ -0,0 +1,128 @@
+package libwallet
+
+import (
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/portfolio"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// portfolioPricesID identifies the rate listener that caches the prices used
+// to value past balances.
+const portfolioPricesID = "portfolio_prices"
+
+// listenForPortfolioPrices caches the price of each asset every time the
+// rates are refreshed.
+func (mgr *AssetsManager) listenForPortfolioPrices() {
+	rateListener := &ext.RateListener{
+		OnRateUpdated: mgr.recordPortfolioPrices,
+	}
+	if err := mgr.RateSource.AddRateListener(rateListener, portfolioPricesID); err != nil {
+		log.Errorf("Error adding portfolio rate listener: %v", err)
+	}
+}
+
+func (mgr *AssetsManager) recordPortfolioPrices() {
+	now := time.Now()
+	for _, market := range values.AssetExchangeMarketValue {
+		ticker := mgr.RateSource.GetTicker(market, true)
+		if ticker == nil {
+			continue
+		}
+		if err := mgr.PriceCache.Record(market.String(), ticker.LastTradePrice, now); err != nil {
+			log.Errorf("Error caching %s price: %v", market, err)
+		}
+	}
+}
+
+// PortfolioHistory rebuilds the balance history of each asset over rng from
+// the stored wallet transactions. The balances are valued in the fiat
+// currency with the ISO 4217 code using the cached daily prices and the
+// current FX rate. The total series holds the fiat value of all the assets.
+func (mgr *AssetsManager) PortfolioHistory(rng portfolio.Range, code string) (assets []*portfolio.Series, total *portfolio.Series, err error) {
+	type walletHistory struct {
+		wallet  sharedW.Asset
+		current int64
+		txs     []*sharedW.Transaction
+	}
+
+	histories := make(map[utils.AssetType][]*walletHistory)
+	first := time.Now().Unix()
+	for _, wallet := range mgr.AllWallets() {
+		accounts, err := wallet.GetAccountsRaw()
+		if err != nil {
+			return nil, nil, err
+		}
+		var current int64
+		for _, account := range accounts.Accounts {
+			current += account.Balance.Total.ToInt()
+		}
+
+		txs, err := wallet.GetTransactionsRaw(0, 0, utils.TxFilterAll, true, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tx := range txs {
+			if tx.Timestamp > 0 && tx.Timestamp < first {
+				first = tx.Timestamp
+			}
+		}
+
+		assetType := wallet.GetAssetType()
+		histories[assetType] = append(histories[assetType], &walletHistory{wallet: wallet, current: current, txs: txs})
+	}
+
+	now := time.Now()
+	stamps := rng.Stamps(now, first)
+	total = &portfolio.Series{Points: make([]portfolio.Point, len(stamps))}
+	for i, stamp := range stamps {
+		total.Points[i].Stamp = stamp
+	}
+
+	// Past balances are valued with the current FX rate.
+	fxRate, err := mgr.USDToFiat(1, code)
+	if err != nil {
+		fxRate = 0
+	}
+
+	for _, assetType := range []utils.AssetType{utils.DCRWalletAsset, utils.BTCWalletAsset, utils.LTCWalletAsset} {
+		wallets, ok := histories[assetType]
+		if !ok {
+			continue
+		}
+
+		balances := make([]int64, len(stamps))
+		for _, history := range wallets {
+			for i, balance := range portfolio.BalanceHistory(history.current, history.txs, stamps) {
+				balances[i] += balance
+			}
+		}
+
+		// The asset is valued at zero if no price is known.
+		var prices []*portfolio.PricePoint
+		var currentPrice float64
+		if market, ok := values.AssetExchangeMarketValue[assetType]; ok && mgr.ExchangeRateFetchingEnabled() {
+			if prices, err = mgr.PriceCache.Prices(market.String(), stamps[0]); err != nil {
+				return nil, nil, err
+			}
+			if ticker := mgr.RateSource.GetTicker(market, true); ticker != nil {
+				currentPrice = ticker.LastTradePrice
+			}
+		}
+
+		series := &portfolio.Series{Asset: string(assetType), Points: make([]portfolio.Point, len(stamps))}
+		toAmount := wallets[0].wallet.ToAmount
+		for i, stamp := range stamps {
+			coins := toAmount(balances[i]).ToCoin()
+			fiat := coins * portfolio.PriceAt(prices, stamp, currentPrice) * fxRate
+			series.Points[i] = portfolio.Point{Stamp: stamp, Balance: coins, Fiat: fiat}
+			total.Points[i].Fiat += fiat
+		}
+		assets = append(assets, series)
+	}
+
+	return assets, total, nil
+}
@@ -0,0 +1,201 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/asdine/storm"
+	"github.com/asdine/storm/q"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+)
+
+// Range is the period covered by a balance history.
+type Range string
+
+const (
+	RangeWeek  Range = "1W"
+	RangeMonth Range = "1M"
+	RangeYear  Range = "1Y"
+	RangeAll   Range = "All"
+)
+
+// Ranges are the selectable balance history periods.
+var Ranges = []Range{RangeWeek, RangeMonth, RangeYear, RangeAll}
+
+// Number of points in a balance history. RangeAll uses maxPoints spread
+// evenly from the first transaction.
+var rangePoints = map[Range]int{
+	RangeWeek:  28, // every 6 hours
+	RangeMonth: 30, // daily
+	RangeYear:  52, // weekly
+}
+
+const maxPoints = 60
+
+// Start returns the beginning of the range ending at now. The start of
+// RangeAll is the time of the first transaction, first.
+func (r Range) Start(now time.Time, first int64) time.Time {
+	switch r {
+	case RangeWeek:
+		return now.AddDate(0, 0, -7)
+	case RangeMonth:
+		return now.AddDate(0, -1, 0)
+	case RangeYear:
+		return now.AddDate(-1, 0, 0)
+	}
+	if first <= 0 || first > now.Unix() {
+		return now.AddDate(0, 0, -7)
+	}
+	return time.Unix(first, 0)
+}
+
+// Stamps returns the unix timestamps of the points of a balance history of
+// the range ending at now.
+func (r Range) Stamps(now time.Time, first int64) []int64 {
+	points, ok := rangePoints[r]
+	if !ok {
+		points = maxPoints
+	}
+
+	start := r.Start(now, first).Unix()
+	step := (now.Unix() - start) / int64(points)
+	if step <= 0 {
+		step = 1
+	}
+
+	// The rest of the integer division is left to the last step rather than
+	// adding a point right before now.
+	stamps := make([]int64, 0, points+1)
+	for i := 0; i < points; i++ {
+		stamp := start + int64(i)*step
+		if stamp >= now.Unix() {
+			break
+		}
+		stamps = append(stamps, stamp)
+	}
+	return append(stamps, now.Unix())
+}
+
+// Point is the balance of a wallet or portfolio at a point in time.
+type Point struct {
+	Stamp   int64
+	Balance float64 // in coins, zero for the total series
+	Fiat    float64 // zero if no price is known
+}
+
+// Series is the balance history of an asset, or of the whole portfolio if
+// Asset is empty.
+type Series struct {
+	Asset  string
+	Points []Point
+}
+
+// PricePoint is the price of a market cached on a day.
+type PricePoint struct {
+	ID     string  `storm:"id"`
+	Market string  `storm:"index"`
+	Day    int64   `storm:"index"` // unix timestamp at 00:00 UTC
+	Price  float64 // in USD
+}
+
+// PriceCache persists one price per market and day so balances can be
+// valued at past dates.
+type PriceCache struct {
+	db *storm.DB
+}
+
+// NewPriceCache creates a price cache that uses db for persistence.
+func NewPriceCache(db *storm.DB) (*PriceCache, error) {
+	if err := db.Init(&PricePoint{}); err != nil {
+		return nil, fmt.Errorf("error initializing price cache database: %w", err)
+	}
+	return &PriceCache{db: db}, nil
+}
+
+func dayStart(stamp int64) int64 {
+	return stamp - stamp%int64((24*time.Hour).Seconds())
+}
+
+// Record saves the price of market at time at, replacing the price
+// previously recorded for the same day.
+func (c *PriceCache) Record(market string, price float64, at time.Time) error {
+	if price <= 0 {
+		return nil
+	}
+	day := dayStart(at.Unix())
+	return c.db.Save(&PricePoint{
+		ID:     fmt.Sprintf("%s:%d", market, day),
+		Market: market,
+		Day:    day,
+		Price:  price,
+	})
+}
+
+// Prices returns the prices of market recorded since the day of the since
+// timestamp, oldest first.
+func (c *PriceCache) Prices(market string, since int64) ([]*PricePoint, error) {
+	var prices []*PricePoint
+	query := c.db.Select(q.Eq("Market", market), q.Gte("Day", dayStart(since))).OrderBy("Day")
+	if err := query.Find(&prices); err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// PriceAt returns the price recorded for the latest day not after stamp.
+// The earliest price is used for stamps before the first recorded day and
+// fallback is returned if no price is recorded.
+func PriceAt(prices []*PricePoint, stamp int64, fallback float64) float64 {
+	if len(prices) == 0 {
+		return fallback
+	}
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Day > stamp
+	})
+	if i == 0 {
+		return prices[0].Price
+	}
+	return prices[i-1].Price
+}
+
+// TxBalanceDelta returns the change in the balance of a wallet caused by tx.
+func TxBalanceDelta(tx *sharedW.Transaction) int64 {
+	if tx.Type == txhelper.TxTypeVote {
+		return tx.VoteReward
+	}
+
+	switch tx.Direction {
+	case txhelper.TxDirectionReceived:
+		return tx.Amount
+	case txhelper.TxDirectionSent:
+		return -(tx.Amount + tx.Fee)
+	case txhelper.TxDirectionTransferred:
+		return -tx.Fee
+	}
+	return 0
+}
+
+// BalanceHistory rebuilds the balance of a wallet at each of the sorted
+// stamps from its current balance by reverting the transactions that
+// happened after each stamp.
+func BalanceHistory(current int64, txs []*sharedW.Transaction, stamps []int64) []int64 {
+	sorted := make([]*sharedW.Transaction, len(txs))
+	copy(sorted, txs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp > sorted[j].Timestamp
+	})
+
+	balances := make([]int64, len(stamps))
+	balance, next := current, 0
+	for i := len(stamps) - 1; i >= 0; i-- {
+		for next < len(sorted) && sorted[next].Timestamp > stamps[i] {
+			balance -= TxBalanceDelta(sorted[next])
+			next++
+		}
+		balances[i] = balance
+	}
+	return balances
+}
@@ -0,0 +1,127 @@
+package portfolio
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/txhelper"
+)
+
+func TestStamps(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	day := int64((24 * time.Hour).Seconds())
+
+	tests := []struct {
+		name       string
+		r          Range
+		first      int64
+		wantPoints int
+		wantStart  int64
+	}{
+		{"week", RangeWeek, 0, 29, now.AddDate(0, 0, -7).Unix()},
+		{"month", RangeMonth, 0, 31, now.AddDate(0, -1, 0).Unix()},
+		{"year", RangeYear, 0, 53, now.AddDate(-1, 0, 0).Unix()},
+		{"all from first tx", RangeAll, now.Unix() - 120*day, maxPoints + 1, now.Unix() - 120*day},
+		{"all without txs", RangeAll, 0, maxPoints + 1, now.AddDate(0, 0, -7).Unix()},
+		{"all with future first tx", RangeAll, now.Unix() + day, maxPoints + 1, now.AddDate(0, 0, -7).Unix()},
+		{"all from a second ago", RangeAll, now.Unix() - 1, 2, now.Unix() - 1},
+	}
+
+	for _, test := range tests {
+		stamps := test.r.Stamps(now, test.first)
+		if len(stamps) != test.wantPoints {
+			t.Errorf("%s: expected %d points, got %d", test.name, test.wantPoints, len(stamps))
+			continue
+		}
+		if stamps[0] != test.wantStart || stamps[len(stamps)-1] != now.Unix() {
+			t.Errorf("%s: expected stamps from %d to %d, got %d to %d", test.name,
+				test.wantStart, now.Unix(), stamps[0], stamps[len(stamps)-1])
+		}
+		for i := 1; i < len(stamps); i++ {
+			if stamps[i] <= stamps[i-1] {
+				t.Errorf("%s: stamps not increasing at %d", test.name, i)
+				break
+			}
+		}
+	}
+}
+
+func TestPriceAt(t *testing.T) {
+	day := int64((24 * time.Hour).Seconds())
+	prices := []*PricePoint{
+		{Day: 10 * day, Price: 1},
+		{Day: 11 * day, Price: 2},
+		{Day: 13 * day, Price: 3},
+	}
+
+	tests := []struct {
+		name   string
+		prices []*PricePoint
+		stamp  int64
+		want   float64
+	}{
+		{"no prices", nil, 10 * day, 9},
+		{"before the first day", prices, 5 * day, 1},
+		{"first day", prices, 10 * day, 1},
+		{"within a day", prices, 11*day + 3600, 2},
+		{"missing day", prices, 12*day + 3600, 2},
+		{"last day", prices, 13 * day, 3},
+		{"after the last day", prices, 20 * day, 3},
+	}
+
+	for _, test := range tests {
+		if got := PriceAt(test.prices, test.stamp, 9); got != test.want {
+			t.Errorf("%s: expected price %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestBalanceHistory(t *testing.T) {
+	received := func(stamp, amount int64) *sharedW.Transaction {
+		return &sharedW.Transaction{Timestamp: stamp, Direction: txhelper.TxDirectionReceived, Amount: amount}
+	}
+	sent := func(stamp, amount, fee int64) *sharedW.Transaction {
+		return &sharedW.Transaction{Timestamp: stamp, Direction: txhelper.TxDirectionSent, Amount: amount, Fee: fee}
+	}
+	txs := []*sharedW.Transaction{
+		received(100, 1000),
+		sent(200, 300, 10),
+		{Timestamp: 300, Direction: txhelper.TxDirectionTransferred, Amount: 500, Fee: 5},
+		{Timestamp: 400, Type: txhelper.TxTypeVote, VoteReward: 50},
+	}
+
+	tests := []struct {
+		name    string
+		current int64
+		txs     []*sharedW.Transaction
+		stamps  []int64
+		want    []int64
+	}{
+		{"no txs", 500, nil, []int64{100, 200}, []int64{500, 500}},
+		{
+			name:    "each tx",
+			current: 735,
+			txs:     txs,
+			stamps:  []int64{50, 100, 250, 300, 399, 400, 500},
+			want:    []int64{0, 1000, 690, 685, 685, 735, 735},
+		},
+		{
+			// Transactions at the same stamp as a point are included in
+			// its balance.
+			name:    "unsorted txs",
+			current: 735,
+			txs:     []*sharedW.Transaction{txs[3], txs[0], txs[2], txs[1]},
+			stamps:  []int64{99, 200, 400},
+			want:    []int64{0, 690, 735},
+		},
+	}
+
+	for _, test := range tests {
+		got := BalanceHistory(test.current, test.txs, test.stamps)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: expected balances %v, got %v", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package trezor
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+// Device is a Trezor device reached through a transport.
+type Device struct {
+	transport Transport
+
+	// Passphrase opens the hidden wallet of the passphrase when the device
+	// asks for one. It is empty for the standard wallet.
+	Passphrase string
+	// Pin returns the PIN of a locked device, entered as the positions of
+	// its digits in the matrix shown on the device. Devices that take the
+	// PIN on their screen never ask for it. A locked device can not be used
+	// without it.
+	Pin func() (string, error)
+}
+
+// NewDevice returns the device reached through the transport.
+func NewDevice(transport Transport) *Device {
+	return &Device{transport: transport}
+}
+
+// call sends the message to the device and returns its answer, answering
+// the button, PIN and passphrase requests of the device on the way.
+func (d *Device) call(ctx context.Context, kind uint16, data []byte) (uint16, []byte, error) {
+	for {
+		var err error
+		kind, data, err = d.transport.Call(ctx, kind, data)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch kind {
+		case msgButtonRequest:
+			// The user confirms on the device while the ack waits for
+			// the next message.
+			log.Debug("Waiting for confirmation on the Trezor device")
+			kind, data = msgButtonAck, nil
+
+		case msgPinMatrixRequest:
+			if d.Pin == nil {
+				d.cancel(ctx)
+				return 0, nil, errors.E(errors.Locked, "the Trezor device is locked, unlock it with its PIN")
+			}
+			pin, err := d.Pin()
+			if err != nil {
+				d.cancel(ctx)
+				return 0, nil, err
+			}
+			var e encoder
+			e.string(1, pin)
+			kind, data = msgPinMatrixAck, e
+
+		case msgPassphraseRequest:
+			var e encoder
+			e.string(1, d.Passphrase)
+			kind, data = msgPassphraseAck, e
+
+		case msgFailure:
+			return 0, nil, decodeFailure(data)
+
+		default:
+			return kind, data, nil
+		}
+	}
+}
+
+// cancel aborts the pending operation of the device. Its failure answer is
+// ignored.
+func (d *Device) cancel(ctx context.Context) {
+	_, _, _ = d.transport.Call(ctx, msgCancel, nil)
+}
+
+// expect calls the device and checks the type of its answer.
+func (d *Device) expect(ctx context.Context, kind uint16, data []byte, want uint16) ([]byte, error) {
+	kind, data, err := d.call(ctx, kind, data)
+	if err != nil {
+		return nil, err
+	}
+	if kind != want {
+		return nil, fmt.Errorf("unexpected trezor message %d, expected %d", kind, want)
+	}
+	return data, nil
+}
+
+// Initialize starts a session with the device and returns its features.
+func (d *Device) Initialize(ctx context.Context) (*Features, error) {
+	data, err := d.expect(ctx, msgInitialize, nil, msgFeatures)
+	if err != nil {
+		return nil, err
+	}
+	features, err := decodeFeatures(data)
+	if err != nil {
+		return nil, err
+	}
+	if !features.Initialized {
+		return nil, errors.E(errors.Invalid, "the Trezor device has no seed")
+	}
+	return features, nil
+}
+
+// PublicKey returns the extended public key of the path on the coin. The
+// script type sets the version of the key for coins with SLIP-0132 keys.
+func (d *Device) PublicKey(ctx context.Context, coin string, path []uint32, scriptType sharedW.InputScriptType) (string, error) {
+	data, err := d.expect(ctx, msgGetPublicKey, encodeGetPublicKey(path, coin, uint32(scriptType)), msgPublicKey)
+	if err != nil {
+		return "", err
+	}
+	return decodePublicKey(data)
+}
+
+// SignTx signs the transaction of the request on the device and returns the
+// serialized signed transaction. The device requests the inputs, outputs and
+// spent transactions one by one and streams back the signed transaction.
+func (d *Device) SignTx(ctx context.Context, coin string, req *sharedW.SignRequest) ([]byte, error) {
+	decred := isDecred(coin)
+	msg := &signTx{
+		outputsCount: uint32(len(req.Outputs)),
+		inputsCount:  uint32(len(req.Inputs)),
+		coin:         coin,
+		version:      req.Version,
+		lockTime:     req.LockTime,
+		expiry:       req.Expiry,
+	}
+	kind, data, err := d.call(ctx, msgSignTx, msg.encode(decred))
+	if err != nil {
+		return nil, err
+	}
+
+	var signedTx []byte
+	for {
+		if kind != msgTxRequest {
+			return nil, fmt.Errorf("unexpected trezor message %d while signing", kind)
+		}
+		txReq, err := decodeTxRequest(data)
+		if err != nil {
+			return nil, err
+		}
+		signedTx = append(signedTx, txReq.serializedTx...)
+		if txReq.requestType == reqTxFinished {
+			return signedTx, nil
+		}
+
+		ack, err := answerTxRequest(req, txReq, decred)
+		if err != nil {
+			d.cancel(ctx)
+			return nil, err
+		}
+		kind, data, err = d.call(ctx, msgTxAck, ack)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// answerTxRequest returns the TxAck with the part of the signed transaction,
+// or of one of the transactions it spends, requested by the device.
+func answerTxRequest(req *sharedW.SignRequest, txReq *txRequest, decred bool) ([]byte, error) {
+	var ack txAck
+	idx := int(txReq.requestIndex)
+
+	if len(txReq.txHash) == 0 {
+		switch txReq.requestType {
+		case reqTxMeta:
+			ack.meta(req.Version, req.LockTime, len(req.Inputs), len(req.Outputs), req.Expiry, decred)
+		case reqTxInput:
+			if idx >= len(req.Inputs) {
+				return nil, fmt.Errorf("trezor requested input %d of %d", idx, len(req.Inputs))
+			}
+			in, err := encodeInput(req.Inputs[idx], decred)
+			if err != nil {
+				return nil, err
+			}
+			ack.input(in)
+		case reqTxOutput:
+			if idx >= len(req.Outputs) {
+				return nil, fmt.Errorf("trezor requested output %d of %d", idx, len(req.Outputs))
+			}
+			ack.output(req.Outputs[idx].Address, req.Outputs[idx].Amount)
+		default:
+			return nil, fmt.Errorf("unsupported trezor request %d", txReq.requestType)
+		}
+		return ack.encode(), nil
+	}
+
+	// The hashes of the spent transactions are in the byte order of their
+	// string encoding.
+	txHash := hex.EncodeToString(txReq.txHash)
+	prevTx, ok := req.PrevTxs[txHash]
+	if !ok {
+		return nil, fmt.Errorf("trezor requested unknown transaction %s", txHash)
+	}
+	switch txReq.requestType {
+	case reqTxMeta:
+		ack.meta(prevTx.Version, prevTx.LockTime, len(prevTx.Inputs), len(prevTx.Outputs), prevTx.Expiry, decred)
+	case reqTxInput:
+		if idx >= len(prevTx.Inputs) {
+			return nil, fmt.Errorf("trezor requested input %d of %d of %s", idx, len(prevTx.Inputs), txHash)
+		}
+		prevIn := prevTx.Inputs[idx]
+		in, err := encodeInput(&sharedW.SignInput{
+			PrevHash:  prevIn.PrevHash,
+			PrevIndex: prevIn.PrevIndex,
+			Tree:      prevIn.Tree,
+			Sequence:  prevIn.Sequence,
+		}, decred)
+		if err != nil {
+			return nil, err
+		}
+		in.bytes(4, prevIn.ScriptSig)
+		ack.input(in)
+	case reqTxOutput:
+		if idx >= len(prevTx.Outputs) {
+			return nil, fmt.Errorf("trezor requested output %d of %d of %s", idx, len(prevTx.Outputs), txHash)
+		}
+		out := prevTx.Outputs[idx]
+		ack.binOutput(out.Amount, out.PkScript, out.ScriptVersion, decred)
+	default:
+		return nil, fmt.Errorf("unsupported trezor request %d", txReq.requestType)
+	}
+	return ack.encode(), nil
+}
+
+// encodeInput encodes the TxInputType of the input. Inputs of spent
+// transactions have no path, amount and script type.
+func encodeInput(in *sharedW.SignInput, decred bool) (*encoder, error) {
+	prevHash, err := hex.DecodeString(in.PrevHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous hash %s", in.PrevHash)
+	}
+	e := new(encoder)
+	e.path(1, in.Path)
+	e.bytes(2, prevHash)
+	e.uint(3, uint64(in.PrevIndex))
+	e.uint(5, uint64(in.Sequence))
+	if len(in.Path) > 0 {
+		e.uint(6, uint64(in.ScriptType))
+		e.uint(8, uint64(in.Amount))
+	}
+	if decred {
+		e.uint(9, uint64(in.Tree))
+	}
+	return e, nil
+}
+
+// isDecred returns true for the Decred coins, whose transactions have an
+// expiry, input trees and output script versions.
+func isDecred(coin string) bool {
+	return strings.HasPrefix(coin, "Decred")
+}
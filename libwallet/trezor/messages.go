@@ -0,0 +1,317 @@
+package trezor
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Message types of the Trezor protocol used by the package.
+const (
+	msgInitialize        uint16 = 0
+	msgSuccess           uint16 = 2
+	msgFailure           uint16 = 3
+	msgGetPublicKey      uint16 = 11
+	msgPublicKey         uint16 = 12
+	msgSignTx            uint16 = 15
+	msgFeatures          uint16 = 17
+	msgPinMatrixRequest  uint16 = 18
+	msgPinMatrixAck      uint16 = 19
+	msgCancel            uint16 = 20
+	msgTxRequest         uint16 = 21
+	msgTxAck             uint16 = 22
+	msgButtonRequest     uint16 = 26
+	msgButtonAck         uint16 = 27
+	msgPassphraseRequest uint16 = 41
+	msgPassphraseAck     uint16 = 42
+)
+
+// Request types of a TxRequest.
+const (
+	reqTxInput     uint32 = 0
+	reqTxOutput    uint32 = 1
+	reqTxMeta      uint32 = 2
+	reqTxFinished  uint32 = 3
+	reqTxExtraData uint32 = 4
+)
+
+// outputPayToAddress is the OutputScriptType of outputs paying to an address.
+const outputPayToAddress = 0
+
+// The messages are protobuf encoded. Only the fields the package needs are
+// encoded and decoded, unknown fields are skipped.
+
+// field is a decoded field of a message. Varint fields set value, length
+// delimited fields set bytes.
+type field struct {
+	num   protowire.Number
+	value uint64
+	bytes []byte
+}
+
+// decodeFields decodes the top level fields of a message.
+func decodeFields(data []byte) ([]field, error) {
+	var fields []field
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		f := field{num: num}
+		switch typ {
+		case protowire.VarintType:
+			f.value, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			f.bytes, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// encoder builds a message field by field.
+type encoder []byte
+
+func (e *encoder) uint(num protowire.Number, v uint64) {
+	*e = protowire.AppendTag(*e, num, protowire.VarintType)
+	*e = protowire.AppendVarint(*e, v)
+}
+
+func (e *encoder) bytes(num protowire.Number, b []byte) {
+	*e = protowire.AppendTag(*e, num, protowire.BytesType)
+	*e = protowire.AppendBytes(*e, b)
+}
+
+func (e *encoder) string(num protowire.Number, s string) {
+	*e = protowire.AppendTag(*e, num, protowire.BytesType)
+	*e = protowire.AppendString(*e, s)
+}
+
+// path encodes a repeated address_n field. The messages are proto2, so
+// repeated scalars are not packed.
+func (e *encoder) path(num protowire.Number, path []uint32) {
+	for _, index := range path {
+		e.uint(num, uint64(index))
+	}
+}
+
+// Features describes a device.
+type Features struct {
+	Vendor       string
+	Major        uint32
+	Minor        uint32
+	Patch        uint32
+	DeviceID     string
+	Label        string
+	Initialized  bool
+	PinProtected bool
+}
+
+func decodeFeatures(data []byte) (*Features, error) {
+	fields, err := decodeFields(data)
+	if err != nil {
+		return nil, err
+	}
+	features := new(Features)
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			features.Vendor = string(f.bytes)
+		case 2:
+			features.Major = uint32(f.value)
+		case 3:
+			features.Minor = uint32(f.value)
+		case 4:
+			features.Patch = uint32(f.value)
+		case 6:
+			features.DeviceID = string(f.bytes)
+		case 7:
+			features.PinProtected = f.value != 0
+		case 10:
+			features.Label = string(f.bytes)
+		case 12:
+			features.Initialized = f.value != 0
+		}
+	}
+	return features, nil
+}
+
+// Failure is an error returned by a device.
+type Failure struct {
+	Code    uint32
+	Message string
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("trezor failure %d: %s", f.Code, f.Message)
+}
+
+func decodeFailure(data []byte) *Failure {
+	failure := new(Failure)
+	fields, err := decodeFields(data)
+	if err != nil {
+		failure.Message = err.Error()
+		return failure
+	}
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			failure.Code = uint32(f.value)
+		case 2:
+			failure.Message = string(f.bytes)
+		}
+	}
+	return failure
+}
+
+func encodeGetPublicKey(path []uint32, coin string, scriptType uint32) []byte {
+	var e encoder
+	e.path(1, path)
+	e.string(4, coin)
+	e.uint(5, uint64(scriptType))
+	return e
+}
+
+func decodePublicKey(data []byte) (string, error) {
+	fields, err := decodeFields(data)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range fields {
+		if f.num == 2 {
+			return string(f.bytes), nil
+		}
+	}
+	return "", fmt.Errorf("public key message has no xpub")
+}
+
+// signTx is the SignTx message that starts the signing of a transaction.
+type signTx struct {
+	outputsCount uint32
+	inputsCount  uint32
+	coin         string
+	version      uint32
+	lockTime     uint32
+	expiry       uint32
+}
+
+func (m *signTx) encode(decred bool) []byte {
+	var e encoder
+	e.uint(1, uint64(m.outputsCount))
+	e.uint(2, uint64(m.inputsCount))
+	e.string(3, m.coin)
+	e.uint(4, uint64(m.version))
+	e.uint(5, uint64(m.lockTime))
+	if decred {
+		e.uint(6, uint64(m.expiry))
+	}
+	return e
+}
+
+// txRequest is the request of the device for a part of the transaction it
+// signs, along with the next part of the serialized signed transaction.
+type txRequest struct {
+	requestType  uint32
+	requestIndex uint32
+	txHash       []byte
+	serializedTx []byte
+}
+
+func decodeTxRequest(data []byte) (*txRequest, error) {
+	fields, err := decodeFields(data)
+	if err != nil {
+		return nil, err
+	}
+	req := new(txRequest)
+	for _, f := range fields {
+		switch f.num {
+		case 1:
+			req.requestType = uint32(f.value)
+		case 2:
+			details, err := decodeFields(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range details {
+				switch d.num {
+				case 1:
+					req.requestIndex = uint32(d.value)
+				case 2:
+					req.txHash = d.bytes
+				}
+			}
+		case 3:
+			serialized, err := decodeFields(f.bytes)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range serialized {
+				if s.num == 3 {
+					req.serializedTx = s.bytes
+				}
+			}
+		}
+	}
+	return req, nil
+}
+
+// txAck answers a txRequest with a TransactionType holding the requested
+// part of a transaction.
+type txAck struct {
+	encoder
+}
+
+// meta sets the fields of a TXMETA answer.
+func (a *txAck) meta(version, lockTime uint32, inputs, outputs int, expiry uint32, decred bool) {
+	a.uint(1, uint64(version))
+	a.uint(4, uint64(lockTime))
+	a.uint(6, uint64(inputs))
+	a.uint(7, uint64(outputs))
+	if decred {
+		a.uint(10, uint64(expiry))
+	} else {
+		a.uint(9, 0)
+	}
+}
+
+// input sets the TxInputType of a TXINPUT answer.
+func (a *txAck) input(in *encoder) {
+	a.bytes(2, *in)
+}
+
+// binOutput sets the TxOutputBinType of a TXOUTPUT answer for a previous
+// transaction.
+func (a *txAck) binOutput(amount int64, pkScript []byte, scriptVersion uint16, decred bool) {
+	var out encoder
+	out.uint(1, uint64(amount))
+	out.bytes(2, pkScript)
+	if decred {
+		out.uint(3, uint64(scriptVersion))
+	}
+	a.bytes(3, out)
+}
+
+// output sets the TxOutputType of a TXOUTPUT answer for the signed
+// transaction.
+func (a *txAck) output(address string, amount int64) {
+	var out encoder
+	out.string(1, address)
+	out.uint(3, uint64(amount))
+	out.uint(4, outputPayToAddress)
+	a.bytes(5, out)
+}
+
+// encode wraps the TransactionType in the TxAck message.
+func (a *txAck) encode() []byte {
+	var e encoder
+	e.bytes(1, a.encoder)
+	return e
+}
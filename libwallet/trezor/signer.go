@@ -0,0 +1,98 @@
+// Package trezor implements the Trezor protocol to sign the transactions of
+// watch-only wallets on a Trezor device. The device is reached through the
+// Trezor Bridge, which owns its USB connection, or over the UDP port of the
+// Trezor emulator.
+package trezor
+
+import (
+	"context"
+	"fmt"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+)
+
+// CoinName returns the name the device knows the coin of the asset on the
+// network by.
+func CoinName(assetType utils.AssetType, net utils.NetworkType) (string, error) {
+	names := map[utils.AssetType]map[utils.NetworkType]string{
+		utils.BTCWalletAsset: {
+			utils.Mainnet:    "Bitcoin",
+			utils.Testnet:    "Testnet",
+			utils.Regression: "Regtest",
+		},
+		utils.LTCWalletAsset: {
+			utils.Mainnet: "Litecoin",
+			utils.Testnet: "Litecoin Testnet",
+		},
+		utils.DCRWalletAsset: {
+			utils.Mainnet: "Decred",
+			utils.Testnet: "Decred Testnet",
+		},
+	}
+	name, ok := names[assetType][net]
+	if !ok {
+		return "", fmt.Errorf("trezor does not support %s on %s", assetType, net)
+	}
+	return name, nil
+}
+
+// Signer signs transactions on the device of a hardware signer config. It
+// connects to the device for each transaction.
+type Signer struct {
+	cfg  *sharedW.HardwareSignerConfig
+	coin string
+	dial func(ctx context.Context) (Transport, error)
+
+	// Pin returns the PIN of a locked device. See Device.Pin.
+	Pin func() (string, error)
+}
+
+var _ sharedW.Signer = (*Signer)(nil)
+
+// NewSigner returns the signer of the coin on the device of the config.
+func NewSigner(cfg *sharedW.HardwareSignerConfig, coin string) *Signer {
+	s := &Signer{cfg: cfg, coin: coin}
+	s.dial = func(ctx context.Context) (Transport, error) {
+		return Dial(ctx, s.cfg)
+	}
+	return s
+}
+
+// Features connects to the device and returns its features.
+func (s *Signer) Features(ctx context.Context) (*Features, error) {
+	device, err := s.open(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	defer device.transport.Close()
+	return device.Initialize(ctx)
+}
+
+// SignTx signs the transaction of the request on the device. The passphrase
+// opens a hidden wallet of the device.
+func (s *Signer) SignTx(ctx context.Context, req *sharedW.SignRequest, passphrase string) ([]byte, error) {
+	device, err := s.open(ctx, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer device.transport.Close()
+
+	features, err := device.Initialize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Signing a %s transaction of %d inputs on Trezor %q", s.coin, len(req.Inputs), features.Label)
+	return device.SignTx(ctx, s.coin, req)
+}
+
+func (s *Signer) open(ctx context.Context, passphrase string) (*Device, error) {
+	transport, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	device := NewDevice(transport)
+	device.Passphrase = passphrase
+	device.Pin = s.Pin
+	return device, nil
+}
@@ -0,0 +1,281 @@
+package trezor
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"decred.org/dcrwallet/v4/errors"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+)
+
+const (
+	// DefaultBridgeAddress is the address the Trezor Bridge listens on.
+	DefaultBridgeAddress = "127.0.0.1:21325"
+	// DefaultEmulatorAddress is the UDP address of the Trezor emulator.
+	DefaultEmulatorAddress = "127.0.0.1:21324"
+
+	// bridgeOrigin is sent as the Origin of the requests to the bridge,
+	// which only serves known origins.
+	bridgeOrigin = "https://python.trezor.io"
+
+	// reportSize is the size of the USB HID reports a message is split
+	// into. The emulator takes the same reports as UDP datagrams.
+	reportSize = 64
+	// headerSize is the size of the message type and length.
+	headerSize = 6
+	// maxMessageSize bounds the length read from a message header.
+	maxMessageSize = 1 << 20
+
+	pingTimeout = time.Second
+)
+
+// Transport exchanges messages with a device.
+type Transport interface {
+	// Call sends a message to the device and returns its answer.
+	Call(ctx context.Context, kind uint16, data []byte) (uint16, []byte, error)
+	Close() error
+}
+
+// Dial connects to the device of the config.
+func Dial(ctx context.Context, cfg *sharedW.HardwareSignerConfig) (Transport, error) {
+	switch cfg.Transport {
+	case sharedW.HardwareTransportBridge:
+		address := cfg.Address
+		if address == "" {
+			address = DefaultBridgeAddress
+		}
+		return dialBridge(ctx, address)
+	case sharedW.HardwareTransportUDP:
+		address := cfg.Address
+		if address == "" {
+			address = DefaultEmulatorAddress
+		}
+		return dialUDP(ctx, address)
+	default:
+		return nil, errors.E(errors.Invalid, "unsupported hardware signer transport "+cfg.Transport)
+	}
+}
+
+// writeReports writes the message to w as a sequence of reports. The first
+// report starts with "?##" and the header of the message, the next ones
+// with "?".
+func writeReports(w io.Writer, kind uint16, data []byte) error {
+	msg := make([]byte, 2+headerSize+len(data))
+	msg[0], msg[1] = '#', '#'
+	binary.BigEndian.PutUint16(msg[2:], kind)
+	binary.BigEndian.PutUint32(msg[4:], uint32(len(data)))
+	copy(msg[2+headerSize:], data)
+
+	for len(msg) > 0 {
+		report := make([]byte, reportSize)
+		report[0] = '?'
+		n := copy(report[1:], msg)
+		msg = msg[n:]
+		if _, err := w.Write(report); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readReports reads a message from the sequence of reports of r.
+func readReports(r io.Reader) (uint16, []byte, error) {
+	report := make([]byte, reportSize)
+	if _, err := io.ReadFull(r, report); err != nil {
+		return 0, nil, err
+	}
+	if !bytes.HasPrefix(report, []byte("?##")) {
+		return 0, nil, fmt.Errorf("invalid message header")
+	}
+	kind := binary.BigEndian.Uint16(report[3:])
+	length := binary.BigEndian.Uint32(report[5:])
+	if length > maxMessageSize {
+		return 0, nil, fmt.Errorf("message of %d bytes is too large", length)
+	}
+
+	data := make([]byte, 0, length)
+	data = append(data, report[3+headerSize:]...)
+	for uint32(len(data)) < length {
+		if _, err := io.ReadFull(r, report); err != nil {
+			return 0, nil, err
+		}
+		if report[0] != '?' {
+			return 0, nil, fmt.Errorf("invalid message report")
+		}
+		data = append(data, report[1:]...)
+	}
+	return kind, data[:length], nil
+}
+
+// udpTransport talks to the Trezor emulator over UDP.
+type udpTransport struct {
+	conn net.Conn
+}
+
+func dialUDP(ctx context.Context, address string) (*udpTransport, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The emulator answers a ping, which tells whether it is running as
+	// UDP has no connection.
+	pong := make([]byte, 8)
+	_ = conn.SetDeadline(time.Now().Add(pingTimeout))
+	_, err = conn.Write([]byte("PINGPING"))
+	if err == nil {
+		_, err = io.ReadFull(conn, pong)
+	}
+	if err != nil || string(pong) != "PONGPONG" {
+		conn.Close()
+		return nil, errors.E(errors.NotExist, fmt.Sprintf("no Trezor emulator at %s", address))
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return &udpTransport{conn: conn}, nil
+}
+
+// Call writes the message and waits for the answer of the emulator, which
+// may wait for the user to confirm on the device, until ctx is done.
+func (t *udpTransport) Call(ctx context.Context, kind uint16, data []byte) (uint16, []byte, error) {
+	stop := context.AfterFunc(ctx, func() {
+		_ = t.conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := writeReports(t.conn, kind, data); err != nil {
+		return 0, nil, err
+	}
+	kind, data, err := readReports(t.conn)
+	if err != nil && ctx.Err() != nil {
+		return 0, nil, ctx.Err()
+	}
+	return kind, data, err
+}
+
+func (t *udpTransport) Close() error {
+	return t.conn.Close()
+}
+
+// bridgeTransport talks to a device through the HTTP API of the Trezor
+// Bridge, which owns the USB connection to the device.
+type bridgeTransport struct {
+	client  *http.Client
+	url     string
+	session string
+}
+
+// bridgeDevice is a device listed by the bridge.
+type bridgeDevice struct {
+	Path    string  `json:"path"`
+	Session *string `json:"session"`
+}
+
+func dialBridge(ctx context.Context, address string) (*bridgeTransport, error) {
+	t := &bridgeTransport{
+		client: new(http.Client),
+		url:    "http://" + address,
+	}
+
+	var devices []*bridgeDevice
+	if err := t.post(ctx, "/enumerate", "", &devices); err != nil {
+		return nil, errors.E(errors.NotExist, fmt.Errorf("Trezor Bridge at %s is not available: %v", address, err))
+	}
+	if len(devices) == 0 {
+		return nil, errors.E(errors.NotExist, "no Trezor device is connected")
+	}
+
+	// The first device is used. A session held by another application is
+	// taken over.
+	device := devices[0]
+	previous := "null"
+	if device.Session != nil {
+		previous = *device.Session
+	}
+	var acquired struct {
+		Session string `json:"session"`
+	}
+	if err := t.post(ctx, fmt.Sprintf("/acquire/%s/%s", device.Path, previous), "", &acquired); err != nil {
+		return nil, err
+	}
+	t.session = acquired.Session
+	return t, nil
+}
+
+// post sends a request to the bridge and decodes its JSON answer into
+// result, or its hex answer if result is a string.
+func (t *bridgeTransport) post(ctx context.Context, path, body string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url+path, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Origin", bridgeOrigin)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 2*maxMessageSize+2*headerSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var bridgeErr struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(respBody, &bridgeErr) == nil && bridgeErr.Error != "" {
+			return fmt.Errorf("trezor bridge: %s", bridgeErr.Error)
+		}
+		return fmt.Errorf("trezor bridge: %s", resp.Status)
+	}
+	if s, ok := result.(*string); ok {
+		*s = strings.TrimSpace(string(respBody))
+		return nil
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+// Call sends the hex encoded header and message to the device of the
+// session and decodes its answer.
+func (t *bridgeTransport) Call(ctx context.Context, kind uint16, data []byte) (uint16, []byte, error) {
+	msg := make([]byte, headerSize+len(data))
+	binary.BigEndian.PutUint16(msg, kind)
+	binary.BigEndian.PutUint32(msg[2:], uint32(len(data)))
+	copy(msg[headerSize:], data)
+
+	var answer string
+	if err := t.post(ctx, "/call/"+t.session, hex.EncodeToString(msg), &answer); err != nil {
+		return 0, nil, err
+	}
+	msg, err := hex.DecodeString(answer)
+	if err != nil || len(msg) < headerSize {
+		return 0, nil, fmt.Errorf("invalid answer from the trezor bridge")
+	}
+	kind = binary.BigEndian.Uint16(msg)
+	length := binary.BigEndian.Uint32(msg[2:])
+	if uint32(len(msg)-headerSize) != length {
+		return 0, nil, fmt.Errorf("invalid answer from the trezor bridge")
+	}
+	return kind, msg[headerSize:], nil
+}
+
+// Close releases the session so other applications can use the device.
+func (t *bridgeTransport) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return t.post(ctx, "/release/"+t.session, "", nil)
+}
@@ -0,0 +1,479 @@
+package trezor
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// mockDevice is a Transport that plays the signing protocol of a device. It
+// requests every input with its spent transaction and every output, checks
+// the answers against the expected request, and streams back signedTx.
+type mockDevice struct {
+	t        *testing.T
+	expected *sharedW.SignRequest
+	signedTx []byte
+	decred   bool
+
+	// steps are the requests left to make, each checking the answer to
+	// the previous one.
+	steps []mockStep
+	check func([]field)
+	// serializedChunk is the number of bytes of the signed transaction
+	// sent with each request.
+	serializedChunk int
+
+	buttonAcked bool
+	closed      bool
+}
+
+type mockStep struct {
+	requestType uint32
+	index       uint32
+	txHash      string
+	check       func([]field)
+}
+
+func (m *mockDevice) Call(_ context.Context, kind uint16, data []byte) (uint16, []byte, error) {
+	switch kind {
+	case msgInitialize:
+		var e encoder
+		e.string(1, "trezor.io")
+		e.string(10, "mock")
+		e.uint(12, 1)
+		return msgFeatures, e, nil
+
+	case msgSignTx:
+		fields := mustDecode(m.t, data)
+		if got := fieldValue(fields, 2); got != uint64(len(m.expected.Inputs)) {
+			m.t.Errorf("expected %d inputs, got %d", len(m.expected.Inputs), got)
+		}
+		if got := fieldValue(fields, 1); got != uint64(len(m.expected.Outputs)) {
+			m.t.Errorf("expected %d outputs, got %d", len(m.expected.Outputs), got)
+		}
+		m.steps = m.plan()
+		// The user confirms the transaction on the device.
+		return msgButtonRequest, nil, nil
+
+	case msgButtonAck:
+		m.buttonAcked = true
+		return m.next()
+
+	case msgTxAck:
+		if !m.buttonAcked {
+			m.t.Error("expected the button request to be acked")
+		}
+		fields := mustDecode(m.t, data)
+		if len(fields) != 1 || fields[0].num != 1 {
+			m.t.Fatalf("invalid tx ack %x", data)
+		}
+		if m.check != nil {
+			m.check(mustDecode(m.t, fields[0].bytes))
+		}
+		return m.next()
+
+	case msgCancel:
+		var e encoder
+		e.string(2, "cancelled")
+		return msgFailure, e, nil
+	}
+	return 0, nil, fmt.Errorf("unexpected message %d", kind)
+}
+
+func (m *mockDevice) Close() error {
+	m.closed = true
+	return nil
+}
+
+// next sends the next request with the next chunk of the signed
+// transaction.
+func (m *mockDevice) next() (uint16, []byte, error) {
+	var chunk []byte
+	if len(m.steps) > 0 && m.serializedChunk > 0 && len(m.signedTx) > 0 {
+		n := m.serializedChunk
+		if n > len(m.signedTx) {
+			n = len(m.signedTx)
+		}
+		chunk, m.signedTx = m.signedTx[:n], m.signedTx[n:]
+	}
+
+	var e encoder
+	if len(m.steps) == 0 {
+		e.uint(1, uint64(reqTxFinished))
+		var serialized encoder
+		serialized.bytes(3, m.signedTx)
+		e.bytes(3, serialized)
+		m.check = nil
+		return msgTxRequest, e, nil
+	}
+
+	step := m.steps[0]
+	m.steps = m.steps[1:]
+	m.check = step.check
+	e.uint(1, uint64(step.requestType))
+	var details encoder
+	details.uint(1, uint64(step.index))
+	if step.txHash != "" {
+		hash, _ := hex.DecodeString(step.txHash)
+		details.bytes(2, hash)
+	}
+	e.bytes(2, details)
+	if len(chunk) > 0 {
+		var serialized encoder
+		serialized.bytes(3, chunk)
+		e.bytes(3, serialized)
+	}
+	return msgTxRequest, e, nil
+}
+
+// plan returns the requests of the device for the expected transaction.
+func (m *mockDevice) plan() []mockStep {
+	var steps []mockStep
+	for i, in := range m.expected.Inputs {
+		in := in
+		steps = append(steps, mockStep{requestType: reqTxInput, index: uint32(i), check: func(tx []field) {
+			input := mustDecode(m.t, fieldBytes(tx, 2))
+			if got := hex.EncodeToString(fieldBytes(input, 2)); got != in.PrevHash {
+				m.t.Errorf("expected previous hash %s, got %s", in.PrevHash, got)
+			}
+			if got := fieldValue(input, 8); got != uint64(in.Amount) {
+				m.t.Errorf("expected amount %d, got %d", in.Amount, got)
+			}
+			if got := fieldValue(input, 6); got != uint64(in.ScriptType) {
+				m.t.Errorf("expected script type %d, got %d", in.ScriptType, got)
+			}
+			var path []uint32
+			for _, f := range input {
+				if f.num == 1 {
+					path = append(path, uint32(f.value))
+				}
+			}
+			if fmt.Sprint(path) != fmt.Sprint(in.Path) {
+				m.t.Errorf("expected path %v, got %v", in.Path, path)
+			}
+			if hasField(input, 9) != m.decred {
+				m.t.Errorf("expected a decred tree only for decred inputs")
+			}
+		}})
+
+		prevTx := m.expected.PrevTxs[in.PrevHash]
+		steps = append(steps, mockStep{requestType: reqTxMeta, txHash: in.PrevHash, check: func(tx []field) {
+			if got := fieldValue(tx, 6); got != uint64(len(prevTx.Inputs)) {
+				m.t.Errorf("expected %d previous inputs, got %d", len(prevTx.Inputs), got)
+			}
+			if got := fieldValue(tx, 7); got != uint64(len(prevTx.Outputs)) {
+				m.t.Errorf("expected %d previous outputs, got %d", len(prevTx.Outputs), got)
+			}
+		}})
+		for j, prevIn := range prevTx.Inputs {
+			prevIn := prevIn
+			steps = append(steps, mockStep{requestType: reqTxInput, index: uint32(j), txHash: in.PrevHash, check: func(tx []field) {
+				input := mustDecode(m.t, fieldBytes(tx, 2))
+				if !bytes.Equal(fieldBytes(input, 4), prevIn.ScriptSig) {
+					m.t.Errorf("expected script sig %x, got %x", prevIn.ScriptSig, fieldBytes(input, 4))
+				}
+				if hasField(input, 1) || hasField(input, 8) {
+					m.t.Error("expected no path and amount for a previous input")
+				}
+			}})
+		}
+		for j, prevOut := range prevTx.Outputs {
+			prevOut := prevOut
+			steps = append(steps, mockStep{requestType: reqTxOutput, index: uint32(j), txHash: in.PrevHash, check: func(tx []field) {
+				output := mustDecode(m.t, fieldBytes(tx, 3))
+				if got := fieldValue(output, 1); got != uint64(prevOut.Amount) {
+					m.t.Errorf("expected previous amount %d, got %d", prevOut.Amount, got)
+				}
+				if !bytes.Equal(fieldBytes(output, 2), prevOut.PkScript) {
+					m.t.Errorf("expected previous script %x, got %x", prevOut.PkScript, fieldBytes(output, 2))
+				}
+			}})
+		}
+	}
+	for i, out := range m.expected.Outputs {
+		out := out
+		steps = append(steps, mockStep{requestType: reqTxOutput, index: uint32(i), check: func(tx []field) {
+			output := mustDecode(m.t, fieldBytes(tx, 5))
+			if got := string(fieldBytes(output, 1)); got != out.Address {
+				m.t.Errorf("expected address %s, got %s", out.Address, got)
+			}
+			if got := fieldValue(output, 3); got != uint64(out.Amount) {
+				m.t.Errorf("expected amount %d, got %d", out.Amount, got)
+			}
+		}})
+	}
+	return steps
+}
+
+func mustDecode(t *testing.T, data []byte) []field {
+	t.Helper()
+	fields, err := decodeFields(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fields
+}
+
+func fieldValue(fields []field, num protowire.Number) uint64 {
+	for _, f := range fields {
+		if f.num == num {
+			return f.value
+		}
+	}
+	return 0
+}
+
+func fieldBytes(fields []field, num protowire.Number) []byte {
+	for _, f := range fields {
+		if f.num == num {
+			return f.bytes
+		}
+	}
+	return nil
+}
+
+func hasField(fields []field, num protowire.Number) bool {
+	for _, f := range fields {
+		if f.num == num {
+			return true
+		}
+	}
+	return false
+}
+
+func testSignRequest() *sharedW.SignRequest {
+	prevHash := strings.Repeat("ab", 32)
+	return &sharedW.SignRequest{
+		Version:  2,
+		LockTime: 100,
+		Inputs: []*sharedW.SignInput{{
+			PrevHash:   prevHash,
+			PrevIndex:  1,
+			Sequence:   0xfffffffd,
+			Amount:     5e7,
+			Path:       []uint32{84 | 1<<31, 1 | 1<<31, 1 << 31, 0, 3},
+			ScriptType: sharedW.SpendWitness,
+		}},
+		Outputs: []*sharedW.SignOutput{
+			{Address: "tb1qpayee", Amount: 3e7},
+			{Address: "tb1qchange", Amount: 19990000},
+		},
+		PrevTxs: map[string]*sharedW.PrevTx{
+			prevHash: {
+				Version: 2,
+				Inputs: []*sharedW.PrevTxInput{{
+					PrevHash:  strings.Repeat("cd", 32),
+					Sequence:  0xffffffff,
+					ScriptSig: []byte{1, 2, 3},
+				}},
+				Outputs: []*sharedW.PrevTxOutput{
+					{Amount: 1e6, PkScript: []byte{0, 20, 1}},
+					{Amount: 5e7, PkScript: []byte{0, 20, 2}},
+				},
+			},
+		},
+	}
+}
+
+func TestSignTx(t *testing.T) {
+	for _, coin := range []string{"Testnet", "Decred Testnet"} {
+		t.Run(coin, func(t *testing.T) {
+			req := testSignRequest()
+			signedTx := bytes.Repeat([]byte{0x42}, 150)
+			mock := &mockDevice{
+				t:               t,
+				expected:        req,
+				signedTx:        append([]byte(nil), signedTx...),
+				decred:          isDecred(coin),
+				serializedChunk: 20,
+			}
+			signer := NewSigner(&sharedW.HardwareSignerConfig{Type: sharedW.HardwareSignerTrezor}, coin)
+			signer.dial = func(context.Context) (Transport, error) {
+				return mock, nil
+			}
+
+			got, err := signer.SignTx(context.Background(), req, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, signedTx) {
+				t.Fatalf("expected signed tx %x, got %x", signedTx, got)
+			}
+			if len(mock.steps) != 0 {
+				t.Fatalf("expected every request to be answered, %d left", len(mock.steps))
+			}
+			if !mock.closed {
+				t.Fatal("expected the transport to be closed")
+			}
+		})
+	}
+}
+
+func TestSignTxUnknownPrevTx(t *testing.T) {
+	req := testSignRequest()
+	mock := &mockDevice{t: t, expected: req, signedTx: []byte{1}}
+	signer := NewSigner(&sharedW.HardwareSignerConfig{Type: sharedW.HardwareSignerTrezor}, "Testnet")
+	signer.dial = func(context.Context) (Transport, error) {
+		return mock, nil
+	}
+
+	// The device asks for a transaction the wallet does not spend.
+	for hash, prevTx := range req.PrevTxs {
+		delete(req.PrevTxs, hash)
+		req.PrevTxs[strings.Repeat("ef", 32)] = prevTx
+	}
+	mock.expected = testSignRequest()
+	if _, err := signer.SignTx(context.Background(), req, ""); err == nil {
+		t.Fatal("expected a request for an unknown transaction to fail")
+	}
+}
+
+func TestDeviceFailure(t *testing.T) {
+	transport := transportFunc(func(kind uint16, _ []byte) (uint16, []byte, error) {
+		var e encoder
+		e.uint(1, 4)
+		e.string(2, "Action cancelled by user")
+		return msgFailure, e, nil
+	})
+	_, err := NewDevice(transport).Initialize(context.Background())
+	failure, ok := err.(*Failure)
+	if !ok || failure.Code != 4 || failure.Message != "Action cancelled by user" {
+		t.Fatalf("expected the failure of the device, got %v", err)
+	}
+}
+
+func TestDevicePinAndPassphrase(t *testing.T) {
+	var pin, passphrase string
+	transport := transportFunc(func(kind uint16, data []byte) (uint16, []byte, error) {
+		switch kind {
+		case msgGetPublicKey:
+			return msgPinMatrixRequest, nil, nil
+		case msgPinMatrixAck:
+			pin = string(fieldBytes(mustDecode(t, data), 1))
+			return msgPassphraseRequest, nil, nil
+		case msgPassphraseAck:
+			passphrase = string(fieldBytes(mustDecode(t, data), 1))
+			var e encoder
+			e.string(2, "tpubmock")
+			return msgPublicKey, e, nil
+		case msgCancel:
+			return msgFailure, nil, nil
+		}
+		return 0, nil, fmt.Errorf("unexpected message %d", kind)
+	})
+
+	device := NewDevice(transport)
+	device.Passphrase = "hidden"
+	if _, err := device.PublicKey(context.Background(), "Testnet", []uint32{84 | 1<<31}, sharedW.SpendWitness); err == nil {
+		t.Fatal("expected a locked device without a PIN callback to fail")
+	}
+
+	device.Pin = func() (string, error) { return "1234", nil }
+	xpub, err := device.PublicKey(context.Background(), "Testnet", []uint32{84 | 1<<31}, sharedW.SpendWitness)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xpub != "tpubmock" || pin != "1234" || passphrase != "hidden" {
+		t.Fatalf("unexpected xpub %s, pin %s and passphrase %s", xpub, pin, passphrase)
+	}
+}
+
+type transportFunc func(kind uint16, data []byte) (uint16, []byte, error)
+
+func (f transportFunc) Call(_ context.Context, kind uint16, data []byte) (uint16, []byte, error) {
+	return f(kind, data)
+}
+
+func (f transportFunc) Close() error { return nil }
+
+func TestReports(t *testing.T) {
+	for _, size := range []int{0, 55, 56, 200} {
+		data := bytes.Repeat([]byte{7}, size)
+		var buf bytes.Buffer
+		if err := writeReports(&buf, msgTxAck, data); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len()%reportSize != 0 {
+			t.Fatalf("%d bytes: reports of %d bytes are not whole", size, buf.Len())
+		}
+		kind, got, err := readReports(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != msgTxAck || !bytes.Equal(got, data) {
+			t.Fatalf("%d bytes: expected message %d of %x, got %d of %x", size, msgTxAck, data, kind, got)
+		}
+	}
+}
+
+func TestBridgeTransport(t *testing.T) {
+	var released bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Origin") == "" {
+			http.Error(w, `{"error":"origin not allowed"}`, http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.URL.Path == "/enumerate":
+			fmt.Fprint(w, `[{"path":"1","session":null}]`)
+		case r.URL.Path == "/acquire/1/null":
+			fmt.Fprint(w, `{"session":"7"}`)
+		case r.URL.Path == "/call/7":
+			body, _ := io.ReadAll(r.Body)
+			msg, err := hex.DecodeString(string(body))
+			if err != nil || len(msg) < headerSize || binary.BigEndian.Uint16(msg) != msgInitialize {
+				http.Error(w, `{"error":"bad message"}`, http.StatusBadRequest)
+				return
+			}
+			var features encoder
+			features.string(10, "bridge")
+			features.uint(12, 1)
+			answer := make([]byte, headerSize)
+			binary.BigEndian.PutUint16(answer, msgFeatures)
+			binary.BigEndian.PutUint32(answer[2:], uint32(len(features)))
+			fmt.Fprint(w, hex.EncodeToString(append(answer, features...)))
+		case r.URL.Path == "/release/7":
+			released = true
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	signer := NewSigner(&sharedW.HardwareSignerConfig{
+		Type:      sharedW.HardwareSignerTrezor,
+		Transport: sharedW.HardwareTransportBridge,
+		Address:   strings.TrimPrefix(server.URL, "http://"),
+	}, "Bitcoin")
+	features, err := signer.Features(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if features.Label != "bridge" {
+		t.Fatalf("expected the features of the bridge device, got %+v", features)
+	}
+	if !released {
+		t.Fatal("expected the session to be released")
+	}
+}
+
+func TestCoinName(t *testing.T) {
+	if _, err := CoinName("DOGE", "mainnet"); err == nil {
+		t.Fatal("expected an unsupported asset to fail")
+	}
+	name, err := CoinName("DCR", "testnet")
+	if err != nil || name != "Decred Testnet" {
+		t.Fatalf("expected Decred Testnet, got %s and %v", name, err)
+	}
+}
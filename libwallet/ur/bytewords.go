@@ -0,0 +1,74 @@
+package ur
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+// bytewords are the words of the 256 byte values. The first and last letters
+// of every word are unique, so the minimal encoding keeps only those.
+var bytewords = strings.Fields(`
+	able acid also apex aqua arch atom aunt away axis back bald barn belt beta bias
+	blue body brag brew bulb buzz calm cash cats chef city claw code cola cook cost
+	crux curl cusp cyan dark data days deli dice diet door down draw drop drum dull
+	duty each easy echo edge epic even exam exit eyes fact fair fern figs film fish
+	fizz flap flew flux foxy free frog fuel fund gala game gear gems gift girl glow
+	good gray grim guru gush gyro half hang hard hawk heat help high hill holy hope
+	horn huts iced idea idle inch inky into iris iron item jade jazz join jolt jowl
+	judo jugs jump junk jury keep keno kept keys kick kiln king kite kiwi knob lamb
+	lava lazy leaf legs liar limp lion list logo loud love luau luck lung main many
+	math maze memo menu meow mild mint miss monk nail navy need news next noon note
+	numb obey oboe omit onyx open oval owls paid part peck play plus poem pool pose
+	puff puma purr quad quiz race ramp real redo rich road rock roof ruby ruin runs
+	rust safe saga scar sets silk skew slot soap solo song stub surf swan taco task
+	taxi tent tied time tiny toil tomb toys trip tuna twin ugly undo unit urge user
+	vast very veto vial vibe view visa void vows wall wand warm wasp wave waxy webs
+	what when whiz wolf work yank yawn yell yoga yurt zaps zero zest zinc zone zoom`)
+
+// minimalBytewords maps the first and last letters of the words to their
+// byte values.
+var minimalBytewords = func() map[string]byte {
+	m := make(map[string]byte, len(bytewords))
+	for i, word := range bytewords {
+		m[word[:1]+word[3:]] = byte(i)
+	}
+	return m
+}()
+
+// encodeBytewords returns the minimal bytewords of the data followed by its
+// CRC32 checksum.
+func encodeBytewords(data []byte) string {
+	checksum := binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(data))
+	var b strings.Builder
+	b.Grow(2 * (len(data) + len(checksum)))
+	for _, v := range append(append([]byte(nil), data...), checksum...) {
+		word := bytewords[v]
+		b.WriteByte(word[0])
+		b.WriteByte(word[3])
+	}
+	return b.String()
+}
+
+// decodeBytewords decodes minimal bytewords and checks their checksum.
+func decodeBytewords(s string) ([]byte, error) {
+	s = strings.ToLower(s)
+	if len(s)%2 != 0 || len(s) < 2*crc32.Size {
+		return nil, errors.E(errors.Encoding, "invalid bytewords length")
+	}
+	data := make([]byte, 0, len(s)/2)
+	for i := 0; i < len(s); i += 2 {
+		v, ok := minimalBytewords[s[i:i+2]]
+		if !ok {
+			return nil, errors.E(errors.Encoding, "invalid byteword "+s[i:i+2])
+		}
+		data = append(data, v)
+	}
+	data, checksum := data[:len(data)-crc32.Size], data[len(data)-crc32.Size:]
+	if binary.BigEndian.Uint32(checksum) != crc32.ChecksumIEEE(data) {
+		return nil, errors.E(errors.Encoding, "invalid bytewords checksum")
+	}
+	return data, nil
+}
@@ -0,0 +1,98 @@
+package ur
+
+import (
+	"encoding/binary"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+// CBOR major types used by the parts and payloads.
+const (
+	cborUint  = 0
+	cborBytes = 2
+	cborArray = 4
+)
+
+// appendCBORHead appends the head of a CBOR item of the major type and
+// argument.
+func appendCBORHead(b []byte, major byte, v uint64) []byte {
+	major <<= 5
+	switch {
+	case v < 24:
+		return append(b, major|byte(v))
+	case v <= 0xff:
+		return append(b, major|24, byte(v))
+	case v <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(v))
+	case v <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, major|27), v)
+	}
+}
+
+// cborReader reads the CBOR items of a buffer.
+type cborReader struct {
+	b []byte
+}
+
+// head reads the head of the next item, which must be of the major type.
+func (r *cborReader) head(major byte) (uint64, error) {
+	if len(r.b) == 0 {
+		return 0, errors.E(errors.Encoding, "truncated CBOR")
+	}
+	if r.b[0]>>5 != major {
+		return 0, errors.E(errors.Encoding, "unexpected CBOR type")
+	}
+	info := r.b[0] & 0x1f
+	r.b = r.b[1:]
+	if info < 24 {
+		return uint64(info), nil
+	}
+	if info > 27 {
+		return 0, errors.E(errors.Encoding, "unsupported CBOR length")
+	}
+	size := 1 << (info - 24)
+	if len(r.b) < size {
+		return 0, errors.E(errors.Encoding, "truncated CBOR")
+	}
+	var v uint64
+	for _, c := range r.b[:size] {
+		v = v<<8 | uint64(c)
+	}
+	r.b = r.b[size:]
+	return v, nil
+}
+
+// bytes reads a byte string.
+func (r *cborReader) bytes() ([]byte, error) {
+	n, err := r.head(cborBytes)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(r.b)) < n {
+		return nil, errors.E(errors.Encoding, "truncated CBOR")
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v, nil
+}
+
+// EncodeBytes returns the CBOR byte string of the data, the message of the
+// bytes and crypto-psbt UR types.
+func EncodeBytes(data []byte) []byte {
+	return append(appendCBORHead(nil, cborBytes, uint64(len(data))), data...)
+}
+
+// DecodeBytes returns the data of a CBOR byte string.
+func DecodeBytes(message []byte) ([]byte, error) {
+	r := &cborReader{b: message}
+	data, err := r.bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(r.b) != 0 {
+		return nil, errors.E(errors.Encoding, "trailing CBOR data")
+	}
+	return data, nil
+}
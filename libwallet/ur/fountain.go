@@ -0,0 +1,420 @@
+package ur
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"math/bits"
+	"sort"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+// minFragmentLen is the smallest fragment a message is split into.
+const minFragmentLen = 10
+
+// xoshiro is the xoshiro256** generator the encoder and decoder choose the
+// fragments mixed into a part with.
+type xoshiro struct {
+	s [4]uint64
+}
+
+// newXoshiro seeds the generator with the SHA256 hash of the seed.
+func newXoshiro(seed []byte) *xoshiro {
+	digest := sha256.Sum256(seed)
+	x := new(xoshiro)
+	for i := range x.s {
+		x.s[i] = binary.BigEndian.Uint64(digest[8*i:])
+	}
+	return x
+}
+
+func (x *xoshiro) next() uint64 {
+	result := bits.RotateLeft64(x.s[1]*5, 7) * 9
+	t := x.s[1] << 17
+	x.s[2] ^= x.s[0]
+	x.s[3] ^= x.s[1]
+	x.s[1] ^= x.s[2]
+	x.s[0] ^= x.s[3]
+	x.s[2] ^= t
+	x.s[3] = bits.RotateLeft64(x.s[3], 45)
+	return result
+}
+
+// nextDouble returns a number in [0, 1).
+func (x *xoshiro) nextDouble() float64 {
+	return float64(x.next()) / (math.MaxUint64 + 1.0)
+}
+
+// nextInt returns a number in [low, high].
+func (x *xoshiro) nextInt(low, high int) int {
+	return int(x.nextDouble()*float64(high-low+1)) + low
+}
+
+// chooseDegree picks the number of fragments mixed into a part, with the
+// probability of a degree inversely proportional to it.
+func chooseDegree(seqLen int, rng *xoshiro) int {
+	weights := make([]float64, seqLen)
+	for i := range weights {
+		weights[i] = 1 / float64(i+1)
+	}
+	return newSampler(weights).next(rng) + 1
+}
+
+// sampler is the Vose alias method sampler of weighted indexes.
+type sampler struct {
+	probs   []float64
+	aliases []int
+}
+
+func newSampler(weights []float64) *sampler {
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	n := len(weights)
+	p := make([]float64, n)
+	for i, w := range weights {
+		p[i] = w * float64(n) / sum
+	}
+
+	var small, large []int
+	for j := n - 1; j >= 0; j-- {
+		if p[j] < 1 {
+			small = append(small, j)
+		} else {
+			large = append(large, j)
+		}
+	}
+
+	s := &sampler{probs: make([]float64, n), aliases: make([]int, n)}
+	for len(small) > 0 && len(large) > 0 {
+		a := small[len(small)-1]
+		small = small[:len(small)-1]
+		g := large[len(large)-1]
+		large = large[:len(large)-1]
+		s.probs[a] = p[a]
+		s.aliases[a] = g
+		p[g] += p[a] - 1
+		if p[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+	for _, g := range large {
+		s.probs[g] = 1
+	}
+	for _, a := range small {
+		s.probs[a] = 1
+	}
+	return s
+}
+
+func (s *sampler) next(rng *xoshiro) int {
+	r1 := rng.nextDouble()
+	r2 := rng.nextDouble()
+	i := int(float64(len(s.probs)) * r1)
+	if r2 < s.probs[i] {
+		return i
+	}
+	return s.aliases[i]
+}
+
+// chooseFragments returns the sorted indexes of the fragments mixed into the
+// part. The first seqLen parts are the fragments themselves, the following
+// ones mix fragments picked from the sequence number and message checksum.
+func chooseFragments(seqNum uint32, seqLen int, checksum uint32) []int {
+	if int(seqNum) <= seqLen {
+		return []int{int(seqNum) - 1}
+	}
+
+	seed := binary.BigEndian.AppendUint32(nil, seqNum)
+	seed = binary.BigEndian.AppendUint32(seed, checksum)
+	rng := newXoshiro(seed)
+	degree := chooseDegree(seqLen, rng)
+
+	remaining := make([]int, seqLen)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	shuffled := make([]int, 0, seqLen)
+	for len(remaining) > 0 {
+		i := rng.nextInt(0, len(remaining)-1)
+		shuffled = append(shuffled, remaining[i])
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+	indexes := shuffled[:degree]
+	sort.Ints(indexes)
+	return indexes
+}
+
+// fragmentLen returns the length of the fragments of a message, the length
+// of its smallest number of fragments that are no longer than maxLen.
+func fragmentLen(messageLen, maxLen int) int {
+	maxCount := messageLen / minFragmentLen
+	if maxCount < 1 {
+		maxCount = 1
+	}
+	var length int
+	for count := 1; count <= maxCount; count++ {
+		length = (messageLen + count - 1) / count
+		if length <= maxLen {
+			break
+		}
+	}
+	return length
+}
+
+func xorInto(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// part is a fragment, or the XOR of fragments, of a message.
+type part struct {
+	seqNum     uint32
+	seqLen     int
+	messageLen int
+	checksum   uint32
+	data       []byte
+}
+
+func (p *part) encode() []byte {
+	b := appendCBORHead(nil, cborArray, 5)
+	b = appendCBORHead(b, cborUint, uint64(p.seqNum))
+	b = appendCBORHead(b, cborUint, uint64(p.seqLen))
+	b = appendCBORHead(b, cborUint, uint64(p.messageLen))
+	b = appendCBORHead(b, cborUint, uint64(p.checksum))
+	b = appendCBORHead(b, cborBytes, uint64(len(p.data)))
+	return append(b, p.data...)
+}
+
+func decodePart(b []byte) (*part, error) {
+	r := &cborReader{b: b}
+	if n, err := r.head(cborArray); err != nil || n != 5 {
+		return nil, errors.E(errors.Encoding, "invalid UR part")
+	}
+	var fields [4]uint64
+	for i := range fields {
+		v, err := r.head(cborUint)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = v
+	}
+	data, err := r.bytes()
+	if err != nil {
+		return nil, err
+	}
+	if fields[0] == 0 || fields[0] > math.MaxUint32 || fields[1] == 0 || fields[1] > math.MaxUint16 ||
+		fields[2] == 0 || fields[2] > uint64(fields[1])*uint64(len(data)) || fields[3] > math.MaxUint32 {
+		return nil, errors.E(errors.Encoding, "invalid UR part")
+	}
+	return &part{
+		seqNum:     uint32(fields[0]),
+		seqLen:     int(fields[1]),
+		messageLen: int(fields[2]),
+		checksum:   uint32(fields[3]),
+		data:       data,
+	}, nil
+}
+
+// fountainEncoder emits an endless sequence of parts of a message from which
+// any large enough subset recovers the message.
+type fountainEncoder struct {
+	messageLen int
+	checksum   uint32
+	fragments  [][]byte
+	seqNum     uint32
+}
+
+func newFountainEncoder(message []byte, maxFragmentLen int) *fountainEncoder {
+	length := fragmentLen(len(message), maxFragmentLen)
+	padded := make([]byte, (len(message)+length-1)/length*length)
+	copy(padded, message)
+	e := &fountainEncoder{
+		messageLen: len(message),
+		checksum:   crc32.ChecksumIEEE(message),
+	}
+	for i := 0; i < len(padded); i += length {
+		e.fragments = append(e.fragments, padded[i:i+length])
+	}
+	return e
+}
+
+func (e *fountainEncoder) nextPart() *part {
+	e.seqNum++
+	data := make([]byte, len(e.fragments[0]))
+	for _, i := range chooseFragments(e.seqNum, len(e.fragments), e.checksum) {
+		xorInto(data, e.fragments[i])
+	}
+	return &part{
+		seqNum:     e.seqNum,
+		seqLen:     len(e.fragments),
+		messageLen: e.messageLen,
+		checksum:   e.checksum,
+		data:       data,
+	}
+}
+
+// mixedPart is a received part whose fragments are not known yet.
+type mixedPart struct {
+	indexes []int
+	data    []byte
+}
+
+// isSubset returns true if every index of a is in b.
+func isSubset(a, b []int) bool {
+	j := 0
+	for _, i := range a {
+		for j < len(b) && b[j] < i {
+			j++
+		}
+		if j == len(b) || b[j] != i {
+			return false
+		}
+	}
+	return true
+}
+
+// without returns the indexes of a that are not in b.
+func without(a, b []int) []int {
+	var rest []int
+	for _, i := range a {
+		if !isSubset([]int{i}, b) {
+			rest = append(rest, i)
+		}
+	}
+	return rest
+}
+
+// fountainDecoder recovers a message from its parts received in any order.
+type fountainDecoder struct {
+	seqLen     int
+	messageLen int
+	checksum   uint32
+	fragLen    int
+
+	simple  map[int][]byte
+	mixed   []*mixedPart
+	message []byte
+}
+
+func (d *fountainDecoder) receive(p *part) error {
+	if d.seqLen == 0 {
+		d.seqLen = p.seqLen
+		d.messageLen = p.messageLen
+		d.checksum = p.checksum
+		d.fragLen = len(p.data)
+		d.simple = make(map[int][]byte)
+	}
+	if p.seqLen != d.seqLen || p.messageLen != d.messageLen || p.checksum != d.checksum || len(p.data) != d.fragLen {
+		return errors.E(errors.Invalid, "the part belongs to another message")
+	}
+	if d.message != nil {
+		return nil
+	}
+
+	queue := []*mixedPart{{
+		indexes: chooseFragments(p.seqNum, p.seqLen, p.checksum),
+		data:    append([]byte(nil), p.data...),
+	}}
+	for len(queue) > 0 && d.message == nil {
+		m := queue[0]
+		queue = queue[1:]
+
+		// Remove the known fragments from the part.
+		var unknown []int
+		for _, i := range m.indexes {
+			if fragment, ok := d.simple[i]; ok {
+				xorInto(m.data, fragment)
+			} else {
+				unknown = append(unknown, i)
+			}
+		}
+		m.indexes = unknown
+
+		// Remove the mixed parts of some of its fragments.
+		for _, other := range d.mixed {
+			if len(other.indexes) < len(m.indexes) && isSubset(other.indexes, m.indexes) {
+				xorInto(m.data, other.data)
+				m.indexes = without(m.indexes, other.indexes)
+			}
+		}
+
+		switch len(m.indexes) {
+		case 0:
+			continue
+		case 1:
+			d.simple[m.indexes[0]] = m.data
+			// The mixed parts of the fragment are reduced again.
+			var kept []*mixedPart
+			for _, other := range d.mixed {
+				if isSubset(m.indexes, other.indexes) {
+					queue = append(queue, other)
+				} else {
+					kept = append(kept, other)
+				}
+			}
+			d.mixed = kept
+			if len(d.simple) == d.seqLen {
+				return d.join()
+			}
+		default:
+			if d.hasMixed(m.indexes) {
+				continue
+			}
+			// The mixed parts of more fragments are reduced by the part.
+			kept := []*mixedPart{m}
+			for _, other := range d.mixed {
+				if len(m.indexes) < len(other.indexes) && isSubset(m.indexes, other.indexes) {
+					xorInto(other.data, m.data)
+					other.indexes = without(other.indexes, m.indexes)
+					queue = append(queue, other)
+				} else {
+					kept = append(kept, other)
+				}
+			}
+			d.mixed = kept
+		}
+	}
+	return nil
+}
+
+// hasMixed returns true if a mixed part of the fragments was received.
+func (d *fountainDecoder) hasMixed(indexes []int) bool {
+	for _, m := range d.mixed {
+		if len(m.indexes) == len(indexes) && isSubset(m.indexes, indexes) {
+			return true
+		}
+	}
+	return false
+}
+
+// join assembles the message of the fragments and checks its checksum.
+func (d *fountainDecoder) join() error {
+	message := make([]byte, 0, d.seqLen*d.fragLen)
+	for i := 0; i < d.seqLen; i++ {
+		message = append(message, d.simple[i]...)
+	}
+	message = message[:d.messageLen]
+	if crc32.ChecksumIEEE(message) != d.checksum {
+		return errors.E(errors.Invalid, "invalid message checksum")
+	}
+	d.message = message
+	return nil
+}
+
+// progress returns the fraction of the fragments recovered.
+func (d *fountainDecoder) progress() float64 {
+	if d.message != nil {
+		return 1
+	}
+	if d.seqLen == 0 {
+		return 0
+	}
+	return float64(len(d.simple)) / float64(d.seqLen)
+}
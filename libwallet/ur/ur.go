@@ -0,0 +1,205 @@
+// Package ur implements the Uniform Resources (BC-UR) encoding of binary
+// messages as QR codes. A message too large for one code is split by a
+// fountain code into an endless sequence of parts shown as an animated QR
+// code, from which the scanner recovers the message after seeing any large
+// enough subset of the parts.
+package ur
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrwallet/v4/errors"
+)
+
+const (
+	// TypeBytes is the UR type of an untyped byte string.
+	TypeBytes = "bytes"
+	// TypePSBT is the UR type of a PSBT.
+	TypePSBT = "crypto-psbt"
+
+	scheme = "ur:"
+)
+
+// Encoder returns the parts of a message. A message that fits a single
+// fragment is a single part, repeated forever.
+type Encoder struct {
+	urType   string
+	message  []byte
+	fountain *fountainEncoder
+}
+
+// NewEncoder returns the encoder of the CBOR message of the UR type, split
+// into fragments of up to maxFragmentLen bytes.
+func NewEncoder(urType string, message []byte, maxFragmentLen int) (*Encoder, error) {
+	if !isValidType(urType) {
+		return nil, errors.E(errors.Invalid, fmt.Sprintf("invalid UR type %q", urType))
+	}
+	if len(message) == 0 {
+		return nil, errors.E(errors.Invalid, "empty UR message")
+	}
+	if maxFragmentLen < minFragmentLen {
+		maxFragmentLen = minFragmentLen
+	}
+	return &Encoder{
+		urType:   urType,
+		message:  message,
+		fountain: newFountainEncoder(message, maxFragmentLen),
+	}, nil
+}
+
+// IsSinglePart returns true if the message is encoded as a single part.
+func (e *Encoder) IsSinglePart() bool {
+	return len(e.fountain.fragments) == 1
+}
+
+// FragmentsCount returns the number of fragments of the message, the
+// smallest number of parts a scanner needs to recover it.
+func (e *Encoder) FragmentsCount() int {
+	return len(e.fountain.fragments)
+}
+
+// NextPart returns the next part of the message. The first parts are its
+// fragments in order, the following ones mixes of them.
+func (e *Encoder) NextPart() string {
+	if e.IsSinglePart() {
+		return Encode(e.urType, e.message)
+	}
+	p := e.fountain.nextPart()
+	return fmt.Sprintf("%s%s/%d-%d/%s", scheme, e.urType, p.seqNum, p.seqLen, encodeBytewords(p.encode()))
+}
+
+// Encode returns the single part UR of the CBOR message of the UR type.
+func Encode(urType string, message []byte) string {
+	return scheme + urType + "/" + encodeBytewords(message)
+}
+
+// Decoder recovers a message from its parts, received in any order.
+type Decoder struct {
+	urType   string
+	message  []byte
+	fountain fountainDecoder
+}
+
+// Receive adds a part scanned or read from a file. Parts of the same message
+// may be received more than once.
+func (d *Decoder) Receive(s string) error {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if !strings.HasPrefix(s, scheme) {
+		return errors.E(errors.Encoding, "not a UR")
+	}
+	components := strings.Split(s[len(scheme):], "/")
+	urType := components[0]
+	if !isValidType(urType) {
+		return errors.E(errors.Encoding, fmt.Sprintf("invalid UR type %q", urType))
+	}
+	if d.urType != "" && urType != d.urType {
+		return errors.E(errors.Invalid, fmt.Sprintf("expected a %s UR, got %s", d.urType, urType))
+	}
+
+	switch len(components) {
+	case 2:
+		message, err := decodeBytewords(components[1])
+		if err != nil {
+			return err
+		}
+		d.urType, d.message = urType, message
+		return nil
+
+	case 3:
+		seqNum, seqLen, ok := parseSequence(components[1])
+		if !ok {
+			return errors.E(errors.Encoding, "invalid UR sequence "+components[1])
+		}
+		b, err := decodeBytewords(components[2])
+		if err != nil {
+			return err
+		}
+		p, err := decodePart(b)
+		if err != nil {
+			return err
+		}
+		if p.seqNum != seqNum || p.seqLen != seqLen {
+			return errors.E(errors.Encoding, "invalid UR sequence "+components[1])
+		}
+		d.urType = urType
+		if d.message != nil {
+			return nil
+		}
+		if err := d.fountain.receive(p); err != nil {
+			return err
+		}
+		d.message = d.fountain.message
+		return nil
+
+	default:
+		return errors.E(errors.Encoding, "invalid UR")
+	}
+}
+
+// IsComplete returns true once the message is recovered.
+func (d *Decoder) IsComplete() bool {
+	return d.message != nil
+}
+
+// Progress returns the fraction of the message recovered.
+func (d *Decoder) Progress() float64 {
+	if d.message != nil {
+		return 1
+	}
+	return d.fountain.progress()
+}
+
+// Result returns the UR type and CBOR message of a complete decoder.
+func (d *Decoder) Result() (string, []byte, error) {
+	if d.message == nil {
+		return "", nil, errors.E(errors.Invalid, "incomplete UR")
+	}
+	return d.urType, d.message, nil
+}
+
+// Decode recovers the message of the parts.
+func Decode(parts ...string) (string, []byte, error) {
+	var d Decoder
+	for _, p := range parts {
+		if err := d.Receive(p); err != nil {
+			return "", nil, err
+		}
+		if d.IsComplete() {
+			break
+		}
+	}
+	return d.Result()
+}
+
+// isValidType returns true for UR types of lowercase letters, digits and
+// hyphens.
+func isValidType(urType string) bool {
+	if urType == "" {
+		return false
+	}
+	for _, c := range urType {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSequence parses the seqNum-seqLen component of a multipart UR.
+func parseSequence(s string) (uint32, int, bool) {
+	num, length, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	seqNum, err := strconv.ParseUint(num, 10, 32)
+	if err != nil || seqNum == 0 {
+		return 0, 0, false
+	}
+	seqLen, err := strconv.ParseUint(length, 10, 16)
+	if err != nil || seqLen == 0 {
+		return 0, 0, false
+	}
+	return uint32(seqNum), int(seqLen), true
+}
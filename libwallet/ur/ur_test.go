@@ -0,0 +1,137 @@
+package ur
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestBytewords(t *testing.T) {
+	data := []byte{0, 1, 2, 128, 255}
+	encoded := encodeBytewords(data)
+	if encoded != "aeadaolazmjendeoti" {
+		t.Fatalf("unexpected bytewords %s", encoded)
+	}
+	decoded, err := decodeBytewords(strings.ToUpper(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Fatalf("decoded %x, want %x", decoded, data)
+	}
+	if _, err := decodeBytewords("aeadaolazmjendeote"); err == nil {
+		t.Fatal("expected a checksum error")
+	}
+}
+
+func TestXoshiro(t *testing.T) {
+	want := []uint64{42, 81, 85, 8, 82, 84, 76, 73, 70, 88, 2, 74}
+	rng := newXoshiro([]byte("Wolf"))
+	for i, w := range want {
+		if got := rng.next() % 100; got != w {
+			t.Fatalf("number %d: got %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestFragmentLen(t *testing.T) {
+	tests := []struct {
+		messageLen, maxLen, want int
+	}{
+		{12345, 1955, 1764},
+		{12345, 30000, 12345},
+		{5, 100, 5},
+	}
+	for _, test := range tests {
+		if got := fragmentLen(test.messageLen, test.maxLen); got != test.want {
+			t.Errorf("fragmentLen(%d, %d) = %d, want %d", test.messageLen, test.maxLen, got, test.want)
+		}
+	}
+}
+
+func TestSinglePart(t *testing.T) {
+	message := EncodeBytes([]byte("a short message"))
+	encoder, err := NewEncoder(TypeBytes, message, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !encoder.IsSinglePart() {
+		t.Fatal("expected a single part")
+	}
+	urType, decoded, err := Decode(encoder.NextPart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := DecodeBytes(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if urType != TypeBytes || string(data) != "a short message" {
+		t.Fatalf("decoded %s %q", urType, data)
+	}
+}
+
+func TestFountain(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	data := make([]byte, 5000)
+	rng.Read(data)
+	message := EncodeBytes(data)
+
+	encoder, err := NewEncoder(TypePSBT, message, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoder.IsSinglePart() {
+		t.Fatal("expected multiple parts")
+	}
+
+	// Lose a third of the parts, including fragments, and receive some
+	// twice.
+	var d Decoder
+	for i := 0; i < 20*encoder.FragmentsCount() && !d.IsComplete(); i++ {
+		part := encoder.NextPart()
+		if rng.Intn(3) == 0 {
+			continue
+		}
+		if err := d.Receive(part); err != nil {
+			t.Fatal(err)
+		}
+		if rng.Intn(5) == 0 {
+			if err := d.Receive(strings.ToUpper(part)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	urType, decoded, err := d.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if urType != TypePSBT || !bytes.Equal(decoded, message) {
+		t.Fatal("decoded message does not match")
+	}
+	if d.Progress() != 1 {
+		t.Fatalf("progress %f of a complete message", d.Progress())
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	first, _ := NewEncoder(TypeBytes, EncodeBytes(bytes.Repeat([]byte{1}, 500)), 100)
+	second, _ := NewEncoder(TypeBytes, EncodeBytes(bytes.Repeat([]byte{2}, 500)), 100)
+
+	var d Decoder
+	if err := d.Receive(first.NextPart()); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Receive(second.NextPart()); err == nil {
+		t.Fatal("expected an error for a part of another message")
+	}
+	if err := d.Receive(Encode(TypePSBT, []byte{1})); err == nil {
+		t.Fatal("expected an error for a UR of another type")
+	}
+	for _, s := range []string{"", "bytes/aeadaolazmjendeoti", "ur:Bytes!/ae", "ur:bytes/1-0/aeadaolazmjendeoti"} {
+		if err := new(Decoder).Receive(s); err == nil {
+			t.Errorf("expected an error for %q", s)
+		}
+	}
+}
@@ -47,6 +47,9 @@ const (
 	ErrInvalidVoteBit               = "err_invalid_vote_bit"
 	ErrNotSynced                    = "err_not_synced"
 	ErrNoSeed                       = "no_seed"
+	ErrTxAuthorBusy                 = "tx_author_busy"
+	ErrRPCPasswordRequired          = "rpc_password_required"
+	ErrAccountRescanUnsupported     = "account_rescan_unsupported"
 )
 
 var (
@@ -0,0 +1,375 @@
+package libwallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"decred.org/dcrwallet/v4/errors"
+	"github.com/crypto-power/cryptopower/libwallet/assets/btc"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/assets/wallet/walletdata"
+	"github.com/crypto-power/cryptopower/libwallet/dbcheck"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	bolt "go.etcd.io/bbolt"
+)
+
+// walletDbName is the name of the wallet database of every asset.
+const walletDbName = "wallet.db"
+
+// WalletIssue is a kind of problem found by the diagnosis of a wallet.
+type WalletIssue string
+
+const (
+	// IssueLoadFailed is reported with the error of the failed load of the
+	// wallet.
+	IssueLoadFailed WalletIssue = "load_failed"
+	// IssueUnknownAsset is reported for the wallets of unsupported asset
+	// types.
+	IssueUnknownAsset WalletIssue = "unknown_asset"
+	// IssueWalletDbMissing is reported if the wallet database is missing.
+	IssueWalletDbMissing WalletIssue = "wallet_db_missing"
+	// IssueWalletDbCorrupt is reported if the wallet database fails to open
+	// or to be read.
+	IssueWalletDbCorrupt WalletIssue = "wallet_db_corrupt"
+	// IssueMissingBuckets is reported if the wallet database lacks the
+	// buckets of the wallet.
+	IssueMissingBuckets WalletIssue = "missing_buckets"
+	// IssueWalletDataCorrupt is reported if the wallet data database, the tx
+	// index, fails to open or to be read.
+	IssueWalletDataCorrupt WalletIssue = "wallet_data_corrupt"
+	// IssueStaleTxIndex is reported if the tx index has another version than
+	// walletdata.TxDbVersion.
+	IssueStaleTxIndex WalletIssue = "stale_tx_index"
+	// IssueOrphanedConfig is reported if config remains of deleted wallets.
+	IssueOrphanedConfig WalletIssue = "orphaned_config"
+)
+
+// RepairAction is a repair of the problems of a wallet.
+type RepairAction string
+
+const (
+	// RepairReindex drops the tx index of a DCR wallet, which is rebuilt on
+	// the next sync.
+	RepairReindex RepairAction = "reindex"
+	// RepairDropFilterCache drops the block headers and filters of a BTC or
+	// LTC wallet, which are downloaded again on the next sync.
+	RepairDropFilterCache RepairAction = "drop_filter_cache"
+	// RepairRestoreFromSeed replaces the wallet database with one restored
+	// from the seed of the wallet. The wallet keeps its ID, name and config.
+	RepairRestoreFromSeed RepairAction = "restore_from_seed"
+	// RepairRemoveOrphanedConfig removes the config of deleted wallets.
+	RepairRemoveOrphanedConfig RepairAction = "remove_orphaned_config"
+)
+
+// neutrinoCacheFiles are the files of the block headers and filters of the
+// BTC and LTC wallets. The wallet data database is shared with neutrino.
+var neutrinoCacheFiles = []string{walletdata.BTCDBName, "block_headers.bin", "reg_filter_headers.bin"}
+
+// WalletProblem is a problem found by the diagnosis of a wallet.
+type WalletProblem struct {
+	Issue   WalletIssue
+	Detail  string
+	Repairs []RepairAction
+}
+
+// WalletDiagnosis is the report of the diagnosis of a wallet.
+type WalletDiagnosis struct {
+	WalletID   int
+	WalletName string
+	AssetType  utils.AssetType
+	Problems   []*WalletProblem
+}
+
+// Healthy returns true if no problems were found.
+func (d *WalletDiagnosis) Healthy() bool {
+	return len(d.Problems) == 0
+}
+
+// Repairs returns the repairs of the problems found, without duplicates.
+func (d *WalletDiagnosis) Repairs() []RepairAction {
+	var repairs []RepairAction
+	seen := make(map[RepairAction]bool)
+	for _, problem := range d.Problems {
+		for _, repair := range problem.Repairs {
+			if !seen[repair] {
+				seen[repair] = true
+				repairs = append(repairs, repair)
+			}
+		}
+	}
+	return repairs
+}
+
+func (d *WalletDiagnosis) addProblem(issue WalletIssue, detail string, repairs ...RepairAction) {
+	d.Problems = append(d.Problems, &WalletProblem{Issue: issue, Detail: detail, Repairs: repairs})
+}
+
+// BadWalletError returns the error of the failed load of the bad wallet.
+func (mgr *AssetsManager) BadWalletError(walletID int) error {
+	return mgr.badWalletErrors[walletID]
+}
+
+// DiagnoseBadWallet checks the databases of the bad wallet and the config of
+// the deleted wallets, and reports the problems found with their repairs.
+func (mgr *AssetsManager) DiagnoseBadWallet(walletID int) (*WalletDiagnosis, error) {
+	wallet := mgr.getbadWallet(walletID)
+	if wallet == nil {
+		return nil, errors.New(utils.ErrNotExist)
+	}
+
+	diagnosis := &WalletDiagnosis{
+		WalletID:   wallet.ID,
+		WalletName: wallet.Name,
+		AssetType:  wallet.Type,
+	}
+	if err := mgr.badWalletErrors[wallet.ID]; err != nil {
+		diagnosis.addProblem(IssueLoadFailed, err.Error())
+	}
+
+	var dbDriver, walletDataName string
+	var requiredBuckets []string
+	var dataRepair RepairAction
+	switch wallet.Type {
+	case utils.DCRWalletAsset:
+		dbDriver, walletDataName = mgr.DBDriver(), walletdata.DCRDbName
+		requiredBuckets = []string{"waddrmgr", "wtxmgr", "wstakemgr"}
+		dataRepair = RepairReindex
+	case utils.BTCWalletAsset, utils.LTCWalletAsset:
+		// The BTC and LTC wallet databases are always bolt databases.
+		dbDriver, walletDataName = BoltDB, walletdata.BTCDBName
+		requiredBuckets = []string{"waddrmgr", "wtxmgr"}
+		dataRepair = RepairDropFilterCache
+	default:
+		diagnosis.addProblem(IssueUnknownAsset, string(wallet.Type))
+		return diagnosis, nil
+	}
+
+	var seedRepairs []RepairAction
+	if wallet.HasWalletSeed() {
+		seedRepairs = append(seedRepairs, RepairRestoreFromSeed)
+	}
+
+	dataDir := mgr.badWalletDataDir(wallet)
+	report, err := dbcheck.Check(dbDriver, filepath.Join(dataDir, walletDbName), requiredBuckets)
+	switch {
+	case errors.Is(err, errors.NotExist):
+		diagnosis.addProblem(IssueWalletDbMissing, err.Error(), seedRepairs...)
+	case err != nil:
+		diagnosis.addProblem(IssueWalletDbCorrupt, err.Error(), seedRepairs...)
+	default:
+		if len(report.Problems) > 0 {
+			diagnosis.addProblem(IssueWalletDbCorrupt, strings.Join(report.Problems, "; "), seedRepairs...)
+		}
+		if len(report.MissingBuckets) > 0 {
+			diagnosis.addProblem(IssueMissingBuckets, strings.Join(report.MissingBuckets, ", "), seedRepairs...)
+		}
+	}
+
+	walletDataPath := filepath.Join(dataDir, walletDataName)
+	if fileExists(walletDataPath) {
+		version, _, err := walletdata.ReadIndexInfo(walletDataPath)
+		if err != nil {
+			diagnosis.addProblem(IssueWalletDataCorrupt, err.Error(), dataRepair)
+		} else if version != walletdata.TxDbVersion {
+			diagnosis.addProblem(IssueStaleTxIndex,
+				fmt.Sprintf("version %d, expected version %d", version, walletdata.TxDbVersion), dataRepair)
+		}
+	}
+
+	orphaned, err := mgr.orphanedConfigKeys()
+	if err != nil {
+		return nil, err
+	}
+	if len(orphaned) > 0 {
+		diagnosis.addProblem(IssueOrphanedConfig, fmt.Sprintf("%d keys", len(orphaned)), RepairRemoveOrphanedConfig)
+	}
+	return diagnosis, nil
+}
+
+// RepairBadWallet runs the repair on the bad wallet then loads it again. The
+// private passphrase of the wallet is only required to restore it from its
+// seed. The wallet is no longer a bad wallet once it loads.
+func (mgr *AssetsManager) RepairBadWallet(walletID int, repair RepairAction, privatePassphrase string) error {
+	wallet := mgr.getbadWallet(walletID)
+	if wallet == nil {
+		return errors.New(utils.ErrNotExist)
+	}
+
+	dataDir := mgr.badWalletDataDir(wallet)
+	switch repair {
+	case RepairReindex:
+		if wallet.Type != utils.DCRWalletAsset {
+			return errors.E(errors.Invalid, fmt.Sprintf("%s wallets have no tx index to rebuild", wallet.Type))
+		}
+		if err := removeFiles(dataDir, walletdata.DCRDbName); err != nil {
+			return err
+		}
+
+	case RepairDropFilterCache:
+		if wallet.Type != utils.BTCWalletAsset && wallet.Type != utils.LTCWalletAsset {
+			return errors.E(errors.Invalid, fmt.Sprintf("%s wallets have no filter cache", wallet.Type))
+		}
+		if err := removeFiles(dataDir, neutrinoCacheFiles...); err != nil {
+			return err
+		}
+
+	case RepairRestoreFromSeed:
+		return mgr.restoreBadWallet(wallet, privatePassphrase)
+
+	case RepairRemoveOrphanedConfig:
+		return mgr.removeOrphanedConfig()
+
+	default:
+		return errors.E(errors.Invalid, fmt.Sprintf("unknown repair %q", repair))
+	}
+
+	log.Infof("Repaired bad wallet %d (%s) with %s, loading it", wallet.ID, wallet.Name, repair)
+	return mgr.reloadBadWallet(wallet)
+}
+
+// badWalletDataDir returns the data directory of the bad wallet, whose root
+// directory is not set if its load failed early.
+func (mgr *AssetsManager) badWalletDataDir(wallet *sharedW.Wallet) string {
+	return sharedW.WalletDataDir(mgr.RootDir(), mgr.NetType(), wallet.Type, wallet.ID)
+}
+
+// reloadBadWallet loads the bad wallet, moving it to the wallets once loaded.
+func (mgr *AssetsManager) reloadBadWallet(wallet *sharedW.Wallet) error {
+	var asset sharedW.Asset
+	var err error
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
+		asset, err = btc.LoadExisting(wallet, mgr.params)
+	case utils.DCRWalletAsset:
+		asset, err = dcr.LoadExisting(wallet, mgr.params)
+	case utils.LTCWalletAsset:
+		asset, err = ltc.LoadExisting(wallet, mgr.params)
+	default:
+		err = errors.Errorf("unknown asset type %q", wallet.Type)
+	}
+	if err != nil {
+		mgr.badWalletErrors[wallet.ID] = err
+		return err
+	}
+	mgr.addRepairedWallet(wallet, asset)
+	return nil
+}
+
+// restoreBadWallet replaces the wallet database of the bad wallet with one
+// restored from the seed of the wallet.
+func (mgr *AssetsManager) restoreBadWallet(wallet *sharedW.Wallet, privatePassphrase string) error {
+	seedMnemonic, err := wallet.DecryptSeed(privatePassphrase)
+	if err != nil {
+		return err
+	}
+	wordSeedType := sharedW.WordSeedType(len(strings.Fields(seedMnemonic)))
+
+	var asset sharedW.Asset
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
+		asset, err = btc.RecreateWallet(wallet, seedMnemonic, privatePassphrase, wordSeedType, mgr.params)
+	case utils.DCRWalletAsset:
+		asset, err = dcr.RecreateWallet(wallet, seedMnemonic, privatePassphrase, wordSeedType, mgr.params)
+	case utils.LTCWalletAsset:
+		asset, err = ltc.RecreateWallet(wallet, seedMnemonic, privatePassphrase, wordSeedType, mgr.params)
+	default:
+		err = errors.Errorf("unknown asset type %q", wallet.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Restored bad wallet %d (%s) from its seed", wallet.ID, wallet.Name)
+	mgr.addRepairedWallet(wallet, asset)
+	return nil
+}
+
+// addRepairedWallet moves the repaired bad wallet to the wallets.
+func (mgr *AssetsManager) addRepairedWallet(wallet *sharedW.Wallet, asset sharedW.Asset) {
+	mgr.removeBadWallet(wallet)
+	switch wallet.Type {
+	case utils.BTCWalletAsset:
+		mgr.Assets.BTC.Wallets[wallet.ID] = asset
+	case utils.DCRWalletAsset:
+		mgr.Assets.DCR.Wallets[wallet.ID] = asset
+	case utils.LTCWalletAsset:
+		mgr.Assets.LTC.Wallets[wallet.ID] = asset
+	}
+	mgr.attachEventListener(asset)
+}
+
+// orphanedConfigKeys returns the keys of the wallet config of the wallets no
+// longer saved.
+func (mgr *AssetsManager) orphanedConfigKeys() ([]string, error) {
+	var wallets []*sharedW.Wallet
+	if err := mgr.params.DB.All(&wallets); err != nil {
+		return nil, err
+	}
+	walletIDs := make(map[int]bool, len(wallets))
+	for _, wallet := range wallets {
+		walletIDs[wallet.ID] = true
+	}
+
+	var keys []string
+	err := mgr.params.DB.Bolt.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(walletsMetadataBucketName))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, _ []byte) error {
+			if walletID, ok := configKeyWalletID(string(k)); ok && !walletIDs[walletID] {
+				keys = append(keys, string(k))
+			}
+			return nil
+		})
+	})
+	return keys, err
+}
+
+// removeOrphanedConfig removes the wallet config of the wallets no longer
+// saved.
+func (mgr *AssetsManager) removeOrphanedConfig() error {
+	keys, err := mgr.orphanedConfigKeys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := mgr.params.DB.Delete(walletsMetadataBucketName, key); err != nil {
+			return err
+		}
+	}
+	log.Infof("Removed %d config keys of deleted wallets", len(keys))
+	return nil
+}
+
+// configKeyWalletID returns the ID of the wallet of the wallet config key,
+// which is the ID of the wallet followed by the name of the config.
+func configKeyWalletID(key string) (int, bool) {
+	i := strings.IndexFunc(key, func(r rune) bool {
+		return !unicode.IsDigit(r)
+	})
+	if i <= 0 {
+		return 0, false
+	}
+	walletID, err := strconv.Atoi(key[:i])
+	if err != nil {
+		return 0, false
+	}
+	return walletID, true
+}
+
+// removeFiles removes the files of the directory, ignoring missing files.
+func removeFiles(dir string, names ...string) error {
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return errors.E(errors.IO, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package libwallet
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigKeyWalletID(t *testing.T) {
+	tests := []struct {
+		key      string
+		walletID int
+		ok       bool
+	}{
+		{"1log_level", 1, true},
+		{"12spv_peer_addresses", 12, true},
+		{"startup-passphrase", 0, false},
+		{"12", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		walletID, ok := configKeyWalletID(test.key)
+		if walletID != test.walletID || ok != test.ok {
+			t.Errorf("%q: expected %d %v, got %d %v", test.key, test.walletID, test.ok, walletID, ok)
+		}
+	}
+}
+
+func TestWalletDiagnosisRepairs(t *testing.T) {
+	diagnosis := new(WalletDiagnosis)
+	if !diagnosis.Healthy() {
+		t.Fatal("expected a diagnosis without problems to be healthy")
+	}
+
+	diagnosis.addProblem(IssueLoadFailed, "wallet data database is in use")
+	diagnosis.addProblem(IssueWalletDbCorrupt, "page 3: unreachable unfreed", RepairRestoreFromSeed)
+	diagnosis.addProblem(IssueMissingBuckets, "wstakemgr", RepairRestoreFromSeed)
+	diagnosis.addProblem(IssueStaleTxIndex, "version 2, expected version 3", RepairReindex)
+
+	expected := []RepairAction{RepairRestoreFromSeed, RepairReindex}
+	if repairs := diagnosis.Repairs(); !reflect.DeepEqual(repairs, expected) {
+		t.Fatalf("expected repairs %v, got %v", expected, repairs)
+	}
+}
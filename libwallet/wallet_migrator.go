@@ -1,115 +1,168 @@
 package libwallet
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"decred.org/dcrwallet/v4/errors"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/dbmigrate"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 )
 
-type WalletMigrator struct {
-	wallet            sharedW.Asset
-	privatePassphrase string
-	seed              string
-	isMigrate         bool
-}
-
-func NewWalletMigrator(wallet sharedW.Asset) *WalletMigrator {
-	return &WalletMigrator{
-		wallet: wallet,
-	}
-}
+// dcrWalletDbName is the name of the walletdb database of the DCR wallets,
+// the only wallet databases that depend on the db driver.
+const dcrWalletDbName = "wallet.db"
 
-func (wm *WalletMigrator) GetIsMigrate() bool {
-	return wm.isMigrate
-}
+// MigrationProgress reports the number of keys of the database of the wallet
+// copied out of its total keys.
+type MigrationProgress func(walletName string, copied, total int)
 
-func (wm *WalletMigrator) SetIsMigrate(isMigrate bool) {
-	wm.isMigrate = isMigrate
-}
+// WalletMigrator moves the root directory of an assets manager to another db
+// driver. The DCR wallet databases are copied key by key, so the wallets keep
+// their labels, account names and config.
+type WalletMigrator struct {
+	mgr      *AssetsManager
+	dbDriver string
 
-func (wm *WalletMigrator) GetAssetType() libutils.AssetType {
-	return wm.wallet.GetAssetType()
+	// walletNames are the names of the DCR wallets by ID.
+	walletNames map[string]string
 }
 
-func (wm *WalletMigrator) GetWalletName() string {
-	return wm.wallet.GetWalletName()
+// NewWalletMigrator returns a migrator of the root directory of the manager to
+// the db driver.
+func NewWalletMigrator(mgr *AssetsManager, dbDriver string) *WalletMigrator {
+	wm := &WalletMigrator{
+		mgr:         mgr,
+		dbDriver:    dbDriver,
+		walletNames: make(map[string]string),
+	}
+	for _, wallet := range mgr.AllDCRWallets() {
+		wm.walletNames[strconv.Itoa(wallet.GetWalletID())] = wallet.GetWalletName()
+	}
+	for id, wallet := range mgr.Assets.DCR.BadWallets {
+		wm.walletNames[strconv.Itoa(id)] = wallet.Name
+	}
+	return wm
 }
 
-func (wm *WalletMigrator) IsWatchingOnlyWallet() bool {
-	return wm.wallet.IsWatchingOnlyWallet()
+// Wallets returns the wallets the migration moves.
+func (wm *WalletMigrator) Wallets() []sharedW.Asset {
+	return wm.mgr.AllWallets()
 }
 
-func (wm *WalletMigrator) SetPrivatePassphrase(privatePassphrase string) error {
-	seed, err := wm.wallet.DecryptSeed(privatePassphrase)
-	if err != nil {
-		return err
+// Migrate shuts the manager down, migrates its root directory and returns the
+// manager of the migrated root directory. The root directory is only replaced
+// once all its databases were migrated. If the migration fails the original
+// root directory is reopened and returned with the error.
+func (wm *WalletMigrator) Migrate(progress MigrationProgress) (*AssetsManager, error) {
+	srcDriver := wm.mgr.DBDriver()
+	if srcDriver == wm.dbDriver {
+		return wm.mgr, errors.E(errors.Invalid, fmt.Sprintf("wallets already use the %s db driver", wm.dbDriver))
 	}
 
-	wm.privatePassphrase = privatePassphrase
-	wm.seed = seed
-	wm.isMigrate = true
-	return nil
-}
-
-func (wm *WalletMigrator) SetSeed(seed string) error {
-	wm.seed = seed
-	wm.isMigrate = true
-	return nil
-}
-
-func (wm *WalletMigrator) Migrate(mgr *AssetsManager) error {
-	if !wm.isMigrate {
-		return nil
+	srcRoot := wm.mgr.RootDir()
+	parentDir := filepath.Dir(srcRoot)
+	netType := wm.mgr.NetType()
+	dstRoot := filepath.Join(parentDir, fmt.Sprintf("%s-%s", string(netType), wm.dbDriver))
+	if fileExists(dstRoot) {
+		return wm.mgr, errors.E(errors.Exist, fmt.Sprintf("%s already exists", dstRoot))
 	}
+	logDir, dexTestAddr := wm.mgr.ParamLogDir(), wm.mgr.DEXTestAddr()
 
-	if wm.wallet.IsWatchingOnlyWallet() {
-		return wm.migrateWatchingOnlyWallet(mgr)
+	// The databases can only be copied once closed.
+	wm.mgr.Shutdown()
+
+	stagingDir := dstRoot + ".migrating"
+	_ = os.RemoveAll(stagingDir)
+	err := wm.migrateRootDir(srcRoot, stagingDir, srcDriver, progress)
+	if err == nil {
+		err = os.Rename(stagingDir, dstRoot)
 	}
-	var err error
-	switch wm.wallet.GetAssetType() {
-	case libutils.DCRWalletAsset:
-		_, err = mgr.RestoreDCRWallet(wm.wallet.GetWalletName(), wm.seed, wm.privatePassphrase, sharedW.WordSeedType(wm.wallet.GetPrivatePassphraseType()), wm.wallet.GetPrivatePassphraseType())
-		if err != nil {
-			return err
+	if err != nil {
+		_ = os.RemoveAll(stagingDir)
+		log.Errorf("Migrating the wallets to the %s db driver failed: %v", wm.dbDriver, err)
+		mgr, openErr := NewAssetsManager(parentDir, logDir, netType, dexTestAddr)
+		if openErr != nil {
+			return nil, errors.Errorf("%v: reopening the wallets failed: %v", err, openErr)
 		}
+		return mgr, err
+	}
 
-	case libutils.BTCWalletAsset:
-		_, err = mgr.RestoreBTCWallet(wm.wallet.GetWalletName(), wm.seed, wm.privatePassphrase, sharedW.WordSeedType(wm.wallet.GetPrivatePassphraseType()), wm.wallet.GetPrivatePassphraseType())
-		if err != nil {
-			return err
-		}
+	if err := os.RemoveAll(srcRoot); err != nil {
+		log.Errorf("Removing the migrated root directory failed: %v", err)
+	}
 
-	case libutils.LTCWalletAsset:
-		_, err = mgr.RestoreLTCWallet(wm.wallet.GetWalletName(), wm.seed, wm.privatePassphrase, sharedW.WordSeedType(wm.wallet.GetPrivatePassphraseType()), wm.wallet.GetPrivatePassphraseType())
-		if err != nil {
-			return err
-		}
+	mgr, err := NewAssetsManager(parentDir, logDir, netType, dexTestAddr)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	mgr.SetDBDriver(wm.dbDriver)
+	return mgr, nil
 }
 
-func (wm *WalletMigrator) migrateWatchingOnlyWallet(mgr *AssetsManager) error {
-	if !wm.isMigrate {
-		return nil
-	}
-	switch wm.wallet.GetAssetType() {
-	case libutils.DCRWalletAsset:
-		_, err := mgr.CreateNewDCRWatchOnlyWallet(wm.wallet.GetWalletName(), wm.seed)
+// migrateRootDir copies the root directory to the staging directory,
+// migrating the DCR wallet databases to the db driver of the migrator.
+func (wm *WalletMigrator) migrateRootDir(srcRoot, stagingDir, srcDriver string, progress MigrationProgress) error {
+	return filepath.Walk(srcRoot, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-
-	case libutils.BTCWalletAsset:
-		_, err := mgr.CreateNewBTCWatchOnlyWallet(wm.wallet.GetWalletName(), wm.seed)
+		relPath, err := filepath.Rel(srcRoot, path)
 		if err != nil {
 			return err
 		}
+		dstPath := filepath.Join(stagingDir, relPath)
+
+		walletDir := filepath.Dir(path)
+		if info.Name() == dcrWalletDbName &&
+			filepath.Base(filepath.Dir(walletDir)) == libutils.DCRWalletAsset.ToStringLower() {
+			walletID := filepath.Base(walletDir)
+			walletName, ok := wm.walletNames[walletID]
+			if !ok {
+				walletName = walletID
+			}
+			log.Infof("Migrating the database of wallet %s to the %s db driver", walletName, wm.dbDriver)
+			err := dbmigrate.Migrate(srcDriver, path, wm.dbDriver, dstPath, func(copied, total int) {
+				if progress != nil {
+					progress(walletName, copied, total)
+				}
+			})
+			if err != nil {
+				return fmt.Errorf("wallet %s: %w", walletName, err)
+			}
+			if info.IsDir() {
+				// Badger databases are directories.
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
-	case libutils.LTCWalletAsset:
-		_, err := mgr.CreateNewLTCWatchOnlyWallet(wm.wallet.GetWalletName(), wm.seed)
-		if err != nil {
-			return err
+		if info.IsDir() {
+			return os.MkdirAll(dstPath, info.Mode().Perm())
 		}
+		return copyFile(path, dstPath, info.Mode().Perm())
+	})
+}
 
+// copyFile copies the file at srcPath to dstPath.
+func copyFile(srcPath, dstPath string, perm os.FileMode) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
 	}
-	return nil
+	return dst.Close()
 }
@@ -14,8 +14,16 @@ import (
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	"github.com/crypto-power/cryptopower/libwallet/assets/ltc"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/batchsend"
+	"github.com/crypto-power/cryptopower/libwallet/dexbot"
+	"github.com/crypto-power/cryptopower/libwallet/electrum"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/libwallet/hooks"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/multisig"
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/libwallet/payrequests"
+	"github.com/crypto-power/cryptopower/libwallet/trezor"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/logger"
 	"github.com/crypto-power/cryptopower/ui"
@@ -147,6 +155,14 @@ func init() {
 	dcrw.UseLogger(dcrLog)
 	spv.UseLogger(dcrSpv)
 	instantswap.UseLogger(sharedWLog)
+	dexbot.UseLogger(sharedWLog)
+	notifications.UseLogger(sharedWLog)
+	hooks.UseLogger(sharedWLog)
+	payrequests.UseLogger(sharedWLog)
+	multisig.UseLogger(sharedWLog)
+	batchsend.UseLogger(sharedWLog)
+	electrum.UseLogger(sharedWLog)
+	trezor.UseLogger(sharedWLog)
 	dcrdex.UseLogger(winLog)
 	account.UseLogger(winLog)
 	wallet.UseLogger(winLog)
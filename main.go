@@ -17,6 +17,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui"
 	_ "github.com/crypto-power/cryptopower/ui/assets"
 	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
 )
 
 const (
@@ -101,6 +102,11 @@ func main() {
 		return
 	}
 
+	// Load the user provided translations before any page is created.
+	if err := values.LoadLocalePacks(filepath.Join(cfg.HomeDir, values.LocalesDirName)); err != nil {
+		log.Errorf("Error loading locale packs: %v", err)
+	}
+
 	win, err := ui.CreateWindow(appInfo)
 	if err != nil {
 		log.Errorf("Could not initialize window: %s\ns", err)
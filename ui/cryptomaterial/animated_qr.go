@@ -0,0 +1,100 @@
+package cryptomaterial
+
+import (
+	"image"
+	"io"
+	"strings"
+	"time"
+
+	"gioui.org/op"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	qrcode "github.com/yeqown/go-qrcode"
+	"golang.org/x/image/draw"
+)
+
+// animatedQRInterval is how long each frame of an animated QR code shows.
+const animatedQRInterval = 250 * time.Millisecond
+
+// QRPartSource is the endless sequence of parts of a message shown by an
+// animated QR code, such as a UR encoder.
+type QRPartSource interface {
+	NextPart() string
+	IsSinglePart() bool
+}
+
+// AnimatedQR shows a message too large for one QR code as a sequence of QR
+// codes, a frame per part of the message. A message of a single part shows
+// as a still QR code.
+type AnimatedQR struct {
+	source QRPartSource
+
+	frame     image.Image
+	scaled    *image.RGBA
+	scaledDp  unit.Dp
+	nextFrame time.Time
+	err       error
+}
+
+// AnimatedQR returns the animated QR code of the parts of the source.
+func (t *Theme) AnimatedQR(source QRPartSource) *AnimatedQR {
+	return &AnimatedQR{source: source}
+}
+
+// Err returns the error of the last frame that could not be drawn.
+func (q *AnimatedQR) Err() error {
+	return q.err
+}
+
+// qrImageEncoder keeps the image of a QR code instead of encoding it.
+type qrImageEncoder struct {
+	img image.Image
+}
+
+func (e *qrImageEncoder) Encode(_ io.Writer, img image.Image) error {
+	e.img = img
+	return nil
+}
+
+// newFrame draws the QR code of the next part. The parts are upper case for
+// the compact alphanumeric mode of QR codes.
+func (q *AnimatedQR) newFrame() {
+	encoder := new(qrImageEncoder)
+	qrCode, err := qrcode.New(strings.ToUpper(q.source.NextPart()), qrcode.WithQRWidth(4),
+		qrcode.WithCustomImageEncoder(encoder))
+	if err == nil {
+		err = qrCode.SaveTo(io.Discard)
+	}
+	q.err = err
+	if err != nil {
+		return
+	}
+	q.frame = encoder.img
+	q.scaled = nil
+}
+
+// Layout draws the current frame as a square of the size and schedules the
+// next one.
+func (q *AnimatedQR) Layout(gtx C, size unit.Dp) D {
+	if q.frame == nil || (!q.source.IsSinglePart() && !gtx.Now.Before(q.nextFrame)) {
+		q.newFrame()
+		q.nextFrame = gtx.Now.Add(animatedQRInterval)
+	}
+	if !q.source.IsSinglePart() {
+		gtx.Execute(op.InvalidateCmd{At: q.nextFrame})
+	}
+	if q.frame == nil {
+		return D{}
+	}
+
+	// The modules of the code are scaled without smoothing to keep their
+	// edges sharp.
+	if q.scaled == nil || q.scaledDp != size {
+		px := gtx.Dp(size)
+		q.scaled = image.NewRGBA(image.Rectangle{Max: image.Point{X: px, Y: px}})
+		q.scaledDp = size
+		draw.NearestNeighbor.Scale(q.scaled, q.scaled.Bounds(), q.frame, q.frame.Bounds(), draw.Src, nil)
+	}
+	return widget.Image{Src: paint.NewImageOp(q.scaled), Scale: 1 / gtx.Metric.PxPerDp}.Layout(gtx)
+}
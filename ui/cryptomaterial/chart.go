@@ -0,0 +1,585 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package cryptomaterial
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// defaultMaxCandles is the maximum number of candles drawn by a
+// CandlestickChart when MaxCandles is not set.
+const defaultMaxCandles = 80
+
+// CandleData is a single candlestick. All rates and volumes are expected in
+// conventional units.
+type CandleData struct {
+	StartStamp uint64 // milliseconds
+	EndStamp   uint64 // milliseconds
+	Open       float64
+	High       float64
+	Low        float64
+	Close      float64
+	Volume     float64
+}
+
+// DepthEntry is an order book entry used to draw a DepthChart. Rate and Qty
+// are expected in conventional units.
+type DepthEntry struct {
+	Rate float64
+	Qty  float64
+}
+
+// ChartMarker highlights a price on a chart, e.g. the price of one of the
+// user's own open orders.
+type ChartMarker struct {
+	Price float64
+	Sell  bool
+}
+
+// CandlestickChart draws candlesticks with a volume histogram underneath.
+// Candles and markers can be updated from any goroutine.
+type CandlestickChart struct {
+	t *Theme
+
+	mtx     sync.RWMutex
+	candles []CandleData
+	markers []ChartMarker
+
+	Height     unit.Dp
+	MaxCandles int
+
+	UpColor     color.NRGBA
+	DownColor   color.NRGBA
+	GridColor   color.NRGBA
+	VolumeColor color.NRGBA
+}
+
+// DepthChart draws the cumulative buy and sell quantities of an order book.
+// The book and markers can be updated from any goroutine.
+type DepthChart struct {
+	t *Theme
+
+	mtx     sync.RWMutex
+	buys    []DepthEntry
+	sells   []DepthEntry
+	markers []ChartMarker
+
+	Height unit.Dp
+
+	BuyColor  color.NRGBA
+	SellColor color.NRGBA
+	GridColor color.NRGBA
+}
+
+// LinePoint is a value drawn by a LineChart at a point in time.
+type LinePoint struct {
+	Stamp int64 // unix timestamp
+	Value float64
+}
+
+// LineChart draws values over time as a line with the area under it filled.
+// Points can be updated from any goroutine.
+type LineChart struct {
+	t *Theme
+
+	mtx    sync.RWMutex
+	points []LinePoint
+
+	Height unit.Dp
+
+	LineColor color.NRGBA
+	GridColor color.NRGBA
+
+	// FormatValue formats the value labels. Values are formatted with their
+	// significant decimals if FormatValue is nil.
+	FormatValue func(float64) string
+}
+
+// CandlestickChart returns a new *CandlestickChart with the theme colors.
+func (t *Theme) CandlestickChart() *CandlestickChart {
+	return &CandlestickChart{
+		t:           t,
+		Height:      values.MarginPadding300,
+		MaxCandles:  defaultMaxCandles,
+		UpColor:     t.Color.Green500,
+		DownColor:   t.Color.OrangeRipple,
+		GridColor:   t.Color.Gray3,
+		VolumeColor: t.Color.Gray3,
+	}
+}
+
+// DepthChart returns a new *DepthChart with the theme colors.
+func (t *Theme) DepthChart() *DepthChart {
+	return &DepthChart{
+		t:         t,
+		Height:    values.MarginPadding300,
+		BuyColor:  t.Color.Green500,
+		SellColor: t.Color.OrangeRipple,
+		GridColor: t.Color.Gray3,
+	}
+}
+
+// LineChart returns a new *LineChart with the theme colors.
+func (t *Theme) LineChart() *LineChart {
+	return &LineChart{
+		t:         t,
+		Height:    values.MarginPadding200,
+		LineColor: t.Color.Primary,
+		GridColor: t.Color.Gray3,
+	}
+}
+
+// SetCandles replaces all the candles in the chart. Candles are sorted by
+// start time.
+func (c *CandlestickChart) SetCandles(candles []CandleData) {
+	sorted := make([]CandleData, len(candles))
+	copy(sorted, candles)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartStamp < sorted[j].StartStamp
+	})
+
+	c.mtx.Lock()
+	c.candles = sorted
+	c.mtx.Unlock()
+}
+
+// UpdateCandle replaces the candle with the same start time or appends the
+// candle if it is newer than the last candle in the chart.
+func (c *CandlestickChart) UpdateCandle(candle CandleData) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for i := len(c.candles) - 1; i >= 0; i-- {
+		if c.candles[i].StartStamp == candle.StartStamp {
+			c.candles[i] = candle
+			return
+		}
+		if c.candles[i].StartStamp < candle.StartStamp {
+			break
+		}
+	}
+
+	n := len(c.candles)
+	if n == 0 || c.candles[n-1].StartStamp < candle.StartStamp {
+		c.candles = append(c.candles, candle)
+	}
+}
+
+// SetMarkers sets the prices that should be highlighted on the chart.
+func (c *CandlestickChart) SetMarkers(markers []ChartMarker) {
+	c.mtx.Lock()
+	c.markers = markers
+	c.mtx.Unlock()
+}
+
+// HasData returns true if the chart has at least one candle to draw.
+func (c *CandlestickChart) HasData() bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return len(c.candles) > 0
+}
+
+// Layout draws the candlestick chart. The chart fills the available width.
+func (c *CandlestickChart) Layout(gtx C) D {
+	maxCandles := c.MaxCandles
+	if maxCandles <= 0 {
+		maxCandles = defaultMaxCandles
+	}
+
+	// Copy the drawn candles, UpdateCandle modifies them in place.
+	c.mtx.RLock()
+	candles := c.candles
+	if len(candles) > maxCandles {
+		candles = candles[len(candles)-maxCandles:]
+	}
+	candles = append([]CandleData(nil), candles...)
+	markers := c.markers
+	c.mtx.RUnlock()
+
+	width, height := gtx.Constraints.Max.X, gtx.Dp(c.Height)
+	size := image.Pt(width, height)
+	if len(candles) == 0 || width <= 0 {
+		return D{Size: size}
+	}
+
+	labelWidth := gtx.Dp(values.MarginPadding80)
+	plotWidth := width - labelWidth
+	volumeHeight := height / 5
+	priceHeight := height - volumeHeight - gtx.Dp(values.MarginPadding8)
+	if plotWidth <= 0 || priceHeight <= 0 {
+		return D{Size: size}
+	}
+
+	low, high := math.MaxFloat64, 0.0
+	var maxVol float64
+	for _, cdl := range candles {
+		low = math.Min(low, cdl.Low)
+		high = math.Max(high, cdl.High)
+		maxVol = math.Max(maxVol, cdl.Volume)
+	}
+	for _, m := range markers {
+		low = math.Min(low, m.Price)
+		high = math.Max(high, m.Price)
+	}
+	low, high = padRange(low, high)
+
+	priceY := func(p float64) int {
+		return int(float64(priceHeight) * (high - p) / (high - low))
+	}
+
+	c.t.drawGridLines(gtx, plotWidth, priceHeight, c.GridColor)
+
+	slot := float32(plotWidth) / float32(maxCandles)
+	bodyWidth := int(math.Max(1, float64(slot*0.7)))
+	wickWidth := int(math.Max(1, float64(gtx.Dp(values.MarginPadding1))))
+	for i, cdl := range candles {
+		col := c.UpColor
+		if cdl.Close < cdl.Open {
+			col = c.DownColor
+		}
+
+		centerX := int(slot*float32(i) + slot/2)
+		fillRect(gtx, image.Rect(centerX-wickWidth/2, priceY(cdl.High), centerX-wickWidth/2+wickWidth, priceY(cdl.Low)+1), col)
+
+		top, bottom := priceY(math.Max(cdl.Open, cdl.Close)), priceY(math.Min(cdl.Open, cdl.Close))
+		if bottom-top < 1 {
+			bottom = top + 1
+		}
+		fillRect(gtx, image.Rect(centerX-bodyWidth/2, top, centerX-bodyWidth/2+bodyWidth, bottom), col)
+
+		if maxVol > 0 {
+			volCol := c.VolumeColor
+			volCol.A = 120
+			volTop := height - int(float64(volumeHeight)*cdl.Volume/maxVol)
+			fillRect(gtx, image.Rect(centerX-bodyWidth/2, volTop, centerX-bodyWidth/2+bodyWidth, height), volCol)
+		}
+	}
+
+	for _, m := range markers {
+		col := c.UpColor
+		if m.Sell {
+			col = c.DownColor
+		}
+		c.t.drawMarkerLine(gtx, image.Pt(0, priceY(m.Price)), image.Pt(plotWidth, priceY(m.Price)), col)
+	}
+
+	last := candles[len(candles)-1]
+	c.t.drawChartLabel(gtx, image.Pt(plotWidth+gtx.Dp(values.MarginPadding4), 0), formatChartValue(high))
+	c.t.drawChartLabel(gtx, image.Pt(plotWidth+gtx.Dp(values.MarginPadding4), priceY(last.Close)), formatChartValue(last.Close))
+	c.t.drawChartLabel(gtx, image.Pt(plotWidth+gtx.Dp(values.MarginPadding4), priceHeight-gtx.Dp(values.MarginPadding14)), formatChartValue(low))
+
+	return D{Size: size}
+}
+
+// SetBook replaces the buy and sell entries used to draw the chart. The
+// entries do not need to be sorted.
+func (d *DepthChart) SetBook(buys, sells []DepthEntry) {
+	sortedBuys := make([]DepthEntry, len(buys))
+	copy(sortedBuys, buys)
+	sort.Slice(sortedBuys, func(i, j int) bool {
+		return sortedBuys[i].Rate > sortedBuys[j].Rate
+	})
+
+	sortedSells := make([]DepthEntry, len(sells))
+	copy(sortedSells, sells)
+	sort.Slice(sortedSells, func(i, j int) bool {
+		return sortedSells[i].Rate < sortedSells[j].Rate
+	})
+
+	d.mtx.Lock()
+	d.buys, d.sells = sortedBuys, sortedSells
+	d.mtx.Unlock()
+}
+
+// SetMarkers sets the prices that should be highlighted on the chart.
+func (d *DepthChart) SetMarkers(markers []ChartMarker) {
+	d.mtx.Lock()
+	d.markers = markers
+	d.mtx.Unlock()
+}
+
+// HasData returns true if the chart has at least one order to draw.
+func (d *DepthChart) HasData() bool {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return len(d.buys) > 0 || len(d.sells) > 0
+}
+
+// Layout draws the depth chart. The chart fills the available width.
+func (d *DepthChart) Layout(gtx C) D {
+	d.mtx.RLock()
+	buys, sells, markers := d.buys, d.sells, d.markers
+	d.mtx.RUnlock()
+
+	width, height := gtx.Constraints.Max.X, gtx.Dp(d.Height)
+	size := image.Pt(width, height)
+	if (len(buys) == 0 && len(sells) == 0) || width <= 0 {
+		return D{Size: size}
+	}
+
+	labelHeight := gtx.Dp(values.MarginPadding20)
+	plotHeight := height - labelHeight
+	if plotHeight <= 0 {
+		return D{Size: size}
+	}
+
+	buyDepth, sellDepth := cumulativeDepth(buys), cumulativeDepth(sells)
+
+	minRate, maxRate := math.MaxFloat64, 0.0
+	var maxDepth float64
+	for _, side := range [][]DepthEntry{buyDepth, sellDepth} {
+		for _, e := range side {
+			minRate = math.Min(minRate, e.Rate)
+			maxRate = math.Max(maxRate, e.Rate)
+		}
+		if len(side) > 0 {
+			maxDepth = math.Max(maxDepth, side[len(side)-1].Qty)
+		}
+	}
+	minRate, maxRate = padRange(minRate, maxRate)
+	if maxDepth <= 0 {
+		return D{Size: size}
+	}
+
+	x := func(rate float64) float32 {
+		return float32(float64(width) * (rate - minRate) / (maxRate - minRate))
+	}
+	y := func(depth float64) float32 {
+		return float32(float64(plotHeight) * (1 - depth/maxDepth))
+	}
+
+	d.t.drawGridLines(gtx, width, plotHeight, d.GridColor)
+	drawDepthSide(gtx, buyDepth, x, y, float32(plotHeight), d.BuyColor)
+	drawDepthSide(gtx, sellDepth, x, y, float32(plotHeight), d.SellColor)
+
+	for _, m := range markers {
+		col := d.BuyColor
+		if m.Sell {
+			col = d.SellColor
+		}
+		mx := int(x(m.Price))
+		d.t.drawMarkerLine(gtx, image.Pt(mx, 0), image.Pt(mx, plotHeight), col)
+	}
+
+	labelY := plotHeight + gtx.Dp(values.MarginPadding2)
+	d.t.drawChartLabel(gtx, image.Pt(0, labelY), formatChartValue(minRate))
+	d.t.drawChartLabel(gtx, image.Pt(width/2-gtx.Dp(values.MarginPadding30), labelY), formatChartValue((minRate+maxRate)/2))
+	d.t.drawChartLabel(gtx, image.Pt(width-gtx.Dp(values.MarginPadding80), labelY), formatChartValue(maxRate))
+	d.t.drawChartLabel(gtx, image.Pt(0, 0), formatChartValue(maxDepth))
+
+	return D{Size: size}
+}
+
+// SetPoints replaces all the points in the chart. Points are sorted by time.
+func (l *LineChart) SetPoints(points []LinePoint) {
+	sorted := make([]LinePoint, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Stamp < sorted[j].Stamp
+	})
+
+	l.mtx.Lock()
+	l.points = sorted
+	l.mtx.Unlock()
+}
+
+// HasData returns true if the chart has at least two points to draw.
+func (l *LineChart) HasData() bool {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return len(l.points) > 1
+}
+
+// Layout draws the line chart. The chart fills the available width.
+func (l *LineChart) Layout(gtx C) D {
+	l.mtx.RLock()
+	points := l.points
+	l.mtx.RUnlock()
+
+	width, height := gtx.Constraints.Max.X, gtx.Dp(l.Height)
+	size := image.Pt(width, height)
+	if len(points) < 2 || width <= 0 {
+		return D{Size: size}
+	}
+
+	labelHeight := gtx.Dp(values.MarginPadding20)
+	plotHeight := height - labelHeight
+	if plotHeight <= 0 {
+		return D{Size: size}
+	}
+
+	minValue, maxValue := math.MaxFloat64, -math.MaxFloat64
+	for _, p := range points {
+		minValue = math.Min(minValue, p.Value)
+		maxValue = math.Max(maxValue, p.Value)
+	}
+	minValue, maxValue = padRange(minValue, maxValue)
+
+	firstStamp, lastStamp := points[0].Stamp, points[len(points)-1].Stamp
+	if lastStamp <= firstStamp {
+		return D{Size: size}
+	}
+
+	x := func(stamp int64) float32 {
+		return float32(float64(width) * float64(stamp-firstStamp) / float64(lastStamp-firstStamp))
+	}
+	y := func(value float64) float32 {
+		return float32(float64(plotHeight) * (1 - (value-minValue)/(maxValue-minValue)))
+	}
+
+	l.t.drawGridLines(gtx, width, plotHeight, l.GridColor)
+
+	var area clip.Path
+	area.Begin(gtx.Ops)
+	area.MoveTo(f32.Pt(x(firstStamp), float32(plotHeight)))
+	for _, p := range points {
+		area.LineTo(f32.Pt(x(p.Stamp), y(p.Value)))
+	}
+	area.LineTo(f32.Pt(x(lastStamp), float32(plotHeight)))
+	area.Close()
+	fillCol := l.LineColor
+	fillCol.A = 40
+	paint.FillShape(gtx.Ops, fillCol, clip.Outline{Path: area.End()}.Op())
+
+	var line clip.Path
+	line.Begin(gtx.Ops)
+	line.MoveTo(f32.Pt(x(firstStamp), y(points[0].Value)))
+	for _, p := range points[1:] {
+		line.LineTo(f32.Pt(x(p.Stamp), y(p.Value)))
+	}
+	lineWidth := float32(math.Max(1, float64(gtx.Dp(values.MarginPadding2))))
+	paint.FillShape(gtx.Ops, l.LineColor, clip.Stroke{Path: line.End(), Width: lineWidth}.Op())
+
+	format := l.FormatValue
+	if format == nil {
+		format = formatChartValue
+	}
+	labelY := plotHeight + gtx.Dp(values.MarginPadding2)
+	l.t.drawChartLabel(gtx, image.Pt(0, 0), format(maxValue))
+	l.t.drawChartLabel(gtx, image.Pt(0, plotHeight-labelHeight), format(minValue))
+	l.t.drawChartLabel(gtx, image.Pt(0, labelY), time.Unix(firstStamp, 0).Format(time.DateOnly))
+	l.t.drawChartLabel(gtx, image.Pt(width-gtx.Dp(values.MarginPadding80), labelY), time.Unix(lastStamp, 0).Format(time.DateOnly))
+
+	return D{Size: size}
+}
+
+// cumulativeDepth returns entries whose Qty is the running total of the
+// provided entries' quantities. Entries must be sorted from the mid-gap
+// outward.
+func cumulativeDepth(entries []DepthEntry) []DepthEntry {
+	depth := make([]DepthEntry, 0, len(entries))
+	var total float64
+	for _, e := range entries {
+		total += e.Qty
+		depth = append(depth, DepthEntry{Rate: e.Rate, Qty: total})
+	}
+	return depth
+}
+
+// drawDepthSide fills the stepped area under one side of a depth chart.
+func drawDepthSide(gtx C, depth []DepthEntry, x func(float64) float32, y func(float64) float32, baseY float32, col color.NRGBA) {
+	if len(depth) == 0 {
+		return
+	}
+
+	var p clip.Path
+	p.Begin(gtx.Ops)
+	p.MoveTo(f32.Pt(x(depth[0].Rate), baseY))
+	prevY := baseY
+	for _, e := range depth {
+		p.LineTo(f32.Pt(x(e.Rate), prevY))
+		prevY = y(e.Qty)
+		p.LineTo(f32.Pt(x(e.Rate), prevY))
+	}
+	p.LineTo(f32.Pt(x(depth[len(depth)-1].Rate), baseY))
+	p.Close()
+
+	fillCol := col
+	fillCol.A = 90
+	paint.FillShape(gtx.Ops, fillCol, clip.Outline{Path: p.End()}.Op())
+}
+
+// drawGridLines draws four evenly spaced horizontal lines across the plot
+// area.
+func (t *Theme) drawGridLines(gtx C, width, height int, col color.NRGBA) {
+	col.A = 60
+	for i := 0; i <= 4; i++ {
+		lineY := height * i / 4
+		fillRect(gtx, image.Rect(0, lineY, width, lineY+1), col)
+	}
+}
+
+// drawMarkerLine draws a dashed line between two points. Only horizontal and
+// vertical lines are supported.
+func (t *Theme) drawMarkerLine(gtx C, from, to image.Point, col color.NRGBA) {
+	dash, gap := gtx.Dp(values.MarginPadding6), gtx.Dp(values.MarginPadding4)
+	thickness := int(math.Max(1, float64(gtx.Dp(values.MarginPadding1))))
+	if from.Y == to.Y {
+		for px := from.X; px < to.X; px += dash + gap {
+			fillRect(gtx, image.Rect(px, from.Y, min(px+dash, to.X), from.Y+thickness), col)
+		}
+		return
+	}
+	for py := from.Y; py < to.Y; py += dash + gap {
+		fillRect(gtx, image.Rect(from.X, py, from.X+thickness, min(py+dash, to.Y)), col)
+	}
+}
+
+// drawChartLabel draws a small label with its top left corner at pos.
+func (t *Theme) drawChartLabel(gtx C, pos image.Point, txt string) {
+	defer op.Offset(pos).Push(gtx.Ops).Pop()
+	gtx.Constraints.Min = image.Point{}
+	lbl := t.Label(values.TextSize12, txt)
+	lbl.Color = t.Color.GrayText2
+	lbl.Layout(gtx)
+}
+
+func fillRect(gtx C, r image.Rectangle, col color.NRGBA) {
+	if r.Empty() {
+		return
+	}
+	paint.FillShape(gtx.Ops, col, clip.Rect(r).Op())
+}
+
+// padRange widens the low-high range by 5% on each end so the extremes are
+// not drawn on the chart edges. A zero-width range is widened by 1% of its
+// value.
+func padRange(low, high float64) (float64, float64) {
+	spread := high - low
+	if spread <= 0 {
+		spread = math.Max(math.Abs(high)*0.02, 1e-8)
+	}
+	low -= spread * 0.05
+	if low < 0 {
+		low = 0
+	}
+	return low, high + spread*0.05
+}
+
+func formatChartValue(v float64) string {
+	precision := 2
+	switch {
+	case v == 0:
+	case v < 0.001:
+		precision = 8
+	case v < 1:
+		precision = 6
+	case v < 100:
+		precision = 4
+	}
+	return strconv.FormatFloat(v, 'f', precision, 64)
+}
@@ -407,6 +407,13 @@ func (sc *SegmentedControl) SelectedSegment() string {
 	return sc.segmentTitlesTranslationKey[sc.selectedIndex]
 }
 
+// Segments returns the translation keys of the segments.
+func (sc *SegmentedControl) Segments() []string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.segmentTitlesTranslationKey
+}
+
 func (sc *SegmentedControl) Changed() bool {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
@@ -15,6 +15,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/assets"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/notification"
+	"github.com/crypto-power/cryptopower/ui/values"
 )
 
 type NeedUnlockRestore func(bool)
@@ -53,6 +54,13 @@ func NewLoad(appInfo *AppInfo, window *giouiApp.Window) *Load {
 	}
 }
 
+// SetUserLanguage sets the display language and the printer used to format
+// numbers in that language.
+func (l *Load) SetUserLanguage(lang string) {
+	values.SetUserLanguage(lang)
+	l.Printer = message.NewPrinter(values.LanguageTag())
+}
+
 func (l *Load) RefreshTheme(window app.WindowNavigator) {
 	isDarkModeOn := l.AssetsManager.IsDarkModeOn()
 	l.Theme.SwitchDarkMode(isDarkModeOn, assets.DecredIcons)
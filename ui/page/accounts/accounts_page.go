@@ -242,7 +242,7 @@ func (pg *Page) accountBalanceLayout(title string, bal sharedW.AssetAmount, balA
 								return D{}
 							}
 
-							balanceUSD := fmt.Sprintf(" (%v)", utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, bal.ToCoin())))
+							balanceUSD := fmt.Sprintf(" (%v)", utils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, utils.CryptoToUSD(pg.exchangeRate, bal.ToCoin())))
 							usdAmtLabel := pg.Theme.Label(pg.ConvertTextSize(values.TextSize16), balanceUSD)
 							usdAmtLabel.Font.Weight = font.SemiBold
 							return usdAmtLabel.Layout(gtx)
@@ -0,0 +1,130 @@
+package components
+
+import (
+	"os"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// ShowAirgapTxModal shows the animated QR code of a transaction carried to or
+// from an offline wallet, with a field to save it to a file instead. next runs
+// on the positive button, if set.
+func ShowAirgapTxModal(l *load.Load, window app.WindowNavigator, title, info string, tx *sharedW.AirgapTx, next func()) {
+	encoder, err := tx.UREncoder()
+	if err != nil {
+		l.Toast.NotifyError(err.Error())
+		return
+	}
+	qr := l.Theme.AnimatedQR(encoder)
+	pathEditor := l.Theme.Editor(new(widget.Editor), values.String(values.StrFilePath))
+	pathEditor.Editor.SingleLine = true
+	saveBtn := l.Theme.OutlineButton(values.String(values.StrSaveToFile))
+
+	positive := values.String(values.StrClose)
+	if next != nil {
+		positive = values.String(values.StrNext)
+	}
+	txModal := modal.NewCustomModal(l).
+		Title(title).
+		UseCustomWidget(func(gtx C) D {
+			if saveBtn.Clicked(gtx) {
+				path := strings.TrimSpace(pathEditor.Editor.Text())
+				if path == "" {
+					pathEditor.SetError(values.String(values.StrFilePath))
+				} else if err := os.WriteFile(path, []byte(tx.UR()+"\n"), 0o600); err != nil {
+					pathEditor.SetError(err.Error())
+				} else {
+					pathEditor.SetError("")
+					l.Toast.Notify(values.StringF(values.StrSavedTo, path))
+				}
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := l.Theme.Body2(info)
+					lbl.Color = l.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Center.Layout(gtx, func(gtx C) D {
+						return qr.Layout(gtx, values.MarginPadding280)
+					})
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding12, Bottom: values.MarginPadding12}.Layout(gtx, pathEditor.Layout)
+				}),
+				layout.Rigid(saveBtn.Layout),
+			)
+		}).
+		SetPositiveButtonText(positive).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if next != nil {
+				next()
+			}
+			return true
+		})
+	if next != nil {
+		txModal.SetNegativeButtonText(values.String(values.StrCancel))
+	}
+	window.ShowModal(txModal)
+}
+
+// ShowImportAirgapTxModal reads a transaction carried from another wallet,
+// pasted as the parts of its UR or read from the file it was saved to, and
+// passes it to submit. The modal stays open on the error of submit.
+func ShowImportAirgapTxModal(l *load.Load, window app.WindowNavigator, title string, submit func(tx *sharedW.AirgapTx) error) {
+	pathEditor := l.Theme.Editor(new(widget.Editor), values.String(values.StrFilePath))
+	pathEditor.Editor.SingleLine = true
+	urEditor := l.Theme.Editor(new(widget.Editor), values.String(values.StrURParts))
+
+	importModal := modal.NewCustomModal(l).
+		Title(title).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := l.Theme.Body2(values.String(values.StrImportAirgapTxInfo))
+					lbl.Color = l.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, pathEditor.Layout)
+				}),
+				layout.Rigid(urEditor.Layout),
+			)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrImport)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			pathEditor.SetError("")
+			urEditor.SetError("")
+
+			text := urEditor.Editor.Text()
+			if path := strings.TrimSpace(pathEditor.Editor.Text()); path != "" {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					pathEditor.SetError(err.Error())
+					return false
+				}
+				text = string(content)
+			}
+			tx, err := sharedW.ParseAirgapTx(text)
+			if err != nil {
+				urEditor.SetError(err.Error())
+				return false
+			}
+			if err := submit(tx); err != nil {
+				urEditor.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+			return true
+		})
+	window.ShowModal(importModal)
+}
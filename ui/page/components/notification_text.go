@@ -0,0 +1,65 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// NotificationEventNames are the display name keys of the notification event
+// types.
+var NotificationEventNames = map[notifications.EventType]string{
+	notifications.EventTxReceived:     values.StrEventTxReceived,
+	notifications.EventTxConfirmed:    values.StrEventTxConfirmed,
+	notifications.EventTicketVoted:    values.StrEventTicketVoted,
+	notifications.EventTicketRevoked:  values.StrEventTicketRevoked,
+	notifications.EventProposalUpdate: values.StrEventProposalUpdate,
+	notifications.EventDEXMatch:       values.StrEventDEXMatch,
+	notifications.EventSwapCompleted:  values.StrEventSwapCompleted,
+}
+
+// NotificationText returns the message of a notification in the display
+// language. The wallet name is prepended if more than one wallet is open.
+func NotificationText(l *load.Load, n *notifications.Notification) string {
+	// remove trailing zeros from amount and convert to string
+	amount := strconv.FormatFloat(n.Amount, 'f', -1, 64)
+
+	var text string
+	switch n.Type {
+	case notifications.EventTxReceived:
+		text = values.StringF(values.StrTxReceivedNotif, amount, n.Asset)
+	case notifications.EventTxConfirmed:
+		text = values.StringF(values.StrTxConfirmedNotif, amount, n.Asset)
+	case notifications.EventTicketVoted:
+		text = values.StringF(values.StrTicketVoted, amount)
+	case notifications.EventTicketRevoked:
+		text = values.String(values.StrTicketRevoked)
+	case notifications.EventProposalUpdate:
+		switch n.Details {
+		case notifications.ProposalNew:
+			text = values.StringF(values.StrProposalAddedNotif, n.Subject)
+		case notifications.ProposalVoteStarted:
+			text = values.StringF(values.StrVoteStartedNotif, n.Subject)
+		case notifications.ProposalVoteFinished:
+			text = values.StringF(values.StrVoteEndedNotif, n.Subject)
+		default:
+			text = values.StringF(values.StrNewProposalUpdate, n.Subject)
+		}
+	case notifications.EventDEXMatch:
+		text = values.StringF(values.StrDEXMatchNotif, n.Subject, n.Details)
+	case notifications.EventSwapCompleted:
+		text = values.StringF(values.StrSwapCompletedNotif, n.Details, amount, n.Asset)
+	default:
+		text = n.Details
+	}
+
+	if n.WalletID != 0 && l.AssetsManager.OpenedWalletsCount() > 1 {
+		if wallet := l.AssetsManager.WalletWithID(n.WalletID); wallet != nil {
+			text = fmt.Sprintf("[%s] %s", wallet.GetWalletName(), text)
+		}
+	}
+	return text
+}
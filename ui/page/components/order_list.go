@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"image/color"
 	"time"
 
 	"gioui.org/layout"
@@ -95,6 +96,15 @@ func OrderItemWidget(gtx C, l *load.Load, orderItem *instantswap.Order) D {
 									}
 									return layout.Inset{Left: values.MarginPadding6}.Layout(gtx, statusLayout)
 								}),
+								layout.Rigid(func(gtx C) D {
+									if !orderItem.Verification.IsFlagged() {
+										return D{}
+									}
+									txt, col := PayoutStatusText(l, orderItem)
+									lbl := l.Theme.Label(textSize16, txt)
+									lbl.Color = col
+									return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+								}),
 							)
 						}),
 						layout.Flexed(1, func(gtx C) D {
@@ -138,6 +148,24 @@ func statusIcon(l *load.Load, status api.Status) func(gtx C) D {
 	return nil
 }
 
+// PayoutStatusText returns the text and color describing the on-chain
+// verification of the payout of an order.
+func PayoutStatusText(l *load.Load, order *instantswap.Order) (string, color.NRGBA) {
+	switch order.Verification.Status {
+	case instantswap.PayoutReceived:
+		return values.String(values.StrPayoutReceived), l.Theme.Color.GreenText
+	case instantswap.PayoutShort:
+		return values.String(values.StrPayoutShort), l.Theme.Color.Danger
+	case instantswap.PayoutLate:
+		return values.String(values.StrPayoutLate), l.Theme.Color.Orange
+	case instantswap.PayoutMissing:
+		return values.String(values.StrPayoutMissing), l.Theme.Color.Danger
+	case instantswap.PayoutRefunded:
+		return values.String(values.StrDepositRefunded), l.Theme.Color.Orange
+	}
+	return values.String(values.StrAwaitingPayout), l.Theme.Color.GrayText2
+}
+
 func LayoutNoOrderHistoryWithMsg(gtx C, l *load.Load, syncing bool, msg string) D {
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
 	text := l.Theme.Label(values.TextSizeTransform(l.IsMobileView(), values.TextSize16), msg)
@@ -0,0 +1,80 @@
+package components
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// ShowRPCPasswordsModal asks once for the passwords of the full nodes the
+// wallets sync with, which are not saved, and starts the sync of the wallets
+// whose password is entered. It does nothing if no password is required.
+func ShowRPCPasswordsModal(l *load.Load, window app.WindowNavigator) {
+	var wallets []sharedW.Asset
+	var editors []*cryptomaterial.Editor
+	for _, wallet := range l.AssetsManager.AllWallets() {
+		if !wallet.RPCPasswordRequired() {
+			continue
+		}
+		editor := l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrRPCPassword))
+		editor.Editor.SingleLine = true
+		wallets = append(wallets, wallet)
+		editors = append(editors, &editor)
+	}
+	if len(wallets) == 0 {
+		return
+	}
+
+	passwordsModal := modal.NewCustomModal(l).
+		Title(values.String(values.StrRPCPasswords)).
+		UseCustomWidget(func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(func(gtx C) D {
+					lbl := l.Theme.Body2(values.String(values.StrRPCPasswordsMsg))
+					lbl.Color = l.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}),
+			}
+			for i, wallet := range wallets {
+				editor := editors[i]
+				children = append(children,
+					layout.Rigid(func(gtx C) D {
+						lbl := l.Theme.Body1(wallet.GetWalletName())
+						return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, editor.Layout)
+					}),
+				)
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrSkip)).
+		SetPositiveButtonText(values.String(values.StrSync)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			// The wallets whose password is left empty do not sync.
+			for i, wallet := range wallets {
+				password := editors[i].Editor.Text()
+				if password == "" {
+					continue
+				}
+				wallet.SetRPCPassword(password)
+				if wallet.IsConnectedToNetwork() {
+					continue
+				}
+				go func(wallet sharedW.Asset) {
+					if err := wallet.SpvSync(); err != nil {
+						log.Errorf("Error syncing %s: %v", wallet.GetWalletName(), err)
+					}
+				}(wallet)
+			}
+			return true
+		})
+	window.ShowModal(passwordsModal)
+}
@@ -0,0 +1,108 @@
+package dcrdex
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+
+	"github.com/crypto-power/cryptopower/dexc"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/notification"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// bondCheckInterval is how often the bond state of every DEX account is
+// checked for tiers that are about to be lost.
+const bondCheckInterval = 30 * time.Minute
+
+// bondMonitorRunning is true while MonitorBonds is watching the dex client.
+var bondMonitorRunning atomic.Bool
+
+// MonitorBonds alerts the user when bonds posted to any DEX server expire, are
+// refunded, when the account is penalized and before the account tier falls
+// because expiring bonds will not be replaced. MonitorBonds blocks until ctx is
+// canceled or the dex client shuts down. It returns immediately if the bonds
+// are already monitored, so it is safe to call wherever the dex client may
+// have been initialized.
+func MonitorBonds(ctx context.Context, l *load.Load) {
+	dexClient := l.AssetsManager.DexClient()
+	if dexClient == nil || !bondMonitorRunning.CompareAndSwap(false, true) {
+		return
+	}
+	defer bondMonitorRunning.Store(false)
+
+	select {
+	case <-dexClient.Ready():
+	case <-ctx.Done():
+		return
+	}
+
+	sysNotifier, err := notification.NewSystemNotification()
+	if err != nil {
+		log.Errorf("Error creating system notifier: %v", err)
+	}
+
+	notify := func(msg string) {
+		l.Toast.NotifyError(msg, true)
+		if sysNotifier == nil {
+			return
+		}
+		if err := sysNotifier.Notify(msg); err != nil {
+			log.Infof("Could not send desktop notification: %v", err)
+		}
+	}
+
+	// warnedTiers tracks the tiers at risk that the user has been warned about
+	// for each host so the same warning is not repeated every check.
+	warnedTiers := make(map[string]int64)
+	checkTiers := func() {
+		for host, xc := range dexClient.Exchanges() {
+			tiersAtRisk := dexc.TiersAtRisk(&xc.Auth)
+			if tiersAtRisk == 0 {
+				delete(warnedTiers, host)
+				continue
+			}
+			if tiersAtRisk == warnedTiers[host] {
+				continue
+			}
+
+			warnedTiers[host] = tiersAtRisk
+			notify(values.StringF(values.StrTierDropWarning, tiersAtRisk, host))
+		}
+	}
+
+	noteFeed := dexClient.NotificationFeed()
+	defer noteFeed.ReturnFeed()
+
+	ticker := time.NewTicker(bondCheckInterval)
+	defer ticker.Stop()
+
+	checkTiers()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-dexClient.WaitForShutdown():
+			return
+		case <-ticker.C:
+			checkTiers()
+		case n := <-noteFeed.C:
+			if n == nil {
+				return
+			}
+
+			switch n.Topic() {
+			case core.TopicBondExpired, core.TopicBondRefunded, core.TopicPenalized:
+				notify(fmt.Sprintf("%s: %s", n.Subject(), n.Details()))
+			}
+
+			switch n.Type() {
+			case core.NoteTypeBondPost, core.NoteTypeBondRefund, core.NoteTypeReputation:
+				checkTiers()
+			}
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package dcrdex
 
 import (
+	"context"
+
 	"decred.org/dcrdex/client/core"
 	"gioui.org/layout"
 	"gioui.org/widget"
@@ -160,6 +162,9 @@ func (pg *DEXPage) HandleUserInteractions(gtx C) {
 			pg.dexIsLoading = true
 			go func() {
 				pg.AssetsManager.InitializeDEX()
+				// The monitor stops when the dex client shuts down, it
+				// doesn't depend on this page.
+				go MonitorBonds(context.Background(), pg.Load)
 				pg.prepareInitialPage()
 				pg.dexIsLoading = false
 				pg.showSplashPage = false
@@ -0,0 +1,561 @@
+package dcrdex
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"decred.org/dcrdex/client/core"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	DEXBondsPageID = "dex_bonds"
+
+	bondTimeFormat = "2006-01-02 15:04"
+)
+
+// DEXBondsPage displays the bonds posted to a DEX server, the account tier
+// they provide and the options used to automatically renew expiring bonds.
+type DEXBondsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context
+	cancelCtx context.CancelFunc
+
+	host    string
+	xc      *core.Exchange
+	bonds   []*dexc.Bond
+	dexPass []byte
+
+	scrollContainer *widget.List
+	bondsList       *layout.List
+	backButton      cryptomaterial.IconButton
+
+	bondAssets         map[libutils.AssetType]*core.BondAsset
+	walletSelector     *components.WalletDropdown
+	accountSelector    *components.AccountDropdown
+	targetTierEditor   cryptomaterial.Editor
+	maxBondedAmtEditor cryptomaterial.Editor
+	saveBtn            cryptomaterial.Button
+
+	isSaving bool
+}
+
+// NewDEXBondsPage creates a bond management page for the DEX server at host.
+func NewDEXBondsPage(l *load.Load, host string) *DEXBondsPage {
+	th := l.Theme
+	pg := &DEXBondsPage{
+		Load:               l,
+		GenericPageModal:   app.NewGenericPageModal(DEXBondsPageID),
+		host:               host,
+		scrollContainer:    &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
+		bondsList:          &layout.List{Axis: vertical},
+		backButton:         components.GetBackButton(l),
+		targetTierEditor:   newTextEditor(th, values.String(values.StrTargetTier), "0", false),
+		maxBondedAmtEditor: newTextEditor(th, values.String(values.StrMaxBondedAmount), values.String(values.StrMaxBondedAmountHint), false),
+		saveBtn:            th.Button(values.String(values.StrSave)),
+	}
+
+	pg.targetTierEditor.IsTitleLabel, pg.maxBondedAmtEditor.IsTitleLabel = false, false
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and may be
+// used to initialize page features that are only relevant when the page is
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) OnNavigatedTo() {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+
+	pg.ctx, pg.cancelCtx = context.WithCancel(context.Background())
+	pg.refreshExchange(true)
+	go pg.listenForBondNotifications()
+
+	if pg.dexPass != nil {
+		go pg.refreshBonds()
+		return
+	}
+
+	dexPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrBonds)).
+		SetDescription(values.StringF(values.StrLoginDEXToViewBonds, pg.host)).
+		PasswordHint(values.String(values.StrDexPassword)).
+		SetNegativeButtonCallback(func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		}).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			bonds, err := pg.AssetsManager.DexClient().Bonds([]byte(password), pg.host)
+			if err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pg.dexPass, pg.bonds = []byte(password), bonds
+			pg.ParentWindow().Reload()
+			return true
+		}).SetCancelable(false)
+	dexPasswordModal.SetPasswordTitleVisibility(false)
+	pg.ParentWindow().ShowModal(dexPasswordModal)
+}
+
+// refreshExchange fetches the current bond state of the DEX account. The bond
+// options form is only reset if resetForm is true.
+func (pg *DEXBondsPage) refreshExchange(resetForm bool) {
+	xc, err := pg.AssetsManager.DexClient().Exchange(pg.host)
+	if err != nil {
+		log.Errorf("Error fetching exchange %s: %v", pg.host, err)
+		return
+	}
+	pg.xc = xc
+
+	if !resetForm {
+		return
+	}
+
+	pg.bondAssets = make(map[libutils.AssetType]*core.BondAsset)
+	var supportedBondAssets []libutils.AssetType
+	for _, bondAsset := range xc.BondAssets {
+		assetType := convertAssetIDToAssetType(bondAsset.ID)
+		if assetType == assetTypeNoAsset {
+			continue
+		}
+		supportedBondAssets = append(supportedBondAssets, assetType)
+		pg.bondAssets[assetType] = bondAsset
+	}
+
+	dexClient := pg.AssetsManager.DexClient()
+	pg.walletSelector = components.NewWalletDropdown(pg.Load, supportedBondAssets...).
+		SetChangedCallback(func(asset sharedW.Asset) {
+			_ = pg.accountSelector.Setup(asset)
+		}).
+		WalletValidator(func(a sharedW.Asset) bool {
+			return !a.IsWatchingOnlyWallet() && validBondWalletOrAccount(dexClient, a.GetAssetType(), dexc.WalletIDConfigKey, fmt.Sprint(a.GetWalletID()))
+		})
+
+	// Preselect the wallet currently used for bonds.
+	var bondWallet sharedW.Asset
+	if walletID, err := dexClient.WalletIDForAsset(xc.Auth.BondAssetID); err != nil {
+		log.Errorf("dexClient.WalletIDForAsset error: %v", err)
+	} else if walletID != nil {
+		bondWallet = pg.AssetsManager.WalletWithID(*walletID)
+	}
+	if bondWallet != nil {
+		pg.walletSelector.Setup(bondWallet)
+	} else {
+		pg.walletSelector.Setup()
+	}
+
+	pg.accountSelector = components.NewAccountDropdown(pg.Load).
+		AccountValidator(func(a *sharedW.Account) bool {
+			assetType := pg.walletSelector.SelectedWallet().GetAssetType()
+			return !a.IsWatchOnly && validBondWalletOrAccount(dexClient, assetType, dexc.WalletAccountNumberConfigKey, fmt.Sprint(a.AccountNumber)) && !utils.IsImportedAccount(assetType, a)
+		}).
+		Setup(pg.walletSelector.SelectedWallet())
+
+	pg.targetTierEditor.Editor.SetText(fmt.Sprint(xc.Auth.TargetTier))
+	pg.maxBondedAmtEditor.Editor.SetText("")
+	if xc.Auth.MaxBondedAmt > 0 {
+		pg.maxBondedAmtEditor.Editor.SetText(trimmedConventionalAmtString(conventionalAmt(xc.Auth.MaxBondedAmt)))
+	}
+}
+
+// refreshBonds reloads the list of bonds. The DEX password must have been
+// provided.
+func (pg *DEXBondsPage) refreshBonds() {
+	bonds, err := pg.AssetsManager.DexClient().Bonds(pg.dexPass, pg.host)
+	if err != nil {
+		log.Errorf("Error fetching bonds for %s: %v", pg.host, err)
+		return
+	}
+	pg.bonds = bonds
+	pg.ParentWindow().Reload()
+}
+
+// listenForBondNotifications refreshes the page when the bond state of the
+// account changes. It must be called from a goroutine.
+func (pg *DEXBondsPage) listenForBondNotifications() {
+	noteFeed := pg.AssetsManager.DexClient().NotificationFeed()
+	defer noteFeed.ReturnFeed()
+
+	for {
+		select {
+		case <-pg.ctx.Done():
+			return
+		case n := <-noteFeed.C:
+			if n == nil || !pg.AssetsManager.DEXCInitialized() {
+				return
+			}
+
+			switch n.Type() {
+			case core.NoteTypeBondPost, core.NoteTypeBondRefund, core.NoteTypeReputation, core.NoteTypeUnknownBond:
+				pg.refreshExchange(false)
+				if pg.dexPass != nil {
+					pg.refreshBonds()
+				}
+				pg.ParentWindow().Reload()
+			}
+		}
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from the
+// displayed window. This method should ideally be used to disable features
+// that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) OnNavigatedFrom() {
+	if pg.cancelCtx != nil {
+		pg.cancelCtx()
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine if any
+// user interaction recently occurred on the page and may be used to update the
+// page's UI components shortly before they are displayed.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) HandleUserInteractions(gtx C) {
+	if pg.walletSelector != nil {
+		pg.walletSelector.Handle(gtx)
+	}
+
+	if pg.accountSelector != nil {
+		pg.accountSelector.Handle(gtx)
+	}
+
+	if pg.saveBtn.Clicked(gtx) && !pg.isSaving {
+		pg.updateBondOptions()
+	}
+}
+
+// updateBondOptions saves the auto-renewal settings. The selected wallet is
+// added to the dex client first if the dex client has no wallet for the bond
+// asset.
+func (pg *DEXBondsPage) updateBondOptions() {
+	pg.targetTierEditor.SetError("")
+	pg.maxBondedAmtEditor.SetError("")
+
+	targetTier, err := strconv.ParseUint(strings.TrimSpace(pg.targetTierEditor.Editor.Text()), 10, 64)
+	if err != nil {
+		pg.targetTierEditor.SetError(values.String(values.StrTargetTierErrMsg))
+		return
+	}
+
+	var maxBondedAmt uint64
+	if amtStr := strings.TrimSpace(pg.maxBondedAmtEditor.Editor.Text()); amtStr != "" {
+		amt, err := strconv.ParseFloat(amtStr, 64)
+		if err != nil || amt < 0 {
+			pg.maxBondedAmtEditor.SetError(values.String(values.StrInvalidAmount))
+			return
+		}
+		maxBondedAmt = uint64(math.Round(amt * defaultConversionFactor))
+	}
+
+	asset := pg.walletSelector.SelectedWallet()
+	account := pg.accountSelector.SelectedAccount()
+	if asset == nil || account == nil {
+		return
+	}
+
+	bondAsset := pg.bondAssets[asset.GetAssetType()]
+	form := &core.BondOptionsForm{
+		Host:         pg.host,
+		TargetTier:   &targetTier,
+		MaxBondedAmt: &maxBondedAmt,
+		BondAssetID:  &bondAsset.ID,
+	}
+
+	dexClient := pg.AssetsManager.DexClient()
+	updateFn := func() {
+		pg.isSaving = true
+		defer func() {
+			pg.isSaving = false
+			pg.ParentWindow().Reload()
+		}()
+
+		if err := dexClient.UpdateBondOptions(form); err != nil {
+			pg.notifyError(err.Error())
+			return
+		}
+
+		pg.refreshExchange(false)
+		pg.Toast.Notify(values.String(values.StrBondOptionsUpdated))
+	}
+
+	if dexClient.HasWallet(int32(bondAsset.ID)) {
+		go updateFn()
+		return
+	}
+
+	// The bond wallet must be added to the dex client before it can be used
+	// to renew bonds.
+	walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrEnterSpendingPassword)).
+		SetPositiveButtonCallback(func(_, walletPass string, pm *modal.CreatePasswordModal) bool {
+			cfg := map[string]string{
+				dexc.WalletIDConfigKey:            fmt.Sprint(asset.GetWalletID()),
+				dexc.WalletAccountNumberConfigKey: fmt.Sprint(account.AccountNumber),
+			}
+
+			if err := dexClient.AddWallet(bondAsset.ID, cfg, pg.dexPass, []byte(walletPass)); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			go updateFn()
+			return true
+		})
+	pg.ParentWindow().ShowModal(walletPasswordModal)
+}
+
+func (pg *DEXBondsPage) notifyError(errMsg string) {
+	errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// Layout draws the page UI components into the provided layout context to be
+// eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXBondsPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrBonds),
+		SubTitle:   pg.host,
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.tierSummary),
+					layout.Rigid(pg.autoRenewSection),
+					layout.Rigid(pg.bondsSection),
+				)
+			})
+		},
+	}
+
+	return layout.Inset{Right: dp10, Left: dp10}.Layout(gtx, func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	})
+}
+
+func (pg *DEXBondsPage) section(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(dp16),
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, title)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: dp16}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+func (pg *DEXBondsPage) tierSummary(gtx C) D {
+	if pg.xc == nil {
+		return D{}
+	}
+
+	auth := pg.xc.Auth
+	tierColumn := func(title string, tier int64) layout.FlexChild {
+		return layout.Flexed(0.2, func(gtx C) D {
+			return layout.Flex{Axis: vertical}.Layout(gtx,
+				layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+				layout.Rigid(pg.Theme.Label(values.TextSize16, fmt.Sprint(tier)).Layout),
+			)
+		})
+	}
+
+	return pg.section(gtx, values.String(values.StrAccountTier), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					tierColumn(values.String(values.StrCurrentTier), auth.EffectiveTier),
+					tierColumn(values.String(values.StrTargetTier), int64(auth.TargetTier)),
+					tierColumn(values.String(values.StrBondedTiers), auth.LiveStrength),
+					tierColumn(values.String(values.StrPendingTiers), auth.PendingStrength),
+					tierColumn(values.String(values.StrExpiringTiers), auth.WeakStrength),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				tiersAtRisk := dexc.TiersAtRisk(&auth)
+				if tiersAtRisk == 0 {
+					return D{}
+				}
+
+				lb := pg.Theme.Body2(values.StringF(values.StrTierDropWarning, tiersAtRisk, pg.host))
+				lb.Color = pg.Theme.Color.Danger
+				return layout.Inset{Top: dp16}.Layout(gtx, lb.Layout)
+			}),
+		)
+	})
+}
+
+func (pg *DEXBondsPage) autoRenewSection(gtx C) D {
+	if pg.walletSelector == nil || pg.accountSelector == nil {
+		return D{}
+	}
+
+	return pg.section(gtx, values.String(values.StrBondAutoRenew), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: dp16}.Layout(gtx, pg.Theme.Body2(values.String(values.StrBondAutoRenewDesc)).Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: dp16}.Layout(gtx, func(gtx C) D {
+					return pg.walletSelector.Layout(gtx, values.String(values.StrSupportedWallets))
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: dp16}.Layout(gtx, func(gtx C) D {
+					return pg.accountSelector.Layout(gtx, values.String(values.StrAccount))
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					layout.Flexed(0.5, func(gtx C) D {
+						return layout.Inset{Right: dp10}.Layout(gtx, pg.labelledEditor(values.String(values.StrTargetTier), pg.targetTierEditor.Layout))
+					}),
+					layout.Flexed(0.5, func(gtx C) D {
+						return layout.Inset{Left: dp10}.Layout(gtx, pg.labelledEditor(values.String(values.StrMaxBondedAmount), pg.maxBondedAmtEditor.Layout))
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				pg.saveBtn.SetEnabled(!pg.isSaving)
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return layout.E.Layout(gtx, pg.saveBtn.Layout)
+				})
+			}),
+		)
+	})
+}
+
+func (pg *DEXBondsPage) labelledEditor(title string, editor layout.Widget) layout.Widget {
+	return func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+			layout.Rigid(editor),
+		)
+	}
+}
+
+func (pg *DEXBondsPage) bondsSection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrBonds), func(gtx C) D {
+		if len(pg.bonds) == 0 {
+			return pg.Theme.Body2(values.String(values.StrNoBonds)).Layout(gtx)
+		}
+
+		return pg.bondsList.Layout(gtx, len(pg.bonds), func(gtx C, i int) D {
+			return layout.Flex{Axis: vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if i == 0 {
+						return D{}
+					}
+					return layout.Inset{Top: dp10, Bottom: dp10}.Layout(gtx, pg.Theme.Separator().Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return pg.bondRow(gtx, pg.bonds[i])
+				}),
+			)
+		})
+	})
+}
+
+func (pg *DEXBondsPage) bondRow(gtx C, bond *dexc.Bond) D {
+	statusText, statusColor := pg.bondStatus(bond)
+	amount := fmt.Sprintf("%s %s", trimmedConventionalAmtString(conventionalAmt(bond.Amount)), strings.ToUpper(unbip(bond.AssetID)))
+
+	greyLabel := func(txt string) cryptomaterial.Label {
+		lb := pg.Theme.Label(values.TextSize14, txt)
+		lb.Color = pg.Theme.Color.GrayText2
+		return lb
+	}
+
+	return layout.Flex{Axis: vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			status := pg.Theme.Label(values.TextSize14, statusText)
+			status.Color = statusColor
+			status.Font.Weight = font.SemiBold
+			return components.EndToEndRow(gtx, pg.Theme.Label(values.TextSize16, amount).Layout, status.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: dp5}.Layout(gtx, greyLabel(bond.CoinID).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			expires := fmt.Sprintf("%s: %s", values.String(values.StrBondExpires), bond.ExpiresAt.Format(bondTimeFormat))
+			refundable := fmt.Sprintf("%s: %s", values.String(values.StrBondRefundable), bond.LockTime.Format(bondTimeFormat))
+			return layout.Inset{Top: dp5}.Layout(gtx, func(gtx C) D {
+				return components.EndToEndRow(gtx, greyLabel(expires).Layout, greyLabel(refundable).Layout)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if bond.RefundTxID == "" {
+				return D{}
+			}
+			refundTx := fmt.Sprintf("%s: %s", values.String(values.StrRefundTx), bond.RefundTxID)
+			return layout.Inset{Top: dp5}.Layout(gtx, greyLabel(refundTx).Layout)
+		}),
+	)
+}
+
+func (pg *DEXBondsPage) bondStatus(bond *dexc.Bond) (string, color.NRGBA) {
+	switch bond.Status {
+	case dexc.BondStatusPending:
+		bondAsset := pg.bondAssets[convertAssetIDToAssetType(bond.AssetID)]
+		if bondAsset != nil {
+			return values.StringF(values.StrTxStatusPending, bond.Confs, bondAsset.Confs), pg.Theme.Color.Orange
+		}
+		return values.String(values.StrPending), pg.Theme.Color.Orange
+	case dexc.BondStatusExpired:
+		return values.String(values.StrExpired), pg.Theme.Color.Danger
+	case dexc.BondStatusRefunded:
+		return values.String(values.StrBondRefunded), pg.Theme.Color.GrayText2
+	default:
+		return values.String(values.StrBondActive), pg.Theme.Color.GreenText
+	}
+}
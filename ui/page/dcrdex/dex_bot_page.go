@@ -0,0 +1,553 @@
+package dcrdex
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/client/core"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/dexbot"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	DEXBotPageID = "dex_bot"
+
+	// maxDisplayedBotEvents is the number of most recent bot events displayed
+	// on the bot page.
+	maxDisplayedBotEvents = 100
+
+	defaultBotSpreadPercent = 1
+	defaultBotLevels        = 3
+	defaultBotLotsPerLevel  = 1
+	defaultBotRebalanceMins = 15
+)
+
+var priceSources = []string{
+	values.StrPriceOracle,
+	values.StrMidGap,
+}
+
+// DEXBotPage configures, starts and stops the market-making bot of a DEX
+// market and displays the bot's activity.
+type DEXBotPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	host string
+	mkt  *core.Market
+	cfg  *dexbot.Config
+
+	scrollContainer *widget.List
+	eventsList      *layout.List
+	backButton      cryptomaterial.IconButton
+
+	priceSourceSelector *cryptomaterial.SegmentedControl
+	spreadEditor        cryptomaterial.Editor
+	levelsEditor        cryptomaterial.Editor
+	lotsEditor          cryptomaterial.Editor
+	maxInventoryEditor  cryptomaterial.Editor
+	rebalanceEditor     cryptomaterial.Editor
+	saveBtn             cryptomaterial.Button
+	startBtn            cryptomaterial.Button
+	stopBtn             cryptomaterial.Button
+
+	status dexbot.Status
+	events []*dexbot.Event
+}
+
+// NewDEXBotPage creates a market maker page for the mkt market of the DEX
+// server at host.
+func NewDEXBotPage(l *load.Load, host string, mkt *core.Market) *DEXBotPage {
+	th := l.Theme
+	pg := &DEXBotPage{
+		Load:                l,
+		GenericPageModal:    app.NewGenericPageModal(DEXBotPageID),
+		host:                host,
+		mkt:                 mkt,
+		scrollContainer:     &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
+		eventsList:          &layout.List{Axis: vertical},
+		backButton:          components.GetBackButton(l),
+		priceSourceSelector: th.SegmentedControl(priceSources, cryptomaterial.SegmentTypeGroup),
+		spreadEditor:        newTextEditor(th, values.String(values.StrSpreadPercent), "", false),
+		levelsEditor:        newTextEditor(th, values.String(values.StrLevelsPerSide), "", false),
+		lotsEditor:          newTextEditor(th, values.String(values.StrLotsPerLevel), "", false),
+		maxInventoryEditor:  newTextEditor(th, values.StringF(values.StrMaxInventory, strings.ToUpper(mkt.BaseSymbol)), "", false),
+		rebalanceEditor:     newTextEditor(th, values.String(values.StrRebalanceMinutes), "", false),
+		saveBtn:             th.OutlineButton(values.String(values.StrSave)),
+		startBtn:            th.Button(values.String(values.StrStart)),
+		stopBtn:             th.DangerButton(values.String(values.StrStop)),
+	}
+
+	for _, editor := range pg.editors() {
+		editor.IsTitleLabel = false
+	}
+
+	return pg
+}
+
+func (pg *DEXBotPage) editors() []*cryptomaterial.Editor {
+	return []*cryptomaterial.Editor{&pg.spreadEditor, &pg.levelsEditor, &pg.lotsEditor, &pg.maxInventoryEditor, &pg.rebalanceEditor}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and may be
+// used to initialize page features that are only relevant when the page is
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXBotPage) OnNavigatedTo() {
+	cfg, err := pg.AssetsManager.DEXBots.MarketBot(pg.host, pg.mkt.BaseID, pg.mkt.QuoteID)
+	if err != nil {
+		log.Errorf("Error fetching market maker config: %v", err)
+	}
+	pg.cfg = cfg
+	pg.resetForm()
+	pg.refreshBot()
+
+	notificationListener := &dexbot.BotNotificationListener{
+		OnBotEvent: func(event *dexbot.Event) {
+			if pg.cfg != nil && event.BotID == pg.cfg.ID {
+				pg.refreshBot()
+				pg.ParentWindow().Reload()
+			}
+		},
+	}
+	if err := pg.AssetsManager.DEXBots.AddNotificationListener(notificationListener, DEXBotPageID); err != nil {
+		log.Errorf("Error adding market maker notification listener: %v", err)
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from the
+// displayed window. This method should ideally be used to disable features
+// that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXBotPage) OnNavigatedFrom() {
+	pg.AssetsManager.DEXBots.RemoveNotificationListener(DEXBotPageID)
+}
+
+// resetForm fills the settings form with the saved bot configuration or the
+// default values if no bot has been configured for the market.
+func (pg *DEXBotPage) resetForm() {
+	cfg := pg.cfg
+	if cfg == nil {
+		cfg = &dexbot.Config{
+			PriceSource:       dexbot.PriceSourceOracle,
+			SpreadPercent:     defaultBotSpreadPercent,
+			Levels:            defaultBotLevels,
+			LotsPerLevel:      defaultBotLotsPerLevel,
+			MaxInventory:      defaultBotLevels * defaultBotLotsPerLevel * pg.mkt.LotSize,
+			RebalanceInterval: defaultBotRebalanceMins * time.Minute,
+		}
+	}
+
+	if cfg.PriceSource == dexbot.PriceSourceMidGap {
+		pg.priceSourceSelector.SetSelectedSegment(values.StrMidGap)
+	} else {
+		pg.priceSourceSelector.SetSelectedSegment(values.StrPriceOracle)
+	}
+	pg.spreadEditor.Editor.SetText(strconv.FormatFloat(cfg.SpreadPercent, 'f', -1, 64))
+	pg.levelsEditor.Editor.SetText(fmt.Sprint(cfg.Levels))
+	pg.lotsEditor.Editor.SetText(fmt.Sprint(cfg.LotsPerLevel))
+	pg.maxInventoryEditor.Editor.SetText(trimmedConventionalAmtString(conventionalAmt(cfg.MaxInventory)))
+	pg.rebalanceEditor.Editor.SetText(fmt.Sprint(int(cfg.RebalanceInterval.Minutes())))
+}
+
+// refreshBot reloads the status and the most recent events of the bot.
+func (pg *DEXBotPage) refreshBot() {
+	if pg.cfg == nil {
+		return
+	}
+
+	pg.status = pg.AssetsManager.DEXBots.Status(pg.cfg.ID)
+	events, err := pg.AssetsManager.DEXBots.Events(pg.cfg.ID, maxDisplayedBotEvents)
+	if err != nil {
+		log.Errorf("Error fetching market maker events: %v", err)
+		return
+	}
+	pg.events = events
+}
+
+// HandleUserInteractions is called just before Layout() to determine if any
+// user interaction recently occurred on the page and may be used to update the
+// page's UI components shortly before they are displayed.
+// Part of the load.Page interface.
+func (pg *DEXBotPage) HandleUserInteractions(gtx C) {
+	if pg.saveBtn.Clicked(gtx) {
+		if cfg := pg.validatedConfig(); cfg != nil {
+			if err := pg.AssetsManager.DEXBots.SaveBot(cfg); err != nil {
+				pg.notifyError(err.Error())
+			} else {
+				pg.cfg = cfg
+				pg.Toast.Notify(values.String(values.StrBotSettingsSaved))
+			}
+		}
+	}
+
+	if pg.startBtn.Clicked(gtx) {
+		pg.startBot()
+	}
+
+	if pg.stopBtn.Clicked(gtx) && pg.cfg != nil {
+		botID := pg.cfg.ID
+		go func() {
+			pg.AssetsManager.DEXBots.Stop(botID)
+			pg.refreshBot()
+			pg.ParentWindow().Reload()
+		}()
+	}
+}
+
+// validatedConfig returns the bot configuration entered in the settings form.
+// A nil value is returned if any field is invalid.
+func (pg *DEXBotPage) validatedConfig() *dexbot.Config {
+	for _, editor := range pg.editors() {
+		editor.SetError("")
+	}
+
+	parseFloat := func(editor *cryptomaterial.Editor) float64 {
+		f, err := strconv.ParseFloat(strings.TrimSpace(editor.Editor.Text()), 64)
+		if err != nil || f <= 0 {
+			editor.SetError(values.String(values.StrInvalidNumber))
+			return 0
+		}
+		return f
+	}
+
+	parseUint := func(editor *cryptomaterial.Editor) uint64 {
+		n, err := strconv.ParseUint(strings.TrimSpace(editor.Editor.Text()), 10, 64)
+		if err != nil || n == 0 {
+			editor.SetError(values.String(values.StrInvalidNumber))
+			return 0
+		}
+		return n
+	}
+
+	spread := parseFloat(&pg.spreadEditor)
+	levels := parseUint(&pg.levelsEditor)
+	lots := parseUint(&pg.lotsEditor)
+	maxInventory := parseFloat(&pg.maxInventoryEditor)
+	rebalanceMins := parseUint(&pg.rebalanceEditor)
+	if spread == 0 || levels == 0 || lots == 0 || maxInventory == 0 || rebalanceMins == 0 {
+		return nil
+	}
+
+	cfg := &dexbot.Config{
+		Host:              pg.host,
+		BaseID:            pg.mkt.BaseID,
+		QuoteID:           pg.mkt.QuoteID,
+		PriceSource:       dexbot.PriceSourceOracle,
+		SpreadPercent:     spread,
+		Levels:            int(levels),
+		LotsPerLevel:      lots,
+		MaxInventory:      uint64(math.Round(maxInventory * defaultConversionFactor)),
+		RebalanceInterval: time.Duration(rebalanceMins) * time.Minute,
+	}
+	if pg.priceSourceSelector.SelectedSegment() == values.StrMidGap {
+		cfg.PriceSource = dexbot.PriceSourceMidGap
+	}
+	if pg.cfg != nil {
+		cfg.ID, cfg.CreatedAt = pg.cfg.ID, pg.cfg.CreatedAt
+	}
+	return cfg
+}
+
+func (pg *DEXBotPage) startBot() {
+	if pg.cfg == nil {
+		return
+	}
+
+	botID := pg.cfg.ID
+	dexPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrMarketMaker)).
+		SetDescription(values.String(values.StrStartBotMsg)).
+		PasswordHint(values.String(values.StrDexPassword)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := pg.AssetsManager.DexClient().Login([]byte(password)); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			if err := pg.AssetsManager.StartDEXBot(botID, []byte(password)); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pg.refreshBot()
+			return true
+		})
+	dexPasswordModal.SetPasswordTitleVisibility(false)
+	pg.ParentWindow().ShowModal(dexPasswordModal)
+}
+
+func (pg *DEXBotPage) notifyError(errMsg string) {
+	errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// Layout draws the page UI components into the provided layout context to be
+// eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXBotPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrMarketMaker),
+		SubTitle:   fmt.Sprintf("%s - %s/%s", pg.host, strings.ToUpper(pg.mkt.BaseSymbol), strings.ToUpper(pg.mkt.QuoteSymbol)),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(pg.statusSection),
+					layout.Rigid(pg.settingsSection),
+					layout.Rigid(pg.eventsSection),
+				)
+			})
+		},
+	}
+
+	return layout.Inset{Right: dp10, Left: dp10}.Layout(gtx, func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	})
+}
+
+func (pg *DEXBotPage) section(gtx C, title string, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(dp16),
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, title)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Bottom: dp16}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(body),
+	)
+}
+
+func (pg *DEXBotPage) statusSection(gtx C) D {
+	status := pg.status
+	base, quote := strings.ToUpper(pg.mkt.BaseSymbol), strings.ToUpper(pg.mkt.QuoteSymbol)
+
+	column := func(title, value string) layout.FlexChild {
+		return layout.Flexed(0.33, func(gtx C) D {
+			return layout.Flex{Axis: vertical}.Layout(gtx,
+				layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+				layout.Rigid(pg.Theme.Label(values.TextSize16, value).Layout),
+			)
+		})
+	}
+
+	return pg.section(gtx, values.String(values.StrStatus), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(pg.Theme.Body2(values.String(values.StrMarketMakerDesc)).Layout),
+			layout.Rigid(func(gtx C) D {
+				statusText := values.String(values.StrBotStopped)
+				statusColor := pg.Theme.Color.GrayText2
+				btn := &pg.startBtn
+				if status.Running {
+					statusText = values.StringF(values.StrBotRunning, status.StartedAt.Format(bondTimeFormat))
+					statusColor = pg.Theme.Color.GreenText
+					btn = &pg.stopBtn
+				}
+				if status.Paused {
+					statusText = values.String(values.StrBotPaused)
+					statusColor = pg.Theme.Color.Orange
+				}
+				btn.SetEnabled(pg.cfg != nil)
+
+				lb := pg.Theme.Label(values.TextSize16, statusText)
+				lb.Color = statusColor
+				lb.Font.Weight = font.SemiBold
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return components.EndToEndRow(gtx, lb.Layout, btn.Layout)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !status.Running {
+					return D{}
+				}
+
+				netInventory := fmt.Sprintf("%s %s", trimmedConventionalAmtString(float64(status.NetInventory)/defaultConversionFactor), base)
+				return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: horizontal}.Layout(gtx,
+						column(values.String(values.StrReferencePrice), fmt.Sprintf("%s %s", trimmedConventionalAmtString(status.RefPrice), quote)),
+						column(values.String(values.StrNetInventory), netInventory),
+						column(values.String(values.StrOpenOrders), fmt.Sprintf("%d / %d", status.BuyOrders, status.SellOrders)),
+					)
+				})
+			}),
+		)
+	})
+}
+
+func (pg *DEXBotPage) settingsSection(gtx C) D {
+	running := pg.status.Running
+	pg.saveBtn.SetEnabled(!running)
+
+	field := func(title string, editor *cryptomaterial.Editor) layout.FlexChild {
+		return layout.Flexed(0.5, func(gtx C) D {
+			return layout.Inset{Right: dp10, Bottom: dp16}.Layout(gtx, func(gtx C) D {
+				if running {
+					gtx = gtx.Disabled()
+				}
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+					layout.Rigid(editor.Layout),
+				)
+			})
+		})
+	}
+
+	return pg.section(gtx, values.String(values.StrSettings), func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: dp16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: vertical}.Layout(gtx,
+						layout.Rigid(semiBoldLabelGrey3(pg.Theme, values.String(values.StrPriceSource)).Layout),
+						layout.Rigid(func(gtx C) D {
+							if running {
+								gtx = gtx.Disabled()
+							}
+							return layout.Inset{Top: dp5}.Layout(gtx, pg.priceSourceSelector.GroupTileLayout)
+						}),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					field(values.String(values.StrSpreadPercent), &pg.spreadEditor),
+					field(values.String(values.StrLevelsPerSide), &pg.levelsEditor),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					field(values.String(values.StrLotsPerLevel), &pg.lotsEditor),
+					field(values.StringF(values.StrMaxInventory, strings.ToUpper(pg.mkt.BaseSymbol)), &pg.maxInventoryEditor),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					field(values.String(values.StrRebalanceMinutes), &pg.rebalanceEditor),
+					layout.Flexed(0.5, func(gtx C) D {
+						return layout.E.Layout(gtx, pg.saveBtn.Layout)
+					}),
+				)
+			}),
+		)
+	})
+}
+
+func (pg *DEXBotPage) eventsSection(gtx C) D {
+	return pg.section(gtx, values.String(values.StrActivity), func(gtx C) D {
+		if len(pg.events) == 0 {
+			return pg.Theme.Body2(values.String(values.StrNoBotEvents)).Layout(gtx)
+		}
+
+		return pg.eventsList.Layout(gtx, len(pg.events), func(gtx C, i int) D {
+			return layout.Flex{Axis: vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if i == 0 {
+						return D{}
+					}
+					return layout.Inset{Top: dp8, Bottom: dp8}.Layout(gtx, pg.Theme.Separator().Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return pg.eventRow(gtx, pg.events[i])
+				}),
+			)
+		})
+	})
+}
+
+func (pg *DEXBotPage) eventRow(gtx C, event *dexbot.Event) D {
+	title, titleColor := pg.eventTitle(event.Type)
+
+	var details string
+	if event.OrderID != "" {
+		side := values.String(values.StrBuy)
+		if event.Sell {
+			side = values.String(values.StrSell)
+		}
+		details = fmt.Sprintf("%s %s %s @ %s %s", side, trimmedConventionalAmtString(event.Qty), strings.ToUpper(pg.mkt.BaseSymbol),
+			trimmedConventionalAmtString(event.Rate), strings.ToUpper(pg.mkt.QuoteSymbol))
+	}
+	if event.Message != "" {
+		details = strings.TrimSpace(fmt.Sprintf("%s %s", details, event.Message))
+	}
+
+	greyLabel := func(txt string) cryptomaterial.Label {
+		lb := pg.Theme.Label(values.TextSize14, txt)
+		lb.Color = pg.Theme.Color.GrayText2
+		return lb
+	}
+
+	return layout.Flex{Axis: vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, title)
+			lb.Color = titleColor
+			lb.Font.Weight = font.SemiBold
+			return components.EndToEndRow(gtx, lb.Layout, greyLabel(time.Unix(event.Stamp, 0).Format(bondTimeFormat)).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if details == "" {
+				return D{}
+			}
+			return layout.Inset{Top: dp2}.Layout(gtx, greyLabel(details).Layout)
+		}),
+	)
+}
+
+func (pg *DEXBotPage) eventTitle(eventType dexbot.EventType) (string, color.NRGBA) {
+	switch eventType {
+	case dexbot.EventStarted:
+		return values.String(values.StrBotEventStarted), pg.Theme.Color.GreenText
+	case dexbot.EventStopped:
+		return values.String(values.StrBotEventStopped), pg.Theme.Color.Orange
+	case dexbot.EventOrderPlaced:
+		return values.String(values.StrBotEventOrderPlaced), pg.Theme.Color.Text
+	case dexbot.EventOrderCanceled:
+		return values.String(values.StrBotEventOrderCanceled), pg.Theme.Color.GrayText2
+	case dexbot.EventOrderFilled:
+		return values.String(values.StrBotEventOrderFilled), pg.Theme.Color.Primary
+	case dexbot.EventOrderDone:
+		return values.String(values.StrBotEventOrderDone), pg.Theme.Color.Text
+	case dexbot.EventPaused:
+		return values.String(values.StrBotEventPaused), pg.Theme.Color.Orange
+	case dexbot.EventResumed:
+		return values.String(values.StrBotEventResumed), pg.Theme.Color.GreenText
+	default:
+		return values.String(values.StrBotEventError), pg.Theme.Color.Danger
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/appos"
 	"github.com/crypto-power/cryptopower/dexc"
+	"github.com/crypto-power/cryptopower/libwallet"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
@@ -1236,7 +1237,8 @@ func (pg *DEXOnboarding) postBond() {
 
 	if pg.existingDEXServer != "" {
 		// These fields(MaintainTier and MaxBondedAmt) can only be set for the
-		// first time. TODO: Use UpdateBondOptions when its design is ready.
+		// first time. They are updated from the bonds page afterwards. See
+		// DEXBondsPage.
 		postBond.MaintainTier = nil
 	}
 
@@ -1317,9 +1319,15 @@ func (pg *DEXOnboarding) postBond() {
 // posting. If user has previously added a wallet/account to the dex client,
 // only that wallet/account should be available when re-posting bonds.
 func (pg *DEXOnboarding) validateBondWalletOrAccount(assetType libutils.AssetType, configKey, walletIDOrAccountNumber string) bool {
-	dexc := pg.AssetsManager.DexClient()
-	if assetID, ok := bip(assetType.ToStringLower()); ok && dexc.HasWallet(int32(assetID)) {
-		if walletSettings, err := dexc.WalletSettings(assetID); err != nil {
+	return validBondWalletOrAccount(pg.AssetsManager.DexClient(), assetType, configKey, walletIDOrAccountNumber)
+}
+
+// validBondWalletOrAccount is true if the wallet or account identified by
+// walletIDOrAccountNumber may be used for bonds. Only the wallet/account
+// already added to the dex client for assetType is valid.
+func validBondWalletOrAccount(dexClient libwallet.DEXClient, assetType libutils.AssetType, configKey, walletIDOrAccountNumber string) bool {
+	if assetID, ok := bip(assetType.ToStringLower()); ok && dexClient.HasWallet(int32(assetID)) {
+		if walletSettings, err := dexClient.WalletSettings(assetID); err != nil {
 			log.Errorf("dexc.WalletSettings error: %v", err)
 		} else {
 			// If wallet has been added to dexc already, only that wallet
@@ -0,0 +1,594 @@
+package dcrdex
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"decred.org/dcrdex/dex/order"
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/dexc"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	DEXTradeHistoryPageID = "dex_trade_history"
+
+	tradeDateFormat = "2006-01-02"
+)
+
+// tradeStatuses maps the status filter options to the order statuses they
+// select.
+var tradeStatuses = map[string][]order.OrderStatus{
+	values.StrExecuted: {order.OrderStatusExecuted},
+	values.StrCanceled: {order.OrderStatusCanceled},
+	values.StrRevoked:  {order.OrderStatusRevoked},
+	values.StrBooked:   {order.OrderStatusBooked, order.OrderStatusEpoch},
+}
+
+// DEXTradeHistoryPage lists the user's DEX trades with their realized prices,
+// fees and fiat profit or loss, and exports them.
+type DEXTradeHistoryPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	tradesList      *layout.List
+	backButton      cryptomaterial.IconButton
+
+	serverSelector *cryptomaterial.DropDown
+	marketSelector *cryptomaterial.DropDown
+	statusSelector *cryptomaterial.DropDown
+	fromEditor     cryptomaterial.Editor
+	toEditor       cryptomaterial.Editor
+
+	// orderID limits the history to a single order. It is set when the page
+	// is opened from the details of a swap or redeem transaction.
+	orderID        string
+	clearOrderBtn  *cryptomaterial.Clickable
+	exportCSVBtn   cryptomaterial.Button
+	exportJSONBtn  cryptomaterial.Button
+	trades         []*dexc.Trade
+	fiatRates      map[uint32]float64
+	summary        *dexc.TradeSummary
+	loadingHistory bool
+}
+
+// NewDEXTradeHistoryPage creates a trade history page. If host or orderID are
+// not empty, the history is filtered by them when the page is displayed.
+func NewDEXTradeHistoryPage(l *load.Load, host, orderID string) *DEXTradeHistoryPage {
+	th := l.Theme
+	pg := &DEXTradeHistoryPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DEXTradeHistoryPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
+		tradesList:       &layout.List{Axis: vertical},
+		backButton:       components.GetBackButton(l),
+		fromEditor:       newTextEditor(th, values.String(values.StrFrom), values.String(values.StrDateHint), false),
+		toEditor:         newTextEditor(th, values.String(values.StrTo), values.String(values.StrDateHint), false),
+		orderID:          orderID,
+		clearOrderBtn:    th.NewClickable(false),
+		exportCSVBtn:     th.OutlineButton(values.String(values.StrExportCSV)),
+		exportJSONBtn:    th.OutlineButton(values.String(values.StrExportJSON)),
+	}
+
+	pg.fromEditor.IsTitleLabel, pg.toEditor.IsTitleLabel = false, false
+
+	servers := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAll)}}
+	markets := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAll)}}
+	seenMarkets := make(map[string]bool)
+	if l.AssetsManager.DEXCInitialized() {
+		for _, xc := range l.AssetsManager.DexClient().Exchanges() {
+			servers = append(servers, cryptomaterial.DropDownItem{Text: xc.Host})
+			for _, m := range xc.Markets {
+				if seenMarkets[m.Name] {
+					continue
+				}
+				seenMarkets[m.Name] = true
+				markets = append(markets, cryptomaterial.DropDownItem{Text: m.Name})
+			}
+		}
+	}
+
+	statuses := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAll)}}
+	for _, status := range []string{values.StrExecuted, values.StrCanceled, values.StrRevoked, values.StrBooked} {
+		statuses = append(statuses, cryptomaterial.DropDownItem{Text: values.String(status)})
+	}
+
+	var selectedServer *cryptomaterial.DropDownItem
+	if host != "" {
+		selectedServer = &cryptomaterial.DropDownItem{Text: host}
+	}
+	pg.serverSelector = th.NewCommonDropDown(servers, selectedServer, values.MarginPadding180, values.DEXServerDropdownGroup, false)
+	pg.marketSelector = th.NewCommonDropDown(markets, nil, values.MarginPadding150, values.DEXCurrencyPairGroup, false)
+	pg.statusSelector = th.NewCommonDropDown(statuses, nil, values.MarginPadding150, values.OrderStatusDropdownGroup, false)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and may be
+// used to initialize page features that are only relevant when the page is
+// displayed.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) OnNavigatedTo() {
+	go pg.refreshTrades()
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from the
+// displayed window. This method should ideally be used to disable features
+// that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) OnNavigatedFrom() {}
+
+// tradeFilter returns the filter selected by the user. ok is false if a date
+// is invalid.
+func (pg *DEXTradeHistoryPage) tradeFilter() (filter *dexc.TradeFilter, ok bool) {
+	filter = &dexc.TradeFilter{OrderID: pg.orderID}
+	if pg.serverSelector.SelectedIndex() > 0 {
+		filter.Host = pg.serverSelector.Selected()
+	}
+	if pg.marketSelector.SelectedIndex() > 0 {
+		filter.Market = pg.marketSelector.Selected()
+	}
+	if pg.statusSelector.SelectedIndex() > 0 {
+		for status, orderStatuses := range tradeStatuses {
+			if values.String(status) == pg.statusSelector.Selected() {
+				filter.Statuses = orderStatuses
+			}
+		}
+	}
+
+	ok = true
+	parseDate := func(editor *cryptomaterial.Editor) time.Time {
+		editor.SetError("")
+		dateStr := strings.TrimSpace(editor.Editor.Text())
+		if dateStr == "" {
+			return time.Time{}
+		}
+
+		date, err := time.ParseInLocation(tradeDateFormat, dateStr, time.Local)
+		if err != nil {
+			editor.SetError(values.String(values.StrInvalidDate))
+			ok = false
+		}
+		return date
+	}
+
+	filter.From = parseDate(&pg.fromEditor)
+	// The end date is inclusive.
+	if to := parseDate(&pg.toEditor); !to.IsZero() {
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	return filter, ok
+}
+
+// refreshTrades reloads the trades that match the selected filters. It must be
+// called from a goroutine.
+func (pg *DEXTradeHistoryPage) refreshTrades() {
+	if !pg.AssetsManager.DEXCInitialized() {
+		return
+	}
+
+	filter, ok := pg.tradeFilter()
+	if !ok {
+		return
+	}
+
+	pg.loadingHistory = true
+	defer func() {
+		pg.loadingHistory = false
+		pg.ParentWindow().Reload()
+	}()
+
+	trades, err := pg.AssetsManager.DexClient().TradeHistory(filter)
+	if err != nil {
+		log.Errorf("Error fetching trade history: %v", err)
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	fiatRates := pg.fiatRatesForTrades(trades)
+	pg.trades, pg.fiatRates = trades, fiatRates
+	pg.summary = dexc.SummarizeTrades(trades, fiatRates)
+}
+
+// fiatRatesForTrades returns the USD rates of the assets traded, keyed by asset
+// ID. Only cached rates are used to avoid blocking the page on network
+// requests.
+func (pg *DEXTradeHistoryPage) fiatRatesForTrades(trades []*dexc.Trade) map[uint32]float64 {
+	rates := make(map[uint32]float64)
+	if !pg.AssetsManager.ExchangeRateFetchingEnabled() {
+		return rates
+	}
+
+	for _, t := range trades {
+		for _, assetID := range []uint32{t.BaseID, t.QuoteID} {
+			if _, ok := rates[assetID]; ok {
+				continue
+			}
+
+			market, err := pageutils.USDMarketFromAsset(convertAssetIDToAssetType(assetID))
+			if err != nil {
+				continue
+			}
+
+			ticker := pg.AssetsManager.RateSource.GetTicker(market, true)
+			if ticker != nil && ticker.LastTradePrice > 0 {
+				rates[assetID] = ticker.LastTradePrice
+			}
+		}
+	}
+	return rates
+}
+
+// HandleUserInteractions is called just before Layout() to determine if any
+// user interaction recently occurred on the page and may be used to update the
+// page's UI components shortly before they are displayed.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) HandleUserInteractions(gtx C) {
+	cryptomaterial.DisplayOneDropdown(gtx, pg.serverSelector, pg.marketSelector, pg.statusSelector)
+
+	var refresh bool
+	for _, dropdown := range []*cryptomaterial.DropDown{pg.serverSelector, pg.marketSelector, pg.statusSelector} {
+		if dropdown.Changed(gtx) {
+			refresh = true
+		}
+	}
+
+	if pg.fromEditor.Changed() || pg.toEditor.Changed() {
+		refresh = true
+	}
+
+	if pg.clearOrderBtn.Clicked(gtx) {
+		pg.orderID = ""
+		refresh = true
+	}
+
+	if refresh {
+		go pg.refreshTrades()
+	}
+
+	if pg.exportCSVBtn.Clicked(gtx) {
+		pg.showExportModal("csv", func(w io.Writer, trades []*dexc.Trade) error {
+			return dexc.WriteTradesCSV(w, trades, runtime.GOOS == "windows")
+		})
+	}
+
+	if pg.exportJSONBtn.Clicked(gtx) {
+		pg.showExportModal("json", dexc.WriteTradesJSON)
+	}
+}
+
+func (pg *DEXTradeHistoryPage) showExportModal(ext string, write func(io.Writer, []*dexc.Trade) error) {
+	trades := pg.trades
+	exportModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrExportTrades)).
+		Body(values.String(values.StrExportTradesMsg)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrExport)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			go func() {
+				fileName := filepath.Join(pg.AssetsManager.RootDir(), "exports", fmt.Sprintf("dex_trades_export_%d.%s", time.Now().Unix(), ext))
+				if err := exportTrades(trades, fileName, write); err != nil {
+					errModal := modal.NewErrorModal(pg.Load, fmt.Errorf("error exporting your DEX trades: %v", err).Error(), modal.DefaultClickFunc())
+					pg.ParentWindow().ShowModal(errModal)
+					return
+				}
+
+				infoModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrExportTradesSuccessMsg, fileName), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(infoModal)
+			}()
+			return true
+		})
+	pg.ParentWindow().ShowModal(exportModal)
+}
+
+func exportTrades(trades []*dexc.Trade, fileName string, write func(io.Writer, []*dexc.Trade) error) error {
+	if err := os.MkdirAll(filepath.Dir(fileName), libutils.UserFilePerm); err != nil {
+		return fmt.Errorf("os.MkdirAll error: %w", err)
+	}
+
+	var success bool
+	defer func() {
+		if !success {
+			os.Remove(fileName)
+		}
+	}()
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("os.Create error: %w", err)
+	}
+	defer f.Close()
+
+	if err := write(f, trades); err != nil {
+		return err
+	}
+
+	success = true
+	return nil
+}
+
+// Layout draws the page UI components into the provided layout context to be
+// eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DEXTradeHistoryPage) Layout(gtx C) D {
+	if !pg.AssetsManager.DEXCInitialized() {
+		pg.ParentNavigator().CloseCurrentPage()
+		return D{}
+	}
+
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrTradeHistory),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			return layout.Stack{}.Layout(gtx,
+				layout.Expanded(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding70}.Layout(gtx, func(gtx C) D {
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, 1, func(gtx C, _ int) D {
+							return layout.Flex{Axis: vertical}.Layout(gtx,
+								layout.Rigid(pg.dateFilters),
+								layout.Rigid(pg.summarySection),
+								layout.Rigid(pg.tradesSection),
+							)
+						})
+					})
+				}),
+				layout.Stacked(pg.dropdownFilters),
+			)
+		},
+	}
+
+	return layout.Inset{Right: dp10, Left: dp10}.Layout(gtx, func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	})
+}
+
+// dropdownFilters is stacked above the rest of the page so the expanded
+// dropdowns are drawn over it.
+func (pg *DEXTradeHistoryPage) dropdownFilters(gtx C) D {
+	filter := func(title string, dropdown *cryptomaterial.DropDown) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			return layout.Inset{Right: dp16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+					layout.Rigid(func(gtx C) D {
+						dropdown.Background = &pg.Theme.Color.Surface
+						dropdown.BorderColor = &pg.Theme.Color.Gray5
+						return layout.Inset{Top: dp2}.Layout(gtx, dropdown.Layout)
+					}),
+				)
+			})
+		})
+	}
+
+	return layout.Flex{Axis: horizontal}.Layout(gtx,
+		filter(values.String(values.StrServer), pg.serverSelector),
+		filter(values.String(values.StrMarket), pg.marketSelector),
+		filter(values.String(values.StrStatus), pg.statusSelector),
+	)
+}
+
+func (pg *DEXTradeHistoryPage) dateFilters(gtx C) D {
+	dateEditor := func(title string, editor *cryptomaterial.Editor) layout.FlexChild {
+		return layout.Flexed(0.3, func(gtx C) D {
+			return layout.Inset{Right: dp16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: vertical}.Layout(gtx,
+					layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+					layout.Rigid(editor.Layout),
+				)
+			})
+		})
+	}
+
+	return layout.Flex{Axis: horizontal, Alignment: layout.End}.Layout(gtx,
+		dateEditor(values.String(values.StrFrom), &pg.fromEditor),
+		dateEditor(values.String(values.StrTo), &pg.toEditor),
+		layout.Flexed(0.4, func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						pg.exportCSVBtn.SetEnabled(len(pg.trades) > 0)
+						return layout.Inset{Right: dp10}.Layout(gtx, pg.exportCSVBtn.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						pg.exportJSONBtn.SetEnabled(len(pg.trades) > 0)
+						return pg.exportJSONBtn.Layout(gtx)
+					}),
+				)
+			})
+		}),
+	)
+}
+
+func (pg *DEXTradeHistoryPage) section(gtx C, body layout.Widget) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(dp16),
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: vertical,
+	}.Layout2(gtx, body)
+}
+
+func (pg *DEXTradeHistoryPage) summarySection(gtx C) D {
+	summary := pg.summary
+	if summary == nil {
+		return D{}
+	}
+
+	column := func(title string, value string, valueColor color.NRGBA) layout.FlexChild {
+		return layout.Flexed(0.25, func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, value)
+			lb.Color = valueColor
+			return layout.Flex{Axis: vertical}.Layout(gtx,
+				layout.Rigid(semiBoldLabelGrey3(pg.Theme, title).Layout),
+				layout.Rigid(lb.Layout),
+			)
+		})
+	}
+
+	return pg.section(gtx, func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: horizontal}.Layout(gtx,
+					column(values.String(values.StrTrades), fmt.Sprint(summary.Trades), pg.Theme.Color.Text),
+					column(values.String(values.StrVolume), pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, summary.Volume), pg.Theme.Color.Text),
+					column(values.String(values.StrTotalFees), pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, summary.Fees), pg.Theme.Color.Text),
+					column(values.String(values.StrMarkToMarketPL), pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, summary.MarkToMarketPL), pg.profitLossColor(summary.MarkToMarketPL)),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				note := values.String(values.StrFiatValuesAtCurrentRates)
+				if unpriced := summary.Trades - summary.Priced; unpriced > 0 {
+					note = fmt.Sprintf("%s %s", note, values.StringF(values.StrUnpricedTrades, unpriced))
+				}
+				lb := pg.Theme.Label(values.TextSize12, note)
+				lb.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Top: dp10}.Layout(gtx, lb.Layout)
+			}),
+		)
+	})
+}
+
+func (pg *DEXTradeHistoryPage) profitLossColor(profitLoss float64) color.NRGBA {
+	switch {
+	case profitLoss > 0:
+		return pg.Theme.Color.GreenText
+	case profitLoss < 0:
+		return pg.Theme.Color.Danger
+	default:
+		return pg.Theme.Color.Text
+	}
+}
+
+func (pg *DEXTradeHistoryPage) tradesSection(gtx C) D {
+	return pg.section(gtx, func(gtx C) D {
+		return layout.Flex{Axis: vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				if pg.orderID == "" {
+					return D{}
+				}
+
+				lb := pg.Theme.Label(values.TextSize14, values.StringF(values.StrOrderFilter, pg.orderID))
+				clearLabel := pg.Theme.Label(values.TextSize14, values.String(values.StrClear))
+				clearLabel.Color = pg.Theme.Color.Primary
+				return layout.Inset{Bottom: dp16}.Layout(gtx, func(gtx C) D {
+					return components.EndToEndRow(gtx, lb.Layout, func(gtx C) D {
+						return pg.clearOrderBtn.Layout(gtx, clearLabel.Layout)
+					})
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				if len(pg.trades) == 0 {
+					msg := values.String(values.StrNoTradesFound)
+					return components.LayoutNoOrderHistoryWithMsg(gtx, pg.Load, pg.loadingHistory, msg)
+				}
+
+				return pg.tradesList.Layout(gtx, len(pg.trades), func(gtx C, i int) D {
+					return layout.Flex{Axis: vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							if i == 0 {
+								return D{}
+							}
+							return layout.Inset{Top: dp10, Bottom: dp10}.Layout(gtx, pg.Theme.Separator().Layout)
+						}),
+						layout.Rigid(func(gtx C) D {
+							return pg.tradeRow(gtx, pg.trades[i])
+						}),
+					)
+				})
+			}),
+		)
+	})
+}
+
+func (pg *DEXTradeHistoryPage) tradeRow(gtx C, t *dexc.Trade) D {
+	base, quote := strings.ToUpper(t.BaseSymbol), strings.ToUpper(t.QuoteSymbol)
+	side := values.String(values.StrBuy)
+	sideColor := pg.Theme.Color.GreenText
+	if t.Sell {
+		side = values.String(values.StrSell)
+		sideColor = pg.Theme.Color.Danger
+	}
+
+	greyLabel := func(txt string) cryptomaterial.Label {
+		lb := pg.Theme.Label(values.TextSize14, txt)
+		lb.Color = pg.Theme.Color.GrayText2
+		return lb
+	}
+
+	fromSymbol, toSymbol := quote, base
+	if t.Sell {
+		fromSymbol, toSymbol = base, quote
+	}
+	fees := fmt.Sprintf("%s %s", trimmedConventionalAmtString(t.SwapFees+t.RefundFees+t.FundingFees), fromSymbol)
+	if t.RedeemFees > 0 {
+		fees = fmt.Sprintf("%s + %s %s", fees, trimmedConventionalAmtString(t.RedeemFees), toSymbol)
+	}
+
+	return layout.Flex{Axis: vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			title := pg.Theme.Label(values.TextSize16, fmt.Sprintf("%s %s/%s", side, base, quote))
+			title.Color = sideColor
+			title.Font.Weight = font.SemiBold
+			status := greyLabel(t.Status)
+			return components.EndToEndRow(gtx, title.Layout, status.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			filled := fmt.Sprintf("%s: %s %s", values.String(values.StrFilled), trimmedConventionalAmtString(t.BaseFilled), base)
+			avgPrice := fmt.Sprintf("%s: %s %s", values.String(values.StrAvgPrice), trimmedConventionalAmtString(t.AvgRate), quote)
+			return layout.Inset{Top: dp5}.Layout(gtx, func(gtx C) D {
+				return components.EndToEndRow(gtx, greyLabel(filled).Layout, greyLabel(avgPrice).Layout)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			feesLabel := greyLabel(fmt.Sprintf("%s: %s", values.String(values.StrTotalFees), fees))
+			return layout.Inset{Top: dp5}.Layout(gtx, func(gtx C) D {
+				return components.EndToEndRow(gtx, feesLabel.Layout, func(gtx C) D {
+					_, _, profitLoss, ok := t.FiatValues(pg.fiatRates)
+					if !ok || t.BaseFilled == 0 {
+						return D{}
+					}
+					lb := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s: %s", values.String(values.StrMarkToMarketPL), pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, profitLoss)))
+					lb.Color = pg.profitLossColor(profitLoss)
+					return lb.Layout(gtx)
+				})
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: dp5}.Layout(gtx, func(gtx C) D {
+				return components.EndToEndRow(gtx, greyLabel(t.OrderID).Layout, greyLabel(t.SubmitTime.Format(bondTimeFormat)).Layout)
+			})
+		}),
+	)
+}
@@ -8,12 +8,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"decred.org/dcrdex/client/comms"
 	"decred.org/dcrdex/client/core"
 	"decred.org/dcrdex/client/orderbook"
 	"decred.org/dcrdex/dex"
+	"decred.org/dcrdex/dex/candles"
 	"decred.org/dcrdex/dex/order"
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -83,6 +85,8 @@ type DEXMarketPage struct {
 	serverSelector        *cryptomaterial.DropDown
 	lastSelectedDEXServer string
 	addServerBtn          *cryptomaterial.Clickable
+	manageBondsBtn        *cryptomaterial.Clickable
+	marketMakerBtn        *cryptomaterial.Clickable
 	xc                    *core.Exchange
 
 	marketSelector               *cryptomaterial.DropDown
@@ -112,11 +116,23 @@ type DEXMarketPage struct {
 
 	seeFullOrderBookBtn     cryptomaterial.Button
 	selectedMarketOrderBook orderbookInfo
-	closeOrderBookListener  func()
+
+	// chartMtx protects the order book feed and the candle duration the
+	// charts are subscribed to, both are used by the order book listener.
+	chartMtx               sync.Mutex
+	closeOrderBookListener func()
+	bookFeed               core.BookFeed
+	candleDur              string
+
+	chartTypeSelector *cryptomaterial.SegmentedControl
+	candleDurSelector *cryptomaterial.SegmentedControl
+	candleChart       *cryptomaterial.CandlestickChart
+	depthChart        *cryptomaterial.DepthChart
 
 	orders                      []*clickableOrder
 	openOrdersBtn               cryptomaterial.Button
 	orderHistoryBtn             cryptomaterial.Button
+	tradeAnalyticsBtn           *cryptomaterial.Clickable
 	ordersTableHorizontalScroll *widget.List
 
 	openOrdersDisplayed bool
@@ -161,6 +177,8 @@ func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
 		scrollContainer:                    &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
 		openOrdersAndOrderHistoryContainer: &widget.List{List: layout.List{Axis: vertical, Alignment: layout.Middle}},
 		addServerBtn:                       th.NewClickable(false),
+		manageBondsBtn:                     th.NewClickable(false),
+		marketMakerBtn:                     th.NewClickable(false),
 		toggleBuyAndSellBtn:                th.SegmentedControl(buyAndSellBtnStrings, cryptomaterial.SegmentTypeGroup),
 		orderTypesDropdown:                 th.NewCommonDropDown(orderTypes, nil, values.MarginPadding100, values.DEXOrderTypes, false),
 		priceEditor:                        newTextEditor(l.Theme, values.String(values.StrPrice), "", false),
@@ -179,13 +197,20 @@ func NewDEXMarketPage(l *load.Load, selectServer string) *DEXMarketPage {
 		seeFullOrderBookBtn:                th.Button(values.String(values.StrSeeMore)),
 		openOrdersBtn:                      th.Button(values.String(values.StrOpenOrders)),
 		orderHistoryBtn:                    th.Button(values.String(values.StrTradeHistory)),
+		tradeAnalyticsBtn:                  th.NewClickable(false),
 		ordersTableHorizontalScroll:        &widget.List{List: layout.List{Axis: horizontal, Alignment: layout.Middle}},
 		openOrdersDisplayed:                true,
 		lastSelectedDEXServer:              selectServer,
+		chartTypeSelector:                  th.SegmentedControl(chartTypes, cryptomaterial.SegmentTypeGroup),
+		candleDurSelector:                  th.SegmentedControl(candles.BinSizes, cryptomaterial.SegmentTypeGroup),
+		candleChart:                        th.CandlestickChart(),
+		depthChart:                         th.DepthChart(),
 	}
 
 	btnPadding := layout.Inset{Top: dp8, Right: dp20, Left: dp20, Bottom: dp8}
 	pg.toggleBuyAndSellBtn.Padding = btnPadding
+	pg.chartTypeSelector.Padding, pg.candleDurSelector.Padding = btnPadding, btnPadding
+	pg.candleDurSelector.SetSelectedSegment(defaultCandleDur)
 	pg.openOrdersBtn.Inset, pg.orderHistoryBtn.Inset = btnPadding, btnPadding
 	pg.openOrdersBtn.Font.Weight, pg.orderHistoryBtn.Font.Weight = font.SemiBold, font.SemiBold
 
@@ -361,6 +386,7 @@ func (pg *DEXMarketPage) setServerMarkets() {
 			pg.notifyError(err.Error())
 		} else {
 			pg.xc = xc
+			pg.setCandleDurations(xc.CandleDurs)
 			serverIsDisconnected = xc.ConnectionStatus != comms.Connected
 			for _, m := range xc.Markets {
 				base, quote := convertAssetIDToAssetType(m.BaseID), convertAssetIDToAssetType(m.QuoteID)
@@ -414,6 +440,7 @@ func (pg *DEXMarketPage) fetchOrderBook() {
 		marketID:    pg.formatSelectedMarketAsDEXMarketName(),
 	}
 	pg.closeAndResetOrderbookListener()
+	pg.resetCharts()
 
 	if pg.noMarketOrServerDisconnected.Load() {
 		return // nothing to do.
@@ -425,13 +452,20 @@ func (pg *DEXMarketPage) fetchOrderBook() {
 	pg.amountEditor.ExtraText = pg.selectedMarketOrderBook.baseSymbol
 
 	pg.showLoader = true
+	candleDur := pg.candleDurSelector.SelectedSegment()
 	go func() {
 		// Fetch order book and only update if we're still on the same market.
 		book, feed, err := pg.AssetsManager.DexClient().SyncBook(pg.serverSelector.Selected(), baseAssetID, quoteAssetID)
 		if err == nil && pg.selectedMarketOrderBook.base == baseAssetID && pg.selectedMarketOrderBook.quote == quoteAssetID {
 			pg.selectedMarketOrderBook.book = book
+			pg.chartMtx.Lock()
 			pg.closeOrderBookListener = feed.Close
+			pg.bookFeed = feed
+			pg.chartMtx.Unlock()
 			pg.showLoader = false
+			pg.refreshDepthChart()
+			pg.refreshChartMarkers()
+			go pg.subscribeCandles(candleDur)
 			pg.ParentWindow().Reload()
 			pg.listenForOrderbookNotifications(feed)
 		} else if err != nil {
@@ -467,6 +501,7 @@ func (pg *DEXMarketPage) listenForOrderbookNotifications(feed core.BookFeed) {
 			}
 
 			if pg.serverSelector.Selected() == bookUpdate.Host && sameMarket {
+				pg.handleChartUpdate(bookUpdate)
 				pg.ParentWindow().Reload()
 			}
 		}
@@ -474,9 +509,12 @@ func (pg *DEXMarketPage) listenForOrderbookNotifications(feed core.BookFeed) {
 }
 
 func (pg *DEXMarketPage) closeAndResetOrderbookListener() {
+	pg.chartMtx.Lock()
+	defer pg.chartMtx.Unlock()
 	if pg.closeOrderBookListener != nil {
 		pg.closeOrderBookListener()
 		pg.closeOrderBookListener = nil // reset
+		pg.bookFeed = nil
 	}
 }
 
@@ -553,6 +591,7 @@ func (pg *DEXMarketPage) Layout(gtx C) D {
 	pageContent := []layout.FlexChild{
 		layout.Rigid(pg.serverAndCurrencySelection),
 		layout.Rigid(pg.priceAndVolumeDetail),
+		layout.Rigid(pg.marketCharts),
 		layout.Rigid(pg.orderFormAndOrderBook),
 		layout.Rigid(pg.openOrdersAndHistory),
 	}
@@ -589,7 +628,7 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(func(gtx C) D {
 						return layout.Flex{Axis: vertical}.Layout(gtx,
-							layout.Rigid(pg.semiBoldLabelText(values.String(values.StrServer)).Layout),
+							layout.Rigid(pg.serverLabel),
 							layout.Rigid(func(gtx C) D {
 								pg.serverSelector.Background = &pg.Theme.Color.Surface
 								pg.serverSelector.BorderColor = &pg.Theme.Color.Gray5
@@ -600,7 +639,7 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 					layout.Rigid(func(gtx C) D {
 						return layout.Inset{Top: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 							return layout.Flex{Axis: vertical}.Layout(gtx,
-								layout.Rigid(pg.semiBoldLabelText(values.String(values.StrCurrencyPair)).Layout),
+								layout.Rigid(pg.marketLabel),
 								layout.Rigid(func(gtx C) D {
 									pg.marketSelector.Background = &pg.Theme.Color.Surface
 									pg.marketSelector.BorderColor = &pg.Theme.Color.Gray5
@@ -628,7 +667,7 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 		layout.Flexed(0.5, func(gtx C) D {
 			return layout.Inset{Left: values.MarginPadding10, Right: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 				return layout.Flex{Axis: vertical}.Layout(gtx,
-					layout.Rigid(pg.semiBoldLabelText(values.String(values.StrServer)).Layout),
+					layout.Rigid(pg.serverLabel),
 					layout.Rigid(func(gtx C) D {
 						pg.serverSelector.Background = &pg.Theme.Color.Surface
 						pg.serverSelector.BorderColor = &pg.Theme.Color.Gray5
@@ -640,7 +679,7 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 		layout.Flexed(0.5, func(gtx C) D {
 			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 				return layout.Flex{Axis: vertical}.Layout(gtx,
-					layout.Rigid(pg.semiBoldLabelText(values.String(values.StrCurrencyPair)).Layout),
+					layout.Rigid(pg.marketLabel),
 					layout.Rigid(func(gtx C) D {
 						pg.marketSelector.Background = &pg.Theme.Color.Surface
 						pg.marketSelector.BorderColor = &pg.Theme.Color.Gray5
@@ -652,6 +691,44 @@ func (pg *DEXMarketPage) serverAndCurrencySelection(gtx C) D {
 	)
 }
 
+// serverLabel displays the server selector title and a link to the bonds page
+// of the selected server.
+func (pg *DEXMarketPage) serverLabel(gtx C) D {
+	return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(pg.semiBoldLabelText(values.String(values.StrServer)).Layout),
+		layout.Flexed(1, func(gtx C) D {
+			if pg.xc == nil || pg.serverSelector.Selected() != pg.xc.Host {
+				return D{}
+			}
+
+			lb := pg.Theme.Label(values.TextSize14, values.String(values.StrManageBonds))
+			lb.Color = pg.Theme.Color.Primary
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return pg.manageBondsBtn.Layout(gtx, lb.Layout)
+			})
+		}),
+	)
+}
+
+// marketLabel displays the market selector title and a link to the market
+// maker page of the selected market.
+func (pg *DEXMarketPage) marketLabel(gtx C) D {
+	return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(pg.semiBoldLabelText(values.String(values.StrCurrencyPair)).Layout),
+		layout.Flexed(1, func(gtx C) D {
+			if pg.xc == nil || pg.selectedMarketInfo() == nil {
+				return D{}
+			}
+
+			lb := pg.Theme.Label(values.TextSize14, values.String(values.StrMarketMaker))
+			lb.Color = pg.Theme.Color.Primary
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return pg.marketMakerBtn.Layout(gtx, lb.Layout)
+			})
+		}),
+	)
+}
+
 func (pg *DEXMarketPage) priceAndVolumeDetail(gtx C) D {
 	var change24, priceChange float64
 	marketRate, low24, high24, baseVol24, quoteVol24 := "------", "------", "------", "------", "------"
@@ -661,7 +738,7 @@ func (pg *DEXMarketPage) priceAndVolumeDetail(gtx C) D {
 		if ticker == nil {
 			marketRate = pg.Printer.Sprintf("%f", rate)
 		} else {
-			marketRate = pg.Printer.Sprintf("%f (~ %s)", rate, pageutils.FormatAsUSDString(pg.Printer, rate*ticker.LastTradePrice))
+			marketRate = pg.Printer.Sprintf("%f (~ %s)", rate, pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, rate*ticker.LastTradePrice))
 		}
 
 		change24 = mkt.SpotPrice.Change24
@@ -1245,7 +1322,7 @@ func (pg *DEXMarketPage) orderbook(gtx C) D {
 								marketRate := mkt.MsgRateToConventional(mkt.SpotPrice.Rate)
 								marketRateStr = fmt.Sprintf("%f %s", marketRate, quoteAsset)
 								if ticker := pg.selectedMarketUSDRateTicker(); ticker != nil {
-									marketRateStr = fmt.Sprintf("%f %s (~ %s)", marketRate, quoteAsset, pageutils.FormatAsUSDString(pg.Printer, marketRate*ticker.LastTradePrice))
+									marketRateStr = fmt.Sprintf("%f %s (~ %s)", marketRate, quoteAsset, pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, marketRate*ticker.LastTradePrice))
 								}
 							}
 							lb := pg.Theme.Label(values.TextSize16, marketRateStr)
@@ -1344,6 +1421,19 @@ func (pg *DEXMarketPage) openOrdersAndHistory(gtx C) D {
 					return layout.Inset{Left: dp5, Right: dp10}.Layout(gtx, pg.openOrdersBtn.Layout)
 				}),
 				layout.Rigid(pg.orderHistoryBtn.Layout),
+				layout.Flexed(1, func(gtx C) D {
+					if pg.openOrdersDisplayed {
+						return D{}
+					}
+
+					lb := pg.Theme.Label(values.TextSize14, values.String(values.StrViewAllOrders))
+					lb.Color = pg.Theme.Color.Primary
+					return layout.E.Layout(gtx, func(gtx C) D {
+						return layout.Inset{Right: dp10}.Layout(gtx, func(gtx C) D {
+							return pg.tradeAnalyticsBtn.Layout(gtx, lb.Layout)
+						})
+					})
+				}),
 			)
 		}),
 		layout.Rigid(func(gtx C) D {
@@ -1600,6 +1690,16 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 		}))
 	}
 
+	if pg.manageBondsBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewDEXBondsPage(pg.Load, pg.serverSelector.Selected()))
+	}
+
+	if pg.marketMakerBtn.Clicked(gtx) {
+		if mkt := pg.selectedMarketInfo(); mkt != nil && pg.xc != nil {
+			pg.ParentNavigator().Display(NewDEXBotPage(pg.Load, pg.xc.Host, mkt))
+		}
+	}
+
 	if pg.openOrdersBtn.Clicked(gtx) {
 		pg.orders = nil // clear orders
 		pg.openOrdersDisplayed = true
@@ -1612,12 +1712,25 @@ func (pg *DEXMarketPage) HandleUserInteractions(gtx C) {
 		pg.setMaxBuyAndMaxSell()
 	}
 
+	if pg.candleDurSelector.Changed() {
+		pg.candleChart.SetCandles(nil)
+		go pg.subscribeCandles(pg.candleDurSelector.SelectedSegment())
+	}
+
 	if pg.orderHistoryBtn.Clicked(gtx) {
 		pg.orders = nil // clear orders
 		pg.openOrdersDisplayed = false
 		go pg.refreshOrders()
 	}
 
+	if pg.tradeAnalyticsBtn.Clicked(gtx) {
+		var host string
+		if pg.xc != nil {
+			host = pg.xc.Host
+		}
+		pg.ParentNavigator().Display(NewDEXTradeHistoryPage(pg.Load, host, ""))
+	}
+
 	if pg.seeFullOrderBookBtn.Clicked(gtx) {
 		// TODO: display full order book
 		log.Info("button click listener for full order book view is not implemented")
@@ -1938,6 +2051,8 @@ func (pg *DEXMarketPage) refreshOrders() {
 	sort.SliceStable(pg.orders, func(i, j int) bool {
 		return pg.orders[i].SubmitTime > pg.orders[j].SubmitTime
 	})
+
+	pg.refreshChartMarkers()
 }
 
 func anyMatchActive(matches []*core.Match) bool {
@@ -0,0 +1,213 @@
+package dcrdex
+
+import (
+	"slices"
+
+	"decred.org/dcrdex/client/core"
+	"decred.org/dcrdex/dex/candles"
+	"decred.org/dcrdex/dex/order"
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// defaultCandleDur is the candlestick bin size selected when the market page
+// is opened.
+const defaultCandleDur = "1h"
+
+var chartTypes = []string{
+	values.StrCandles,
+	values.StrDepth,
+}
+
+// setCandleDurations resets the candle duration selector if the server
+// supports a different set of bin sizes than the ones currently displayed.
+func (pg *DEXMarketPage) setCandleDurations(durs []string) {
+	if len(durs) == 0 {
+		durs = candles.BinSizes
+	}
+	if slices.Equal(durs, pg.candleDurSelector.Segments()) {
+		return
+	}
+
+	selected := pg.candleDurSelector.SelectedSegment()
+	pg.candleDurSelector = pg.Theme.SegmentedControl(durs, cryptomaterial.SegmentTypeGroup)
+	pg.candleDurSelector.Padding = pg.chartTypeSelector.Padding
+	pg.candleDurSelector.SetSelectedSegment(defaultCandleDur)
+	pg.candleDurSelector.SetSelectedSegment(selected)
+}
+
+func (pg *DEXMarketPage) resetCharts() {
+	pg.candleChart.SetCandles(nil)
+	pg.candleChart.SetMarkers(nil)
+	pg.depthChart.SetBook(nil, nil)
+	pg.depthChart.SetMarkers(nil)
+}
+
+// subscribeCandles requests candles for the dur bin size. The initial set of
+// candles is delivered through the book feed.
+func (pg *DEXMarketPage) subscribeCandles(dur string) {
+	pg.chartMtx.Lock()
+	feed := pg.bookFeed
+	pg.candleDur = dur
+	pg.chartMtx.Unlock()
+	if feed == nil {
+		return
+	}
+
+	if err := feed.Candles(dur); err != nil {
+		log.Errorf("Error subscribing to %s candles: %v", dur, err)
+	}
+}
+
+// subscribedCandleDur returns the bin size of the candles shown on the chart.
+func (pg *DEXMarketPage) subscribedCandleDur() string {
+	pg.chartMtx.Lock()
+	defer pg.chartMtx.Unlock()
+	return pg.candleDur
+}
+
+// handleChartUpdate updates the market charts with data from the order book
+// feed. The update must be for the selected market.
+func (pg *DEXMarketPage) handleChartUpdate(bookUpdate *core.BookUpdate) {
+	mkt := pg.selectedMarketInfo()
+	if mkt == nil {
+		return
+	}
+
+	switch bookUpdate.Action {
+	case core.FreshCandlesAction:
+		payload, ok := bookUpdate.Payload.(*core.CandlesPayload)
+		if !ok || payload.Dur != pg.subscribedCandleDur() {
+			return
+		}
+		chartCandles := make([]cryptomaterial.CandleData, 0, len(payload.Candles))
+		for i := range payload.Candles {
+			chartCandles = append(chartCandles, toChartCandle(mkt, &payload.Candles[i]))
+		}
+		pg.candleChart.SetCandles(chartCandles)
+
+	case core.CandleUpdateAction:
+		payload, ok := bookUpdate.Payload.(core.CandleUpdate)
+		if !ok || payload.Candle == nil || payload.Dur != pg.subscribedCandleDur() {
+			return
+		}
+		pg.candleChart.UpdateCandle(toChartCandle(mkt, payload.Candle))
+
+	case core.FreshBookAction, core.BookOrderAction, core.UnbookOrderAction, core.UpdateRemainingAction:
+		pg.refreshDepthChart()
+	}
+}
+
+func toChartCandle(mkt *core.Market, c *candles.Candle) cryptomaterial.CandleData {
+	return cryptomaterial.CandleData{
+		StartStamp: c.StartStamp,
+		EndStamp:   c.EndStamp,
+		Open:       mkt.MsgRateToConventional(c.StartRate),
+		High:       mkt.MsgRateToConventional(c.HighRate),
+		Low:        mkt.MsgRateToConventional(c.LowRate),
+		Close:      mkt.MsgRateToConventional(c.EndRate),
+		Volume:     conventionalAmt(c.MatchVolume),
+	}
+}
+
+// refreshDepthChart rebuilds the depth chart from the synced order book.
+func (pg *DEXMarketPage) refreshDepthChart() {
+	book, mkt := pg.selectedMarketOrderBook.book, pg.selectedMarketInfo()
+	if book == nil || mkt == nil {
+		return
+	}
+
+	buyOrders, sellOrders, _ := book.Orders()
+	buys := make([]cryptomaterial.DepthEntry, 0, len(buyOrders))
+	for _, ord := range buyOrders {
+		buys = append(buys, cryptomaterial.DepthEntry{Rate: mkt.MsgRateToConventional(ord.Rate), Qty: conventionalAmt(ord.Quantity)})
+	}
+	sells := make([]cryptomaterial.DepthEntry, 0, len(sellOrders))
+	for _, ord := range sellOrders {
+		sells = append(sells, cryptomaterial.DepthEntry{Rate: mkt.MsgRateToConventional(ord.Rate), Qty: conventionalAmt(ord.Quantity)})
+	}
+
+	pg.depthChart.SetBook(buys, sells)
+}
+
+// refreshChartMarkers highlights the user's open limit orders for the selected
+// market on both charts.
+func (pg *DEXMarketPage) refreshChartMarkers() {
+	mkt := pg.selectedMarketInfo()
+	if mkt == nil || !pg.AssetsManager.DexClient().IsLoggedIn() {
+		return
+	}
+
+	filter := &core.OrderFilter{
+		Hosts:    []string{pg.serverSelector.Selected()},
+		Statuses: []order.OrderStatus{order.OrderStatusBooked, order.OrderStatusEpoch},
+		Market: &struct {
+			Base  uint32 `json:"baseID"`
+			Quote uint32 `json:"quoteID"`
+		}{Base: mkt.BaseID, Quote: mkt.QuoteID},
+	}
+	orders, err := pg.AssetsManager.DexClient().Orders(filter)
+	if err != nil {
+		log.Errorf("Error fetching open orders for chart markers: %v", err)
+		return
+	}
+
+	var markers []cryptomaterial.ChartMarker
+	for _, ord := range orders {
+		if ord.Type != order.LimitOrderType || ord.Rate == 0 {
+			continue
+		}
+		markers = append(markers, cryptomaterial.ChartMarker{Price: mkt.MsgRateToConventional(ord.Rate), Sell: ord.Sell})
+	}
+
+	pg.candleChart.SetMarkers(markers)
+	pg.depthChart.SetMarkers(markers)
+}
+
+func (pg *DEXMarketPage) marketCharts(gtx C) D {
+	if pg.noMarketOrServerDisconnected.Load() {
+		return D{}
+	}
+
+	showCandles := pg.chartTypeSelector.SelectedSegment() == values.StrCandles
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(dp16),
+		Margin:      layout.Inset{Top: dp5, Bottom: dp5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{Axis: horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(pg.chartTypeSelector.GroupTileLayout),
+				layout.Flexed(1, func(gtx C) D {
+					if !showCandles {
+						return D{}
+					}
+					return layout.E.Layout(gtx, pg.candleDurSelector.GroupTileLayout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: dp16}.Layout(gtx, func(gtx C) D {
+				hasData := pg.depthChart.HasData()
+				chart := pg.depthChart.Layout
+				if showCandles {
+					hasData = pg.candleChart.HasData()
+					chart = pg.candleChart.Layout
+				}
+
+				if !hasData {
+					return layout.Center.Layout(gtx, func(gtx C) D {
+						return layout.Inset{Top: dp20, Bottom: dp20}.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoChartData)).Layout)
+					})
+				}
+				return chart(gtx)
+			})
+		}),
+	)
+}
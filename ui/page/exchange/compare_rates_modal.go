@@ -0,0 +1,265 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget/material"
+
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// compareRatesModal requests quotes from all the exchange servers and
+// displays them ranked from the best to the worst.
+type compareRatesModal struct {
+	*load.Load
+	*cryptomaterial.Modal
+
+	ctx       context.Context
+	ctxCancel context.CancelFunc
+
+	fromCurrency libutils.AssetType
+	toCurrency   libutils.AssetType
+	amount       float64
+
+	quotes         []*instantswap.Quote
+	quoteItems     []*cryptomaterial.Clickable
+	fetching       bool
+	quotesList     layout.List
+	materialLoader material.LoaderStyle
+
+	refreshBtn cryptomaterial.Button
+	closeBtn   cryptomaterial.Button
+
+	quoteSelected func(*instantswap.Quote)
+}
+
+func newCompareRatesModal(l *load.Load, fromCurrency, toCurrency libutils.AssetType, amount float64) *compareRatesModal {
+	crm := &compareRatesModal{
+		Load:           l,
+		Modal:          l.Theme.ModalFloatTitle(values.String(values.StrCompareRates), l.IsMobileView(), nil),
+		fromCurrency:   fromCurrency,
+		toCurrency:     toCurrency,
+		amount:         amount,
+		quotesList:     layout.List{Axis: layout.Vertical},
+		materialLoader: material.Loader(l.Theme.Base),
+		refreshBtn:     l.Theme.OutlineButton(values.String(values.StrRetry)),
+		closeBtn:       l.Theme.Button(values.String(values.StrCancel)),
+	}
+
+	crm.Modal.ShowScrollbar(true)
+	return crm
+}
+
+// OnQuoteSelected sets the callback executed when a successful quote is
+// clicked.
+func (crm *compareRatesModal) OnQuoteSelected(quoteSelected func(*instantswap.Quote)) *compareRatesModal {
+	crm.quoteSelected = quoteSelected
+	return crm
+}
+
+func (crm *compareRatesModal) OnResume() {
+	crm.ctx, crm.ctxCancel = context.WithCancel(context.TODO())
+	go crm.fetchQuotes()
+}
+
+func (crm *compareRatesModal) OnDismiss() {
+	crm.ctxCancel()
+}
+
+func (crm *compareRatesModal) fetchQuotes() {
+	crm.fetching = true
+	crm.ParentWindow().Reload()
+
+	quotes := crm.AssetsManager.InstantSwapQuotes(crm.ctx, crm.fromCurrency.String(), crm.toCurrency.String(), crm.amount)
+	if crm.ctx.Err() != nil {
+		return // modal dismissed
+	}
+
+	quoteItems := make([]*cryptomaterial.Clickable, len(quotes))
+	for i := range quotes {
+		quoteItems[i] = crm.Theme.NewClickable(true)
+	}
+
+	crm.quotes, crm.quoteItems = quotes, quoteItems
+	crm.fetching = false
+	crm.ParentWindow().Reload()
+}
+
+func (crm *compareRatesModal) Handle(gtx C) {
+	for i, quoteItem := range crm.quoteItems {
+		if quoteItem.Clicked(gtx) && crm.quotes[i].Err == nil {
+			if crm.quoteSelected != nil {
+				crm.quoteSelected(crm.quotes[i])
+			}
+			crm.Dismiss()
+		}
+	}
+
+	if crm.refreshBtn.Clicked(gtx) && !crm.fetching {
+		go crm.fetchQuotes()
+	}
+
+	if crm.closeBtn.Clicked(gtx) || crm.Modal.BackdropClicked(gtx, true) {
+		crm.Dismiss()
+	}
+}
+
+func (crm *compareRatesModal) Layout(gtx C) D {
+	fromCur := strings.ToUpper(crm.fromCurrency.String())
+	toCur := strings.ToUpper(crm.toCurrency.String())
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			titleTxt := crm.Theme.Label(values.TextSize20, values.String(values.StrCompareRates))
+			titleTxt.Font.Weight = font.SemiBold
+			return titleTxt.Layout(gtx)
+		},
+		func(gtx C) D {
+			amount := fmt.Sprintf("%s %s", formatQuoteAmount(crm.amount), fromCur)
+			return crm.Theme.Body2(values.StringF(values.StrCompareRatesDesc, amount)).Layout(gtx)
+		},
+		func(gtx C) D {
+			if crm.fetching {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding16)
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return crm.materialLoader.Layout(gtx)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, crm.Theme.Body2(values.String(values.StrFetchingQuotes)).Layout)
+					}),
+				)
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return crm.quoteRow(gtx,
+						crm.headerLabel(values.String(values.StrServer)),
+						crm.headerLabel(values.String(values.StrYouReceive)),
+						crm.headerLabel(values.String(values.StrLimits)),
+						crm.headerLabel(values.String(values.StrEstimatedTime)),
+						crm.headerLabel(values.String(values.StrVsMarket)),
+					)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return crm.quotesList.Layout(gtx, len(crm.quotes), func(gtx C, i int) D {
+						return crm.quoteItems[i].Layout(gtx, func(gtx C) D {
+							return crm.quoteLayout(gtx, i, fromCur, toCur)
+						})
+					})
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if crm.fetching {
+							return D{}
+						}
+						return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, crm.refreshBtn.Layout)
+					}),
+					layout.Rigid(crm.closeBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return crm.Modal.Layout(gtx, w, 750)
+}
+
+func (crm *compareRatesModal) quoteLayout(gtx C, i int, fromCur, toCur string) D {
+	quote := crm.quotes[i]
+	serverName := quote.Server.Server.CapFirstLetter()
+
+	if quote.Err != nil {
+		return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(crm.Theme.Label(values.TextSize14, serverName).Layout),
+				layout.Rigid(crm.noteLabel(values.String(values.StrFetchRateError), crm.Theme.Color.Danger)),
+			)
+		})
+	}
+
+	info := quote.RateInfo
+	maxLimit := values.String(values.StrNoLimit)
+	if info.Max > 0 {
+		maxLimit = formatQuoteAmount(info.Max)
+	}
+
+	deviation := "--"
+	if quote.Deviation >= 0 {
+		deviation = fmt.Sprintf("%.2f%%", quote.Deviation)
+	}
+
+	var note string
+	noteColor := crm.Theme.Color.GreenText
+	switch {
+	case !quote.WithinLimits:
+		note, noteColor = values.String(values.StrOutsideLimits), crm.Theme.Color.Danger
+	case quote.Deviates:
+		note, noteColor = values.String(values.StrRateDeviates), crm.Theme.Color.Orange
+	case i == 0:
+		note = values.String(values.StrBestRate)
+	}
+
+	return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return crm.quoteRow(gtx,
+					crm.Theme.Label(values.TextSize14, serverName).Layout,
+					crm.Theme.Label(values.TextSize14, fmt.Sprintf("%s %s", formatQuoteAmount(quote.ReceiveAmount), toCur)).Layout,
+					crm.Theme.Label(values.TextSize14, fmt.Sprintf("%s - %s %s", formatQuoteAmount(info.Min), maxLimit, fromCur)).Layout,
+					crm.Theme.Label(values.TextSize14, fmt.Sprintf("~%d min", int(quote.EstimatedTime.Round(time.Minute).Minutes()))).Layout,
+					crm.Theme.Label(values.TextSize14, deviation).Layout,
+				)
+			}),
+			layout.Rigid(crm.noteLabel(note, noteColor)),
+		)
+	})
+}
+
+// quoteRow lays out the columns of a row of the quotes table.
+func (crm *compareRatesModal) quoteRow(gtx C, columns ...layout.Widget) D {
+	weights := []float32{0.2, 0.25, 0.25, 0.15, 0.15}
+	children := make([]layout.FlexChild, len(columns))
+	for i, column := range columns {
+		children[i] = layout.Flexed(weights[i], column)
+	}
+	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx, children...)
+}
+
+func (crm *compareRatesModal) headerLabel(txt string) layout.Widget {
+	lb := crm.Theme.Label(values.TextSize14, txt)
+	lb.Color = crm.Theme.Color.GrayText2
+	lb.Font.Weight = font.SemiBold
+	return lb.Layout
+}
+
+func (crm *compareRatesModal) noteLabel(txt string, color color.NRGBA) layout.Widget {
+	return func(gtx C) D {
+		if txt == "" {
+			return D{}
+		}
+		lb := crm.Theme.Label(values.TextSize12, txt)
+		lb.Color = color
+		return lb.Layout(gtx)
+	}
+}
+
+// formatQuoteAmount formats a quoted amount without trailing zeros.
+func formatQuoteAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -131,6 +131,14 @@ func (com *confirmOrderModal) confirmOrder() {
 			return
 		}
 
+		// The wallet's unsigned tx is reserved until the deposit is sent.
+		sourceWallet := com.sourceWalletSelector.SelectedWallet()
+		if !sourceWallet.TryLockTxAuthor() {
+			com.SetError(values.TranslateErr(utils.ErrTxAuthorBusy))
+			return
+		}
+		defer sourceWallet.UnlockTxAuthor()
+
 		order, err := com.createOrder()
 		if err != nil {
 			log.Error(errors.E(errors.Op("instantSwap.CreateOrder"), err))
@@ -65,6 +65,8 @@ type CreateOrderPage struct {
 	settingsButton                           cryptomaterial.IconButton
 	iconClickable                            *cryptomaterial.Clickable
 	refreshClickable                         *cryptomaterial.Clickable
+	compareRatesBtn                          *cryptomaterial.Clickable
+	schedulesBtn                             *cryptomaterial.Clickable
 	viewAllButton                            cryptomaterial.Button
 	navToSettingsBtn                         cryptomaterial.Button
 	createWalletBtn                          cryptomaterial.Button
@@ -127,6 +129,8 @@ func NewCreateOrderPage(l *load.Load) *CreateOrderPage {
 		orderData:        &orderData{},
 		exchangeRate:     -1,
 		refreshClickable: l.Theme.NewClickable(true),
+		compareRatesBtn:  l.Theme.NewClickable(false),
+		schedulesBtn:     l.Theme.NewClickable(false),
 		iconClickable:    l.Theme.NewClickable(true),
 		navToSettingsBtn: l.Theme.Button(values.String(values.StrStartTrading)),
 		createWalletBtn:  l.Theme.Button(values.String(values.StrCreateANewWallet)),
@@ -207,31 +211,52 @@ func NewCreateOrderPage(l *load.Load) *CreateOrderPage {
 
 	pg.navToSettingsBtn = pg.Theme.Button(values.StringF(values.StrEnableAPI, values.String(values.StrExchange)))
 
-	pg.exchangeSelector.ExchangeSelected(func(es *Exchange) {
-		pg.selectedExchange = es
+	pg.exchangeSelector.ExchangeSelected(pg.setExchange)
 
-		// Initialize a new exchange using the selected exchange server
-		exchange, err := pg.AssetsManager.InstantSwap.NewExchangeServer(pg.selectedExchange.Server)
+	return pg
+}
+
+// setExchange initializes the selected exchange server and fetches its rate
+// for the selected currencies.
+func (pg *CreateOrderPage) setExchange(es *Exchange) {
+	pg.selectedExchange = es
+
+	// Initialize a new exchange using the selected exchange server
+	exchange, err := pg.AssetsManager.InstantSwap.NewExchangeServer(pg.selectedExchange.Server)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	pg.exchange = exchange
+
+	go func() {
+		err := pg.fetchInstantExchangeCurrencies()
 		if err != nil {
 			log.Error(err)
 			return
 		}
-		pg.exchange = exchange
+		err = pg.getExchangeRateInfo()
+		if err != nil {
+			log.Error(err)
+		}
+	}()
+}
 
-		go func() {
-			err := pg.fetchInstantExchangeCurrencies()
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			err = pg.getExchangeRateInfo()
-			if err != nil {
-				log.Error(err)
+// showCompareRatesModal displays the quotes of all the exchange servers for
+// the entered amount and selects the exchange of the quote clicked.
+func (pg *CreateOrderPage) showCompareRatesModal() {
+	amount, _ := strconv.ParseFloat(pg.fromAmountEditor.Edit.Editor.Text(), 64)
+	compareRatesModal := newCompareRatesModal(pg.Load, pg.fromCurrency, pg.toCurrency, amount).
+		OnQuoteSelected(func(quote *instantswap.Quote) {
+			for _, exch := range pg.exchangeSelector.SupportedExchanges() {
+				if exch.Server.Server == quote.Server.Server {
+					pg.exchangeSelector.SetSelectedExchange(exch)
+					pg.setExchange(exch)
+					return
+				}
 			}
-		}()
-	})
-
-	return pg
+		})
+	pg.ParentWindow().ShowModal(compareRatesModal)
 }
 
 func (pg *CreateOrderPage) updateWalletAndAccountSelector(selectedFromAsset []libutils.AssetType, selectedToAsset []libutils.AssetType) bool {
@@ -296,7 +321,7 @@ func (pg *CreateOrderPage) isExchangeAPIAllowed() bool {
 // once after it has been displayed.
 func (pg *CreateOrderPage) initPage() {
 	pg.inited = true
-	pg.scheduler.SetChecked(pg.AssetsManager.IsOrderSchedulerRunning())
+	pg.scheduler.SetChecked(len(pg.AssetsManager.RunningScheduleIDs()) > 0)
 	pg.listenForNotifications()
 	pg.loadOrderConfig()
 	go pg.scroll.FetchScrollData(false, pg.ParentWindow(), false)
@@ -326,6 +351,10 @@ func (pg *CreateOrderPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().Display(NewOrderDetailsPage(pg.Load, orderItems[selectedItem]))
 	}
 
+	if pg.compareRatesBtn.Clicked(gtx) {
+		pg.showCompareRatesModal()
+	}
+
 	if pg.refreshExchangeRateBtn.Button.Clicked(gtx) {
 		go func() {
 			err := pg.getExchangeRateInfo()
@@ -402,10 +431,14 @@ func (pg *CreateOrderPage) HandleUserInteractions(gtx C) {
 				})
 			pg.ParentWindow().ShowModal(orderSettingsModal)
 		} else {
-			pg.AssetsManager.StopScheduler()
+			pg.AssetsManager.StopAllSchedules()
 		}
 	}
 
+	if pg.schedulesBtn.Clicked(gtx) {
+		pg.ParentWindow().Display(NewSchedulesPage(pg.Load))
+	}
+
 	if pg.navToSettingsBtn.Button.Clicked(gtx) {
 		pg.ParentWindow().Display(settings.NewAppSettingsPage(pg.Load))
 	}
@@ -798,6 +831,18 @@ func (pg *CreateOrderPage) layoutDesktop(gtx C) D {
 										layout.Rigid(func(gtx C) D {
 											return pg.exchangeSelector.Layout(pg.ParentWindow(), gtx)
 										}),
+										layout.Rigid(func(gtx C) D {
+											if pg.fromCurrency == libutils.NilAsset || pg.toCurrency == libutils.NilAsset || pg.fromCurrency == pg.toCurrency {
+												return D{}
+											}
+
+											txt := pg.Theme.Label(textSize14, values.String(values.StrCompareRates))
+											txt.Color = pg.Theme.Color.Primary
+											txt.Font.Weight = font.SemiBold
+											return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+												return pg.compareRatesBtn.Layout(gtx, txt.Layout)
+											})
+										}),
 									)
 								}),
 							)
@@ -1073,6 +1118,7 @@ func (pg *CreateOrderPage) layoutMobile(gtx C) D {
 // settings and indicator
 func (pg *CreateOrderPage) orderSchedulerLayout(gtx C) D {
 	textSize16 := values.TextSizeTransform(pg.IsMobileView(), values.TextSize16)
+	runningSchedules := len(pg.AssetsManager.RunningScheduleIDs())
 	return layout.E.Layout(gtx, func(gtx C) D {
 		return layout.Flex{
 			Axis:      layout.Horizontal,
@@ -1083,12 +1129,12 @@ func (pg *CreateOrderPage) orderSchedulerLayout(gtx C) D {
 					Axis: layout.Vertical,
 				}.Layout(gtx,
 					layout.Rigid(func(gtx C) D {
-						title := pg.Theme.Label(textSize16, values.String(values.StrScheduler))
-						title.Color = pg.Theme.Color.GrayText2
-						return title.Layout(gtx)
+						title := pg.Theme.Label(textSize16, values.String(values.StrSchedules))
+						title.Color = pg.Theme.Color.Primary
+						return pg.schedulesBtn.Layout(gtx, title.Layout)
 					}),
 					layout.Rigid(func(gtx C) D {
-						if pg.AssetsManager.IsOrderSchedulerRunning() {
+						if runningSchedules > 0 {
 							return layout.Flex{
 								Axis: layout.Horizontal,
 							}.Layout(gtx,
@@ -1099,7 +1145,7 @@ func (pg *CreateOrderPage) orderSchedulerLayout(gtx C) D {
 									}.Layout(gtx, pg.Theme.Icons.TimerIcon.Layout12dp)
 								}),
 								layout.Rigid(func(gtx C) D {
-									title := pg.Theme.Label(textSize16, pg.AssetsManager.GetSchedulerRuntime())
+									title := pg.Theme.Label(textSize16, values.StringF(values.StrSchedulesRunning, runningSchedules))
 									title.Color = pg.Theme.Color.GrayText2
 									return title.Layout(gtx)
 								}),
@@ -1116,7 +1162,7 @@ func (pg *CreateOrderPage) orderSchedulerLayout(gtx C) D {
 				}.Layout(gtx, pg.scheduler.Layout)
 			}),
 			layout.Rigid(func(gtx C) D {
-				if pg.AssetsManager.IsOrderSchedulerRunning() {
+				if runningSchedules > 0 {
 					return layout.Inset{Left: values.MarginPadding4, Top: unit.Dp(2)}.Layout(gtx, func(gtx C) D {
 						gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding16)
 						gtx.Constraints.Min.X = gtx.Constraints.Max.X
@@ -1431,11 +1477,17 @@ func (pg *CreateOrderPage) listenForNotifications() {
 			pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
 			pg.ParentWindow().Reload()
 		},
-		OnOrderSchedulerStarted: func() {
-			pg.scheduler.SetChecked(pg.AssetsManager.IsOrderSchedulerRunning())
+		OnOrderVerified: func(_ *instantswap.Order) {
+			pg.scroll.FetchScrollData(false, pg.ParentWindow(), true)
+			pg.ParentWindow().Reload()
+		},
+		OnScheduleStarted: func(_ *instantswap.Schedule) {
+			pg.scheduler.SetChecked(true)
+			pg.ParentWindow().Reload()
 		},
-		OnOrderSchedulerEnded: func() {
-			pg.scheduler.SetChecked(false)
+		OnScheduleStopped: func(_ *instantswap.Schedule) {
+			pg.scheduler.SetChecked(len(pg.AssetsManager.RunningScheduleIDs()) > 0)
+			pg.ParentWindow().Reload()
 		},
 	}
 	err := pg.AssetsManager.InstantSwap.AddNotificationListener(orderNotificationListener, CreateOrderPageID)
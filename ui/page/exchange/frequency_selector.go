@@ -7,6 +7,7 @@ import (
 	"gioui.org/layout"
 
 	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/values"
@@ -24,7 +25,7 @@ type FrequencySelector struct {
 // frequencyItem wraps the frequency in a clickable.
 type frequencyItem struct {
 	name      string
-	item      time.Duration
+	item      time.Duration // time between orders, 0 to create the next order once the previous one completes
 	clickable *cryptomaterial.Clickable
 }
 
@@ -132,27 +133,27 @@ func (fs *FrequencySelector) buildFrequencyItems() []*frequencyItem {
 	return []*frequencyItem{
 		{
 			name:      "Fastest",
-			item:      time.Duration(1),
+			item:      instantswap.MinScheduleFrequency,
 			clickable: fs.Theme.NewClickable(true),
 		},
 		{
 			name:      "1x/3 hr",
-			item:      time.Duration(3),
+			item:      3 * time.Hour,
 			clickable: fs.Theme.NewClickable(true),
 		},
 		{
 			name:      "1x/6 hr",
-			item:      time.Duration(6),
+			item:      6 * time.Hour,
 			clickable: fs.Theme.NewClickable(true),
 		},
 		{
 			name:      "1x/12 hr",
-			item:      time.Duration(12),
+			item:      12 * time.Hour,
 			clickable: fs.Theme.NewClickable(true),
 		},
 		{
 			name:      "1x/day",
-			item:      time.Duration(24),
+			item:      24 * time.Hour,
 			clickable: fs.Theme.NewClickable(true),
 		},
 	}
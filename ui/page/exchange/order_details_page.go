@@ -270,6 +270,7 @@ func (pg *OrderDetailsPage) layout(gtx C) D {
 					}
 					return D{}
 				}),
+				layout.Rigid(pg.verificationLayout),
 				layout.Rigid(func(gtx C) D {
 					return layout.E.Layout(gtx, func(gtx C) D {
 						return layout.Inset{
@@ -302,6 +303,41 @@ func (pg *OrderDetailsPage) layout(gtx C) D {
 	})
 }
 
+// verificationLayout displays the transactions of the order seen in the local
+// wallets and the resulting payout status.
+func (pg *OrderDetailsPage) verificationLayout(gtx C) D {
+	verification := pg.orderInfo.Verification
+	statusText, statusColor := components.PayoutStatusText(pg.Load, pg.orderInfo)
+
+	txRow := func(title, txHash string) layout.FlexChild {
+		return layout.Rigid(func(gtx C) D {
+			if txHash == "" {
+				return D{}
+			}
+			lbl := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s: %s", title, txHash))
+			lbl.Color = pg.Theme.Color.GrayText2
+			return lbl.Layout(gtx)
+		})
+	}
+
+	return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				title := pg.Theme.Label(values.TextSize16, values.String(values.StrOnChainVerification)+": ")
+				status := pg.Theme.Label(values.TextSize16, statusText)
+				status.Color = statusColor
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(title.Layout),
+					layout.Rigid(status.Layout),
+				)
+			}),
+			txRow(values.String(values.StrDepositTx), verification.DepositTxID),
+			txRow(values.String(values.StrPayoutTx), verification.PayoutTxID),
+			txRow(values.String(values.StrRefundTx), verification.RefundTxID),
+		)
+	})
+}
+
 func (pg *OrderDetailsPage) getOrderInfo(UUID string) (*instantswap.Order, error) {
 	orderInfo, err := pg.AssetsManager.InstantSwap.GetOrderInfo(pg.exchange, UUID)
 	if err != nil {
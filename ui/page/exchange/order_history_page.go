@@ -448,6 +448,10 @@ func (pg *OrderHistoryPage) listenForSyncNotifications() {
 			pg.scroll.FetchScrollData(false, pg.ParentWindow(), false)
 			pg.ParentWindow().Reload()
 		},
+		OnOrderVerified: func(_ *instantswap.Order) {
+			pg.scroll.FetchScrollData(false, pg.ParentWindow(), false)
+			pg.ParentWindow().Reload()
+		},
 	}
 	err := pg.AssetsManager.InstantSwap.AddNotificationListener(orderNotificationListener, OrderHistoryPageID)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"strings"
+	"time"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -22,6 +23,13 @@ import (
 	api "github.com/crypto-power/instantswap/instantswap"
 )
 
+// amountModes are the translation keys of the amount modes of a schedule.
+var amountModes = []string{
+	values.StrFixedAmount,
+	values.StrPercentOfBalance,
+	values.StrAboveBalance,
+}
+
 type orderSchedulerModal struct {
 	*load.Load
 	*cryptomaterial.Modal
@@ -37,10 +45,15 @@ type orderSchedulerModal struct {
 	startBtn               cryptomaterial.Button
 	refreshExchangeRateBtn cryptomaterial.IconButton
 
-	balanceToMaintain          cryptomaterial.Editor
-	balanceToMaintainErrorText string
-	passwordEditor             cryptomaterial.Editor
-	copyRedirect               *cryptomaterial.Clickable
+	nameEditor         cryptomaterial.Editor
+	amountModeSelector *cryptomaterial.SegmentedControl
+	amountEditor       cryptomaterial.Editor
+	amountErrorText    string
+	maxOrdersEditor    cryptomaterial.Editor
+	maxTotalEditor     cryptomaterial.Editor
+	endDateEditor      cryptomaterial.Editor
+	passwordEditor     cryptomaterial.Editor
+	copyRedirect       *cryptomaterial.Clickable
 
 	exchangeSelector  *ExSelector
 	frequencySelector *FrequencySelector
@@ -79,8 +92,18 @@ func newOrderSchedulerModalModal(l *load.Load, data *orderData) *orderSchedulerM
 	osm.refreshExchangeRateBtn.Size = values.MarginPadding18
 	osm.refreshExchangeRateBtn.Inset = layout.UniformInset(values.MarginPadding0)
 
-	osm.balanceToMaintain = l.Theme.Editor(new(widget.Editor), values.StringF(values.StrBalanceToMaintain, osm.fromCurrency))
-	osm.balanceToMaintain.Editor.SingleLine, osm.balanceToMaintain.Editor.Submit = true, true
+	osm.nameEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrScheduleName))
+	osm.nameEditor.Editor.SingleLine = true
+
+	osm.amountModeSelector = l.Theme.SegmentedControl(amountModes, cryptomaterial.SegmentTypeGroup)
+	osm.amountEditor = l.Theme.Editor(new(widget.Editor), "")
+	osm.amountEditor.Editor.SingleLine, osm.amountEditor.Editor.Submit = true, true
+	osm.setAmountHint()
+
+	osm.maxOrdersEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrMaxOrdersOptional))
+	osm.maxTotalEditor = l.Theme.Editor(new(widget.Editor), values.StringF(values.StrMaxTotalOptional, osm.fromCurrency))
+	osm.endDateEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrEndDateOptional))
+	osm.maxOrdersEditor.Editor.SingleLine, osm.maxTotalEditor.Editor.SingleLine, osm.endDateEditor.Editor.SingleLine = true, true, true
 
 	osm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
 	osm.passwordEditor.Editor.SetText("")
@@ -164,26 +187,116 @@ func (osm *orderSchedulerModal) Handle(gtx C) {
 		}()
 	}
 
-	for osm.balanceToMaintain.Changed() && osm.balanceToMaintain.IsFocused() {
-		balanceToMaintain := osm.balanceToMaintain.Editor.Text()
-		if balanceToMaintain == "" {
-			continue
+	if osm.amountModeSelector.Changed() {
+		osm.setAmountHint()
+		osm.validateAmount()
+	}
+
+	for osm.amountEditor.Changed() && osm.amountEditor.IsFocused() {
+		osm.validateAmount()
+	}
+}
+
+// amountMode returns the amount mode selected for the schedule.
+func (osm *orderSchedulerModal) amountMode() instantswap.AmountMode {
+	switch osm.amountModeSelector.SelectedSegment() {
+	case values.StrPercentOfBalance:
+		return instantswap.AmountPercentage
+	case values.StrAboveBalance:
+		return instantswap.AmountAboveThreshold
+	}
+	return instantswap.AmountFixed
+}
+
+// setAmountHint updates the hint of the amount editor for the selected amount
+// mode.
+func (osm *orderSchedulerModal) setAmountHint() {
+	var hint string
+	switch osm.amountMode() {
+	case instantswap.AmountPercentage:
+		hint = values.String(values.StrPercentToSwap)
+	case instantswap.AmountAboveThreshold:
+		hint = values.StringF(values.StrBalanceToMaintain, osm.fromCurrency)
+	default:
+		hint = values.StringF(values.StrAmountToSwap, osm.fromCurrency)
+	}
+	osm.amountEditor.Hint = hint
+	osm.amountEditor.TitleLabel.Text = hint
+}
+
+func (osm *orderSchedulerModal) validateAmount() {
+	osm.amountErrorText = ""
+	osm.amountEditor.LineColor = osm.Theme.Color.Gray2
+
+	amountText := osm.amountEditor.Editor.Text()
+	if amountText == "" {
+		return
+	}
+
+	f, err := strconv.ParseFloat(amountText, 64)
+	if err != nil || f < 0 {
+		osm.amountErrorText = values.String(values.StrInvalidAmount)
+		osm.amountEditor.LineColor = osm.Theme.Color.Danger
+		return
+	}
+
+	spendable := osm.sourceAccountSelector.SelectedAccount().Balance.Spendable.ToCoin()
+	switch osm.amountMode() {
+	case instantswap.AmountPercentage:
+		if f == 0 || f > 100 {
+			osm.amountErrorText = values.String(values.StrInvalidAmount)
+		}
+	case instantswap.AmountAboveThreshold:
+		if f >= spendable {
+			osm.amountErrorText = values.String(values.StrInvalidAmount)
+		}
+	default:
+		if f == 0 || f >= spendable {
+			osm.amountErrorText = values.String(values.StrInvalidAmount)
 		}
+	}
 
-		osm.balanceToMaintainErrorText = ""
-		f, err := strconv.ParseFloat(osm.balanceToMaintain.Editor.Text(), 32)
-		if err != nil {
-			osm.balanceToMaintainErrorText = values.String(values.StrInvalidAmount)
-			osm.balanceToMaintain.LineColor = osm.Theme.Color.Danger
-			return
+	if osm.amountErrorText != "" {
+		osm.amountEditor.LineColor = osm.Theme.Color.Danger
+	}
+}
+
+// endConditions returns the optional end conditions entered for the schedule.
+// ok is false if any of them is invalid.
+func (osm *orderSchedulerModal) endConditions() (maxOrders int, maxTotal float64, endTime int64, ok bool) {
+	osm.maxOrdersEditor.SetError("")
+	osm.maxTotalEditor.SetError("")
+	osm.endDateEditor.SetError("")
+	ok = true
+
+	if txt := strings.TrimSpace(osm.maxOrdersEditor.Editor.Text()); txt != "" {
+		n, err := strconv.ParseUint(txt, 10, 32)
+		if err != nil || n == 0 {
+			osm.maxOrdersEditor.SetError(values.String(values.StrInvalidAmount))
+			ok = false
 		}
+		maxOrders = int(n)
+	}
 
-		if f >= osm.sourceAccountSelector.SelectedAccount().Balance.Spendable.ToCoin() || f < 0 {
-			osm.balanceToMaintainErrorText = values.String(values.StrInvalidAmount)
-			osm.balanceToMaintain.LineColor = osm.Theme.Color.Danger
-			return
+	if txt := strings.TrimSpace(osm.maxTotalEditor.Editor.Text()); txt != "" {
+		f, err := strconv.ParseFloat(txt, 64)
+		if err != nil || f <= 0 {
+			osm.maxTotalEditor.SetError(values.String(values.StrInvalidAmount))
+			ok = false
 		}
+		maxTotal = f
 	}
+
+	if txt := strings.TrimSpace(osm.endDateEditor.Editor.Text()); txt != "" {
+		endDate, err := time.ParseInLocation(time.DateOnly, txt, time.Local)
+		if err != nil || !endDate.After(time.Now()) {
+			osm.endDateEditor.SetError(values.String(values.StrInvalidDate))
+			ok = false
+		}
+		endTime = endDate.Unix()
+	}
+
+	return maxOrders, maxTotal, endTime, ok
 }
 
 func (osm *orderSchedulerModal) canStart() bool {
@@ -195,11 +308,19 @@ func (osm *orderSchedulerModal) canStart() bool {
 		return false
 	}
 
-	if osm.balanceToMaintain.Editor.Text() == "" {
+	if strings.TrimSpace(osm.nameEditor.Editor.Text()) == "" {
 		return false
 	}
 
-	if osm.balanceToMaintainErrorText != "" {
+	if osm.amountEditor.Editor.Text() == "" {
+		return false
+	}
+
+	if osm.amountErrorText != "" {
+		return false
+	}
+
+	if _, _, _, ok := osm.endConditions(); !ok {
 		return false
 	}
 
@@ -248,6 +369,9 @@ func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 																	Orientation: layout.Vertical,
 																	Margin:      layout.Inset{Bottom: values.MarginPadding4},
 																}.Layout(gtx,
+																	layout.Rigid(func(gtx C) D {
+																		return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, osm.nameEditor.Layout)
+																	}),
 																	layout.Rigid(func(gtx C) D {
 																		return osm.exchangeSelector.Layout(osm.ParentWindow(), gtx)
 																	}),
@@ -345,11 +469,14 @@ func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 																	Margin:      layout.Inset{Bottom: values.MarginPadding4},
 																}.Layout(gtx,
 																	layout.Rigid(func(gtx C) D {
-																		return osm.balanceToMaintain.Layout(gtx)
+																		return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, osm.amountModeSelector.GroupTileLayout)
 																	}),
 																	layout.Rigid(func(gtx C) D {
-																		if osm.balanceToMaintainErrorText != "" {
-																			txt := osm.Theme.Label(values.TextSize14, osm.balanceToMaintainErrorText)
+																		return osm.amountEditor.Layout(gtx)
+																	}),
+																	layout.Rigid(func(gtx C) D {
+																		if osm.amountErrorText != "" {
+																			txt := osm.Theme.Label(values.TextSize14, osm.amountErrorText)
 																			txt.Font.Weight = font.SemiBold
 																			txt.Color = osm.Theme.Color.Danger
 																			return txt.Layout(gtx)
@@ -359,6 +486,19 @@ func (osm *orderSchedulerModal) Layout(gtx layout.Context) D {
 																)
 															})
 														}),
+														layout.Rigid(func(gtx C) D {
+															return layout.Inset{
+																Bottom: values.MarginPadding16,
+															}.Layout(gtx, func(gtx C) D {
+																return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+																	layout.Rigid(osm.maxOrdersEditor.Layout),
+																	layout.Rigid(func(gtx C) D {
+																		return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, osm.maxTotalEditor.Layout)
+																	}),
+																	layout.Rigid(osm.endDateEditor.Layout),
+																)
+															})
+														}),
 														layout.Rigid(func(gtx C) D {
 															return layout.Inset{
 																Bottom: values.MarginPadding16,
@@ -459,8 +599,10 @@ func (osm *orderSchedulerModal) startOrderScheduler() {
 			return
 		}
 
-		balanceToMaintain, _ := strconv.ParseFloat(osm.balanceToMaintain.Editor.Text(), 32)
-		params := instantswap.SchedulerParams{
+		amount, _ := strconv.ParseFloat(osm.amountEditor.Editor.Text(), 64)
+		maxOrders, maxTotal, endTime, _ := osm.endConditions()
+		schedule := &instantswap.Schedule{
+			Name: strings.TrimSpace(osm.nameEditor.Editor.Text()),
 			Order: instantswap.Order{
 				ExchangeServer:           osm.exchangeSelector.selectedExchange.Server,
 				SourceWalletID:           osm.orderData.sourceWalletID,
@@ -479,27 +621,27 @@ func (osm *orderSchedulerModal) startOrderScheduler() {
 				RefundAddress:      osm.orderData.refundAddress,
 			},
 
-			Frequency:          osm.frequencySelector.selectedFrequency.item,
-			BalanceToMaintain:  balanceToMaintain,
-			SpendingPassphrase: osm.passwordEditor.Editor.Text(),
+			Frequency:  osm.frequencySelector.selectedFrequency.item,
+			AmountMode: osm.amountMode(),
+			Amount:     amount,
+			MaxOrders:  maxOrders,
+			MaxTotal:   maxTotal,
+			EndTime:    endTime,
 		}
 
-		successModal := modal.NewSuccessModal(osm.Load, values.String(values.StrSchedulerRunning), modal.DefaultClickFunc())
-		go func() {
-			err = osm.AssetsManager.StartScheduler(context.Background(), params)
-			if err != nil {
-				// Dismiss the success modal if still displayed before showing
-				// the error modal.
-				successModal.Dismiss()
-
-				errModal := modal.NewErrorModal(osm.Load, values.String(values.StrUnexpectedError), modal.DefaultClickFunc()).
-					Body(values.StringF(values.StrUnexpectedErrorMsgFmt, err.Error()))
-				osm.ParentWindow().ShowModal(errModal)
+		err = osm.AssetsManager.CreateSchedule(schedule, osm.passwordEditor.Editor.Text())
+		osm.setLoading(false)
+		if err != nil {
+			if err.Error() == instantswap.ErrScheduleExists {
+				osm.nameEditor.SetError(values.String(values.StrScheduleExists))
+				return
 			}
-		}()
+			osm.SetError(err.Error())
+			return
+		}
 
 		osm.Dismiss()
-		osm.ParentWindow().ShowModal(successModal)
+		osm.ParentWindow().ShowModal(modal.NewSuccessModal(osm.Load, values.String(values.StrSchedulerRunning), modal.DefaultClickFunc()))
 	}()
 }
 
@@ -513,27 +655,7 @@ func (osm *orderSchedulerModal) fetchInstantExchangeCurrencies() error {
 }
 
 func getNetwork(coinName string, currencies []api.Currency) string {
-	var lowerName = strings.ToLower(coinName)
-	var currency *api.Currency
-	for _, c := range currencies {
-		if strings.ToLower(c.Symbol) == lowerName {
-			currency = &c
-			break
-		}
-	}
-	if currency == nil || len(currency.Networks) == 0 {
-		return ""
-	}
-	for _, network := range currency.Networks {
-		var lowerNetwork = strings.ToLower(network)
-		if lowerNetwork == string(libutils.Mainnet) {
-			return network
-		}
-		if lowerNetwork == lowerName {
-			return network
-		}
-	}
-	return currency.Networks[0]
+	return instantswap.CurrencyNetwork(coinName, currencies)
 }
 
 func (osm *orderSchedulerModal) getExchangeRateInfo() error {
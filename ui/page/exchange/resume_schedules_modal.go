@@ -0,0 +1,114 @@
+package exchange
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// resumeWallet is a source wallet of the schedules to resume, which are
+// resumed with its spending passphrase.
+type resumeWallet struct {
+	wallet         sharedW.Asset
+	schedules      []*instantswap.Schedule
+	passwordEditor cryptomaterial.Editor
+}
+
+// ShowResumeSchedulesModal asks once for the spending passphrases of the
+// source wallets of the active schedules that stopped when the app was closed
+// and resumes them. It does nothing if there is no schedule to resume.
+func ShowResumeSchedulesModal(l *load.Load, window app.WindowNavigator) {
+	schedules, err := l.AssetsManager.SchedulesToResume()
+	if err != nil {
+		log.Errorf("Error loading order schedules: %v", err)
+		return
+	}
+	if len(schedules) == 0 {
+		return
+	}
+
+	var wallets []*resumeWallet
+	byWalletID := make(map[int]*resumeWallet)
+	for _, schedule := range schedules {
+		rw, ok := byWalletID[schedule.Order.SourceWalletID]
+		if !ok {
+			rw = &resumeWallet{
+				wallet:         l.AssetsManager.WalletWithID(schedule.Order.SourceWalletID),
+				passwordEditor: l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword)),
+			}
+			rw.passwordEditor.Editor.SingleLine = true
+			byWalletID[schedule.Order.SourceWalletID] = rw
+			wallets = append(wallets, rw)
+		}
+		rw.schedules = append(rw.schedules, schedule)
+	}
+
+	resumeModal := modal.NewCustomModal(l).
+		Title(values.String(values.StrResumeSchedules)).
+		UseCustomWidget(func(gtx C) D {
+			children := []layout.FlexChild{
+				layout.Rigid(func(gtx C) D {
+					lbl := l.Theme.Body2(values.String(values.StrResumeSchedulesMsg))
+					lbl.Color = l.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}),
+			}
+			for _, rw := range wallets {
+				names := make([]string, 0, len(rw.schedules))
+				for _, schedule := range rw.schedules {
+					names = append(names, schedule.Name)
+				}
+				children = append(children,
+					layout.Rigid(func(gtx C) D {
+						lbl := l.Theme.Body1(rw.wallet.GetWalletName() + ": " + strings.Join(names, ", "))
+						return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, rw.passwordEditor.Layout)
+					}),
+				)
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrSkip)).
+		SetPositiveButtonText(values.String(values.StrResume)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			// The schedules of the wallets whose passphrase is left empty
+			// are not resumed.
+			resumed := true
+			for _, rw := range wallets {
+				password := rw.passwordEditor.Editor.Text()
+				if password == "" || len(rw.schedules) == 0 {
+					continue
+				}
+				rw.passwordEditor.SetError("")
+				if err := rw.wallet.UnlockWallet(password); err != nil {
+					rw.passwordEditor.SetError(values.TranslateErr(err.Error()))
+					resumed = false
+					continue
+				}
+
+				var failed []*instantswap.Schedule
+				for _, schedule := range rw.schedules {
+					if err := l.AssetsManager.StartSchedule(schedule.ID, password); err != nil {
+						log.Errorf("Error resuming schedule %q: %v", schedule.Name, err)
+						rw.passwordEditor.SetError(err.Error())
+						failed = append(failed, schedule)
+					}
+				}
+				rw.schedules = failed
+				resumed = resumed && len(failed) == 0
+			}
+			return resumed
+		})
+	window.ShowModal(resumeModal)
+}
@@ -0,0 +1,400 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	SchedulesPageID = "Schedules"
+
+	// maxDisplayedExecutions is the number of most recent runs displayed in
+	// the execution log of a schedule.
+	maxDisplayedExecutions = 20
+)
+
+// scheduleItem holds the widgets of a schedule listed on the schedules page.
+type scheduleItem struct {
+	schedule   *instantswap.Schedule
+	executions []*instantswap.ScheduleExecution
+	running    bool
+	showLog    bool
+
+	resumeBtn cryptomaterial.Button
+	pauseBtn  cryptomaterial.Button
+	deleteBtn cryptomaterial.Button
+	logBtn    *cryptomaterial.Clickable
+}
+
+// SchedulesPage lists the saved order schedules and allows resuming, pausing
+// and deleting them.
+type SchedulesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	items []*scheduleItem
+}
+
+func NewSchedulesPage(l *load.Load) *SchedulesPage {
+	return &SchedulesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(SchedulesPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+	}
+}
+
+// ID is a unique string that identifies the page and may be used
+// to differentiate this page from other pages.
+// Part of the load.Page interface.
+func (pg *SchedulesPage) ID() string {
+	return SchedulesPageID
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *SchedulesPage) OnNavigatedTo() {
+	pg.loadSchedules()
+
+	reload := func() {
+		pg.loadSchedules()
+		pg.ParentWindow().Reload()
+	}
+	orderNotificationListener := &instantswap.OrderNotificationListener{
+		OnScheduleStarted:  func(_ *instantswap.Schedule) { reload() },
+		OnScheduleStopped:  func(_ *instantswap.Schedule) { reload() },
+		OnScheduleExecuted: func(_ *instantswap.ScheduleExecution) { reload() },
+	}
+	err := pg.AssetsManager.InstantSwap.AddNotificationListener(orderNotificationListener, SchedulesPageID)
+	if err != nil {
+		log.Errorf("Error adding instanswap notification listener: %v", err)
+	}
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *SchedulesPage) OnNavigatedFrom() {
+	pg.AssetsManager.InstantSwap.RemoveNotificationListener(SchedulesPageID)
+}
+
+// loadSchedules reloads the saved schedules, keeping the execution logs that
+// are currently displayed open.
+func (pg *SchedulesPage) loadSchedules() {
+	schedules, err := pg.AssetsManager.InstantSwap.Schedules()
+	if err != nil {
+		log.Errorf("Error loading order schedules: %v", err)
+		return
+	}
+
+	openLogs := make(map[int]bool)
+	for _, item := range pg.items {
+		openLogs[item.schedule.ID] = item.showLog
+	}
+
+	items := make([]*scheduleItem, 0, len(schedules))
+	for _, schedule := range schedules {
+		item := &scheduleItem{
+			schedule:  schedule,
+			running:   pg.AssetsManager.IsScheduleRunning(schedule.ID),
+			showLog:   openLogs[schedule.ID],
+			resumeBtn: pg.Theme.Button(values.String(values.StrResume)),
+			pauseBtn:  pg.Theme.OutlineButton(values.String(values.StrPause)),
+			deleteBtn: pg.Theme.DangerButton(values.String(values.StrDelete)),
+			logBtn:    pg.Theme.NewClickable(false),
+		}
+		if item.showLog {
+			pg.loadExecutions(item)
+		}
+		items = append(items, item)
+	}
+	pg.items = items
+}
+
+func (pg *SchedulesPage) loadExecutions(item *scheduleItem) {
+	executions, err := pg.AssetsManager.InstantSwap.ScheduleExecutions(item.schedule.ID, maxDisplayedExecutions)
+	if err != nil {
+		log.Errorf("Error loading schedule executions: %v", err)
+		return
+	}
+	item.executions = executions
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *SchedulesPage) HandleUserInteractions(gtx C) {
+	for _, item := range pg.items {
+		if item.resumeBtn.Clicked(gtx) {
+			pg.resumeSchedule(item.schedule)
+		}
+
+		if item.pauseBtn.Clicked(gtx) {
+			scheduleID := item.schedule.ID
+			go func() {
+				pg.AssetsManager.StopSchedule(scheduleID)
+				pg.loadSchedules()
+				pg.ParentWindow().Reload()
+			}()
+		}
+
+		if item.deleteBtn.Clicked(gtx) {
+			pg.deleteSchedule(item.schedule)
+		}
+
+		if item.logBtn.Clicked(gtx) {
+			item.showLog = !item.showLog
+			if item.showLog {
+				pg.loadExecutions(item)
+			}
+		}
+	}
+}
+
+func (pg *SchedulesPage) resumeSchedule(schedule *instantswap.Schedule) {
+	sourceWallet := pg.AssetsManager.WalletWithID(schedule.Order.SourceWalletID)
+	if sourceWallet == nil {
+		pg.notifyError(fmt.Sprintf("wallet with id:%d not found", schedule.Order.SourceWalletID))
+		return
+	}
+
+	walletPasswordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrEnterSpendingPassword)).
+		SetDescription(values.StringF(values.StrResumeScheduleMsg, sourceWallet.GetWalletName())).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := sourceWallet.UnlockWallet(password); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			if err := pg.AssetsManager.StartSchedule(schedule.ID, password); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+
+			pg.loadSchedules()
+			return true
+		})
+	pg.ParentWindow().ShowModal(walletPasswordModal)
+}
+
+func (pg *SchedulesPage) deleteSchedule(schedule *instantswap.Schedule) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrDelete)).
+		Body(values.StringF(values.StrDeleteScheduleMsg, schedule.Name)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrDelete)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.AssetsManager.DeleteSchedule(schedule.ID); err != nil {
+				pg.notifyError(err.Error())
+				return true
+			}
+
+			pg.loadSchedules()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+func (pg *SchedulesPage) notifyError(errMsg string) {
+	errModal := modal.NewErrorModal(pg.Load, errMsg, modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(errModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *SchedulesPage) Layout(gtx C) D {
+	sp := components.SubPage{
+		Load:       pg.Load,
+		Title:      values.String(values.StrSchedules),
+		BackButton: pg.backButton,
+		Back: func() {
+			pg.ParentNavigator().CloseCurrentPage()
+		},
+		Body: func(gtx C) D {
+			if len(pg.items) == 0 {
+				return components.LayoutNoOrderHistoryWithMsg(gtx, pg.Load, false, values.String(values.StrNoSchedules))
+			}
+
+			return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.items), func(gtx C, i int) D {
+				return pg.scheduleLayout(gtx, pg.items[i])
+			})
+		},
+	}
+
+	return layout.Inset{Right: values.MarginPadding10, Left: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+		return sp.Layout(pg.ParentWindow(), gtx)
+	})
+}
+
+func (pg *SchedulesPage) scheduleLayout(gtx C, item *scheduleItem) D {
+	schedule := item.schedule
+	fromCur, toCur := strings.ToUpper(schedule.Order.FromCurrency), strings.ToUpper(schedule.Order.ToCurrency)
+
+	statusText := values.String(values.StrScheduleActive)
+	statusColor := pg.Theme.Color.GreenText
+	switch {
+	case schedule.Status == instantswap.SchedulePaused:
+		statusText, statusColor = values.String(values.StrSchedulePaused), pg.Theme.Color.Orange
+	case schedule.Status == instantswap.ScheduleEnded:
+		statusText, statusColor = values.String(values.StrScheduleEnded), pg.Theme.Color.GrayText2
+	case !item.running:
+		statusText, statusColor = values.String(values.StrWaitingForPassphrase), pg.Theme.Color.Orange
+	}
+	if schedule.StatusReason != "" {
+		statusText = fmt.Sprintf("%s: %s", statusText, schedule.StatusReason)
+	}
+
+	nextRun := "--"
+	if schedule.Status == instantswap.ScheduleActive {
+		nextRun = time.Unix(schedule.NextRun, 0).Format(time.DateTime)
+	}
+
+	column := func(title, value string) layout.FlexChild {
+		return layout.Flexed(0.33, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.greyLabel(title).Layout),
+				layout.Rigid(pg.Theme.Label(values.TextSize16, value).Layout),
+			)
+		})
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize16, schedule.Name)
+						lb.Font.Weight = font.SemiBold
+						return lb.Layout(gtx)
+					}),
+					layout.Rigid(pg.greyLabel(fmt.Sprintf("%s → %s · %s", fromCur, toCur, schedule.Order.ExchangeServer.Server.CapFirstLetter())).Layout),
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, statusText)
+						lb.Color = statusColor
+						return lb.Layout(gtx)
+					}),
+				)
+			}, func(gtx C) D {
+				return pg.actionsLayout(gtx, item)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					column(values.String(values.StrNextRun), nextRun),
+					column(values.String(values.StrOrdersCreated), fmt.Sprint(schedule.OrdersCount)),
+					column(values.String(values.StrTotalSwapped), fmt.Sprintf("%s %s", formatQuoteAmount(schedule.TotalAmount), fromCur)),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, values.String(values.StrExecutionLog))
+			lb.Color = pg.Theme.Color.Primary
+			return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return item.logBtn.Layout(gtx, lb.Layout)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !item.showLog {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return pg.executionsLayout(gtx, item.executions, fromCur)
+			})
+		}),
+	)
+}
+
+func (pg *SchedulesPage) actionsLayout(gtx C, item *scheduleItem) D {
+	status := item.schedule.Status
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			switch {
+			case status == instantswap.ScheduleEnded:
+				return D{}
+			case item.running:
+				return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, item.pauseBtn.Layout)
+			}
+			return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, item.resumeBtn.Layout)
+		}),
+		layout.Rigid(item.deleteBtn.Layout),
+	)
+}
+
+func (pg *SchedulesPage) executionsLayout(gtx C, executions []*instantswap.ScheduleExecution, fromCur string) D {
+	if len(executions) == 0 {
+		return pg.greyLabel(values.String(values.StrNoExecutions)).Layout(gtx)
+	}
+
+	children := make([]layout.FlexChild, 0, len(executions))
+	for _, execution := range executions {
+		execution := execution
+		children = append(children, layout.Rigid(func(gtx C) D {
+			var details string
+			detailsColor := pg.Theme.Color.Text
+			if execution.Error != "" {
+				details, detailsColor = execution.Error, pg.Theme.Color.Danger
+			} else {
+				details = fmt.Sprintf("%s %s · %s", formatQuoteAmount(execution.InvoicedAmount), fromCur, execution.OrderUUID)
+			}
+
+			detailsLabel := pg.Theme.Label(values.TextSize14, details)
+			detailsLabel.Color = detailsColor
+			return layout.Inset{Top: values.MarginPadding4, Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Flexed(0.3, pg.greyLabel(pageutils.FormatDateOrTime(execution.Stamp)).Layout),
+					layout.Flexed(0.7, detailsLabel.Layout),
+				)
+			})
+		}))
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (pg *SchedulesPage) greyLabel(txt string) cryptomaterial.Label {
+	lb := pg.Theme.Label(values.TextSize14, txt)
+	lb.Color = pg.Theme.Color.GrayText2
+	return lb
+}
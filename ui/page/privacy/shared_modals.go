@@ -1,6 +1,8 @@
 package privacy
 
 import (
+	"errors"
+
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	"github.com/crypto-power/cryptopower/libwallet/utils"
@@ -117,6 +119,11 @@ func moveFundsFromDefaultToUnmixed(conf *sharedModalConfig, dcrWallet *dcr.Asset
 		return err
 	}
 
+	if !dcrWallet.TryLockTxAuthor() {
+		return errors.New(utils.ErrTxAuthorBusy)
+	}
+	defer dcrWallet.UnlockTxAuthor()
+
 	err = dcrWallet.NewUnsignedTx(sourceAccount.Number, nil)
 	if err != nil {
 		return err
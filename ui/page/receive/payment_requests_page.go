@@ -0,0 +1,455 @@
+package receive
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/payrequests"
+	"github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	uiutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const PaymentRequestsPageID = "PaymentRequests"
+
+// Filters of the payment request list.
+const (
+	filterOpen = iota
+	filterSettled
+	filterAll
+)
+
+// requestStatusNames are the display name keys of the payment request
+// statuses.
+var requestStatusNames = map[payrequests.Status]string{
+	payrequests.StatusOpen:      values.StrRequestOpen,
+	payrequests.StatusUnderpaid: values.StrRequestUnderpaid,
+	payrequests.StatusPaid:      values.StrRequestPaid,
+	payrequests.StatusOverpaid:  values.StrRequestOverpaid,
+	payrequests.StatusExpired:   values.StrRequestExpired,
+}
+
+// requestControls are the widgets of a payment request row.
+type requestControls struct {
+	qrBtn     *cryptomaterial.Clickable
+	removeBtn *cryptomaterial.Clickable
+}
+
+// PaymentRequestsPage creates the payment requests of a wallet and lists its
+// open and settled requests.
+type PaymentRequestsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	accountDropdown *components.AccountDropdown
+	amountEditor    cryptomaterial.Editor
+	memoEditor      cryptomaterial.Editor
+	expiryDropdown  *cryptomaterial.DropDown
+	filterDropdown  *cryptomaterial.DropDown
+	createBtn       cryptomaterial.Button
+
+	// expiries hold the expiry of the expiry dropdown items.
+	expiries []time.Duration
+
+	requests []*payrequests.Request
+	controls map[int]*requestControls
+}
+
+func NewPaymentRequestsPage(l *load.Load, wallet sharedW.Asset) *PaymentRequestsPage {
+	pg := &PaymentRequestsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PaymentRequestsPageID),
+		wallet:           wallet,
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		amountEditor:     l.Theme.Editor(new(widget.Editor), values.String(values.StrAmountOptional)),
+		memoEditor:       l.Theme.Editor(new(widget.Editor), values.String(values.StrMemo)),
+		createBtn:        l.Theme.Button(values.String(values.StrCreate)),
+		expiries:         []time.Duration{0, time.Hour, 24 * time.Hour, 7 * 24 * time.Hour},
+		controls:         make(map[int]*requestControls),
+	}
+	pg.amountEditor.Editor.SingleLine = true
+	pg.memoEditor.Editor.SingleLine = true
+
+	pg.accountDropdown = components.NewAccountDropdown(l).
+		AccountValidator(func(account *sharedW.Account) bool {
+			return account.Number != load.MaxInt32 && account.Number != load.MixedAccountNumber(wallet)
+		}).
+		Setup(wallet)
+
+	expiryItems := []cryptomaterial.DropDownItem{
+		{Text: values.String(values.StrNever)},
+		{Text: values.String(values.StrOneHour)},
+		{Text: values.String(values.StrOneDay)},
+		{Text: values.String(values.StrOneWeek)},
+	}
+	pg.expiryDropdown = l.Theme.NewCommonDropDown(expiryItems, nil, values.MarginPadding180, values.PaymentRequestExpiryDropdownGroup, false)
+
+	filterItems := []cryptomaterial.DropDownItem{
+		{Text: values.String(values.StrOpenRequests)},
+		{Text: values.String(values.StrSettledRequests)},
+		{Text: values.String(values.StrAll)},
+	}
+	pg.filterDropdown = l.Theme.NewCommonDropDown(filterItems, nil, values.MarginPadding180, values.PaymentRequestFilterDropdownGroup, false)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) OnNavigatedTo() {
+	pg.loadRequests()
+
+	requestListener := &payrequests.RequestListener{
+		OnRequestPaid: func(request *payrequests.Request) {
+			if request.WalletID == pg.wallet.GetWalletID() {
+				pg.loadRequests()
+				pg.ParentWindow().Reload()
+			}
+		},
+		OnRequestsChanged: func(walletID int) {
+			if walletID == pg.wallet.GetWalletID() {
+				pg.loadRequests()
+				pg.ParentWindow().Reload()
+			}
+		},
+	}
+	err := pg.AssetsManager.PaymentRequests.AddRequestListener(requestListener, PaymentRequestsPageID)
+	if err != nil {
+		log.Errorf("Error adding payment request listener: %v", err)
+	}
+}
+
+func (pg *PaymentRequestsPage) loadRequests() {
+	requests, err := pg.AssetsManager.PaymentRequests.Requests(pg.wallet.GetWalletID())
+	if err != nil {
+		log.Errorf("Error loading payment requests: %v", err)
+		return
+	}
+
+	filtered := make([]*payrequests.Request, 0, len(requests))
+	for _, request := range requests {
+		switch pg.filterDropdown.SelectedIndex() {
+		case filterOpen:
+			if request.Settled() {
+				continue
+			}
+		case filterSettled:
+			if !request.Settled() {
+				continue
+			}
+		}
+
+		if _, ok := pg.controls[request.ID]; !ok {
+			pg.controls[request.ID] = &requestControls{
+				qrBtn:     pg.Theme.NewClickable(true),
+				removeBtn: pg.Theme.NewClickable(true),
+			}
+		}
+		filtered = append(filtered, request)
+	}
+	pg.requests = filtered
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) HandleUserInteractions(gtx C) {
+	pg.accountDropdown.Handle(gtx)
+
+	if pg.filterDropdown.Changed(gtx) {
+		pg.loadRequests()
+	}
+
+	if pg.createBtn.Clicked(gtx) {
+		pg.createRequest()
+	}
+
+	for _, request := range pg.requests {
+		controls, ok := pg.controls[request.ID]
+		if !ok {
+			continue
+		}
+
+		if controls.qrBtn.Clicked(gtx) {
+			pg.showQRModal(request)
+		}
+
+		if controls.removeBtn.Clicked(gtx) {
+			pg.removeRequest(request)
+		}
+	}
+}
+
+func (pg *PaymentRequestsPage) createRequest() {
+	pg.amountEditor.SetError("")
+
+	account := pg.accountDropdown.SelectedAccount()
+	if account == nil {
+		return
+	}
+
+	var amount float64
+	if amountStr := strings.TrimSpace(pg.amountEditor.Editor.Text()); amountStr != "" {
+		var err error
+		amount, err = strconv.ParseFloat(amountStr, 64)
+		if err != nil || amount < 0 {
+			pg.amountEditor.SetError(values.String(values.StrInvalidAmount))
+			return
+		}
+	}
+
+	expiry := pg.expiries[pg.expiryDropdown.SelectedIndex()]
+	request, err := pg.AssetsManager.CreatePaymentRequest(pg.wallet.GetWalletID(), account.Number, amount, pg.memoEditor.Editor.Text(), expiry)
+	if err != nil {
+		pg.amountEditor.SetError(err.Error())
+		return
+	}
+
+	pg.amountEditor.Editor.SetText("")
+	pg.memoEditor.Editor.SetText("")
+	pg.showQRModal(request)
+}
+
+func (pg *PaymentRequestsPage) showQRModal(request *payrequests.Request) {
+	uri := pg.AssetsManager.PaymentRequestURI(request)
+	qrImage, err := newQRImage(uri, walletLogo(pg.Load, utils.AssetType(request.Asset)))
+	if err != nil {
+		pg.Toast.NotifyError(err.Error())
+		return
+	}
+
+	copyURI := pg.Theme.NewClickable(false)
+	qrModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrPaymentRequests)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					return pg.Theme.ImageIcon(gtx, *qrImage, 200)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+						return components.BrowserURLWidget(gtx, pg.Load, uri, copyURI)
+					})
+				}),
+			)
+		}).
+		SetPositiveButtonText(values.String(values.StrOk))
+	pg.ParentWindow().ShowModal(qrModal)
+}
+
+func (pg *PaymentRequestsPage) removeRequest(request *payrequests.Request) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRemove)).
+		Body(values.StringF(values.StrRemovePaymentRequestMsg, request.Address)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrRemove)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.AssetsManager.PaymentRequests.Delete(request.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return true
+			}
+			delete(pg.controls, request.ID)
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrPaymentRequests),
+			SubTitle:   pg.wallet.GetWalletName(),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.requestForm),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, pg.filterDropdown.Layout)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.requests) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoPaymentRequests)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.requests), func(gtx C, i int) D {
+							return pg.requestLayout(gtx, pg.requests[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *PaymentRequestsPage) requestForm(gtx C) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, values.String(values.StrNewPaymentRequest))
+			lb.Font.Weight = font.SemiBold
+			return lb.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return pg.accountDropdown.Layout(gtx, values.String(values.StrAccount))
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.amountEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.memoEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(pg.Theme.Label(values.TextSize14, values.String(values.StrExpiry)).Layout),
+					layout.Rigid(pg.expiryDropdown.Layout),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.createBtn.Layout)
+		}),
+	)
+}
+
+func (pg *PaymentRequestsPage) requestLayout(gtx C, request *payrequests.Request) D {
+	controls, ok := pg.controls[request.ID]
+	if !ok {
+		return D{}
+	}
+
+	status := request.Status(time.Now())
+	statusColor := pg.Theme.Color.GrayText2
+	switch status {
+	case payrequests.StatusPaid, payrequests.StatusOverpaid:
+		statusColor = pg.Theme.Color.GreenText
+	case payrequests.StatusUnderpaid, payrequests.StatusExpired:
+		statusColor = pg.Theme.Color.Danger
+	}
+
+	title := request.Memo
+	if title == "" {
+		title = request.Address
+	}
+
+	amount := values.String(values.StrAnyAmount)
+	if request.Amount > 0 {
+		amount = pg.wallet.ToAmount(request.Amount).String()
+	}
+	details := []string{amount, uiutils.FormatDateOrTime(request.CreatedAt)}
+	if request.Received > 0 {
+		details[0] = values.StringF(values.StrReceivedOf, pg.wallet.ToAmount(request.Received).String(), amount)
+	}
+	if request.ExpiresAt > 0 && !request.Settled() {
+		details = append(details, values.StringF(values.StrExpiresAt, time.Unix(request.ExpiresAt, 0).Format(time.DateTime)))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize16, title)
+				lb.Font.Weight = font.SemiBold
+				return lb.Layout(gtx)
+			}, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize14, values.String(requestStatusNames[status]))
+				lb.Color = statusColor
+				return lb.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if request.Memo == "" {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, pg.Theme.Label(values.TextSize14, request.Address).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, strings.Join(details, " · "))
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, values.String(values.StrShowQR))
+						lb.Color = pg.Theme.Color.Primary
+						return controls.qrBtn.Layout(gtx, lb.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, values.String(values.StrRemove))
+						lb.Color = pg.Theme.Color.Danger
+						return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return controls.removeBtn.Layout(gtx, lb.Layout)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) OnNavigatedFrom() {
+	pg.AssetsManager.PaymentRequests.RemoveRequestListener(PaymentRequestsPageID)
+}
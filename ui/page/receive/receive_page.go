@@ -47,6 +47,7 @@ type Page struct {
 	currentAddress  string
 	qrImage         *image.Image
 	newAddr, copy   *cryptomaterial.Clickable
+	paymentRequests *cryptomaterial.Clickable
 	info            cryptomaterial.IconButton
 	card            cryptomaterial.Card
 
@@ -78,6 +79,7 @@ func NewReceivePage(l *load.Load, wallet sharedW.Asset) *Page {
 		info:              l.Theme.IconButton(cryptomaterial.MustIcon(widget.NewIcon(icons.ActionInfo))),
 		copy:              l.Theme.NewClickable(false),
 		newAddr:           l.Theme.NewClickable(false),
+		paymentRequests:   l.Theme.NewClickable(false),
 		card:              l.Theme.Card(),
 		backdrop:          new(widget.Clickable),
 		qrCopyButton:      new(widget.Clickable),
@@ -182,37 +184,45 @@ func (pg *Page) OnNavigatedTo() {
 }
 
 func (pg *Page) generateQRForAddress() {
-	qrCode, err := qrcode.New(pg.currentAddress, qrcode.WithLogoImage(pg.getSelectedWalletLogo()))
+	qrImage, err := newQRImage(pg.currentAddress, pg.getSelectedWalletLogo())
 	if err != nil {
 		log.Error("Error generating address qrCode: " + err.Error())
 		return
 	}
+	pg.qrImage = qrImage
+}
 
-	var buff bytes.Buffer
-	err = qrCode.SaveTo(&buff)
+// newQRImage returns the QR code of content with the logo in its center.
+func newQRImage(content string, logo *cryptomaterial.Image) (*image.Image, error) {
+	qrCode, err := qrcode.New(content, qrcode.WithLogoImage(logo))
 	if err != nil {
-		log.Error(err.Error())
-		return
+		return nil, err
+	}
+
+	var buff bytes.Buffer
+	if err = qrCode.SaveTo(&buff); err != nil {
+		return nil, err
 	}
 
 	imgdec, _, err := image.Decode(bytes.NewReader(buff.Bytes()))
 	if err != nil {
-		log.Error(err.Error())
-		return
+		return nil, err
 	}
-
-	pg.qrImage = &imgdec
+	return &imgdec, nil
 }
 
 func (pg *Page) getSelectedWalletLogo() *cryptomaterial.Image {
-	pg.selectedWallet.GetAssetType()
-	switch pg.selectedWallet.GetAssetType() {
+	return walletLogo(pg.Load, pg.selectedWallet.GetAssetType())
+}
+
+func walletLogo(l *load.Load, assetType utils.AssetType) *cryptomaterial.Image {
+	switch assetType {
 	case utils.BTCWalletAsset:
-		return pg.Theme.Icons.CircleBTC
+		return l.Theme.Icons.CircleBTC
 	case utils.DCRWalletAsset:
-		return pg.Theme.Icons.CircleDCR
+		return l.Theme.Icons.CircleDCR
 	case utils.LTCWalletAsset:
-		return pg.Theme.Icons.CircleLTC
+		return l.Theme.Icons.CircleLTC
 	default:
 		return nil
 	}
@@ -365,6 +375,15 @@ func (pg *Page) copyAndNewAddressLayout(gtx C) D {
 			layout.Rigid(func(gtx C) D {
 				return pg.buttonIconLayout(gtx, pg.Theme.NewIcon(pg.Theme.Icons.NavigationRefresh), values.String(values.StrNextAddress), pg.newAddr)
 			}),
+			layout.Rigid(func(gtx C) D {
+				// Payment requests are opened from the wallet page only.
+				if pg.modalLayout != nil {
+					return D{}
+				}
+				return layout.Inset{Left: values.MarginPadding32}.Layout(gtx, func(gtx C) D {
+					return pg.buttonIconLayout(gtx, pg.Theme.NewIcon(pg.Theme.Icons.MenuIcon), values.String(values.StrPaymentRequests), pg.paymentRequests)
+				})
+			}),
 		)
 	})
 }
@@ -465,6 +484,10 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 		pg.isNewAddr = false
 	}
 
+	if pg.paymentRequests.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewPaymentRequestsPage(pg.Load, pg.selectedWallet))
+	}
+
 	if pg.infoButton.Button.Clicked(gtx) {
 		textWithUnit := values.String(values.StrReceive) + " " + string(pg.selectedWallet.GetAssetType())
 		info := modal.NewCustomModal(pg.Load).
@@ -0,0 +1,75 @@
+package root
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/ui/notification"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// listenForNotifications keeps the unread notifications count up to date and
+// posts a desktop notification for each notification that should alert the
+// user.
+func (hp *HomePage) listenForNotifications() {
+	if hp.sysNotifier == nil {
+		sysNotifier, err := notification.NewSystemNotification()
+		if err != nil {
+			log.Errorf("Error creating system notifier: %v", err)
+		}
+		hp.sysNotifier = sysNotifier
+	}
+
+	notificationListener := &notifications.NotificationListener{
+		OnNotification: func(n *notifications.Notification, alert bool) {
+			if alert && hp.sysNotifier != nil {
+				if err := hp.sysNotifier.Notify(components.NotificationText(hp.Load, n)); err != nil {
+					log.Infof("Could not send desktop notification: %v", err)
+				}
+			}
+			hp.updateUnreadNotifications()
+		},
+		OnHistoryChanged: hp.updateUnreadNotifications,
+	}
+	err := hp.AssetsManager.Notifications.AddNotificationListener(notificationListener, HomePageID)
+	if err != nil {
+		log.Errorf("Error adding notification listener: %v", err)
+	}
+	go hp.updateUnreadNotifications()
+}
+
+func (hp *HomePage) updateUnreadNotifications() {
+	unread, err := hp.AssetsManager.Notifications.UnreadCount()
+	if err != nil {
+		log.Errorf("Error counting unread notifications: %v", err)
+		return
+	}
+	hp.unreadNotifications.Store(int32(unread))
+	hp.ParentWindow().Reload()
+}
+
+// notificationButtonLayout draws the notification center button with the
+// number of unread notifications.
+func (hp *HomePage) notificationButtonLayout(gtx C) D {
+	return hp.appNotificationButton.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(hp.Theme.Icons.Notification.Layout20dp),
+			layout.Rigid(func(gtx C) D {
+				unread := hp.unreadNotifications.Load()
+				if unread == 0 {
+					return D{}
+				}
+				count := fmt.Sprint(unread)
+				if unread > 99 {
+					count = "99+"
+				}
+				lb := hp.Theme.Label(values.TextSize12, count)
+				lb.Color = hp.Theme.Color.Danger
+				return layout.Inset{Left: values.MarginPadding2}.Layout(gtx, lb.Layout)
+			}),
+		)
+	})
+}
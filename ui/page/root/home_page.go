@@ -24,7 +24,9 @@ import (
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/notification"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/page/dcrdex"
 	"github.com/crypto-power/cryptopower/ui/page/exchange"
 	"github.com/crypto-power/cryptopower/ui/page/governance"
 	"github.com/crypto-power/cryptopower/ui/page/receive"
@@ -73,6 +75,14 @@ type HomePage struct {
 	updateAvailableBtn *cryptomaterial.Clickable
 	copyRedirectURL    *cryptomaterial.Clickable
 	releaseResponse    *components.ReleaseResponse
+
+	sysNotifier         *notification.SystemNotification
+	unreadNotifications atomic.Int32
+
+	// startupPrompted is set once the user was asked for the full node
+	// passwords, which are not saved, and to resume the swap schedules that
+	// stopped when the app was closed.
+	startupPrompted bool
 }
 
 func NewHomePage(l *load.Load) *HomePage {
@@ -184,6 +194,7 @@ func (hp *HomePage) OnNavigatedTo() {
 	hp.ctx, hp.ctxCancel = context.WithCancel(context.TODO())
 	hp.initPageItems()
 	hp.initDEX()
+	hp.listenForNotifications()
 
 	if hp.CurrentPage() == nil {
 		hp.Display(NewOverviewPage(hp.Load, hp.showNavigationFunc))
@@ -212,6 +223,12 @@ func (hp *HomePage) OnNavigatedTo() {
 	if hp.isUpdateAPIAllowed() {
 		go hp.checkForUpdates()
 	}
+
+	if !hp.startupPrompted {
+		hp.startupPrompted = true
+		components.ShowRPCPasswordsModal(hp.Load, hp.ParentWindow())
+		go exchange.ShowResumeSchedulesModal(hp.Load, hp.ParentWindow())
+	}
 	// When the new tx has been registered
 	hp.AssetsManager.ListenForTxAndBlockNotification(func(walletID int) {
 		go hp.CalculateAssetsUSDBalance()
@@ -242,7 +259,12 @@ func (hp *HomePage) UpdateSubpageWhenHasNewTx(walletID int) {
 // initDEX initializes a new dex client if dex is not ready. If a dex client has
 // never been created before, initDEX will return early and do nothing.
 func (hp *HomePage) initDEX() {
-	if !hp.AssetsManager.DEXDBExists() || hp.AssetsManager.DEXCInitialized() {
+	if hp.AssetsManager.DEXCInitialized() {
+		go dcrdex.MonitorBonds(hp.ctx, hp.Load)
+		return
+	}
+
+	if !hp.AssetsManager.DEXDBExists() {
 		return // do nothing
 	}
 
@@ -257,6 +279,7 @@ func (hp *HomePage) initDEX() {
 
 		// Wait until dex is ready
 		<-dexClient.Ready()
+		go dcrdex.MonitorBonds(hp.ctx, hp.Load)
 
 		activeOrders, _, err := dexClient.ActiveOrders() // we just initialized dexc, no inflight order expected
 		if err != nil {
@@ -426,7 +449,7 @@ func (hp *HomePage) HandleUserInteractions(gtx C) {
 				allWallets := hp.AssetsManager.AllWallets()
 				isSendAvailable := false
 				for _, wallet := range allWallets {
-					if !wallet.IsWatchingOnlyWallet() {
+					if wallet.CanSign() {
 						isSendAvailable = true
 					}
 				}
@@ -453,8 +476,7 @@ func (hp *HomePage) HandleUserInteractions(gtx C) {
 	}
 
 	if hp.appNotificationButton.Clicked(gtx) {
-		// TODO: Use real values as these are dummy so lint will pass
-		hp.ParentNavigator().Display(settings.NewAppSettingsPage(hp.Load))
+		hp.ParentNavigator().Display(NewNotificationCenterPage(hp.Load))
 	}
 
 	for hp.appLevelSettingsButton.Clicked(gtx) {
@@ -550,6 +572,7 @@ func (hp *HomePage) displaySelectedPage(title string) {
 			// Attempt to initialize dex again, only if a dex client was created
 			// in a previous instance.
 			hp.AssetsManager.InitializeDEX()
+			go dcrdex.MonitorBonds(hp.ctx, hp.Load)
 		}
 		pg = exchange.NewTradePage(hp.Load)
 	case values.String(values.StrGovernance):
@@ -610,6 +633,7 @@ func (hp *HomePage) OnNavigatedFrom() {
 	}
 
 	hp.AssetsManager.RemoveAssetChange()
+	hp.AssetsManager.Notifications.RemoveNotificationListener(HomePageID)
 	hp.ctxCancel()
 }
 
@@ -986,14 +1010,12 @@ func (hp *HomePage) notificationSettingsLayout(gtx C) D {
 						}
 						return components.LayoutNavigationBar(gtx, hp.Theme, hp.sendReceiveNavItems)
 					}),
-					// layout.Rigid(func(gtx C) D { // TODO: Uncomment when notifications are implemented
-					// 	return layout.Inset{
-					// 		Left:  values.MarginPadding10,
-					// 		Right: values.MarginPadding10,
-					// 	}.Layout(gtx, func(gtx C) D {
-					// 		return hp.appNotificationButton.Layout(gtx, hp.Theme.Icons.Notification.Layout20dp)
-					// 	})
-					// }),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{
+							Left:  values.MarginPadding10,
+							Right: values.MarginPadding10,
+						}.Layout(gtx, hp.notificationButtonLayout)
+					}),
 					layout.Rigid(func(gtx C) D {
 						return hp.appLevelSettingsButton.Layout(gtx, hp.Theme.Icons.SettingsIcon.Layout20dp)
 					}),
@@ -1111,7 +1133,7 @@ func (hp *HomePage) CalculateAssetsUSDBalance() {
 			totalBalance += balance
 		}
 
-		totalBalanceUSD = utils.FormatAsUSDString(hp.Printer, totalBalance)
+		totalBalanceUSD = utils.FormatUSDAsFiat(hp.Printer, hp.AssetsManager, totalBalance)
 		hp.ParentWindow().Reload()
 	}
 }
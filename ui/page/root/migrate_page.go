@@ -2,15 +2,16 @@ package root
 
 import (
 	"context"
-	"path"
+	"sync"
+	"sync/atomic"
 
 	"gioui.org/layout"
 	"gioui.org/widget"
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
-	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/page/components"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
@@ -22,13 +23,17 @@ const (
 type MigrationPage struct {
 	*app.GenericPageModal
 	*load.Load
-	ctx                  context.Context
-	migrateButton        cryptomaterial.Button
-	cancelButton         cryptomaterial.Button
-	list                 *widget.List
-	scroll               cryptomaterial.ListStyle
-	walletMigrators      []*libwallet.WalletMigrator
-	inputPasswordButtons []cryptomaterial.Button
+	ctx            context.Context
+	migrateButton  cryptomaterial.Button
+	cancelButton   cryptomaterial.Button
+	list           *widget.List
+	scroll         cryptomaterial.ListStyle
+	walletMigrator *libwallet.WalletMigrator
+	wallets        []sharedW.Asset
+
+	migrating    atomic.Bool
+	progressMu   sync.Mutex
+	progressText string
 }
 
 func NewMigrationPage(ctx context.Context, l *load.Load) *MigrationPage {
@@ -42,12 +47,8 @@ func NewMigrationPage(ctx context.Context, l *load.Load) *MigrationPage {
 	}
 	p.scroll = p.Theme.List(p.list)
 
-	allWallet := p.AssetsManager.AllWallets()
-	p.walletMigrators = make([]*libwallet.WalletMigrator, 0)
-	for _, wallet := range allWallet {
-		p.walletMigrators = append(p.walletMigrators, libwallet.NewWalletMigrator(wallet))
-		p.inputPasswordButtons = append(p.inputPasswordButtons, p.Theme.Button(values.String(values.StrMigrateNow)))
-	}
+	p.walletMigrator = libwallet.NewWalletMigrator(p.AssetsManager, libwallet.BadgerDB)
+	p.wallets = p.walletMigrator.Wallets()
 	return p
 }
 
@@ -62,76 +63,36 @@ func (mp *MigrationPage) OnNavigatedFrom() {
 }
 
 func (mp *MigrationPage) HandleUserInteractions(gtx C) {
-	if mp.migrateButton.Clicked(gtx) {
-		err := mp.AssetsManager.RemoveRootDir()
-		if err != nil {
-			log.Error("unable to remove root dir: %v", err)
-		}
-		newmgr, err := libwallet.NewAssetsManager(path.Dir(mp.AssetsManager.RootDir()), mp.AssetsManager.ParamLogDir(), mp.AssetsManager.NetType(), mp.AssetsManager.DEXTestAddr())
-		if err != nil {
-			log.Errorf("Error create new asset manager: %v", err)
-		}
-
-		for _, w := range mp.walletMigrators {
-			err := w.Migrate(newmgr)
-			if err != nil {
-				log.Errorf("Error migrate wallet: %v", err)
-			}
-		}
-
-		mp.AssetsManager = newmgr
-		mp.ParentWindow().ClearStackAndDisplay(NewHomePage(mp.Load))
+	if mp.migrateButton.Clicked(gtx) && mp.migrating.CompareAndSwap(false, true) {
+		mp.migrateButton.SetEnabled(false)
+		mp.cancelButton.SetEnabled(false)
+		go mp.migrate()
 	}
 
-	if mp.cancelButton.Clicked(gtx) {
+	if mp.cancelButton.Clicked(gtx) && !mp.migrating.Load() {
 		mp.ParentWindow().ClearStackAndDisplay(NewHomePage(mp.Load))
 	}
+}
 
-	for i := range mp.inputPasswordButtons {
-		w := mp.walletMigrators[i]
-		if mp.inputPasswordButtons[i].Clicked(gtx) {
-
-			if w.IsWatchingOnlyWallet() {
-				inputSeedModal := modal.NewTextInputModal(mp.Load).
-					Hint(values.String(values.StrInputSeed)).
-					PositiveButtonStyle(mp.Load.Theme.Color.Primary, mp.Load.Theme.Color.InvText).
-					SetPositiveButtonCallback(func(seed string, m *modal.TextInputModal) bool {
-						err := w.SetSeed(seed)
-						if err != nil {
-							m.SetError(err.Error())
-							return false
-						}
-						m.Dismiss()
-						return true
-					})
-				inputSeedModal.Title(values.String(values.StrInputSeed)).
-					SetPositiveButtonText(values.String(values.StrMigrateNow))
-
-				mp.ParentWindow().ShowModal(inputSeedModal)
-			} else {
-				inputPasswordModal := modal.NewCreatePasswordModal(mp.Load).
-					EnableName(false).
-					EnableConfirmPassword(false).
-					Title(values.String(values.StrInputPassword)).
-					PasswordHint(values.String(values.StrInputPassword)).
-					SetNegativeButtonText(values.String(values.StrCancel)).
-					SetNegativeButtonCallback(func() {
-					}).
-					SetCancelable(false).
-					SetPositiveButtonText(values.String(values.StrMigrateNow)).
-					SetPositiveButtonCallback(func(_, password string, m *modal.CreatePasswordModal) bool {
-						err := w.SetPrivatePassphrase(password)
-						if err != nil {
-							m.SetError(err.Error())
-							return false
-						}
-						m.Dismiss()
-						return true
-					})
-				mp.ParentWindow().ShowModal(inputPasswordModal)
-			}
+func (mp *MigrationPage) migrate() {
+	newmgr, err := mp.walletMigrator.Migrate(func(walletName string, copied, total int) {
+		percent := 100
+		if total > 0 {
+			percent = copied * 100 / total
 		}
+		mp.progressMu.Lock()
+		mp.progressText = values.StringF(values.StrMigratingWallet, walletName, percent)
+		mp.progressMu.Unlock()
+		mp.ParentWindow().Reload()
+	})
+	if newmgr != nil {
+		mp.AssetsManager = newmgr
+	}
+	if err != nil {
+		log.Errorf("Error migrating wallets: %v", err)
+		mp.Toast.NotifyError(values.StringF(values.StrMigrationFailed, err))
 	}
+	mp.ParentWindow().ClearStackAndDisplay(NewHomePage(mp.Load))
 }
 
 func (mp *MigrationPage) Layout(gtx C) D {
@@ -166,40 +127,40 @@ func (mp *MigrationPage) headerLayout(gtx C) D {
 func (mp *MigrationPage) bodyLayout(gtx C) D {
 	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 		layout.Flexed(1, func(gtx C) D {
-			return mp.scroll.Layout(gtx, len(mp.walletMigrators), func(gtx C, i int) D {
-				w := mp.walletMigrators[i]
-				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			return mp.scroll.Layout(gtx, len(mp.wallets), func(gtx C, i int) D {
+				w := mp.wallets[i]
+				return cryptomaterial.LinearLayout{
+					Orientation: layout.Vertical,
+					Width:       cryptomaterial.MatchParent,
+					Height:      cryptomaterial.WrapContent,
+					Background:  mp.Theme.Color.Surface,
+					Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
+					Padding:     layout.UniformInset(values.MarginPadding15),
+					Margin:      layout.Inset{Bottom: values.MarginPadding5},
+				}.Layout(gtx,
 					layout.Rigid(func(gtx C) D {
-						return cryptomaterial.LinearLayout{
-							Orientation: layout.Vertical,
-							Width:       cryptomaterial.MatchParent,
-							Height:      cryptomaterial.WrapContent,
-							Background:  mp.Theme.Color.Surface,
-							Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(14)},
-							Padding:     layout.UniformInset(values.MarginPadding15),
-							Margin:      layout.Inset{Bottom: values.MarginPadding5},
-						}.Layout(gtx,
+						return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
 							layout.Rigid(func(gtx C) D {
-								return layout.Flex{Axis: layout.Horizontal, Spacing: layout.SpaceBetween}.Layout(gtx,
-									layout.Rigid(func(gtx C) D {
-										return layout.Inset{Right: values.MarginPadding5}.Layout(gtx, components.CoinImageBySymbol(mp.Load, w.GetAssetType(), w.IsWatchingOnlyWallet()).Layout24dp)
-									}),
-									layout.Flexed(1, func(gtx C) D {
-										return mp.Theme.Label(values.TextSize14, w.GetWalletName()).Layout(gtx)
-									}),
-									layout.Rigid(func(gtx C) D {
-										if w.GetIsMigrate() {
-											return mp.Theme.Body1(values.String(values.StrWillMigrate)).Layout(gtx)
-										}
-										return mp.inputPasswordButtons[i].Layout(gtx)
-									}),
-								)
+								return layout.Inset{Right: values.MarginPadding5}.Layout(gtx, components.CoinImageBySymbol(mp.Load, w.GetAssetType(), w.IsWatchingOnlyWallet()).Layout24dp)
 							}),
+							layout.Flexed(1, func(gtx C) D {
+								return mp.Theme.Label(values.TextSize14, w.GetWalletName()).Layout(gtx)
+							}),
+							layout.Rigid(mp.Theme.Body1(values.String(values.StrWillMigrate)).Layout),
 						)
 					}),
 				)
 			})
 		}),
+		layout.Rigid(func(gtx C) D {
+			mp.progressMu.Lock()
+			progressText := mp.progressText
+			mp.progressMu.Unlock()
+			if progressText == "" {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding10, Bottom: values.MarginPadding10}.Layout(gtx, mp.Theme.Body1(progressText).Layout)
+		}),
 		layout.Rigid(func(gtx C) D {
 			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
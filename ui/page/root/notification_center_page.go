@@ -0,0 +1,222 @@
+package root
+
+import (
+	"fmt"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	NotificationCenterPageID = "NotificationCenter"
+
+	// notificationHistoryLimit is the number of notifications displayed.
+	notificationHistoryLimit = 200
+)
+
+// NotificationCenterPage displays the notification history.
+type NotificationCenterPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+	markAllReadBtn  cryptomaterial.Button
+	clearBtn        cryptomaterial.Button
+	rulesBtn        cryptomaterial.Button
+
+	history    []*notifications.Notification
+	clickables map[int]*cryptomaterial.Clickable
+}
+
+func NewNotificationCenterPage(l *load.Load) *NotificationCenterPage {
+	pg := &NotificationCenterPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(NotificationCenterPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		markAllReadBtn:   l.Theme.OutlineButton(values.String(values.StrMarkAllRead)),
+		clearBtn:         l.Theme.OutlineButton(values.String(values.StrClearAll)),
+		rulesBtn:         l.Theme.Button(values.String(values.StrNotificationRules)),
+		clickables:       make(map[int]*cryptomaterial.Clickable),
+	}
+	pg.clearBtn.Color = l.Theme.Color.Danger
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *NotificationCenterPage) OnNavigatedTo() {
+	pg.loadHistory()
+
+	notificationListener := &notifications.NotificationListener{
+		OnNotification: func(_ *notifications.Notification, _ bool) {
+			pg.loadHistory()
+			pg.ParentWindow().Reload()
+		},
+		OnHistoryChanged: func() {
+			pg.loadHistory()
+			pg.ParentWindow().Reload()
+		},
+	}
+	err := pg.AssetsManager.Notifications.AddNotificationListener(notificationListener, NotificationCenterPageID)
+	if err != nil {
+		log.Errorf("Error adding notification listener: %v", err)
+	}
+}
+
+func (pg *NotificationCenterPage) loadHistory() {
+	history, err := pg.AssetsManager.Notifications.History(notificationHistoryLimit)
+	if err != nil {
+		log.Errorf("Error loading notification history: %v", err)
+		return
+	}
+
+	for _, n := range history {
+		if _, ok := pg.clickables[n.ID]; !ok {
+			pg.clickables[n.ID] = pg.Theme.NewClickable(true)
+		}
+	}
+	pg.history = history
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *NotificationCenterPage) HandleUserInteractions(gtx C) {
+	if pg.markAllReadBtn.Clicked(gtx) {
+		if err := pg.AssetsManager.Notifications.MarkAllRead(); err != nil {
+			pg.Toast.NotifyError(err.Error())
+		}
+	}
+
+	if pg.clearBtn.Clicked(gtx) {
+		if err := pg.AssetsManager.Notifications.Clear(); err != nil {
+			pg.Toast.NotifyError(err.Error())
+		}
+	}
+
+	if pg.rulesBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewNotificationRulesPage(pg.Load))
+	}
+
+	for _, n := range pg.history {
+		if clickable, ok := pg.clickables[n.ID]; ok && clickable.Clicked(gtx) && !n.Read {
+			if err := pg.AssetsManager.Notifications.MarkRead(n.ID); err != nil {
+				log.Errorf("Error marking notification %d as read: %v", n.ID, err)
+			}
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *NotificationCenterPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrNotifications),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+								layout.Rigid(pg.rulesBtn.Layout),
+								layout.Flexed(1, func(gtx C) D {
+									return layout.E.Layout(gtx, func(gtx C) D {
+										return layout.Flex{}.Layout(gtx,
+											layout.Rigid(func(gtx C) D {
+												return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, pg.markAllReadBtn.Layout)
+											}),
+											layout.Rigid(pg.clearBtn.Layout),
+										)
+									})
+								}),
+							)
+						})
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.history) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoNotifications)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.history), func(gtx C, i int) D {
+							return pg.notificationLayout(gtx, pg.history[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *NotificationCenterPage) notificationLayout(gtx C, n *notifications.Notification) D {
+	clickable, ok := pg.clickables[n.ID]
+	if !ok {
+		return D{}
+	}
+
+	return layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+		return cryptomaterial.LinearLayout{
+			Width:       cryptomaterial.MatchParent,
+			Height:      cryptomaterial.WrapContent,
+			Padding:     layout.UniformInset(values.MarginPadding16),
+			Background:  pg.Theme.Color.Surface,
+			Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+			Orientation: layout.Vertical,
+			Clickable:   clickable,
+		}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize16, components.NotificationText(pg.Load, n))
+				if !n.Read {
+					lb.Font.Weight = font.SemiBold
+				}
+				return lb.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				eventName := values.String(components.NotificationEventNames[n.Type])
+				lb := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s · %s", eventName, utils.FormatDateOrTime(n.Stamp)))
+				lb.Color = pg.Theme.Color.GrayText2
+				return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+			}),
+		)
+	})
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *NotificationCenterPage) OnNavigatedFrom() {
+	pg.AssetsManager.Notifications.RemoveNotificationListener(NotificationCenterPageID)
+}
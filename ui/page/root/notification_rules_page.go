@@ -0,0 +1,330 @@
+package root
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/notifications"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const NotificationRulesPageID = "NotificationRules"
+
+// NotificationRulesPage lists the notification rules and allows adding and
+// removing rules.
+type NotificationRulesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	walletDropdown   *cryptomaterial.DropDown
+	eventDropdown    *cryptomaterial.DropDown
+	muteCheckBox     cryptomaterial.CheckBoxStyle
+	minAmountEditor  cryptomaterial.Editor
+	quietFromEditor  cryptomaterial.Editor
+	quietUntilEditor cryptomaterial.Editor
+	addRuleBtn       cryptomaterial.Button
+
+	// walletIDs and eventTypes hold the rule values of the dropdown items.
+	walletIDs  []int
+	eventTypes []notifications.EventType
+
+	rules      []*notifications.Rule
+	removeBtns map[int]*cryptomaterial.Clickable
+}
+
+func NewNotificationRulesPage(l *load.Load) *NotificationRulesPage {
+	pg := &NotificationRulesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(NotificationRulesPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		muteCheckBox:     l.Theme.CheckBox(new(widget.Bool), values.String(values.StrMuteNotifications)),
+		minAmountEditor:  l.Theme.Editor(new(widget.Editor), values.String(values.StrMinAmount)),
+		quietFromEditor:  l.Theme.Editor(new(widget.Editor), values.String(values.StrQuietFrom)),
+		quietUntilEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrQuietUntil)),
+		addRuleBtn:       l.Theme.Button(values.String(values.StrAddRule)),
+		removeBtns:       make(map[int]*cryptomaterial.Clickable),
+	}
+	pg.minAmountEditor.Editor.SingleLine = true
+	pg.quietFromEditor.Editor.SingleLine = true
+	pg.quietUntilEditor.Editor.SingleLine = true
+
+	walletItems := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAllWallets)}}
+	pg.walletIDs = []int{0}
+	for _, wallet := range l.AssetsManager.AllWallets() {
+		walletItems = append(walletItems, cryptomaterial.DropDownItem{Text: wallet.GetWalletName()})
+		pg.walletIDs = append(pg.walletIDs, wallet.GetWalletID())
+	}
+	pg.walletDropdown = l.Theme.NewCommonDropDown(walletItems, nil, values.MarginPadding180, values.NotificationWalletDropdownGroup, false)
+
+	eventItems := []cryptomaterial.DropDownItem{{Text: values.String(values.StrAllEvents)}}
+	pg.eventTypes = []notifications.EventType{""}
+	for _, eventType := range notifications.EventTypes {
+		eventItems = append(eventItems, cryptomaterial.DropDownItem{Text: values.String(components.NotificationEventNames[eventType])})
+		pg.eventTypes = append(pg.eventTypes, eventType)
+	}
+	pg.eventDropdown = l.Theme.NewCommonDropDown(eventItems, nil, values.MarginPadding180, values.NotificationEventDropdownGroup, false)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *NotificationRulesPage) OnNavigatedTo() {
+	pg.loadRules()
+}
+
+func (pg *NotificationRulesPage) loadRules() {
+	rules, err := pg.AssetsManager.Notifications.Rules()
+	if err != nil {
+		log.Errorf("Error loading notification rules: %v", err)
+		return
+	}
+
+	for _, rule := range rules {
+		if _, ok := pg.removeBtns[rule.ID]; !ok {
+			pg.removeBtns[rule.ID] = pg.Theme.NewClickable(true)
+		}
+	}
+	pg.rules = rules
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *NotificationRulesPage) HandleUserInteractions(gtx C) {
+	if pg.addRuleBtn.Clicked(gtx) {
+		pg.addRule()
+	}
+
+	for id, removeBtn := range pg.removeBtns {
+		if removeBtn.Clicked(gtx) {
+			if err := pg.AssetsManager.Notifications.DeleteRule(id); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				continue
+			}
+			delete(pg.removeBtns, id)
+			pg.loadRules()
+		}
+	}
+}
+
+func (pg *NotificationRulesPage) addRule() {
+	pg.minAmountEditor.SetError("")
+	pg.quietFromEditor.SetError("")
+	pg.quietUntilEditor.SetError("")
+
+	rule := &notifications.Rule{
+		WalletID: pg.walletIDs[pg.walletDropdown.SelectedIndex()],
+		Type:     pg.eventTypes[pg.eventDropdown.SelectedIndex()],
+		Muted:    pg.muteCheckBox.CheckBox.Value,
+	}
+
+	if amount := strings.TrimSpace(pg.minAmountEditor.Editor.Text()); amount != "" {
+		minAmount, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			pg.minAmountEditor.SetError(values.String(values.StrInvalidAmount))
+			return
+		}
+		rule.MinAmount = minAmount
+	}
+
+	var err error
+	if rule.QuietStart, err = parseClock(pg.quietFromEditor.Editor.Text()); err != nil {
+		pg.quietFromEditor.SetError(values.String(values.StrInvalidTime))
+		return
+	}
+	if rule.QuietEnd, err = parseClock(pg.quietUntilEditor.Editor.Text()); err != nil {
+		pg.quietUntilEditor.SetError(values.String(values.StrInvalidTime))
+		return
+	}
+
+	if err := pg.AssetsManager.Notifications.SaveRule(rule); err != nil {
+		pg.minAmountEditor.SetError(err.Error())
+		return
+	}
+
+	pg.muteCheckBox.CheckBox.Value = false
+	pg.minAmountEditor.Editor.SetText("")
+	pg.quietFromEditor.Editor.SetText("")
+	pg.quietUntilEditor.Editor.SetText("")
+	pg.loadRules()
+}
+
+// parseClock returns the minutes since midnight of a HH:MM time. An empty
+// time is midnight.
+func parseClock(clock string) (int, error) {
+	clock = strings.TrimSpace(clock)
+	if clock == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *NotificationRulesPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrNotificationRules),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(pg.ruleForm),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.rules) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoRules)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.rules), func(gtx C, i int) D {
+							return pg.ruleLayout(gtx, pg.rules[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *NotificationRulesPage) ruleForm(gtx C) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Bottom: values.MarginPadding16},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Rigid(pg.walletDropdown.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.eventDropdown.Layout)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.muteCheckBox.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.minAmountEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Flexed(0.5, pg.quietFromEditor.Layout),
+					layout.Flexed(0.5, func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.quietUntilEditor.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.addRuleBtn.Layout)
+		}),
+	)
+}
+
+func (pg *NotificationRulesPage) ruleLayout(gtx C, rule *notifications.Rule) D {
+	walletName := values.String(values.StrAllWallets)
+	if rule.WalletID != 0 {
+		if wallet := pg.AssetsManager.WalletWithID(rule.WalletID); wallet != nil {
+			walletName = wallet.GetWalletName()
+		}
+	}
+	eventName := values.String(values.StrAllEvents)
+	if rule.Type != "" {
+		eventName = values.String(components.NotificationEventNames[rule.Type])
+	}
+
+	var details []string
+	if rule.Muted {
+		details = append(details, values.String(values.StrMuteNotifications))
+	}
+	if rule.MinAmount > 0 {
+		details = append(details, fmt.Sprintf("%s: %s", values.String(values.StrMinAmount), strconv.FormatFloat(rule.MinAmount, 'f', -1, 64)))
+	}
+	if rule.QuietStart != rule.QuietEnd {
+		details = append(details, values.StringF(values.StrQuietHours, formatClock(rule.QuietStart), formatClock(rule.QuietEnd)))
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize16, fmt.Sprintf("%s · %s", walletName, eventName))
+				lb.Font.Weight = font.SemiBold
+				return lb.Layout(gtx)
+			}, func(gtx C) D {
+				removeBtn, ok := pg.removeBtns[rule.ID]
+				if !ok {
+					return D{}
+				}
+				lb := pg.Theme.Label(values.TextSize14, values.String(values.StrRemove))
+				lb.Color = pg.Theme.Color.Danger
+				return removeBtn.Layout(gtx, lb.Layout)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, strings.Join(details, " · "))
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *NotificationRulesPage) OnNavigatedFrom() {}
@@ -5,6 +5,7 @@ import (
 	"image/color"
 	"sort"
 	"strings"
+	"sync"
 
 	"gioui.org/font"
 	"gioui.org/layout"
@@ -22,6 +23,7 @@ import (
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
 	"github.com/crypto-power/cryptopower/libwallet/ext"
 	"github.com/crypto-power/cryptopower/libwallet/instantswap"
+	"github.com/crypto-power/cryptopower/libwallet/portfolio"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -92,6 +94,13 @@ type OverviewPage struct {
 	showNavigationFunc showNavigationFunc
 
 	listInfoWallets []*components.WalletSyncInfo
+
+	portfolioRangeSelector *cryptomaterial.SegmentedControl
+	portfolioAssetSelector *cryptomaterial.SegmentedControl
+	portfolioChart         *cryptomaterial.LineChart
+	portfolioMtx           sync.RWMutex
+	portfolioAssets        []*portfolio.Series
+	portfolioTotal         *portfolio.Series
 }
 
 type assetBalanceSliderItem struct {
@@ -210,6 +219,7 @@ func NewOverviewPage(l *load.Load, showNavigationFunc showNavigationFunc) *Overv
 	pg.stakes = make([]*multiWalletTx, 0)
 	pg.transactions = make([]*multiWalletTx, 0)
 	pg.initInfoWallets()
+	pg.initPortfolioChart()
 
 	return pg
 }
@@ -232,6 +242,7 @@ func (pg *OverviewPage) OnNavigatedTo() {
 		go pg.updateAssetsUSDBalance()
 	}
 	go pg.loadTransactions()
+	go pg.loadPortfolioHistory()
 
 	pg.proposalItems = components.LoadProposals(pg.Load, libwallet.ProposalCategoryAll, 0, 3, true, "")
 	pg.orders = components.LoadOrders(pg.Load, 0, 3, true, "", "")
@@ -250,6 +261,8 @@ func (pg *OverviewPage) OnNavigatedTo() {
 // displayed.
 // Part of the load.Page interface.
 func (pg *OverviewPage) HandleUserInteractions(gtx C) {
+	pg.handlePortfolioInteractions()
+
 	if pg.assetBalanceSlider.Clicked() {
 		walPage := NewWalletSelectorPage(pg.Load)
 		walPage.showNavigationFunc = pg.showNavigationFunc
@@ -340,6 +353,7 @@ func (pg *OverviewPage) OnNavigatedFrom() {
 
 func (pg *OverviewPage) OnCurrencyChanged() {
 	go pg.updateAssetsUSDBalance()
+	go pg.loadPortfolioHistory()
 }
 
 func (pg *OverviewPage) reload() {
@@ -367,6 +381,7 @@ func (pg *OverviewPage) layoutDesktop(gtx C) D {
 	pageContent := []func(gtx C) D{
 		pg.sliderLayout,
 		pg.infoWalletLayout,
+		pg.portfolioHistory,
 		pg.marketOverview,
 		pg.txStakingSection,
 		pg.recentTrades,
@@ -390,6 +405,7 @@ func (pg *OverviewPage) layoutMobile(gtx C) D {
 	pageContent := []func(gtx C) D{
 		pg.sliderLayout,
 		pg.infoWalletLayout,
+		pg.portfolioHistory,
 		pg.mobileMarketOverview,
 		pg.txStakingSection,
 		pg.recentProposal,
@@ -792,7 +808,7 @@ func (pg *OverviewPage) mobileMarketOverview(gtx C) D {
 									}),
 									layout.Rigid(func(gtx C) D {
 										return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
-											txt := pg.Theme.Label(values.TextSize16, pageutils.FormatAsUSDString(pg.Printer, rate.LastTradePrice))
+											txt := pg.Theme.Label(values.TextSize16, pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, rate.LastTradePrice))
 											txt.Color = pg.Theme.Color.Text
 											return txt.Layout(gtx)
 										})
@@ -909,7 +925,7 @@ func (pg *OverviewPage) marketTableRows(gtx C, asset assetMarketData, rate *ext.
 			Alignment: layout.Middle,
 		}.Layout(gtx,
 			layout.Flexed(.785, func(gtx C) D {
-				return layout.E.Layout(gtx, pg.assetTableLabel(pageutils.FormatAsUSDString(pg.Printer, rate.LastTradePrice), pg.Theme.Color.Text))
+				return layout.E.Layout(gtx, pg.assetTableLabel(pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, rate.LastTradePrice), pg.Theme.Color.Text))
 			}),
 			layout.Flexed(.215, func(gtx C) D {
 				hasRateChange := rate.PriceChangePercent != nil
@@ -1138,7 +1154,7 @@ func (pg *OverviewPage) updateAssetsUSDBalance() {
 		}
 
 		toUSDString := func(balance float64) string {
-			return pageutils.FormatAsUSDString(pg.Printer, balance)
+			return pageutils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, balance)
 		}
 
 		for assetType, balance := range assetsTotalUSDBalance {
@@ -0,0 +1,134 @@
+package root
+
+import (
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/libwallet/portfolio"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	pageutils "github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// initPortfolioChart creates the portfolio history selectors. The asset
+// selector lists the total portfolio value and the assets with a wallet.
+func (pg *OverviewPage) initPortfolioChart() {
+	ranges := make([]string, 0, len(portfolio.Ranges))
+	for _, rng := range portfolio.Ranges {
+		ranges = append(ranges, string(rng))
+	}
+
+	assets := []string{values.StrTotal}
+	for _, asset := range pg.AssetsManager.AllAssetTypes() {
+		if len(pg.AssetsManager.AssetWallets(asset)) > 0 {
+			assets = append(assets, asset.String())
+		}
+	}
+
+	btnPadding := layout.Inset{Top: values.MarginPadding8, Right: values.MarginPadding20, Left: values.MarginPadding20, Bottom: values.MarginPadding8}
+	pg.portfolioRangeSelector = pg.Theme.SegmentedControl(ranges, cryptomaterial.SegmentTypeGroup)
+	pg.portfolioRangeSelector.Padding = btnPadding
+	pg.portfolioRangeSelector.SetSelectedSegment(string(portfolio.RangeMonth))
+	pg.portfolioAssetSelector = pg.Theme.SegmentedControl(assets, cryptomaterial.SegmentTypeGroup)
+	pg.portfolioAssetSelector.Padding = btnPadding
+	pg.portfolioChart = pg.Theme.LineChart()
+	pg.portfolioChart.FormatValue = func(v float64) string {
+		if pg.portfolioAssetSelector.SelectedSegment() == values.StrTotal {
+			return pageutils.FormatAsFiatString(pg.Printer, pg.AssetsManager.GetFiatCurrency(), v)
+		}
+		return pg.Printer.Sprintf("%.4f %s", v, pg.portfolioAssetSelector.SelectedSegment())
+	}
+}
+
+// loadPortfolioHistory rebuilds the portfolio history of the selected range.
+// Must be called from a goroutine.
+func (pg *OverviewPage) loadPortfolioHistory() {
+	rng := portfolio.Range(pg.portfolioRangeSelector.SelectedSegment())
+	assets, total, err := pg.AssetsManager.PortfolioHistory(rng, pg.AssetsManager.GetFiatCurrency())
+	if err != nil {
+		log.Errorf("Error loading portfolio history: %v", err)
+		return
+	}
+
+	pg.portfolioMtx.Lock()
+	pg.portfolioAssets, pg.portfolioTotal = assets, total
+	pg.portfolioMtx.Unlock()
+
+	pg.updatePortfolioChart()
+	pg.ParentWindow().Reload()
+}
+
+// updatePortfolioChart draws the series of the selected asset. The total
+// series is drawn in fiat and the asset series in coins.
+func (pg *OverviewPage) updatePortfolioChart() {
+	pg.portfolioMtx.RLock()
+	defer pg.portfolioMtx.RUnlock()
+
+	selected := pg.portfolioAssetSelector.SelectedSegment()
+	if selected == values.StrTotal {
+		if pg.portfolioTotal == nil {
+			pg.portfolioChart.SetPoints(nil)
+			return
+		}
+		pg.portfolioChart.SetPoints(linePoints(pg.portfolioTotal, true))
+		return
+	}
+
+	for _, series := range pg.portfolioAssets {
+		if series.Asset == selected {
+			pg.portfolioChart.SetPoints(linePoints(series, false))
+			return
+		}
+	}
+	pg.portfolioChart.SetPoints(nil)
+}
+
+func linePoints(series *portfolio.Series, fiat bool) []cryptomaterial.LinePoint {
+	points := make([]cryptomaterial.LinePoint, 0, len(series.Points))
+	for _, p := range series.Points {
+		value := p.Balance
+		if fiat {
+			value = p.Fiat
+		}
+		points = append(points, cryptomaterial.LinePoint{Stamp: p.Stamp, Value: value})
+	}
+	return points
+}
+
+func (pg *OverviewPage) handlePortfolioInteractions() {
+	if pg.portfolioRangeSelector.Changed() {
+		go pg.loadPortfolioHistory()
+	}
+
+	if pg.portfolioAssetSelector.Changed() {
+		pg.updatePortfolioChart()
+	}
+}
+
+func (pg *OverviewPage) portfolioHistory(gtx C) D {
+	if pg.portfolioAssetSelector == nil {
+		return D{}
+	}
+
+	return pg.pageContentWrapper(gtx, values.String(values.StrPortfolioHistory), nil, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(pg.portfolioAssetSelector.GroupTileLayout),
+					layout.Flexed(1, func(gtx C) D {
+						return layout.E.Layout(gtx, pg.portfolioRangeSelector.GroupTileLayout)
+					}),
+				)
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					if !pg.portfolioChart.HasData() {
+						return layout.Center.Layout(gtx, func(gtx C) D {
+							return layout.Inset{Top: values.MarginPadding20, Bottom: values.MarginPadding20}.Layout(gtx, pg.Theme.Body2(values.String(values.StrNoChartData)).Layout)
+						})
+					}
+					return pg.portfolioChart.Layout(gtx)
+				})
+			}),
+		)
+	})
+}
@@ -0,0 +1,147 @@
+package root
+
+import (
+	"gioui.org/font"
+	"gioui.org/layout"
+
+	"github.com/crypto-power/cryptopower/libwallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+// issueTitles are the titles of the problems of the wallet diagnosis.
+var issueTitles = map[libwallet.WalletIssue]string{
+	libwallet.IssueLoadFailed:        values.StrIssueLoadFailed,
+	libwallet.IssueUnknownAsset:      values.StrIssueUnknownAsset,
+	libwallet.IssueWalletDbMissing:   values.StrIssueWalletDbMissing,
+	libwallet.IssueWalletDbCorrupt:   values.StrIssueWalletDbCorrupt,
+	libwallet.IssueMissingBuckets:    values.StrIssueMissingBuckets,
+	libwallet.IssueWalletDataCorrupt: values.StrIssueWalletDataCorrupt,
+	libwallet.IssueStaleTxIndex:      values.StrIssueStaleTxIndex,
+	libwallet.IssueOrphanedConfig:    values.StrIssueOrphanedConfig,
+}
+
+// repairTitles are the titles and confirmation messages of the repairs of the
+// wallet diagnosis. The restore from seed is confirmed with the passphrase.
+var repairTitles = map[libwallet.RepairAction][2]string{
+	libwallet.RepairReindex:              {values.StrRepairReindex, values.StrRepairReindexMsg},
+	libwallet.RepairDropFilterCache:      {values.StrRepairDropFilterCache, values.StrRepairDropFilterCacheMsg},
+	libwallet.RepairRestoreFromSeed:      {values.StrRepairRestoreFromSeed, ""},
+	libwallet.RepairRemoveOrphanedConfig: {values.StrRepairRemoveOrphanedConfig, values.StrRepairRemoveOrphanedConfigMsg},
+}
+
+type repairButton struct {
+	repair libwallet.RepairAction
+	button cryptomaterial.Button
+}
+
+// diagnoseBadWallet shows the report of the diagnosis of the bad wallet, with
+// the repairs of the problems found.
+func (pg *WalletSelectorPage) diagnoseBadWallet(badWalletID int) {
+	diagnosis, err := pg.AssetsManager.DiagnoseBadWallet(badWalletID)
+	if err != nil {
+		errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(errorModal)
+		return
+	}
+
+	var repairButtons []*repairButton
+	for _, repair := range diagnosis.Repairs() {
+		btn := pg.Theme.OutlineButton(values.String(repairTitles[repair][0]))
+		btn.Inset = layout.UniformInset(values.MarginPadding8)
+		repairButtons = append(repairButtons, &repairButton{repair: repair, button: btn})
+	}
+
+	diagnosisModal := modal.NewCustomModal(pg.Load).
+		Title(values.StringF(values.StrWalletDiagnosis, diagnosis.WalletName)).
+		SetPositiveButtonText(values.String(values.StrClose))
+	diagnosisModal.UseCustomWidget(func(gtx C) D {
+		for _, rb := range repairButtons {
+			if rb.button.Clicked(gtx) {
+				diagnosisModal.Dismiss()
+				pg.repairBadWallet(diagnosis.WalletID, rb.repair)
+			}
+		}
+
+		var children []layout.FlexChild
+		if diagnosis.Healthy() {
+			children = append(children, layout.Rigid(pg.Theme.Body2(values.String(values.StrNoWalletProblems)).Layout))
+		}
+		for _, problem := range diagnosis.Problems {
+			problem := problem
+			children = append(children, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							lbl := pg.Theme.Body1(values.String(issueTitles[problem.Issue]))
+							lbl.Font.Weight = font.SemiBold
+							return lbl.Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							lbl := pg.Theme.Body2(problem.Detail)
+							lbl.Color = pg.Theme.Color.GrayText2
+							return lbl.Layout(gtx)
+						}),
+					)
+				})
+			}))
+		}
+		for _, rb := range repairButtons {
+			rb := rb
+			children = append(children, layout.Rigid(func(gtx C) D {
+				return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, rb.button.Layout)
+			}))
+		}
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+	})
+	pg.ParentWindow().ShowModal(diagnosisModal)
+}
+
+// repairBadWallet confirms and runs the repair of the bad wallet.
+func (pg *WalletSelectorPage) repairBadWallet(badWalletID int, repair libwallet.RepairAction) {
+	if repair == libwallet.RepairRestoreFromSeed {
+		passwordModal := modal.NewCreatePasswordModal(pg.Load).
+			EnableName(false).
+			EnableConfirmPassword(false).
+			Title(values.String(values.StrRepairRestoreFromSeed)).
+			SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+				if err := pg.AssetsManager.RepairBadWallet(badWalletID, repair, password); err != nil {
+					pm.SetError(err.Error())
+					return false
+				}
+				pm.Dismiss()
+				pg.badWalletRepaired()
+				return true
+			})
+		pg.ParentWindow().ShowModal(passwordModal)
+		return
+	}
+
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(repairTitles[repair][0])).
+		Body(values.String(repairTitles[repair][1])).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrRepair)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.AssetsManager.RepairBadWallet(badWalletID, repair, ""); err != nil {
+				errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
+				pg.ParentWindow().ShowModal(errorModal)
+				// The wallet stays a bad wallet if it still fails to load.
+				pg.loadBadWallets()
+				return true
+			}
+			pg.badWalletRepaired()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// badWalletRepaired refreshes the wallets once a bad wallet was repaired.
+func (pg *WalletSelectorPage) badWalletRepaired() {
+	infoModal := modal.NewSuccessModal(pg.Load, values.String(values.StrWalletRepaired), modal.DefaultClickFunc())
+	pg.ParentWindow().ShowModal(infoModal)
+	pg.loadWallets()
+	pg.loadBadWallets()
+	pg.ParentWindow().Reload()
+}
@@ -53,9 +53,11 @@ func (pg *WalletSelectorPage) loadBadWallets() {
 	populateBadWallets := func(assetType libutils.AssetType, badWallets map[int]*sharedW.Wallet) {
 		for _, badWallet := range badWallets {
 			listItem := &badWalletListItem{
-				Wallet:    badWallet,
-				deleteBtn: pg.Theme.OutlineButton(values.String(values.StrDelete)),
+				Wallet:      badWallet,
+				diagnoseBtn: pg.Theme.OutlineButton(values.String(values.StrDiagnose)),
+				deleteBtn:   pg.Theme.OutlineButton(values.String(values.StrDelete)),
 			}
+			listItem.diagnoseBtn.Inset = layout.Inset{}
 			listItem.deleteBtn.Color = pg.Theme.Color.Danger
 			listItem.deleteBtn.Inset = layout.Inset{}
 			pg.badWalletsList[assetType] = append(pg.badWalletsList[assetType], listItem)
@@ -174,7 +176,14 @@ func (pg *WalletSelectorPage) badWalletsWrapper(gtx C, badWalletsList []*badWall
 					return layout.Flex{}.Layout(gtx,
 						layout.Rigid(pg.Theme.Body2(badWallet.Name).Layout),
 						layout.Flexed(1, func(gtx C) D {
-							return layout.E.Layout(gtx, badWallet.deleteBtn.Layout)
+							return layout.E.Layout(gtx, func(gtx C) D {
+								return layout.Flex{}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Right: m16}.Layout(gtx, badWallet.diagnoseBtn.Layout)
+									}),
+									layout.Rigid(badWallet.deleteBtn.Layout),
+								)
+							})
 						}),
 					)
 				}),
@@ -305,7 +314,7 @@ func (pg *WalletSelectorPage) layoutUSDBalance(gtx C, item *walletWithBalance) D
 
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X // full-width, so we can align the usd balance text to the right
 	return layout.E.Layout(gtx, func(gtx C) D {
-		usdBalance := utils.FormatAsUSDString(pg.Printer, item.totalBalance.MulF64(pg.assetRate[item.wallet.GetAssetType()]).ToCoin())
+		usdBalance := utils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, item.totalBalance.MulF64(pg.assetRate[item.wallet.GetAssetType()]).ToCoin())
 		return components.LayoutBalanceWithStateUSD(gtx, pg.Load, usdBalance)
 	})
 }
@@ -30,7 +30,8 @@ type (
 
 type badWalletListItem struct {
 	*sharedW.Wallet
-	deleteBtn cryptomaterial.Button
+	diagnoseBtn cryptomaterial.Button
+	deleteBtn   cryptomaterial.Button
 }
 
 type walletIndexTuple struct {
@@ -192,6 +193,9 @@ func (pg *WalletSelectorPage) HandleUserInteractions(gtx C) {
 
 	for _, walletsOfType := range pg.badWalletsList {
 		for _, badWallet := range walletsOfType {
+			if badWallet.diagnoseBtn.Clicked(gtx) {
+				pg.diagnoseBadWallet(badWallet.Wallet.ID)
+			}
 			if badWallet.deleteBtn.Clicked(gtx) {
 				pg.deleteBadWallet(badWallet.Wallet.ID)
 				pg.ParentWindow().Reload()
@@ -384,7 +388,7 @@ func (pg *WalletSelectorPage) dropdownTitleLayout(gtx C, asset libutils.AssetTyp
 							layout.Rigid(func(gtx C) D {
 								usdBalance := ""
 								if pg.AssetsManager.ExchangeRateFetchingEnabled() {
-									usdBalance = utils.FormatAsUSDString(pg.Printer, pg.assetsTotalUSDBalance[asset])
+									usdBalance = utils.FormatUSDAsFiat(pg.Printer, pg.AssetsManager, pg.assetsTotalUSDBalance[asset])
 								}
 								return components.LayoutBalanceWithStateUSD(gtx, pg.Load, usdBalance)
 							}),
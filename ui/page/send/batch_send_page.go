@@ -0,0 +1,378 @@
+package send
+
+import (
+	"os"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/batchsend"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const BatchSendPageID = "BatchSend"
+
+// rowErrorNames are the display name keys of the batch row errors.
+var rowErrorNames = map[string]string{
+	batchsend.ErrMissingField:     values.StrMissingAddressOrAmount,
+	batchsend.ErrInvalidAddress:   values.StrInvalidAddress,
+	batchsend.ErrInvalidAmount:    values.StrInvalidAmount,
+	batchsend.ErrDuplicateAddress: values.StrDuplicateOfLine,
+}
+
+// BatchSendPage sends the payments of a CSV file from a wallet account,
+// split into as few transactions as the output count and size limits allow.
+type BatchSendPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	accountDropdown *components.AccountDropdown
+	pathEditor      cryptomaterial.Editor
+	csvEditor       cryptomaterial.Editor
+	labelEditor     cryptomaterial.Editor
+	importBtn       cryptomaterial.Button
+	reviewBtn       cryptomaterial.Button
+	sendBtn         cryptomaterial.Button
+
+	rows    []*batchsend.Row
+	valid   int
+	batches []*batchsend.Batch
+	planErr string
+}
+
+func NewBatchSendPage(l *load.Load, wallet sharedW.Asset) *BatchSendPage {
+	pg := &BatchSendPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(BatchSendPageID),
+		wallet:           wallet,
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		pathEditor:       l.Theme.Editor(new(widget.Editor), values.String(values.StrCSVFilePath)),
+		csvEditor:        l.Theme.Editor(new(widget.Editor), values.String(values.StrPaymentsCSV)),
+		labelEditor:      l.Theme.Editor(new(widget.Editor), values.String(values.StrBatchLabel)),
+		importBtn:        l.Theme.OutlineButton(values.String(values.StrImport)),
+		reviewBtn:        l.Theme.Button(values.String(values.StrReview)),
+		sendBtn:          l.Theme.Button(values.String(values.StrSend)),
+	}
+	pg.pathEditor.Editor.SingleLine = true
+	pg.labelEditor.Editor.SingleLine = true
+
+	pg.accountDropdown = components.NewAccountDropdown(l).
+		SetChangedCallback(func(_ *sharedW.Account) {
+			pg.clearPlan()
+		}).
+		AccountValidator(func(account *sharedW.Account) bool {
+			if account.Number == load.MaxInt32 || !wallet.CanSign() {
+				return false
+			}
+			if wallet.ReadBoolConfigValueForKey(sharedW.AccountMixerConfigSet, false) &&
+				!wallet.ReadBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, false) {
+				// Only the mixed account can send to addresses.
+				return account.Number == load.MixedAccountNumber(wallet)
+			}
+			return true
+		}).
+		Setup(wallet)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) OnNavigatedTo() {}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) HandleUserInteractions(gtx C) {
+	pg.accountDropdown.Handle(gtx)
+
+	if pg.importBtn.Clicked(gtx) {
+		pg.importFile()
+	}
+
+	for {
+		event, ok := pg.csvEditor.Editor.Update(gtx)
+		if !ok {
+			break
+		}
+		if _, ok := event.(widget.ChangeEvent); ok {
+			pg.clearPlan()
+		}
+	}
+
+	pg.reviewBtn.SetEnabled(strings.TrimSpace(pg.csvEditor.Editor.Text()) != "")
+	if pg.reviewBtn.Clicked(gtx) {
+		pg.review()
+	}
+
+	pg.sendBtn.SetEnabled(len(pg.batches) > 0 && !pg.sent())
+	if pg.sendBtn.Clicked(gtx) {
+		pg.send()
+	}
+}
+
+func (pg *BatchSendPage) importFile() {
+	pg.pathEditor.SetError("")
+	path := strings.TrimSpace(pg.pathEditor.Editor.Text())
+	if path == "" {
+		return
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		pg.pathEditor.SetError(err.Error())
+		return
+	}
+	pg.csvEditor.Editor.SetText(string(content))
+	pg.clearPlan()
+}
+
+// clearPlan drops the parsed rows and batches after the payments or the
+// source account changed.
+func (pg *BatchSendPage) clearPlan() {
+	pg.rows = nil
+	pg.valid = 0
+	pg.batches = nil
+	pg.planErr = ""
+}
+
+// review parses and validates the payments and plans their transactions.
+func (pg *BatchSendPage) review() {
+	pg.clearPlan()
+	pg.csvEditor.SetError("")
+
+	rows, err := batchsend.ParseCSV(strings.NewReader(pg.csvEditor.Editor.Text()))
+	if err != nil {
+		pg.csvEditor.SetError(err.Error())
+		return
+	}
+	pg.rows = rows
+	pg.valid = batchsend.Validate(pg.wallet, rows)
+
+	account := pg.accountDropdown.SelectedAccount()
+	if account == nil || pg.valid == 0 {
+		return
+	}
+	pg.batches, err = batchsend.Plan(pg.wallet, account.Number, rows)
+	if err != nil {
+		pg.planErr = values.TranslateErr(err.Error())
+	}
+}
+
+// sent returns true if every batch has been broadcast.
+func (pg *BatchSendPage) sent() bool {
+	for _, batch := range pg.batches {
+		if batch.TxHash == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (pg *BatchSendPage) send() {
+	account := pg.accountDropdown.SelectedAccount()
+	if account == nil {
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmSend)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			label := strings.TrimSpace(pg.labelEditor.Editor.Text())
+			if err := batchsend.Send(pg.wallet, account.Number, pg.batches, password, label); err != nil {
+				pm.SetError(values.TranslateErr(err.Error()))
+				return false
+			}
+
+			pm.Dismiss()
+			successModal := modal.NewSuccessModal(pg.Load, values.PluralF(values.StrBatchSent, len(pg.batches)), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(successModal)
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrBatchSend),
+			SubTitle:   pg.wallet.GetWalletName(),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				sections := []func(gtx C) D{pg.paymentsForm}
+				if len(pg.rows) > 0 {
+					sections = append(sections, pg.planLayout)
+				}
+				return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+					return sections[i](gtx)
+				})
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *BatchSendPage) card(gtx C, children ...layout.FlexChild) D {
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx, children...)
+}
+
+func (pg *BatchSendPage) paymentsForm(gtx C) D {
+	return pg.card(gtx,
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, values.String(values.StrBatchSendHint))
+			lb.Color = pg.Theme.Color.GrayText2
+			return lb.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return pg.accountDropdown.Layout(gtx, values.String(values.StrSourceAccount))
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+					layout.Flexed(1, pg.pathEditor.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.importBtn.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min.Y = gtx.Dp(values.MarginPadding120)
+				return pg.csvEditor.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, pg.labelEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(pg.reviewBtn.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.sendBtn.Layout)
+					}),
+				)
+			})
+		}),
+	)
+}
+
+func (pg *BatchSendPage) planLayout(gtx C) D {
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, values.StringF(values.StrValidPayments, pg.valid, len(pg.rows)))
+			lb.Font.Weight = font.SemiBold
+			return lb.Layout(gtx)
+		}),
+	}
+
+	if pg.planErr != "" {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, pg.planErr)
+			lb.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}))
+	}
+
+	var total, fees int64
+	for i, batch := range pg.batches {
+		total += batch.Total() + batch.Fee
+		fees += batch.Fee
+		text := values.StringF(values.StrBatchPlanRow, i+1, len(batch.Rows),
+			pg.wallet.ToAmount(batch.Total()).String(), pg.wallet.ToAmount(batch.Fee).String())
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return components.EndToEndRow(gtx, pg.Theme.Label(values.TextSize14, text).Layout, func(gtx C) D {
+					if batch.TxHash == "" {
+						return D{}
+					}
+					lb := pg.Theme.Label(values.TextSize14, batch.TxHash)
+					lb.Color = pg.Theme.Color.GreenText
+					return lb.Layout(gtx)
+				})
+			})
+		}))
+	}
+	if len(pg.batches) > 0 {
+		children = append(children, layout.Rigid(func(gtx C) D {
+			text := values.StringF(values.StrBatchTotal, pg.wallet.ToAmount(total).String(), pg.wallet.ToAmount(fees).String())
+			lb := pg.Theme.Label(values.TextSize14, text)
+			lb.Font.Weight = font.SemiBold
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}))
+	}
+
+	for _, row := range pg.rows {
+		if row.Error == "" {
+			continue
+		}
+		reason := values.String(rowErrorNames[row.Error])
+		if row.Error == batchsend.ErrDuplicateAddress {
+			reason = values.StringF(values.StrDuplicateOfLine, row.DuplicateOf)
+		}
+		text := values.StringF(values.StrLineError, row.Line, reason)
+		children = append(children, layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, text)
+			lb.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}))
+	}
+
+	return pg.card(gtx, children...)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *BatchSendPage) OnNavigatedFrom() {}
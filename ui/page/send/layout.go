@@ -170,16 +170,27 @@ func (pg *Page) recipientsLayout(gtx C) D {
 				return re.recipientLayout(j+1, len(pg.recipients) > 1)(gtx)
 			}))
 		}
-		if pg.modalLayout == nil && len(pg.recipients) < 3 {
+		if pg.modalLayout == nil {
 			flexChilds = append(flexChilds, layout.Rigid(func(gtx C) D {
-				gtx.Constraints.Min.X = gtx.Constraints.Max.X
-				return layout.E.Layout(gtx, pg.addRecipientBtnLayout)
+				return components.EndToEndRow(gtx, pg.batchSendBtnLayout, func(gtx C) D {
+					if len(pg.recipients) >= 3 {
+						return D{}
+					}
+					return pg.addRecipientBtnLayout(gtx)
+				})
 			}))
 		}
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx, flexChilds...)
 	})
 }
 
+func (pg *Page) batchSendBtnLayout(gtx C) D {
+	txt := pg.Theme.Label(values.TextSize16, values.String(values.StrBatchSend))
+	txt.Color = pg.Theme.Color.Primary
+	txt.Font.Weight = font.SemiBold
+	return pg.batchSendBtn.Layout(gtx, txt.Layout)
+}
+
 func (pg *Page) addRecipientBtnLayout(gtx C) D {
 	return cryptomaterial.LinearLayout{
 		Width:      cryptomaterial.WrapContent,
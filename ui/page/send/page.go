@@ -1,6 +1,7 @@
 package send
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -59,15 +60,20 @@ type Page struct {
 	nextButton      cryptomaterial.Button
 	closeButton     cryptomaterial.Button
 	addRecipientBtn *cryptomaterial.Clickable
+	batchSendBtn    *cryptomaterial.Clickable
 
 	isFetchingExchangeRate bool
 
 	exchangeRate   float64
+	fiatCurrency   string
 	usdExchangeSet bool
 	confirmTxModal *sendConfirmModal
 
 	*authoredTxData
-	selectedWallet  sharedW.Asset
+	selectedWallet sharedW.Asset
+	// txAuthorWallet is the wallet whose unsigned tx is reserved by the
+	// page until it is navigated from.
+	txAuthorWallet  sharedW.Asset
 	feeRateSelector *components.FeeRateSelector
 
 	toCoinSelection *cryptomaterial.Clickable
@@ -113,6 +119,7 @@ func NewSendPage(l *load.Load, wallet sharedW.Asset) *Page {
 		exchangeRate:      -1,
 		navigateToSyncBtn: l.Theme.Button(values.String(values.StrStartSync)),
 		addRecipientBtn:   l.Theme.NewClickable(false),
+		batchSendBtn:      l.Theme.NewClickable(false),
 		recipients:        make([]*recipient, 0),
 	}
 
@@ -164,7 +171,7 @@ func (pg *Page) addRecipient() {
 	if pg.accountDropdown != nil && pg.accountDropdown.SelectedAccount() != nil {
 		rc.initializeAccountSelectors(pg.accountDropdown.SelectedAccount())
 	}
-	rc.amount.setExchangeRate(pg.exchangeRate)
+	rc.amount.setExchangeRate(pg.exchangeRate, pg.fiatCurrency)
 	pg.recipients = append(pg.recipients, rc)
 	pg.currentIDRecipient++
 }
@@ -191,6 +198,12 @@ func (pg *Page) pageFields() pageFields {
 // initWalletSelector is used for the send modal for wallet selection.
 func (pg *Page) initModalWalletSelector(wallet sharedW.Asset) {
 	pg.walletDropdown = components.NewWalletDropdown(pg.Load).
+		// Watch-only wallets sign with a hardware signer or an offline
+		// wallet holding their seed.
+		EnableWatchOnlyWallets(true).
+		WalletValidator(func(w sharedW.Asset) bool {
+			return w.CanSign()
+		}).
 		SetChangedCallback(func(w sharedW.Asset) {
 			pg.selectedWallet = w
 			if pg.accountDropdown != nil {
@@ -215,7 +228,7 @@ func (pg *Page) initModalWalletSelector(wallet sharedW.Asset) {
 			if pg.selectedWallet == nil {
 				return false
 			}
-			accountIsValid := account.Number != load.MaxInt32 && !pg.selectedWallet.IsWatchingOnlyWallet()
+			accountIsValid := account.Number != load.MaxInt32 && pg.selectedWallet.CanSign()
 
 			if pg.selectedWallet.ReadBoolConfigValueForKey(sharedW.AccountMixerConfigSet, false) &&
 				!pg.selectedWallet.ReadBoolConfigValueForKey(sharedW.SpendUnmixedFundsKey, false) {
@@ -325,7 +338,16 @@ func (pg *Page) fetchExchangeRate() {
 		return
 	}
 
-	pg.exchangeRate = rate.LastTradePrice
+	// Amounts are entered and displayed in the display currency selected by
+	// the user, falling back to USD if its rate is not available.
+	fiatCurrency := pg.AssetsManager.GetFiatCurrency()
+	exchangeRate, err := pg.AssetsManager.USDToFiat(rate.LastTradePrice, fiatCurrency)
+	if err != nil {
+		fiatCurrency, exchangeRate = values.DefaultFiatCurrency, rate.LastTradePrice
+	}
+
+	pg.fiatCurrency = fiatCurrency
+	pg.exchangeRate = exchangeRate
 	pg.updateRecipientExchangeRate()
 	pg.validateAndConstructTx() // convert estimates to usd
 
@@ -358,6 +380,11 @@ func (pg *Page) constructTx() {
 		selectedUTXOs = pg.selectedUTXOs.selectedUTXOs
 	}
 
+	if !pg.reserveTxAuthor() {
+		pg.setRecipientsAmountErr(errors.New(values.TranslateErr(libUtil.ErrTxAuthorBusy)))
+		return
+	}
+
 	err := pg.selectedWallet.NewUnsignedTx(sourceAccount.Number, selectedUTXOs)
 	if err != nil {
 		pg.setRecipientsAmountErr(err)
@@ -395,13 +422,37 @@ func (pg *Page) constructTx() {
 
 	if pg.exchangeRate != -1 && pg.usdExchangeSet {
 		pg.feeRateSelector.USDExchangeSet = true
-		pg.txFeeUSD = fmt.Sprintf("$%.4f", utils.CryptoToUSD(pg.exchangeRate, feeAndSize.Fee.CoinValue))
+		pg.txFeeUSD = utils.FormatAsFiatString(pg.Printer, pg.fiatCurrency, utils.CryptoToUSD(pg.exchangeRate, feeAndSize.Fee.CoinValue))
 		pg.feeRateSelector.TxFeeUSD = pg.txFeeUSD
-		pg.totalCostUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, totalCost.ToCoin()))
-		pg.balanceAfterSendUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+		pg.totalCostUSD = utils.FormatAsFiatString(pg.Printer, pg.fiatCurrency, utils.CryptoToUSD(pg.exchangeRate, totalCost.ToCoin()))
+		pg.balanceAfterSendUSD = utils.FormatAsFiatString(pg.Printer, pg.fiatCurrency, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
 
 		usdAmount := utils.CryptoToUSD(pg.exchangeRate, wal.ToAmount(totalAmount).ToCoin())
-		pg.sendAmountUSD = utils.FormatAsUSDString(pg.Printer, usdAmount)
+		pg.sendAmountUSD = utils.FormatAsFiatString(pg.Printer, pg.fiatCurrency, usdAmount)
+	}
+}
+
+// reserveTxAuthor reserves the unsigned tx of the selected wallet, which the
+// page authors, and releases the one of the previously selected wallet. It
+// returns false if another tx is authored on the wallet, such as the deposit
+// of a scheduled swap.
+func (pg *Page) reserveTxAuthor() bool {
+	if pg.txAuthorWallet == pg.selectedWallet {
+		return true
+	}
+	pg.releaseTxAuthor()
+	if !pg.selectedWallet.TryLockTxAuthor() {
+		return false
+	}
+	pg.txAuthorWallet = pg.selectedWallet
+	return true
+}
+
+// releaseTxAuthor releases the unsigned tx reserved by the page.
+func (pg *Page) releaseTxAuthor() {
+	if pg.txAuthorWallet != nil {
+		pg.txAuthorWallet.UnlockTxAuthor()
+		pg.txAuthorWallet = nil
 	}
 }
 
@@ -473,7 +524,7 @@ func (pg *Page) cleanAllRecipientErrors() {
 func (pg *Page) updateRecipientExchangeRate() {
 	for i := range pg.recipients {
 		recipient := pg.recipients[i]
-		recipient.amount.setExchangeRate(pg.exchangeRate)
+		recipient.amount.setExchangeRate(pg.exchangeRate, pg.fiatCurrency)
 	}
 }
 
@@ -556,7 +607,7 @@ func (pg *Page) showBalanceAfterSend() {
 		}
 		balanceAfterSend := sourceAccount.Balance.Spendable
 		pg.balanceAfterSend = balanceAfterSend.String()
-		pg.balanceAfterSendUSD = utils.FormatAsUSDString(pg.Printer, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+		pg.balanceAfterSendUSD = utils.FormatAsFiatString(pg.Printer, pg.fiatCurrency, utils.CryptoToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
 	}
 }
 
@@ -649,6 +700,10 @@ func (pg *Page) HandleUserInteractions(gtx C) {
 		pg.addRecipient()
 	}
 
+	if pg.batchSendBtn.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewBatchSendPage(pg.Load, pg.selectedWallet))
+	}
+
 	// handle recipient user interactions
 	for _, re := range pg.recipients {
 		re.HandleUserInteractions(gtx)
@@ -715,6 +770,7 @@ func (pg *Page) HandleKeyPress(_ *key.Event) {}
 // Part of the load.Page interface.
 func (pg *Page) OnNavigatedFrom() {
 	pg.walletDropdown.StopTxNtfnListener()
+	pg.releaseTxAuthor()
 }
 
 func (pg *Page) isFeerateAPIApproved() bool {
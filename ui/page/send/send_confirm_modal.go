@@ -32,6 +32,12 @@ type sendConfirmModal struct {
 
 	txSent    func()
 	isSending bool
+	// hardwareSigner is set for watch-only wallets signing on a device,
+	// which take the optional passphrase of the device.
+	hardwareSigner bool
+	// airgap is set for the watch-only wallets whose transactions are
+	// signed by an offline wallet holding their seed.
+	airgap bool
 
 	*authoredTxData
 	asset           sharedW.Asset
@@ -46,7 +52,9 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData, asset sharedW.Asset
 		authoredTxData: data,
 		asset:          asset,
 		sentHandle:     sentHandle,
+		hardwareSigner: asset.HardwareSigner() != nil,
 	}
+	scm.airgap = asset.AirgapSigner() && !scm.hardwareSigner
 	scm.Modal = l.Theme.ModalFloatTitle("send_confirm_modal", l.IsMobileView(), scm.firstLoad)
 
 	scm.closeConfirmationModalButton = l.Theme.OutlineButton(values.String(values.StrCancel))
@@ -54,9 +62,13 @@ func newSendConfirmModal(l *load.Load, data *authoredTxData, asset sharedW.Asset
 
 	scm.confirmButton = l.Theme.Button("")
 	scm.confirmButton.Font.Weight = font.Medium
-	scm.confirmButton.SetEnabled(false)
+	scm.confirmButton.SetEnabled(scm.hardwareSigner || scm.airgap)
 
-	scm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), values.String(values.StrSpendingPassword))
+	passwordHint := values.String(values.StrSpendingPassword)
+	if scm.hardwareSigner {
+		passwordHint = values.String(values.StrDevicePassphrase)
+	}
+	scm.passwordEditor = l.Theme.EditorPassword(new(widget.Editor), passwordHint)
 	scm.passwordEditor.Editor.SetText("")
 	scm.passwordEditor.Editor.SingleLine = true
 	scm.passwordEditor.Editor.Submit = true
@@ -80,13 +92,58 @@ func (scm *sendConfirmModal) setLoading(loading bool) {
 
 func (scm *sendConfirmModal) OnDismiss() {}
 
+// signOffline exports the transaction to be signed by the offline wallet and
+// publishes the signed transaction imported back.
+func (scm *sendConfirmModal) signOffline() {
+	if scm.isSending {
+		return
+	}
+
+	scm.setLoading(true)
+	go func() {
+		defer scm.setLoading(false)
+		unsignedTx, err := scm.asset.ExportUnsignedTx()
+		if err != nil {
+			scm.Toast.NotifyError(values.TranslateErr(err.Error()))
+			return
+		}
+
+		window := scm.ParentWindow()
+		components.ShowAirgapTxModal(scm.Load, window, values.String(values.StrUnsignedTx),
+			values.String(values.StrUnsignedTxInfo), unsignedTx, func() {
+				components.ShowImportAirgapTxModal(scm.Load, window, values.String(values.StrImportSignedTx), func(signedTx *sharedW.AirgapTx) error {
+					txHash, err := scm.asset.PublishAirgapTx(signedTx, scm.txLabel)
+					if err != nil {
+						return err
+					}
+					successModal := modal.NewSuccessModal(scm.Load, values.String(values.StrTxSent), func(_ bool, _ *modal.InfoModal) bool {
+						scm.sentHandle(txHash)
+						return true
+					})
+					window.ShowModal(successModal)
+					scm.txSent()
+					return nil
+				})
+			})
+		scm.Dismiss()
+	}()
+}
+
 func (scm *sendConfirmModal) broadcastTransaction() {
+	if scm.airgap {
+		scm.signOffline()
+		return
+	}
+
 	password := scm.passwordEditor.Editor.Text()
-	if password == "" || scm.isSending {
+	if password == "" && !scm.hardwareSigner || scm.isSending {
 		return
 	}
 
 	scm.setLoading(true)
+	if scm.hardwareSigner {
+		scm.Toast.Notify(values.String(values.StrConfirmOnDevice))
+	}
 	go func() {
 		defer scm.setLoading(false)
 		txHash, err := scm.asset.Broadcast(password, scm.txLabel)
@@ -109,7 +166,7 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 
 func (scm *sendConfirmModal) Handle(gtx C) {
 	if scm.passwordEditor.Changed() {
-		scm.confirmButton.SetEnabled(scm.passwordEditor.Editor.Text() != "")
+		scm.confirmButton.SetEnabled(scm.passwordEditor.Editor.Text() != "" || scm.hardwareSigner)
 		scm.passwordEditor.SetError("")
 	}
 
@@ -251,6 +308,9 @@ func (scm *sendConfirmModal) Layout(gtx C) D {
 			})
 		},
 		func(gtx C) D {
+			if scm.airgap {
+				return D{}
+			}
 			return layout.Inset{Left: dp16, Right: dp16}.Layout(gtx, scm.passwordEditor.Layout)
 		},
 		func(gtx C) D {
@@ -274,6 +334,9 @@ func (scm *sendConfirmModal) Layout(gtx C) D {
 								})
 							}
 							scm.confirmButton.Text = values.StrSend
+							if scm.airgap {
+								scm.confirmButton.Text = values.String(values.StrSignOffline)
+							}
 							return scm.confirmButton.Layout(gtx)
 						}),
 					)
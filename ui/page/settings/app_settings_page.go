@@ -55,6 +55,8 @@ type AppSettingsPage struct {
 	network                 *cryptomaterial.Clickable
 	language                *cryptomaterial.Clickable
 	currency                *cryptomaterial.Clickable
+	rateSources             *cryptomaterial.Clickable
+	fiatCurrency            *cryptomaterial.Clickable
 	help                    *cryptomaterial.Clickable
 	about                   *cryptomaterial.Clickable
 	appearanceMode          *cryptomaterial.Clickable
@@ -65,6 +67,9 @@ type AppSettingsPage struct {
 	networkInfoButton       cryptomaterial.IconButton
 	logLevel                *cryptomaterial.Clickable
 	viewLog                 *cryptomaterial.Clickable
+	translations            *cryptomaterial.Clickable
+	hooks                   *cryptomaterial.Clickable
+	multisigWallets         *cryptomaterial.Clickable
 	deleteDEX               *cryptomaterial.Clickable
 	backupDEX               *cryptomaterial.Clickable
 	copyDEXSeed             cryptomaterial.Button
@@ -102,11 +107,16 @@ func NewAppSettingsPage(l *load.Load) *AppSettingsPage {
 		network:           l.Theme.NewClickable(false),
 		language:          l.Theme.NewClickable(false),
 		currency:          l.Theme.NewClickable(false),
+		rateSources:       l.Theme.NewClickable(false),
+		fiatCurrency:      l.Theme.NewClickable(false),
 		help:              l.Theme.NewClickable(false),
 		about:             l.Theme.NewClickable(false),
 		appearanceMode:    l.Theme.NewClickable(false),
 		logLevel:          l.Theme.NewClickable(false),
 		viewLog:           l.Theme.NewClickable(false),
+		translations:      l.Theme.NewClickable(false),
+		hooks:             l.Theme.NewClickable(false),
+		multisigWallets:   l.Theme.NewClickable(false),
 		deleteDEX:         l.Theme.NewClickable(false),
 		backupDEX:         l.Theme.NewClickable(false),
 		copyDEXSeed:       l.Theme.Button(values.String(values.StrCopy)),
@@ -351,7 +361,7 @@ func (pg *AppSettingsPage) networkSettings() layout.Widget {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
 					lKey := pg.AssetsManager.GetCurrencyConversionExchange()
-					l := preference.GetKeyValue(lKey, preference.ExchOptions)
+					l := preference.GetKeyValue(lKey, pg.exchangeOptions())
 					exchangeRate := row{
 						title:     values.String(values.StrExchangeRate),
 						clickable: pg.currency,
@@ -359,6 +369,22 @@ func (pg *AppSettingsPage) networkSettings() layout.Widget {
 					}
 					return pg.clickableRow(gtx, exchangeRate)
 				}),
+				layout.Rigid(func(gtx C) D {
+					fiatCurrencyRow := row{
+						title:     values.String(values.StrDisplayCurrency),
+						clickable: pg.fiatCurrency,
+						label:     pg.Theme.Body2(pg.AssetsManager.GetFiatCurrency()),
+					}
+					return pg.clickableRow(gtx, fiatCurrencyRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					rateSourcesRow := row{
+						title:     values.String(values.StrRateSources),
+						clickable: pg.rateSources,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, rateSourcesRow)
+				}),
 				layout.Rigid(func(gtx C) D {
 					return pg.subSectionSwitch(gtx, values.String(values.StrGovernanceAPI), pg.governanceAPI)
 				}),
@@ -476,6 +502,30 @@ func (pg *AppSettingsPage) debug() layout.Widget {
 					}
 					return pg.clickableRow(gtx, viewLogRow)
 				}),
+				layout.Rigid(func(gtx C) D {
+					translationsRow := row{
+						title:     values.String(values.StrTranslations),
+						clickable: pg.translations,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, translationsRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					hooksRow := row{
+						title:     values.String(values.StrHooks),
+						clickable: pg.hooks,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, hooksRow)
+				}),
+				layout.Rigid(func(gtx C) D {
+					multisigRow := row{
+						title:     values.String(values.StrMultisigWallets),
+						clickable: pg.multisigWallets,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, multisigRow)
+				}),
 			)
 		})
 	}
@@ -511,6 +561,17 @@ func (pg *AppSettingsPage) clickableRow(gtx C, row row) D {
 	})
 }
 
+// exchangeOptions returns the selectable rate sources, including the user
+// defined ones.
+func (pg *AppSettingsPage) exchangeOptions() []preference.ItemPreference {
+	customSources := pg.AssetsManager.CustomRateSources()
+	names := make([]string, 0, len(customSources))
+	for _, cfg := range customSources {
+		names = append(names, cfg.Name)
+	}
+	return preference.ExchangeOptions(names)
+}
+
 func (pg *AppSettingsPage) subSectionLabel(title string) layout.Widget {
 	return func(gtx C) D {
 		return pg.Theme.Label(values.TextSizeTransform(pg.Load.IsMobileView(), values.TextSize16), title).Layout(gtx)
@@ -537,10 +598,10 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 
 	if pg.language.Clicked(gtx) {
 		langSelectorModal := preference.NewListPreference(pg.Load,
-			sharedW.LanguagePreferenceKey, values.DefaultLanguage, preference.LangOptions).
+			sharedW.LanguagePreferenceKey, values.DefaultLanguage, preference.LanguageOptions()).
 			Title(values.StrLanguage).
 			UpdateValues(func(_ string) {
-				values.SetUserLanguage(pg.AssetsManager.GetLanguagePreference())
+				pg.SetUserLanguage(pg.AssetsManager.GetLanguagePreference())
 			})
 		pg.ParentWindow().ShowModal(langSelectorModal)
 	}
@@ -552,7 +613,7 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 	if pg.currency.Clicked(gtx) {
 		currencySelectorModal := preference.NewListPreference(pg.Load,
 			sharedW.CurrencyConversionConfigKey, values.DefaultExchangeValue,
-			preference.ExchOptions).
+			pg.exchangeOptions()).
 			Title(values.StrExchangeRate).
 			UpdateValues(func(_ string) {})
 		pg.ParentWindow().ShowModal(currencySelectorModal)
@@ -605,6 +666,19 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentWindow().ShowModal(info)
 	}
 
+	if pg.fiatCurrency.Clicked(gtx) {
+		fiatSelectorModal := preference.NewListPreference(pg.Load,
+			sharedW.FiatCurrencyConfigKey, values.DefaultFiatCurrency,
+			preference.FiatOptions).
+			Title(values.StrDisplayCurrency).
+			UpdateValues(func(_ string) {})
+		pg.ParentWindow().ShowModal(fiatSelectorModal)
+	}
+
+	if pg.rateSources.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewRateSourcesPage(pg.Load))
+	}
+
 	if pg.help.Clicked(gtx) {
 		pg.ParentNavigator().Display(NewHelpPage(pg.Load))
 	}
@@ -627,6 +701,18 @@ func (pg *AppSettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentNavigator().Display(NewLogPage(pg.Load, pg.AssetsManager.LogFile(), values.String(values.StrAppLog)))
 	}
 
+	if pg.translations.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewTranslationsPage(pg.Load))
+	}
+
+	if pg.hooks.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewHooksPage(pg.Load))
+	}
+
+	if pg.multisigWallets.Clicked(gtx) {
+		pg.ParentNavigator().Display(NewMultisigPage(pg.Load))
+	}
+
 	if pg.copyDEXSeed.Clicked(gtx) {
 		gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(pg.dexSeed.String()))})
 		pg.copyDEXSeed.Text = values.String(values.StrCopied)
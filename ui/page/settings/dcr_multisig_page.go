@@ -0,0 +1,398 @@
+package settings
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const DCRMultisigPageID = "DCRMultisig"
+
+// multisigAccountControls are the widgets of a multisig account row.
+type multisigAccountControls struct {
+	receiveBtn *cryptomaterial.Clickable
+	sendBtn    *cryptomaterial.Clickable
+	signBtn    *cryptomaterial.Clickable
+	publishBtn *cryptomaterial.Clickable
+	removeBtn  *cryptomaterial.Clickable
+}
+
+// DCRMultisigPage lists the P2SH multisig accounts of a DCR wallet and drives
+// their setup and the signing rounds of their transaction bundles.
+type DCRMultisigPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet *dcr.Asset
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+	createBtn       cryptomaterial.Button
+	cosignerKeyBtn  cryptomaterial.Button
+
+	accounts []*dcr.MultisigAccount
+	controls map[string]*multisigAccountControls
+
+	balancesMu sync.Mutex
+	balances   map[string]string
+}
+
+func NewDCRMultisigPage(l *load.Load, wallet *dcr.Asset) *DCRMultisigPage {
+	return &DCRMultisigPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(DCRMultisigPageID),
+		wallet:           wallet,
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		createBtn:        l.Theme.Button(values.String(values.StrCreateMultisigAccount)),
+		cosignerKeyBtn:   l.Theme.OutlineButton(values.String(values.StrMyCosignerKey)),
+		controls:         make(map[string]*multisigAccountControls),
+		balances:         make(map[string]string),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *DCRMultisigPage) OnNavigatedTo() {
+	pg.loadAccounts()
+}
+
+func (pg *DCRMultisigPage) loadAccounts() {
+	accounts := pg.wallet.MultisigAccounts()
+	for _, account := range accounts {
+		if _, ok := pg.controls[account.Address]; !ok {
+			pg.controls[account.Address] = &multisigAccountControls{
+				receiveBtn: pg.Theme.NewClickable(true),
+				sendBtn:    pg.Theme.NewClickable(true),
+				signBtn:    pg.Theme.NewClickable(true),
+				publishBtn: pg.Theme.NewClickable(true),
+				removeBtn:  pg.Theme.NewClickable(true),
+			}
+		}
+	}
+	pg.accounts = accounts
+
+	go func() {
+		for _, account := range accounts {
+			balance, err := pg.wallet.MultisigBalance(account.Address)
+			if err != nil {
+				log.Errorf("Error reading the balance of multisig account %s: %v", account.Name, err)
+				continue
+			}
+			pg.balancesMu.Lock()
+			pg.balances[account.Address] = balance.String()
+			pg.balancesMu.Unlock()
+			pg.ParentWindow().Reload()
+		}
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *DCRMultisigPage) HandleUserInteractions(gtx C) {
+	if pg.createBtn.Clicked(gtx) {
+		pg.showCreateModal()
+	}
+	if pg.cosignerKeyBtn.Clicked(gtx) {
+		key, err := pg.wallet.MultisigCosignerKey(dcr.DefaultAccountNum)
+		if err != nil {
+			pg.Toast.NotifyError(err.Error())
+		} else {
+			copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrMyCosignerKey),
+				values.String(values.StrMultisigAccountKeyInfo), key)
+		}
+	}
+
+	for _, account := range pg.accounts {
+		controls, ok := pg.controls[account.Address]
+		if !ok {
+			continue
+		}
+
+		if controls.receiveBtn.Clicked(gtx) {
+			copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrReceive), "", account.Address)
+		}
+		if controls.sendBtn.Clicked(gtx) {
+			pg.showSendModal(account)
+		}
+		if controls.signBtn.Clicked(gtx) {
+			pg.showSignModal()
+		}
+		if controls.publishBtn.Clicked(gtx) {
+			pg.showPublishModal()
+		}
+		if controls.removeBtn.Clicked(gtx) {
+			pg.removeAccount(account)
+		}
+	}
+}
+
+func (pg *DCRMultisigPage) showCreateModal() {
+	nameEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrName))
+	nameEditor.Editor.SingleLine = true
+	thresholdEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRequiredSignatures))
+	thresholdEditor.Editor.SingleLine = true
+	thresholdEditor.Editor.SetText("2")
+	keysEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrCosignerKeys))
+
+	editors := []*cryptomaterial.Editor{&nameEditor, &thresholdEditor, &keysEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrCreateMultisigAccount),
+		values.String(values.StrMultisigAccountKeysInfo), editors, nil, values.String(values.StrCreate), func() bool {
+			threshold, err := strconv.Atoi(strings.TrimSpace(thresholdEditor.Editor.Text()))
+			if err != nil {
+				thresholdEditor.SetError(values.String(values.StrInvalidNumber))
+				return false
+			}
+			_, err = pg.wallet.CreateMultisigAccount(nameEditor.Editor.Text(), threshold,
+				strings.Fields(keysEditor.Editor.Text()), dcr.DefaultAccountNum)
+			if err != nil {
+				keysEditor.SetError(err.Error())
+				return false
+			}
+			pg.loadAccounts()
+			return true
+		})
+}
+
+func (pg *DCRMultisigPage) showSendModal(account *dcr.MultisigAccount) {
+	addressEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrAddress))
+	addressEditor.Editor.SingleLine = true
+	amountEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrAmount))
+	amountEditor.Editor.SingleLine = true
+
+	editors := []*cryptomaterial.Editor{&addressEditor, &amountEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrCreateTxBundle), "", editors, nil,
+		values.String(values.StrCreate), func() bool {
+			amount, err := strconv.ParseFloat(strings.TrimSpace(amountEditor.Editor.Text()), 64)
+			if err != nil || amount <= 0 {
+				amountEditor.SetError(values.String(values.StrInvalidAmount))
+				return false
+			}
+
+			bundle, err := pg.wallet.CreateMultisigSpend(account.Address, addressEditor.Editor.Text(), dcr.AmountAtom(amount))
+			if err != nil {
+				addressEditor.SetError(err.Error())
+				return false
+			}
+			copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrTxBundle), values.String(values.StrTxBundleInfo), bundle)
+			return true
+		})
+}
+
+func (pg *DCRMultisigPage) showSignModal() {
+	bundleEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrTxBundle))
+	editors := []*cryptomaterial.Editor{&bundleEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrSignTxBundle), "", editors, nil,
+		values.String(values.StrNext), func() bool {
+			bundle := bundleEditor.Editor.Text()
+			if _, err := dcr.DecodeMultisigBundle([]byte(bundle)); err != nil {
+				bundleEditor.SetError(err.Error())
+				return false
+			}
+
+			passwordModal := modal.NewCreatePasswordModal(pg.Load).
+				EnableName(false).
+				EnableConfirmPassword(false).
+				Title(values.String(values.StrConfirmToSign)).
+				SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+					signed, err := pg.wallet.SignMultisigBundle(bundle, password)
+					if err != nil {
+						pm.SetError(err.Error())
+						return false
+					}
+					pm.Dismiss()
+					copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrTxBundle), values.String(values.StrTxBundleInfo), signed)
+					return true
+				})
+			pg.ParentWindow().ShowModal(passwordModal)
+			return true
+		})
+}
+
+func (pg *DCRMultisigPage) showPublishModal() {
+	bundlesEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrSignedTxBundles))
+	editors := []*cryptomaterial.Editor{&bundlesEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrPublishTxBundle), "", editors, nil,
+		values.String(values.StrPublishTxBundle), func() bool {
+			combined, complete, err := pg.wallet.CombineMultisigBundles(strings.Fields(bundlesEditor.Editor.Text())...)
+			if err != nil {
+				bundlesEditor.SetError(err.Error())
+				return false
+			}
+			if !complete {
+				copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrTxBundle),
+					values.String(values.StrTxBundleNeedsSignatures), combined)
+				return true
+			}
+
+			txHash, err := pg.wallet.PublishMultisigBundle(combined)
+			if err != nil {
+				bundlesEditor.SetError(err.Error())
+				return false
+			}
+			successModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrTxBroadcast, txHash), modal.DefaultClickFunc())
+			pg.ParentWindow().ShowModal(successModal)
+			pg.loadAccounts()
+			return true
+		})
+}
+
+func (pg *DCRMultisigPage) removeAccount(account *dcr.MultisigAccount) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRemove)).
+		Body(values.StringF(values.StrRemoveMultisigAccountMsg, account.Name)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrRemove)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.wallet.RemoveMultisigAccount(account.Address); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return true
+			}
+			delete(pg.controls, account.Address)
+			pg.loadAccounts()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *DCRMultisigPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrMultisigAccounts),
+			SubTitle:   pg.wallet.GetWalletName(),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{}.Layout(gtx,
+								layout.Rigid(pg.createBtn.Layout),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.cosignerKeyBtn.Layout)
+								}),
+							)
+						})
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.accounts) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoMultisigAccounts)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.accounts), func(gtx C, i int) D {
+							return pg.accountLayout(gtx, pg.accounts[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *DCRMultisigPage) accountLayout(gtx C, account *dcr.MultisigAccount) D {
+	controls, ok := pg.controls[account.Address]
+	if !ok {
+		return D{}
+	}
+
+	summary := values.StringF(values.StrMultisigSummary, account.Threshold, len(account.PubKeys), account.Address)
+	pg.balancesMu.Lock()
+	balance := pg.balances[account.Address]
+	pg.balancesMu.Unlock()
+
+	actions := []struct {
+		title     string
+		clickable *cryptomaterial.Clickable
+	}{
+		{values.String(values.StrReceive), controls.receiveBtn},
+		{values.String(values.StrCreateTxBundle), controls.sendBtn},
+		{values.String(values.StrSignTxBundle), controls.signBtn},
+		{values.String(values.StrPublishTxBundle), controls.publishBtn},
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize16, account.Name)
+				lb.Font.Weight = font.SemiBold
+				return lb.Layout(gtx)
+			}, pg.Theme.Label(values.TextSize16, balance).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, summary)
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				children := make([]layout.FlexChild, 0, len(actions)+1)
+				for _, action := range actions {
+					action := action
+					children = append(children, layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, action.title)
+						lb.Color = pg.Theme.Color.Primary
+						return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return action.clickable.Layout(gtx, lb.Layout)
+						})
+					}))
+				}
+				children = append(children, layout.Rigid(func(gtx C) D {
+					lb := pg.Theme.Label(values.TextSize14, values.String(values.StrRemove))
+					lb.Color = pg.Theme.Color.Danger
+					return controls.removeBtn.Layout(gtx, lb.Layout)
+				}))
+				return layout.Flex{}.Layout(gtx, children...)
+			})
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *DCRMultisigPage) OnNavigatedFrom() {}
@@ -0,0 +1,178 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/hooks"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/utils"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const (
+	HookDeliveriesPageID = "HookDeliveries"
+
+	// hookDeliveriesLimit is the number of deliveries displayed.
+	hookDeliveriesLimit = 100
+)
+
+// HookDeliveriesPage displays the delivery log of a hook.
+type HookDeliveriesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	hook       *hooks.Hook
+	deliveries []*hooks.Delivery
+	loadedAt   time.Time
+}
+
+func NewHookDeliveriesPage(l *load.Load, hook *hooks.Hook) *HookDeliveriesPage {
+	return &HookDeliveriesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(HookDeliveriesPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		hook:             hook,
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *HookDeliveriesPage) OnNavigatedTo() {
+	pg.loadDeliveries()
+}
+
+func (pg *HookDeliveriesPage) loadDeliveries() {
+	pg.loadedAt = time.Now()
+	deliveries, err := pg.AssetsManager.Hooks.Deliveries(pg.hook.ID, hookDeliveriesLimit)
+	if err != nil {
+		log.Errorf("Error loading hook deliveries: %v", err)
+		return
+	}
+	pg.deliveries = deliveries
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *HookDeliveriesPage) HandleUserInteractions(_ C) {
+	// Pick up the retries of the pending deliveries.
+	if time.Since(pg.loadedAt) >= time.Second {
+		pg.loadDeliveries()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *HookDeliveriesPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      pg.hook.Name,
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if pg.hook.Secret == "" {
+							return D{}
+						}
+						lb := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s: %s", values.String(values.StrSigningSecret), pg.hook.Secret))
+						lb.Color = pg.Theme.Color.GrayText2
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, lb.Layout)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.deliveries) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoDeliveries)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.deliveries), func(gtx C, i int) D {
+							return pg.deliveryLayout(gtx, pg.deliveries[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	gtx.Execute(op.InvalidateCmd{At: time.Now().Add(time.Second)})
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *HookDeliveriesPage) deliveryLayout(gtx C, delivery *hooks.Delivery) D {
+	statusText, statusColor := values.String(values.StrDeliveryPending), pg.Theme.Color.GrayText2
+	switch delivery.Status {
+	case hooks.DeliveryDelivered:
+		statusText, statusColor = values.String(values.StrDeliveryDelivered), pg.Theme.Color.GreenText
+	case hooks.DeliveryFailed:
+		statusText, statusColor = values.String(values.StrDeliveryFailed), pg.Theme.Color.Danger
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				return pg.Theme.Label(values.TextSize16, values.String(hookEventNames[delivery.Event])).Layout(gtx)
+			}, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize14, statusText)
+				lb.Color = statusColor
+				return lb.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s · %s · %s: %d", delivery.Subject,
+				utils.FormatDateOrTime(delivery.UpdatedAt), values.String(values.StrAttempts), delivery.Attempts))
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if delivery.LastError == "" {
+				return D{}
+			}
+			lb := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s: %s", values.String(values.StrLastError), delivery.LastError))
+			lb.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *HookDeliveriesPage) OnNavigatedFrom() {}
@@ -0,0 +1,335 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/hooks"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const HooksPageID = "Hooks"
+
+// hookEventNames are the display name keys of the hook event types.
+var hookEventNames = map[hooks.EventType]string{
+	hooks.EventTransaction: values.StrEventTransaction,
+	hooks.EventTxConfirmed: values.StrEventTxConfirmed,
+	hooks.EventTicketVoted: values.StrEventTicketVoted,
+	hooks.EventSwapStatus:  values.StrEventSwapStatus,
+	hooks.EventDEXMatch:    values.StrEventDEXMatch,
+}
+
+// hookControls are the widgets of a hook row.
+type hookControls struct {
+	enabled       *cryptomaterial.Switch
+	deliveriesBtn *cryptomaterial.Clickable
+	removeBtn     *cryptomaterial.Clickable
+}
+
+// HooksPage lists the webhooks and script hooks and allows adding, enabling
+// and removing hooks.
+type HooksPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+	addHookBtn      cryptomaterial.Button
+
+	hooks    []*hooks.Hook
+	controls map[int]*hookControls
+}
+
+func NewHooksPage(l *load.Load) *HooksPage {
+	return &HooksPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(HooksPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		addHookBtn:       l.Theme.Button(values.String(values.StrAddHook)),
+		controls:         make(map[int]*hookControls),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *HooksPage) OnNavigatedTo() {
+	pg.loadHooks()
+}
+
+func (pg *HooksPage) loadHooks() {
+	savedHooks, err := pg.AssetsManager.Hooks.Hooks()
+	if err != nil {
+		log.Errorf("Error loading hooks: %v", err)
+		return
+	}
+
+	for _, hook := range savedHooks {
+		if _, ok := pg.controls[hook.ID]; !ok {
+			pg.controls[hook.ID] = &hookControls{
+				enabled:       pg.Theme.Switch(),
+				deliveriesBtn: pg.Theme.NewClickable(true),
+				removeBtn:     pg.Theme.NewClickable(true),
+			}
+		}
+		pg.controls[hook.ID].enabled.SetChecked(hook.Enabled)
+	}
+	pg.hooks = savedHooks
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *HooksPage) HandleUserInteractions(gtx C) {
+	if pg.addHookBtn.Clicked(gtx) {
+		pg.showAddHookModal()
+	}
+
+	for _, hook := range pg.hooks {
+		controls, ok := pg.controls[hook.ID]
+		if !ok {
+			continue
+		}
+
+		if controls.enabled.Changed(gtx) {
+			hook.Enabled = controls.enabled.IsChecked()
+			if err := pg.AssetsManager.Hooks.SaveHook(hook); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				pg.loadHooks()
+			}
+		}
+
+		if controls.deliveriesBtn.Clicked(gtx) {
+			pg.ParentNavigator().Display(NewHookDeliveriesPage(pg.Load, hook))
+		}
+
+		if controls.removeBtn.Clicked(gtx) {
+			pg.removeHook(hook)
+		}
+	}
+}
+
+func (pg *HooksPage) showAddHookModal() {
+	nameEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrName))
+	nameEditor.Editor.SingleLine = true
+	urlEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrHookURL))
+	urlEditor.Editor.SingleLine = true
+	commandEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrHookCommand))
+	commandEditor.Editor.SingleLine = true
+	confirmationsEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrHookConfirmationsHint))
+	confirmationsEditor.Editor.SingleLine = true
+	confirmationsEditor.Editor.SetText("1")
+
+	eventCheckBoxes := make([]cryptomaterial.CheckBoxStyle, len(hooks.EventTypes))
+	for i, eventType := range hooks.EventTypes {
+		eventCheckBoxes[i] = pg.Theme.CheckBox(new(widget.Bool), values.String(hookEventNames[eventType]))
+	}
+
+	editors := []*cryptomaterial.Editor{&nameEditor, &urlEditor, &commandEditor, &confirmationsEditor}
+	addHookModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrAddHook)).
+		UseCustomWidget(func(gtx C) D {
+			children := make([]layout.FlexChild, 0, len(editors)+len(eventCheckBoxes))
+			for _, editor := range editors {
+				editor := editor
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, editor.Layout)
+				}))
+			}
+			for i := range eventCheckBoxes {
+				checkBox := eventCheckBoxes[i]
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, checkBox.Layout)
+				}))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrAdd)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			nameEditor.SetError("")
+			confirmationsEditor.SetError("")
+
+			hook := &hooks.Hook{
+				Name:    nameEditor.Editor.Text(),
+				URL:     strings.TrimSpace(urlEditor.Editor.Text()),
+				Command: strings.TrimSpace(commandEditor.Editor.Text()),
+				Enabled: true,
+			}
+			if (hook.URL == "") == (hook.Command == "") {
+				nameEditor.SetError(values.String(values.StrHookURLOrCommand))
+				return false
+			}
+
+			confirmations, err := strconv.ParseInt(strings.TrimSpace(confirmationsEditor.Editor.Text()), 10, 32)
+			if err != nil || confirmations < 1 {
+				confirmationsEditor.SetError(values.String(values.StrInvalidAmount))
+				return false
+			}
+			hook.Confirmations = int32(confirmations)
+
+			for i, checkBox := range eventCheckBoxes {
+				if checkBox.CheckBox.Value {
+					hook.Events = append(hook.Events, hooks.EventTypes[i])
+				}
+			}
+
+			if err := pg.AssetsManager.Hooks.SaveHook(hook); err != nil {
+				nameEditor.SetError(err.Error())
+				return false
+			}
+
+			pg.loadHooks()
+			return true
+		})
+	pg.ParentWindow().ShowModal(addHookModal)
+}
+
+func (pg *HooksPage) removeHook(hook *hooks.Hook) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRemove)).
+		Body(values.StringF(values.StrRemoveHookMsg, hook.Name)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrRemove)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.AssetsManager.Hooks.DeleteHook(hook.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return true
+			}
+			delete(pg.controls, hook.ID)
+			pg.loadHooks()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *HooksPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrHooks),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.addHookBtn.Layout)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.hooks) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoHooks)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.hooks), func(gtx C, i int) D {
+							return pg.hookLayout(gtx, pg.hooks[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *HooksPage) hookLayout(gtx C, hook *hooks.Hook) D {
+	controls, ok := pg.controls[hook.ID]
+	if !ok {
+		return D{}
+	}
+
+	eventNames := make([]string, 0, len(hook.Events))
+	for _, event := range hook.Events {
+		name := values.String(hookEventNames[event])
+		if event == hooks.EventTxConfirmed {
+			name = fmt.Sprintf("%s (%d)", name, hook.Confirmations)
+		}
+		eventNames = append(eventNames, name)
+	}
+
+	target := hook.URL
+	if hook.Command != "" {
+		target = fmt.Sprintf("$ %s", hook.Command)
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize16, hook.Name)
+				lb.Font.Weight = font.SemiBold
+				return lb.Layout(gtx)
+			}, controls.enabled.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, target)
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, strings.Join(eventNames, " · "))
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, values.String(values.StrDeliveries))
+						lb.Color = pg.Theme.Color.Primary
+						return controls.deliveriesBtn.Layout(gtx, lb.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, values.String(values.StrRemove))
+						lb.Color = pg.Theme.Color.Danger
+						return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return controls.removeBtn.Layout(gtx, lb.Layout)
+						})
+					}),
+				)
+			})
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *HooksPage) OnNavigatedFrom() {}
@@ -0,0 +1,673 @@
+package settings
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gioui.org/font"
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/multisig"
+	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const MultisigPageID = "Multisig"
+
+// watchOnlySigner is the signing wallet option of watch-only multisig
+// wallets.
+const watchOnlySigner = "0"
+
+// multisigControls are the widgets of a multisig wallet row.
+type multisigControls struct {
+	receiveBtn   *cryptomaterial.Clickable
+	sendBtn      *cryptomaterial.Clickable
+	signBtn      *cryptomaterial.Clickable
+	broadcastBtn *cryptomaterial.Clickable
+	exportBtn    *cryptomaterial.Clickable
+	serverBtn    *cryptomaterial.Clickable
+	removeBtn    *cryptomaterial.Clickable
+}
+
+// MultisigPage lists the BTC and LTC multisig wallets and drives their setup
+// and the PSBT signing rounds of their transactions.
+type MultisigPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+	createBtn       cryptomaterial.Button
+	importBtn       cryptomaterial.Button
+	cosignerKeyBtn  cryptomaterial.Button
+
+	wallets  []*multisig.Wallet
+	controls map[int]*multisigControls
+
+	balancesMu sync.Mutex
+	balances   map[int]string
+}
+
+func NewMultisigPage(l *load.Load) *MultisigPage {
+	pg := &MultisigPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(MultisigPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		createBtn:        l.Theme.Button(values.String(values.StrCreateMultisigWallet)),
+		importBtn:        l.Theme.OutlineButton(values.String(values.StrImportMultisigWallet)),
+		cosignerKeyBtn:   l.Theme.OutlineButton(values.String(values.StrMyCosignerKey)),
+		controls:         make(map[int]*multisigControls),
+		balances:         make(map[int]string),
+	}
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *MultisigPage) OnNavigatedTo() {
+	pg.loadWallets()
+}
+
+func (pg *MultisigPage) loadWallets() {
+	wallets, err := pg.AssetsManager.Multisig.Wallets()
+	if err != nil {
+		log.Errorf("Error loading multisig wallets: %v", err)
+		return
+	}
+
+	for _, wallet := range wallets {
+		if _, ok := pg.controls[wallet.ID]; !ok {
+			pg.controls[wallet.ID] = &multisigControls{
+				receiveBtn:   pg.Theme.NewClickable(true),
+				sendBtn:      pg.Theme.NewClickable(true),
+				signBtn:      pg.Theme.NewClickable(true),
+				broadcastBtn: pg.Theme.NewClickable(true),
+				exportBtn:    pg.Theme.NewClickable(true),
+				serverBtn:    pg.Theme.NewClickable(true),
+				removeBtn:    pg.Theme.NewClickable(true),
+			}
+		}
+	}
+	pg.wallets = wallets
+
+	// The balances are scanned from the Electrum servers of the wallets.
+	go func() {
+		for _, wallet := range wallets {
+			if wallet.ElectrumServer == "" {
+				continue
+			}
+			balance, err := pg.AssetsManager.MultisigBalance(wallet.ID)
+			if err != nil {
+				log.Errorf("Error reading the balance of multisig wallet %s: %v", wallet.Name, err)
+				continue
+			}
+			pg.balancesMu.Lock()
+			pg.balances[wallet.ID] = libwallet.MultisigAmount(wallet, balance.Confirmed+balance.Unconfirmed).String()
+			pg.balancesMu.Unlock()
+			pg.ParentWindow().Reload()
+		}
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *MultisigPage) HandleUserInteractions(gtx C) {
+	if pg.createBtn.Clicked(gtx) {
+		pg.showCreateModal()
+	}
+	if pg.importBtn.Clicked(gtx) {
+		pg.showImportModal()
+	}
+	if pg.cosignerKeyBtn.Clicked(gtx) {
+		pg.showCosignerKeyModal()
+	}
+
+	for _, wallet := range pg.wallets {
+		controls, ok := pg.controls[wallet.ID]
+		if !ok {
+			continue
+		}
+
+		if controls.receiveBtn.Clicked(gtx) {
+			address, err := pg.AssetsManager.MultisigReceiveAddress(wallet.ID)
+			if err != nil {
+				pg.Toast.NotifyError(err.Error())
+			} else {
+				copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrReceive), "", address)
+			}
+		}
+		if controls.sendBtn.Clicked(gtx) {
+			pg.showSendModal(wallet)
+		}
+		if controls.signBtn.Clicked(gtx) {
+			pg.showSignModal(wallet)
+		}
+		if controls.broadcastBtn.Clicked(gtx) {
+			pg.showBroadcastModal(wallet)
+		}
+		if controls.exportBtn.Clicked(gtx) {
+			config, err := pg.AssetsManager.ExportMultisigWallet(wallet.ID)
+			if err != nil {
+				pg.Toast.NotifyError(err.Error())
+			} else {
+				copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrExport), values.String(values.StrMultisigConfigInfo), string(config))
+			}
+		}
+		if controls.serverBtn.Clicked(gtx) {
+			pg.showServerModal(wallet)
+		}
+		if controls.removeBtn.Clicked(gtx) {
+			pg.removeWallet(wallet)
+		}
+	}
+}
+
+// signerOptions returns the radio buttons of the BTC and LTC wallets holding
+// their seed. The watch-only option is included if watchOnly is true.
+func (pg *MultisigPage) signerOptions(group *widget.Enum, watchOnly bool) []cryptomaterial.RadioButton {
+	var options []cryptomaterial.RadioButton
+	if watchOnly {
+		options = append(options, pg.Theme.RadioButton(group, watchOnlySigner, values.String(values.StrNoSigningWallet),
+			pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary))
+	}
+	for _, wallet := range pg.AssetsManager.AssetWallets(libutils.BTCWalletAsset, libutils.LTCWalletAsset) {
+		if wallet.IsWatchingOnlyWallet() || !wallet.HasWalletSeed() {
+			continue
+		}
+		label := wallet.GetWalletName() + " (" + wallet.GetAssetType().ToFull() + ")"
+		options = append(options, pg.Theme.RadioButton(group, strconv.Itoa(wallet.GetWalletID()), label,
+			pg.Theme.Color.DeepBlue, pg.Theme.Color.Primary))
+	}
+	if group.Value == "" && len(options) > 0 {
+		group.Value = options[0].Key
+	}
+	return options
+}
+
+// withPassphrase runs fn with the passphrase of the signing wallet, or an
+// empty passphrase for watch-only wallets.
+func (pg *MultisigPage) withPassphrase(signerWalletID int, fn func(passphrase string) error) {
+	if signerWalletID == 0 {
+		if err := fn(""); err != nil {
+			pg.Toast.NotifyError(err.Error())
+		}
+		return
+	}
+
+	passwordModal := modal.NewCreatePasswordModal(pg.Load).
+		EnableName(false).
+		EnableConfirmPassword(false).
+		Title(values.String(values.StrConfirmToSign)).
+		SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+			if err := fn(password); err != nil {
+				pm.SetError(err.Error())
+				return false
+			}
+			pm.Dismiss()
+			return true
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// multisigFormModal shows a modal of the editors and radio buttons that runs
+// submit on its positive button.
+func multisigFormModal(l *load.Load, window app.WindowNavigator, title, info string, editors []*cryptomaterial.Editor,
+	options []cryptomaterial.RadioButton, positive string, submit func() bool) {
+	formModal := modal.NewCustomModal(l).
+		Title(title).
+		UseCustomWidget(func(gtx C) D {
+			var children []layout.FlexChild
+			if info != "" {
+				children = append(children, layout.Rigid(func(gtx C) D {
+					lbl := l.Theme.Body2(info)
+					lbl.Color = l.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}))
+			}
+			for _, editor := range editors {
+				editor := editor
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, editor.Layout)
+				}))
+			}
+			if len(options) > 0 {
+				children = append(children, layout.Rigid(func(gtx C) D {
+					lbl := l.Theme.Body1(values.String(values.StrSigningWallet))
+					lbl.Font.Weight = font.SemiBold
+					return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, lbl.Layout)
+				}))
+			}
+			for i := range options {
+				option := options[i]
+				children = append(children, layout.Rigid(option.Layout))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(positive).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			for _, editor := range editors {
+				editor.SetError("")
+			}
+			return submit()
+		})
+	window.ShowModal(formModal)
+}
+
+func (pg *MultisigPage) showCreateModal() {
+	nameEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrName))
+	nameEditor.Editor.SingleLine = true
+	thresholdEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRequiredSignatures))
+	thresholdEditor.Editor.SingleLine = true
+	thresholdEditor.Editor.SetText("2")
+	keysEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrCosignerKeys))
+
+	signer := new(widget.Enum)
+	options := pg.signerOptions(signer, false)
+	editors := []*cryptomaterial.Editor{&nameEditor, &thresholdEditor, &keysEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrCreateMultisigWallet), values.String(values.StrCosignerKeysInfo), editors, options,
+		values.String(values.StrCreate), func() bool {
+			threshold, err := strconv.Atoi(strings.TrimSpace(thresholdEditor.Editor.Text()))
+			if err != nil {
+				thresholdEditor.SetError(values.String(values.StrInvalidNumber))
+				return false
+			}
+
+			var cosigners []*multisig.Cosigner
+			for _, key := range strings.Fields(keysEditor.Editor.Text()) {
+				cosigner, err := multisig.ParseCosigner(values.StringF(values.StrMultisigCosigner, len(cosigners)+1), key)
+				if err != nil {
+					keysEditor.SetError(err.Error())
+					return false
+				}
+				cosigners = append(cosigners, cosigner)
+			}
+
+			signerWalletID, _ := strconv.Atoi(signer.Value)
+			signerWallet := pg.AssetsManager.WalletWithID(signerWalletID)
+			if signerWallet == nil {
+				keysEditor.SetError(values.String(values.StrNoSigningWallets))
+				return false
+			}
+			pg.withPassphrase(signerWalletID, func(passphrase string) error {
+				_, err := pg.AssetsManager.CreateMultisigWallet(nameEditor.Editor.Text(), signerWallet.GetAssetType(),
+					threshold, cosigners, signerWalletID, passphrase)
+				if err == nil {
+					pg.loadWallets()
+				}
+				return err
+			})
+			return true
+		})
+}
+
+func (pg *MultisigPage) showImportModal() {
+	nameEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrName))
+	nameEditor.Editor.SingleLine = true
+	configEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrMultisigConfig))
+
+	signer := new(widget.Enum)
+	options := pg.signerOptions(signer, true)
+	editors := []*cryptomaterial.Editor{&nameEditor, &configEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrImportMultisigWallet), "", editors, options,
+		values.String(values.StrImport), func() bool {
+			config := []byte(configEditor.Editor.Text())
+			if _, err := multisig.ParseConfig(config); err != nil {
+				configEditor.SetError(err.Error())
+				return false
+			}
+
+			signerWalletID, _ := strconv.Atoi(signer.Value)
+			pg.withPassphrase(signerWalletID, func(passphrase string) error {
+				_, err := pg.AssetsManager.ImportMultisigWallet(config, nameEditor.Editor.Text(), signerWalletID, passphrase)
+				if err == nil {
+					pg.loadWallets()
+				}
+				return err
+			})
+			return true
+		})
+}
+
+func (pg *MultisigPage) showCosignerKeyModal() {
+	signer := new(widget.Enum)
+	options := pg.signerOptions(signer, false)
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrMyCosignerKey), "", nil, options, values.String(values.StrNext), func() bool {
+		signerWalletID, _ := strconv.Atoi(signer.Value)
+		if signerWalletID == 0 {
+			return true
+		}
+		pg.withPassphrase(signerWalletID, func(passphrase string) error {
+			cosigner, err := pg.AssetsManager.MultisigCosigner(signerWalletID, passphrase)
+			if err != nil {
+				return err
+			}
+			copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrMyCosignerKey), values.String(values.StrCosignerKeyInfo), cosigner.KeyExpression())
+			return nil
+		})
+		return true
+	})
+}
+
+func (pg *MultisigPage) showSendModal(wallet *multisig.Wallet) {
+	addressEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrAddress))
+	addressEditor.Editor.SingleLine = true
+	amountEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrAmount))
+	amountEditor.Editor.SingleLine = true
+	feeRateEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrFeeRateSatVB))
+	feeRateEditor.Editor.SingleLine = true
+	feeRateEditor.Editor.SetText("2")
+
+	editors := []*cryptomaterial.Editor{&addressEditor, &amountEditor, &feeRateEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrCreatePSBT), "", editors, nil, values.String(values.StrCreate), func() bool {
+		amount, err := strconv.ParseFloat(strings.TrimSpace(amountEditor.Editor.Text()), 64)
+		if err != nil || amount <= 0 {
+			amountEditor.SetError(values.String(values.StrInvalidAmount))
+			return false
+		}
+		feeRate, err := strconv.ParseInt(strings.TrimSpace(feeRateEditor.Editor.Text()), 10, 64)
+		if err != nil || feeRate <= 0 {
+			feeRateEditor.SetError(values.String(values.StrInvalidNumber))
+			return false
+		}
+
+		encoded, err := pg.AssetsManager.CreateMultisigPSBT(wallet.ID, addressEditor.Editor.Text(), amount, feeRate)
+		if err != nil {
+			addressEditor.SetError(err.Error())
+			return false
+		}
+		copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrPSBT), values.String(values.StrPSBTInfo), encoded)
+		return true
+	})
+}
+
+func (pg *MultisigPage) showSignModal(wallet *multisig.Wallet) {
+	psbtEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrPSBT))
+	editors := []*cryptomaterial.Editor{&psbtEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrSignPSBT), "", editors, nil, values.String(values.StrNext), func() bool {
+		encoded := psbtEditor.Editor.Text()
+		if _, err := multisig.DecodePSBT(encoded); err != nil {
+			psbtEditor.SetError(err.Error())
+			return false
+		}
+		pg.withPassphrase(wallet.SignerWalletID, func(passphrase string) error {
+			signed, err := pg.AssetsManager.SignMultisigPSBT(wallet.ID, encoded, passphrase)
+			if err != nil {
+				return err
+			}
+			copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrPSBT), values.String(values.StrPSBTInfo), signed)
+			return nil
+		})
+		return true
+	})
+}
+
+func (pg *MultisigPage) showBroadcastModal(wallet *multisig.Wallet) {
+	psbtsEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrSignedPSBTs))
+	editors := []*cryptomaterial.Editor{&psbtsEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrBroadcastPSBT), "", editors, nil, values.String(values.StrBroadcastPSBT), func() bool {
+		combined, complete, err := pg.AssetsManager.CombineMultisigPSBTs(wallet.ID, strings.Fields(psbtsEditor.Editor.Text())...)
+		if err != nil {
+			psbtsEditor.SetError(err.Error())
+			return false
+		}
+		if !complete {
+			copyTextModal(pg.Load, pg.ParentWindow(), values.String(values.StrPSBT), values.String(values.StrNeedMoreSignatures), combined)
+			return true
+		}
+
+		txHash, err := pg.AssetsManager.BroadcastMultisigPSBT(wallet.ID, combined)
+		if err != nil {
+			psbtsEditor.SetError(err.Error())
+			return false
+		}
+		successModal := modal.NewSuccessModal(pg.Load, values.StringF(values.StrTxBroadcast, txHash), modal.DefaultClickFunc())
+		pg.ParentWindow().ShowModal(successModal)
+		pg.loadWallets()
+		return true
+	})
+}
+
+func (pg *MultisigPage) showServerModal(wallet *multisig.Wallet) {
+	addressEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrElectrumAddress))
+	addressEditor.Editor.SingleLine = true
+	addressEditor.Editor.SetText(wallet.ElectrumServer)
+	certHint := values.String(values.StrRPCCertPath)
+	if wallet.ElectrumCert != "" {
+		certHint = values.String(values.StrRPCCertKept)
+	}
+	certEditor := pg.Theme.Editor(new(widget.Editor), certHint)
+	certEditor.Editor.SingleLine = true
+
+	editors := []*cryptomaterial.Editor{&addressEditor, &certEditor}
+	multisigFormModal(pg.Load, pg.ParentWindow(), values.String(values.StrUseElectrumServer), values.String(values.StrElectrumServerInfo), editors, nil,
+		values.String(values.StrSave), func() bool {
+			server := &sharedW.ElectrumServer{Address: addressEditor.Editor.Text(), Cert: wallet.ElectrumCert}
+			if certPath := strings.TrimSpace(certEditor.Editor.Text()); certPath != "" {
+				cert, err := os.ReadFile(certPath)
+				if err != nil {
+					certEditor.SetError(err.Error())
+					return false
+				}
+				server.Cert = string(cert)
+			}
+			if err := pg.AssetsManager.SetMultisigElectrumServer(wallet.ID, server); err != nil {
+				addressEditor.SetError(err.Error())
+				return false
+			}
+			pg.loadWallets()
+			return true
+		})
+}
+
+// copyTextModal shows the text with a button copying it.
+func copyTextModal(l *load.Load, window app.WindowNavigator, title, info, text string) {
+	copyBtn := l.Theme.OutlineButton(values.String(values.StrCopy))
+	textModal := modal.NewCustomModal(l).
+		Title(title).
+		UseCustomWidget(func(gtx C) D {
+			if copyBtn.Clicked(gtx) {
+				gtx.Execute(clipboard.WriteCmd{Data: io.NopCloser(strings.NewReader(text))})
+				l.Toast.Notify(values.String(values.StrCopied))
+			}
+
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if info == "" {
+						return D{}
+					}
+					lbl := l.Theme.Body2(info)
+					lbl.Color = l.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, l.Theme.Body1(text).Layout)
+				}),
+				layout.Rigid(copyBtn.Layout),
+			)
+		}).
+		SetPositiveButtonText(values.String(values.StrClose))
+	window.ShowModal(textModal)
+}
+
+func (pg *MultisigPage) removeWallet(wallet *multisig.Wallet) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRemove)).
+		Body(values.StringF(values.StrRemoveMultisigWalletMsg, wallet.Name)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrRemove)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			if err := pg.AssetsManager.Multisig.Delete(wallet.ID); err != nil {
+				pg.Toast.NotifyError(err.Error())
+				return true
+			}
+			delete(pg.controls, wallet.ID)
+			pg.loadWallets()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *MultisigPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrMultisigWallets),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return layout.Flex{}.Layout(gtx,
+								layout.Rigid(pg.createBtn.Layout),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.importBtn.Layout)
+								}),
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.cosignerKeyBtn.Layout)
+								}),
+							)
+						})
+					}),
+					layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, values.String(values.StrMultisigElectrumInfo))
+						lb.Color = pg.Theme.Color.GrayText2
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, lb.Layout)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						if len(pg.wallets) == 0 {
+							return layout.Center.Layout(gtx, pg.Theme.Body1(values.String(values.StrNoMultisigWallets)).Layout)
+						}
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.wallets), func(gtx C, i int) D {
+							return pg.walletLayout(gtx, pg.wallets[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *MultisigPage) walletLayout(gtx C, wallet *multisig.Wallet) D {
+	controls, ok := pg.controls[wallet.ID]
+	if !ok {
+		return D{}
+	}
+
+	summary := values.StringF(values.StrMultisigSummary, wallet.Threshold, len(wallet.Cosigners), wallet.Asset)
+	if wallet.WatchOnly() {
+		summary += " · " + values.String(values.StrWatchOnly)
+	}
+	pg.balancesMu.Lock()
+	balance := pg.balances[wallet.ID]
+	pg.balancesMu.Unlock()
+
+	serverTitle := values.String(values.StrSetElectrumServer)
+	if wallet.ElectrumServer != "" {
+		serverTitle = values.StringF(values.StrElectrumServerAt, wallet.ElectrumServer)
+	}
+
+	actions := []struct {
+		title     string
+		clickable *cryptomaterial.Clickable
+		show      bool
+	}{
+		{values.String(values.StrReceive), controls.receiveBtn, true},
+		{values.String(values.StrCreatePSBT), controls.sendBtn, true},
+		{values.String(values.StrSignPSBT), controls.signBtn, !wallet.WatchOnly()},
+		{values.String(values.StrBroadcastPSBT), controls.broadcastBtn, true},
+		{values.String(values.StrExport), controls.exportBtn, true},
+		{serverTitle, controls.serverBtn, true},
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				lb := pg.Theme.Label(values.TextSize16, wallet.Name)
+				lb.Font.Weight = font.SemiBold
+				return lb.Layout(gtx)
+			}, pg.Theme.Label(values.TextSize16, balance).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, summary)
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				children := make([]layout.FlexChild, 0, len(actions)+1)
+				for _, action := range actions {
+					if !action.show {
+						continue
+					}
+					action := action
+					children = append(children, layout.Rigid(func(gtx C) D {
+						lb := pg.Theme.Label(values.TextSize14, action.title)
+						lb.Color = pg.Theme.Color.Primary
+						return layout.Inset{Right: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+							return action.clickable.Layout(gtx, lb.Layout)
+						})
+					}))
+				}
+				children = append(children, layout.Rigid(func(gtx C) D {
+					lb := pg.Theme.Label(values.TextSize14, values.String(values.StrRemove))
+					lb.Color = pg.Theme.Color.Danger
+					return controls.removeBtn.Layout(gtx, lb.Layout)
+				}))
+				return layout.Flex{}.Layout(gtx, children...)
+			})
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *MultisigPage) OnNavigatedFrom() {}
@@ -0,0 +1,368 @@
+package settings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const PeersPageID = "Peers"
+
+// defaultBanHours is the ban duration suggested by the ban peer modal.
+const defaultBanHours = 24
+
+// PeersPage lists the connected, banned and preferred peers of a wallet and
+// allows banning peers and managing the preferred peers.
+type PeersPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet sharedW.Asset
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+	refreshBtn      cryptomaterial.Button
+	addPeerBtn      cryptomaterial.Button
+
+	connectedPeers []sharedW.PeerInfo
+	bannedPeers    []*sharedW.BannedPeer
+	preferredPeers []*sharedW.PreferredPeer
+
+	// The row buttons are keyed by the peer address, or host for the banned
+	// peers.
+	banBtns    map[string]*cryptomaterial.Clickable
+	unbanBtns  map[string]*cryptomaterial.Clickable
+	removeBtns map[string]*cryptomaterial.Clickable
+}
+
+func NewPeersPage(l *load.Load, wallet sharedW.Asset) *PeersPage {
+	return &PeersPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PeersPageID),
+		wallet:           wallet,
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		refreshBtn:       l.Theme.OutlineButton(values.String(values.StrRefresh)),
+		addPeerBtn:       l.Theme.Button(values.String(values.StrAddPreferredPeer)),
+		banBtns:          make(map[string]*cryptomaterial.Clickable),
+		unbanBtns:        make(map[string]*cryptomaterial.Clickable),
+		removeBtns:       make(map[string]*cryptomaterial.Clickable),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedTo() {
+	pg.loadPeers()
+}
+
+func (pg *PeersPage) loadPeers() {
+	connectedPeers, err := pg.wallet.PeerInfoRaw()
+	if err != nil {
+		// The wallet has no peers while it is not connected.
+		log.Debugf("Error loading connected peers: %v", err)
+	}
+	pg.connectedPeers = connectedPeers
+	pg.bannedPeers = pg.wallet.BannedPeers()
+	pg.preferredPeers = pg.wallet.PreferredPeers()
+
+	for _, peer := range pg.connectedPeers {
+		pg.clickable(pg.banBtns, peer.Addr)
+	}
+	for _, ban := range pg.bannedPeers {
+		pg.clickable(pg.unbanBtns, ban.Host)
+	}
+	for _, peer := range pg.preferredPeers {
+		pg.clickable(pg.removeBtns, peer.Address)
+	}
+}
+
+// clickable returns the button of the key, creating it if needed.
+func (pg *PeersPage) clickable(btns map[string]*cryptomaterial.Clickable, key string) *cryptomaterial.Clickable {
+	btn, ok := btns[key]
+	if !ok {
+		btn = pg.Theme.NewClickable(true)
+		btns[key] = btn
+	}
+	return btn
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PeersPage) HandleUserInteractions(gtx C) {
+	if pg.refreshBtn.Clicked(gtx) {
+		pg.loadPeers()
+	}
+
+	if pg.addPeerBtn.Clicked(gtx) {
+		pg.showAddPeerModal()
+	}
+
+	for _, peer := range pg.connectedPeers {
+		if pg.clickable(pg.banBtns, peer.Addr).Clicked(gtx) {
+			pg.showBanPeerModal(peer.Addr)
+		}
+	}
+
+	for _, ban := range pg.bannedPeers {
+		if pg.clickable(pg.unbanBtns, ban.Host).Clicked(gtx) {
+			if err := pg.wallet.UnbanPeer(ban.Host); err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.loadPeers()
+		}
+	}
+
+	for _, peer := range pg.preferredPeers {
+		if pg.clickable(pg.removeBtns, peer.Address).Clicked(gtx) {
+			if err := pg.wallet.RemovePreferredPeer(peer.Address); err != nil {
+				pg.Toast.NotifyError(err.Error())
+			}
+			pg.loadPeers()
+		}
+	}
+}
+
+func (pg *PeersPage) showBanPeerModal(address string) {
+	hoursEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrBanDurationHours))
+	hoursEditor.Editor.SingleLine = true
+	hoursEditor.Editor.SetText(strconv.Itoa(defaultBanHours))
+
+	banModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrBanPeer)).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lb := pg.Theme.Body1(values.StringF(values.StrBanPeerMsg, sharedW.PeerHost(address)))
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lb.Layout)
+				}),
+				layout.Rigid(hoursEditor.Layout),
+			)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrBan)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			hoursEditor.SetError("")
+			hours, err := strconv.ParseUint(strings.TrimSpace(hoursEditor.Editor.Text()), 10, 32)
+			if err != nil || hours == 0 {
+				hoursEditor.SetError(values.String(values.StrInvalidAmount))
+				return false
+			}
+
+			if err := pg.wallet.BanPeer(address, time.Duration(hours)*time.Hour); err != nil {
+				hoursEditor.SetError(err.Error())
+				return false
+			}
+
+			pg.loadPeers()
+			return true
+		})
+	pg.ParentWindow().ShowModal(banModal)
+}
+
+func (pg *PeersPage) showAddPeerModal() {
+	addressEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrIPAddress))
+	addressEditor.Editor.SingleLine = true
+
+	addPeerModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrAddPreferredPeer)).
+		UseCustomWidget(addressEditor.Layout).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrAdd)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			addressEditor.SetError("")
+			if err := pg.wallet.AddPreferredPeer(strings.TrimSpace(addressEditor.Editor.Text())); err != nil {
+				addressEditor.SetError(err.Error())
+				return false
+			}
+
+			pg.loadPeers()
+			return true
+		})
+	pg.ParentWindow().ShowModal(addPeerModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PeersPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrManagePeers),
+			SubTitle:   pg.wallet.GetWalletName(),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				sections := []layout.Widget{
+					pg.connectedPeersSection,
+					pg.bannedPeersSection,
+					pg.preferredPeersSection,
+				}
+				return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(sections), func(gtx C, i int) D {
+					return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, sections[i])
+				})
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *PeersPage) connectedPeersSection(gtx C) D {
+	rows := make([]layout.FlexChild, 0, len(pg.connectedPeers))
+	for _, peer := range pg.connectedPeers {
+		peer := peer
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			stats := values.StringF(values.StrPeerStats, peer.LastBlock, peer.Latency.Round(time.Millisecond),
+				formatBytes(peer.BytesSent), formatBytes(peer.BytesReceived))
+			return pg.peerRow(gtx, peer.Addr, peer.SubVer, stats, pg.clickable(pg.banBtns, peer.Addr), values.StrBan)
+		}))
+	}
+	return pg.section(gtx, values.StrConnectedPeers, values.StrNoConnectedPeers, pg.refreshBtn.Layout, rows)
+}
+
+func (pg *PeersPage) bannedPeersSection(gtx C) D {
+	rows := make([]layout.FlexChild, 0, len(pg.bannedPeers))
+	for _, ban := range pg.bannedPeers {
+		ban := ban
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			until := time.Unix(ban.Until, 0).Format(time.DateTime)
+			return pg.peerRow(gtx, ban.Host, values.StringF(values.StrBannedUntil, until), "",
+				pg.clickable(pg.unbanBtns, ban.Host), values.StrUnban)
+		}))
+	}
+	return pg.section(gtx, values.StrBannedPeers, values.StrNoBannedPeers, nil, rows)
+}
+
+func (pg *PeersPage) preferredPeersSection(gtx C) D {
+	rows := make([]layout.FlexChild, 0, len(pg.preferredPeers))
+	for _, peer := range pg.preferredPeers {
+		peer := peer
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			health := values.String(values.StrPeerUnhealthy)
+			if peer.Healthy() {
+				health = values.String(values.StrPeerHealthy)
+			}
+			return pg.peerRow(gtx, peer.Address, values.StringF(values.StrPeerScore, peer.Score, health), "",
+				pg.clickable(pg.removeBtns, peer.Address), values.StrRemove)
+		}))
+	}
+	return pg.section(gtx, values.StrPreferredPeers, values.StrNoPreferredPeers, pg.addPeerBtn.Layout, rows)
+}
+
+// section lays out a titled list of peer rows. The action is drawn next to
+// the title if set.
+func (pg *PeersPage) section(gtx C, title, emptyText string, action layout.Widget, rows []layout.FlexChild) D {
+	if len(rows) == 0 {
+		rows = append(rows, layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Body2(values.String(emptyText))
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}))
+	}
+
+	header := layout.Rigid(func(gtx C) D {
+		return components.EndToEndRow(gtx, func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, values.String(title))
+			lb.Font.Weight = font.SemiBold
+			return lb.Layout(gtx)
+		}, func(gtx C) D {
+			if action == nil {
+				return D{}
+			}
+			return action(gtx)
+		})
+	})
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx, append([]layout.FlexChild{header}, rows...)...)
+}
+
+func (pg *PeersPage) peerRow(gtx C, address, detail, stats string, btn *cryptomaterial.Clickable, btnText string) D {
+	return layout.Inset{Top: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+		return components.EndToEndRow(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Label(values.TextSize14, address).Layout),
+				layout.Rigid(func(gtx C) D {
+					lb := pg.Theme.Label(values.TextSize12, detail)
+					lb.Color = pg.Theme.Color.GrayText2
+					return lb.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if stats == "" {
+						return D{}
+					}
+					lb := pg.Theme.Label(values.TextSize12, stats)
+					lb.Color = pg.Theme.Color.GrayText2
+					return lb.Layout(gtx)
+				}),
+			)
+		}, func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, values.String(btnText))
+			lb.Color = pg.Theme.Color.Primary
+			if btnText == values.StrBan {
+				lb.Color = pg.Theme.Color.Danger
+			}
+			return btn.Layout(gtx, lb.Layout)
+		})
+	})
+}
+
+// formatBytes formats the byte count with a binary unit.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PeersPage) OnNavigatedFrom() {}
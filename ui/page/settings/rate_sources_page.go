@@ -0,0 +1,248 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/libwallet/ext"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/modal"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const RateSourcesPageID = "RateSources"
+
+// RateSourcesPage displays the health of the exchange rate sources and allows
+// adding and removing user defined sources.
+type RateSourcesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+	addSourceBtn    cryptomaterial.Button
+
+	sources    []ext.SourceHealth
+	removeBtns map[string]*cryptomaterial.Clickable
+}
+
+func NewRateSourcesPage(l *load.Load) *RateSourcesPage {
+	return &RateSourcesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(RateSourcesPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+		addSourceBtn:     l.Theme.Button(values.String(values.StrAddRateSource)),
+		removeBtns:       make(map[string]*cryptomaterial.Clickable),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *RateSourcesPage) OnNavigatedTo() {
+	pg.loadSources()
+}
+
+func (pg *RateSourcesPage) loadSources() {
+	pg.sources = pg.AssetsManager.RateSource.SourcesHealth()
+	for _, source := range pg.sources {
+		if _, ok := pg.removeBtns[source.Name]; source.Custom && !ok {
+			pg.removeBtns[source.Name] = pg.Theme.NewClickable(true)
+		}
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *RateSourcesPage) HandleUserInteractions(gtx C) {
+	pg.loadSources()
+
+	if pg.addSourceBtn.Clicked(gtx) {
+		pg.showAddSourceModal()
+	}
+
+	for name, removeBtn := range pg.removeBtns {
+		if removeBtn.Clicked(gtx) {
+			pg.removeSource(name)
+		}
+	}
+}
+
+func (pg *RateSourcesPage) showAddSourceModal() {
+	nameEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrName))
+	nameEditor.Editor.SingleLine = true
+	urlEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrURLTemplate))
+	urlEditor.Editor.SingleLine = true
+	priceEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrPricePath))
+	priceEditor.Editor.SingleLine = true
+	volumeEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrVolumePath))
+	volumeEditor.Editor.SingleLine = true
+
+	editors := []*cryptomaterial.Editor{&nameEditor, &urlEditor, &priceEditor, &volumeEditor}
+	addSourceModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrAddRateSource)).
+		UseCustomWidget(func(gtx C) D {
+			children := make([]layout.FlexChild, 0, len(editors))
+			for _, editor := range editors {
+				editor := editor
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, editor.Layout)
+				}))
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrAdd)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			cfg := ext.HTTPSourceConfig{
+				Name:        nameEditor.Editor.Text(),
+				URLTemplate: urlEditor.Editor.Text(),
+				PricePath:   priceEditor.Editor.Text(),
+				VolumePath:  volumeEditor.Editor.Text(),
+			}
+			if err := pg.AssetsManager.AddCustomRateSource(cfg); err != nil {
+				nameEditor.SetError(err.Error())
+				return false
+			}
+
+			pg.loadSources()
+			return true
+		})
+	pg.ParentWindow().ShowModal(addSourceModal)
+}
+
+func (pg *RateSourcesPage) removeSource(name string) {
+	confirmModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrRemove)).
+		Body(values.StringF(values.StrRemoveRateSourceMsg, name)).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		SetPositiveButtonText(values.String(values.StrRemove)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			pg.AssetsManager.RemoveCustomRateSource(name)
+			delete(pg.removeBtns, name)
+			pg.loadSources()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *RateSourcesPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrRateSources),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.addSourceBtn.Layout)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.sources), func(gtx C, i int) D {
+							return pg.sourceLayout(gtx, pg.sources[i])
+						})
+					}),
+				)
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	// Refresh the source health every second.
+	gtx.Execute(op.InvalidateCmd{At: time.Now().Add(time.Second)})
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *RateSourcesPage) sourceLayout(gtx C, source ext.SourceHealth) D {
+	statusText, statusColor := values.String(values.StrSourceNotUsed), pg.Theme.Color.GrayText2
+	switch {
+	case source.Healthy():
+		statusText, statusColor = values.String(values.StrSourceHealthy), pg.Theme.Color.GreenText
+	case source.LastError != "":
+		statusText = fmt.Sprintf("%s: %s", values.String(values.StrLastError), source.LastError)
+		statusColor = pg.Theme.Color.Danger
+	}
+
+	lastSuccess := "--"
+	if !source.LastSuccess.IsZero() {
+		lastSuccess = source.LastSuccess.Format(time.DateTime)
+	}
+
+	return cryptomaterial.LinearLayout{
+		Width:       cryptomaterial.MatchParent,
+		Height:      cryptomaterial.WrapContent,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+		Margin:      layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5},
+		Background:  pg.Theme.Color.Surface,
+		Border:      cryptomaterial.Border{Radius: cryptomaterial.Radius(8)},
+		Orientation: layout.Vertical,
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				name := source.Name
+				if source.Custom {
+					name = fmt.Sprintf("%s (%s)", name, values.String(values.StrCustomSource))
+				}
+				lb := pg.Theme.Label(values.TextSize16, name)
+				lb.Font.Weight = font.SemiBold
+				return lb.Layout(gtx)
+			}, func(gtx C) D {
+				removeBtn, ok := pg.removeBtns[source.Name]
+				if !source.Custom || !ok {
+					return D{}
+				}
+				lb := pg.Theme.Label(values.TextSize14, values.String(values.StrRemove))
+				lb.Color = pg.Theme.Color.Danger
+				return removeBtn.Layout(gtx, lb.Layout)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, statusText)
+			lb.Color = statusColor
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, lb.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, fmt.Sprintf("%s: %s · %s: %d",
+				values.String(values.StrLastSuccess), lastSuccess,
+				values.String(values.StrConsecutiveFailures), source.Failures))
+			lb.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, lb.Layout)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *RateSourcesPage) OnNavigatedFrom() {}
@@ -0,0 +1,138 @@
+package settings
+
+import (
+	"strings"
+
+	"gioui.org/font"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/crypto-power/cryptopower/app"
+	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
+	"github.com/crypto-power/cryptopower/ui/load"
+	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/preference"
+	"github.com/crypto-power/cryptopower/ui/values"
+)
+
+const TranslationsPageID = "Translations"
+
+type languageCoverage struct {
+	lang        string
+	name        string
+	missingKeys []string
+	collapsible *cryptomaterial.Collapsible
+}
+
+// TranslationsPage lists the keys each language has no string for, including
+// the languages added by locale packs.
+type TranslationsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	scrollContainer *widget.List
+	backButton      cryptomaterial.IconButton
+
+	languages []*languageCoverage
+}
+
+func NewTranslationsPage(l *load.Load) *TranslationsPage {
+	return &TranslationsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(TranslationsPageID),
+		scrollContainer:  &widget.List{List: layout.List{Axis: layout.Vertical}},
+		backButton:       components.GetBackButton(l),
+	}
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *TranslationsPage) OnNavigatedTo() {
+	pg.languages = nil
+	for _, opt := range preference.LanguageOptions() {
+		if opt.Key == values.DefaultLanguage {
+			continue
+		}
+		pg.languages = append(pg.languages, &languageCoverage{
+			lang:        opt.Key,
+			name:        values.String(opt.Value),
+			missingKeys: values.MissingKeys(opt.Key),
+			collapsible: pg.Theme.Collapsible(),
+		})
+	}
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *TranslationsPage) HandleUserInteractions(_ C) {}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *TranslationsPage) Layout(gtx C) D {
+	container := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      values.String(values.StrTranslations),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return pg.Theme.List(pg.scrollContainer).Layout(gtx, len(pg.languages), func(gtx C, i int) D {
+					return pg.languageLayout(gtx, pg.languages[i])
+				})
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+
+	if pg.Load.IsMobileView() {
+		return components.UniformMobile(gtx, false, true, container)
+	}
+	return container(gtx)
+}
+
+func (pg *TranslationsPage) languageLayout(gtx C, coverage *languageCoverage) D {
+	header := func(gtx C) D {
+		return components.EndToEndRow(gtx, func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize16, coverage.name)
+			lb.Font.Weight = font.SemiBold
+			return lb.Layout(gtx)
+		}, func(gtx C) D {
+			lb := pg.Theme.Label(values.TextSize14, values.PluralF(values.StrMissingKeys, len(coverage.missingKeys)))
+			lb.Color = pg.Theme.Color.GrayText2
+			return lb.Layout(gtx)
+		})
+	}
+
+	body := func(gtx C) D {
+		keys := values.String(values.StrAllKeysTranslated)
+		if len(coverage.missingKeys) > 0 {
+			keys = strings.Join(coverage.missingKeys, "\n")
+		}
+		return pg.Theme.Body2(keys).Layout(gtx)
+	}
+
+	return layout.Inset{Top: values.MarginPadding5, Bottom: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+		return coverage.collapsible.Layout(gtx, header, body)
+	})
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *TranslationsPage) OnNavigatedFrom() {}
@@ -158,12 +158,12 @@ func (sp *startPage) OnNavigatedTo() {
 }
 
 func (sp *startPage) initPage() {
-	sp.languageDropdown = sp.Theme.NewCommonDropDown([]cryptomaterial.DropDownItem{
-		{Text: titler.String(values.StrEnglish)},
-		{Text: titler.String(values.StrFrench)},
-		{Text: titler.String(values.StrSpanish)},
-		{Text: titler.String(values.StrChinese)},
-	}, nil, values.MarginPadding120, values.StartPageDropdownGroup, false)
+	langOptions := preference.LanguageOptions()
+	languageItems := make([]cryptomaterial.DropDownItem, 0, len(langOptions))
+	for _, opt := range langOptions {
+		languageItems = append(languageItems, cryptomaterial.DropDownItem{Text: titler.String(opt.Value)})
+	}
+	sp.languageDropdown = sp.Theme.NewCommonDropDown(languageItems, nil, values.MarginPadding120, values.StartPageDropdownGroup, false)
 
 	sp.onBoardingScreens = []onBoardingScreen{
 		{
@@ -313,7 +313,7 @@ func (sp *startPage) HandleUserInteractions(gtx C) {
 
 	if sp.languageDropdown.Changed(gtx) {
 		// Refresh the user language now.
-		values.SetUserLanguage(sp.selectedLanguageKey())
+		sp.SetUserLanguage(sp.selectedLanguageKey())
 		sp.RefreshTheme(sp.ParentWindow())
 	}
 
@@ -718,13 +718,13 @@ func (sp *startPage) setLanguagePref(useExistingUserPreference bool) {
 		lang = values.DefaultLanguage
 	}
 	sp.AssetsManager.SetLanguagePreference(lang)
-	values.SetUserLanguage(lang)
+	sp.SetUserLanguage(lang)
 }
 
 func (sp *startPage) selectedLanguageKey() string {
 	selectedLang := sp.languageDropdown.Selected()
-	for _, opt := range preference.LangOptions {
-		if strings.ToLower(selectedLang) == opt.Value {
+	for _, opt := range preference.LanguageOptions() {
+		if strings.EqualFold(selectedLang, opt.Value) {
 			return opt.Key
 		}
 	}
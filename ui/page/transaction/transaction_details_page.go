@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"decred.org/dcrdex/client/asset"
+	"decred.org/dcrdex/dex"
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -23,6 +25,7 @@ import (
 	"github.com/crypto-power/cryptopower/ui/load"
 	"github.com/crypto-power/cryptopower/ui/modal"
 	"github.com/crypto-power/cryptopower/ui/page/components"
+	"github.com/crypto-power/cryptopower/ui/page/dcrdex"
 	pageutils "github.com/crypto-power/cryptopower/ui/utils"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
@@ -89,10 +92,21 @@ type TxDetailsPage struct {
 	title                                 string
 	vspHost                               string
 	vspHostFees                           string
+	dexBondInfo                           string
+	dexOrderID                            string
+	dexOrderClickable                     *cryptomaterial.Clickable
+	// dexTxInfoCh delivers the result of checkDEXTx to the UI goroutine.
+	dexTxInfoCh chan dexTxInfo
 
 	moreOptionIsOpen bool
 }
 
+// dexTxInfo describes the DEX bond or order a transaction belongs to.
+type dexTxInfo struct {
+	bondInfo string
+	orderID  string
+}
+
 func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *sharedW.Transaction) *TxDetailsPage {
 	rebroadcast := l.Theme.Label(values.TextSize14, values.String(values.StrRebroadcast))
 	rebroadcast.TextSize = values.TextSize14
@@ -118,6 +132,8 @@ func NewTransactionDetailsPage(l *load.Load, wallet sharedW.Asset, transaction *
 
 		associatedTicketClickable: l.Theme.NewClickable(true),
 		hashClickable:             l.Theme.NewClickable(true),
+		dexOrderClickable:         l.Theme.NewClickable(true),
+		dexTxInfoCh:               make(chan dexTxInfo, 1),
 		destAddressClickables:     make([]*cryptomaterial.Clickable, 0),
 		moreOption:                l.Theme.NewClickable(false),
 		shadowBox:                 l.Theme.Shadow(),
@@ -222,6 +238,10 @@ destinationAddrLoop:
 // the page is displayed.
 // Part of the load.Page interface.
 func (pg *TxDetailsPage) OnNavigatedTo() {
+	if pg.AssetsManager.DEXCInitialized() {
+		go pg.checkDEXTx()
+	}
+
 	if dcrImp, ok := pg.wallet.(*dcr.Asset); ok {
 		// this tx is a vote transaction
 		if pg.transaction.TicketSpentHash != "" {
@@ -790,6 +810,23 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx C) D {
 			}
 			return pg.keyValue(gtx, values.String(values.StrTransactionID), dim)
 		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.dexBondInfo == "" {
+				return D{}
+			}
+			return pg.keyValue(gtx, values.String(values.StrDEXBond), pg.Theme.Label(values.TextSize14, pg.dexBondInfo).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.dexOrderID == "" {
+				return D{}
+			}
+
+			lbl := pg.Theme.Label(values.TextSize14, pageutils.SplitSingleString(pg.dexOrderID, 30))
+			lbl.Color = pg.Theme.Color.Primary
+			return pg.keyValue(gtx, values.String(values.StrDEXOrder), func(gtx C) D {
+				return pg.dexOrderClickable.Layout(gtx, lbl.Layout)
+			})
+		}),
 		layout.Rigid(func(gtx C) D {
 			if len(pg.transaction.Label) != 0 {
 				txlabel := pg.Theme.Label(values.TextSize14, pg.transaction.Label)
@@ -800,6 +837,51 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx C) D {
 	)
 }
 
+// checkDEXTx checks if the transaction posted or refunded a DEX bond, or was
+// created by a DEX trade, using the transaction history of the DEX wallet. The
+// result is applied by HandleUserInteractions.
+func (pg *TxDetailsPage) checkDEXTx() {
+	assetID, ok := dex.BipSymbolID(pg.wallet.GetAssetType().ToStringLower())
+	if !ok {
+		return
+	}
+
+	dexClient := pg.AssetsManager.DexClient()
+	walletID, err := dexClient.WalletIDForAsset(assetID)
+	if err != nil || walletID == nil || *walletID != pg.wallet.GetWalletID() {
+		return // not the DEX wallet
+	}
+
+	if bondTx := dexClient.BondTransaction(assetID, pg.transaction.Hash); bondTx != nil && bondTx.BondInfo != nil {
+		info := dexTxInfo{bondInfo: values.String(values.StrDEXBondRefund)}
+		if bondTx.Type != asset.RedeemBond {
+			lockTime := time.Unix(int64(bondTx.BondInfo.LockTime), 0)
+			info.bondInfo = values.StringF(values.StrDEXBondPosted, lockTime.Format("2006-01-02 15:04"))
+		}
+		pg.postDEXTxInfo(info)
+		return
+	}
+
+	orderID, err := dexClient.OrderIDForTx(assetID, pg.transaction.Hash)
+	if err != nil {
+		log.Errorf("Error checking DEX order for tx %s: %v", pg.transaction.Hash, err)
+		return
+	}
+	if orderID != "" {
+		pg.postDEXTxInfo(dexTxInfo{orderID: orderID})
+	}
+}
+
+// postDEXTxInfo hands info to the UI goroutine. An unread result of an earlier
+// check is for the same transaction, so info is dropped if one is pending.
+func (pg *TxDetailsPage) postDEXTxInfo(info dexTxInfo) {
+	select {
+	case pg.dexTxInfoCh <- info:
+		pg.ParentWindow().Reload()
+	default:
+	}
+}
+
 func (pg *TxDetailsPage) txnInputs(gtx C) D {
 	transaction := pg.transaction
 
@@ -951,6 +1033,12 @@ func (pg *TxDetailsPage) pageSections(gtx C, body layout.Widget) D {
 // displayed.
 // Part of the load.Page interface.
 func (pg *TxDetailsPage) HandleUserInteractions(gtx C) {
+	select {
+	case info := <-pg.dexTxInfoCh:
+		pg.dexBondInfo, pg.dexOrderID = info.bondInfo, info.orderID
+	default:
+	}
+
 	for _, item := range pg.moreItems {
 		if item.button.Clicked(gtx) {
 			switch item.id {
@@ -966,6 +1054,10 @@ func (pg *TxDetailsPage) HandleUserInteractions(gtx C) {
 		pg.moreOptionIsOpen = !pg.moreOptionIsOpen
 	}
 
+	if pg.dexOrderClickable.Clicked(gtx) {
+		pg.ParentNavigator().Display(dcrdex.NewDEXTradeHistoryPage(pg.Load, "", pg.dexOrderID))
+	}
+
 	if pg.associatedTicketClickable.Clicked(gtx) {
 		if pg.ticketSpent != nil {
 			pg.txBackStack = pg.transaction
@@ -3,8 +3,6 @@ package wallet
 import (
 	"context"
 	"fmt"
-	"path/filepath"
-	"strconv"
 
 	"gioui.org/io/event"
 	"gioui.org/io/key"
@@ -226,8 +224,10 @@ func (swmp *SingleWalletMasterPage) initTabOptions() {
 		values.StrSettings,
 	}
 
-	if !swmp.selectedWallet.IsWatchingOnlyWallet() {
-		// Add 'Send' to the tabs for non-watching-only wallets.
+	if swmp.selectedWallet.CanSign() {
+		// Add 'Send' to the tabs for wallets that can sign, which are the
+		// non-watching-only wallets and those with a hardware or an offline
+		// signer.
 		sendTab := []string{values.StrSend}
 		// Insert 'Send' after 'StrInfo'.
 		commonTabs = append(commonTabs[:1], append(sendTab, commonTabs[1:]...)...)
@@ -250,7 +250,7 @@ func (swmp *SingleWalletMasterPage) initTabOptions() {
 		insertIndex := 3 // Default position before 'StrAccounts' in the commonTabs.
 
 		// If 'Send' has been added, adjust the insertIndex accordingly.
-		if !swmp.selectedWallet.IsWatchingOnlyWallet() {
+		if swmp.selectedWallet.CanSign() {
 			insertIndex++
 		}
 
@@ -313,7 +313,7 @@ func (swmp *SingleWalletMasterPage) updateBalance() {
 	}
 	swmp.walletBalance = totalBalance.Total
 	balanceInUSD := totalBalance.Total.MulF64(swmp.usdExchangeRate).ToCoin()
-	swmp.totalBalanceUSD = utils.FormatAsUSDString(swmp.Printer, balanceInUSD)
+	swmp.totalBalanceUSD = utils.FormatUSDAsFiat(swmp.Printer, swmp.AssetsManager, balanceInUSD)
 }
 
 // OnDarkModeChanged is triggered whenever the dark mode setting is changed
@@ -716,67 +716,6 @@ func (swmp *SingleWalletMasterPage) totalAssetBalance(gtx C) D {
 	return components.LayoutBalanceWithUnitSize(gtx, swmp.Load, swmp.walletBalance.String(), textSize)
 }
 
-func (swmp *SingleWalletMasterPage) postTransactionNotification(t *sharedW.Transaction) {
-	var notification string
-	wal := swmp.selectedWallet
-	switch t.Type {
-	case dcr.TxTypeRegular:
-		if t.Direction != dcr.TxDirectionReceived {
-			return
-		}
-		// remove trailing zeros from amount and convert to string
-		amount := strconv.FormatFloat(wal.ToAmount(t.Amount).ToCoin(), 'f', -1, 64)
-		notification = values.StringF(values.StrDcrReceived, amount)
-	case dcr.TxTypeVote:
-		reward := strconv.FormatFloat(wal.ToAmount(t.VoteReward).ToCoin(), 'f', -1, 64)
-		notification = values.StringF(values.StrTicketVoted, reward)
-	case dcr.TxTypeRevocation:
-		notification = values.String(values.StrTicketRevoked)
-	default:
-		return
-	}
-
-	if swmp.AssetsManager.OpenedWalletsCount() > 1 {
-		notification = fmt.Sprintf("[%s] %s", wal.GetWalletName(), notification)
-	}
-
-	initializeBeepNotification(notification)
-}
-
-func (swmp *SingleWalletMasterPage) postProposalNotification(propName string, status libutils.ProposalStatus) {
-	proposalNotification := swmp.selectedWallet.ReadBoolConfigValueForKey(sharedW.ProposalNotificationConfigKey, false) ||
-		!swmp.AssetsManager.IsPrivacyModeOn()
-	if !proposalNotification {
-		return
-	}
-
-	var notification string
-	switch status {
-	case libutils.ProposalStatusNewProposal:
-		notification = values.StringF(values.StrProposalAddedNotif, propName)
-	case libutils.ProposalStatusVoteStarted:
-		notification = values.StringF(values.StrVoteStartedNotif, propName)
-	case libutils.ProposalStatusVoteFinished:
-		notification = values.StringF(values.StrVoteEndedNotif, propName)
-	default:
-		notification = values.StringF(values.StrNewProposalUpdate, propName)
-	}
-	initializeBeepNotification(notification)
-}
-
-func initializeBeepNotification(n string) {
-	absoluteWdPath, err := utils.GetAbsolutePath()
-	if err != nil {
-		log.Error(err.Error())
-	}
-
-	err = beeep.Notify(values.String(values.StrAppWallet), n,
-		filepath.Join(absoluteWdPath, "ui/assets/decredicons/ic_dcr_qr.png"))
-	if err != nil {
-		log.Info("could not initiate desktop notification, reason:", err.Error())
-	}
-}
-
 // listenForNotifications starts a goroutine to watch for notifications
 // and update the UI accordingly.
 func (swmp *SingleWalletMasterPage) listenForNotifications(listenForSubpage func(int)) {
@@ -793,15 +732,8 @@ func (swmp *SingleWalletMasterPage) listenForNotifications(listenForSubpage func
 	}
 
 	txAndBlockNotificationListener := &sharedW.TxAndBlockNotificationListener{
-		OnTransaction: func(walletID int, transaction *sharedW.Transaction) {
+		OnTransaction: func(walletID int, _ *sharedW.Transaction) {
 			swmp.updateBalance()
-			if swmp.AssetsManager.IsTransactionNotificationsOn() {
-				// TODO: SPV wallets only receive mempool tx ntfn for txs that
-				// were broadcast by the wallet. We should probably be posting
-				// desktop ntfns for txs received from external parties, which
-				// will can be gotten from the OnTransactionConfirmed callback.
-				swmp.postTransactionNotification(transaction)
-			}
 			swmp.ParentWindow().Reload()
 			listenForSubpage(walletID)
 		},
@@ -829,29 +761,11 @@ func (swmp *SingleWalletMasterPage) listenForNotifications(listenForSubpage func
 		log.Errorf("Error adding tx and block notification listener: %v", err)
 		return
 	}
-
-	if swmp.isGovernanceAPIAllowed() {
-		proposalSyncCallback := func(propName string, status libutils.ProposalStatus) {
-			// Post desktop notification for all events except the synced event.
-			if status != libutils.ProposalStatusSynced {
-				swmp.postProposalNotification(propName, status)
-			}
-		}
-		err = swmp.AssetsManager.Politeia.AddSyncCallback(proposalSyncCallback, MainPageID)
-		if err != nil {
-			log.Errorf("Error adding politeia notification listener: %v", err)
-			return
-		}
-	}
-
-	// TODO: Register trade order ntfn listener and post desktop ntfns for all
-	// events except the synced event.
 }
 
 func (swmp *SingleWalletMasterPage) stopNtfnListeners() {
 	swmp.selectedWallet.RemoveSyncProgressListener(MainPageID)
 	swmp.selectedWallet.RemoveTxAndBlockNotificationListener(MainPageID)
-	swmp.AssetsManager.Politeia.RemoveSyncCallback(MainPageID)
 }
 
 func (swmp *SingleWalletMasterPage) showBackupInfo() {
@@ -1,11 +1,15 @@
 package wallet
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"decred.org/dcrdex/dex"
 	"gioui.org/font"
@@ -15,6 +19,7 @@ import (
 	"github.com/crypto-power/cryptopower/app"
 	"github.com/crypto-power/cryptopower/libwallet/assets/dcr"
 	sharedW "github.com/crypto-power/cryptopower/libwallet/assets/wallet"
+	"github.com/crypto-power/cryptopower/libwallet/trezor"
 	libutils "github.com/crypto-power/cryptopower/libwallet/utils"
 	"github.com/crypto-power/cryptopower/ui/cryptomaterial"
 	"github.com/crypto-power/cryptopower/ui/load"
@@ -59,6 +64,9 @@ type SettingsPage struct {
 	changeWalletName, addAccount, deleteWallet *cryptomaterial.Clickable
 	verifyMessage, validateAddr, signMessage   *cryptomaterial.Clickable
 	updateConnectToPeer, setGapLimit           *cryptomaterial.Clickable
+	networkMode, managePeers                   *cryptomaterial.Clickable
+	multisigAccounts, hardwareSigner           *cryptomaterial.Clickable
+	signOfflineTx                              *cryptomaterial.Clickable
 
 	backButton cryptomaterial.IconButton
 	infoButton cryptomaterial.IconButton
@@ -66,6 +74,7 @@ type SettingsPage struct {
 	spendUnconfirmed  *cryptomaterial.Switch
 	spendUnmixedFunds *cryptomaterial.Switch
 	connectToPeer     *cryptomaterial.Switch
+	airgapSigner      *cryptomaterial.Switch
 
 	walletCallbackFunc func()
 	changeTab          func(string)
@@ -92,8 +101,14 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 		validateAddr:        l.Theme.NewClickable(false),
 		signMessage:         l.Theme.NewClickable(false),
 		updateConnectToPeer: l.Theme.NewClickable(false),
+		networkMode:         l.Theme.NewClickable(false),
+		managePeers:         l.Theme.NewClickable(false),
+		multisigAccounts:    l.Theme.NewClickable(false),
+		hardwareSigner:      l.Theme.NewClickable(false),
+		signOfflineTx:       l.Theme.NewClickable(false),
 
 		spendUnconfirmed:  l.Theme.Switch(),
+		airgapSigner:      l.Theme.Switch(),
 		spendUnmixedFunds: l.Theme.Switch(),
 		connectToPeer:     l.Theme.Switch(),
 
@@ -117,6 +132,7 @@ func NewSettingsPage(l *load.Load, wallet sharedW.Asset, walletCallbackFunc func
 func (pg *SettingsPage) OnNavigatedTo() {
 	pg.spendUnconfirmed.SetChecked(pg.readBool(sharedW.SpendUnconfirmedConfigKey))
 	pg.spendUnmixedFunds.SetChecked(pg.readBool(sharedW.SpendUnmixedFundsKey))
+	pg.airgapSigner.SetChecked(pg.readBool(sharedW.AirgapSignerConfigKey))
 
 	pg.loadPeerAddress()
 
@@ -203,6 +219,64 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 				return D{}
 			}),
 			layout.Rigid(func(gtx C) D {
+				if pg.wallet.GetAssetType() != libutils.DCRWalletAsset || pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return pg.sectionDimension(gtx, pg.multisigAccounts, values.String(values.StrMultisigAccounts))
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				hardwareSignerRow := clickableRowData{
+					title:     values.String(values.StrHardwareSigner),
+					clickable: pg.hardwareSigner,
+					labelText: values.String(values.StrNone),
+				}
+				if cfg := pg.wallet.HardwareSigner(); cfg != nil {
+					hardwareSignerRow.labelText = "Trezor"
+				}
+				return pg.clickableRow(gtx, hardwareSignerRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				// Watch-only wallets without a hardware signer may have
+				// their transactions signed by an offline wallet.
+				if !pg.wallet.IsWatchingOnlyWallet() || pg.wallet.HardwareSigner() != nil {
+					return D{}
+				}
+				return pg.subSection(gtx, values.String(values.StrAirgapSigner), pg.airgapSigner.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				// Wallets holding their seed sign the transactions of
+				// their watch-only wallets offline.
+				if pg.wallet.IsWatchingOnlyWallet() {
+					return D{}
+				}
+				return pg.sectionDimension(gtx, pg.signOfflineTx, values.String(values.StrSignOfflineTx))
+			}),
+			layout.Rigid(func(gtx C) D {
+				networkModeRow := clickableRowData{
+					title:     values.String(values.StrNetworkMode),
+					clickable: pg.networkMode,
+					labelText: values.String(values.StrSPV),
+				}
+				switch pg.wallet.NetworkMode() {
+				case sharedW.NetworkModeRPC:
+					if node := pg.wallet.RPCNode(); node != nil {
+						networkModeRow.labelText = values.StringF(values.StrFullNodeAt, node.Address)
+					}
+				case sharedW.NetworkModeElectrum:
+					if server := pg.wallet.ElectrumServer(); server != nil {
+						networkModeRow.labelText = values.StringF(values.StrElectrumServerAt, server.Address)
+					}
+				}
+				return pg.clickableRow(gtx, networkModeRow)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.wallet.NetworkMode() != sharedW.NetworkModeSPV {
+					// Peers are only used by the SPV sync.
+					return D{}
+				}
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(pg.subSectionSwitch(values.String(values.StrConnectToSpecificPeer), pg.connectToPeer)),
 					layout.Rigid(func(gtx C) D {
@@ -217,6 +291,7 @@ func (pg *SettingsPage) generalSection() layout.Widget {
 						}
 						return pg.clickableRow(gtx, peerAddrRow)
 					}),
+					layout.Rigid(pg.sectionContent(pg.managePeers, values.String(values.StrManagePeers))),
 				)
 			}),
 		)
@@ -251,10 +326,7 @@ func (pg *SettingsPage) debug() layout.Widget {
 			}),
 			layout.Rigid(pg.sectionContent(pg.rescan, values.String(values.StrRescanBlockchain))),
 			layout.Rigid(func(gtx C) D {
-				if pg.wallet.GetAssetType() == libutils.DCRWalletAsset {
-					return pg.sectionDimension(gtx, pg.setGapLimit, values.String(values.StrSetGapLimit))
-				}
-				return D{}
+				return pg.sectionDimension(gtx, pg.setGapLimit, values.String(values.StrSetGapLimit))
 			}),
 			layout.Rigid(pg.sectionContent(pg.checklog, values.String(values.StrViewLog))),
 			layout.Rigid(pg.sectionContent(pg.checkStats, values.String(values.StrViewStats))),
@@ -592,6 +664,346 @@ func (pg *SettingsPage) showSPVPeerDialog() {
 	pg.ParentWindow().ShowModal(textModal)
 }
 
+func (pg *SettingsPage) showNetworkModeDialog() {
+	useFullNode := pg.Theme.Switch()
+	addressEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRPCAddress))
+	userEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRPCUser))
+	passwordEditor := pg.Theme.EditorPassword(new(widget.Editor), values.String(values.StrRPCPassword))
+	current := pg.wallet.RPCNode()
+	certHint := values.String(values.StrRPCCertPath)
+	if current != nil && current.Cert != "" {
+		certHint = values.String(values.StrRPCCertKept)
+	}
+	certEditor := pg.Theme.Editor(new(widget.Editor), certHint)
+	editors := []*cryptomaterial.Editor{&addressEditor, &userEditor, &passwordEditor, &certEditor}
+	for _, editor := range editors {
+		editor.Editor.SingleLine = true
+	}
+
+	useFullNode.SetChecked(pg.wallet.NetworkMode() == sharedW.NetworkModeRPC)
+	useBitcoind := pg.Theme.Switch()
+	if current != nil {
+		useBitcoind.SetChecked(current.Bitcoind())
+		addressEditor.Editor.SetText(current.Address)
+		userEditor.Editor.SetText(current.User)
+		passwordEditor.Editor.SetText(current.Password)
+	}
+
+	// Electrum servers only serve the BTC and LTC chains.
+	supportsElectrum := pg.wallet.GetAssetType() != libutils.DCRWalletAsset
+	useElectrum := pg.Theme.Switch()
+	electrumAddressEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrElectrumAddress))
+	currentElectrum := pg.wallet.ElectrumServer()
+	electrumCertHint := values.String(values.StrRPCCertPath)
+	if currentElectrum != nil && currentElectrum.Cert != "" {
+		electrumCertHint = values.String(values.StrRPCCertKept)
+	}
+	electrumCertEditor := pg.Theme.Editor(new(widget.Editor), electrumCertHint)
+	electrumEditors := []*cryptomaterial.Editor{&electrumAddressEditor, &electrumCertEditor}
+	for _, editor := range electrumEditors {
+		editor.Editor.SingleLine = true
+	}
+
+	useElectrum.SetChecked(pg.wallet.NetworkMode() == sharedW.NetworkModeElectrum)
+	if currentElectrum != nil {
+		electrumAddressEditor.Editor.SetText(currentElectrum.Address)
+	}
+
+	// readCert returns the content of the certificate file of the editor, or
+	// the current certificate if the editor is empty.
+	readCert := func(editor *cryptomaterial.Editor, current string) (string, bool) {
+		certPath := strings.TrimSpace(editor.Editor.Text())
+		if certPath == "" {
+			return current, true
+		}
+		cert, err := os.ReadFile(certPath)
+		if err != nil {
+			editor.SetError(err.Error())
+			return "", false
+		}
+		return string(cert), true
+	}
+
+	networkModeModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrNetworkMode)).
+		UseCustomWidget(func(gtx C) D {
+			// The full node and the Electrum server are exclusive.
+			if useFullNode.Changed(gtx) && useFullNode.IsChecked() {
+				useElectrum.SetChecked(false)
+			}
+			if useElectrum.Changed(gtx) && useElectrum.IsChecked() {
+				useFullNode.SetChecked(false)
+			}
+
+			var children []layout.FlexChild
+			addInfo := func(info string) {
+				children = append(children, layout.Rigid(func(gtx C) D {
+					lbl := pg.Theme.Body2(info)
+					lbl.Color = pg.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+				}))
+			}
+			addOption := func(title, info string, option *cryptomaterial.Switch, optionEditors []*cryptomaterial.Editor) {
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+						return components.EndToEndRow(gtx, pg.Theme.Body1(title).Layout, option.Layout)
+					})
+				}))
+				if !option.IsChecked() {
+					return
+				}
+
+				addInfo(info)
+				if option == useFullNode && supportsElectrum {
+					// The BTC and LTC wallets may also sync with a bitcoind
+					// or litecoind node, which does not serve TLS.
+					children = append(children, layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+							return components.EndToEndRow(gtx, pg.Theme.Body2(values.String(values.StrUseBitcoind)).Layout, useBitcoind.Layout)
+						})
+					}))
+					if useBitcoind.IsChecked() {
+						addInfo(values.String(values.StrBitcoindInfo))
+						optionEditors = optionEditors[:len(optionEditors)-1]
+					}
+				}
+				for _, editor := range optionEditors {
+					editor := editor
+					children = append(children, layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, editor.Layout)
+					}))
+				}
+			}
+			addOption(values.String(values.StrUseFullNode), values.String(values.StrFullNodeInfo), useFullNode, editors)
+			if supportsElectrum {
+				addOption(values.String(values.StrUseElectrumServer), values.String(values.StrElectrumServerInfo), useElectrum, electrumEditors)
+			}
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			for _, editor := range append(editors, electrumEditors...) {
+				editor.SetError("")
+			}
+
+			if useElectrum.IsChecked() && supportsElectrum {
+				server := &sharedW.ElectrumServer{Address: electrumAddressEditor.Editor.Text()}
+				if currentElectrum != nil {
+					server.Cert = currentElectrum.Cert
+				}
+				var ok bool
+				if server.Cert, ok = readCert(&electrumCertEditor, server.Cert); !ok {
+					return false
+				}
+				if err := pg.wallet.SaveElectrumServer(server); err != nil {
+					electrumAddressEditor.SetError(err.Error())
+					return false
+				}
+				if err := pg.wallet.SetNetworkMode(sharedW.NetworkModeElectrum, nil); err != nil {
+					electrumAddressEditor.SetError(err.Error())
+					return false
+				}
+				return true
+			}
+
+			if !useFullNode.IsChecked() {
+				if err := pg.wallet.SetNetworkMode(sharedW.NetworkModeSPV, nil); err != nil {
+					pg.Toast.NotifyError(err.Error())
+				}
+				return true
+			}
+
+			node := &sharedW.RPCNode{
+				Backend:  sharedW.RPCBackendBtcd,
+				Address:  strings.TrimSpace(addressEditor.Editor.Text()),
+				User:     userEditor.Editor.Text(),
+				Password: passwordEditor.Editor.Text(),
+			}
+			if supportsElectrum && useBitcoind.IsChecked() {
+				node.Backend = sharedW.RPCBackendBitcoind
+			} else {
+				if current != nil {
+					node.Cert = current.Cert
+				}
+				var ok bool
+				if node.Cert, ok = readCert(&certEditor, node.Cert); !ok {
+					return false
+				}
+			}
+
+			if err := pg.wallet.SetNetworkMode(sharedW.NetworkModeRPC, node); err != nil {
+				addressEditor.SetError(err.Error())
+				return false
+			}
+			return true
+		})
+	pg.ParentWindow().ShowModal(networkModeModal)
+}
+
+// showHardwareSignerDialog sets the Trezor device that signs the transactions
+// of the watch-only wallet. The device is checked before it is saved.
+func (pg *SettingsPage) showHardwareSignerDialog() {
+	useTrezor := pg.Theme.Switch()
+	useEmulator := pg.Theme.Switch()
+	bridgeEditor := pg.Theme.Editor(new(widget.Editor), values.StringF(values.StrTrezorBridgeAddress, trezor.DefaultBridgeAddress))
+	emulatorEditor := pg.Theme.Editor(new(widget.Editor), values.StringF(values.StrTrezorEmulatorAddress, trezor.DefaultEmulatorAddress))
+	bridgeEditor.Editor.SingleLine = true
+	emulatorEditor.Editor.SingleLine = true
+
+	if current := pg.wallet.HardwareSigner(); current != nil {
+		switch current.Transport {
+		case sharedW.HardwareTransportBridge:
+			useTrezor.SetChecked(true)
+			bridgeEditor.Editor.SetText(current.Address)
+		case sharedW.HardwareTransportUDP:
+			useEmulator.SetChecked(true)
+			emulatorEditor.Editor.SetText(current.Address)
+		}
+	}
+
+	hardwareSignerModal := modal.NewCustomModal(pg.Load).
+		Title(values.String(values.StrHardwareSigner)).
+		UseCustomWidget(func(gtx C) D {
+			// The device and the emulator are exclusive.
+			if useTrezor.Changed(gtx) && useTrezor.IsChecked() {
+				useEmulator.SetChecked(false)
+			}
+			if useEmulator.Changed(gtx) && useEmulator.IsChecked() {
+				useTrezor.SetChecked(false)
+			}
+
+			var children []layout.FlexChild
+			addOption := func(title, info string, option *cryptomaterial.Switch, editor *cryptomaterial.Editor) {
+				children = append(children, layout.Rigid(func(gtx C) D {
+					return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, func(gtx C) D {
+						return components.EndToEndRow(gtx, pg.Theme.Body1(title).Layout, option.Layout)
+					})
+				}))
+				if !option.IsChecked() {
+					return
+				}
+
+				children = append(children,
+					layout.Rigid(func(gtx C) D {
+						lbl := pg.Theme.Body2(info)
+						lbl.Color = pg.Theme.Color.GrayText2
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, lbl.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, editor.Layout)
+					}),
+				)
+			}
+			addOption(values.String(values.StrUseTrezor), values.String(values.StrTrezorInfo), useTrezor, &bridgeEditor)
+			addOption(values.String(values.StrUseTrezorEmulator), values.String(values.StrTrezorEmulatorInfo), useEmulator, &emulatorEditor)
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+		}).
+		SetNegativeButtonText(values.String(values.StrCancel)).
+		SetPositiveButtonText(values.String(values.StrSave)).
+		SetPositiveButtonCallback(func(_ bool, _ *modal.InfoModal) bool {
+			bridgeEditor.SetError("")
+			emulatorEditor.SetError("")
+
+			if !useTrezor.IsChecked() && !useEmulator.IsChecked() {
+				if err := pg.wallet.SaveHardwareSigner(nil); err != nil {
+					pg.Toast.NotifyError(err.Error())
+				}
+				return true
+			}
+
+			cfg := &sharedW.HardwareSignerConfig{
+				Type:      sharedW.HardwareSignerTrezor,
+				Transport: sharedW.HardwareTransportBridge,
+				Address:   strings.TrimSpace(bridgeEditor.Editor.Text()),
+			}
+			editor := &bridgeEditor
+			if useEmulator.IsChecked() {
+				cfg.Transport = sharedW.HardwareTransportUDP
+				cfg.Address = strings.TrimSpace(emulatorEditor.Editor.Text())
+				editor = &emulatorEditor
+			}
+			if err := cfg.Validate(); err != nil {
+				editor.SetError(err.Error())
+				return false
+			}
+
+			coin, err := trezor.CoinName(pg.wallet.GetAssetType(), pg.wallet.NetType())
+			if err != nil {
+				editor.SetError(err.Error())
+				return false
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			features, err := trezor.NewSigner(cfg, coin).Features(ctx)
+			if err != nil {
+				editor.SetError(err.Error())
+				return false
+			}
+
+			if err := pg.wallet.SaveHardwareSigner(cfg); err != nil {
+				editor.SetError(err.Error())
+				return false
+			}
+			pg.Toast.Notify(values.StringF(values.StrTrezorConnected, features.Label))
+			return true
+		})
+	pg.ParentWindow().ShowModal(hardwareSignerModal)
+}
+
+// showSignOfflineTxDialog imports a transaction exported by the watch-only
+// wallet of the seed of the wallet, signs it once its outputs and fee are
+// confirmed with the spending password and shows the signed transaction to be
+// carried back.
+func (pg *SettingsPage) showSignOfflineTxDialog() {
+	components.ShowImportAirgapTxModal(pg.Load, pg.ParentWindow(), values.String(values.StrSignOfflineTx), func(tx *sharedW.AirgapTx) error {
+		summary, err := pg.wallet.AirgapTxSummary(tx)
+		if err != nil {
+			return err
+		}
+		if summary.Signed {
+			return errors.New(values.String(values.StrTxAlreadySigned))
+		}
+
+		passwordModal := modal.NewCreatePasswordModal(pg.Load).
+			Title(values.String(values.StrSignOfflineTx)).
+			EnableName(false).
+			EnableConfirmPassword(false).
+			UseCustomWidget(func(gtx C) D {
+				var children []layout.FlexChild
+				addRow := func(title, value string) {
+					children = append(children, layout.Rigid(func(gtx C) D {
+						return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+							return components.EndToEndRow(gtx, pg.Theme.Body2(title).Layout, pg.Theme.Body2(value).Layout)
+						})
+					}))
+				}
+				for _, output := range summary.Outputs {
+					address := output.Address
+					if address == "" {
+						address = values.String(values.StrNone)
+					}
+					addRow(address, pg.wallet.ToAmount(output.Amount).String())
+				}
+				addRow(values.String(values.StrFee), pg.wallet.ToAmount(summary.Fee).String())
+				return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+			}).
+			SetPositiveButtonText(values.String(values.StrSignOffline)).
+			SetPositiveButtonCallback(func(_, password string, pm *modal.CreatePasswordModal) bool {
+				signedTx, err := pg.wallet.SignAirgapTx(tx, password)
+				if err != nil {
+					pm.SetError(values.TranslateErr(err.Error()))
+					return false
+				}
+				components.ShowAirgapTxModal(pg.Load, pg.ParentWindow(), values.String(values.StrSignedTx),
+					values.String(values.StrSignedTxInfo), signedTx, nil)
+				return true
+			})
+		pg.ParentWindow().ShowModal(passwordModal)
+		return nil
+	})
+}
+
 // validatePeerAddressStr validates the provided addrs string to ensure it's a
 // valid peer address or a valid list of peer addresses. Returns the validated
 // addrs string and true if there are no issues.
@@ -687,14 +1099,33 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 
 	if pg.rescan.Clicked(gtx) {
 		go func() {
+			dateEditor := pg.Theme.Editor(new(widget.Editor), values.String(values.StrRescanFromDate))
+			dateEditor.Editor.SingleLine = true
 			info := modal.NewCustomModal(pg.Load).
 				Title(values.String(values.StrRescanBlockchain)).
-				Body(values.String(values.StrRescanInfo)).
+				UseCustomWidget(func(gtx C) D {
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Bottom: values.MarginPadding12}.Layout(gtx, pg.Theme.Body1(values.String(values.StrRescanInfo)).Layout)
+						}),
+						layout.Rigid(dateEditor.Layout),
+					)
+				}).
 				SetNegativeButtonText(values.String(values.StrCancel)).
 				PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.Surface).
 				SetPositiveButtonText(values.String(values.StrRescan)).
 				SetPositiveButtonCallback(func(_ bool, im *modal.InfoModal) bool {
-					err := pg.wallet.RescanBlocks()
+					options := new(sharedW.RescanOptions)
+					if date := strings.TrimSpace(dateEditor.Editor.Text()); date != "" {
+						startDate, err := time.ParseInLocation("2006-01-02", date, time.Local)
+						if err != nil {
+							dateEditor.SetError(values.String(values.StrInvalidRescanDate))
+							return false
+						}
+						options.StartDate = startDate
+					}
+
+					err := pg.wallet.Rescan(options)
 					if err != nil {
 						errorModal := modal.NewErrorModal(pg.Load, err.Error(), modal.DefaultClickFunc())
 						pg.ParentWindow().ShowModal(errorModal)
@@ -736,6 +1167,10 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.wallet.SaveUserConfigValue(sharedW.SpendUnconfirmedConfigKey, pg.spendUnconfirmed.IsChecked())
 	}
 
+	if pg.airgapSigner.Changed(gtx) {
+		pg.wallet.SaveUserConfigValue(sharedW.AirgapSignerConfigKey, pg.airgapSigner.IsChecked())
+	}
+
 	if pg.spendUnmixedFunds.Changed(gtx) {
 		if pg.spendUnmixedFunds.IsChecked() {
 			textModal := modal.NewTextInputModal(pg.Load).
@@ -782,6 +1217,18 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.showWarningModalDialog(title, msg)
 	}
 
+	if pg.networkMode.Clicked(gtx) {
+		pg.showNetworkModeDialog()
+	}
+
+	if pg.hardwareSigner.Clicked(gtx) {
+		pg.showHardwareSignerDialog()
+	}
+
+	if pg.signOfflineTx.Clicked(gtx) {
+		pg.showSignOfflineTxDialog()
+	}
+
 	if pg.updateConnectToPeer.Clicked(gtx) && !pg.isPrivacyModeOn() {
 		pg.showSPVPeerDialog()
 	}
@@ -806,6 +1253,16 @@ func (pg *SettingsPage) HandleUserInteractions(gtx C) {
 		pg.ParentNavigator().Display(s.NewStatPage(pg.Load, pg.wallet))
 	}
 
+	if pg.managePeers.Clicked(gtx) {
+		pg.ParentNavigator().Display(s.NewPeersPage(pg.Load, pg.wallet))
+	}
+
+	if pg.multisigAccounts.Clicked(gtx) {
+		if dcrAsset, ok := pg.wallet.(*dcr.Asset); ok {
+			pg.ParentNavigator().Display(s.NewDCRMultisigPage(pg.Load, dcrAsset))
+		}
+	}
+
 	for pg.addAccount.Clicked(gtx) {
 		newPasswordModal := modal.NewCreatePasswordModal(pg.Load).
 			Title(values.String(values.StrCreateNewAccount)).
@@ -850,9 +1307,10 @@ func (pg *SettingsPage) gapLimitModal() {
 				tm.SetError(values.String(values.StrGapLimitInputErr))
 				return false
 			}
-			gLimit := uint32(val)
 
-			err = pg.wallet.(*dcr.Asset).DiscoverUsage(gLimit)
+			// The used addresses are discovered before the blockchain
+			// is rescanned for them.
+			err = pg.wallet.Rescan(&sharedW.RescanOptions{GapLimit: uint32(val)})
 			if err != nil {
 				tm.SetError(err.Error())
 				return false
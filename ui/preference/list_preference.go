@@ -42,9 +42,13 @@ var (
 		{Key: values.Coinpaprika, Value: values.StrUsdCoinpaprika},
 		{Key: values.Messari, Value: values.StrUsdMessari},
 		{Key: values.KucoinExchange, Value: values.StrUsdKucoin, Warning: values.String(values.StrRateKucoinWarning), WarningLink: kucoinProhibitedCountries},
+		{Key: values.AggregateRateSource, Value: values.StrAggregateRates},
 		{Key: values.DefaultExchangeValue, Value: values.StrNone},
 	}
 
+	// FiatOptions holds the selectable display currencies.
+	FiatOptions = fiatOptions()
+
 	// LangOptions stores the configurable language options.
 	LangOptions = []ItemPreference{
 		{Key: localizable.ENGLISH, Value: values.StrEnglish},
@@ -128,6 +132,8 @@ func (lp *ListPreferenceModal) ReadPreferenceKeyedValue() string {
 	switch lp.preferenceKey {
 	case sharedW.CurrencyConversionConfigKey:
 		return lp.AssetsManager.GetCurrencyConversionExchange()
+	case sharedW.FiatCurrencyConfigKey:
+		return lp.AssetsManager.GetFiatCurrency()
 	case sharedW.LanguagePreferenceKey:
 		return lp.AssetsManager.GetLanguagePreference()
 	case sharedW.LogLevelConfigKey:
@@ -142,6 +148,8 @@ func (lp *ListPreferenceModal) SavePreferenceKeyedValue() {
 	switch lp.preferenceKey {
 	case sharedW.CurrencyConversionConfigKey:
 		lp.AssetsManager.SetCurrencyConversionExchange(val)
+	case sharedW.FiatCurrencyConfigKey:
+		lp.AssetsManager.SetFiatCurrency(val)
 	case sharedW.LanguagePreferenceKey:
 		// TODO: We should be able to update dex core's language when the user
 		// changes language.
@@ -347,6 +355,42 @@ func (lp *ListPreferenceModal) layoutItems() []layout.FlexChild {
 // GetKeyValue return the value for a key within a set of prefence options.
 // The key is case sensitive, `Key` != `key`.
 // Returns the empty string if the key is not found.
+func fiatOptions() []ItemPreference {
+	options := make([]ItemPreference, 0, len(values.FiatCurrencies))
+	for _, code := range values.FiatCurrencies {
+		options = append(options, ItemPreference{Key: code, Value: code})
+	}
+	return options
+}
+
+// ExchangeOptions returns ExchOptions with the user defined rate sources
+// listed before the none option.
+func ExchangeOptions(customSources []string) []ItemPreference {
+	last := len(ExchOptions) - 1
+	options := append([]ItemPreference{}, ExchOptions[:last]...)
+	for _, name := range customSources {
+		options = append(options, ItemPreference{Key: name, Value: name})
+	}
+	return append(options, ExchOptions[last])
+}
+
+// LanguageOptions returns LangOptions with the languages added by locale
+// packs.
+func LanguageOptions() []ItemPreference {
+	options := append([]ItemPreference{}, LangOptions...)
+	for _, lang := range values.Languages {
+		if GetKeyValue(lang, LangOptions) != "" {
+			continue
+		}
+		name, ok := values.LanguageName(lang)
+		if !ok {
+			name = lang
+		}
+		options = append(options, ItemPreference{Key: lang, Value: name})
+	}
+	return options
+}
+
 func GetKeyValue(key string, options []ItemPreference) string {
 	for _, option := range options {
 		if option.Key == key {
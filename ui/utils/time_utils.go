@@ -2,11 +2,8 @@ package utils
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
-	"github.com/ararog/timeago"
 	"github.com/crypto-power/cryptopower/ui/values"
 )
 
@@ -18,17 +15,34 @@ const (
 
 // File holds all time manipulation implementations required for a given page UI.
 
-// TimeAgo returns the elapsed time since now.
+// TimeAgo returns the elapsed time since now in the display language.
 func TimeAgo(timestamp int64) string {
-	timeAgo, _ := timeago.TimeAgoWithTime(time.Now(), time.Unix(timestamp, 0))
-	return timeAgo
+	elapsed := time.Since(time.Unix(timestamp, 0))
+	hours := elapsed.Hours()
+	switch {
+	case hours >= 8760:
+		return values.PluralF(values.StrTimeAgoYears, int(hours/8760))
+	case hours >= 730:
+		return values.PluralF(values.StrTimeAgoMonths, int(hours/730))
+	case hours >= 168:
+		return values.PluralF(values.StrTimeAgoWeeks, int(hours/168))
+	case hours >= 24:
+		return values.PluralF(values.StrTimeAgoDays, int(hours/24))
+	case hours >= 1:
+		return values.PluralF(values.StrTimeAgoHours, int(hours))
+	case elapsed.Minutes() >= 1:
+		return values.PluralF(values.StrTimeAgoMinutes, int(elapsed.Minutes()))
+	case elapsed.Seconds() >= 2:
+		return values.PluralF(values.StrTimeAgoSeconds, int(elapsed.Seconds()))
+	}
+	return values.String(values.StrJustNow)
 }
 
 // FormatDateOrTime formats the provided timestamp parameter as follows.
 // If the provided matches to time within today, the time difference between now
 // and then is returned.
 // If it matches to time within the previous one day, "yesterday" is returned.
-// Otherwise the formatted time and date is returned.
+// Otherwise the date is returned in the layout of the display language.
 func FormatDateOrTime(timestamp int64) string {
 	utcTime := time.Unix(timestamp, 0).UTC()
 	timeDiff := int(time.Now().UTC().Sub(utcTime).Seconds())
@@ -41,13 +55,7 @@ func FormatDateOrTime(timestamp int64) string {
 		return values.String(values.StrYesterday)
 	}
 
-	t := strings.Split(utcTime.Format(time.UnixDate), " ")
-	t2 := t[2]
-	year := strconv.Itoa(utcTime.Year())
-	if t[2] == "" {
-		t2 = t[3]
-	}
-	return fmt.Sprintf("%s %s, %s", t[1], t2, year)
+	return utcTime.Format(values.String(values.StrDateLayout))
 }
 
 // TimeFormat formats the provided `secs` parameter into:
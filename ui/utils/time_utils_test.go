@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeAgo(t *testing.T) {
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		expected string
+	}{
+		{name: "now", elapsed: 0, expected: "Just now"},
+		{name: "seconds", elapsed: 30 * time.Second, expected: "30 seconds ago"},
+		{name: "minutes truncated", elapsed: 119 * time.Second, expected: "1 minute ago"},
+		{name: "hours truncated", elapsed: 100 * time.Minute, expected: "1 hour ago"},
+		{name: "hours", elapsed: 23*time.Hour + 59*time.Minute, expected: "23 hours ago"},
+		{name: "days", elapsed: 50 * time.Hour, expected: "2 days ago"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if resp := TimeAgo(time.Now().Add(-tc.elapsed).Unix()); resp != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, resp)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"gioui.org/op"
 	"gioui.org/op/clip"
 	"gioui.org/widget"
+	"golang.org/x/text/currency"
 	"golang.org/x/text/message"
 )
 
@@ -82,8 +83,34 @@ func BreakBalance(p *message.Printer, balance string) (b1, b2 string) {
 	return
 }
 
-func FormatAsUSDString(p *message.Printer, usdAmt float64) string {
-	return p.Sprintf("$%.2f", usdAmt)
+// FormatAsFiatString formats a fiat amount with the symbol and decimals of
+// the currency with the ISO 4217 code, grouping digits per the printer's
+// locale.
+func FormatAsFiatString(p *message.Printer, code string, amt float64) string {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return p.Sprintf("%.2f %s", amt, code)
+	}
+	return p.Sprint(currency.NarrowSymbol(unit.Amount(amt)))
+}
+
+// FiatConverter converts USD amounts to the display currency selected by the
+// user. It is implemented by the AssetsManager.
+type FiatConverter interface {
+	GetFiatCurrency() string
+	USDToFiat(usd float64, code string) (float64, error)
+}
+
+// FormatUSDAsFiat formats a USD amount in the display currency selected by the
+// user. The amount is formatted in USD if the display currency rate is not
+// available.
+func FormatUSDAsFiat(p *message.Printer, converter FiatConverter, usdAmt float64) string {
+	code := converter.GetFiatCurrency()
+	amt, err := converter.USDToFiat(usdAmt, code)
+	if err != nil {
+		code, amt = values.DefaultFiatCurrency, usdAmt
+	}
+	return FormatAsFiatString(p, code, amt)
 }
 
 func CryptoToUSD(exchangeRate, coin float64) float64 {
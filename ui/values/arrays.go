@@ -10,8 +10,19 @@ const (
 	Coinpaprika          = "coinpaprika"
 	Messari              = "messari"
 	KucoinExchange       = "kucoin"
+	AggregateRateSource  = "aggregate"
 )
 
+// DefaultFiatCurrency is the currency balances are displayed in until the user
+// selects another one.
+const DefaultFiatCurrency = "USD"
+
+// FiatCurrencies are the ISO 4217 codes of the selectable display currencies.
+var FiatCurrencies = []string{
+	"USD", "EUR", "GBP", "JPY", "CNY", "INR", "NGN", "BRL",
+	"CAD", "AUD", "CHF", "ZAR", "KRW", "MXN", "TRY", "RUB",
+}
+
 // initialize an asset market value map
 var AssetExchangeMarketValue = map[utils.AssetType]Market{
 	utils.DCRWalletAsset: DCRUSDTMarket,
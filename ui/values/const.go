@@ -13,4 +13,8 @@ const (
 	StartPageDropdownGroup
 	AssetTypeDropdownGroup
 	AccountsDropdownGroup
+	NotificationWalletDropdownGroup
+	NotificationEventDropdownGroup
+	PaymentRequestExpiryDropdownGroup
+	PaymentRequestFilterDropdownGroup
 )
@@ -23,6 +23,15 @@ func TranslateErr(errStr string) string {
 	case utils.ErrInsufficientBalance:
 		return String(StrInsufficientFund)
 
+	case utils.ErrTxAuthorBusy:
+		return String(StrTxAuthorBusy)
+
+	case utils.ErrRPCPasswordRequired:
+		return String(StrRPCPasswordRequired)
+
+	case utils.ErrAccountRescanUnsupported:
+		return String(StrAccountRescanUnsupported)
+
 	default:
 		if strings.Contains(errStr, "strconv.ParseFloat") {
 			return String((StrInvalidAmount))
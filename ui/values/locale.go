@@ -0,0 +1,193 @@
+package values
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+const (
+	// LocalesDirName is the directory in the app data directory that locale
+	// packs are loaded from.
+	LocalesDirName = "locales"
+
+	// localePackExt is the extension of locale pack files. The file name is
+	// the BCP 47 tag of the language, e.g. "pt-BR.strings".
+	localePackExt = ".strings"
+
+	// languageNameKey is the pack key holding the display name of the
+	// language.
+	languageNameKey = "@name"
+
+	// fallbackKey is the pack key holding the comma separated languages that
+	// strings missing from the pack are looked up in, e.g. "es, en".
+	fallbackKey = "@fallback"
+)
+
+var (
+	// languageNames are the display names of the languages added by locale
+	// packs.
+	languageNames = make(map[string]string)
+
+	// fallbacks are the explicit fallback languages set by locale packs.
+	fallbacks = make(map[string][]string)
+
+	// pluralForms are the key suffixes of the CLDR plural categories.
+	pluralForms = map[plural.Form]string{
+		plural.Other: "other",
+		plural.Zero:  "zero",
+		plural.One:   "one",
+		plural.Two:   "two",
+		plural.Few:   "few",
+		plural.Many:  "many",
+	}
+)
+
+// LoadLocalePacks reads the locale packs in dir. A pack for a built-in
+// language adds to or overrides its strings and a pack for any other language
+// makes it selectable. A missing dir is not an error.
+func LoadLocalePacks(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != localePackExt {
+			continue
+		}
+
+		tag, err := language.Parse(strings.TrimSuffix(name, localePackExt))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid locale pack name %s: %w", name, err))
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		lang := tag.String()
+		m, ok := languageStrings[lang]
+		if !ok {
+			m = make(map[string]string)
+		}
+		readStrings(m, string(content))
+
+		if displayName, ok := m[languageNameKey]; ok {
+			languageNames[lang] = displayName
+			delete(m, languageNameKey)
+		}
+		if fallback, ok := m[fallbackKey]; ok {
+			fallbacks[lang] = nil
+			for _, fallbackLang := range strings.Split(fallback, ",") {
+				if fallbackLang = strings.TrimSpace(fallbackLang); fallbackLang != "" {
+					fallbacks[lang] = append(fallbacks[lang], fallbackLang)
+				}
+			}
+			delete(m, fallbackKey)
+		}
+
+		languageStrings[lang] = m
+		if !hasLanguage(lang) {
+			Languages = append(Languages, lang)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LanguageName returns the display name a locale pack set for lang. False is
+// returned for the built-in languages.
+func LanguageName(lang string) (string, bool) {
+	name, ok := languageNames[lang]
+	return name, ok
+}
+
+// FallbackChain returns the languages strings are looked up in when lang is
+// the display language: lang, the fallbacks set by its locale pack, its
+// parent languages (e.g. "pt" for "pt-BR") and the default language.
+func FallbackChain(lang string) []string {
+	chain := []string{lang}
+	add := func(l string) {
+		if !hasLanguage(l) {
+			return
+		}
+		for _, existing := range chain {
+			if existing == l {
+				return
+			}
+		}
+		chain = append(chain, l)
+	}
+
+	for _, l := range fallbacks[lang] {
+		add(l)
+	}
+	for parent := lang; strings.Contains(parent, "-"); {
+		parent = parent[:strings.LastIndex(parent, "-")]
+		add(parent)
+	}
+	add(DefaultLanguage)
+	return chain
+}
+
+// LanguageTag returns the tag of the display language, used to format
+// numbers and plurals.
+func LanguageTag() language.Tag {
+	return language.Make(UserLanguages[0])
+}
+
+// pluralString returns the string of key in the plural form of n. Each
+// language of the fallback chain is searched for the form of n in that
+// language, then the "other" form and then key itself.
+func pluralString(key string, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	for _, lang := range UserLanguages {
+		languageMap := languageStrings[lang]
+		form := plural.Cardinal.MatchPlural(language.Make(lang), n, 0, 0, 0, 0)
+		for _, k := range []string{key + "." + pluralForms[form], key + "." + pluralForms[plural.Other], key} {
+			if str, ok := languageMap[k]; ok {
+				return str
+			}
+		}
+	}
+
+	return key
+}
+
+// MissingKeys returns the keys of the default language that lang has no
+// string for, sorted. A plural form is not missing if lang has the "other"
+// form of the key.
+func MissingKeys(lang string) []string {
+	languageMap := languageStrings[lang]
+	var missing []string
+	for key := range languageStrings[DefaultLanguage] {
+		if _, ok := languageMap[key]; ok {
+			continue
+		}
+		if i := strings.LastIndex(key, "."); i > 0 {
+			if _, ok := languageMap[key[:i]+"."+pluralForms[plural.Other]]; ok {
+				continue
+			}
+		}
+		missing = append(missing, key)
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,158 @@
+package values
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// saveLanguages restores the languages and strings changed by a test.
+func saveLanguages(t *testing.T) {
+	savedStrings := make(map[string]map[string]string, len(languageStrings))
+	for lang, m := range languageStrings {
+		savedStrings[lang] = make(map[string]string, len(m))
+		for k, v := range m {
+			savedStrings[lang][k] = v
+		}
+	}
+	savedLanguages := append([]string(nil), Languages...)
+	savedUserLanguages := append([]string(nil), UserLanguages...)
+	savedNames, savedFallbacks := languageNames, fallbacks
+
+	languageNames, fallbacks = make(map[string]string), make(map[string][]string)
+	t.Cleanup(func() {
+		languageStrings, Languages, UserLanguages = savedStrings, savedLanguages, savedUserLanguages
+		languageNames, fallbacks = savedNames, savedFallbacks
+	})
+}
+
+func writePack(t *testing.T, dir, name, content string) {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPluralF(t *testing.T) {
+	saveLanguages(t)
+	languageStrings["en"]["testApples.one"] = "%d apple"
+	languageStrings["en"]["testApples.other"] = "%d apples"
+	languageStrings["fr"]["testApples.one"] = "%d pomme"
+	languageStrings["fr"]["testApples.other"] = "%d pommes"
+	languageStrings["en"]["testBoxes"] = "%d boxes of %s"
+
+	tests := []struct {
+		name     string
+		lang     string
+		key      string
+		n        int
+		args     []interface{}
+		expected string
+	}{
+		{name: "english one", lang: "en", key: "testApples", n: 1, expected: "1 apple"},
+		{name: "english zero is other", lang: "en", key: "testApples", n: 0, expected: "0 apples"},
+		{name: "english negative", lang: "en", key: "testApples", n: -1, expected: "-1 apple"},
+		{name: "french zero is one", lang: "fr", key: "testApples", n: 0, expected: "0 pomme"},
+		{name: "french other", lang: "fr", key: "testApples", n: 2, expected: "2 pommes"},
+		{name: "no plural forms", lang: "en", key: "testBoxes", n: 3, args: []interface{}{"pears"}, expected: "3 boxes of pears"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetUserLanguage(tc.lang)
+			if resp := PluralF(tc.key, tc.n, tc.args...); resp != tc.expected {
+				t.Errorf("(%v), expected (%v), got (%v)", tc.name, tc.expected, resp)
+			}
+		})
+	}
+}
+
+func TestLoadLocalePacks(t *testing.T) {
+	saveLanguages(t)
+	dir := t.TempDir()
+	writePack(t, dir, "pl.strings", `
+"@name" = "Polski"
+"testFiles.one" = "%d plik"
+"testFiles.few" = "%d pliki"
+"testFiles.many" = "%d plików"
+`)
+	writePack(t, dir, "pt-BR.strings", `
+"@name" = "Português (Brasil)"
+"@fallback" = "es, xx"
+"testGreeting" = "Olá"
+`)
+	writePack(t, dir, "fr.strings", `"testGreeting" = "Salut"`)
+	writePack(t, dir, "notes.txt", `"testGreeting" = "ignored"`)
+
+	if err := LoadLocalePacks(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadLocalePacks(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("expected no error for a missing dir, got %v", err)
+	}
+
+	if name, ok := LanguageName("pl"); !ok || name != "Polski" {
+		t.Errorf("expected pl to be named Polski, got %q", name)
+	}
+	if _, ok := LanguageName("fr"); ok {
+		t.Error("expected no pack name for the built-in fr")
+	}
+	if !reflect.DeepEqual(fallbacks["pt-BR"], []string{"es", "xx"}) {
+		t.Errorf("expected pt-BR to fall back to es and xx, got %v", fallbacks["pt-BR"])
+	}
+	if !hasLanguage("pl") || !hasLanguage("pt-BR") {
+		t.Errorf("expected pack languages to be selectable, got %v", Languages)
+	}
+
+	SetUserLanguage("fr")
+	if resp := String("testGreeting"); resp != "Salut" {
+		t.Errorf("expected the fr pack to add strings, got %q", resp)
+	}
+	if resp := String(StrSend); resp != languageStrings["fr"][StrSend] {
+		t.Errorf("expected the fr pack to keep the built-in strings, got %q", resp)
+	}
+
+	SetUserLanguage("pl")
+	for n, expected := range map[int]string{1: "1 plik", 3: "3 pliki", 5: "5 plików", 22: "22 pliki"} {
+		if resp := PluralF("testFiles", n); resp != expected {
+			t.Errorf("expected %q for %d, got %q", expected, n, resp)
+		}
+	}
+
+	writePack(t, dir, "bad_name!.strings", "")
+	if err := LoadLocalePacks(dir); err == nil {
+		t.Error("expected an error for an invalid pack name")
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	saveLanguages(t)
+	Languages = append(Languages, "pt", "pt-BR", "zh-Hant-TW")
+	fallbacks["pt-BR"] = []string{"es", "xx", "pt"}
+
+	tests := []struct {
+		lang     string
+		expected []string
+	}{
+		{lang: "en", expected: []string{"en"}},
+		{lang: "fr", expected: []string{"fr", "en"}},
+		{lang: "pt-BR", expected: []string{"pt-BR", "es", "pt", "en"}},
+		{lang: "zh-Hant-TW", expected: []string{"zh-Hant-TW", "zh", "en"}},
+	}
+
+	for _, tc := range tests {
+		if resp := FallbackChain(tc.lang); !reflect.DeepEqual(resp, tc.expected) {
+			t.Errorf("(%v), expected (%v), got (%v)", tc.lang, tc.expected, resp)
+		}
+	}
+
+	SetUserLanguage("pt-BR")
+	languageStrings["pt-BR"] = map[string]string{}
+	languageStrings["pt"] = map[string]string{"testOnlyPt": "pt"}
+	if resp := String(StrSend); resp != languageStrings["es"][StrSend] {
+		t.Errorf("expected the es string, got %q", resp)
+	}
+	if resp := String("testOnlyPt"); resp != "pt" {
+		t.Errorf("expected the pt string, got %q", resp)
+	}
+}
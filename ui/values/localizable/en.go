@@ -881,7 +881,7 @@ const EN = `
 "assetAmount" = "Amount (%s)"
 "booked" = "Booked"
 "executed" = "Executed"
-"cannotSpendWatchOnlyWallet" = "You cannot spend from a watch only wallet, try creating another wallet."
+"cannotSpendWatchOnlyWallet" = "You cannot spend from a watch only wallet unless a hardware signer or an offline wallet signs its transactions. Set one up in the wallet settings."
 "allWallets" = "All Wallets"
 "multipleAssetTypeRequiredToTradeDEX" = "Multiple coin type wallets are required to trade on DCRDEX."
 "noSupportedBondAsset" = "DEX server (%s) does not support available asset wallets. Please choose another server."
@@ -978,4 +978,367 @@ const EN = `
 "lowStorageSpaceBody" = "Your device storage space is low and is not enough to sync a wallet. Required space to sync a wallet is ~%dmb while your free internal memory is %dmb"
 "walletCreationLimitTitle" = "Wallet creation limit"
 "walletCreationLimitBody" = "Limit of 1 wallet per 1 gig of ram on the device. You can create up to 1 wallet for every 1 gigabyte of RAM available on your device."
+"candles" = "Candles"
+"depth" = "Depth"
+"noChartData" = "No chart data is available for this market yet."
+"manageBonds" = "Manage Bonds"
+"bonds" = "Bonds"
+"targetTier" = "Target Tier"
+"bondedTiers" = "Bonded Tiers"
+"pendingTiers" = "Pending Tiers"
+"expiringTiers" = "Expiring Soon"
+"tierDropWarning" = "%d tier(s) at %s will expire soon and no replacement bond is pending. Enable auto-renewal or post a new bond to keep your trading tier."
+"bondAutoRenew" = "Auto-Renewal"
+"bondAutoRenewDesc" = "Expiring bonds are replaced automatically from the selected wallet account to keep your account at the target tier. Set the target tier to 0 to disable auto-renewal."
+"maxBondedAmount" = "Max Bonded Amount"
+"maxBondedAmountHint" = "Leave empty to use the default"
+"bondOptionsUpdated" = "Bond options updated"
+"targetTierErrMsg" = "Target tier must be a valid number"
+"noBonds" = "No bonds have been posted to this server."
+"bondExpires" = "Expires"
+"bondRefundable" = "Refundable"
+"refundTx" = "Refund Tx"
+"bondActive" = "Active"
+"bondRefunded" = "Refunded"
+"loginDEXToViewBonds" = "Enter your DEX password to view the bonds posted to %s."
+"dexBond" = "DEX Bond"
+"dexBondPosted" = "Bond posted, locked until %s"
+"dexBondRefund" = "Bond refund"
+"accountTier" = "Account Tier"
+"canceled" = "Canceled"
+"dexOrder" = "DEX Order"
+"avgPrice" = "Avg. Price"
+"trades" = "Trades"
+"volume" = "Volume"
+"totalFees" = "Total Fees"
+"markToMarketPL" = "Mark-to-market P/L"
+"exportCSV" = "Export CSV"
+"exportJSON" = "Export JSON"
+"exportTrades" = "Export Trades"
+"exportTradesMsg" = "The trades matching the selected filters will be exported with their fees and transaction IDs."
+"exportTradesSuccessMsg" = "Your trades have been exported successfully and saved to %s."
+"dateHint" = "YYYY-MM-DD"
+"invalidDate" = "Invalid date, use YYYY-MM-DD"
+"noTradesFound" = "No trades match the selected filters."
+"unpricedTrades" = "%d trades could not be priced because a fiat rate is unavailable."
+"orderFilter" = "Order %s"
+"fiatValuesAtCurrentRates" = "Fiat values use current exchange rates. The mark-to-market P/L values both sides of each trade at today's rates, not at the rates of the matches."
+"marketMaker" = "Market Maker"
+"marketMakerDesc" = "The market maker places buy and sell orders around a reference price and replaces them as the price moves. It stops and cancels its orders when the inventory limit is reached or a wallet cannot fund its orders."
+"priceSource" = "Price Source"
+"priceOracle" = "Fiat Oracle"
+"midGap" = "Order Book Mid-gap"
+"spreadPercent" = "Spread (%)"
+"levelsPerSide" = "Levels per Side"
+"lotsPerLevel" = "Lots per Level"
+"maxInventory" = "Max Inventory (%s)"
+"rebalanceMinutes" = "Rebalance Every (minutes)"
+"botSettingsSaved" = "Market maker settings saved"
+"stop" = "Stop"
+"botRunning" = "Running since %s"
+"botStopped" = "Stopped"
+"referencePrice" = "Reference Price"
+"netInventory" = "Net Inventory"
+"activity" = "Activity"
+"noBotEvents" = "Market maker activity will be shown here."
+"startBotMsg" = "Enter your DEX password to start the market maker. The password is kept in memory until the market maker stops."
+"invalidNumber" = "Invalid number"
+"botEventStarted" = "Started"
+"botEventStopped" = "Stopped"
+"botEventOrderPlaced" = "Order placed"
+"botEventOrderCanceled" = "Order canceled"
+"botEventOrderFilled" = "Order filled"
+"botEventOrderDone" = "Order completed"
+"botEventError" = "Error"
+"botEventPaused" = "Paused"
+"botEventResumed" = "Resumed"
+"botPaused" = "Paused, the oracle price deviates from the order book"
+"compareRates" = "Compare Rates"
+"compareRatesDesc" = "Quotes for %s from all exchanges, ranked by the amount you receive after exchange fees. Select a quote to use its exchange."
+"youReceive" = "You Receive"
+"limits" = "Limits"
+"vsMarket" = "Vs. Market"
+"outsideLimits" = "Amount outside limits"
+"rateDeviates" = "Deviates from market rate"
+"noLimit" = "No limit"
+"fetchingQuotes" = "Fetching quotes from all exchanges..."
+"bestRate" = "Best rate"
+"scheduleName" = "Schedule name"
+"fixedAmount" = "Fixed Amount"
+"percentOfBalance" = "% of Balance"
+"aboveBalance" = "Above Balance"
+"amountToSwap" = "Amount to swap (%s)"
+"percentToSwap" = "Percentage of balance to swap"
+"maxOrdersOptional" = "Max. number of orders (optional)"
+"maxTotalOptional" = "Max. total to swap in %s (optional)"
+"endDateOptional" = "End date, YYYY-MM-DD (optional)"
+"schedules" = "Schedules"
+"schedulesRunning" = "%d running"
+"noSchedules" = "No schedules yet. Turn on the scheduler on the create order page to add one."
+"nextRun" = "Next run"
+"ordersCreated" = "Orders created"
+"totalSwapped" = "Total swapped"
+"resume" = "Resume"
+"pause" = "Pause"
+"scheduleActive" = "Active"
+"schedulePaused" = "Paused"
+"scheduleEnded" = "Ended"
+"waitingForPassphrase" = "Waiting for spending passphrase"
+"executionLog" = "Execution Log"
+"noExecutions" = "This schedule has not run yet."
+"resumeScheduleMsg" = "Enter the spending passphrase of %s to resume this schedule. The passphrase is kept in memory while the schedule runs."
+"deleteScheduleMsg" = "Delete schedule %s and its execution log? Orders already created are not affected."
+"scheduleExists" = "A schedule with this name already exists"
+"orderPayoutFlagged" = "Payout of order %s is %s"
+"onChainVerification" = "On-chain verification"
+"awaitingPayout" = "Awaiting payout"
+"payoutReceived" = "Payout received"
+"payoutShort" = "Payout is short"
+"payoutLate" = "Payout is late"
+"payoutMissing" = "Payout is missing"
+"depositRefunded" = "Deposit refunded"
+"depositTx" = "Deposit Tx"
+"payoutTx" = "Payout Tx"
+"aggregateRates" = "Median of all sources"
+"rateSources" = "Rate sources"
+"addRateSource" = "Add rate source"
+"urlTemplate" = "URL template, e.g. https://api.example.com/ticker/{BASE}-{QUOTE}"
+"pricePath" = "Price JSON path, e.g. data.price"
+"volumePath" = "Volume JSON path (optional)"
+"sourceHealthy" = "Healthy"
+"sourceNotUsed" = "Not used yet"
+"lastSuccess" = "Last success"
+"lastError" = "Last error"
+"customSource" = "Custom"
+"removeRateSourceMsg" = "Remove the rate source %s?"
+"consecutiveFailures" = "Failed requests"
+"displayCurrency" = "Display currency"
+"portfolioHistory" = "Portfolio history"
+// dateLayout is a Go time layout, see https://pkg.go.dev/time#Layout
+"dateLayout" = "Jan 2, 2006"
+"timeAgoSeconds.one" = "%d second ago"
+"timeAgoSeconds.other" = "%d seconds ago"
+"timeAgoMinutes.one" = "%d minute ago"
+"timeAgoMinutes.other" = "%d minutes ago"
+"timeAgoHours.one" = "%d hour ago"
+"timeAgoHours.other" = "%d hours ago"
+"timeAgoDays.one" = "%d day ago"
+"timeAgoDays.other" = "%d days ago"
+"timeAgoWeeks.one" = "%d week ago"
+"timeAgoWeeks.other" = "%d weeks ago"
+"timeAgoMonths.one" = "%d month ago"
+"timeAgoMonths.other" = "%d months ago"
+"timeAgoYears.one" = "%d year ago"
+"timeAgoYears.other" = "%d years ago"
+"translations" = "Translations"
+"missingKeys.one" = "%d missing key"
+"missingKeys.other" = "%d missing keys"
+"allKeysTranslated" = "All keys translated"
+"txReceivedNotif" = "You have received %s %s"
+"txConfirmedNotif" = "Your received %s %s is confirmed"
+"dexMatchNotif" = "New match on %s: %s"
+"swapCompletedNotif" = "Swap from %s completed, received %s %s"
+"markAllRead" = "Mark all as read"
+"noNotifications" = "No notifications yet"
+"notificationRules" = "Notification rules"
+"addRule" = "Add rule"
+"allEvents" = "All events"
+"muteNotifications" = "Mute notifications"
+"minAmount" = "Minimum amount"
+"quietFrom" = "Quiet from (HH:MM)"
+"quietUntil" = "Quiet until (HH:MM)"
+"quietHours" = "Quiet hours %s - %s"
+"invalidTime" = "Invalid time, use HH:MM"
+"noRules" = "No rules, all notifications are shown"
+"eventTxReceived" = "Received transactions"
+"eventTxConfirmed" = "Confirmations"
+"eventTicketVoted" = "Ticket votes"
+"eventTicketRevoked" = "Ticket revocations"
+"eventProposalUpdate" = "Proposal updates"
+"eventDEXMatch" = "DEX matches"
+"eventSwapCompleted" = "Completed swaps"
+"hooks" = "Hooks"
+"addHook" = "Add hook"
+"noHooks" = "No hooks yet. Hooks POST a signed JSON payload to a local URL or run a command with the JSON payload on stdin when wallet events happen."
+"hookURL" = "Local URL (http://localhost/...)"
+"hookCommand" = "Command"
+"hookURLOrCommand" = "Set either a local URL or a command"
+"hookConfirmationsHint" = "Confirmations for the transaction confirmed event"
+"eventTransaction" = "New transaction"
+"eventSwapStatus" = "Swap status changed"
+"deliveries" = "Deliveries"
+"noDeliveries" = "No deliveries yet"
+"signingSecret" = "Signing secret"
+"attempts" = "Attempts"
+"removeHookMsg" = "Remove the %s hook and its delivery log?"
+"deliveryPending" = "Pending"
+"deliveryDelivered" = "Delivered"
+"deliveryFailed" = "Failed"
+"paymentRequests" = "Payment requests"
+"newPaymentRequest" = "New payment request"
+"noPaymentRequests" = "No payment requests"
+"memo" = "Memo"
+"amountOptional" = "Amount (optional)"
+"expiry" = "Expiry"
+"never" = "Never"
+"oneHour" = "1 hour"
+"oneDay" = "24 hours"
+"oneWeek" = "7 days"
+"openRequests" = "Open"
+"settledRequests" = "Settled"
+"requestOpen" = "Awaiting payment"
+"requestUnderpaid" = "Underpaid"
+"requestPaid" = "Paid"
+"requestOverpaid" = "Overpaid"
+"requestExpired" = "Expired"
+"receivedOf" = "Received %s of %s"
+"anyAmount" = "Any amount"
+"expiresAt" = "Expires %s"
+"showQR" = "QR code"
+"removePaymentRequestMsg" = "Stop tracking payments to %s? The address stays in the wallet."
+"batchSend" = "Batch send"
+"batchSendHint" = "One payment per line: address, amount and an optional label. Large batches are split into several transactions."
+"csvFilePath" = "CSV file path"
+"paymentsCSV" = "Payments (CSV)"
+"batchLabel" = "Transaction label (optional)"
+"review" = "Review"
+"lineError" = "Line %d: %s"
+"missingAddressOrAmount" = "Missing address or amount"
+"duplicateOfLine" = "Address already paid on line %d"
+"validPayments" = "%d of %d payments are valid"
+"batchPlanRow" = "Transaction %d: %d payments, %s, fee %s"
+"batchTotal" = "Total %s including %s fees"
+"batchSent.one" = "%d transaction sent"
+"batchSent.other" = "%d transactions sent"
+"networkMode" = "Network mode"
+"spv" = "SPV"
+"fullNodeAt" = "Full node (%s)"
+"useFullNode" = "Sync with my own full node"
+"fullNodeInfo" = "The wallet syncs with the node over its authenticated RPC interface. Only the node's TLS certificate is trusted; it may be left empty for a node on this computer. The password is not saved, you enter it again when the app starts."
+"rpcAddress" = "RPC address (host:port)"
+"rpcUser" = "RPC username"
+"rpcPassword" = "RPC password"
+"rpcCertPath" = "TLS certificate file"
+"rpcCertKept" = "TLS certificate file (empty keeps the current one)"
+"electrumServerAt" = "Electrum server (%s)"
+"useElectrumServer" = "Sync with an Electrum server"
+"electrumServerInfo" = "The wallet finds its transactions through the server and verifies them against the block headers it serves. The server learns the addresses of the wallet, use a server you trust."
+"electrumAddress" = "Server address (host:port)"
+"rescanFromDate" = "Rescan from date (YYYY-MM-DD, empty rescans the entire blockchain)"
+"invalidRescanDate" = "Enter the date as YYYY-MM-DD"
+"managePeers" = "Manage peers"
+"connectedPeers" = "Connected peers"
+"bannedPeers" = "Banned peers"
+"preferredPeers" = "Preferred peers"
+"noConnectedPeers" = "No peers connected"
+"noBannedPeers" = "No banned peers"
+"noPreferredPeers" = "No preferred peers"
+"ban" = "Ban"
+"unban" = "Unban"
+"banPeer" = "Ban peer"
+"banPeerMsg" = "Peers of %s will be disconnected and not connected to again until the ban expires."
+"banDurationHours" = "Ban duration (hours)"
+"bannedUntil" = "Banned until %s"
+"addPreferredPeer" = "Add preferred peer"
+"peerStats" = "Height %d · Latency %s · Sent %s · Received %s"
+"peerScore" = "Score %d · %s"
+"peerHealthy" = "Healthy"
+"peerUnhealthy" = "Unhealthy"
+"migratingWallet" = "Migrating %s (%d%%)"
+"migrationFailed" = "Migration failed: %v"
+"diagnose" = "Diagnose"
+"walletDiagnosis" = "Diagnosis of %s"
+"noWalletProblems" = "No problems found"
+"issueLoadFailed" = "Loading the wallet failed"
+"issueUnknownAsset" = "Unknown asset type"
+"issueWalletDbMissing" = "Wallet database missing"
+"issueWalletDbCorrupt" = "Wallet database corrupt"
+"issueMissingBuckets" = "Wallet database incomplete"
+"issueWalletDataCorrupt" = "Transaction index corrupt"
+"issueStaleTxIndex" = "Outdated transaction index"
+"issueOrphanedConfig" = "Settings of deleted wallets"
+"repairReindex" = "Rebuild transaction index"
+"repairReindexMsg" = "The transaction index of the wallet will be rebuilt on the next sync."
+"repairDropFilterCache" = "Drop filter cache"
+"repairDropFilterCacheMsg" = "The block headers and filters of the wallet will be downloaded again on the next sync."
+"repairRestoreFromSeed" = "Restore from seed"
+"repairRemoveOrphanedConfig" = "Remove settings of deleted wallets"
+"repairRemoveOrphanedConfigMsg" = "The settings of the deleted wallets will be removed."
+"repair" = "Repair"
+"walletRepaired" = "Wallet repaired"
+"close" = "Close"
+"multisigWallets" = "Multisig wallets"
+"createMultisigWallet" = "Create multisig wallet"
+"importMultisigWallet" = "Import multisig wallet"
+"noMultisigWallets" = "No multisig wallets"
+"multisigSummary" = "%d of %d · %s"
+"myCosignerKey" = "My cosigner key"
+"cosignerKeyInfo" = "Share this key with the other cosigners to add the wallet to a multisig wallet."
+"requiredSignatures" = "Required signatures"
+"cosignerKeys" = "Cosigner keys, one per line"
+"cosignerKeysInfo" = "The key of the signing wallet is added if missing."
+"signingWallet" = "Signing wallet"
+"noSigningWallet" = "None (watch-only)"
+"multisigConfig" = "Multisig config or output descriptor"
+"multisigConfigInfo" = "Share the config with the other cosigners to import the wallet."
+"createPSBT" = "Create PSBT"
+"signPSBT" = "Sign PSBT"
+"broadcastPSBT" = "Combine and broadcast"
+"psbt" = "PSBT"
+"signedPSBTs" = "Signed PSBTs, one per line"
+"psbtInfo" = "Share the PSBT with the cosigners, each signs it and sends it back."
+"feeRateSatVB" = "Fee rate (sat/vB)"
+"needMoreSignatures" = "The PSBT needs more signatures. Share the combined PSBT with the other cosigners."
+"txBroadcast" = "Transaction %s broadcast"
+"removeMultisigWalletMsg" = "Remove the multisig wallet %s? Its funds stay spendable by the cosigners, import its config to watch it again."
+"multisigCosigner" = "Cosigner %d"
+"noSigningWallets" = "Add a BTC or LTC wallet holding its seed to sign the multisig wallet."
+"multisigAccounts" = "Multisig accounts"
+"createMultisigAccount" = "Create multisig account"
+"noMultisigAccounts" = "No multisig accounts yet"
+"multisigAccountKeysInfo" = "Paste the public keys of the cosigners, one per line. The key of this wallet is added if it is missing. Funds received before the account is created are found by a rescan."
+"multisigAccountKeyInfo" = "Share this public key with the other cosigners to add the wallet to a multisig account."
+"createTxBundle" = "Create transaction"
+"signTxBundle" = "Sign transaction"
+"publishTxBundle" = "Combine and publish"
+"txBundle" = "Transaction bundle"
+"txBundleInfo" = "Share the transaction bundle with the cosigners, each signs it and sends it back."
+"signedTxBundles" = "Signed transaction bundles, one per line"
+"txBundleNeedsSignatures" = "The transaction needs more signatures. Share the combined bundle with the other cosigners."
+"removeMultisigAccountMsg" = "Remove the multisig account %s? Its funds stay spendable by the cosigners, create it again from its keys to spend them."
+"hardwareSigner" = "Hardware signer"
+"useTrezor" = "Sign with a Trezor"
+"trezorInfo" = "Transactions of this watch-only wallet are signed on the Trezor its extended public key was exported from. The device is reached through the Trezor Bridge."
+"useTrezorEmulator" = "Sign with the Trezor emulator"
+"trezorEmulatorInfo" = "Transactions are signed on a Trezor emulator reached over UDP, for testing."
+"trezorBridgeAddress" = "Bridge address (default %s)"
+"trezorEmulatorAddress" = "Emulator address (default %s)"
+"trezorConnected" = "Connected to Trezor %s"
+"devicePassphrase" = "Device passphrase (optional)"
+"confirmOnDevice" = "Confirm the transaction on your device"
+"signOffline" = "Sign offline"
+"unsignedTx" = "Unsigned transaction"
+"unsignedTxInfo" = "Scan the animated QR code, or carry the saved file, to the offline wallet holding the seed of this wallet and sign the transaction there. Then import the signed transaction."
+"signedTx" = "Signed transaction"
+"signedTxInfo" = "Scan the animated QR code, or carry the saved file, back to the watch-only wallet to publish the transaction."
+"importSignedTx" = "Import signed transaction"
+"importAirgapTxInfo" = "Enter the path of the file the transaction was saved to, or paste the UR parts of the transaction. Scanning them with a camera is not supported."
+"urParts" = "UR parts"
+"filePath" = "File path"
+"saveToFile" = "Save to file"
+"savedTo" = "Saved to %s"
+"signOfflineTx" = "Sign offline transaction"
+"txAlreadySigned" = "The transaction is already signed"
+"txAuthorBusy" = "Another transaction is being created from this wallet, try again shortly"
+"resumeSchedules" = "Resume swap schedules"
+"resumeSchedulesMsg" = "These schedules were running when the app was closed. Enter the spending passphrase of their wallets to resume them from their next due run. The passphrases are kept in memory while the schedules run. Skipped schedules can be resumed from the schedules page."
+"useBitcoind" = "Bitcoin Core or Litecoin Core node"
+"bitcoindInfo" = "The wallet polls the node for new blocks and transactions. The node does not serve TLS, so it must run on this computer."
+"rpcPasswordRequired" = "Enter the password of the full node to sync this wallet."
+"rpcPasswords" = "Full node passwords"
+"rpcPasswordsMsg" = "The full node passwords are not saved. Enter them to sync these wallets with their nodes."
+"airgapSigner" = "Signed by an offline wallet"
+"accountRescanUnsupported" = "DCR wallets discover the addresses of all their accounts, a rescan can not be limited to some accounts"
+"multisigElectrumInfo" = "Multisig wallets read their balance and broadcast through an Electrum server. The SPV and full node connections of your wallets are not used, set a server for each multisig wallet."
+"setElectrumServer" = "Set Electrum server"
 `
@@ -527,4 +527,19 @@ syncCompTime" = "Est. sync completion time"
 "mixedAccDisabled" = "La recepción en una cuenta mixta está deshabilitada por la configuración de StakeShuffle para proteger su privacidad"
 "totalValue" = "Total Value" //TODO
 "chinese" = "Chinese"
+"dateLayout" = "02/01/2006"
+"timeAgoSeconds.one" = "hace %d segundo"
+"timeAgoSeconds.other" = "hace %d segundos"
+"timeAgoMinutes.one" = "hace %d minuto"
+"timeAgoMinutes.other" = "hace %d minutos"
+"timeAgoHours.one" = "hace %d hora"
+"timeAgoHours.other" = "hace %d horas"
+"timeAgoDays.one" = "hace %d día"
+"timeAgoDays.other" = "hace %d días"
+"timeAgoWeeks.one" = "hace %d semana"
+"timeAgoWeeks.other" = "hace %d semanas"
+"timeAgoMonths.one" = "hace %d mes"
+"timeAgoMonths.other" = "hace %d meses"
+"timeAgoYears.one" = "hace %d año"
+"timeAgoYears.other" = "hace %d años"
 `
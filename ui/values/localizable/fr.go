@@ -504,4 +504,19 @@ const FR = `
 "mixedAccDisabled" = "La réception sur un compte mixte est désactivée par les paramètres StakeShuffle pour protéger votre vie privée"
 "totalValue" = "Total Value" //TODO
 "chinese" = "Chinese"
+"dateLayout" = "02/01/2006"
+"timeAgoSeconds.one" = "il y a %d seconde"
+"timeAgoSeconds.other" = "il y a %d secondes"
+"timeAgoMinutes.one" = "il y a %d minute"
+"timeAgoMinutes.other" = "il y a %d minutes"
+"timeAgoHours.one" = "il y a %d heure"
+"timeAgoHours.other" = "il y a %d heures"
+"timeAgoDays.one" = "il y a %d jour"
+"timeAgoDays.other" = "il y a %d jours"
+"timeAgoWeeks.one" = "il y a %d semaine"
+"timeAgoWeeks.other" = "il y a %d semaines"
+"timeAgoMonths.one" = "il y a %d mois"
+"timeAgoMonths.other" = "il y a %d mois"
+"timeAgoYears.one" = "il y a %d an"
+"timeAgoYears.other" = "il y a %d ans"
 `
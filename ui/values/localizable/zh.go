@@ -905,4 +905,12 @@ const ZH = `
 "unexpectedErrorMsgFmt" = "发生了意外的错误：%s"
 "unexpectedError" = "意外错误"
 "chinese" = "Chinese"
+"dateLayout" = "2006年1月2日"
+"timeAgoSeconds.other" = "%d秒前"
+"timeAgoMinutes.other" = "%d分钟前"
+"timeAgoHours.other" = "%d小时前"
+"timeAgoDays.other" = "%d天前"
+"timeAgoWeeks.other" = "%d周前"
+"timeAgoMonths.other" = "%d个月前"
+"timeAgoYears.other" = "%d年前"
 `
@@ -23,45 +23,35 @@ var (
 var languageStrings map[string]map[string]string
 
 func init() {
-	readIntoMap := func(m map[string]string, localizableStrings string) {
-		scanner := bufio.NewScanner(strings.NewReader(localizableStrings))
-		for scanner.Scan() {
-			line := scanner.Text()
-			line = strings.TrimSpace(line)
-			if line == "" || strings.HasPrefix(line, commentPrefix) {
-				continue
-			}
-
-			matches := rex.FindAllStringSubmatch(line, -1)
-			if len(matches) == 0 {
-				continue
-			}
-
-			kv := matches[0]
-			key := trimQuotes(kv[1])
-			value := trimQuotes(kv[2])
-
-			m[key] = value
-		}
-	}
-
-	en := make(map[string]string)
-	zh := make(map[string]string)
-	fr := make(map[string]string)
-	es := make(map[string]string)
 	languageStrings = make(map[string]map[string]string)
+	languageStrings[localizable.ENGLISH] = readStrings(make(map[string]string), localizable.EN)
+	languageStrings[localizable.CHINESE] = readStrings(make(map[string]string), localizable.ZH)
+	languageStrings[localizable.FRENCH] = readStrings(make(map[string]string), localizable.FR)
+	languageStrings[localizable.SPANISH] = readStrings(make(map[string]string), localizable.ES)
+}
 
-	readIntoMap(en, localizable.EN)
-	languageStrings[localizable.ENGLISH] = en
+// readStrings reads the "key" = "value" lines of localizableStrings into m.
+func readStrings(m map[string]string, localizableStrings string) map[string]string {
+	scanner := bufio.NewScanner(strings.NewReader(localizableStrings))
+	for scanner.Scan() {
+		line := scanner.Text()
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, commentPrefix) {
+			continue
+		}
 
-	readIntoMap(zh, localizable.ZH)
-	languageStrings[localizable.CHINESE] = zh
+		matches := rex.FindAllStringSubmatch(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
 
-	readIntoMap(fr, localizable.FR)
-	languageStrings[localizable.FRENCH] = fr
+		kv := matches[0]
+		key := trimQuotes(kv[1])
+		value := trimQuotes(kv[2])
 
-	readIntoMap(es, localizable.ES)
-	languageStrings[localizable.SPANISH] = es
+		m[key] = value
+	}
+	return m
 }
 
 func hasLanguage(language string) bool {
@@ -74,14 +64,11 @@ func hasLanguage(language string) bool {
 	return false
 }
 
+// SetUserLanguage sets the display language. Strings missing from lang are
+// looked up in the languages of its fallback chain.
 func SetUserLanguage(lang string) {
 	if hasLanguage(lang) {
-		languages := []string{lang}
-		if lang != DefaultLanguage {
-			languages = append(languages, DefaultLanguage)
-		}
-
-		UserLanguages = languages
+		UserLanguages = FallbackChain(lang)
 	}
 }
 
@@ -115,6 +102,18 @@ func StringF(key string, a ...interface{}) string {
 	return fmt.Sprintf(str, a...)
 }
 
+// PluralF formats the plural form of key matching the CLDR plural category of
+// n, e.g. "key.one" or "key.other", falling back to "key". The string is
+// formatted with n followed by a.
+func PluralF(key string, n int, a ...interface{}) string {
+	str := pluralString(key, n)
+	if str == "" {
+		return str
+	}
+
+	return fmt.Sprintf(str, append([]interface{}{n}, a...)...)
+}
+
 const (
 	StrAbandoned                             = "abandoned"
 	StrAbout                                 = "about"
@@ -1089,4 +1088,357 @@ const (
 	StrLowStorageSpaceBody                   = "lowStorageSpaceBody"
 	StrWalletsCreationLimitTitle             = "walletCreationLimitTitle"
 	StrWalletsCreationLimitBody              = "walletCreationLimitBody"
+	StrCandles                               = "candles"
+	StrDepth                                 = "depth"
+	StrNoChartData                           = "noChartData"
+	StrManageBonds                           = "manageBonds"
+	StrBonds                                 = "bonds"
+	StrTargetTier                            = "targetTier"
+	StrBondedTiers                           = "bondedTiers"
+	StrPendingTiers                          = "pendingTiers"
+	StrExpiringTiers                         = "expiringTiers"
+	StrTierDropWarning                       = "tierDropWarning"
+	StrBondAutoRenew                         = "bondAutoRenew"
+	StrBondAutoRenewDesc                     = "bondAutoRenewDesc"
+	StrMaxBondedAmount                       = "maxBondedAmount"
+	StrMaxBondedAmountHint                   = "maxBondedAmountHint"
+	StrBondOptionsUpdated                    = "bondOptionsUpdated"
+	StrTargetTierErrMsg                      = "targetTierErrMsg"
+	StrNoBonds                               = "noBonds"
+	StrBondExpires                           = "bondExpires"
+	StrBondRefundable                        = "bondRefundable"
+	StrRefundTx                              = "refundTx"
+	StrBondActive                            = "bondActive"
+	StrBondRefunded                          = "bondRefunded"
+	StrLoginDEXToViewBonds                   = "loginDEXToViewBonds"
+	StrDEXBond                               = "dexBond"
+	StrDEXBondPosted                         = "dexBondPosted"
+	StrDEXBondRefund                         = "dexBondRefund"
+	StrAccountTier                           = "accountTier"
+	StrCanceled                              = "canceled"
+	StrDEXOrder                              = "dexOrder"
+	StrAvgPrice                              = "avgPrice"
+	StrTrades                                = "trades"
+	StrVolume                                = "volume"
+	StrTotalFees                             = "totalFees"
+	StrMarkToMarketPL                        = "markToMarketPL"
+	StrExportCSV                             = "exportCSV"
+	StrExportJSON                            = "exportJSON"
+	StrExportTrades                          = "exportTrades"
+	StrExportTradesMsg                       = "exportTradesMsg"
+	StrExportTradesSuccessMsg                = "exportTradesSuccessMsg"
+	StrDateHint                              = "dateHint"
+	StrInvalidDate                           = "invalidDate"
+	StrNoTradesFound                         = "noTradesFound"
+	StrUnpricedTrades                        = "unpricedTrades"
+	StrOrderFilter                           = "orderFilter"
+	StrFiatValuesAtCurrentRates              = "fiatValuesAtCurrentRates"
+	StrMarketMaker                           = "marketMaker"
+	StrMarketMakerDesc                       = "marketMakerDesc"
+	StrPriceSource                           = "priceSource"
+	StrPriceOracle                           = "priceOracle"
+	StrMidGap                                = "midGap"
+	StrSpreadPercent                         = "spreadPercent"
+	StrLevelsPerSide                         = "levelsPerSide"
+	StrLotsPerLevel                          = "lotsPerLevel"
+	StrMaxInventory                          = "maxInventory"
+	StrRebalanceMinutes                      = "rebalanceMinutes"
+	StrBotSettingsSaved                      = "botSettingsSaved"
+	StrStop                                  = "stop"
+	StrBotRunning                            = "botRunning"
+	StrBotStopped                            = "botStopped"
+	StrReferencePrice                        = "referencePrice"
+	StrNetInventory                          = "netInventory"
+	StrActivity                              = "activity"
+	StrNoBotEvents                           = "noBotEvents"
+	StrStartBotMsg                           = "startBotMsg"
+	StrInvalidNumber                         = "invalidNumber"
+	StrBotEventStarted                       = "botEventStarted"
+	StrBotEventStopped                       = "botEventStopped"
+	StrBotEventOrderPlaced                   = "botEventOrderPlaced"
+	StrBotEventOrderCanceled                 = "botEventOrderCanceled"
+	StrBotEventOrderFilled                   = "botEventOrderFilled"
+	StrBotEventOrderDone                     = "botEventOrderDone"
+	StrBotEventError                         = "botEventError"
+	StrBotEventPaused                        = "botEventPaused"
+	StrBotEventResumed                       = "botEventResumed"
+	StrBotPaused                             = "botPaused"
+	StrCompareRates                          = "compareRates"
+	StrCompareRatesDesc                      = "compareRatesDesc"
+	StrYouReceive                            = "youReceive"
+	StrLimits                                = "limits"
+	StrVsMarket                              = "vsMarket"
+	StrOutsideLimits                         = "outsideLimits"
+	StrRateDeviates                          = "rateDeviates"
+	StrNoLimit                               = "noLimit"
+	StrFetchingQuotes                        = "fetchingQuotes"
+	StrBestRate                              = "bestRate"
+	StrScheduleName                          = "scheduleName"
+	StrFixedAmount                           = "fixedAmount"
+	StrPercentOfBalance                      = "percentOfBalance"
+	StrAboveBalance                          = "aboveBalance"
+	StrAmountToSwap                          = "amountToSwap"
+	StrPercentToSwap                         = "percentToSwap"
+	StrMaxOrdersOptional                     = "maxOrdersOptional"
+	StrMaxTotalOptional                      = "maxTotalOptional"
+	StrEndDateOptional                       = "endDateOptional"
+	StrSchedules                             = "schedules"
+	StrSchedulesRunning                      = "schedulesRunning"
+	StrNoSchedules                           = "noSchedules"
+	StrNextRun                               = "nextRun"
+	StrOrdersCreated                         = "ordersCreated"
+	StrTotalSwapped                          = "totalSwapped"
+	StrResume                                = "resume"
+	StrPause                                 = "pause"
+	StrScheduleActive                        = "scheduleActive"
+	StrSchedulePaused                        = "schedulePaused"
+	StrScheduleEnded                         = "scheduleEnded"
+	StrWaitingForPassphrase                  = "waitingForPassphrase"
+	StrExecutionLog                          = "executionLog"
+	StrNoExecutions                          = "noExecutions"
+	StrResumeScheduleMsg                     = "resumeScheduleMsg"
+	StrDeleteScheduleMsg                     = "deleteScheduleMsg"
+	StrScheduleExists                        = "scheduleExists"
+	StrOrderPayoutFlagged                    = "orderPayoutFlagged"
+	StrOnChainVerification                   = "onChainVerification"
+	StrAwaitingPayout                        = "awaitingPayout"
+	StrPayoutReceived                        = "payoutReceived"
+	StrPayoutShort                           = "payoutShort"
+	StrPayoutLate                            = "payoutLate"
+	StrPayoutMissing                         = "payoutMissing"
+	StrDepositRefunded                       = "depositRefunded"
+	StrDepositTx                             = "depositTx"
+	StrPayoutTx                              = "payoutTx"
+	StrAggregateRates                        = "aggregateRates"
+	StrRateSources                           = "rateSources"
+	StrAddRateSource                         = "addRateSource"
+	StrURLTemplate                           = "urlTemplate"
+	StrPricePath                             = "pricePath"
+	StrVolumePath                            = "volumePath"
+	StrSourceHealthy                         = "sourceHealthy"
+	StrSourceNotUsed                         = "sourceNotUsed"
+	StrLastSuccess                           = "lastSuccess"
+	StrLastError                             = "lastError"
+	StrCustomSource                          = "customSource"
+	StrRemoveRateSourceMsg                   = "removeRateSourceMsg"
+	StrConsecutiveFailures                   = "consecutiveFailures"
+	StrDisplayCurrency                       = "displayCurrency"
+	StrPortfolioHistory                      = "portfolioHistory"
+	StrDateLayout                            = "dateLayout"
+	StrTimeAgoSeconds                        = "timeAgoSeconds"
+	StrTimeAgoMinutes                        = "timeAgoMinutes"
+	StrTimeAgoHours                          = "timeAgoHours"
+	StrTimeAgoDays                           = "timeAgoDays"
+	StrTimeAgoWeeks                          = "timeAgoWeeks"
+	StrTimeAgoMonths                         = "timeAgoMonths"
+	StrTimeAgoYears                          = "timeAgoYears"
+	StrTranslations                          = "translations"
+	StrMissingKeys                           = "missingKeys"
+	StrAllKeysTranslated                     = "allKeysTranslated"
+	StrTxReceivedNotif                       = "txReceivedNotif"
+	StrTxConfirmedNotif                      = "txConfirmedNotif"
+	StrDEXMatchNotif                         = "dexMatchNotif"
+	StrSwapCompletedNotif                    = "swapCompletedNotif"
+	StrMarkAllRead                           = "markAllRead"
+	StrNoNotifications                       = "noNotifications"
+	StrNotificationRules                     = "notificationRules"
+	StrAddRule                               = "addRule"
+	StrAllEvents                             = "allEvents"
+	StrMuteNotifications                     = "muteNotifications"
+	StrMinAmount                             = "minAmount"
+	StrQuietFrom                             = "quietFrom"
+	StrQuietUntil                            = "quietUntil"
+	StrQuietHours                            = "quietHours"
+	StrInvalidTime                           = "invalidTime"
+	StrNoRules                               = "noRules"
+	StrEventTxReceived                       = "eventTxReceived"
+	StrEventTxConfirmed                      = "eventTxConfirmed"
+	StrEventTicketVoted                      = "eventTicketVoted"
+	StrEventTicketRevoked                    = "eventTicketRevoked"
+	StrEventProposalUpdate                   = "eventProposalUpdate"
+	StrEventDEXMatch                         = "eventDEXMatch"
+	StrEventSwapCompleted                    = "eventSwapCompleted"
+	StrHooks                                 = "hooks"
+	StrAddHook                               = "addHook"
+	StrNoHooks                               = "noHooks"
+	StrHookURL                               = "hookURL"
+	StrHookCommand                           = "hookCommand"
+	StrHookURLOrCommand                      = "hookURLOrCommand"
+	StrHookConfirmationsHint                 = "hookConfirmationsHint"
+	StrEventTransaction                      = "eventTransaction"
+	StrEventSwapStatus                       = "eventSwapStatus"
+	StrDeliveries                            = "deliveries"
+	StrNoDeliveries                          = "noDeliveries"
+	StrSigningSecret                         = "signingSecret"
+	StrAttempts                              = "attempts"
+	StrRemoveHookMsg                         = "removeHookMsg"
+	StrDeliveryPending                       = "deliveryPending"
+	StrDeliveryDelivered                     = "deliveryDelivered"
+	StrDeliveryFailed                        = "deliveryFailed"
+	StrPaymentRequests                       = "paymentRequests"
+	StrNewPaymentRequest                     = "newPaymentRequest"
+	StrNoPaymentRequests                     = "noPaymentRequests"
+	StrMemo                                  = "memo"
+	StrAmountOptional                        = "amountOptional"
+	StrExpiry                                = "expiry"
+	StrNever                                 = "never"
+	StrOneHour                               = "oneHour"
+	StrOneDay                                = "oneDay"
+	StrOneWeek                               = "oneWeek"
+	StrOpenRequests                          = "openRequests"
+	StrSettledRequests                       = "settledRequests"
+	StrRequestOpen                           = "requestOpen"
+	StrRequestUnderpaid                      = "requestUnderpaid"
+	StrRequestPaid                           = "requestPaid"
+	StrRequestOverpaid                       = "requestOverpaid"
+	StrRequestExpired                        = "requestExpired"
+	StrReceivedOf                            = "receivedOf"
+	StrAnyAmount                             = "anyAmount"
+	StrExpiresAt                             = "expiresAt"
+	StrShowQR                                = "showQR"
+	StrRemovePaymentRequestMsg               = "removePaymentRequestMsg"
+	StrBatchSend                             = "batchSend"
+	StrBatchSendHint                         = "batchSendHint"
+	StrCSVFilePath                           = "csvFilePath"
+	StrPaymentsCSV                           = "paymentsCSV"
+	StrBatchLabel                            = "batchLabel"
+	StrReview                                = "review"
+	StrLineError                             = "lineError"
+	StrMissingAddressOrAmount                = "missingAddressOrAmount"
+	StrDuplicateOfLine                       = "duplicateOfLine"
+	StrValidPayments                         = "validPayments"
+	StrBatchPlanRow                          = "batchPlanRow"
+	StrBatchTotal                            = "batchTotal"
+	StrBatchSent                             = "batchSent"
+	StrNetworkMode                           = "networkMode"
+	StrSPV                                   = "spv"
+	StrFullNodeAt                            = "fullNodeAt"
+	StrUseFullNode                           = "useFullNode"
+	StrFullNodeInfo                          = "fullNodeInfo"
+	StrRPCAddress                            = "rpcAddress"
+	StrRPCUser                               = "rpcUser"
+	StrRPCPassword                           = "rpcPassword"
+	StrRPCCertPath                           = "rpcCertPath"
+	StrRPCCertKept                           = "rpcCertKept"
+	StrElectrumServerAt                      = "electrumServerAt"
+	StrUseElectrumServer                     = "useElectrumServer"
+	StrElectrumServerInfo                    = "electrumServerInfo"
+	StrElectrumAddress                       = "electrumAddress"
+	StrRescanFromDate                        = "rescanFromDate"
+	StrInvalidRescanDate                     = "invalidRescanDate"
+	StrManagePeers                           = "managePeers"
+	StrConnectedPeers                        = "connectedPeers"
+	StrBannedPeers                           = "bannedPeers"
+	StrPreferredPeers                        = "preferredPeers"
+	StrNoConnectedPeers                      = "noConnectedPeers"
+	StrNoBannedPeers                         = "noBannedPeers"
+	StrNoPreferredPeers                      = "noPreferredPeers"
+	StrBan                                   = "ban"
+	StrUnban                                 = "unban"
+	StrBanPeer                               = "banPeer"
+	StrBanPeerMsg                            = "banPeerMsg"
+	StrBanDurationHours                      = "banDurationHours"
+	StrBannedUntil                           = "bannedUntil"
+	StrAddPreferredPeer                      = "addPreferredPeer"
+	StrPeerStats                             = "peerStats"
+	StrPeerScore                             = "peerScore"
+	StrPeerHealthy                           = "peerHealthy"
+	StrPeerUnhealthy                         = "peerUnhealthy"
+	StrMigratingWallet                       = "migratingWallet"
+	StrMigrationFailed                       = "migrationFailed"
+	StrDiagnose                              = "diagnose"
+	StrWalletDiagnosis                       = "walletDiagnosis"
+	StrNoWalletProblems                      = "noWalletProblems"
+	StrIssueLoadFailed                       = "issueLoadFailed"
+	StrIssueUnknownAsset                     = "issueUnknownAsset"
+	StrIssueWalletDbMissing                  = "issueWalletDbMissing"
+	StrIssueWalletDbCorrupt                  = "issueWalletDbCorrupt"
+	StrIssueMissingBuckets                   = "issueMissingBuckets"
+	StrIssueWalletDataCorrupt                = "issueWalletDataCorrupt"
+	StrIssueStaleTxIndex                     = "issueStaleTxIndex"
+	StrIssueOrphanedConfig                   = "issueOrphanedConfig"
+	StrRepairReindex                         = "repairReindex"
+	StrRepairReindexMsg                      = "repairReindexMsg"
+	StrRepairDropFilterCache                 = "repairDropFilterCache"
+	StrRepairDropFilterCacheMsg              = "repairDropFilterCacheMsg"
+	StrRepairRestoreFromSeed                 = "repairRestoreFromSeed"
+	StrRepairRemoveOrphanedConfig            = "repairRemoveOrphanedConfig"
+	StrRepairRemoveOrphanedConfigMsg         = "repairRemoveOrphanedConfigMsg"
+	StrRepair                                = "repair"
+	StrWalletRepaired                        = "walletRepaired"
+	StrClose                                 = "close"
+	StrMultisigWallets                       = "multisigWallets"
+	StrCreateMultisigWallet                  = "createMultisigWallet"
+	StrImportMultisigWallet                  = "importMultisigWallet"
+	StrNoMultisigWallets                     = "noMultisigWallets"
+	StrMultisigSummary                       = "multisigSummary"
+	StrMyCosignerKey                         = "myCosignerKey"
+	StrCosignerKeyInfo                       = "cosignerKeyInfo"
+	StrRequiredSignatures                    = "requiredSignatures"
+	StrCosignerKeys                          = "cosignerKeys"
+	StrCosignerKeysInfo                      = "cosignerKeysInfo"
+	StrSigningWallet                         = "signingWallet"
+	StrNoSigningWallet                       = "noSigningWallet"
+	StrMultisigConfig                        = "multisigConfig"
+	StrMultisigConfigInfo                    = "multisigConfigInfo"
+	StrCreatePSBT                            = "createPSBT"
+	StrSignPSBT                              = "signPSBT"
+	StrBroadcastPSBT                         = "broadcastPSBT"
+	StrPSBT                                  = "psbt"
+	StrSignedPSBTs                           = "signedPSBTs"
+	StrPSBTInfo                              = "psbtInfo"
+	StrFeeRateSatVB                          = "feeRateSatVB"
+	StrNeedMoreSignatures                    = "needMoreSignatures"
+	StrTxBroadcast                           = "txBroadcast"
+	StrRemoveMultisigWalletMsg               = "removeMultisigWalletMsg"
+	StrMultisigCosigner                      = "multisigCosigner"
+	StrNoSigningWallets                      = "noSigningWallets"
+	StrMultisigAccounts                      = "multisigAccounts"
+	StrCreateMultisigAccount                 = "createMultisigAccount"
+	StrNoMultisigAccounts                    = "noMultisigAccounts"
+	StrMultisigAccountKeysInfo               = "multisigAccountKeysInfo"
+	StrMultisigAccountKeyInfo                = "multisigAccountKeyInfo"
+	StrCreateTxBundle                        = "createTxBundle"
+	StrSignTxBundle                          = "signTxBundle"
+	StrPublishTxBundle                       = "publishTxBundle"
+	StrTxBundle                              = "txBundle"
+	StrTxBundleInfo                          = "txBundleInfo"
+	StrSignedTxBundles                       = "signedTxBundles"
+	StrTxBundleNeedsSignatures               = "txBundleNeedsSignatures"
+	StrRemoveMultisigAccountMsg              = "removeMultisigAccountMsg"
+	StrHardwareSigner                        = "hardwareSigner"
+	StrUseTrezor                             = "useTrezor"
+	StrTrezorInfo                            = "trezorInfo"
+	StrUseTrezorEmulator                     = "useTrezorEmulator"
+	StrTrezorEmulatorInfo                    = "trezorEmulatorInfo"
+	StrTrezorBridgeAddress                   = "trezorBridgeAddress"
+	StrTrezorEmulatorAddress                 = "trezorEmulatorAddress"
+	StrTrezorConnected                       = "trezorConnected"
+	StrDevicePassphrase                      = "devicePassphrase"
+	StrConfirmOnDevice                       = "confirmOnDevice"
+	StrSignOffline                           = "signOffline"
+	StrUnsignedTx                            = "unsignedTx"
+	StrUnsignedTxInfo                        = "unsignedTxInfo"
+	StrSignedTx                              = "signedTx"
+	StrSignedTxInfo                          = "signedTxInfo"
+	StrImportSignedTx                        = "importSignedTx"
+	StrImportAirgapTxInfo                    = "importAirgapTxInfo"
+	StrURParts                               = "urParts"
+	StrFilePath                              = "filePath"
+	StrSaveToFile                            = "saveToFile"
+	StrSavedTo                               = "savedTo"
+	StrSignOfflineTx                         = "signOfflineTx"
+	StrTxAlreadySigned                       = "txAlreadySigned"
+	StrTxAuthorBusy                          = "txAuthorBusy"
+	StrResumeSchedules                       = "resumeSchedules"
+	StrResumeSchedulesMsg                    = "resumeSchedulesMsg"
+	StrUseBitcoind                           = "useBitcoind"
+	StrBitcoindInfo                          = "bitcoindInfo"
+	StrRPCPasswordRequired                   = "rpcPasswordRequired"
+	StrRPCPasswords                          = "rpcPasswords"
+	StrRPCPasswordsMsg                       = "rpcPasswordsMsg"
+	StrAirgapSigner                          = "airgapSigner"
+	StrAccountRescanUnsupported              = "accountRescanUnsupported"
+	StrMultisigElectrumInfo                  = "multisigElectrumInfo"
+	StrSetElectrumServer                     = "setElectrumServer"
 )
@@ -15,7 +15,6 @@ import (
 	"gioui.org/io/pointer"
 	"gioui.org/layout"
 	"gioui.org/op"
-	"golang.org/x/text/language"
 	"golang.org/x/text/message"
 
 	"github.com/crypto-power/cryptopower/app"
@@ -130,7 +129,7 @@ func (win *Window) NewLoad(appInfo *load.AppInfo, window *giouiApp.Window) (*loa
 	// very essential to have a toast UI component implementation otherwise
 	// restraints should be exercised when planning to reuse it else where.
 	l.Toast = notification.NewToast(th)
-	l.Printer = message.NewPrinter(language.English)
+	l.Printer = message.NewPrinter(values.LanguageTag())
 
 	appInfo.AssetsManager.SetToast(l.Toast)
 